@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/litescript/ls-horizons/internal/focussync"
+	"github.com/litescript/ls-horizons/internal/sandbox"
+	"github.com/litescript/ls-horizons/internal/ui"
+)
+
+// openAnnouncer opens the --announce side channel for focus changes:
+// "osc" for in-band terminal sequences, "fd:N" for a descriptor inherited
+// from the parent (e.g. 3>/tmp/focus), or a file or named pipe path. The
+// returned file, if any, is closed by the caller.
+func openAnnouncer(spec string) (ui.Announcer, *os.File, error) {
+	switch {
+	case spec == "osc":
+		return ui.NewOSCAnnouncer(os.Stdout), nil, nil
+
+	case strings.HasPrefix(spec, "fd:"):
+		fd, err := strconv.Atoi(strings.TrimPrefix(spec, "fd:"))
+		if err != nil || fd < 3 {
+			return nil, nil, fmt.Errorf("--announce %s: want fd:N with N >= 3", spec)
+		}
+		f := os.NewFile(uintptr(fd), "announce")
+		if _, err := f.Stat(); err != nil {
+			return nil, nil, fmt.Errorf("--announce %s: descriptor not open", spec)
+		}
+		return ui.NewLineAnnouncer(f), f, nil
+
+	default:
+		if err := sandbox.CheckWrite(spec); err != nil {
+			return nil, nil, err
+		}
+		f, err := os.OpenFile(spec, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+		if err != nil {
+			return nil, nil, fmt.Errorf("--announce: %w", err)
+		}
+		return ui.NewLineAnnouncer(f), f, nil
+	}
+}
+
+// followRemoteFocus passes spacecraft focused in other --sync instances to
+// the TUI until ctx is cancelled.
+func followRemoteFocus(ctx context.Context, bus *focussync.Bus, p *tea.Program) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case code := <-bus.Focus():
+			p.Send(ui.RemoteFocusMsg{Code: code})
+		}
+	}
+}
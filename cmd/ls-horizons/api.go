@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+
+	"github.com/litescript/ls-horizons/internal/api"
+	"github.com/litescript/ls-horizons/internal/ephem"
+	"github.com/litescript/ls-horizons/internal/logging"
+	"github.com/litescript/ls-horizons/internal/serve"
+	"github.com/litescript/ls-horizons/internal/state"
+)
+
+// startAPI serves the JSON API for the latest state on cfg.Addr until ctx
+// is cancelled. cfg must already be loaded. Pass plans use radec (Horizons
+// or the demo ephemeris) unless --ephem dsn was given.
+func startAPI(ctx context.Context, cfg serve.Config, stateMgr *state.Manager, radec ephem.RADecProvider, logger *logging.Logger) {
+	var paths api.PathSource
+	if ephem.ParseMode(ephemMode) != ephem.ModeDSN {
+		paths = radec
+	}
+	// Without auth, only pages served by this API may use /stream and /graphql
+	handler := api.New(stateMgr, paths, api.Options{AllowCrossOrigin: cfg.AuthEnabled()})
+
+	scheme := "http"
+	if cfg.TLSEnabled() {
+		scheme = "https"
+	}
+
+	go func() {
+		logger.Info("API listening on %s://%s", scheme, cfg.Addr)
+		if err := serve.ListenAndServe(ctx, cfg, handler); err != nil {
+			logger.Error("API server: %v", err)
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		handler.Close()
+	}()
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/backup"
+	"github.com/litescript/ls-horizons/internal/bookmarks"
+	"github.com/litescript/ls-horizons/internal/eventlog"
+	"github.com/litescript/ls-horizons/internal/notes"
+	"github.com/litescript/ls-horizons/internal/sandbox"
+	"github.com/litescript/ls-horizons/internal/sightings"
+)
+
+// profilePrefix is the bundle directory holding named profiles.
+const profilePrefix = "profiles/"
+
+// userData locates the files a bundle carries. The flags default to the
+// same paths as the dashboard's, so only relocated files need them.
+type userData struct {
+	config    string
+	notes     string
+	bookmarks string
+	sightings string
+	events    string
+}
+
+func userDataFlags(fs *flag.FlagSet) *userData {
+	var d userData
+	fs.StringVar(&d.config, "config", defaultConfigPath(), "Config file; named profiles go in profiles/ beside it")
+	fs.StringVar(&d.notes, "notes-file", notes.DefaultPath(), "Spacecraft notes journal")
+	fs.StringVar(&d.bookmarks, "bookmarks-file", bookmarks.DefaultPath(), "Bookmarks file")
+	fs.StringVar(&d.sightings, "sightings-file", sightings.DefaultPath(), "Sighting log (last tracked times and learned rate baselines)")
+	fs.StringVar(&d.events, "event-log", eventlog.DefaultPath(), "Persistent event log")
+	return &d
+}
+
+// fixed returns the files with fixed bundle names, by name.
+func (d *userData) fixed() map[string]string {
+	return map[string]string{
+		"config.toml":     d.config,
+		"notes.jsonl":     d.notes,
+		"bookmarks.jsonl": d.bookmarks,
+		"sightings.json":  d.sightings,
+		"events.jsonl":    d.events,
+	}
+}
+
+// items lists every file to back up, profiles last.
+func (d *userData) items() []backup.Item {
+	var items []backup.Item
+	for _, name := range []string{"config.toml", "notes.jsonl", "bookmarks.jsonl", "sightings.json", "events.jsonl"} {
+		if path := d.fixed()[name]; path != "" {
+			items = append(items, backup.Item{Name: name, Path: path})
+		}
+	}
+	for _, p := range profileNames(d.config) {
+		items = append(items, backup.Item{
+			Name: profilePrefix + p + ".toml",
+			Path: filepath.Join(profileDir(d.config), p+".toml"),
+		})
+	}
+	return items
+}
+
+// dest maps a bundle entry to where it goes on this machine. Profile
+// names must be plain file names, so an entry can't escape profiles/.
+func (d *userData) dest(name string) (string, bool) {
+	if path, ok := d.fixed()[name]; ok {
+		return path, path != ""
+	}
+	file, ok := strings.CutPrefix(name, profilePrefix)
+	if !ok || d.config == "" || file != filepath.Base(file) || !strings.HasSuffix(file, ".toml") || strings.HasPrefix(file, ".") {
+		return "", false
+	}
+	return filepath.Join(profileDir(d.config), file), true
+}
+
+// runBackupCmd implements "ls-horizons backup [--out file]": bundle the
+// config, profiles (and the watchlists in them), notes, bookmarks, sighting
+// log, and event log into one archive to carry to another machine.
+func runBackupCmd(args []string) error {
+	fs := flag.NewFlagSet("backup", flag.ContinueOnError)
+	out := fs.String("out", "", "Archive to write (default ls-horizons-backup-YYYYMMDD.tar.gz; - for stdout)")
+	data := userDataFlags(fs)
+	fs.Usage = func() {
+		w := fs.Output()
+		fmt.Fprintf(w, "Usage: %s backup [--out file]\n\n", os.Args[0])
+		fmt.Fprintln(w, "Bundles your config, profiles, notes, bookmarks, sighting log, and event log into one archive.")
+		fmt.Fprintln(w, "Recordings (--record) are not included; copy their directory separately.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+	if *out == "" {
+		*out = "ls-horizons-backup-" + time.Now().Format("20060102") + ".tar.gz"
+	}
+	if err := sandbox.CheckWrite(*out); err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	var f *os.File
+	if *out != "-" {
+		var err error
+		f, err = os.OpenFile(*out, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+		if err != nil {
+			return err
+		}
+		w = f
+	}
+	written, err := backup.Write(w, data.items())
+	if f != nil {
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			os.Remove(*out)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if len(written) == 0 {
+		fmt.Fprintln(os.Stderr, "Nothing to back up: no config or data files found.")
+		if f != nil {
+			os.Remove(*out)
+		}
+		return errQuiet
+	}
+	if f != nil {
+		fmt.Fprintf(os.Stderr, "Wrote %s: %s\n", *out, strings.Join(written, ", "))
+	}
+	return nil
+}
+
+// runRestoreCmd implements "ls-horizons restore file": unpack a backup
+// bundle to this machine's paths, leaving existing files alone unless
+// --force.
+func runRestoreCmd(args []string) error {
+	fs := flag.NewFlagSet("restore", flag.ContinueOnError)
+	force := fs.Bool("force", false, "Replace files that already exist")
+	dryRun := fs.Bool("dry-run", false, "List what would be restored without writing anything")
+	data := userDataFlags(fs)
+	fs.Usage = func() {
+		w := fs.Output()
+		fmt.Fprintf(w, "Usage: %s restore [--force] [--dry-run] file\n\n", os.Args[0])
+		fmt.Fprintln(w, "Restores a bundle written by backup (- reads stdin). Existing files are kept unless --force.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("restore takes one archive")
+	}
+	if !*dryRun {
+		if err := sandbox.Check("restore"); err != nil {
+			return err
+		}
+	}
+
+	var r io.Reader = os.Stdin
+	if name := fs.Arg(0); name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+	res, err := backup.Restore(r, data.dest, backup.Options{Overwrite: *force, DryRun: *dryRun})
+	verb := "Restored"
+	if *dryRun {
+		verb = "Would restore"
+	}
+	if len(res.Restored) > 0 {
+		fmt.Printf("%s: %s\n", verb, strings.Join(res.Restored, ", "))
+	}
+	if len(res.Existing) > 0 {
+		fmt.Printf("Kept existing (use --force to replace): %s\n", strings.Join(res.Existing, ", "))
+	}
+	if len(res.Unknown) > 0 {
+		fmt.Printf("Skipped unknown entries: %s\n", strings.Join(res.Unknown, ", "))
+	}
+	return err
+}
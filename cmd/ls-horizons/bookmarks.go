@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"sync"
+
+	"github.com/litescript/ls-horizons/internal/bookmarks"
+	"github.com/litescript/ls-horizons/internal/dsn"
+	"github.com/litescript/ls-horizons/internal/logging"
+	"github.com/litescript/ls-horizons/internal/state"
+	"github.com/litescript/ls-horizons/internal/ui"
+)
+
+// feedSwitcher runs one data feed at a time for the TUI: the live fetch
+// loop, a replay, or a revisited bookmark.
+type feedSwitcher struct {
+	parent context.Context
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newFeedSwitcher(ctx context.Context) *feedSwitcher {
+	return &feedSwitcher{parent: ctx}
+}
+
+// start stops the running feed, waiting for it to return so it can't
+// post over the new one, and runs feed in its place.
+func (f *feedSwitcher) start(feed func(ctx context.Context)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.cancel != nil {
+		f.cancel()
+		<-f.done
+	}
+	ctx, cancel := context.WithCancel(f.parent)
+	done := make(chan struct{})
+	f.cancel, f.done = cancel, done
+	go func() {
+		defer close(done)
+		feed(ctx)
+	}()
+}
+
+// bookmarkPlayer revisits a bookmark by replaying its snapshot in place of
+// the running feed. Alerts and the sighting log are detached first, since
+// the jump back in time would otherwise be reported as link changes. The
+// session stays in replay until restarted.
+func bookmarkPlayer(feeds *feedSwitcher, stopAlerts func(), stateMgr *state.Manager, mailbox *ui.Mailbox, logger *logging.Logger) ui.BookmarkPlayer {
+	return func(b bookmarks.Bookmark) error {
+		stopAlerts()
+		stateMgr.SetSightings(nil)
+		feeds.start(func(ctx context.Context) {
+			runReplayLoop(ctx, []*dsn.SnapshotExport{b.Snapshot}, 1, stateMgr, mailbox, logger)
+		})
+		logger.Info("Revisiting bookmark from %s", b.FeedTime().Format("2006-01-02 15:04:05 MST"))
+		return nil
+	}
+}
@@ -0,0 +1,278 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/record"
+	"github.com/litescript/ls-horizons/internal/sandbox"
+	"github.com/litescript/ls-horizons/internal/serve"
+	"github.com/litescript/ls-horizons/internal/state"
+)
+
+// cacheSnapshots is the cache kind for recorded snapshots on disk (see
+// --record); the other kinds live in a running instance's memory.
+const cacheSnapshots = "snapshots"
+
+// runCacheCmd implements "ls-horizons cache stats|clear": list the
+// ephemeris, elevation trace, and pass plan caches of an instance running
+// with --serve, and the snapshot recordings on disk, or clear them, so a
+// stale pass plan can be looked into without a restart.
+func runCacheCmd(args []string) error {
+	fs := flag.NewFlagSet("cache", flag.ContinueOnError)
+	apiAddr := fs.String("api", serve.DefaultAddr, "Address or URL (https://host:port) of an instance running with --serve, for its in-memory caches")
+	token := fs.String("auth-token", "", "Bearer token for the instance's API")
+	user := fs.String("auth-user", "", "HTTP basic auth user for the instance's API")
+	pass := fs.String("auth-pass", "", "HTTP basic auth password")
+	recordDir := fs.String("record-dir", record.DefaultDir(), "Directory of --record snapshots")
+	olderThan := fs.Duration("older-than", 0, "clear: only recordings last written longer ago than this")
+	asJSON := fs.Bool("json", false, "stats: print JSON")
+	fs.Usage = func() {
+		out := fs.Output()
+		fmt.Fprintf(out, "Usage: %s cache stats [flags]\n       %s cache clear kind... [flags]\n\n", os.Args[0], os.Args[0])
+		fmt.Fprintf(out, "Kinds: %s, %s (recordings on disk).\n", joinKinds(state.CacheKinds), cacheSnapshots)
+		fs.PrintDefaults()
+	}
+	if len(args) == 0 {
+		fs.Usage()
+		return errors.New("missing stats or clear")
+	}
+	action := args[0]
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	base, err := apiBaseURL(*apiAddr)
+	if err != nil {
+		return fmt.Errorf("--api: %w", err)
+	}
+	client := cacheClient{base: base, token: *token, user: *user, pass: *pass}
+
+	switch action {
+	case "stats":
+		if fs.NArg() > 0 {
+			return fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+		}
+		return cacheStats(os.Stdout, client, *recordDir, *asJSON, time.Now())
+	case "clear":
+		if fs.NArg() == 0 {
+			return fmt.Errorf("clear what? %s, or %s", joinKinds(state.CacheKinds), cacheSnapshots)
+		}
+		if err := sandbox.Check("cache clear"); err != nil {
+			return err
+		}
+		for _, kind := range fs.Args() {
+			if err := cacheClear(os.Stdout, client, *recordDir, kind, *olderThan, time.Now()); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		fs.Usage()
+		return fmt.Errorf("unknown cache command %q", action)
+	}
+}
+
+// cacheStats prints every cache entry: the running instance's, if one is
+// reachable, then the recordings.
+func cacheStats(w io.Writer, client cacheClient, recordDir string, asJSON bool, now time.Time) error {
+	entries, err := client.stats()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "In-memory caches not shown: %v\n", err)
+	}
+	files, err := record.List(recordDir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	if asJSON {
+		type recording struct {
+			Path      string    `json:"path"`
+			Bytes     int64     `json:"bytes"`
+			UpdatedAt time.Time `json:"updated_at"`
+		}
+		out := struct {
+			Memory    []state.CacheEntry `json:"memory"`
+			Snapshots []recording        `json:"snapshots"`
+		}{Memory: entries, Snapshots: []recording{}}
+		if out.Memory == nil {
+			out.Memory = []state.CacheEntry{}
+		}
+		for _, f := range files {
+			out.Snapshots = append(out.Snapshots, recording{Path: f.Path, Bytes: f.Size, UpdatedAt: f.ModTime})
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(out)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "KIND\tKEY\tSIZE\tAGE\tSTATUS")
+	for _, e := range entries {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", e.Kind, e.Key, cacheSize(e), cacheAge(now, e.UpdatedAt), e.Status)
+	}
+	for _, f := range files {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t\n", cacheSnapshots, f.Day.Format("2006-01-02"), formatBytes(f.Size), cacheAge(now, f.ModTime))
+	}
+	return tw.Flush()
+}
+
+// cacheClear empties one cache: in the running instance, or recordings on
+// disk last written more than olderThan ago (all of them when zero).
+func cacheClear(w io.Writer, client cacheClient, recordDir, kind string, olderThan time.Duration, now time.Time) error {
+	if kind != cacheSnapshots {
+		k, err := state.ParseCacheKind(kind)
+		if err != nil {
+			return err
+		}
+		n, err := client.clear(k)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "Cleared %d %s entries\n", n, k)
+		return nil
+	}
+
+	files, err := record.List(recordDir)
+	if err != nil {
+		return err
+	}
+	var n int
+	var freed int64
+	for _, f := range files {
+		if olderThan > 0 && now.Sub(f.ModTime) <= olderThan {
+			continue
+		}
+		if err := os.Remove(f.Path); err != nil {
+			return err
+		}
+		n++
+		freed += f.Size
+	}
+	fmt.Fprintf(w, "Deleted %d recordings (%s)\n", n, formatBytes(freed))
+	return nil
+}
+
+// apiBaseURL turns --api into the API's base URL: a bare host:port is
+// served over plain HTTP, as --serve does without --tls-cert; give a URL
+// to use HTTPS.
+func apiBaseURL(addr string) (string, error) {
+	if !strings.Contains(addr, "://") {
+		addr = "http://" + addr
+	}
+	u, err := url.Parse(addr)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("unsupported scheme %q (want http or https)", u.Scheme)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("no host in %q", addr)
+	}
+	return strings.TrimSuffix(u.String(), "/"), nil
+}
+
+// cacheClient talks to the cache endpoints of a running instance's API.
+type cacheClient struct {
+	base  string
+	token string
+
+	user, pass string // HTTP basic auth
+}
+
+func (c cacheClient) stats() ([]state.CacheEntry, error) {
+	var entries []state.CacheEntry
+	return entries, c.do(http.MethodGet, "/cache", &entries)
+}
+
+func (c cacheClient) clear(kind state.CacheKind) (int, error) {
+	var out struct {
+		Cleared int `json:"cleared"`
+	}
+	return out.Cleared, c.do(http.MethodDelete, "/cache/"+string(kind), &out)
+}
+
+func (c cacheClient) do(method, path string, v any) error {
+	req, err := http.NewRequest(method, c.base+path, nil)
+	if err != nil {
+		return err
+	}
+	switch {
+	case c.token != "":
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	case c.user != "":
+		req.SetBasicAuth(c.user, c.pass)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("no instance serving the API at %s (start one with --serve): %w", c.base, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Error string `json:"error"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&apiErr)
+		if apiErr.Error == "" {
+			apiErr.Error = resp.Status
+		}
+		return fmt.Errorf("%s %s: %s", method, path, apiErr.Error)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// cacheSize describes an in-memory entry's size in its own units.
+func cacheSize(e state.CacheEntry) string {
+	if e.Kind == state.CachePassPlans {
+		return fmt.Sprintf("%d passes", e.Samples)
+	}
+	return fmt.Sprintf("%d samples", e.Samples)
+}
+
+// cacheAge formats how long ago an entry was updated: "12s", "4m", "2h 10m".
+func cacheAge(now, t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	d := max(now.Sub(t), 0)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	default:
+		return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+}
+
+// formatBytes formats a file size: "512 B", "1.2 MB".
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGT"[exp])
+}
+
+// joinKinds lists cache kinds for messages.
+func joinKinds(kinds []state.CacheKind) string {
+	names := make([]string, len(kinds))
+	for i, k := range kinds {
+		names[i] = string(k)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,624 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/astro"
+	"github.com/litescript/ls-horizons/internal/dsn"
+	"github.com/litescript/ls-horizons/internal/ephem"
+	"github.com/litescript/ls-horizons/internal/notify"
+	"github.com/litescript/ls-horizons/internal/state"
+	"github.com/litescript/ls-horizons/internal/ui"
+)
+
+// fileConfig holds settings from config.toml and, with --profile, a named
+// profile layered over it. Empty fields leave the built-in default in
+// place; command-line flags override both files.
+//
+// The file is a small TOML subset: top-level keys, [sky], [orbit],
+// [health], [wind], [passes], [passes.complex], [passes.spacecraft],
+// [horizons], [notify], and [notify.spacecraft] tables, quoted strings,
+// booleans, and numbers (seconds, for durations).
+//
+//	refresh = "10s"
+//	view    = "sky"        # dashboard, mission, sky, orbit, events
+//	ephem   = "horizons"   # horizons, dsn, auto
+//	theme   = "mono"       # default, mono
+//	layout  = "small"      # default, small (or compact)
+//	follow  = "VGR1, JWST" # watchlist, as --follow
+//	event_history = 5000   # events kept for the event log
+//	event_log = "/var/log/ls-horizons/events.jsonl"  # as --event-log
+//	history_db = "/var/lib/ls-horizons/history.db"  # as --history-db
+//	timeline_window = "12h"  # span of the dashboard utilization timeline
+//	pass_window = "72h"    # pass plan span, up to 168h
+//	pass_step   = "2m"     # pass plan sample step
+//	window_title = false   # leave the terminal title alone
+//	refraction = false     # airless elevations, as --refraction=false
+//
+//	[sky]
+//	labels = "all"         # none, focused, all
+//	sun_avoidance = 15     # degrees from the Sun that warn about the focused spacecraft
+//	star_catalog = "/usr/local/share/hyg/hygdata_v41.csv.gz"  # HYG-format CSV, gzipped or not
+//	star_mag_limit = 7     # faintest stars drawn
+//
+//	[orbit]
+//	labels = "none"
+//
+//	[health]
+//	model = "elevation"    # default, elevation, band-rate
+//	rate_weight = 0.1      # distance_, rate_, elevation_, quality_, band_weight
+//	band_rates = true      # judge data rate against the band's usual range
+//	poor = 0.7             # marginal, poor: struggle thresholds
+//
+//	[wind]                 # km/h, for the wind-risk indicator and WIND_RISK
+//	caution = 45
+//	stow = 72
+//
+//	[passes]
+//	min_elevation = 5      # degrees; passes start and end here
+//
+//	[passes.complex]       # per complex: gdscc, cdscc, mdscc
+//	mdscc = 8
+//
+//	[passes.spacecraft]    # per spacecraft; the higher of the two applies
+//	JUNO = 15
+//
+//	[horizons]             # self-imposed quota on JPL Horizons requests
+//	per_hour = 120         # 0 for no limit
+//	per_day  = 1000
+//
+//	[notify]
+//	desktop = true
+//	command = "~/bin/dsn-hook"   # run with LSH_* event variables
+//	webhook = "https://hooks.slack.com/services/..."
+//	events  = "handoff, link_lost"
+//
+//	[notify.spacecraft]    # events per spacecraft; "none" mutes one
+//	VGR1 = "new_link, handoff, link_lost"
+type fileConfig struct {
+	Refresh        time.Duration
+	EventHistory   int
+	EventLog       string
+	HistoryDB      string
+	TimelineWindow time.Duration
+	PassWindow     time.Duration
+	PassStep       time.Duration
+	View           string
+	Ephem          string
+	Theme          string
+	Layout         string
+	Follow         string
+	SkyLabels      string
+	SunAvoidance   float64 // [sky] sun_avoidance, degrees
+	StarCatalog    string  // [sky] star_catalog path ("" = built-in)
+	StarMagLimit   float64 // [sky] star_mag_limit
+	OrbitLabels    string
+	WindowTitle    *bool // window_title, if set
+	Refraction     *bool // refraction, if set
+
+	HealthModel     string
+	HealthOverrides map[string]float64 // [health] weights and thresholds by key
+	HealthBandRates *bool              // [health] band_rates, if set
+
+	WindCaution float64 // [wind] limits in km/h
+	WindStow    float64
+
+	PassElevations dsn.PassElevations // [passes] minimum elevations
+
+	HorizonsPerHour *int // [horizons] quota, if set
+	HorizonsPerDay  *int
+
+	Notify notify.Config
+}
+
+// Allowed values for enumerated config keys.
+var (
+	configViews  = []string{"dashboard", "mission", "sky", "orbit", "events"}
+	configEphem  = []string{"horizons", "dsn", "auto"}
+	configThemes = []string{"default", "mono"}
+	configLayout = []string{"default", "small", "compact"}
+	configLabels = []string{"none", "focused", "all"}
+)
+
+// configTables are the tables a config file may contain.
+var configTables = []string{"sky", "orbit", "health", "wind", "passes", "passes.complex", "passes.spacecraft", "horizons", "notify", "notify.spacecraft"}
+
+// healthKeys are the numeric [health] keys that adjust the chosen model.
+var healthKeys = []string{"distance_weight", "rate_weight", "elevation_weight", "quality_weight", "band_weight", "marginal", "poor"}
+
+// defaultConfigPath returns $XDG_CONFIG_HOME/ls-horizons/config.toml,
+// falling back to ~/.config.
+func defaultConfigPath() string {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "ls-horizons", "config.toml")
+}
+
+// profileDir returns the directory of named profiles for a config file:
+// profiles/ beside it, so ~/.config/ls-horizons/profiles/ops-wall.toml is
+// the "ops-wall" profile.
+func profileDir(configPath string) string {
+	if configPath == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(configPath), "profiles")
+}
+
+// profileNames lists the profiles saved beside a config file.
+func profileNames(configPath string) []string {
+	matches, _ := filepath.Glob(filepath.Join(profileDir(configPath), "*.toml"))
+	names := make([]string, len(matches))
+	for i, m := range matches {
+		names[i] = strings.TrimSuffix(filepath.Base(m), ".toml")
+	}
+	return names
+}
+
+// loadConfig reads the config file at path, then the named profile (if
+// any) over it: keys the profile sets win, the rest carry over. A missing
+// config file is not an error and yields an empty config; a missing
+// profile is, unless it names a layout, which --profile small then selects.
+func loadConfig(path, profile string) (fileConfig, error) {
+	var cfg fileConfig
+	if path != "" {
+		if err := readConfig(&cfg, path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fileConfig{}, err
+		}
+	}
+	if profile == "" {
+		return cfg, nil
+	}
+	if strings.ContainsAny(profile, `/\`) {
+		return fileConfig{}, fmt.Errorf("profile %q: name can't contain a path separator", profile)
+	}
+	err := fs.ErrNotExist
+	if path != "" {
+		err = readConfig(&cfg, filepath.Join(profileDir(path), profile+".toml"))
+	}
+	if errors.Is(err, fs.ErrNotExist) {
+		if slices.Contains(configLayout, profile) {
+			cfg.Layout = profile
+			return cfg, nil
+		}
+		if path == "" {
+			return fileConfig{}, fmt.Errorf("profile %q: no config directory", profile)
+		}
+		known := "none saved"
+		if names := profileNames(path); len(names) > 0 {
+			known = "have " + strings.Join(names, ", ")
+		}
+		return fileConfig{}, fmt.Errorf("no profile %q in %s (%s)", profile, profileDir(path), known)
+	}
+	if err != nil {
+		return fileConfig{}, err
+	}
+	return cfg, nil
+}
+
+// readConfig parses the file at path into cfg.
+func readConfig(cfg *fileConfig, path string) error {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("open config: %w", err)
+	}
+	defer f.Close()
+
+	if err := parseConfig(cfg, f); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// parseConfig parses the config.toml subset described on fileConfig into
+// cfg, overwriting the keys the file sets.
+func parseConfig(cfg *fileConfig, r io.Reader) error {
+	section := ""
+
+	sc := bufio.NewScanner(r)
+	for lineNo := 1; sc.Scan(); lineNo++ {
+		line := strings.TrimSpace(stripComment(sc.Text()))
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			if !slices.Contains(configTables, section) {
+				return fmt.Errorf("line %d: unknown table [%s]", lineNo, section)
+			}
+			continue
+		}
+
+		key, raw, ok := strings.Cut(line, "=")
+		if !ok {
+			return fmt.Errorf("line %d: expected key = value", lineNo)
+		}
+		key = strings.TrimSpace(key)
+		if section != "" {
+			key = section + "." + key
+		}
+		value, quoted, err := parseConfigValue(strings.TrimSpace(raw))
+		if err != nil {
+			return fmt.Errorf("line %d: %s: %w", lineNo, key, err)
+		}
+
+		switch key {
+		case "refresh":
+			cfg.Refresh, err = parseConfigDuration(value, quoted)
+		case "timeline_window":
+			cfg.TimelineWindow, err = parseConfigDuration(value, quoted)
+		case "pass_window":
+			cfg.PassWindow, err = parseConfigDuration(value, quoted)
+		case "pass_step":
+			cfg.PassStep, err = parseConfigDuration(value, quoted)
+		case "event_history":
+			cfg.EventHistory, err = strconv.Atoi(value)
+			if err == nil && cfg.EventHistory <= 0 {
+				err = errors.New("must be positive")
+			}
+		case "event_log":
+			cfg.EventLog = value
+		case "history_db":
+			cfg.HistoryDB = value
+		case "window_title":
+			var v bool
+			if v, err = strconv.ParseBool(value); err == nil {
+				cfg.WindowTitle = &v
+			}
+		case "refraction":
+			var v bool
+			if v, err = strconv.ParseBool(value); err == nil {
+				cfg.Refraction = &v
+			}
+		case "view":
+			cfg.View, err = oneOf(value, configViews)
+		case "ephem":
+			cfg.Ephem, err = oneOf(value, configEphem)
+		case "theme":
+			cfg.Theme, err = oneOf(value, configThemes)
+		case "layout":
+			cfg.Layout, err = oneOf(value, configLayout)
+		case "follow":
+			cfg.Follow = value
+		case "sky.labels":
+			cfg.SkyLabels, err = oneOf(value, configLabels)
+		case "sky.sun_avoidance":
+			cfg.SunAvoidance, err = parsePositive(value)
+			if err == nil && cfg.SunAvoidance >= 180 {
+				err = errors.New("must be less than 180")
+			}
+		case "sky.star_catalog":
+			cfg.StarCatalog = value
+		case "sky.star_mag_limit":
+			cfg.StarMagLimit, err = parsePositive(value)
+		case "orbit.labels":
+			cfg.OrbitLabels, err = oneOf(value, configLabels)
+		case "health.model":
+			cfg.HealthModel, err = oneOf(value, dsn.HealthModelNames())
+		case "health.band_rates":
+			var v bool
+			if v, err = strconv.ParseBool(value); err == nil {
+				cfg.HealthBandRates = &v
+			}
+		case "wind.caution":
+			cfg.WindCaution, err = parsePositive(value)
+		case "wind.stow":
+			cfg.WindStow, err = parsePositive(value)
+		case "passes.min_elevation":
+			var el float64
+			if el, err = parseElevation(value); err == nil {
+				cfg.PassElevations.Default = &el
+			}
+		case "horizons.per_hour":
+			cfg.HorizonsPerHour, err = parseLimit(value)
+		case "horizons.per_day":
+			cfg.HorizonsPerDay, err = parseLimit(value)
+		case "notify.desktop":
+			cfg.Notify.Desktop, err = strconv.ParseBool(value)
+		case "notify.command":
+			cfg.Notify.Command = value
+		case "notify.webhook":
+			cfg.Notify.Webhook = value
+		case "notify.events":
+			cfg.Notify.Events, err = notify.ParseEvents(value)
+		default:
+			if name, ok := strings.CutPrefix(key, "passes.complex."); ok {
+				c, known := dsn.ParseComplex(name)
+				if !known {
+					err = errors.New("unknown complex (gdscc, cdscc, mdscc)")
+					break
+				}
+				var el float64
+				if el, err = parseElevation(value); err == nil {
+					if cfg.PassElevations.Complex == nil {
+						cfg.PassElevations.Complex = make(map[dsn.Complex]float64)
+					}
+					cfg.PassElevations.Complex[c] = el
+				}
+				break
+			}
+			if code, ok := strings.CutPrefix(key, "passes.spacecraft."); ok {
+				var el float64
+				if el, err = parseElevation(value); err == nil {
+					if cfg.PassElevations.Spacecraft == nil {
+						cfg.PassElevations.Spacecraft = make(map[string]float64)
+					}
+					cfg.PassElevations.Spacecraft[dsn.CanonicalCode(code)] = el
+				}
+				break
+			}
+			if code, ok := strings.CutPrefix(key, "notify.spacecraft."); ok {
+				var events []state.EventType
+				if events, err = notify.ParseEvents(value); err == nil {
+					if cfg.Notify.Spacecraft == nil {
+						cfg.Notify.Spacecraft = make(map[string][]state.EventType)
+					}
+					cfg.Notify.Spacecraft[dsn.CanonicalCode(code)] = events
+				}
+				break
+			}
+			name, isHealth := strings.CutPrefix(key, "health.")
+			if !isHealth || !slices.Contains(healthKeys, name) {
+				err = errors.New("unknown key")
+				break
+			}
+			var v float64
+			if v, err = strconv.ParseFloat(value, 64); err == nil {
+				if cfg.HealthOverrides == nil {
+					cfg.HealthOverrides = make(map[string]float64)
+				}
+				cfg.HealthOverrides[name] = v
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("line %d: %s: %w", lineNo, key, err)
+		}
+	}
+	return sc.Err()
+}
+
+// parsePositive parses a number that must be above zero.
+func parsePositive(value string) (float64, error) {
+	v, err := strconv.ParseFloat(value, 64)
+	if err == nil && v <= 0 {
+		err = errors.New("must be positive")
+	}
+	return v, err
+}
+
+// parseElevation parses an elevation in degrees from 0 to below 90.
+func parseElevation(value string) (float64, error) {
+	v, err := strconv.ParseFloat(value, 64)
+	if err == nil && (v < 0 || v >= 90) {
+		err = errors.New("must be from 0 to below 90 degrees")
+	}
+	return v, err
+}
+
+// parseLimit parses a count that may be zero, for no limit.
+func parseLimit(value string) (*int, error) {
+	v, err := strconv.Atoi(value)
+	if err == nil && v < 0 {
+		err = errors.New("must not be negative")
+	}
+	return &v, err
+}
+
+// stripComment removes a trailing # comment outside of quotes.
+func stripComment(line string) string {
+	var quote rune
+	for i, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// parseConfigValue unquotes a basic ("...") or literal ('...') string,
+// or returns a bare value as-is.
+func parseConfigValue(raw string) (value string, quoted bool, err error) {
+	switch {
+	case raw == "":
+		return "", false, errors.New("missing value")
+	case strings.HasPrefix(raw, `"`):
+		value, err = strconv.Unquote(raw)
+		return value, true, err
+	case strings.HasPrefix(raw, "'"):
+		if len(raw) < 2 || !strings.HasSuffix(raw, "'") {
+			return "", true, errors.New("unterminated string")
+		}
+		return raw[1 : len(raw)-1], true, nil
+	default:
+		return raw, false, nil
+	}
+}
+
+// parseConfigDuration parses a positive duration: a quoted Go duration
+// ("10s", "12h") or a bare number of seconds.
+func parseConfigDuration(value string, quoted bool) (time.Duration, error) {
+	var d time.Duration
+	var err error
+	if quoted {
+		d, err = time.ParseDuration(value)
+	} else {
+		var secs int
+		secs, err = strconv.Atoi(value)
+		d = time.Duration(secs) * time.Second
+	}
+	if err == nil && d <= 0 {
+		err = errors.New("must be positive")
+	}
+	return d, err
+}
+
+// oneOf returns value if it is one of allowed.
+func oneOf(value string, allowed []string) (string, error) {
+	for _, a := range allowed {
+		if value == a {
+			return value, nil
+		}
+	}
+	return "", fmt.Errorf("%q is not one of %s", value, strings.Join(allowed, ", "))
+}
+
+// flagsSet returns the names of the flags in fs given on the command line.
+func flagsSet(fs *flag.FlagSet) map[string]bool {
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	return set
+}
+
+// subcommandSettings are the settings subcommands take from the config
+// file, --profile, and the global flags.
+type subcommandSettings struct {
+	Usage          *ephem.Usage // Horizons quota tracker
+	Refraction     bool
+	PassWindow     dsn.PassWindow
+	PassElevations dsn.PassElevations
+}
+
+// subcommandConfig loads the config file and --profile for a subcommand.
+// Global flags go before the subcommand name, so they are parsed by now
+// and still win over the files.
+func subcommandConfig() (subcommandSettings, error) {
+	cfg, err := loadConfig(configPath, profileName)
+	if err != nil {
+		return subcommandSettings{}, err
+	}
+	explicit := flagsSet(flag.CommandLine)
+	usage, err := cfg.horizonsUsage(explicit)
+	if err != nil {
+		return subcommandSettings{}, err
+	}
+	window := cfg.planWindow(explicit)
+	if err := window.Validate(); err != nil {
+		return subcommandSettings{}, fmt.Errorf("pass_window/pass_step: %w", err)
+	}
+	return subcommandSettings{
+		Usage:          usage,
+		Refraction:     cfg.useRefraction(explicit),
+		PassWindow:     window,
+		PassElevations: cfg.PassElevations,
+	}, nil
+}
+
+// planWindow returns the pass plan window: --pass-window and --pass-step
+// where given, else pass_window and pass_step, else the default.
+func (c fileConfig) planWindow(explicit map[string]bool) dsn.PassWindow {
+	w := passWindow
+	if c.PassWindow > 0 && !explicit["pass-window"] {
+		w.Span = c.PassWindow
+	}
+	if c.PassStep > 0 && !explicit["pass-step"] {
+		w.Step = c.PassStep
+	}
+	return w
+}
+
+// useRefraction reports whether elevations are refracted: --refraction
+// where given, else the config file's refraction, else on.
+func (c fileConfig) useRefraction(explicit map[string]bool) bool {
+	if c.Refraction != nil && !explicit["refraction"] {
+		return *c.Refraction
+	}
+	return refraction
+}
+
+// healthModel returns the named built-in model (or the config file's
+// choice when name is empty) with the [health] overrides applied.
+func (c fileConfig) healthModel(name string) (dsn.HealthModel, error) {
+	if name == "" {
+		name = c.HealthModel
+	}
+	if name == "" {
+		name = dsn.HealthModelDefault
+	}
+	m, err := dsn.LookupHealthModel(name)
+	if err != nil {
+		return m, err
+	}
+	for key, v := range c.HealthOverrides {
+		switch key {
+		case "distance_weight":
+			m.DistanceWeight = v
+		case "rate_weight":
+			m.RateWeight = v
+		case "elevation_weight":
+			m.ElevationWeight = v
+		case "quality_weight":
+			m.QualityWeight = v
+		case "band_weight":
+			m.BandWeight = v
+		case "marginal":
+			m.MarginalAt = v
+		case "poor":
+			m.PoorAt = v
+		}
+	}
+	if c.HealthBandRates != nil {
+		m.BandRates = *c.HealthBandRates
+	}
+	if len(c.HealthOverrides) > 0 || c.HealthBandRates != nil {
+		m.Name += " (custom)"
+	}
+	return m, m.Validate()
+}
+
+// uiSettings converts the file config to UI settings, loading the star
+// catalog it names. refresh is the already-resolved refresh interval.
+func (c fileConfig) uiSettings(refresh time.Duration) (ui.Settings, error) {
+	s := ui.DefaultSettings()
+	s.Refresh = refresh
+	if c.View != "" {
+		s.DefaultView = ui.ParseViewMode(c.View)
+	}
+	if c.SkyLabels != "" {
+		s.SkyLabels = ui.ParseLabelMode(c.SkyLabels)
+	}
+	if c.SunAvoidance > 0 {
+		s.SunAvoidance = c.SunAvoidance
+	}
+	if c.StarMagLimit > 0 {
+		s.StarMagLimit = c.StarMagLimit
+	}
+	if c.StarCatalog != "" {
+		stars, err := astro.LoadStarCatalog(c.StarCatalog)
+		if err != nil {
+			return s, fmt.Errorf("star_catalog: %w", err)
+		}
+		s.Stars = stars
+	}
+	if c.OrbitLabels != "" {
+		s.OrbitLabels = ui.ParseLabelMode(c.OrbitLabels)
+	}
+	s.Theme = ui.ParseTheme(c.Theme)
+	return s, nil
+}
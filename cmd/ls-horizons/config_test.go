@@ -0,0 +1,84 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+)
+
+func TestLoadConfig_ProfileNamesLayout(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+
+	cfg, err := loadConfig(path, "small")
+	if err != nil {
+		t.Fatalf("loadConfig with no saved profiles: %v", err)
+	}
+	if cfg.Layout != "small" {
+		t.Errorf("Layout = %q, want --profile small to select the small layout", cfg.Layout)
+	}
+
+	if _, err := loadConfig(path, "ops-wall"); err == nil || !strings.Contains(err.Error(), "none saved") {
+		t.Errorf("loadConfig(ops-wall) error = %v, want no such profile", err)
+	}
+}
+
+func TestLoadConfig_SavedProfileWinsOverLayout(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.MkdirAll(profileDir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	profile := "theme = \"mono\"\nlayout = \"default\"\n"
+	if err := os.WriteFile(filepath.Join(profileDir(path), "small.toml"), []byte(profile), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := loadConfig(path, "small")
+	if err != nil {
+		t.Fatalf("loadConfig: %v", err)
+	}
+	if cfg.Theme != "mono" || cfg.Layout != "default" {
+		t.Errorf("cfg = theme %q layout %q, want the saved small profile", cfg.Theme, cfg.Layout)
+	}
+}
+
+func TestParseConfig_ZeroMinElevation(t *testing.T) {
+	var cfg fileConfig
+	if err := parseConfig(&cfg, strings.NewReader("[passes]\nmin_elevation = 0\n")); err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.PassElevations.For("VGR1", dsn.ComplexMadrid); got != 0 {
+		t.Errorf("min_elevation = 0 gives %v°, want passes from the horizon", got)
+	}
+}
+
+func TestSubcommandConfig_Refraction(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte("refraction = false\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	defer func(prev string) { configPath = prev }(configPath)
+	configPath = path
+	defer func(prev bool) { refraction = prev }(refraction)
+	refraction = true // the --refraction default
+
+	cfg, err := subcommandConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Refraction {
+		t.Error("Refraction = true, want the config file's false")
+	}
+	obs, err := parseObserver("34.2,-118.2", cfg.Refraction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if obs.Refraction {
+		t.Error("observer refracts, want airless elevations from the config file")
+	}
+	if obs, _ := parseObserver("goldstone", true); !obs.Refraction {
+		t.Error("complex observer ignores refraction")
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/demo"
+	"github.com/litescript/ls-horizons/internal/dsn"
+)
+
+// runContention implements "ls-horizons contention [--within d]": overlay
+// the pass plans of every spacecraft the DSN is tracking and print when
+// several peak over the same complex at once.
+func runContention(args []string) error {
+	fs := flag.NewFlagSet("contention", flag.ContinueOnError)
+	within := fs.Duration("within", dsn.PassWindowDuration, "How far ahead to look, up to 168h (overrides pass_window and --pass-window)")
+	atOnce := fs.Int("min", dsn.DefaultMinContention, "Spacecraft that must peak together to count as contention")
+	useDemo := fs.Bool("demo", false, "Use bundled DSN data and synthetic ephemerides (no network)")
+	fs.Usage = func() {
+		out := fs.Output()
+		fmt.Fprintf(out, "Usage: %s contention [--within d] [--min n]\n\n", os.Args[0])
+		fmt.Fprintln(out, "Lists, per complex, the periods when the tracked spacecraft's passes peak together\n(within an hour either side of each pass's peak).")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+	switch {
+	case *within <= 0 || *within > dsn.MaxPassWindowDuration:
+		return fmt.Errorf("--within must be positive and at most %s", dsn.MaxPassWindowDuration)
+	case *atOnce < 2:
+		return errors.New("--min must be at least 2")
+	}
+	cfg, err := subcommandConfig()
+	if err != nil {
+		return err
+	}
+	window := cfg.PassWindow
+	if flagsSet(fs)["within"] {
+		window.Span = *within
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	fetcher := dsn.NewFetcher()
+	var demoSrc *demo.Source
+	if *useDemo {
+		if demoSrc, err = demo.New(time.Now()); err != nil {
+			return err
+		}
+		fetcher = demoSrc.Fetcher()
+	}
+	result := fetcher.Fetch(ctx)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	now := result.FetchedAt
+	plans, err := publishPassPlans(ctx, result.Data, now, window, cfg.PassElevations, radecSource(demoSrc, cfg.Usage))
+	if err != nil {
+		return err
+	}
+	dsn.WriteContention(os.Stdout, dsn.FindContention(plans, *atOnce), now, now.Add(window.Span), *atOnce, time.Local)
+	return nil
+}
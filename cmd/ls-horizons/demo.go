@@ -0,0 +1,16 @@
+package main
+
+import (
+	"github.com/litescript/ls-horizons/internal/demo"
+	"github.com/litescript/ls-horizons/internal/ephem"
+)
+
+// radecSource returns the RA/Dec source for pass calculations: the
+// synthetic ephemeris of src when --demo is set (src non-nil), Horizons
+// counting against usage otherwise.
+func radecSource(src *demo.Source, usage *ephem.Usage) ephem.RADecProvider {
+	if src != nil {
+		return src.Ephemeris()
+	}
+	return newHorizons(usage)
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/demo"
+	"github.com/litescript/ls-horizons/internal/dsn"
+	"github.com/litescript/ls-horizons/internal/ephem"
+	"github.com/litescript/ls-horizons/internal/record"
+	"github.com/litescript/ls-horizons/internal/sandbox"
+)
+
+// runDigest implements "ls-horizons digest": summarize the last week of
+// --record history (notable passes, rare spacecraft, upcoming solar
+// conjunctions) and print it, write it to a file, turn it into a mailto:
+// link, or send it over SMTP, for a weekly cron job.
+func runDigest(args []string) error {
+	fs := flag.NewFlagSet("digest", flag.ContinueOnError)
+	recordDir := fs.String("record-dir", record.DefaultDir(), "Recorded snapshots (from --record) to summarize")
+	days := fs.Int("days", int(dsn.DigestWindow/(24*time.Hour)), "Days of history to summarize")
+	lookahead := fs.Duration("lookahead", dsn.DigestLookahead, "How far ahead to look for solar conjunctions (0 skips the Horizons queries)")
+	outPath := fs.String("out", "-", "Write the digest to this file (- for stdout)")
+	mailto := fs.String("mailto", "", "Print a mailto: link with the digest for this address instead")
+	smtpAddr := fs.String("smtp", "", "Send the digest through this SMTP server (host:port); the password is read from $LSH_SMTP_PASSWORD")
+	smtpUser := fs.String("smtp-user", "", "SMTP user name (default: --from)")
+	from := fs.String("from", "", "Sender address for --smtp")
+	to := fs.String("to", "", "Comma-separated recipients for --smtp")
+	useDemo := fs.Bool("demo", false, "Use synthetic ephemerides for conjunctions (no network)")
+	fs.Usage = func() {
+		out := fs.Output()
+		fmt.Fprintf(out, "Usage: %s digest [--days n] [--out file | --mailto addr | --smtp host:port --from addr --to addrs]\n\n", os.Args[0])
+		fmt.Fprintln(out, "Summarizes recorded history: notable passes, rare spacecraft appearances, and upcoming solar conjunctions.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+	switch {
+	case *days <= 0:
+		return errors.New("--days must be positive")
+	case *lookahead < 0:
+		return errors.New("--lookahead must not be negative")
+	case *smtpAddr != "" && *mailto != "":
+		return errors.New("--smtp and --mailto are exclusive")
+	case *smtpAddr != "" && (*from == "" || *to == ""):
+		return errors.New("--smtp needs --from and --to")
+	}
+	// SMTP bypasses the HTTP transport read-only mode restricts
+	switch {
+	case *smtpAddr != "":
+		if err := sandbox.Check("sending mail"); err != nil {
+			return err
+		}
+	case *mailto == "":
+		if err := sandbox.CheckWrite(*outPath); err != nil {
+			return err
+		}
+	}
+	var recipients []string
+	if *smtpAddr != "" {
+		if _, _, err := net.SplitHostPort(*smtpAddr); err != nil {
+			return fmt.Errorf("--smtp: %w", err)
+		}
+		if _, err := mail.ParseAddress(*from); err != nil {
+			return fmt.Errorf("--from: %w", err)
+		}
+		list, err := mail.ParseAddressList(*to)
+		if err != nil {
+			return fmt.Errorf("--to: %w", err)
+		}
+		for _, a := range list {
+			recipients = append(recipients, a.Address)
+		}
+	}
+
+	cfg, err := subcommandConfig()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	var demoSrc *demo.Source
+	if *useDemo {
+		if demoSrc, err = demo.New(now); err != nil {
+			return err
+		}
+	}
+
+	start := now.Add(-time.Duration(*days) * 24 * time.Hour)
+	snaps, err := dsn.LoadDigestHistory(*recordDir, start, now)
+	if err != nil {
+		return fmt.Errorf("no recorded history (run with --record first): %w", err)
+	}
+	digest := dsn.BuildDigest(snaps, start, now)
+	if *lookahead > 0 {
+		digest.Conjunctions = digestConjunctions(digest.Spacecraft, now, *lookahead, radecSource(demoSrc, cfg.Usage))
+	}
+
+	var body bytes.Buffer
+	dsn.WriteDigest(&body, digest, time.Local)
+	subject := dsn.DigestSubject(digest)
+
+	switch {
+	case *smtpAddr != "":
+		user := *smtpUser
+		if user == "" {
+			user = *from
+		}
+		var auth smtp.Auth
+		if pass := os.Getenv("LSH_SMTP_PASSWORD"); pass != "" {
+			host, _, _ := net.SplitHostPort(*smtpAddr)
+			auth = smtp.PlainAuth("", user, pass, host)
+		}
+		msg := digestMessage(*from, recipients, subject, body.String(), now)
+		if err := smtp.SendMail(*smtpAddr, auth, *from, recipients, msg); err != nil {
+			return fmt.Errorf("sending digest: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Sent %q to %s\n", subject, strings.Join(recipients, ", "))
+		return nil
+	case *mailto != "":
+		fmt.Println(digestMailto(*mailto, subject, body.String()))
+		return nil
+	case *outPath == "-":
+		_, err := io.Copy(os.Stdout, &body)
+		return err
+	default:
+		if err := os.MkdirAll(filepath.Dir(*outPath), 0o755); err != nil {
+			return err
+		}
+		return os.WriteFile(*outPath, body.Bytes(), 0o644)
+	}
+}
+
+// digestConjunctions searches each spacecraft's path from hp over the
+// lookahead for a solar conjunction. A failed query leaves that spacecraft
+// out.
+func digestConjunctions(codes []string, now time.Time, lookahead time.Duration, hp ephem.RADecProvider) []dsn.Conjunction {
+	seen := make(map[ephem.TargetID]bool)
+	var conjunctions []dsn.Conjunction
+	for _, code := range codes {
+		naifID := ephem.GetNAIFIDByName(code)
+		if naifID == 0 || seen[naifID] {
+			continue
+		}
+		seen[naifID] = true
+
+		samples, err := hp.GetRADecPath(naifID, now, now.Add(lookahead), dsn.ConjunctionStep)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: conjunctions for %s: %v\n", code, err)
+			continue
+		}
+		if c, ok := dsn.FindConjunction(code, samples); ok {
+			conjunctions = append(conjunctions, c)
+		}
+	}
+	return conjunctions
+}
+
+// digestMessage formats the digest as a plain-text email.
+func digestMessage(from string, to []string, subject, body string, date time.Time) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	return b.Bytes()
+}
+
+// digestMailto builds a mailto: link that opens the digest in a mail
+// client. Spaces are %20, since mail clients don't read + as one.
+func digestMailto(addr, subject, body string) string {
+	escape := func(s string) string { return strings.ReplaceAll(url.QueryEscape(s), "+", "%20") }
+	return "mailto:" + url.PathEscape(addr) + "?subject=" + escape(subject) + "&body=" + escape(body)
+}
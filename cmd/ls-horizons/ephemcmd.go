@@ -0,0 +1,157 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/astro"
+	"github.com/litescript/ls-horizons/internal/dsn"
+	"github.com/litescript/ls-horizons/internal/ephem"
+)
+
+// runEphemCmd implements "ls-horizons ephem <target> [--at time]
+// [--observer site]": a one-shot position lookup for any registered target.
+func runEphemCmd(args []string) error {
+	fs := flag.NewFlagSet("ephem", flag.ContinueOnError)
+	at := fs.String("at", "now", "Time: now, RFC 3339, or \"YYYY-MM-DD HH:MM\" (UTC)")
+	site := fs.String("observer", "goldstone", "Observer: goldstone, canberra, madrid, a DSS antenna (e.g. DSS-43), or LAT,LON")
+	fs.Usage = func() {
+		out := fs.Output()
+		fmt.Fprintf(out, "Usage: %s ephem <target> [--at time] [--observer site]\n\n", os.Args[0])
+		fmt.Fprintln(out, "Target is a DSN code (VGR1), mission name, or NAIF ID (-31).")
+		fs.PrintDefaults()
+	}
+
+	// Accept the target before or after the flags. A leading negative NAIF
+	// ID (-31) is a target, not a flag.
+	var name string
+	if len(args) > 0 && !isFlagArg(args[0]) {
+		name, args = args[0], args[1:]
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if name == "" {
+		if fs.NArg() == 0 {
+			fs.Usage()
+			return fmt.Errorf("missing target")
+		}
+		name = fs.Arg(0)
+		if err := fs.Parse(fs.Args()[1:]); err != nil {
+			return err
+		}
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+
+	target, err := resolveTarget(name)
+	if err != nil {
+		return err
+	}
+	t, err := parseLookupTime(*at, time.Now())
+	if err != nil {
+		return err
+	}
+	cfg, err := subcommandConfig()
+	if err != nil {
+		return err
+	}
+	obs, err := parseObserver(*site, cfg.Refraction)
+	if err != nil {
+		return err
+	}
+	res, err := newHorizons(cfg.Usage).Lookup(target, t, obs)
+	if err != nil {
+		return err
+	}
+	writeLookup(os.Stdout, res)
+	return nil
+}
+
+// isFlagArg reports whether arg looks like a flag rather than a target.
+func isFlagArg(arg string) bool {
+	if !strings.HasPrefix(arg, "-") {
+		return false
+	}
+	_, err := strconv.Atoi(arg)
+	return err != nil
+}
+
+// resolveTarget finds a registered target by DSN code, name, or NAIF ID.
+func resolveTarget(s string) (ephem.TargetInfo, error) {
+	if t, ok := ephem.GetTargetByCode(strings.ToUpper(s)); ok {
+		return t, nil
+	}
+	if t, ok := ephem.GetTargetByName(s); ok {
+		return t, nil
+	}
+	if id, err := strconv.Atoi(s); err == nil {
+		if t, ok := ephem.GetTargetByNAIF(ephem.TargetID(id)); ok {
+			return t, nil
+		}
+	}
+	return ephem.TargetInfo{}, fmt.Errorf("unknown target %q", s)
+}
+
+// parseLookupTime accepts "now", RFC 3339, or "YYYY-MM-DD HH:MM" in UTC.
+func parseLookupTime(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || strings.EqualFold(s, "now") {
+		return now.UTC(), nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.UTC(), nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02T15:04", "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("time %q: want now, RFC 3339, or YYYY-MM-DD HH:MM (UTC)", s)
+}
+
+// parseObserver resolves a DSN complex, a DSS antenna, or a LAT,LON pair,
+// refracting its elevations if refraction is set.
+func parseObserver(s string, refraction bool) (astro.Observer, error) {
+	s = strings.TrimSpace(s)
+	if strings.Contains(s, ",") {
+		obs, err := parseLatLon(s, refraction)
+		obs.Name = s
+		return obs, err
+	}
+	if _, ok := dsn.GetAntennaSite(s); ok {
+		obs := dsn.ObserverForAntenna(s, "")
+		obs.Refraction = refraction
+		return obs, nil
+	}
+	for id, info := range dsn.KnownComplexes {
+		if strings.EqualFold(s, string(id)) || strings.EqualFold(s, info.Name) ||
+			strings.EqualFold(s, string(id)[:3]) {
+			obs := dsn.ObserverForComplex(id)
+			obs.Refraction = refraction
+			return obs, nil
+		}
+	}
+	return astro.Observer{}, fmt.Errorf("unknown observer %q: want goldstone, canberra, madrid, DSS-nn, or LAT,LON", s)
+}
+
+// writeLookup prints a lookup result as aligned label/value lines.
+func writeLookup(w io.Writer, l ephem.Lookup) {
+	fmt.Fprintf(w, "Target:     %s (%s, NAIF %d)\n", l.Target.Name, l.Target.Code, l.Target.NAIFID)
+	fmt.Fprintf(w, "Time:       %s\n", l.Time.UTC().Format("2006-01-02 15:04:05 UTC"))
+	if l.Observer.AltitudeM != 0 {
+		fmt.Fprintf(w, "Observer:   %s (%.4f, %.4f, %.0f m)\n", l.Observer.Name, l.Observer.LatDeg, l.Observer.LonDeg, l.Observer.AltitudeM)
+	} else {
+		fmt.Fprintf(w, "Observer:   %s (%.4f, %.4f)\n", l.Observer.Name, l.Observer.LatDeg, l.Observer.LonDeg)
+	}
+	fmt.Fprintf(w, "RA/Dec:     %.4f° %+.4f°\n", l.Sky.RAdeg, l.Sky.DecDeg)
+	fmt.Fprintf(w, "Az/El:      %.2f° %+.2f°\n", l.Sky.AzDeg, l.Sky.ElDeg)
+	fmt.Fprintf(w, "Range:      %s\n", dsn.FormatDistance(l.RangeKm))
+	fmt.Fprintf(w, "Light time: %s\n", astro.FormatLightTime(l.LightTime))
+}
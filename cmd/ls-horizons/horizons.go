@@ -0,0 +1,28 @@
+package main
+
+import (
+	"github.com/litescript/ls-horizons/internal/ephem"
+)
+
+// horizonsUsage returns the tracker every Horizons request of the process
+// counts against, so all its providers share one quota: the
+// --horizons-per-hour and --horizons-per-day flags where given, else the
+// [horizons] table, else the default.
+func (c fileConfig) horizonsUsage(explicit map[string]bool) (*ephem.Usage, error) {
+	quota := horizonsQuota
+	if c.HorizonsPerHour != nil && !explicit["horizons-per-hour"] {
+		quota.PerHour = *c.HorizonsPerHour
+	}
+	if c.HorizonsPerDay != nil && !explicit["horizons-per-day"] {
+		quota.PerDay = *c.HorizonsPerDay
+	}
+	if err := quota.Validate(); err != nil {
+		return nil, err
+	}
+	return ephem.NewUsage(quota), nil
+}
+
+// newHorizons returns a Horizons provider counting against usage.
+func newHorizons(usage *ephem.Usage) *ephem.HorizonsProvider {
+	return ephem.NewHorizonsProvider(ephem.WithUsage(usage))
+}
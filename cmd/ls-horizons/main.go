@@ -3,19 +3,32 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"golang.org/x/term"
 
+	"github.com/litescript/ls-horizons/internal/bookmarks"
+	"github.com/litescript/ls-horizons/internal/demo"
 	"github.com/litescript/ls-horizons/internal/dsn"
 	"github.com/litescript/ls-horizons/internal/ephem"
+	"github.com/litescript/ls-horizons/internal/eventlog"
+	"github.com/litescript/ls-horizons/internal/focussync"
 	"github.com/litescript/ls-horizons/internal/logging"
+	"github.com/litescript/ls-horizons/internal/notes"
+	"github.com/litescript/ls-horizons/internal/record"
+	"github.com/litescript/ls-horizons/internal/sandbox"
+	"github.com/litescript/ls-horizons/internal/serve"
+	"github.com/litescript/ls-horizons/internal/sightings"
 	"github.com/litescript/ls-horizons/internal/state"
 	"github.com/litescript/ls-horizons/internal/ui"
 )
@@ -25,6 +38,7 @@ var (
 	summaryMode   bool
 	watchInterval time.Duration
 	snapshotPath  string
+	snapshotFmt   string
 	miniSkyMode   bool
 	nowMode       bool
 	scName        string
@@ -32,35 +46,325 @@ var (
 	beepMode      bool
 	eventsMode    bool
 	ephemMode     string
+	dumpRawPath   string
+	parsePath     string
+	readOnly      bool
+	ecoMode       bool
+	profileName   string
+	layoutName    string
+	charsetName   string
+	langName      string
+	announceSpec  string
+	windowTitle   bool
+	refraction    bool
+	syncFocus     bool
+	syncSocket    string
+	notifyDesktop bool
+	notifyCmd     string
+	notifyEvents  string
+	notifySC      string
+	webhookURL    string
+	notesPath     string
+	bookmarksPath string
+	sightingsPath string
+	eventLogPath  string
+	historyDBPath string
+	eventsSince   time.Duration
+	rareAfter     time.Duration
+	quietAfter    time.Duration
+	divergenceDeg float64
+	divergenceN   int
+	windLimits    = dsn.DefaultWindLimits()
+	horizonsQuota = ephem.DefaultHorizonsQuota()
+	followList    string
+	eventHistory  int
+	timelineSpan  time.Duration
+	passWindow    = dsn.DefaultPassWindow()
+	pprofAddr     string
+	tonightAt     string
+	configPath    string
+	healthModel   string
+	replayPath    string
+	replaySpeed   float64
+	recordMode    bool
+	recordCfg     = record.DefaultConfig()
+	recordMaxMB   int64
+	demoMode      bool
+
+	metricsAddr string
+	serveAddr   string
+	serveCfg    = serve.DefaultConfig()
+
+	// recorder persists every fetch when --record is set (nil otherwise)
+	recorder *record.Recorder
+
+	// journal holds the user's spacecraft notes
+	journal *notes.Store
+
+	// sightingLog remembers when spacecraft were last tracked, for rare
+	// acquisitions (nil for replays and demo data)
+	sightingLog    *sightings.Log
+	sightingsSaved time.Time
+
+	// watchlist narrows output and alerts to the --follow spacecraft
+	watchlist dsn.Watchlist
 )
 
 const (
 	defaultRefresh = 5 * time.Second
 	minRefresh     = 1 * time.Second
 	maxRefresh     = 5 * time.Minute
+
+	// Eco mode floors the DSN refresh and leans on the planet vector
+	// series, fetching all planets in one batch per day.
+	ecoMinRefresh    = 1 * time.Minute
+	ecoPlanetRefresh = 24 * time.Hour
+)
+
+// Snapshot export formats for --format.
+const (
+	formatJSON = "json"
+	formatCSV  = "csv"
+	formatHTML = "html"
 )
 
+// subcommands take their own flags and run instead of the dashboard.
+var subcommands = map[string]func(args []string) error{
+	"backup":       runBackupCmd,
+	"cache":        runCacheCmd,
+	"contention":   runContention,
+	"digest":       runDigest,
+	"ephem":        runEphemCmd,
+	"next-pass":    runNextPass,
+	"observe":      runObserveCmd,
+	"publish":      runPublish,
+	"restore":      runRestoreCmd,
+	"verify-astro": runVerifyAstro,
+	"wait":         runWaitCmd,
+}
+
+// errQuiet makes a subcommand exit 1 without printing an error, for
+// answers like "no pass in the window" that scripts test by exit status.
+var errQuiet = errors.New("no result")
+
 func main() {
 	// Parse flags
 	refresh := flag.Duration("refresh", defaultRefresh, "Data refresh interval (e.g., 5s, 1m)")
 	logLevel := flag.String("log-level", "info", "Log level (debug, info, warn, error)")
+	planetRefresh := flag.Duration("planet-refresh", dsn.PlanetCacheTTL, "Orbit view planet position refresh interval")
+	scRefresh := flag.Duration("spacecraft-refresh", dsn.SpacecraftCacheTTL, "Orbit view spacecraft position refresh interval")
 	flag.BoolVar(&summaryMode, "summary", false, "Print text summary instead of TUI")
 	flag.DurationVar(&watchInterval, "watch", 0, "Repeat fetch at interval (e.g., 30s)")
 	flag.StringVar(&snapshotPath, "snapshot-path", "", "Export JSON snapshot to file (use - for stdout)")
+	flag.StringVar(&snapshotFmt, "format", formatJSON, "Format for --snapshot-path: json, csv (one row per link), or html (static status page)")
 	flag.BoolVar(&miniSkyMode, "mini-sky", false, "Show ASCII mini sky view")
 	flag.BoolVar(&nowMode, "now", false, "Single-line now-playing mode")
 	flag.StringVar(&scName, "sc", "", "Show card for specific spacecraft")
 	flag.BoolVar(&diffMode, "diff", false, "Show only changes between fetches")
 	flag.BoolVar(&beepMode, "beep", false, "Beep on important events (TTY only)")
 	flag.BoolVar(&eventsMode, "events", false, "Show event log")
+	flag.DurationVar(&eventsSince, "events-since", 0, "Print events from --event-log detected within this long ago (e.g. 24h) and exit")
 	flag.StringVar(&ephemMode, "ephem", "auto", "Ephemeris source: horizons, dsn, or auto")
+	flag.StringVar(&dumpRawPath, "dump-raw", "", "Fetch once and save raw DSN XML to file (use - for stdout)")
+	flag.StringVar(&parsePath, "parse", "", "Parse a local DSN XML file and print diagnostics")
+	flag.StringVar(&charsetName, "charset", "auto", "Chart glyphs: braille, ascii, or auto (detect from TERM and locale)")
+	flag.StringVar(&langName, "lang", "auto", "Language of in-app about pages: en, es, or auto (detect from locale)")
+	flag.StringVar(&announceSpec, "announce", "", "Announce focus changes for screen readers and tools: osc, fd:N, or a file/pipe path")
+	flag.BoolVar(&refraction, "refraction", true, "Correct Sky view, --tonight, observe, and ephem elevations for atmospheric refraction, as antennas see them near the horizon; false for airless geometry")
+	flag.BoolVar(&windowTitle, "window-title", true, "Keep the terminal and tmux pane title set to a live status (DSN: 27 links | VGR1 160 bps)")
+	flag.BoolVar(&syncFocus, "sync", false, "Share the focused spacecraft with other ls-horizons instances running with --sync")
+	flag.StringVar(&syncSocket, "sync-socket", focussync.DefaultPath(), "Unix socket where --sync instances meet")
+	flag.BoolVar(&notifyDesktop, "notify", false, "Show desktop notifications for link events (new link, handoff, link lost)")
+	flag.StringVar(&notifyCmd, "notify-cmd", "", "Run this shell command for each link event, with LSH_* variables describing it")
+	flag.StringVar(&notifyEvents, "notify-events", "", "Events to notify, comma-separated (default new_link,handoff,link_lost; also rare_acquisition, complex_quiet, complex_active, wind_risk, pointing_divergence)")
+	flag.StringVar(&notifySC, "notify-sc", "", "Only notify for these spacecraft, comma-separated (e.g. VGR1,JWST)")
+	flag.StringVar(&webhookURL, "webhook-url", "", "POST each link event as JSON to this URL (Slack and Discord incoming webhooks work as is)")
+	flag.StringVar(&followList, "follow", "", "Watchlist: show and alert on only these spacecraft, comma-separated (e.g. VGR1,JWST,MRO); w toggles it in the TUI")
+	flag.IntVar(&eventHistory, "event-history", state.DefaultMaxEvents, "Events kept for the event log (view 5, --events, and the API)")
+	flag.DurationVar(&passWindow.Span, "pass-window", dsn.PassWindowDuration, "How far ahead pass plans look, up to 168h (7 days); longer plans are grouped by day")
+	flag.DurationVar(&passWindow.Step, "pass-step", dsn.PassSampleInterval, "Pass plan sample step, whole minutes up to 1h; finer steps time AOS and LOS more closely")
+	flag.DurationVar(&timelineSpan, "timeline-window", state.DefaultTimelineWindow, "Span of the dashboard utilization timeline (t), sampled once a minute")
+	flag.StringVar(&notesPath, "notes-file", notes.DefaultPath(), "Spacecraft notes journal (JSON Lines); add notes with n in the mission view")
+	flag.StringVar(&bookmarksPath, "bookmarks-file", bookmarks.DefaultPath(), "Bookmarks file (JSON Lines); b bookmarks the moment in the TUI, B browses and revisits")
+	flag.StringVar(&eventLogPath, "event-log", eventlog.DefaultPath(), "Append detected events to this JSON Lines file so they survive restarts (empty disables)")
+	flag.StringVar(&historyDBPath, "history-db", "", "Store link samples, events, and pass plans in this SQLite database for long-term analysis (needs a build with -tags sqlite)")
+	flag.StringVar(&sightingsPath, "sightings-file", sightings.DefaultPath(), "Log of when each spacecraft was last tracked, for RARE_ACQUISITION events")
+	flag.DurationVar(&rareAfter, "rare-after", state.DefaultRareAfter, "Watched time a spacecraft must go untracked for its next acquisition to be rare")
+	flag.Float64Var(&windLimits.Caution, "wind-caution", windLimits.Caution, "Wind speed (km/h) at which an antenna's pass is at risk; overrides the config file")
+	flag.Float64Var(&windLimits.Stow, "wind-stow", windLimits.Stow, "Wind speed (km/h) at which an antenna may stow; overrides the config file")
+	flag.IntVar(&horizonsQuota.PerHour, "horizons-per-hour", horizonsQuota.PerHour, "Self-imposed limit on JPL Horizons requests per hour (0 for none); overrides the config file")
+	flag.IntVar(&horizonsQuota.PerDay, "horizons-per-day", horizonsQuota.PerDay, "Self-imposed limit on JPL Horizons requests per day (0 for none); overrides the config file")
+	flag.DurationVar(&quietAfter, "quiet-after", state.DefaultQuietAfter, "Time a whole complex must track nothing before a COMPLEX_QUIET event (possible outage)")
+	flag.Float64Var(&divergenceDeg, "divergence-deg", dsn.DefaultDivergenceDeg, "Degrees a dish may point from its spacecraft's ephemeris before it counts as diverging")
+	flag.IntVar(&divergenceN, "divergence-fetches", dsn.DefaultDivergenceFetches, "Fetches in a row a dish must diverge before a POINTING_DIVERGENCE event")
+	flag.BoolVar(&readOnly, "read-only", false, "Disable disk writes and all network access except NASA/JPL endpoints")
+	flag.BoolVar(&readOnly, "sandbox", false, "Alias for --read-only")
+	flag.BoolVar(&ecoMode, "eco", false, "Low-power mode: no animation, slower refresh, fewer Horizons calls")
+	flag.StringVar(&profileName, "profile", "", "Named config profile to layer over the config file: profiles/NAME.toml beside it (e.g. ops-wall, laptop, radio)")
+	flag.StringVar(&layoutName, "layout", "default", "Layout: default, or small (alias compact) for 80x24 and 40-column displays")
+	flag.StringVar(&tonightAt, "tonight", "", "Show spacecraft above your horizon tonight from LAT,LON[,HEIGHT] (e.g. 34.2,-118.2; height in meters above the terrain lowers the horizon)")
+	flag.StringVar(&configPath, "config", defaultConfigPath(), "Config file (TOML); flags override its settings")
+	flag.StringVar(&healthModel, "health-model", "", "Link health model: default, elevation, or band-rate (overrides the config file)")
+	flag.StringVar(&replayPath, "replay", "", "Play back JSON snapshots from a file or directory instead of the live feed")
+	flag.Float64Var(&replaySpeed, "replay-speed", 1, "Replay playback speed (e.g. 10 for ten times faster)")
+	flag.BoolVar(&demoMode, "demo", false, "Run offline on bundled DSN data and synthetic ephemerides (for screenshots and development)")
+	flag.BoolVar(&recordMode, "record", false, "Save every fetched snapshot to --record-dir (gzipped JSON Lines, one file per day)")
+	flag.StringVar(&recordCfg.Dir, "record-dir", recordCfg.Dir, "Directory for --record snapshots")
+	flag.DurationVar(&recordCfg.MaxAge, "record-max-age", recordCfg.MaxAge, "Delete recordings older than this (0 keeps them)")
+	flag.Int64Var(&recordMaxMB, "record-max-size", recordCfg.MaxBytes>>20, "Delete the oldest recordings beyond this many MB (0 for no limit)")
+	flag.StringVar(&metricsAddr, "metrics-addr", "", "Serve Prometheus metrics at ADDR/metrics (e.g. localhost:9120)")
+	flag.StringVar(&serveAddr, "serve", "", "Serve the JSON API on ADDR instead of running the TUI (e.g. localhost:8080)")
+	serveCfg.RegisterSecurityFlags(flag.CommandLine)
+	flag.StringVar(&pprofAddr, "pprof", "", "Serve net/http/pprof on this address (e.g. localhost:6060)")
+	flag.Usage = usage
 	flag.Parse()
 
+	// Read-only mode: block writes and restrict outbound HTTP to the DSN
+	// feed and Horizons for every client that uses the default transport
+	if readOnly {
+		sandbox.Enable()
+		http.DefaultTransport = sandbox.Transport(http.DefaultTransport)
+	}
+
+	// Subcommands parse their own flags; global flags such as --read-only
+	// go before the subcommand name, where parsing stopped
+	if args := flag.Args(); len(args) > 0 {
+		if run, ok := subcommands[args[0]]; ok {
+			if err := run(args[1:]); err != nil {
+				if err != flag.ErrHelp && err != errQuiet {
+					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				}
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	// Debug modes: operate on a single raw feed and exit
+	if parsePath != "" {
+		if err := runParse(parsePath); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Config file and profile settings apply where no flag was given.
+	explicit := flagsSet(flag.CommandLine)
+	cfg, err := loadConfig(configPath, profileName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if cfg.Refresh > 0 && !explicit["refresh"] {
+		*refresh = cfg.Refresh
+	}
+	if cfg.Ephem != "" && !explicit["ephem"] {
+		ephemMode = cfg.Ephem
+	}
+	if cfg.EventHistory > 0 && !explicit["event-history"] {
+		eventHistory = cfg.EventHistory
+	}
+	passWindow = cfg.planWindow(explicit)
+	if cfg.TimelineWindow > 0 && !explicit["timeline-window"] {
+		timelineSpan = cfg.TimelineWindow
+	}
+	if cfg.WindCaution > 0 && !explicit["wind-caution"] {
+		windLimits.Caution = cfg.WindCaution
+	}
+	if cfg.WindStow > 0 && !explicit["wind-stow"] {
+		windLimits.Stow = cfg.WindStow
+	}
+	if cfg.WindowTitle != nil && !explicit["window-title"] {
+		windowTitle = *cfg.WindowTitle
+	}
+	refraction = cfg.useRefraction(explicit)
+	if cfg.EventLog != "" && !explicit["event-log"] {
+		eventLogPath = cfg.EventLog
+	}
+	if cfg.HistoryDB != "" && !explicit["history-db"] {
+		historyDBPath = cfg.HistoryDB
+	}
+	if cfg.Layout != "" && !explicit["layout"] {
+		layoutName = cfg.Layout
+	}
+	if cfg.Follow != "" && !explicit["follow"] {
+		followList = cfg.Follow
+	}
+	health, err := cfg.healthModel(healthModel)
+	if err == nil {
+		err = windLimits.Validate()
+	}
+	var horizonsUsage *ephem.Usage
+	if err == nil {
+		horizonsUsage, err = cfg.horizonsUsage(explicit)
+	}
+	if err == nil {
+		err = cfg.PassElevations.Validate()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	watchlist = dsn.ParseWatchlist(followList)
+
+	// Event history mode: read back the event log and exit
+	if eventsSince != 0 {
+		if err := runEventsSince(os.Stdout, eventLogPath, eventsSince, time.Now()); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	journal, err = notes.Open(notesPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	notifyCfg, err := cfg.notifySettings()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	switch {
+	case snapshotFmt != formatJSON && snapshotFmt != formatCSV && snapshotFmt != formatHTML:
+		fmt.Fprintf(os.Stderr, "Error: --format must be %s, %s, or %s\n", formatJSON, formatCSV, formatHTML)
+		os.Exit(1)
+	case snapshotFmt != formatJSON && scName != "":
+		fmt.Fprintf(os.Stderr, "Error: --format %s exports snapshots; --sc cards are JSON only (--follow narrows the html page)\n", snapshotFmt)
+		os.Exit(1)
+	case rareAfter <= 0:
+		fmt.Fprintln(os.Stderr, "Error: --rare-after must be positive")
+		os.Exit(1)
+	case quietAfter <= 0:
+		fmt.Fprintln(os.Stderr, "Error: --quiet-after must be positive")
+		os.Exit(1)
+	case divergenceDeg <= 0:
+		fmt.Fprintln(os.Stderr, "Error: --divergence-deg must be positive")
+		os.Exit(1)
+	case divergenceN <= 0:
+		fmt.Fprintln(os.Stderr, "Error: --divergence-fetches must be positive")
+		os.Exit(1)
+	case eventHistory <= 0:
+		fmt.Fprintln(os.Stderr, "Error: --event-history must be positive")
+		os.Exit(1)
+	case timelineSpan < time.Minute:
+		fmt.Fprintln(os.Stderr, "Error: --timeline-window must be at least 1m")
+		os.Exit(1)
+	}
+	if err := passWindow.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: --pass-window/--pass-step: %v\n", err)
+		os.Exit(1)
+	}
+
 	// Validate refresh interval
-	if *refresh < minRefresh {
-		*refresh = minRefresh
-	} else if *refresh > maxRefresh {
-		*refresh = maxRefresh
+	*refresh = clampRefresh(*refresh)
+	if ecoMode {
+		*planetRefresh = max(*planetRefresh, ecoPlanetRefresh)
 	}
 
 	// Set up logging
@@ -78,44 +382,303 @@ func main() {
 		cancel()
 	}()
 
+	if pprofAddr != "" {
+		startPprof(ctx, pprofAddr, logger)
+	}
+
 	// Initialize components
 	stateCfg := state.DefaultConfig()
 	stateCfg.RefreshInterval = *refresh
+	stateCfg.MaxEvents = eventHistory
+	stateCfg.TimelineWindow = timelineSpan
+	stateCfg.RareAfter = rareAfter
+	stateCfg.QuietAfter = quietAfter
+	stateCfg.DivergenceDeg = divergenceDeg
+	stateCfg.DivergenceFetches = divergenceN
+	stateCfg.PassWindow = passWindow
+	stateCfg.HealthModel = health
+	stateCfg.WindLimits = windLimits
+	stateCfg.PassElevations = cfg.PassElevations
 	stateMgr := state.NewManager(stateCfg)
 
 	fetcher := dsn.NewFetcher()
+	var demoSrc *demo.Source
+	if demoMode {
+		if replayPath != "" {
+			fmt.Fprintln(os.Stderr, "Error: --demo and --replay both replace the live feed; use one")
+			os.Exit(1)
+		}
+		demoSrc, err = demo.New(time.Now())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fetcher = demoSrc.Fetcher()
+		logger.Info("Demo mode: bundled DSN data and synthetic ephemerides")
+	}
+	radec := radecSource(demoSrc, horizonsUsage)
 
-	// Headless mode: no TUI
+	// Alerts stop when the TUI leaves the live feed for a bookmark
+	alertCtx, stopAlerts := context.WithCancel(ctx)
+	defer stopAlerts()
+	if notifyCfg.Enabled() {
+		startNotifier(alertCtx, notifyCfg, stateMgr, logger)
+	}
+
+	if metricsAddr != "" {
+		cfg := serveCfg
+		cfg.Addr = metricsAddr
+		if err := cfg.Load(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: metrics: %v\n", err)
+			os.Exit(1)
+		}
+		startMetrics(ctx, cfg, stateMgr, logger)
+	}
+
+	// One-shot modes print once and exit, which would take the API down
+	// as soon as it was up
 	headless := summaryMode || snapshotPath != "" || miniSkyMode || nowMode || scName != "" || diffMode || eventsMode
+	if serveAddr != "" && (dumpRawPath != "" || tonightAt != "" || headless && watchInterval == 0) {
+		fmt.Fprintln(os.Stderr, "Error: --serve keeps running; combine it with headless modes only under --watch, and not with --dump-raw or --tonight")
+		os.Exit(1)
+	}
+	if serveAddr != "" {
+		cfg := serveCfg
+		cfg.Addr = serveAddr
+		if err := cfg.Load(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: serve: %v\n", err)
+			os.Exit(1)
+		}
+		startAPI(ctx, cfg, stateMgr, radec, logger)
+	}
+
+	if dumpRawPath != "" {
+		if err := runDumpRaw(ctx, fetcher, dumpRawPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if tonightAt != "" {
+		obs, err := parseLatLon(tonightAt, refraction)
+		if err == nil {
+			err = runTonight(ctx, fetcher, radec, obs, logger)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Replay mode: recorded snapshots stand in for the live feed
+	var replay []*dsn.SnapshotExport
+	if replayPath != "" {
+		if replaySpeed <= 0 {
+			fmt.Fprintln(os.Stderr, "Error: --replay-speed must be positive")
+			os.Exit(1)
+		}
+		replay, err = dsn.LoadSnapshots(replayPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		logger.Info("Replaying %d snapshots from %s", len(replay), replayPath)
+	}
+
+	// Only the live feed counts as a sighting
+	if replay == nil && demoSrc == nil {
+		sightingLog, err = sightings.Open(sightingsPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		stateMgr.SetSightings(sightingLog)
+		defer saveSightings(time.Time{}, logger)
+		if eventLogPath != "" && !readOnly {
+			startEventLog(alertCtx, eventLogPath, stateMgr, logger)
+		}
+		// An explicit --history-db can't be honored read-only; one from
+		// the config file is skipped with a warning
+		if historyDBPath != "" && readOnly {
+			err := sandbox.CheckWrite(historyDBPath)
+			if explicit["history-db"] {
+				fmt.Fprintf(os.Stderr, "Error: --history-db: %v\n", err)
+				os.Exit(1)
+			}
+			logger.Warn("History database not opened: %v", err)
+		}
+		if historyDBPath != "" && !readOnly {
+			historyDB, err := state.OpenHistoryDB(historyDBPath)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			go func() {
+				defer historyDB.Close()
+				historyDB.Run(alertCtx, stateMgr, logger)
+			}()
+		}
+	}
+
+	if recordMode && replay == nil {
+		recordCfg.MaxBytes = recordMaxMB << 20
+		recorder, err = record.New(recordCfg)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: record: %v\n", err)
+			os.Exit(1)
+		}
+		logger.Info("Recording snapshots to %s", recorder.Dir())
+	}
+
+	// Headless mode: no TUI
+	if headless && replay != nil {
+		fmt.Fprintln(os.Stderr, "Error: --replay plays back in the TUI and can't be combined with headless modes")
+		os.Exit(1)
+	}
 	if headless {
 		runHeadless(ctx, fetcher, stateMgr, logger)
 		return
 	}
 
+	// Serve mode: keep the state current for API clients, without a TUI.
+	// Nothing reads the mailbox; it only ever holds the latest update.
+	if serveAddr != "" {
+		mailbox := ui.NewMailbox()
+		if replay != nil {
+			runReplayLoop(ctx, replay, replaySpeed, stateMgr, mailbox, logger)
+			<-ctx.Done()
+		} else {
+			runFetchLoop(ctx, fetcher, stateMgr, mailbox, nil, logger)
+		}
+		return
+	}
+
 	// Create ephemeris provider based on mode
 	var ephemProvider ephem.Provider
 	mode := ephem.ParseMode(ephemMode)
-	switch mode {
-	case ephem.ModeHorizons:
-		ephemProvider = ephem.NewHorizonsProvider()
+	switch {
+	case demoSrc != nil:
+		ephemProvider = demoSrc.Ephemeris()
+		logger.Info("Using demo ephemeris")
+	case mode == ephem.ModeHorizons:
+		ephemProvider = newHorizons(horizonsUsage)
 		logger.Info("Using JPL Horizons ephemeris")
-	case ephem.ModeDSN:
+	case mode == ephem.ModeDSN:
 		ephemProvider = ephem.NewDSNProvider()
 		logger.Info("Using DSN-derived ephemeris")
-	case ephem.ModeAuto:
+	case mode == ephem.ModeAuto:
 		// Try Horizons, will fall back gracefully if unavailable
-		ephemProvider = ephem.NewHorizonsProvider()
+		ephemProvider = newHorizons(horizonsUsage)
 		logger.Info("Using auto ephemeris mode (Horizons with fallback)")
 	}
 
+	settings, err := cfg.uiSettings(*refresh)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
 	// Create TUI model with ephemeris provider
-	model := ui.New(stateMgr, ephemProvider)
+	model := ui.New(stateMgr, ephemProvider).
+		SetSolarSystemConfig(dsn.SolarSystemConfig{
+			PlanetRefresh:     *planetRefresh,
+			SpacecraftRefresh: *scRefresh,
+		}).
+		SetEcoMode(ecoMode).
+		SetRefraction(refraction).
+		SetHorizonsUsage(horizonsUsage).
+		SetProfile(ui.ParseProfile(layoutName)).
+		SetCharset(ui.ParseCharset(charsetName)).
+		SetLanguage(ui.ParseLanguage(langName)).
+		SetNotes(journal).
+		SetWatchlist(watchlist).
+		SetSettings(settings).
+		SetSettingsLoader(func() (ui.Settings, error) {
+			cfg, err := loadConfig(configPath, profileName)
+			if err != nil {
+				return ui.Settings{}, err
+			}
+			interval := defaultRefresh
+			if explicit["refresh"] {
+				interval = *refresh
+			} else if cfg.Refresh > 0 {
+				interval = cfg.Refresh
+			}
+			return cfg.uiSettings(clampRefresh(interval))
+		})
+
+	// Background results go through a latest-only mailbox so a slow
+	// terminal doesn't build a backlog of stale snapshots
+	mailbox := ui.NewMailbox()
+	model = model.SetMailbox(mailbox)
+	marks, err := bookmarks.Open(bookmarksPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	feeds := newFeedSwitcher(ctx)
+	model = model.SetBookmarks(marks, bookmarkPlayer(feeds, stopAlerts, stateMgr, mailbox, logger))
+
+	// The command palette can ask the fetch loop for an early fetch; a
+	// request made while one is pending is dropped
+	refreshNow := make(chan struct{}, 1)
+	model = model.SetRefresher(func() {
+		select {
+		case refreshNow <- struct{}{}:
+		default:
+		}
+	})
+	if replay != nil {
+		model = model.SetReplay(replaySpeed)
+	}
+	if announceSpec != "" {
+		announcer, f, err := openAnnouncer(announceSpec)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if f != nil {
+			defer f.Close()
+		}
+		model = model.SetAnnouncer(announcer)
+	}
+	var bus *focussync.Bus
+	if syncFocus {
+		if err := sandbox.CheckWrite(syncSocket); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --sync: %v\n", err)
+			os.Exit(1)
+		}
+		bus = focussync.New(syncSocket, logger)
+		model = model.SetFocusSharing(bus.Publish)
+	}
+
+	if windowTitle {
+		model = model.SetWindowTitle(true)
+		// Give the terminal its own title back on exit
+		fmt.Print(ui.PushTitle)
+		defer fmt.Print(ui.PopTitle)
+	}
 
 	// Create Bubble Tea program
 	p := tea.NewProgram(model, tea.WithAltScreen())
+	if bus != nil {
+		go bus.Run(ctx)
+		go followRemoteFocus(ctx, bus, p)
+	}
 
-	// Start fetch loop in background
-	go runFetchLoop(ctx, fetcher, stateMgr, p, logger)
+	// Start mailbox pump and fetch loop in background
+	go mailbox.Run(ctx, p.Send)
+	if replay != nil {
+		feeds.start(func(ctx context.Context) {
+			runReplayLoop(ctx, replay, replaySpeed, stateMgr, mailbox, logger)
+		})
+	} else {
+		feeds.start(func(ctx context.Context) {
+			runFetchLoop(ctx, fetcher, stateMgr, mailbox, refreshNow, logger)
+		})
+	}
 
 	// Run TUI (blocks until quit)
 	if _, err := p.Run(); err != nil {
@@ -124,20 +687,21 @@ func main() {
 	}
 }
 
-func runFetchLoop(ctx context.Context, fetcher *dsn.Fetcher, stateMgr *state.Manager, p *tea.Program, logger *logging.Logger) {
-	interval := stateMgr.RefreshInterval()
-
+// runFetchLoop fetches on aligned refresh boundaries, and early whenever
+// refreshNow receives (nil = never).
+func runFetchLoop(ctx context.Context, fetcher *dsn.Fetcher, stateMgr *state.Manager, mailbox *ui.Mailbox, refreshNow <-chan struct{}, logger *logging.Logger) {
 	// Calculate next aligned refresh time and set it before initial fetch
-	next := nextAlignedTime(time.Now(), interval)
+	next := state.NextAlignedRefresh(time.Now(), stateMgr.RefreshInterval())
 	stateMgr.SetNextRefresh(next)
 
 	// Do initial fetch immediately
-	doFetch(ctx, fetcher, stateMgr, p, logger)
+	doFetch(ctx, fetcher, stateMgr, mailbox, logger)
 
 	for {
-		// Calculate time until next aligned refresh
+		// Calculate time until next aligned refresh; the interval is
+		// re-read so a config reload takes effect on the next cycle
 		now := time.Now()
-		next = nextAlignedTime(now, interval)
+		next = state.NextAlignedRefresh(now, stateMgr.RefreshInterval())
 		stateMgr.SetNextRefresh(next)
 
 		// Create timer for the wait duration
@@ -150,52 +714,38 @@ func runFetchLoop(ctx context.Context, fetcher *dsn.Fetcher, stateMgr *state.Man
 			logger.Debug("Fetch loop shutting down")
 			return
 		case <-timer.C:
-			doFetch(ctx, fetcher, stateMgr, p, logger)
+			doFetch(ctx, fetcher, stateMgr, mailbox, logger)
+		case <-refreshNow:
+			timer.Stop()
+			logger.Debug("Refresh requested")
+			doFetch(ctx, fetcher, stateMgr, mailbox, logger)
 		}
 	}
 }
 
-// nextAlignedTime calculates the next refresh time aligned to wall clock.
-// For example, with 5s interval, refreshes happen at :00, :05, :10, etc.
-func nextAlignedTime(now time.Time, interval time.Duration) time.Time {
-	// Get the interval in seconds
-	intervalSec := int64(interval.Seconds())
-	if intervalSec < 1 {
-		intervalSec = 1
+// clampRefresh limits a refresh interval to the supported range, and to
+// the eco-mode floor when eco mode is on.
+func clampRefresh(d time.Duration) time.Duration {
+	d = min(max(d, minRefresh), maxRefresh)
+	if ecoMode {
+		d = max(d, ecoMinRefresh)
 	}
-
-	// Calculate seconds since start of minute
-	sec := int64(now.Second())
-	nsec := now.Nanosecond()
-
-	// Find next aligned second
-	nextSec := ((sec / intervalSec) + 1) * intervalSec
-
-	// Build the next time
-	next := time.Date(now.Year(), now.Month(), now.Day(),
-		now.Hour(), now.Minute(), 0, 0, now.Location())
-
-	// Add the aligned seconds (may roll over to next minute)
-	next = next.Add(time.Duration(nextSec) * time.Second)
-
-	// If we're very close to the boundary (within 100ms), skip to the next one
-	if next.Sub(now) < 100*time.Millisecond {
-		next = next.Add(interval)
-	}
-
-	_ = nsec // unused but kept for clarity
-	return next
+	return d
 }
 
-func doFetch(ctx context.Context, fetcher *dsn.Fetcher, stateMgr *state.Manager, p *tea.Program, logger *logging.Logger) {
+func doFetch(ctx context.Context, fetcher *dsn.Fetcher, stateMgr *state.Manager, mailbox *ui.Mailbox, logger *logging.Logger) {
 	logger.Debug("Fetching DSN data...")
 
 	result := fetcher.Fetch(ctx)
+	if ctx.Err() != nil {
+		// Cut short by shutdown or a switch to another feed, not a failure
+		return
+	}
 
 	if result.Error != nil {
-		logger.Error("Fetch failed: %v", result.Error)
+		logger.Error("Fetch failed: %s", dsn.DescribeError(result.Error))
 		stateMgr.Update(nil, result.Duration, result.Error)
-		p.Send(ui.ErrorMsg{Error: result.Error})
+		mailbox.PostFetch(ui.ErrorMsg{Error: result.Error})
 		return
 	}
 
@@ -203,13 +753,93 @@ func doFetch(ctx context.Context, fetcher *dsn.Fetcher, stateMgr *state.Manager,
 		len(result.Data.Stations), len(result.Data.Links), result.Duration)
 
 	stateMgr.Update(result.Data, result.Duration, nil)
-	p.Send(ui.DataUpdateMsg{Snapshot: stateMgr.Snapshot()})
+	snap := stateMgr.Snapshot()
+	recordFetch(snap, logger)
+	saveSightings(snap.LastFetch, logger)
+	mailbox.PostFetch(ui.DataUpdateMsg{Snapshot: snap})
+}
+
+// recordFetch saves the latest fetch when --record is on. Failures are
+// logged and don't interrupt the session.
+func recordFetch(snap state.Snapshot, logger *logging.Logger) {
+	if recorder == nil {
+		return
+	}
+	if err := recorder.Record(snap.Data, snap.LastFetch); err != nil {
+		logger.Warn("Record snapshot: %v", err)
+	}
+}
+
+// sightingsSaveInterval is how often the sighting log is written while
+// running; it is also written on exit.
+const sightingsSaveInterval = 5 * time.Minute
+
+// saveSightings writes the sighting log when sightingsSaveInterval has
+// passed since the last write, or always for a zero now. Failures are
+// logged and don't interrupt the session.
+func saveSightings(now time.Time, logger *logging.Logger) {
+	if sightingLog == nil || readOnly || (!now.IsZero() && now.Sub(sightingsSaved) < sightingsSaveInterval) {
+		return
+	}
+	if err := sightingLog.Save(); err != nil {
+		logger.Warn("Save sightings: %v", err)
+		return
+	}
+	sightingsSaved = now
+}
+
+// startEventLog appends detected events to the log at path until ctx is
+// cancelled. Only the live feed is logged, so it stops with alerts when
+// the TUI leaves it for a bookmark.
+func startEventLog(ctx context.Context, path string, stateMgr *state.Manager, logger *logging.Logger) {
+	updates, cancel := stateMgr.Subscribe()
+	go func() {
+		defer cancel()
+		eventlog.Run(ctx, path, updates, logger)
+	}()
+}
+
+// runEventsSince prints the logged events detected within since of now,
+// newest first.
+func runEventsSince(w io.Writer, path string, since time.Duration, now time.Time) error {
+	if since < 0 {
+		return errors.New("--events-since must be positive")
+	}
+	if path == "" {
+		return errors.New("--events-since needs an --event-log")
+	}
+	events, err := eventlog.Since(path, now.Add(-since))
+	if err != nil {
+		return err
+	}
+	dsn.WriteEvents(w, convertEvents(events), len(events))
+	return nil
+}
+
+// hasNewEvents reports whether events holds any event missing from prev,
+// the events as of the previous fetch.
+func hasNewEvents(prev, events []state.Event) bool {
+	seen := make(map[state.Event]bool, len(prev))
+	for _, e := range prev {
+		seen[e] = true
+	}
+	for _, e := range events {
+		if !seen[e] {
+			return true
+		}
+	}
+	return false
 }
 
 // runHeadless handles all headless modes without starting TUI.
 func runHeadless(ctx context.Context, fetcher *dsn.Fetcher, stateMgr *state.Manager, logger *logging.Logger) {
 	var prevData *dsn.DSNData
+	var prevEvents []state.Event
 	isTTY := term.IsTerminal(int(os.Stdout.Fd()))
+	csvHeaderDone := false
+	// Snapshots exported to stdout form one machine-readable stream
+	// (NDJSON or CSV) with nothing printed between them
+	stream := snapshotPath == "-" && scName == ""
 
 	outputOnce := func() error {
 		result := fetcher.Fetch(ctx)
@@ -219,6 +849,9 @@ func runHeadless(ctx context.Context, fetcher *dsn.Fetcher, stateMgr *state.Mana
 
 		stateMgr.Update(result.Data, result.Duration, nil)
 		snap := stateMgr.Snapshot()
+		recordFetch(snap, logger)
+		saveSightings(snap.LastFetch, logger)
+		snap = snap.Follow(watchlist)
 
 		// Diff mode
 		if diffMode {
@@ -240,28 +873,43 @@ func runHeadless(ctx context.Context, fetcher *dsn.Fetcher, stateMgr *state.Mana
 
 		// Spacecraft card mode
 		if scName != "" {
+			if snapshotPath != "" {
+				card := dsn.FindSpacecraftCard(snap.Data, scName)
+				if card == nil {
+					return fmt.Errorf("spacecraft %q not currently tracked", scName)
+				}
+				card.Notes = journal.For(scName)
+				return writeOutput(snapshotPath, card.WriteJSON)
+			}
 			events := convertEvents(snap.Events)
 			dsn.WriteSpacecraftCard(os.Stdout, snap.Data, scName, events)
+			notes.Write(os.Stdout, journal.For(scName), time.Local)
 			return nil
 		}
 
-		// Export JSON if requested
+		// Export snapshot if requested
 		if snapshotPath != "" {
 			export := dsn.ExportSnapshot(snap.Data, snap.LastFetch)
-			if snapshotPath == "-" {
-				if err := export.WriteJSON(os.Stdout); err != nil {
-					return fmt.Errorf("write JSON to stdout: %w", err)
-				}
-			} else {
-				f, err := os.Create(snapshotPath)
-				if err != nil {
-					return fmt.Errorf("create snapshot file: %w", err)
-				}
-				defer f.Close()
-				if err := export.WriteJSON(f); err != nil {
-					return fmt.Errorf("write JSON to file: %w", err)
+			write := export.WriteJSON
+			switch {
+			case snapshotFmt == formatJSON && stream && watchInterval > 0:
+				write = export.WriteJSONLine
+			case snapshotFmt == formatCSV:
+				// A file is rewritten on every fetch; stdout is one
+				// stream, so it gets a single header
+				header := snapshotPath != "-" || !csvHeaderDone
+				csvHeaderDone = true
+				write = func(w io.Writer) error { return export.WriteCSV(w, header) }
+			case snapshotFmt == formatHTML:
+				// The page reloads itself as often as --watch rewrites it
+				events := convertEvents(snap.Events)
+				write = func(w io.Writer) error {
+					return dsn.WriteHTML(w, snap.Data, snap.LastFetch, events, watchInterval)
 				}
 			}
+			if err := writeOutput(snapshotPath, write); err != nil {
+				return err
+			}
 		}
 
 		// Print summary table if requested
@@ -282,25 +930,22 @@ func runHeadless(ctx context.Context, fetcher *dsn.Fetcher, stateMgr *state.Mana
 			dsn.WriteEvents(os.Stdout, events, 10)
 		}
 
-		// Beep check for events (only in non-diff mode)
-		if beepMode && isTTY && len(snap.Events) > 0 {
-			// Beep if there are recent events (within last interval)
-			for _, e := range snap.Events {
-				if time.Since(e.Timestamp) < watchInterval+time.Second {
-					fmt.Print("\a")
-					break
-				}
-			}
+		// Beep on events this fetch detected (only in non-diff mode).
+		// Events carry feed time, which can lag the clock, so they are
+		// told apart from the previous fetch's rather than by age.
+		if beepMode && isTTY && hasNewEvents(prevEvents, snap.Events) {
+			fmt.Print("\a")
 		}
 
 		prevData = snap.Data
+		prevEvents = snap.Events
 		return nil
 	}
 
 	// Single run
 	if watchInterval == 0 {
 		if err := outputOnce(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Error: %s\n", dsn.DescribeError(err))
 			os.Exit(1)
 		}
 		return
@@ -308,7 +953,7 @@ func runHeadless(ctx context.Context, fetcher *dsn.Fetcher, stateMgr *state.Mana
 
 	// Watch mode: repeat at interval
 	if err := outputOnce(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error: %s\n", dsn.DescribeError(err))
 	}
 
 	ticker := time.NewTicker(watchInterval)
@@ -319,16 +964,109 @@ func runHeadless(ctx context.Context, fetcher *dsn.Fetcher, stateMgr *state.Mana
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
-			if !diffMode && !nowMode {
+			if !diffMode && !nowMode && !stream {
 				fmt.Println() // Blank line between outputs (except diff/now mode)
 			}
 			if err := outputOnce(); err != nil {
-				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				fmt.Fprintf(os.Stderr, "Error: %s\n", dsn.DescribeError(err))
 			}
 		}
 	}
 }
 
+// runParse parses a local DSN XML file and pretty-prints it with diagnostics.
+func runParse(path string) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read XML file: %w", err)
+	}
+
+	data, err := dsn.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	dsn.WriteParseReport(os.Stdout, data)
+	return nil
+}
+
+// runDumpRaw fetches the DSN feed once and saves the raw XML unmodified.
+func runDumpRaw(ctx context.Context, fetcher *dsn.Fetcher, path string) error {
+	if err := sandbox.CheckWrite(path); err != nil {
+		return err
+	}
+
+	raw, err := fetcher.FetchRaw(ctx)
+	if err != nil {
+		return err
+	}
+
+	if path == "-" {
+		if _, err := os.Stdout.Write(raw); err != nil {
+			return fmt.Errorf("write XML to stdout: %w", err)
+		}
+		return nil
+	}
+
+	if err := os.WriteFile(path, raw, 0o644); err != nil {
+		return fmt.Errorf("write XML file: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Wrote %d bytes from %s to %s\n", len(raw), fetcher.URL(), path)
+	return nil
+}
+
+// writeOutput writes an export with write to path, or to stdout if path
+// is "-".
+func writeOutput(path string, write func(io.Writer) error) error {
+	if path == "-" {
+		if err := write(os.Stdout); err != nil {
+			return fmt.Errorf("write to stdout: %w", err)
+		}
+		return nil
+	}
+
+	if err := sandbox.CheckWrite(path); err != nil {
+		return err
+	}
+
+	// A regular file is replaced whole, so a web server or a reader
+	// polling it never sees half a snapshot. Pipes and devices are
+	// written in place.
+	if fi, err := os.Stat(path); err == nil && !fi.Mode().IsRegular() {
+		f, err := os.OpenFile(path, os.O_WRONLY, 0)
+		if err != nil {
+			return fmt.Errorf("open snapshot file: %w", err)
+		}
+		defer f.Close()
+		if err := write(f); err != nil {
+			return fmt.Errorf("write snapshot file: %w", err)
+		}
+		return nil
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("create snapshot file: %w", err)
+	}
+	defer os.Remove(f.Name())
+	if err := write(f); err != nil {
+		f.Close()
+		return fmt.Errorf("write snapshot file: %w", err)
+	}
+	// CreateTemp makes the file 0600; the page is meant to be served
+	if err := f.Chmod(0o644); err != nil {
+		f.Close()
+		return fmt.Errorf("write snapshot file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("write snapshot file: %w", err)
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("replace snapshot file: %w", err)
+	}
+	return nil
+}
+
 // convertEvents converts state.Event to dsn.Event (avoiding import cycle).
 func convertEvents(stateEvents []state.Event) []dsn.Event {
 	events := make([]dsn.Event, len(stateEvents))
@@ -341,6 +1079,12 @@ func convertEvents(stateEvents []state.Event) []dsn.Event {
 			NewStation: e.NewStation,
 			AntennaID:  e.AntennaID,
 			Complex:    e.Complex,
+			LastSeen:   e.LastSeen,
+			Wind:       e.Wind,
+			WindStow:   e.WindStow,
+			Divergence: e.Divergence,
+
+			FeedLatency: e.FeedLatency,
 		}
 	}
 	return events
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/litescript/ls-horizons/internal/logging"
+	"github.com/litescript/ls-horizons/internal/metrics"
+	"github.com/litescript/ls-horizons/internal/serve"
+	"github.com/litescript/ls-horizons/internal/state"
+)
+
+// startMetrics serves Prometheus gauges for the latest state on
+// cfg.Addr/metrics until ctx is cancelled. cfg must already be loaded.
+func startMetrics(ctx context.Context, cfg serve.Config, stateMgr *state.Manager, logger *logging.Logger) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler(stateMgr))
+
+	scheme := "http"
+	if cfg.TLSEnabled() {
+		scheme = "https"
+	}
+
+	go func() {
+		logger.Info("Metrics listening on %s://%s/metrics", scheme, cfg.Addr)
+		if err := serve.ListenAndServe(ctx, cfg, mux); err != nil {
+			logger.Error("metrics server: %v", err)
+		}
+	}()
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+)
+
+// runNextPass implements "ls-horizons next-pass --sc code [--within d]":
+// print the passes that begin within the window and exit 0, or exit 1
+// quietly if there are none, so a crontab line can chain an alert on it.
+func runNextPass(args []string) error {
+	fs := flag.NewFlagSet("next-pass", flag.ContinueOnError)
+	scName := fs.String("sc", "", "Spacecraft: DSN code (VGR2), mission name, or NAIF ID")
+	within := fs.Duration("within", 30*time.Minute, "Window from now in which a pass must begin")
+	asJSON := fs.Bool("json", false, "Print the passes as a JSON pass plan")
+	fs.Usage = func() {
+		out := fs.Output()
+		fmt.Fprintf(out, "Usage: %s next-pass --sc code [--within d]\n\n", os.Args[0])
+		fmt.Fprintln(out, "Exits 0 and prints the pass if one begins at a DSN complex within the window; exits 1 silently otherwise.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+	if *scName == "" {
+		fs.Usage()
+		return errors.New("missing --sc")
+	}
+	if *within <= 0 {
+		return errors.New("--within must be positive")
+	}
+
+	target, err := resolveTarget(*scName)
+	if err != nil {
+		return err
+	}
+	cfg, err := subcommandConfig()
+	if err != nil {
+		return err
+	}
+
+	// Sample past the window so passes that start in it have their peak
+	// and end, and one step before now to place a crossing right at the
+	// start of the window
+	now := time.Now()
+	step := cfg.PassWindow.Step
+	samples, err := radecSource(nil, cfg.Usage).GetRADecPath(target.NAIFID,
+		now.Add(-step), now.Add(*within+cfg.PassWindow.Span), step)
+	if err != nil {
+		return err
+	}
+	plan := dsn.ComputePassPlan(target.Code, cfg.PassElevations, samples, now)
+	plan.Passes = plan.PassesStartingBetween(now, now.Add(*within))
+	if len(plan.Passes) == 0 {
+		return errQuiet
+	}
+
+	if *asJSON {
+		return dsn.ExportPassPlan(plan).WriteJSON(os.Stdout)
+	}
+	writeNextPasses(os.Stdout, target.Code, plan.Passes, now, time.Local)
+	return nil
+}
+
+// writeNextPasses prints one line per upcoming pass.
+func writeNextPasses(w io.Writer, code string, passes []dsn.Pass, now time.Time, loc *time.Location) {
+	for _, p := range passes {
+		name := dsn.ComplexShortName(p.Complex)
+		if info, ok := dsn.KnownComplexes[p.Complex]; ok {
+			name = info.Name
+		}
+		fmt.Fprintf(w, "%s pass over %s starts %s (in %s), peaks %.0f° at %s, sets %s\n",
+			code, name, p.Start.In(loc).Format("15:04 MST"), p.Start.Sub(now).Round(time.Minute),
+			p.MaxElDeg, p.Peak.In(loc).Format("15:04"), p.End.In(loc).Format("Mon 15:04"))
+	}
+}
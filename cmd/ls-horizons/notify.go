@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+	"github.com/litescript/ls-horizons/internal/logging"
+	"github.com/litescript/ls-horizons/internal/notify"
+	"github.com/litescript/ls-horizons/internal/sandbox"
+	"github.com/litescript/ls-horizons/internal/state"
+)
+
+// notifySettings combines the config file's [notify] tables with the
+// --notify and --webhook-url flags, which take precedence. --notify-sc
+// and the --follow watchlist limit notifications to the listed
+// spacecraft, keeping any per-spacecraft events from the file.
+func (c fileConfig) notifySettings() (notify.Config, error) {
+	nc := c.Notify
+	if notifyDesktop {
+		nc.Desktop = true
+	}
+	if notifyCmd != "" {
+		nc.Command = notifyCmd
+	}
+	if webhookURL != "" {
+		nc.Webhook = webhookURL
+	}
+	if notifyEvents != "" {
+		events, err := notify.ParseEvents(notifyEvents)
+		if err != nil {
+			return nc, fmt.Errorf("--notify-events: %w", err)
+		}
+		nc.Events = events
+	}
+	if notifySC != "" {
+		nc = notifyOnly(nc, dsn.ParseWatchlist(notifySC))
+	}
+	if len(watchlist) > 0 {
+		nc = notifyOnly(nc, watchlist)
+	}
+	if nc.Command != "" {
+		if err := sandbox.Check("notification commands"); err != nil {
+			return nc, err
+		}
+	}
+	if nc.Webhook != "" {
+		if u, err := url.Parse(nc.Webhook); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nc, fmt.Errorf("webhook %q: want an http or https URL", nc.Webhook)
+		}
+		if err := sandbox.Check("webhooks"); err != nil {
+			return nc, err
+		}
+	}
+	return nc, nil
+}
+
+// notifyOnly limits nc to the spacecraft on w, keeping their own events
+// where nc has them. Applied twice, it keeps the spacecraft on both lists.
+func notifyOnly(nc notify.Config, w dsn.Watchlist) notify.Config {
+	defaults := nc.Events
+	if defaults == nil {
+		defaults = notify.DefaultEvents
+	}
+	only := make(map[string][]state.EventType)
+	for _, code := range w {
+		if events, ok := nc.Spacecraft[code]; ok {
+			only[code] = events
+		} else {
+			only[code] = defaults
+		}
+	}
+	nc.Spacecraft = only
+	nc.Events = []state.EventType{}
+	return nc
+}
+
+// startNotifier delivers link events to cfg's channels until ctx is
+// cancelled. It works the same under the TUI, watch mode, and replay,
+// since all of them feed stateMgr.
+func startNotifier(ctx context.Context, cfg notify.Config, stateMgr *state.Manager, logger *logging.Logger) {
+	updates, cancel := stateMgr.Subscribe()
+	go func() {
+		defer cancel()
+		notify.New(cfg, logger).Run(ctx, updates)
+	}()
+}
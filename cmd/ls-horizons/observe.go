@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/term"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+)
+
+// observeLookback is how far back passes are searched, so one already in
+// progress still has its AOS.
+const observeLookback = 12 * time.Hour
+
+// runObserveCmd implements "ls-horizons observe --sc code --at LAT,LON":
+// a guided checklist for watching a pass from a personal station (where
+// to point by T-5, the Doppler to tune for at AOS, the peak, LOS), kept
+// up to date as the pass goes on.
+func runObserveCmd(args []string) error {
+	fs := flag.NewFlagSet("observe", flag.ContinueOnError)
+	scName := fs.String("sc", "", "Spacecraft: DSN code (VGR1), mission name, or NAIF ID")
+	at := fs.String("at", "", "Your location as LAT,LON in degrees, optionally ,HEIGHT in meters above the terrain (e.g. 34.2,-118.2,10)")
+	band := fs.String("band", "X", "Downlink band to tune for: S, X, or Ka")
+	passNum := fs.Int("pass", 1, "Which upcoming pass: 1 for the next (or current), 2 for the one after, ...")
+	once := fs.Bool("once", false, "Print the checklist and exit instead of following the pass")
+	interval := fs.Duration("interval", 10*time.Second, "How often to update pointing and Doppler while following the pass")
+	fs.Usage = func() {
+		out := fs.Output()
+		fmt.Fprintf(out, "Usage: %s observe --sc code --at LAT,LON[,HEIGHT] [--band X] [--pass n] [--once]\n\n", os.Args[0])
+		fmt.Fprintln(out, "Prints a step-by-step checklist for a pass over your horizon, then follows it live until LOS.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+	if *scName == "" || *at == "" {
+		fs.Usage()
+		return errors.New("missing --sc or --at")
+	}
+	switch {
+	case *band != "S" && *band != "X" && *band != "Ka":
+		return fmt.Errorf("--band %q: want S, X, or Ka", *band)
+	case *passNum < 1:
+		return errors.New("--pass must be 1 or more")
+	case *interval < time.Second:
+		return errors.New("--interval must be at least 1s")
+	}
+	cfg, err := subcommandConfig()
+	if err != nil {
+		return err
+	}
+	obs, err := parseLatLon(*at, cfg.Refraction)
+	if err != nil {
+		return err
+	}
+	target, err := resolveTarget(*scName)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	src := radecSource(nil, cfg.Usage)
+	span := cfg.PassWindow.Span + observeLookback
+	samples, err := src.GetRADecPath(target.NAIFID, now.Add(-observeLookback), now.Add(span), cfg.PassWindow.Step)
+	if err != nil {
+		return err
+	}
+	pass, ok := pickObserverPass(dsn.ComputeObserverPasses(obs, samples, now), now, *passNum)
+	if !ok {
+		return fmt.Errorf("%s has no pass %d above your horizon in the next %s", target.Code, *passNum, span)
+	}
+
+	var rangeRate func(time.Time) float64
+	if vectors, ok := src.(dsn.SolarSystemSeriesProvider); ok {
+		rangeRate, err = dsn.PassRangeRate(vectors, int(target.NAIFID), pass)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Doppler from Earth's rotation only: %v\n", err)
+		}
+	}
+	plan := dsn.PlanObservation(target.Code, obs, pass, samples, *band, rangeRate)
+
+	dsn.WriteObservationPlan(os.Stdout, plan, now, time.Local)
+	if *once || !now.Before(pass.End) {
+		return nil
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	followObservation(ctx, os.Stdout, plan, *interval, term.IsTerminal(int(os.Stdout.Fd())))
+	return nil
+}
+
+// pickObserverPass returns the nth pass (from 1) that hasn't ended by now.
+func pickObserverPass(passes []dsn.Pass, now time.Time, n int) (dsn.Pass, bool) {
+	for _, p := range passes {
+		if !p.End.After(now) {
+			continue
+		}
+		if n--; n == 0 {
+			return p, true
+		}
+	}
+	return dsn.Pass{}, false
+}
+
+// followObservation announces each step as it comes due and, on a
+// terminal, keeps a status line with the live pointing and Doppler, until
+// LOS or ctx is cancelled.
+func followObservation(ctx context.Context, w io.Writer, plan dsn.ObservationPlan, interval time.Duration, tty bool) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	fmt.Fprintln(w)
+	done := plan.Next(time.Now())
+	for {
+		now := time.Now()
+		if tty {
+			fmt.Fprint(w, "\r\033[K")
+		}
+		for next := plan.Next(now); done < next; done++ {
+			fmt.Fprintf(w, "▶ %s\n", dsn.FormatObservationStep(plan, plan.Steps[done], time.Local))
+		}
+		if done == len(plan.Steps) {
+			return
+		}
+		if tty {
+			fmt.Fprint(w, dsn.FormatObservationNow(plan, now))
+		}
+
+		select {
+		case <-ctx.Done():
+			if tty {
+				fmt.Fprintln(w)
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
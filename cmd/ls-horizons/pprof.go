@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/http/pprof"
+	"os"
+
+	"github.com/litescript/ls-horizons/internal/logging"
+	"github.com/litescript/ls-horizons/internal/serve"
+)
+
+// hiddenFlags are accepted but left out of --help.
+var hiddenFlags = map[string]bool{
+	"pprof": true,
+}
+
+// usage prints flag help without the hidden flags.
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintf(out, "Usage of %s:\n", os.Args[0])
+
+	visible := flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	visible.SetOutput(out)
+	flag.VisitAll(func(f *flag.Flag) {
+		if !hiddenFlags[f.Name] {
+			visible.Var(f.Value, f.Name, f.Usage)
+		}
+	})
+	visible.PrintDefaults()
+}
+
+// startPprof serves net/http/pprof on addr until ctx is cancelled. The
+// shared server config keeps it on loopback unless TLS and auth are set.
+func startPprof(ctx context.Context, addr string, logger *logging.Logger) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	cfg := serve.DefaultConfig()
+	cfg.Addr = addr
+
+	go func() {
+		logger.Info("pprof listening on http://%s/debug/pprof/", addr)
+		if err := serve.ListenAndServe(ctx, cfg, mux); err != nil {
+			logger.Error("pprof server: %v", err)
+		}
+	}()
+}
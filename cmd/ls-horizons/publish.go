@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/demo"
+	"github.com/litescript/ls-horizons/internal/dsn"
+	"github.com/litescript/ls-horizons/internal/ephem"
+	"github.com/litescript/ls-horizons/internal/record"
+	"github.com/litescript/ls-horizons/internal/sandbox"
+	"github.com/litescript/ls-horizons/internal/state"
+)
+
+// runPublish implements "ls-horizons publish --out dir": fetch the feed
+// once and generate a static status site from it, the --record history,
+// and pass plans, for cron to refresh and any web server to serve.
+func runPublish(args []string) error {
+	fs := flag.NewFlagSet("publish", flag.ContinueOnError)
+	outDir := fs.String("out", "", "Directory to write the site to (created if missing)")
+	recordDir := fs.String("record-dir", record.DefaultDir(), "Recorded snapshots (from --record) to chart history from")
+	history := fs.Duration("history", 24*time.Hour, "How much recorded history to chart")
+	passWindow := fs.Duration("passes", dsn.PassWindowDuration, "Pass schedule window (0 skips the Horizons queries); overrides pass_window and --pass-window")
+	reload := fs.Duration("reload", 0, "Make browsers reload pages this often; match the cron interval (0 = never)")
+	useDemo := fs.Bool("demo", false, "Publish from bundled DSN data and synthetic ephemerides (no network)")
+	fs.Usage = func() {
+		out := fs.Output()
+		fmt.Fprintf(out, "Usage: %s publish --out dir [--record-dir dir] [--history d] [--passes d]\n\n", os.Args[0])
+		fmt.Fprintln(out, "Writes index.html (current status), passes.html (pass schedule), and sc/CODE.html (per-spacecraft history) to dir.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+	switch {
+	case *outDir == "":
+		fs.Usage()
+		return errors.New("missing --out")
+	case *history <= 0:
+		return errors.New("--history must be positive")
+	case *passWindow < 0:
+		return errors.New("--passes must not be negative")
+	case *reload < 0:
+		return errors.New("--reload must not be negative")
+	}
+	if err := sandbox.CheckWrite(*outDir); err != nil {
+		return err
+	}
+	cfg, err := subcommandConfig()
+	if err != nil {
+		return err
+	}
+	window := cfg.PassWindow
+	if flagsSet(fs)["passes"] {
+		window.Span = *passWindow
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	fetcher := dsn.NewFetcher()
+	var demoSrc *demo.Source
+	if *useDemo {
+		if demoSrc, err = demo.New(time.Now()); err != nil {
+			return err
+		}
+		fetcher = demoSrc.Fetcher()
+	}
+	result := fetcher.Fetch(ctx)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	// Replay the history window so the pages list the events in it
+	start := result.FetchedAt.Add(-*history)
+	snaps, err := dsn.LoadSnapshotsBetween(*recordDir, start, result.FetchedAt)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: no recorded history: %v\n", err)
+	}
+	stateMgr := state.NewManager(state.DefaultConfig())
+	for _, snap := range snaps {
+		fetchedAt := snap.FetchedAt
+		if fetchedAt.IsZero() {
+			fetchedAt = snap.Timestamp
+		}
+		if fetchedAt.After(start) && fetchedAt.Before(result.FetchedAt) {
+			stateMgr.UpdateAt(dsn.ImportSnapshot(snap), fetchedAt, 0, nil)
+		}
+	}
+	stateMgr.UpdateAt(result.Data, result.FetchedAt, result.Duration, nil)
+	snap := stateMgr.Snapshot()
+
+	var plans []*dsn.PassPlan
+	if window.Span > 0 {
+		plans, err = publishPassPlans(ctx, snap.Data, result.FetchedAt, window, cfg.PassElevations, radecSource(demoSrc, cfg.Usage))
+		if err != nil {
+			return err
+		}
+	}
+
+	site := dsn.Site{
+		Data:          snap.Data,
+		FetchedAt:     result.FetchedAt,
+		Events:        convertEvents(snap.Events),
+		History:       snaps,
+		HistoryWindow: *history,
+		Passes:        plans,
+		Refresh:       *reload,
+	}
+	if err := dsn.WriteSite(*outDir, site); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Published %s\n", filepath.Join(*outDir, "index.html"))
+	return nil
+}
+
+// publishPassPlans computes the passes above elev within window for each
+// tracked spacecraft Horizons knows, from the paths hp gives. A failed
+// query leaves that spacecraft out of the schedule rather than failing the
+// site.
+func publishPassPlans(ctx context.Context, data *dsn.DSNData, now time.Time, window dsn.PassWindow, elev dsn.PassElevations, hp ephem.RADecProvider) ([]*dsn.PassPlan, error) {
+	if data == nil {
+		return nil, nil
+	}
+	seen := make(map[ephem.TargetID]bool)
+	var plans []*dsn.PassPlan
+	for _, link := range data.Links {
+		naifID := ephem.GetNAIFIDByName(link.Spacecraft)
+		if naifID == 0 || seen[naifID] {
+			continue
+		}
+		seen[naifID] = true
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		samples, err := hp.GetRADecPath(naifID, now.Add(-window.Step), now.Add(window.Span), window.Step)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: passes for %s: %v\n", link.Spacecraft, err)
+			continue
+		}
+		plans = append(plans, dsn.ComputePassPlan(link.Spacecraft, elev, samples, now))
+	}
+	return plans, nil
+}
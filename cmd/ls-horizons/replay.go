@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+	"github.com/litescript/ls-horizons/internal/logging"
+	"github.com/litescript/ls-horizons/internal/state"
+	"github.com/litescript/ls-horizons/internal/ui"
+)
+
+// runReplayLoop feeds recorded snapshots through the state manager in
+// place of the fetch loop, spaced by their recorded feed times divided by
+// speed. The last snapshot stays on screen when playback ends.
+func runReplayLoop(ctx context.Context, snaps []*dsn.SnapshotExport, speed float64, stateMgr *state.Manager, mailbox *ui.Mailbox, logger *logging.Logger) {
+	for i, snap := range snaps {
+		// Use the recorded fetch time so latency and event stamps match the
+		// live session rather than the wall clock
+		fetchedAt := snap.FetchedAt
+		if fetchedAt.IsZero() {
+			fetchedAt = snap.Timestamp
+		}
+		stateMgr.UpdateAt(dsn.ImportSnapshot(snap), fetchedAt, 0, nil)
+		if i+1 == len(snaps) {
+			break
+		}
+
+		delay := dsn.ReplayDelay(snap, snaps[i+1], speed)
+		stateMgr.SetNextRefresh(time.Now().Add(delay))
+		mailbox.PostFetch(ui.DataUpdateMsg{Snapshot: stateMgr.Snapshot()})
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+
+	mailbox.PostFetch(ui.DataUpdateMsg{Snapshot: stateMgr.Snapshot()})
+	logger.Debug("Replay finished after %d snapshots", len(snaps))
+}
@@ -0,0 +1,7 @@
+//go:build sqlite
+
+package main
+
+// The pure-Go SQLite driver behind --history-db, linked only in builds
+// with -tags sqlite so default builds stay free of it.
+import _ "modernc.org/sqlite"
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/astro"
+	"github.com/litescript/ls-horizons/internal/dsn"
+	"github.com/litescript/ls-horizons/internal/ephem"
+	"github.com/litescript/ls-horizons/internal/logging"
+)
+
+// parseLatLon parses a "lat,lon" pair in degrees (north and east positive),
+// optionally followed by ",height" in meters above the surrounding terrain
+// or sea, which lowers the visible horizon. Elevations from it are refracted
+// if refraction is set.
+func parseLatLon(s string, refraction bool) (astro.Observer, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) < 2 || len(parts) > 3 {
+		return astro.Observer{}, fmt.Errorf("location %q: want LAT,LON or LAT,LON,HEIGHT", s)
+	}
+	lat, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil || lat < -90 || lat > 90 {
+		return astro.Observer{}, fmt.Errorf("location %q: latitude must be between -90 and 90", s)
+	}
+	lon, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil || lon < -180 || lon > 180 {
+		return astro.Observer{}, fmt.Errorf("location %q: longitude must be between -180 and 180", s)
+	}
+	obs := astro.Observer{LatDeg: lat, LonDeg: lon, Name: "You", Refraction: refraction}
+	if len(parts) == 3 {
+		obs.HeightM, err = strconv.ParseFloat(strings.TrimSpace(parts[2]), 64)
+		if err != nil || obs.HeightM < 0 {
+			return astro.Observer{}, fmt.Errorf("location %q: height must be meters, 0 or more", s)
+		}
+	}
+	return obs, nil
+}
+
+// runTonight prints which spacecraft the DSN is talking to right now are
+// above the observer's horizon tonight, with rise and set times from the
+// paths hp gives.
+func runTonight(ctx context.Context, fetcher *dsn.Fetcher, hp ephem.RADecProvider, obs astro.Observer, logger *logging.Logger) error {
+
+	now := time.Now()
+	start, end, ok := dsn.NightWindow(obs, now)
+	if !ok {
+		fmt.Println("The Sun doesn't set at your location in the next 24 hours")
+		return nil
+	}
+
+	result := fetcher.Fetch(ctx)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	seen := make(map[ephem.TargetID]bool)
+	var rows []dsn.TonightRow
+	for _, link := range result.Data.Links {
+		naifID := ephem.GetNAIFIDByName(link.Spacecraft)
+		if naifID == 0 || seen[naifID] {
+			continue
+		}
+		seen[naifID] = true
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		row := dsn.TonightRow{Spacecraft: link.Spacecraft}
+		samples, err := hp.GetRADecPath(naifID, start, end, dsn.PassSampleInterval)
+		if err != nil {
+			logger.Debug("Horizons RA/Dec for %s failed: %v", link.Spacecraft, err)
+			row.Err = err
+		} else {
+			row.Passes = dsn.ComputeObserverPasses(obs, samples, now)
+		}
+		rows = append(rows, row)
+	}
+
+	dsn.WriteTonight(os.Stdout, obs, start, end, rows, time.Local)
+	return nil
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+	"github.com/litescript/ls-horizons/internal/notify"
+	"github.com/litescript/ls-horizons/internal/state"
+)
+
+// runWaitCmd implements "ls-horizons wait [--for events] [--sc codes]
+// [--timeout d]": poll the feed until a matching event occurs, print it,
+// and exit 0. Links already up when it starts don't count.
+func runWaitCmd(args []string) error {
+	fs := flag.NewFlagSet("wait", flag.ContinueOnError)
+	forEvents := fs.String("for", "new_link,handoff,link_lost", "Events to wait for, comma-separated (also link_resumed, uplink_start, uplink_end, rare_acquisition, complex_quiet, complex_active, wind_risk, pointing_divergence)")
+	scCodes := fs.String("sc", "", "Only events for these spacecraft, comma-separated (e.g. JWST,VGR1); complex events always match")
+	timeout := fs.Duration("timeout", 0, "Give up after this long and exit 1 (0 waits forever)")
+	interval := fs.Duration("interval", defaultRefresh, "Feed polling interval")
+	asJSON := fs.Bool("json", false, "Print the event as JSON instead of a text line")
+	fs.Usage = func() {
+		out := fs.Output()
+		fmt.Fprintf(out, "Usage: %s wait [--for events] [--sc codes] [--timeout d]\n\n", os.Args[0])
+		fmt.Fprintln(out, "Blocks until a matching event occurs, prints it, and exits 0.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+
+	types, err := notify.ParseEvents(*forEvents)
+	if err != nil {
+		return fmt.Errorf("--for: %w", err)
+	}
+	if len(types) == 0 {
+		return errors.New("--for: no events to wait for")
+	}
+	match := waitMatcher(types, dsn.ParseWatchlist(*scCodes))
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+
+	e, err := waitForEvent(ctx, dsn.NewFetcher(), clampRefresh(*interval), match)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("no matching event within %s", *timeout)
+	}
+	if err != nil {
+		return err
+	}
+	return writeWaitEvent(os.Stdout, e, *asJSON)
+}
+
+// waitMatcher matches events of the given types for spacecraft on watch,
+// by any of their codes, and complex events, which have no spacecraft.
+func waitMatcher(types []state.EventType, watch dsn.Watchlist) func(state.Event) bool {
+	return func(e state.Event) bool {
+		return slices.Contains(types, e.Type) && (e.Spacecraft == "" || watch.Follows(e.Spacecraft))
+	}
+}
+
+// waitForEvent fetches every interval until an event satisfies match.
+// The first successful fetch is the baseline; fetch errors are reported
+// and polling continues.
+func waitForEvent(ctx context.Context, fetcher *dsn.Fetcher, interval time.Duration, match func(state.Event) bool) (state.Event, error) {
+	stateMgr := state.NewManager(state.DefaultConfig())
+	updates, cancel := stateMgr.Subscribe()
+	defer cancel()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	primed := false
+	for {
+		result := fetcher.Fetch(ctx)
+		if ctx.Err() != nil {
+			return state.Event{}, ctx.Err()
+		}
+		if result.Error != nil {
+			fmt.Fprintf(os.Stderr, "Warning: fetch: %v\n", result.Error)
+		} else {
+			stateMgr.Update(result.Data, result.Duration, nil)
+			u := <-updates
+			if primed {
+				for _, e := range u.Events {
+					if match(e) {
+						return e, nil
+					}
+				}
+			}
+			primed = true
+		}
+
+		select {
+		case <-ctx.Done():
+			return state.Event{}, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// writeWaitEvent prints e as a text line or a JSON object.
+func writeWaitEvent(w io.Writer, e state.Event, asJSON bool) error {
+	if asJSON {
+		return json.NewEncoder(w).Encode(e)
+	}
+	_, err := fmt.Fprintf(w, "%s %s %s %s\n",
+		e.Timestamp.UTC().Format(time.RFC3339), e.Type, e.Spacecraft, notify.Message(e))
+	return err
+}
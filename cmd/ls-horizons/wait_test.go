@@ -0,0 +1,32 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+	"github.com/litescript/ls-horizons/internal/state"
+)
+
+func TestWaitMatcher(t *testing.T) {
+	match := waitMatcher([]state.EventType{state.EventNewLink, state.EventComplexQuiet}, dsn.ParseWatchlist("maven, JUNO"))
+	tests := []struct {
+		e    state.Event
+		want bool
+	}{
+		{state.Event{Type: state.EventNewLink, Spacecraft: "MVN"}, true}, // feed code of MAVEN
+		{state.Event{Type: state.EventNewLink, Spacecraft: "JNO"}, true}, // feed code of JUNO
+		{state.Event{Type: state.EventNewLink, Spacecraft: "VGR1"}, false},
+		{state.Event{Type: state.EventHandoff, Spacecraft: "JNO"}, false},
+		{state.Event{Type: state.EventComplexQuiet}, true},
+	}
+	for _, tt := range tests {
+		if got := match(tt.e); got != tt.want {
+			t.Errorf("match(%s %s) = %v, want %v", tt.e.Type, tt.e.Spacecraft, got, tt.want)
+		}
+	}
+
+	all := waitMatcher([]state.EventType{state.EventNewLink}, dsn.ParseWatchlist(""))
+	if !all(state.Event{Type: state.EventNewLink, Spacecraft: "VGR1"}) {
+		t.Error("no --sc should match every spacecraft")
+	}
+}
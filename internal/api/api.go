@@ -0,0 +1,299 @@
+// Package api serves live DSN state as JSON over HTTP, so other tools can
+// consume it without scraping the TUI.
+//
+// Endpoints:
+//
+//	GET /snapshot           latest snapshot (same format as --snapshot-path)
+//	GET /events             recent link events, oldest first
+//	GET /passes/{sc}        24-hour pass plan for a spacecraft
+//	GET /spacecraft/{name}  card for a currently tracked spacecraft
+//	GET /stream             WebSocket push of updates and events
+//	GET /badge/{sc}.svg     embeddable status badge for a spacecraft
+//	GET /cache              cached ephemeris, elevation traces, and pass plans
+//	DELETE /cache/{kind}    empty one cache (ephemeris, traces, passplans)
+//	GET|POST /graphql       GraphQL queries over all of the above and history
+//	GET /graphql/schema     the GraphQL schema (SDL)
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/astro"
+	"github.com/litescript/ls-horizons/internal/dsn"
+	"github.com/litescript/ls-horizons/internal/ephem"
+	"github.com/litescript/ls-horizons/internal/state"
+)
+
+// PathSource supplies RA/Dec samples for pass planning.
+// ephem.HorizonsProvider implements it.
+type PathSource interface {
+	GetRADecPath(target ephem.TargetID, start, end time.Time, step time.Duration) ([]astro.RADecAtTime, error)
+}
+
+// Options configures a Server.
+type Options struct {
+	// AllowCrossOrigin lets pages from other origins open /stream and
+	// query /graphql. Set it when the server requires authentication;
+	// otherwise any page the user visits could use them through their
+	// browser.
+	AllowCrossOrigin bool
+}
+
+// Server handles API requests from the state manager.
+type Server struct {
+	state *state.Manager
+	paths PathSource // nil disables /passes
+	opts  Options
+	mux   *http.ServeMux
+
+	done      chan struct{} // closed by Close to end streams
+	closeOnce sync.Once
+}
+
+// New creates an API server backed by mgr. Pass plans are computed from
+// paths and cached in mgr; with a nil paths /passes reports 501.
+func New(mgr *state.Manager, paths PathSource, opts Options) *Server {
+	s := &Server{
+		state: mgr,
+		paths: paths,
+		opts:  opts,
+		mux:   http.NewServeMux(),
+		done:  make(chan struct{}),
+	}
+	s.mux.HandleFunc("GET /snapshot", s.handleSnapshot)
+	s.mux.HandleFunc("GET /events", s.handleEvents)
+	s.mux.HandleFunc("GET /passes/{sc}", s.handlePasses)
+	s.mux.HandleFunc("GET /spacecraft/{name}", s.handleSpacecraft)
+	s.mux.HandleFunc("GET /stream", s.handleStream)
+	s.mux.HandleFunc("GET /badge/{file}", s.handleBadge)
+	s.mux.HandleFunc("GET /cache", s.handleCache)
+	s.mux.HandleFunc("DELETE /cache/{kind}", s.handleClearCache)
+	s.mux.HandleFunc("GET /graphql", s.handleGraphQL)
+	s.mux.HandleFunc("POST /graphql", s.handleGraphQL)
+	s.mux.HandleFunc("GET /graphql/schema", s.handleGraphQLSchema)
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Close ends open /stream connections. http.Server.Shutdown doesn't,
+// since their connections have been hijacked.
+func (s *Server) Close() {
+	s.closeOnce.Do(func() { close(s.done) })
+}
+
+func (s *Server) handleSnapshot(w http.ResponseWriter, r *http.Request) {
+	snap := s.state.Snapshot()
+	if snap.Data == nil {
+		writeError(w, http.StatusServiceUnavailable, "no data yet")
+		return
+	}
+	export := dsn.ExportSnapshot(snap.Data, snap.LastFetch)
+	export.Error = dsn.DescribeError(snap.LastError)
+	writeJSON(w, http.StatusOK, export)
+}
+
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	events := s.state.Snapshot().Events
+	if events == nil {
+		events = []state.Event{}
+	}
+	writeJSON(w, http.StatusOK, events)
+}
+
+func (s *Server) handleCache(w http.ResponseWriter, r *http.Request) {
+	entries := s.state.CacheStats()
+	if entries == nil {
+		entries = []state.CacheEntry{}
+	}
+	writeJSON(w, http.StatusOK, entries)
+}
+
+func (s *Server) handleClearCache(w http.ResponseWriter, r *http.Request) {
+	kind, err := state.ParseCacheKind(r.PathValue("kind"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]int{"cleared": s.state.ClearCache(kind)})
+}
+
+func (s *Server) handleSpacecraft(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	snap := s.state.Snapshot()
+	if snap.Data == nil {
+		writeError(w, http.StatusServiceUnavailable, "no data yet")
+		return
+	}
+	card := dsn.FindSpacecraftCard(snap.Data, name)
+	if card == nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("spacecraft %q not currently tracked", name))
+		return
+	}
+	writeJSON(w, http.StatusOK, card)
+}
+
+func (s *Server) handlePasses(w http.ResponseWriter, r *http.Request) {
+	if s.paths == nil {
+		writeError(w, http.StatusNotImplemented, "pass planning needs Horizons ephemeris")
+		return
+	}
+	target, ok := ephem.GetTargetByName(r.PathValue("sc"))
+	if !ok {
+		writeErrorInfo(w, http.StatusNotFound, fmt.Errorf("%w: spacecraft %q", dsn.ErrUnknownTarget, r.PathValue("sc")))
+		return
+	}
+
+	plan, err := s.passPlan(target)
+	if err != nil {
+		writeErrorInfo(w, http.StatusBadGateway, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, dsn.ExportPassPlan(plan))
+}
+
+// passPlan returns the cached plan while it is younger than
+// state.PassPlanTTL. Older plans slide forward the way the TUI's do,
+// fetching only samples past the end of the cached window. Plans are
+// cached under the DSN spacecraft ID, which is the NAIF ID negated.
+func (s *Server) passPlan(target ephem.TargetInfo) (*dsn.PassPlan, error) {
+	id := -int(target.NAIFID)
+	now := time.Now()
+
+	var prevPlan *dsn.PassPlan
+	var prevSamples []astro.RADecAtTime
+	if cached := s.state.GetCachedPassPlan(id); cached != nil && cached.Plan != nil {
+		if now.Sub(cached.UpdatedAt) < state.PassPlanTTL {
+			return cached.Plan, nil
+		}
+		prevPlan, prevSamples = cached.Plan, cached.Samples
+	}
+
+	window := s.state.PassWindow()
+	end := now.Add(window.Span)
+	step := window.Step
+	samples, from := dsn.ReusableSamples(prevSamples, now, step)
+	if len(samples) == 0 {
+		prevPlan = nil
+	}
+	if end.Sub(from) >= step {
+		tail, err := s.paths.GetRADecPath(target.NAIFID, from, end, step)
+		if err != nil {
+			return nil, fmt.Errorf("fetch %s ephemeris: %w", target.Code, err)
+		}
+		samples = append(samples[:len(samples):len(samples)], tail...)
+	}
+
+	plan := dsn.ExtendPassPlan(target.Code, s.state.PassElevations(), prevPlan, samples, now)
+	s.state.UpdatePassPlanSamples(id, plan, samples, nil)
+	return plan, nil
+}
+
+// Stream message types.
+const (
+	MessageDataUpdate = "data_update"
+	MessageEvent      = "event"
+)
+
+// StreamMessage is one JSON message on /stream. A data_update carries the
+// new snapshot; each event detected by that update follows as an event
+// message.
+type StreamMessage struct {
+	Type     string              `json:"type"`
+	Snapshot *dsn.SnapshotExport `json:"snapshot,omitempty"`
+	Event    *state.Event        `json:"event,omitempty"`
+}
+
+// handleStream upgrades to a WebSocket, sends the current snapshot if
+// there is one, then pushes each update as the state manager sees it,
+// until the client goes away or the server is closed.
+func (s *Server) handleStream(w http.ResponseWriter, r *http.Request) {
+	// Browsers send cookies and cached credentials with cross-origin
+	// WebSocket handshakes, so other sites' pages are turned away
+	if !s.opts.AllowCrossOrigin && !sameOrigin(r) {
+		writeError(w, http.StatusForbidden, "cross-origin request refused")
+		return
+	}
+	ws, err := upgradeWebSocket(w, r)
+	if err != nil {
+		return
+	}
+	defer ws.Close()
+
+	updates, cancel := s.state.Subscribe()
+	defer cancel()
+
+	closed := make(chan struct{})
+	go func() {
+		ws.readLoop()
+		close(closed)
+	}()
+
+	if snap := s.state.Snapshot(); snap.Data != nil {
+		msg := StreamMessage{Type: MessageDataUpdate, Snapshot: dsn.ExportSnapshot(snap.Data, snap.LastFetch)}
+		if ws.writeJSON(msg) != nil {
+			return
+		}
+	}
+
+	for {
+		select {
+		case <-closed:
+			return
+		case <-s.done:
+			_ = ws.writeFrame(opClose, closeGoingAway)
+			return
+		case u, ok := <-updates:
+			if !ok {
+				return
+			}
+			if ws.writeJSON(StreamMessage{Type: MessageDataUpdate, Snapshot: dsn.ExportSnapshot(u.Data, u.FetchedAt)}) != nil {
+				return
+			}
+			for i := range u.Events {
+				if ws.writeJSON(StreamMessage{Type: MessageEvent, Event: &u.Events[i]}) != nil {
+					return
+				}
+			}
+		}
+	}
+}
+
+// sameOrigin reports whether r has no Origin header, as from a non-browser
+// client, or one naming the host it was sent to.
+func sameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(u.Host, r.Host)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
+
+// writeErrorInfo writes err with its code and remediation hint alongside
+// the message, so clients can act on the class of failure.
+func writeErrorInfo(w http.ResponseWriter, status int, err error) {
+	info := dsn.DescribeError(err)
+	writeJSON(w, status, map[string]string{"error": info.Message, "code": string(info.Code), "hint": info.Hint})
+}
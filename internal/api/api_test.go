@@ -0,0 +1,232 @@
+package api
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/astro"
+	"github.com/litescript/ls-horizons/internal/dsn"
+	"github.com/litescript/ls-horizons/internal/ephem"
+	"github.com/litescript/ls-horizons/internal/state"
+)
+
+// fakePaths returns a fixed RA/Dec at every step and counts queries.
+type fakePaths struct {
+	calls int
+	err   error
+}
+
+func (f *fakePaths) GetRADecPath(target ephem.TargetID, start, end time.Time, step time.Duration) ([]astro.RADecAtTime, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	var samples []astro.RADecAtTime
+	for t := start; !t.After(end); t = t.Add(step) {
+		samples = append(samples, astro.RADecAtTime{Time: t, RAdeg: 257, DecDeg: 12})
+	}
+	return samples, nil
+}
+
+func testManager() *state.Manager {
+	mgr := state.NewManager(state.DefaultConfig())
+	mgr.Update(&dsn.DSNData{
+		Timestamp: time.Now(),
+		Links: []dsn.Link{
+			{Spacecraft: "VGR1", SpacecraftID: 31, AntennaID: "DSS43", Complex: dsn.ComplexCanberra, DataRate: 160},
+		},
+	}, 0, nil)
+	return mgr
+}
+
+func get(t *testing.T, h http.Handler, path string, v any) int {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	if ct := rec.Header().Get("Content-Type"); rec.Code != http.StatusNotFound && ct != "application/json" {
+		t.Errorf("%s: Content-Type = %q, want application/json", path, ct)
+	}
+	if v != nil {
+		if err := json.Unmarshal(rec.Body.Bytes(), v); err != nil {
+			t.Fatalf("%s: decode %q: %v", path, rec.Body.String(), err)
+		}
+	}
+	return rec.Code
+}
+
+func TestSnapshot(t *testing.T) {
+	if code := get(t, New(state.NewManager(state.DefaultConfig()), nil, Options{}), "/snapshot", nil); code != http.StatusServiceUnavailable {
+		t.Errorf("before first fetch: status = %d, want 503", code)
+	}
+
+	var snap dsn.SnapshotExport
+	if code := get(t, New(testManager(), nil, Options{}), "/snapshot", &snap); code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", code)
+	}
+	if len(snap.Links) != 1 || snap.Links[0].Spacecraft != "VGR1" {
+		t.Errorf("Links = %+v, want VGR1", snap.Links)
+	}
+	if snap.Error != nil {
+		t.Errorf("Error = %+v, want none", snap.Error)
+	}
+
+	// A failed fetch keeps the data and says why it is stale
+	mgr := testManager()
+	mgr.Update(nil, 0, fmt.Errorf("%w: status 503", dsn.ErrFeedUnavailable))
+	snap = dsn.SnapshotExport{}
+	get(t, New(mgr, nil, Options{}), "/snapshot", &snap)
+	if len(snap.Links) != 1 || snap.Error == nil || snap.Error.Code != dsn.CodeFeedUnavailable || snap.Error.Hint == "" {
+		t.Errorf("after a failed fetch: links %d, error %+v", len(snap.Links), snap.Error)
+	}
+}
+
+func TestEvents(t *testing.T) {
+	var events []state.Event
+	if code := get(t, New(testManager(), nil, Options{}), "/events", &events); code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", code)
+	}
+	if len(events) != 1 || events[0].Type != state.EventNewLink || events[0].Spacecraft != "VGR1" {
+		t.Errorf("events = %+v, want one new link for VGR1", events)
+	}
+}
+
+func TestSpacecraft(t *testing.T) {
+	s := New(testManager(), nil, Options{})
+
+	var card map[string]any
+	if code := get(t, s, "/spacecraft/vgr1", &card); code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", code)
+	}
+	if card["name"] != "VGR1" || card["antenna"] != "DSS43" {
+		t.Errorf("card = %v", card)
+	}
+
+	var body map[string]string
+	if code := get(t, s, "/spacecraft/JWST", &body); code != http.StatusNotFound || body["error"] == "" {
+		t.Errorf("untracked: status = %d body = %v, want 404 with error", code, body)
+	}
+}
+
+func TestPasses(t *testing.T) {
+	paths := &fakePaths{}
+	s := New(testManager(), paths, Options{})
+
+	var plan dsn.PassPlanExport
+	if code := get(t, s, "/passes/VGR1", &plan); code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", code)
+	}
+	if plan.Spacecraft != "VGR1" || plan.WindowEnd.Sub(plan.WindowStart) < 23*time.Hour {
+		t.Errorf("plan = %s %v..%v, want a 24h VGR1 window", plan.Spacecraft, plan.WindowStart, plan.WindowEnd)
+	}
+
+	// A fresh plan is served from the cache
+	get(t, s, "/passes/voyager%201", nil)
+	if paths.calls != 1 {
+		t.Errorf("Horizons queried %d times, want 1", paths.calls)
+	}
+}
+
+func TestPasses_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		paths  PathSource
+		path   string
+		status int
+		code   dsn.ErrorCode
+	}{
+		{"no ephemeris", nil, "/passes/VGR1", http.StatusNotImplemented, ""},
+		{"unknown spacecraft", &fakePaths{}, "/passes/NOPE", http.StatusNotFound, dsn.CodeUnknownTarget},
+		{"horizons failure", &fakePaths{err: errors.New("timeout")}, "/passes/VGR1", http.StatusBadGateway, dsn.CodeOther},
+		{"rate limited", &fakePaths{err: fmt.Errorf("%w: status 429", dsn.ErrHorizonsRateLimited)}, "/passes/VGR1", http.StatusBadGateway, dsn.CodeRateLimited},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body map[string]string
+			if code := get(t, New(testManager(), tt.paths, Options{}), tt.path, &body); code != tt.status {
+				t.Errorf("status = %d, want %d", code, tt.status)
+			}
+			if body["error"] == "" {
+				t.Errorf("body = %v, want an error message", body)
+			}
+			if body["code"] != string(tt.code) {
+				t.Errorf("code = %q, want %q", body["code"], tt.code)
+			}
+		})
+	}
+}
+
+func TestBadge(t *testing.T) {
+	tests := []struct {
+		name  string
+		mgr   *state.Manager
+		path  string
+		want  string
+		color string
+	}{
+		{"tracked", testManager(), "/badge/vgr1.svg", "VGR1: 160 bps via DSS-43", badgeMarginal}, // weak rate, no elevation
+		{"not tracked", testManager(), "/badge/JWST.svg", "JWST: not tracked", badgeIdle},
+		{"no data yet", state.NewManager(state.DefaultConfig()), "/badge/VGR1.svg", "VGR1: no data", badgeIdle},
+		{"escaped", testManager(), "/badge/%3Cx%3E.svg", "&lt;X&gt;: not tracked", badgeIdle},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			New(tt.mgr, nil, Options{}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "image/svg+xml" {
+				t.Fatalf("status = %d, Content-Type = %q", rec.Code, rec.Header().Get("Content-Type"))
+			}
+			body := rec.Body.String()
+			if !strings.Contains(body, "<title>"+tt.want+"</title>") || !strings.Contains(body, `fill="`+tt.color+`"`) {
+				t.Errorf("badge = %s, want %q in %s", body, tt.want, tt.color)
+			}
+			var svg struct{}
+			if err := xml.Unmarshal(rec.Body.Bytes(), &svg); err != nil {
+				t.Errorf("badge is not well-formed: %v", err)
+			}
+		})
+	}
+
+	if code := get(t, New(testManager(), nil, Options{}), "/badge/VGR1.png", nil); code != http.StatusNotFound {
+		t.Errorf("non-SVG badge: status = %d, want 404", code)
+	}
+}
+
+func TestCache(t *testing.T) {
+	s := New(testManager(), &fakePaths{}, Options{})
+	get(t, s, "/passes/VGR1", nil)
+
+	var entries []state.CacheEntry
+	if code := get(t, s, "/cache", &entries); code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", code)
+	}
+	var plans int
+	for _, e := range entries {
+		if e.Kind == state.CachePassPlans && e.Key == "VGR1" {
+			plans++
+		}
+	}
+	if plans != 1 {
+		t.Errorf("entries = %+v, want one VGR1 pass plan", entries)
+	}
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/cache/passplans", nil))
+	var cleared struct{ Cleared int }
+	if err := json.Unmarshal(rec.Body.Bytes(), &cleared); err != nil || rec.Code != http.StatusOK || cleared.Cleared != 1 {
+		t.Errorf("DELETE /cache/passplans = %d %s, want 200 cleared 1", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/cache/bogus", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("DELETE /cache/bogus = %d, want 404", rec.Code)
+	}
+}
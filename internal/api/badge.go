@@ -0,0 +1,115 @@
+package api
+
+import (
+	"html/template"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+)
+
+// Badge colors, as on shields.io.
+const (
+	badgeLabelColor = "#555"
+	badgeGood       = "#4c1"
+	badgeMarginal   = "#dfb317"
+	badgePoor       = "#e05d44"
+	badgeCarrier    = "#007ec6"
+	badgeIdle       = "#9f9f9f"
+)
+
+// badgeCharWidth approximates the advance of an 11px Verdana character;
+// each half of the badge is padded by badgePadding.
+const (
+	badgeCharWidth = 7
+	badgePadding   = 10
+)
+
+// badge is a two-part flat badge: a grey label and a colored message.
+type badge struct {
+	Label, Message string
+	Color          string
+	LabelWidth     int
+	MessageWidth   int
+}
+
+// Width is the badge's total width.
+func (b badge) Width() int { return b.LabelWidth + b.MessageWidth }
+
+// LabelX and MessageX center the text in each half.
+func (b badge) LabelX() float64   { return float64(b.LabelWidth) / 2 }
+func (b badge) MessageX() float64 { return float64(b.LabelWidth) + float64(b.MessageWidth)/2 }
+
+// Text is the badge's accessible text.
+func (b badge) Text() string { return b.Label + ": " + b.Message }
+
+// newBadge sizes a badge for its text.
+func newBadge(label, message, color string) badge {
+	return badge{
+		Label:        label,
+		Message:      message,
+		Color:        color,
+		LabelWidth:   utf8.RuneCountInString(label)*badgeCharWidth + badgePadding,
+		MessageWidth: utf8.RuneCountInString(message)*badgeCharWidth + badgePadding,
+	}
+}
+
+// handleBadge serves /badge/{sc}.svg: "VGR1 | 160 bps via DSS-43",
+// colored by link health, or a grey "not tracked". It always answers
+// with an image so embedding pages never show a broken one.
+func (s *Server) handleBadge(w http.ResponseWriter, r *http.Request) {
+	name, ok := strings.CutSuffix(r.PathValue("file"), ".svg")
+	if !ok || name == "" {
+		writeError(w, http.StatusNotFound, "badges are /badge/<spacecraft>.svg")
+		return
+	}
+
+	b := newBadge(strings.ToUpper(name), "no data", badgeIdle)
+	if snap := s.state.Snapshot(); snap.Data != nil {
+		b = spacecraftBadge(snap.Data, name)
+	}
+
+	w.Header().Set("Content-Type", "image/svg+xml")
+	// Image proxies such as GitHub's would otherwise cache a stale rate
+	w.Header().Set("Cache-Control", "no-cache")
+	_ = badgeTemplate.Execute(w, b)
+}
+
+// spacecraftBadge builds the badge for a spacecraft's current link.
+func spacecraftBadge(data *dsn.DSNData, name string) badge {
+	card := dsn.FindSpacecraftCard(data, name)
+	if card == nil {
+		return newBadge(strings.ToUpper(name), "not tracked", badgeIdle)
+	}
+	color := badgeGood
+	switch card.Health {
+	case dsn.HealthMarginal:
+		color = badgeMarginal
+	case dsn.HealthPoor:
+		color = badgePoor
+	case dsn.HealthCarrier:
+		color = badgeCarrier
+	}
+	return newBadge(card.Name, card.Rate+" via "+badgeAntenna(card.Antenna), color)
+}
+
+// badgeAntenna writes a feed antenna ID the way the DSN does: DSS43 as
+// DSS-43.
+func badgeAntenna(id string) string {
+	if num, ok := strings.CutPrefix(id, "DSS"); ok && num != "" && num[0] >= '0' && num[0] <= '9' {
+		return "DSS-" + num
+	}
+	return id
+}
+
+var badgeTemplate = template.Must(template.New("badge").Parse(`<svg xmlns="http://www.w3.org/2000/svg" width="{{.Width}}" height="20" role="img" aria-label="{{.Text}}">
+<title>{{.Text}}</title>
+<linearGradient id="s" x2="0" y2="100%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>
+<clipPath id="r"><rect width="{{.Width}}" height="20" rx="3" fill="#fff"/></clipPath>
+<g clip-path="url(#r)"><rect width="{{.LabelWidth}}" height="20" fill="` + badgeLabelColor + `"/><rect x="{{.LabelWidth}}" width="{{.MessageWidth}}" height="20" fill="{{.Color}}"/><rect width="{{.Width}}" height="20" fill="url(#s)"/></g>
+<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">
+<text x="{{.LabelX}}" y="14">{{.Label}}</text><text x="{{.MessageX}}" y="14">{{.Message}}</text>
+</g>
+</svg>
+`))
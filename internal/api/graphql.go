@@ -0,0 +1,860 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+	"github.com/litescript/ls-horizons/internal/ephem"
+	"github.com/litescript/ls-horizons/internal/state"
+)
+
+// The GraphQL schema is read from the same Go types the REST endpoints
+// encode, so a field has the name it has in their JSON: a Link's rate is
+// data_rate_bps in both. Structs are object types named after their Go
+// type (less an "Export" suffix); time.Time is the Time scalar (RFC 3339)
+// and maps the JSON scalar. Only queries are served: /stream pushes
+// updates.
+
+// maxGraphQLRequest caps a POSTed request body.
+const maxGraphQLRequest = 1 << 20
+
+// Query size limits. Fragments can spread each other several times over,
+// so a short query can name exponentially many fields; these are counted
+// once fragments are expanded.
+const (
+	maxGraphQLFields = 5000 // field selections in the whole query
+	maxGraphQLDepth  = 12   // fields nested inside each other
+)
+
+// gqlRequest is a GraphQL request, as a JSON body or GET parameters.
+type gqlRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// gqlResponse is a GraphQL response. Data is absent when the request
+// failed before execution.
+type gqlResponse struct {
+	Data   any        `json:"data,omitempty"`
+	Errors []gqlError `json:"errors,omitempty"`
+}
+
+type gqlError struct {
+	Message    string         `json:"message"`
+	Path       []any          `json:"path,omitempty"`
+	Extensions map[string]any `json:"extensions,omitempty"` // code and hint of classified errors
+}
+
+// gqlResolveError reports a resolver's error, with its code and hint as
+// extensions when it has a class (see dsn.ErrorCodeOf).
+func gqlResolveError(err error, path []any) gqlError {
+	e := gqlError{Message: err.Error(), Path: path}
+	if info := dsn.DescribeError(err); info.Code != dsn.CodeOther {
+		e.Extensions = map[string]any{"code": info.Code, "hint": info.Hint}
+	}
+	return e
+}
+
+// gqlRootField is a field of the Query type.
+type gqlRootField struct {
+	name    string
+	args    []gqlArgDef
+	typ     reflect.Type
+	resolve func(s *Server, args map[string]any) (any, error)
+}
+
+// gqlArgDef is an argument of a root field: a String or an Int.
+type gqlArgDef struct {
+	name     string
+	typ      string
+	required bool
+}
+
+// spacecraftHistory is a spacecraft's recent RTLT and data rate, oldest
+// first.
+type spacecraftHistory struct {
+	Spacecraft   string         `json:"spacecraft"`
+	SpacecraftID int            `json:"spacecraft_id"`
+	RTLT         []historyPoint `json:"rtlt_seconds"`
+	DataRate     []historyPoint `json:"data_rate_bps"`
+}
+
+type historyPoint struct {
+	Time  time.Time `json:"time"`
+	Value float64   `json:"value"`
+}
+
+// gqlQuery lists the Query type's fields.
+var gqlQuery = []gqlRootField{
+	{
+		name: "snapshot",
+		typ:  reflect.TypeFor[*dsn.SnapshotExport](),
+		resolve: func(s *Server, _ map[string]any) (any, error) {
+			snap := s.state.Snapshot()
+			if snap.Data == nil {
+				return nil, errors.New("no data yet")
+			}
+			export := dsn.ExportSnapshot(snap.Data, snap.LastFetch)
+			export.Error = dsn.DescribeError(snap.LastError)
+			return export, nil
+		},
+	},
+	{
+		name: "events",
+		args: []gqlArgDef{{name: "spacecraft", typ: "String"}, {name: "type", typ: "String"}, {name: "limit", typ: "Int"}},
+		typ:  reflect.TypeFor[[]state.Event](),
+		resolve: func(s *Server, args map[string]any) (any, error) {
+			events := []state.Event{}
+			for _, e := range s.state.Snapshot().Events {
+				if sc, ok := args["spacecraft"].(string); ok && !strings.EqualFold(e.Spacecraft, sc) {
+					continue
+				}
+				if typ, ok := args["type"].(string); ok && !strings.EqualFold(string(e.Type), typ) {
+					continue
+				}
+				events = append(events, e)
+			}
+			if limit, ok := args["limit"].(int); ok {
+				if limit < 0 {
+					return nil, errors.New("limit must not be negative")
+				}
+				events = events[max(len(events)-limit, 0):]
+			}
+			return events, nil
+		},
+	},
+	{
+		name: "spacecraft",
+		args: []gqlArgDef{{name: "name", typ: "String", required: true}},
+		typ:  reflect.TypeFor[*dsn.SpacecraftCard](),
+		resolve: func(s *Server, args map[string]any) (any, error) {
+			snap := s.state.Snapshot()
+			if snap.Data == nil {
+				return nil, errors.New("no data yet")
+			}
+			return dsn.FindSpacecraftCard(snap.Data, args["name"].(string)), nil
+		},
+	},
+	{
+		name: "passes",
+		args: []gqlArgDef{{name: "spacecraft", typ: "String", required: true}},
+		typ:  reflect.TypeFor[*dsn.PassPlanExport](),
+		resolve: func(s *Server, args map[string]any) (any, error) {
+			if s.paths == nil {
+				return nil, errors.New("pass planning needs Horizons ephemeris")
+			}
+			target, ok := ephem.GetTargetByName(args["spacecraft"].(string))
+			if !ok {
+				return nil, fmt.Errorf("%w: spacecraft %q", dsn.ErrUnknownTarget, args["spacecraft"])
+			}
+			plan, err := s.passPlan(target)
+			if err != nil {
+				return nil, err
+			}
+			return dsn.ExportPassPlan(plan), nil
+		},
+	},
+	{
+		name: "history",
+		args: []gqlArgDef{{name: "spacecraft", typ: "String", required: true}},
+		typ:  reflect.TypeFor[*spacecraftHistory](),
+		resolve: func(s *Server, args map[string]any) (any, error) {
+			return s.spacecraftHistory(args["spacecraft"].(string)), nil
+		},
+	},
+	{
+		name: "cache",
+		typ:  reflect.TypeFor[[]state.CacheEntry](),
+		resolve: func(s *Server, _ map[string]any) (any, error) {
+			entries := s.state.CacheStats()
+			if entries == nil {
+				entries = []state.CacheEntry{}
+			}
+			return entries, nil
+		},
+	},
+}
+
+// spacecraftHistory returns the RTLT and rate history of the named
+// spacecraft, found by its current links or else its ephemeris target,
+// or nil if it hasn't been tracked.
+func (s *Server) spacecraftHistory(name string) *spacecraftHistory {
+	id, found := 0, false
+	if data := s.state.Snapshot().Data; data != nil {
+		for _, l := range data.Links {
+			if strings.EqualFold(l.Spacecraft, name) {
+				id, found = l.SpacecraftID, true
+				break
+			}
+		}
+	}
+	if !found {
+		target, ok := ephem.GetTargetByName(name)
+		if !ok {
+			return nil
+		}
+		id = -int(target.NAIFID)
+	}
+	h := s.state.GetSpacecraftHistory(id)
+	if h == nil {
+		return nil
+	}
+	points := func(series []state.TimeSeries) []historyPoint {
+		out := make([]historyPoint, len(series))
+		for i, p := range series {
+			out[i] = historyPoint{Time: p.Timestamp, Value: p.Value}
+		}
+		return out
+	}
+	return &spacecraftHistory{
+		Spacecraft:   h.SpacecraftName,
+		SpacecraftID: h.SpacecraftID,
+		RTLT:         points(h.RTLTHistory),
+		DataRate:     points(h.RateHistory),
+	}
+}
+
+func (s *Server) handleGraphQL(w http.ResponseWriter, r *http.Request) {
+	// Other sites' pages could otherwise run queries with the user's
+	// credentials, or just load the server
+	if !s.opts.AllowCrossOrigin && !sameOrigin(r) {
+		writeJSON(w, http.StatusForbidden, gqlFailure("cross-origin request refused"))
+		return
+	}
+	var req gqlRequest
+	if r.Method == http.MethodGet {
+		q := r.URL.Query()
+		req.Query, req.OperationName = q.Get("query"), q.Get("operationName")
+		if v := q.Get("variables"); v != "" {
+			if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+				writeJSON(w, http.StatusBadRequest, gqlFailure("variables: "+err.Error()))
+				return
+			}
+		}
+	} else if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxGraphQLRequest)).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, gqlFailure("request body: "+err.Error()))
+		return
+	}
+	if req.Query == "" {
+		writeJSON(w, http.StatusBadRequest, gqlFailure("no query"))
+		return
+	}
+	resp, err := s.executeGraphQL(req)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, gqlFailure(err.Error()))
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleGraphQLSchema(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, _ = w.Write([]byte(gqlSchema()))
+}
+
+// gqlFailure is the response to a request that couldn't be executed.
+func gqlFailure(msg string) gqlResponse {
+	return gqlResponse{Errors: []gqlError{{Message: msg}}}
+}
+
+// executeGraphQL validates and runs a request's query. Errors from
+// parsing or validation are returned; errors resolving fields are in the
+// response beside the data.
+func (s *Server) executeGraphQL(req gqlRequest) (gqlResponse, error) {
+	doc, err := parseGraphQL(req.Query)
+	if err != nil {
+		return gqlResponse{}, err
+	}
+	op, err := doc.operation(req.OperationName)
+	if err != nil {
+		return gqlResponse{}, err
+	}
+	if op.kind != "query" {
+		return gqlResponse{}, fmt.Errorf("%ss are not supported; /stream pushes updates", op.kind)
+	}
+	ex := &gqlExecutor{server: s, doc: doc, vars: make(map[string]any), validated: make(map[gqlFragmentUse]int)}
+	for _, v := range op.vars {
+		val, ok := req.Variables[v.name]
+		if !ok && v.hasDef {
+			val, ok = v.def, true
+		}
+		if (!ok || val == nil) && strings.HasSuffix(v.typ, "!") {
+			return gqlResponse{}, fmt.Errorf("variable $%s of type %s is required", v.name, v.typ)
+		}
+		if ok {
+			ex.vars[v.name] = val
+		}
+	}
+	if _, err := ex.validate(op.selections, nil, 1, make(map[string]bool)); err != nil {
+		return gqlResponse{}, err
+	}
+
+	data := ex.query(op.selections)
+	return gqlResponse{Data: data, Errors: ex.errs}, nil
+}
+
+// operation returns the operation to run: the one named, or the only one.
+func (d *gqlDocument) operation(name string) (*gqlOperation, error) {
+	if name == "" {
+		if len(d.operations) > 1 {
+			return nil, errors.New("operationName is required for a document with several operations")
+		}
+		return d.operations[0], nil
+	}
+	for _, op := range d.operations {
+		if op.name == name {
+			return op, nil
+		}
+	}
+	return nil, fmt.Errorf("no operation named %q", name)
+}
+
+// gqlExecutor runs one operation.
+type gqlExecutor struct {
+	server *Server
+	doc    *gqlDocument
+	vars   map[string]any
+	errs   []gqlError
+
+	validated map[gqlFragmentUse]int // fields each fragment use expands to
+}
+
+// gqlFragmentUse is a fragment spread on an object type at a depth, which
+// validates the same wherever it appears.
+type gqlFragmentUse struct {
+	name  string
+	typ   reflect.Type
+	depth int
+}
+
+// validate checks selections against the schema: the Query type when t
+// is nil, else the object type t, at depth fields deep. spreading tracks
+// the fragments being expanded, to catch cycles. It returns the number of
+// fields selected once fragments are expanded, and fails if that or the
+// depth is over the limits. Each fragment is checked once per type and
+// depth however often it is spread.
+func (ex *gqlExecutor) validate(sels []gqlSelection, t reflect.Type, depth int, spreading map[string]bool) (int, error) {
+	typeName := "Query"
+	if t != nil {
+		typeName = gqlTypeName(t)
+	}
+	if depth > maxGraphQLDepth {
+		return 0, fmt.Errorf("query nests fields more than %d deep", maxGraphQLDepth)
+	}
+	fields := 0
+	count := func(n int) error {
+		if fields += n; fields > maxGraphQLFields {
+			return fmt.Errorf("query selects more than %d fields once fragments are expanded", maxGraphQLFields)
+		}
+		return nil
+	}
+	for _, sel := range sels {
+		for _, d := range sel.directives {
+			if d.name != "skip" && d.name != "include" {
+				return 0, fmt.Errorf("unknown directive @%s", d.name)
+			}
+			if len(d.args) != 1 || d.args[0].name != "if" {
+				return 0, fmt.Errorf("@%s takes one argument, if", d.name)
+			}
+		}
+		switch {
+		case sel.spread != "":
+			f, ok := ex.doc.fragments[sel.spread]
+			if !ok {
+				return 0, fmt.Errorf("unknown fragment %q", sel.spread)
+			}
+			use := gqlFragmentUse{f.name, t, depth}
+			n, done := ex.validated[use]
+			if !done {
+				if spreading[f.name] {
+					return 0, fmt.Errorf("fragment %q spreads itself", f.name)
+				}
+				spreading[f.name] = true
+				var err error
+				if n, err = ex.validate(f.selections, t, depth, spreading); err != nil {
+					return 0, err
+				}
+				delete(spreading, f.name)
+				ex.validated[use] = n
+			}
+			if err := count(n); err != nil {
+				return 0, err
+			}
+			continue
+		case sel.inline:
+			n, err := ex.validate(sel.selections, t, depth, spreading)
+			if err != nil {
+				return 0, err
+			}
+			if err := count(n); err != nil {
+				return 0, err
+			}
+			continue
+		case sel.name == "__typename":
+			if sel.selections != nil || sel.args != nil {
+				return 0, errors.New("__typename takes no arguments or selections")
+			}
+			if err := count(1); err != nil {
+				return 0, err
+			}
+			continue
+		}
+
+		var ft reflect.Type
+		if t == nil {
+			root := gqlRoot(sel.name)
+			if root == nil {
+				return 0, fmt.Errorf("unknown field %q on type Query", sel.name)
+			}
+			for _, a := range sel.args {
+				if !slices.ContainsFunc(root.args, func(d gqlArgDef) bool { return d.name == a.name }) {
+					return 0, fmt.Errorf("unknown argument %q on field Query.%s", a.name, sel.name)
+				}
+			}
+			for _, d := range root.args {
+				if d.required && !slices.ContainsFunc(sel.args, func(a gqlArgument) bool { return a.name == d.name }) {
+					return 0, fmt.Errorf("field Query.%s requires argument %q", sel.name, d.name)
+				}
+			}
+			ft = root.typ
+		} else {
+			f, ok := gqlFieldByName(t, sel.name)
+			if !ok {
+				return 0, fmt.Errorf("unknown field %q on type %s", sel.name, typeName)
+			}
+			if sel.args != nil {
+				return 0, fmt.Errorf("field %s.%s takes no arguments", typeName, sel.name)
+			}
+			ft = f.typ
+		}
+
+		elem := gqlElem(ft)
+		switch {
+		case gqlIsLeaf(elem) && sel.selections != nil:
+			return 0, fmt.Errorf("field %s.%s is a %s and takes no selections", typeName, sel.name, gqlScalar(elem))
+		case !gqlIsLeaf(elem) && sel.selections == nil:
+			return 0, fmt.Errorf("field %s.%s of type %s needs a selection of its fields", typeName, sel.name, gqlTypeName(elem))
+		}
+		n := 1
+		if !gqlIsLeaf(elem) {
+			sub, err := ex.validate(sel.selections, elem, depth+1, spreading)
+			if err != nil {
+				return 0, err
+			}
+			n += sub
+		}
+		if err := count(n); err != nil {
+			return 0, err
+		}
+	}
+	return fields, nil
+}
+
+// query resolves the Query type's selected fields.
+func (ex *gqlExecutor) query(sels []gqlSelection) gqlObject {
+	var out gqlObject
+	for _, f := range ex.collect(sels) {
+		if f.name == "__typename" {
+			out = append(out, gqlEntry{f.key, "Query"})
+			continue
+		}
+		root := gqlRoot(f.name)
+		path := []any{f.key}
+		args, err := ex.args(root, f.args)
+		var v any
+		if err == nil {
+			v, err = root.resolve(ex.server, args)
+		}
+		if err != nil {
+			ex.errs = append(ex.errs, gqlResolveError(err, path))
+			out = append(out, gqlEntry{f.key, nil})
+			continue
+		}
+		out = append(out, gqlEntry{f.key, ex.complete(reflect.ValueOf(v), f.selections, path)})
+	}
+	return out
+}
+
+// args coerces a root field's arguments, resolving variables.
+func (ex *gqlExecutor) args(root *gqlRootField, given []gqlArgument) (map[string]any, error) {
+	args := make(map[string]any)
+	for _, a := range given {
+		v := ex.resolve(a.value)
+		if v == nil {
+			continue
+		}
+		def := root.args[slices.IndexFunc(root.args, func(d gqlArgDef) bool { return d.name == a.name })]
+		switch def.typ {
+		case "String":
+			s, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("argument %q must be a String", a.name)
+			}
+			args[a.name] = s
+		case "Int":
+			n, ok := gqlInt(v)
+			if !ok {
+				return nil, fmt.Errorf("argument %q must be an Int", a.name)
+			}
+			args[a.name] = n
+		}
+	}
+	for _, d := range root.args {
+		if _, ok := args[d.name]; d.required && !ok {
+			return nil, fmt.Errorf("argument %q must not be null", d.name)
+		}
+	}
+	return args, nil
+}
+
+// gqlInt converts an integer literal or a whole JSON number to an int.
+func gqlInt(v any) (int, bool) {
+	switch n := v.(type) {
+	case int64:
+		return int(n), n == int64(int32(n))
+	case float64:
+		return int(n), n == float64(int32(n))
+	}
+	return 0, false
+}
+
+// resolve substitutes variables in a value.
+func (ex *gqlExecutor) resolve(v any) any {
+	switch v := v.(type) {
+	case gqlVariable:
+		return ex.vars[string(v)]
+	case []any:
+		out := make([]any, len(v))
+		for i, e := range v {
+			out[i] = ex.resolve(e)
+		}
+		return out
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, e := range v {
+			out[k] = ex.resolve(e)
+		}
+		return out
+	}
+	return v
+}
+
+// gqlCollected is a field to resolve, with the selections of every
+// occurrence of its response key merged.
+type gqlCollected struct {
+	key, name  string
+	args       []gqlArgument
+	selections []gqlSelection
+}
+
+// collect flattens fragments and drops fields skipped by @skip or
+// @include, keeping the order fields first appear in. validate has capped
+// how many fields the fragments expand to.
+func (ex *gqlExecutor) collect(sels []gqlSelection) []*gqlCollected {
+	var fields []*gqlCollected
+	index := make(map[string]int) // response key → position in fields
+	var walk func([]gqlSelection)
+	walk = func(sels []gqlSelection) {
+		for _, sel := range sels {
+			if !ex.included(sel.directives) {
+				continue
+			}
+			switch {
+			case sel.spread != "":
+				walk(ex.doc.fragments[sel.spread].selections)
+			case sel.inline:
+				walk(sel.selections)
+			default:
+				i, ok := index[sel.key()]
+				if !ok {
+					i = len(fields)
+					index[sel.key()] = i
+					fields = append(fields, &gqlCollected{key: sel.key(), name: sel.name, args: sel.args})
+				}
+				fields[i].selections = append(fields[i].selections, sel.selections...)
+			}
+		}
+	}
+	walk(sels)
+	return fields
+}
+
+// included applies @skip(if:) and @include(if:).
+func (ex *gqlExecutor) included(dirs []gqlDirective) bool {
+	for _, d := range dirs {
+		cond, _ := ex.resolve(d.args[0].value).(bool)
+		if d.name == "skip" && cond || d.name == "include" && !cond {
+			return false
+		}
+	}
+	return true
+}
+
+// complete renders v for the response: objects as their selected fields,
+// lists element by element, and leaves as their JSON encoding.
+func (ex *gqlExecutor) complete(v reflect.Value, sels []gqlSelection, path []any) any {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil
+	}
+	t := v.Type()
+	switch {
+	case gqlIsLeaf(t):
+		b, err := json.Marshal(v.Interface())
+		if err != nil {
+			ex.errs = append(ex.errs, gqlError{Message: err.Error(), Path: path})
+			return nil
+		}
+		return json.RawMessage(b)
+	case t.Kind() == reflect.Slice:
+		if v.IsNil() {
+			return nil
+		}
+		out := make([]any, v.Len())
+		for i := range out {
+			out[i] = ex.complete(v.Index(i), sels, append(path[:len(path):len(path)], i))
+		}
+		return out
+	}
+
+	out := gqlObject{}
+	for _, f := range ex.collect(sels) {
+		if f.name == "__typename" {
+			out = append(out, gqlEntry{f.key, gqlTypeName(t)})
+			continue
+		}
+		field, _ := gqlFieldByName(t, f.name)
+		out = append(out, gqlEntry{f.key, ex.complete(v.FieldByIndex(field.index), f.selections, append(path[:len(path):len(path)], f.key))})
+	}
+	return out
+}
+
+// gqlObject is a response object, its fields in the order selected.
+type gqlObject []gqlEntry
+
+type gqlEntry struct {
+	key   string
+	value any
+}
+
+// MarshalJSON implements json.Marshaler.
+func (o gqlObject) MarshalJSON() ([]byte, error) {
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, e := range o {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, _ := json.Marshal(e.key)
+		val, err := json.Marshal(e.value)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(val)
+	}
+	b.WriteByte('}')
+	return []byte(b.String()), nil
+}
+
+// gqlRoot returns the Query field called name, or nil.
+func gqlRoot(name string) *gqlRootField {
+	for i := range gqlQuery {
+		if gqlQuery[i].name == name {
+			return &gqlQuery[i]
+		}
+	}
+	return nil
+}
+
+// gqlField is a struct field as a GraphQL field.
+type gqlField struct {
+	name  string
+	index []int
+	typ   reflect.Type
+}
+
+// gqlFields lists the fields of struct type t under their JSON names,
+// including those of embedded structs, as encoding/json would.
+func gqlFields(t reflect.Type) []gqlField {
+	var fields []gqlField
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() {
+			continue
+		}
+		tag, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if tag == "-" || f.Anonymous && tag == "" && f.Type.Kind() == reflect.Struct {
+			continue
+		}
+		name := f.Name
+		if tag != "" {
+			name = tag
+		}
+		fields = append(fields, gqlField{name: name, index: f.Index, typ: f.Type})
+	}
+	return fields
+}
+
+// gqlFieldByName returns struct type t's field with JSON name name.
+func gqlFieldByName(t reflect.Type, name string) (gqlField, bool) {
+	for _, f := range gqlFields(t) {
+		if f.name == name {
+			return f, true
+		}
+	}
+	return gqlField{}, false
+}
+
+var (
+	timeType      = reflect.TypeFor[time.Time]()
+	durationType  = reflect.TypeFor[time.Duration]()
+	marshalerType = reflect.TypeFor[json.Marshaler]()
+)
+
+// gqlElem strips pointers and lists from t, leaving the named type.
+func gqlElem(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice && !gqlIsLeaf(t) {
+		t = t.Elem()
+	}
+	return t
+}
+
+// gqlIsLeaf reports whether values of t are scalars: anything but
+// structs without their own JSON encoding and lists of them.
+func gqlIsLeaf(t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == timeType || t.Implements(marshalerType) || reflect.PointerTo(t).Implements(marshalerType) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		return false
+	case reflect.Slice, reflect.Array:
+		return t.Elem().Kind() == reflect.Uint8 || gqlIsLeaf(t.Elem())
+	}
+	return true
+}
+
+// gqlScalar names leaf type t's scalar.
+func gqlScalar(t reflect.Type) string {
+	switch {
+	case t == timeType:
+		return "Time"
+	case t == durationType:
+		return "Int"
+	case t.Implements(marshalerType) || reflect.PointerTo(t).Implements(marshalerType):
+		return "JSON"
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "String"
+	case reflect.Bool:
+		return "Boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "Int"
+	case reflect.Float32, reflect.Float64:
+		return "Float"
+	case reflect.Slice, reflect.Array:
+		return "[" + gqlScalar(t.Elem()) + "]"
+	}
+	return "JSON"
+}
+
+// gqlTypeName names object type t: its Go name, capitalized, less an
+// "Export" suffix.
+func gqlTypeName(t reflect.Type) string {
+	name := strings.TrimSuffix(gqlElem(t).Name(), "Export")
+	if name == "" {
+		return name
+	}
+	r := []rune(name)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+// gqlTypeRef writes t as a GraphQL type reference: values that can't be
+// null in the JSON are non-null.
+func gqlTypeRef(t reflect.Type) string {
+	switch {
+	case t.Kind() == reflect.Pointer:
+		return strings.TrimSuffix(gqlTypeRef(t.Elem()), "!")
+	case t.Kind() == reflect.Slice && !gqlIsLeaf(t):
+		return "[" + gqlTypeRef(t.Elem()) + "]"
+	case gqlIsLeaf(t):
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Map || t.Kind() == reflect.Interface {
+			return gqlScalar(t)
+		}
+		return gqlScalar(t) + "!"
+	}
+	return gqlTypeName(t) + "!"
+}
+
+// gqlSchema returns the schema in GraphQL SDL.
+func gqlSchema() string {
+	var b strings.Builder
+	b.WriteString("# Read-only: subscribe to /stream for live updates.\n")
+	b.WriteString("scalar Time # RFC 3339\n")
+	b.WriteString("scalar JSON # any JSON value\n\n")
+
+	b.WriteString("type Query {\n")
+	var pending []reflect.Type
+	for _, f := range gqlQuery {
+		var args []string
+		for _, a := range f.args {
+			ref := a.typ
+			if a.required {
+				ref += "!"
+			}
+			args = append(args, a.name+": "+ref)
+		}
+		sig := f.name
+		if len(args) > 0 {
+			sig += "(" + strings.Join(args, ", ") + ")"
+		}
+		fmt.Fprintf(&b, "  %s: %s\n", sig, gqlTypeRef(f.typ))
+		pending = append(pending, gqlElem(f.typ))
+	}
+	b.WriteString("}\n")
+
+	seen := make(map[reflect.Type]bool)
+	var types []reflect.Type
+	for len(pending) > 0 {
+		t := pending[0]
+		pending = pending[1:]
+		if gqlIsLeaf(t) || seen[t] {
+			continue
+		}
+		seen[t] = true
+		types = append(types, t)
+		for _, f := range gqlFields(t) {
+			pending = append(pending, gqlElem(f.typ))
+		}
+	}
+	slices.SortFunc(types, func(a, b reflect.Type) int { return strings.Compare(gqlTypeName(a), gqlTypeName(b)) })
+	for _, t := range types {
+		fmt.Fprintf(&b, "\ntype %s {\n", gqlTypeName(t))
+		for _, f := range gqlFields(t) {
+			fmt.Fprintf(&b, "  %s: %s\n", f.name, gqlTypeRef(f.typ))
+		}
+		b.WriteString("}\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,645 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Minimal GraphQL query language parser (October 2021 spec, executable
+// documents only): operations with variables, fields with aliases and
+// arguments, fragments, and directives. Block strings aren't supported.
+
+// gqlDocument is a parsed query document.
+type gqlDocument struct {
+	operations []*gqlOperation
+	fragments  map[string]*gqlFragment
+}
+
+// gqlOperation is a query, mutation, or subscription.
+type gqlOperation struct {
+	kind       string // "query", "mutation", or "subscription"
+	name       string
+	vars       []gqlVarDef
+	selections []gqlSelection
+}
+
+// gqlVarDef declares an operation variable.
+type gqlVarDef struct {
+	name   string
+	typ    string // as written, e.g. "String!"
+	def    any
+	hasDef bool
+}
+
+// gqlFragment is a named fragment definition.
+type gqlFragment struct {
+	name       string
+	selections []gqlSelection
+}
+
+// gqlSelection is a field, a fragment spread (spread set), or an inline
+// fragment (inline set). Type conditions are parsed but not kept: every
+// type in the schema is concrete.
+type gqlSelection struct {
+	alias, name string
+	args        []gqlArgument
+	directives  []gqlDirective
+	selections  []gqlSelection
+	spread      string
+	inline      bool
+}
+
+// key returns the field's name in the response.
+func (s gqlSelection) key() string {
+	if s.alias != "" {
+		return s.alias
+	}
+	return s.name
+}
+
+type gqlArgument struct {
+	name  string
+	value any
+}
+
+type gqlDirective struct {
+	name string
+	args []gqlArgument
+}
+
+// gqlVariable is a reference to an operation variable in a value.
+type gqlVariable string
+
+// Token kinds.
+const (
+	tokEOF = iota
+	tokPunct
+	tokName
+	tokInt
+	tokFloat
+	tokString
+)
+
+type gqlToken struct {
+	kind int
+	text string // the punctuator, name, number, or decoded string
+	pos  int
+}
+
+type gqlParser struct {
+	src string
+	pos int
+	tok gqlToken
+}
+
+// parseGraphQL parses an executable GraphQL document.
+func parseGraphQL(src string) (*gqlDocument, error) {
+	p := &gqlParser{src: src}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	doc := &gqlDocument{fragments: make(map[string]*gqlFragment)}
+	for p.tok.kind != tokEOF {
+		switch {
+		case p.peek("{"):
+			sels, err := p.selectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, &gqlOperation{kind: "query", selections: sels})
+		case p.tok.kind == tokName && (p.tok.text == "query" || p.tok.text == "mutation" || p.tok.text == "subscription"):
+			op, err := p.operation()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, op)
+		case p.tok.kind == tokName && p.tok.text == "fragment":
+			f, err := p.fragment()
+			if err != nil {
+				return nil, err
+			}
+			if _, dup := doc.fragments[f.name]; dup {
+				return nil, fmt.Errorf("fragment %q is defined more than once", f.name)
+			}
+			doc.fragments[f.name] = f
+		default:
+			return nil, p.unexpected("a query, mutation, subscription, or fragment")
+		}
+	}
+	if len(doc.operations) == 0 {
+		return nil, errors.New("the document has no operations")
+	}
+	return doc, nil
+}
+
+func (p *gqlParser) operation() (*gqlOperation, error) {
+	op := &gqlOperation{kind: p.tok.text}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokName {
+		op.name = p.tok.text
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+	}
+	if p.peek("(") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		for !p.peek(")") {
+			v, err := p.varDef()
+			if err != nil {
+				return nil, err
+			}
+			op.vars = append(op.vars, v)
+		}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := p.directives(); err != nil {
+		return nil, err
+	}
+	sels, err := p.selectionSet()
+	if err != nil {
+		return nil, err
+	}
+	op.selections = sels
+	return op, nil
+}
+
+func (p *gqlParser) varDef() (gqlVarDef, error) {
+	var v gqlVarDef
+	if err := p.expect("$"); err != nil {
+		return v, err
+	}
+	name, err := p.name()
+	if err != nil {
+		return v, err
+	}
+	v.name = name
+	if err := p.expect(":"); err != nil {
+		return v, err
+	}
+	if v.typ, err = p.typeRef(); err != nil {
+		return v, err
+	}
+	if p.peek("=") {
+		if err := p.next(); err != nil {
+			return v, err
+		}
+		if v.def, err = p.value(true); err != nil {
+			return v, err
+		}
+		v.hasDef = true
+	}
+	_, err = p.directives()
+	return v, err
+}
+
+// typeRef parses a type reference and returns it as written.
+func (p *gqlParser) typeRef() (string, error) {
+	var t string
+	if p.peek("[") {
+		if err := p.next(); err != nil {
+			return "", err
+		}
+		elem, err := p.typeRef()
+		if err != nil {
+			return "", err
+		}
+		if err := p.expect("]"); err != nil {
+			return "", err
+		}
+		t = "[" + elem + "]"
+	} else {
+		name, err := p.name()
+		if err != nil {
+			return "", err
+		}
+		t = name
+	}
+	if p.peek("!") {
+		if err := p.next(); err != nil {
+			return "", err
+		}
+		t += "!"
+	}
+	return t, nil
+}
+
+func (p *gqlParser) fragment() (*gqlFragment, error) {
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	if name == "on" {
+		return nil, errors.New(`a fragment can't be named "on"`)
+	}
+	if err := p.typeCondition(); err != nil {
+		return nil, err
+	}
+	if _, err := p.directives(); err != nil {
+		return nil, err
+	}
+	sels, err := p.selectionSet()
+	if err != nil {
+		return nil, err
+	}
+	return &gqlFragment{name: name, selections: sels}, nil
+}
+
+// typeCondition parses "on Type".
+func (p *gqlParser) typeCondition() error {
+	if p.tok.kind != tokName || p.tok.text != "on" {
+		return p.unexpected(`"on"`)
+	}
+	if err := p.next(); err != nil {
+		return err
+	}
+	_, err := p.name()
+	return err
+}
+
+func (p *gqlParser) selectionSet() ([]gqlSelection, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var sels []gqlSelection
+	for !p.peek("}") {
+		sel, err := p.selection()
+		if err != nil {
+			return nil, err
+		}
+		sels = append(sels, sel)
+	}
+	if len(sels) == 0 {
+		return nil, p.unexpected("a field")
+	}
+	return sels, p.next()
+}
+
+func (p *gqlParser) selection() (gqlSelection, error) {
+	var sel gqlSelection
+	var err error
+	if p.peek("...") {
+		if err := p.next(); err != nil {
+			return sel, err
+		}
+		if p.tok.kind == tokName && p.tok.text != "on" {
+			sel.spread = p.tok.text
+			if err := p.next(); err != nil {
+				return sel, err
+			}
+			sel.directives, err = p.directives()
+			return sel, err
+		}
+		sel.inline = true
+		if p.tok.kind == tokName {
+			if err := p.typeCondition(); err != nil {
+				return sel, err
+			}
+		}
+		if sel.directives, err = p.directives(); err != nil {
+			return sel, err
+		}
+		sel.selections, err = p.selectionSet()
+		return sel, err
+	}
+
+	if sel.name, err = p.name(); err != nil {
+		return sel, err
+	}
+	if p.peek(":") {
+		if err := p.next(); err != nil {
+			return sel, err
+		}
+		sel.alias = sel.name
+		if sel.name, err = p.name(); err != nil {
+			return sel, err
+		}
+	}
+	if sel.args, err = p.arguments(false); err != nil {
+		return sel, err
+	}
+	if sel.directives, err = p.directives(); err != nil {
+		return sel, err
+	}
+	if p.peek("{") {
+		sel.selections, err = p.selectionSet()
+	}
+	return sel, err
+}
+
+func (p *gqlParser) arguments(isConst bool) ([]gqlArgument, error) {
+	if !p.peek("(") {
+		return nil, nil
+	}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	var args []gqlArgument
+	for !p.peek(")") {
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		v, err := p.value(isConst)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, gqlArgument{name: name, value: v})
+	}
+	if len(args) == 0 {
+		return nil, p.unexpected("an argument")
+	}
+	return args, p.next()
+}
+
+func (p *gqlParser) directives() ([]gqlDirective, error) {
+	var dirs []gqlDirective
+	for p.peek("@") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		args, err := p.arguments(false)
+		if err != nil {
+			return nil, err
+		}
+		dirs = append(dirs, gqlDirective{name: name, args: args})
+	}
+	return dirs, nil
+}
+
+// value parses a value: variables are gqlVariable, integers int64,
+// floats float64, enums strings, lists []any, and objects map[string]any.
+func (p *gqlParser) value(isConst bool) (any, error) {
+	tok := p.tok
+	switch {
+	case p.peek("$"):
+		if isConst {
+			return nil, p.unexpected("a constant value")
+		}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		name, err := p.name()
+		return gqlVariable(name), err
+	case p.peek("["):
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		list := []any{}
+		for !p.peek("]") {
+			v, err := p.value(isConst)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		}
+		return list, p.next()
+	case p.peek("{"):
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		obj := map[string]any{}
+		for !p.peek("}") {
+			name, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(":"); err != nil {
+				return nil, err
+			}
+			if obj[name], err = p.value(isConst); err != nil {
+				return nil, err
+			}
+		}
+		return obj, p.next()
+	case tok.kind == tokInt:
+		n, err := strconv.ParseInt(tok.text, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: integer %s out of range", p.position(tok.pos), tok.text)
+		}
+		return n, p.next()
+	case tok.kind == tokFloat:
+		f, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid number %s", p.position(tok.pos), tok.text)
+		}
+		return f, p.next()
+	case tok.kind == tokString:
+		return tok.text, p.next()
+	case tok.kind == tokName:
+		var v any = tok.text
+		switch tok.text {
+		case "true":
+			v = true
+		case "false":
+			v = false
+		case "null":
+			v = nil
+		}
+		return v, p.next()
+	}
+	return nil, p.unexpected("a value")
+}
+
+// name consumes a name token and returns it.
+func (p *gqlParser) name() (string, error) {
+	if p.tok.kind != tokName {
+		return "", p.unexpected("a name")
+	}
+	name := p.tok.text
+	return name, p.next()
+}
+
+// peek reports whether the current token is the punctuator s.
+func (p *gqlParser) peek(s string) bool {
+	return p.tok.kind == tokPunct && p.tok.text == s
+}
+
+// expect consumes the punctuator s.
+func (p *gqlParser) expect(s string) error {
+	if !p.peek(s) {
+		return p.unexpected(fmt.Sprintf("%q", s))
+	}
+	return p.next()
+}
+
+func (p *gqlParser) unexpected(want string) error {
+	found := fmt.Sprintf("%q", p.tok.text)
+	switch p.tok.kind {
+	case tokEOF:
+		found = "end of query"
+	case tokString:
+		found = "a string"
+	}
+	return fmt.Errorf("%s: expected %s, found %s", p.position(p.tok.pos), want, found)
+}
+
+// position formats an offset in the source as "line L, column C".
+func (p *gqlParser) position(pos int) string {
+	before := p.src[:pos]
+	line := strings.Count(before, "\n") + 1
+	col := utf8.RuneCountInString(before[strings.LastIndexByte(before, '\n')+1:]) + 1
+	return fmt.Sprintf("line %d, column %d", line, col)
+}
+
+// next reads the next token, skipping whitespace, commas, and comments.
+func (p *gqlParser) next() error {
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		if c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',' {
+			p.pos++
+			continue
+		}
+		if strings.HasPrefix(p.src[p.pos:], "\uFEFF") {
+			p.pos += len("\uFEFF")
+			continue
+		}
+		if c == '#' {
+			for p.pos < len(p.src) && p.src[p.pos] != '\n' && p.src[p.pos] != '\r' {
+				p.pos++
+			}
+			continue
+		}
+		break
+	}
+	start := p.pos
+	if p.pos >= len(p.src) {
+		p.tok = gqlToken{kind: tokEOF, pos: start}
+		return nil
+	}
+
+	c := p.src[p.pos]
+	switch {
+	case strings.HasPrefix(p.src[p.pos:], "..."):
+		p.pos += 3
+		p.tok = gqlToken{kind: tokPunct, text: "...", pos: start}
+	case strings.IndexByte("!$&()=:@[]{}|", c) >= 0:
+		p.pos++
+		p.tok = gqlToken{kind: tokPunct, text: string(c), pos: start}
+	case c == '_' || isLetter(c):
+		for p.pos < len(p.src) && (p.src[p.pos] == '_' || isLetter(p.src[p.pos]) || isDigit(p.src[p.pos])) {
+			p.pos++
+		}
+		p.tok = gqlToken{kind: tokName, text: p.src[start:p.pos], pos: start}
+	case c == '-' || isDigit(c):
+		return p.number()
+	case c == '"':
+		return p.string()
+	default:
+		r, _ := utf8.DecodeRuneInString(p.src[p.pos:])
+		return fmt.Errorf("%s: unexpected character %q", p.position(start), r)
+	}
+	return nil
+}
+
+func (p *gqlParser) number() error {
+	start := p.pos
+	digits := func() int {
+		n := 0
+		for p.pos < len(p.src) && isDigit(p.src[p.pos]) {
+			p.pos++
+			n++
+		}
+		return n
+	}
+	if p.src[p.pos] == '-' {
+		p.pos++
+	}
+	kind := tokInt
+	ok := digits() > 0
+	if ok && p.pos < len(p.src) && p.src[p.pos] == '.' {
+		p.pos++
+		kind = tokFloat
+		ok = digits() > 0
+	}
+	if ok && p.pos < len(p.src) && (p.src[p.pos] == 'e' || p.src[p.pos] == 'E') {
+		p.pos++
+		kind = tokFloat
+		if p.pos < len(p.src) && (p.src[p.pos] == '+' || p.src[p.pos] == '-') {
+			p.pos++
+		}
+		ok = digits() > 0
+	}
+	if !ok || (p.pos < len(p.src) && (p.src[p.pos] == '_' || isLetter(p.src[p.pos]) || p.src[p.pos] == '.')) {
+		return fmt.Errorf("%s: invalid number", p.position(start))
+	}
+	p.tok = gqlToken{kind: kind, text: p.src[start:p.pos], pos: start}
+	return nil
+}
+
+func (p *gqlParser) string() error {
+	start := p.pos
+	if strings.HasPrefix(p.src[p.pos:], `"""`) {
+		return fmt.Errorf("%s: block strings are not supported", p.position(start))
+	}
+	p.pos++
+	var b strings.Builder
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		switch {
+		case c == '"':
+			p.pos++
+			p.tok = gqlToken{kind: tokString, text: b.String(), pos: start}
+			return nil
+		case c == '\n' || c == '\r':
+			return fmt.Errorf("%s: unterminated string", p.position(start))
+		case c == '\\':
+			if p.pos+1 >= len(p.src) {
+				return fmt.Errorf("%s: unterminated string", p.position(start))
+			}
+			esc := p.src[p.pos+1]
+			p.pos += 2
+			switch esc {
+			case '"', '\\', '/':
+				b.WriteByte(esc)
+			case 'b':
+				b.WriteByte('\b')
+			case 'f':
+				b.WriteByte('\f')
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case 'u':
+				if p.pos+4 > len(p.src) {
+					return fmt.Errorf("%s: invalid unicode escape", p.position(p.pos-2))
+				}
+				n, err := strconv.ParseUint(p.src[p.pos:p.pos+4], 16, 32)
+				if err != nil {
+					return fmt.Errorf("%s: invalid unicode escape", p.position(p.pos-2))
+				}
+				b.WriteRune(rune(n))
+				p.pos += 4
+			default:
+				return fmt.Errorf("%s: invalid escape \\%c", p.position(p.pos-2), esc)
+			}
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+	return fmt.Errorf("%s: unterminated string", p.position(start))
+}
+
+func isLetter(c byte) bool { return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' }
+func isDigit(c byte) bool  { return c >= '0' && c <= '9' }
@@ -0,0 +1,225 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/state"
+)
+
+// postGraphQL runs a query and returns the status and the raw response.
+func postGraphQL(t *testing.T, h http.Handler, query string, vars map[string]any) (int, string) {
+	t.Helper()
+	body, _ := json.Marshal(gqlRequest{Query: query, Variables: vars})
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body)))
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", ct)
+	}
+	return rec.Code, strings.TrimSpace(rec.Body.String())
+}
+
+// compact strips the indentation writeJSON adds.
+func compact(t *testing.T, s string) string {
+	t.Helper()
+	var b bytes.Buffer
+	if err := json.Compact(&b, []byte(s)); err != nil {
+		t.Fatalf("compact %q: %v", s, err)
+	}
+	return b.String()
+}
+
+func TestGraphQL(t *testing.T) {
+	s := New(testManager(), &fakePaths{}, Options{})
+
+	// Only the fields asked for, in the order asked, from several sources
+	code, body := postGraphQL(t, s, `
+		query Dashboard($sc: String!, $withEvents: Boolean = true) {
+			snapshot { links { ...linkFields } }
+			events(spacecraft: $sc, limit: 5) @include(if: $withEvents) { type spacecraft }
+			plan: passes(spacecraft: $sc) { spacecraft __typename }
+			history(spacecraft: $sc) { spacecraft data_rate_bps { value } }
+		}
+		fragment linkFields on Link { spacecraft rate: data_rate_bps antenna_id }
+	`, map[string]any{"sc": "VGR1"})
+	if code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", code, body)
+	}
+	want := `{"data":{` +
+		`"snapshot":{"links":[{"spacecraft":"VGR1","rate":160,"antenna_id":"DSS43"}]},` +
+		`"events":[{"type":"NEW_LINK","spacecraft":"VGR1"}],` +
+		`"plan":{"spacecraft":"VGR1","__typename":"PassPlan"},` +
+		`"history":{"spacecraft":"VGR1","data_rate_bps":[{"value":160}]}}}`
+	if got := compact(t, body); got != want {
+		t.Errorf("response\n got %s\nwant %s", got, want)
+	}
+
+	// GET, and a field that fails beside one that doesn't
+	code, body = func() (int, string) {
+		rec := httptest.NewRecorder()
+		q := url.Values{"query": {`{ events(type: "HANDOFF") { type } spacecraft(name: "JWST") { name } }`}}
+		New(state.NewManager(state.DefaultConfig()), nil, Options{}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/graphql?"+q.Encode(), nil))
+		return rec.Code, rec.Body.String()
+	}()
+	want = `{"data":{"events":[],"spacecraft":null},"errors":[{"message":"no data yet","path":["spacecraft"]}]}`
+	if code != http.StatusOK || compact(t, body) != want {
+		t.Errorf("GET: status %d response %s, want %s", code, compact(t, body), want)
+	}
+}
+
+func TestGraphQL_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{"syntax", `{ snapshot { links }`, "line 1, column 21: expected a name, found end of query"},
+		{"unknown field", `{ snapshot { links { nope } } }`, `unknown field "nope" on type Link`},
+		{"unknown root", `{ weather }`, `unknown field "weather" on type Query`},
+		{"missing argument", `{ passes { spacecraft } }`, `requires argument "spacecraft"`},
+		{"object without selection", `{ snapshot }`, "needs a selection"},
+		{"scalar with selection", `{ events { type { x } } }`, "takes no selections"},
+		{"unknown fragment", `{ snapshot { ...f } }`, `unknown fragment "f"`},
+		{"fragment cycle", `{ snapshot { ...a } } fragment a on Snapshot { ...a }`, "spreads itself"},
+		{"mutation", `mutation { snapshot { timestamp } }`, "mutations are not supported"},
+		{"required variable", `query($sc: String!) { passes(spacecraft: $sc) { spacecraft } }`, "$sc of type String! is required"},
+		{"several operations", `query A { cache { key } } query B { cache { key } }`, "operationName is required"},
+	}
+	s := New(testManager(), &fakePaths{}, Options{})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, body := postGraphQL(t, s, tt.query, nil)
+			var resp struct{ Errors []gqlError }
+			if err := json.Unmarshal([]byte(body), &resp); err != nil {
+				t.Fatal(err)
+			}
+			if code != http.StatusBadRequest || len(resp.Errors) != 1 || !strings.Contains(resp.Errors[0].Message, tt.want) {
+				t.Errorf("status %d body %s, want 400 with %q", code, body, tt.want)
+			}
+		})
+	}
+}
+
+func TestGraphQL_Limits(t *testing.T) {
+	// Each fragment spreads the next twice: 2^40 fields once expanded
+	var q strings.Builder
+	q.WriteString("{ ...f0 }\n")
+	for i := range 40 {
+		fmt.Fprintf(&q, "fragment f%d on Query { ...f%d ...f%d }\n", i, i+1, i+1)
+	}
+	q.WriteString("fragment f40 on Query { cache { key } }\n")
+
+	s := New(testManager(), nil, Options{})
+	done := make(chan struct{})
+	var code int
+	var body string
+	go func() {
+		code, body = postGraphQL(t, s, q.String(), nil)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("doubling fragments were expanded")
+	}
+	if code != http.StatusBadRequest || !strings.Contains(body, "more than 5000 fields") {
+		t.Errorf("doubling fragments: status %d body %s", code, body)
+	}
+}
+
+func TestGraphQL_Depth(t *testing.T) {
+	doc, err := parseGraphQL(`{ snapshot { links { spacecraft } } }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ex := &gqlExecutor{doc: doc, validated: make(map[gqlFragmentUse]int)}
+	if _, err := ex.validate(doc.operations[0].selections, nil, maxGraphQLDepth-2, map[string]bool{}); err != nil {
+		t.Errorf("three levels ending at the limit: %v", err)
+	}
+	if _, err := ex.validate(doc.operations[0].selections, nil, maxGraphQLDepth-1, map[string]bool{}); err == nil || !strings.Contains(err.Error(), "deep") {
+		t.Errorf("three levels past the limit = %v, want a depth error", err)
+	}
+}
+
+func TestGraphQL_CrossOrigin(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query":"{ cache { key } }"}`))
+	req.Header.Set("Origin", "https://elsewhere.example")
+	rec := httptest.NewRecorder()
+	New(testManager(), nil, Options{}).ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want 403", rec.Code)
+	}
+}
+
+func TestGraphQL_ArgumentTypes(t *testing.T) {
+	s := New(testManager(), nil, Options{})
+	code, body := postGraphQL(t, s, `query($n: Int) { events(limit: $n) { type } }`, map[string]any{"n": 1.5})
+	if code != http.StatusOK || !strings.Contains(body, `argument \"limit\" must be an Int`) {
+		t.Errorf("status %d body %s, want a field error for a fractional limit", code, body)
+	}
+	code, body = postGraphQL(t, s, `{ events(limit: 0) { type } }`, nil)
+	if code != http.StatusOK || compact(t, body) != `{"data":{"events":[]}}` {
+		t.Errorf("limit 0: status %d body %s", code, body)
+	}
+}
+
+func TestGraphQLSchema(t *testing.T) {
+	rec := httptest.NewRecorder()
+	New(testManager(), nil, Options{}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/graphql/schema", nil))
+	body := rec.Body.String()
+	for _, want := range []string{
+		"type Query {",
+		"passes(spacecraft: String!): PassPlan",
+		"events(spacecraft: String, type: String, limit: Int): [Event!]",
+		"type Link {",
+		"  data_rate_bps: Float!",
+		"  code: String!", // from the embedded SpacecraftRef
+		"type HistoryPoint {",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("schema missing %q:\n%s", want, body)
+		}
+	}
+	if strings.Contains(body, "type SpacecraftRef") {
+		t.Error("embedded structs should be flattened into their parent")
+	}
+}
+
+func TestParseGraphQL(t *testing.T) {
+	doc, err := parseGraphQL(`
+		# comment
+		query Q($a: [Int!]! = [1, 2], $b: String = "x\u0041\n") {
+			x: f(a: $a, o: {k: -1.5e2, e: ENUM, n: null}) @skip(if: false) { g }
+			... on T { h }
+		}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	op := doc.operations[0]
+	if op.name != "Q" || len(op.vars) != 2 || op.vars[0].typ != "[Int!]!" || op.vars[1].def != "xA\n" {
+		t.Errorf("operation = %+v", op)
+	}
+	f := op.selections[0]
+	if f.key() != "x" || f.name != "f" || len(f.directives) != 1 || f.args[0].value != gqlVariable("a") {
+		t.Errorf("field = %+v", f)
+	}
+	if o := f.args[1].value.(map[string]any); o["k"] != -150.0 || o["e"] != "ENUM" || o["n"] != nil {
+		t.Errorf("object argument = %v", o)
+	}
+	if !op.selections[1].inline || op.selections[1].selections[0].name != "h" {
+		t.Errorf("inline fragment = %+v", op.selections[1])
+	}
+
+	for _, bad := range []string{``, `{}`, `{ f(a: 1.) }`, `{ f(a: "x) }`, `{ f(a: """x""") }`, `fragment on on T { f }`, `{ f } ^`} {
+		if _, err := parseGraphQL(bad); err == nil {
+			t.Errorf("parseGraphQL(%q) succeeded", bad)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package api
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+	"github.com/litescript/ls-horizons/internal/state"
+)
+
+func TestAcceptKey(t *testing.T) {
+	// Example from RFC 6455 section 1.3
+	if got := acceptKey("dGhlIHNhbXBsZSBub25jZQ=="); got != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Errorf("acceptKey = %q", got)
+	}
+}
+
+// dialStream performs the opening handshake against srv's /stream, from a
+// page at origin unless it is empty.
+func dialStream(t *testing.T, srv *httptest.Server, origin string) (net.Conn, *bufio.Reader) {
+	t.Helper()
+	conn, err := net.Dial("tcp", strings.TrimPrefix(srv.URL, "http://"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	req := "GET /stream HTTP/1.1\r\nHost: test\r\nUpgrade: websocket\r\nConnection: keep-alive, Upgrade\r\n" +
+		"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\nSec-WebSocket-Version: 13\r\n"
+	if origin != "" {
+		req += "Origin: " + origin + "\r\n"
+	}
+	req += "\r\n"
+	if _, err := io.WriteString(conn, req); err != nil {
+		t.Fatal(err)
+	}
+
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("handshake status = %d, want 101", resp.StatusCode)
+	}
+	if got := resp.Header.Get("Sec-WebSocket-Accept"); got != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Errorf("Sec-WebSocket-Accept = %q", got)
+	}
+	return conn, br
+}
+
+// readMessage reads one unmasked server text frame as a StreamMessage.
+func readMessage(t *testing.T, br *bufio.Reader) StreamMessage {
+	t.Helper()
+	var head [2]byte
+	if _, err := io.ReadFull(br, head[:]); err != nil {
+		t.Fatal(err)
+	}
+	if head[0] != 0x80|opText || head[1]&0x80 != 0 {
+		t.Fatalf("frame header = %#x %#x, want final unmasked text", head[0], head[1])
+	}
+	n := int(head[1])
+	if n == 126 {
+		var ext [2]byte
+		if _, err := io.ReadFull(br, ext[:]); err != nil {
+			t.Fatal(err)
+		}
+		n = int(binary.BigEndian.Uint16(ext[:]))
+	}
+	payload := make([]byte, n)
+	if _, err := io.ReadFull(br, payload); err != nil {
+		t.Fatal(err)
+	}
+	var msg StreamMessage
+	if err := json.Unmarshal(payload, &msg); err != nil {
+		t.Fatalf("decode %q: %v", payload, err)
+	}
+	return msg
+}
+
+// writeClientFrame sends a masked frame, as browsers do.
+func writeClientFrame(t *testing.T, conn net.Conn, op byte, payload []byte) {
+	t.Helper()
+	mask := [4]byte{1, 2, 3, 4}
+	frame := []byte{0x80 | op, 0x80 | byte(len(payload))}
+	frame = append(frame, mask[:]...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+	if _, err := conn.Write(frame); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStream(t *testing.T) {
+	mgr := testManager()
+	srv := httptest.NewServer(New(mgr, nil, Options{}))
+	defer srv.Close()
+
+	conn, br := dialStream(t, srv, "http://test")
+
+	// The current state arrives first
+	msg := readMessage(t, br)
+	if msg.Type != MessageDataUpdate || msg.Snapshot == nil || len(msg.Snapshot.Links) != 1 {
+		t.Fatalf("first message = %+v, want a data_update with one link", msg)
+	}
+
+	// A new link pushes the update, then its event
+	mgr.Update(&dsn.DSNData{
+		Timestamp: time.Now(),
+		Links: []dsn.Link{
+			{Spacecraft: "VGR1", SpacecraftID: 31, AntennaID: "DSS43", Complex: dsn.ComplexCanberra, DataRate: 160},
+			{Spacecraft: "JWST", SpacecraftID: 170, AntennaID: "DSS26", StationID: "gdscc", Complex: dsn.ComplexGoldstone},
+		},
+	}, 0, nil)
+
+	msg = readMessage(t, br)
+	if msg.Type != MessageDataUpdate || len(msg.Snapshot.Links) != 2 {
+		t.Errorf("update = %+v, want a data_update with two links", msg)
+	}
+	msg = readMessage(t, br)
+	if msg.Type != MessageEvent || msg.Event == nil || msg.Event.Type != state.EventNewLink || msg.Event.Spacecraft != "JWST" {
+		t.Errorf("event = %+v, want NEW_LINK for JWST", msg)
+	}
+
+	// Pings are answered, and a close is echoed
+	writeClientFrame(t, conn, opPing, []byte("hi"))
+	var pong [4]byte
+	if _, err := io.ReadFull(br, pong[:]); err != nil {
+		t.Fatal(err)
+	}
+	if pong != [4]byte{0x80 | opPong, 2, 'h', 'i'} {
+		t.Errorf("pong = %v", pong)
+	}
+
+	writeClientFrame(t, conn, opClose, nil)
+	var closeFrame [2]byte
+	if _, err := io.ReadFull(br, closeFrame[:]); err != nil {
+		t.Fatal(err)
+	}
+	if closeFrame[0] != 0x80|opClose {
+		t.Errorf("close frame = %#x, want close", closeFrame[0])
+	}
+}
+
+func TestStream_NotWebSocket(t *testing.T) {
+	var body map[string]string
+	if code := get(t, New(testManager(), nil, Options{}), "/stream", &body); code != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400", code)
+	}
+}
+
+func TestStream_CrossOrigin(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/stream", nil)
+	req.Header.Set("Origin", "https://elsewhere.example")
+	rec := httptest.NewRecorder()
+	New(testManager(), nil, Options{}).ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want 403", rec.Code)
+	}
+
+	// Allowed when the server requires auth
+	srv := httptest.NewServer(New(testManager(), nil, Options{AllowCrossOrigin: true}))
+	defer srv.Close()
+	_, br := dialStream(t, srv, "https://elsewhere.example")
+	if msg := readMessage(t, br); msg.Type != MessageDataUpdate {
+		t.Errorf("first message = %+v, want a data_update", msg)
+	}
+}
+
+func TestStream_Close(t *testing.T) {
+	s := New(testManager(), nil, Options{})
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	_, br := dialStream(t, srv, "")
+	readMessage(t, br)
+
+	s.Close()
+	var closeFrame [4]byte
+	if _, err := io.ReadFull(br, closeFrame[:]); err != nil {
+		t.Fatal(err)
+	}
+	if closeFrame != [4]byte{0x80 | opClose, 2, 0x03, 0xE9} {
+		t.Errorf("close frame = %v, want close 1001", closeFrame)
+	}
+	if _, err := br.ReadByte(); err != io.EOF {
+		t.Errorf("read after close = %v, want EOF", err)
+	}
+}
@@ -26,6 +26,22 @@ type Observer struct {
 	LatDeg float64 // Latitude in degrees (north positive)
 	LonDeg float64 // Longitude in degrees (east positive)
 	Name   string  // Optional name for the site
+
+	// AltitudeM is the site's height above the WGS84 ellipsoid in meters,
+	// and GeoidM the geoid height there (how far mean sea level lies above
+	// the ellipsoid). Both are optional; the sea-level altitude thins the
+	// air and so the refraction correction.
+	AltitudeM float64
+	GeoidM    float64
+
+	// HeightM is the observer's height above the surrounding terrain or
+	// sea in meters, which lowers the visible horizon (see HorizonDip).
+	HeightM float64
+
+	// Refraction makes elevations apparent, raised by atmospheric
+	// refraction as an antenna or an eye near the horizon sees them.
+	// Without it they are airless geometry.
+	Refraction bool
 }
 
 // EquatorialToHorizontal converts equatorial coordinates (RA/Dec) to horizontal
@@ -35,6 +51,10 @@ type Observer struct {
 // Uses standard astronomical conventions:
 //   - Azimuth: 0° = North, 90° = East, 180° = South, 270° = West
 //   - Elevation: 0° = horizon, 90° = zenith
+//
+// With obs.Refraction set, the elevation is apparent, raised by
+// atmospheric refraction (see Refraction) in the thinner air of the
+// observer's altitude.
 func EquatorialToHorizontal(eq SkyCoord, obs Observer, t time.Time) SkyCoord {
 	// Convert to radians
 	lat := degToRad(obs.LatDeg)
@@ -68,16 +88,57 @@ func EquatorialToHorizontal(eq SkyCoord, obs Observer, t time.Time) SkyCoord {
 		az = 2*math.Pi - az
 	}
 
+	el := radToDeg(alt)
+	if obs.Refraction {
+		el += obs.refraction(el)
+	}
+
 	// Return new SkyCoord with all fields populated
 	return SkyCoord{
 		RAdeg:   eq.RAdeg,
 		DecDeg:  eq.DecDeg,
 		AzDeg:   radToDeg(az),
-		ElDeg:   radToDeg(alt),
+		ElDeg:   el,
 		RangeKm: eq.RangeKm,
 	}
 }
 
+// HorizontalToEquatorial is the inverse of EquatorialToHorizontal: it
+// converts Az/El seen by an observer at time t to RA/Dec. The input Az/El
+// and range are preserved. The elevation is taken as apparent, and
+// refraction removed, when obs.Refraction is set.
+func HorizontalToEquatorial(hz SkyCoord, obs Observer, t time.Time) SkyCoord {
+	lat := degToRad(obs.LatDeg)
+	az := degToRad(hz.AzDeg)
+	el := hz.ElDeg
+	if obs.Refraction {
+		el = obs.unrefract(el)
+	}
+	alt := degToRad(el)
+
+	sinDec := math.Sin(alt)*math.Sin(lat) + math.Cos(alt)*math.Cos(lat)*math.Cos(az)
+	dec := math.Asin(sinDec)
+
+	// Hour angle from its sine and cosine, so the quadrant comes out right
+	sinHA := -math.Sin(az) * math.Cos(alt)
+	cosHA := math.Sin(alt)*math.Cos(lat) - math.Cos(alt)*math.Sin(lat)*math.Cos(az)
+	ha := math.Atan2(sinHA, cosHA)
+
+	ra := localSiderealTime(t, obs.LonDeg) - radToDeg(ha)
+	ra = math.Mod(ra, 360)
+	if ra < 0 {
+		ra += 360
+	}
+
+	return SkyCoord{
+		RAdeg:   ra,
+		DecDeg:  radToDeg(dec),
+		AzDeg:   hz.AzDeg,
+		ElDeg:   hz.ElDeg,
+		RangeKm: hz.RangeKm,
+	}
+}
+
 // localSiderealTime calculates the Local Sidereal Time in degrees
 // for a given UTC time and observer longitude.
 func localSiderealTime(t time.Time, lonDeg float64) float64 {
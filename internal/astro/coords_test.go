@@ -192,6 +192,25 @@ func TestEquatorialToHorizontal_PreservesRange(t *testing.T) {
 	}
 }
 
+func TestHorizontalToEquatorial_RoundTrip(t *testing.T) {
+	observers := []Observer{
+		{LatDeg: 35.4, LonDeg: -116.9},  // Goldstone
+		{LatDeg: -35.4, LonDeg: 148.98}, // Canberra
+	}
+	testTime := time.Date(2024, 6, 15, 7, 30, 0, 0, time.UTC)
+
+	for _, obs := range observers {
+		for _, eq := range []SkyCoord{{RAdeg: 10, DecDeg: 20}, {RAdeg: 200, DecDeg: -40}, {RAdeg: 300, DecDeg: 5}} {
+			hz := EquatorialToHorizontal(eq, obs, testTime)
+			back := HorizontalToEquatorial(hz, obs, testTime)
+			if math.Abs(back.RAdeg-eq.RAdeg) > 1e-6 || math.Abs(back.DecDeg-eq.DecDeg) > 1e-6 {
+				t.Errorf("lat %v: RA/Dec %v/%v -> Az/El %.3f/%.3f -> %v/%v",
+					obs.LatDeg, eq.RAdeg, eq.DecDeg, hz.AzDeg, hz.ElDeg, back.RAdeg, back.DecDeg)
+			}
+		}
+	}
+}
+
 func TestDegToRad(t *testing.T) {
 	tests := []struct {
 		deg float64
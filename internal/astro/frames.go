@@ -62,6 +62,10 @@ const (
 
 	// ScaleOuter uses compressed scaling for outer solar system (>5 AU)
 	ScaleOuter
+
+	// ScaleLinear maps distances linearly with no clamp, for views that
+	// fit their own range (the Orbit view's split-screen inner pane)
+	ScaleLinear
 )
 
 // ProjectionConfig configures the top-down ecliptic projection.
@@ -69,6 +73,12 @@ type ProjectionConfig struct {
 	Scale             float64   // Base scale factor
 	Mode              ScaleMode // Scaling mode
 	StarShellRadiusAU float64   // Radius for star projection (default 100 AU)
+
+	// View orientation for ProjectEcliptic, in degrees: YawDeg turns the
+	// ecliptic about its pole, then PitchDeg tilts it from face-on (0)
+	// toward edge-on (90), north up
+	YawDeg   float64
+	PitchDeg float64
 }
 
 // DefaultProjectionConfig returns a reasonable default configuration.
@@ -103,6 +113,33 @@ func ProjectEclipticTopDown(v Vec3, cfg ProjectionConfig) ProjectedPoint {
 	}
 }
 
+// ProjectEcliptic projects a 3D ecliptic vector to 2D screen coordinates
+// as seen from the orientation in cfg. Face-on it is ProjectEclipticTopDown;
+// otherwise the radial scaling applies to the full 3D distance, so bodies
+// far above or below the ecliptic keep their height off it.
+func ProjectEcliptic(v Vec3, cfg ProjectionConfig) ProjectedPoint {
+	if cfg.YawDeg == 0 && cfg.PitchDeg == 0 {
+		return ProjectEclipticTopDown(v, cfg)
+	}
+
+	r := v.Norm()
+	scaled := Vec3{}
+	if r > 0 {
+		scaled = v.Scale(scaleRadius(r, cfg) / r * cfg.Scale)
+	}
+	x, y := cfg.Rotate(scaled)
+	return ProjectedPoint{X: x, Y: y, R: r, Z: v.Z}
+}
+
+// Rotate turns v to the view orientation in cfg, without scaling, and
+// returns its screen X (right) and Y (up).
+func (cfg ProjectionConfig) Rotate(v Vec3) (x, y float64) {
+	yaw, pitch := degToRad(cfg.YawDeg), degToRad(cfg.PitchDeg)
+	x = v.X*math.Cos(yaw) - v.Y*math.Sin(yaw)
+	y = v.X*math.Sin(yaw) + v.Y*math.Cos(yaw)
+	return x, y*math.Cos(pitch) + v.Z*math.Sin(pitch)
+}
+
 // scaleRadius applies the configured scaling mode to a radial distance.
 func scaleRadius(rAU float64, cfg ProjectionConfig) float64 {
 	switch cfg.Mode {
@@ -119,6 +156,9 @@ func scaleRadius(rAU float64, cfg ProjectionConfig) float64 {
 		}
 		return rAU
 
+	case ScaleLinear:
+		return rAU
+
 	case ScaleOuter:
 		// Piece-wise scaling: linear to 5 AU, then logarithmic beyond
 		if rAU <= 5 {
@@ -202,7 +242,8 @@ const DefaultStarShellRadiusAU = 100.0
 //   - decDeg: Declination in degrees (J2000)
 //   - cfg: ProjectionConfig (uses StarShellRadiusAU, defaults to 100 AU if 0)
 //
-// Returns a ProjectedPoint with X/Y in the same coordinate space as planets.
+// Returns a ProjectedPoint with X/Y in the same coordinate space as planets,
+// following the view orientation in cfg as ProjectEcliptic does.
 func ProjectStarEclipticTopDown(raDeg, decDeg float64, cfg ProjectionConfig) ProjectedPoint {
 	// Convert RA/Dec to unit vector in equatorial frame
 	raRad := degToRad(raDeg)
@@ -227,8 +268,8 @@ func ProjectStarEclipticTopDown(raDeg, decDeg float64, cfg ProjectionConfig) Pro
 	// Scale to shell radius
 	eclScaled := ecl.Scale(shellR)
 
-	// Project using existing top-down projection
-	return ProjectEclipticTopDown(eclScaled, cfg)
+	// Project using the view orientation
+	return ProjectEcliptic(eclScaled, cfg)
 }
 
 // LightTimeFromAU returns the one-way light time for a distance in AU.
@@ -124,6 +124,46 @@ func TestProjectEclipticTopDown(t *testing.T) {
 	}
 }
 
+func TestProjectEcliptic(t *testing.T) {
+	v := Vec3{X: 3, Y: 4, Z: 2}
+	cfg := ProjectionConfig{Scale: 1, Mode: ScaleInner}
+
+	// Face-on is the top-down projection
+	if got, want := ProjectEcliptic(v, cfg), ProjectEclipticTopDown(v, cfg); got != want {
+		t.Errorf("face-on = %+v, want top-down %+v", got, want)
+	}
+
+	tests := []struct {
+		name       string
+		yaw, pitch float64
+		wantX      float64
+		wantY      float64
+	}{
+		{"yaw 90", 90, 0, -4, 3},
+		{"edge-on", 0, 90, 3, 2},
+		{"yaw 90 edge-on", 90, 90, -4, 2},
+		{"pitch 60", 0, 60, 3, 4*0.5 + 2*math.Sqrt(3)/2},
+	}
+	for _, tt := range tests {
+		cfg := ProjectionConfig{Scale: 1, Mode: ScaleInner, YawDeg: tt.yaw, PitchDeg: tt.pitch}
+		got := ProjectEcliptic(v, cfg)
+		// ScaleInner is linear below 5 AU but caps the 3D distance √29
+		k := 5 / math.Sqrt(29)
+		if math.Abs(got.X-tt.wantX*k) > 1e-9 || math.Abs(got.Y-tt.wantY*k) > 1e-9 {
+			t.Errorf("%s: got (%.4f, %.4f), want (%.4f, %.4f)", tt.name, got.X, got.Y, tt.wantX*k, tt.wantY*k)
+		}
+		if got.R != v.Norm() || got.Z != v.Z {
+			t.Errorf("%s: R %v Z %v, want %v %v", tt.name, got.R, got.Z, v.Norm(), v.Z)
+		}
+	}
+
+	// A body above the ecliptic rises on screen as the view tilts
+	high := Vec3{Z: 1}
+	if p := ProjectEcliptic(high, ProjectionConfig{Scale: 1, Mode: ScaleLogR, PitchDeg: 45}); p.Y <= 0 {
+		t.Errorf("north of ecliptic at Y %v, want above the Sun", p.Y)
+	}
+}
+
 func TestScaleModes(t *testing.T) {
 	tests := []struct {
 		name string
@@ -140,6 +180,7 @@ func TestScaleModes(t *testing.T) {
 		{"outer 1AU", ScaleOuter, 1},
 		{"outer 5AU", ScaleOuter, 5},
 		{"outer 20AU", ScaleOuter, 20},
+		{"linear 10AU", ScaleLinear, 10},
 	}
 
 	for _, tt := range tests {
@@ -166,6 +207,11 @@ func TestScaleModes(t *testing.T) {
 			if tt.mode == ScaleInner && tt.rAU > 5 && rDisplay > 5.01 {
 				t.Errorf("ScaleInner should clamp at 5, got %v for r=%v AU", rDisplay, tt.rAU)
 			}
+
+			// Linear mode never clamps
+			if tt.mode == ScaleLinear && math.Abs(rDisplay-tt.rAU) > 1e-9 {
+				t.Errorf("ScaleLinear should be identity, got %v for r=%v AU", rDisplay, tt.rAU)
+			}
 		})
 	}
 }
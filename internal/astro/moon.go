@@ -0,0 +1,47 @@
+package astro
+
+import (
+	"math"
+	"time"
+)
+
+// MoonPosition calculates the geocentric equatorial coordinates of the Moon.
+// Uses the low-precision lunar series from the Astronomical Almanac.
+// Accuracy: ~0.3 degrees, plus up to ~1 degree of topocentric parallax an
+// observer on the ground would see (ample for marking it on a sky chart).
+func MoonPosition(t time.Time) (raDeg, decDeg float64) {
+	// Julian centuries from J2000.0
+	T := (julianDate(t) - 2451545.0) / 36525.0
+
+	sinDeg := func(d float64) float64 { return math.Sin(degToRad(d)) }
+
+	// Ecliptic longitude (degrees): mean longitude plus the largest
+	// periodic terms (equation of center, evection, variation, ...)
+	lon := 218.32 + 481267.881*T +
+		6.29*sinDeg(135.0+477198.87*T) -
+		1.27*sinDeg(259.3-413335.36*T) +
+		0.66*sinDeg(235.7+890534.22*T) +
+		0.21*sinDeg(269.9+954397.74*T) -
+		0.19*sinDeg(357.5+35999.05*T) -
+		0.11*sinDeg(186.5+966404.03*T)
+	lon = normalizeAngle360(lon)
+
+	// Ecliptic latitude (degrees)
+	lat := 5.13*sinDeg(93.3+483202.02*T) +
+		0.28*sinDeg(228.2+960400.89*T) -
+		0.28*sinDeg(318.3+6003.15*T) -
+		0.17*sinDeg(217.6-407332.21*T)
+
+	// Convert to equatorial coordinates
+	lonRad, latRad := degToRad(lon), degToRad(lat)
+	ecl := Vec3{
+		X: math.Cos(latRad) * math.Cos(lonRad),
+		Y: math.Cos(latRad) * math.Sin(lonRad),
+		Z: math.Sin(latRad),
+	}
+	eq := EclipticToEquatorial(ecl)
+
+	raDeg = normalizeAngle360(radToDeg(math.Atan2(eq.Y, eq.X)))
+	decDeg = radToDeg(math.Asin(eq.Z))
+	return raDeg, decDeg
+}
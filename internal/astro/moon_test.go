@@ -0,0 +1,36 @@
+package astro
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestMoonPosition(t *testing.T) {
+	// Meeus, Astronomical Algorithms, example 47.a
+	ra, dec := MoonPosition(time.Date(1992, 4, 12, 0, 0, 0, 0, time.UTC))
+	if math.Abs(ra-134.688) > 0.5 || math.Abs(dec-13.768) > 0.5 {
+		t.Errorf("MoonPosition = RA %.3f Dec %.3f, want RA 134.688 Dec 13.768", ra, dec)
+	}
+}
+
+func TestMoonPosition_Phases(t *testing.T) {
+	tests := []struct {
+		name    string
+		time    time.Time
+		wantSep float64 // separation from the Sun
+	}{
+		{"new moon 2024-04-08 (total eclipse)", time.Date(2024, 4, 8, 18, 21, 0, 0, time.UTC), 0},
+		{"full moon 2024-04-23", time.Date(2024, 4, 23, 23, 49, 0, 0, time.UTC), 180},
+		{"first quarter 2024-04-15", time.Date(2024, 4, 15, 19, 13, 0, 0, time.UTC), 90},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ra, dec := MoonPosition(tt.time)
+			if sep := SunSeparation(ra, dec, tt.time); math.Abs(sep-tt.wantSep) > 6 {
+				t.Errorf("separation from the Sun = %.1f°, want about %.0f°", sep, tt.wantSep)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package astro
+
+import (
+	"math"
+	"time"
+)
+
+// OrbitalElements are mean Keplerian elements of a planet's heliocentric
+// orbit, referred to the J2000 ecliptic and equinox: their values at J2000
+// and their rates per Julian century. Angles are in degrees.
+type OrbitalElements struct {
+	A        float64 // Semi-major axis (AU)
+	E        float64 // Eccentricity
+	I        float64 // Inclination
+	L        float64 // Mean longitude
+	LongPeri float64 // Longitude of perihelion
+	LongNode float64 // Longitude of the ascending node
+
+	ADot, EDot, IDot, LDot, LongPeriDot, LongNodeDot float64
+}
+
+// PlanetElements are the elements of the major planets by name, from
+// Standish's "Keplerian Elements for Approximate Positions of the Major
+// Planets" (JPL), good to a few arcminutes over 1800-2050. Earth's are
+// those of the Earth-Moon barycenter.
+var PlanetElements = map[string]OrbitalElements{
+	"Mercury": {
+		A: 0.38709927, E: 0.20563593, I: 7.00497902, L: 252.25032350, LongPeri: 77.45779628, LongNode: 48.33076593,
+		ADot: 0.00000037, EDot: 0.00001906, IDot: -0.00594749, LDot: 149472.67411175, LongPeriDot: 0.16047689, LongNodeDot: -0.12534081,
+	},
+	"Venus": {
+		A: 0.72333566, E: 0.00677672, I: 3.39467605, L: 181.97909950, LongPeri: 131.60246718, LongNode: 76.67984255,
+		ADot: 0.00000390, EDot: -0.00004107, IDot: -0.00078890, LDot: 58517.81538729, LongPeriDot: 0.00268329, LongNodeDot: -0.27769418,
+	},
+	"Earth": {
+		A: 1.00000261, E: 0.01671123, I: -0.00001531, L: 100.46457166, LongPeri: 102.93768193, LongNode: 0,
+		ADot: 0.00000562, EDot: -0.00004392, IDot: -0.01294668, LDot: 35999.37244981, LongPeriDot: 0.32327364, LongNodeDot: 0,
+	},
+	"Mars": {
+		A: 1.52371034, E: 0.09339410, I: 1.84969142, L: -4.55343205, LongPeri: -23.94362959, LongNode: 49.55953891,
+		ADot: 0.00001847, EDot: 0.00007882, IDot: -0.00813131, LDot: 19140.30268499, LongPeriDot: 0.44441088, LongNodeDot: -0.29257343,
+	},
+	"Jupiter": {
+		A: 5.20288700, E: 0.04838624, I: 1.30439695, L: 34.39644051, LongPeri: 14.72847983, LongNode: 100.47390909,
+		ADot: -0.00011607, EDot: -0.00013253, IDot: -0.00183714, LDot: 3034.74612775, LongPeriDot: 0.21252668, LongNodeDot: 0.20469106,
+	},
+	"Saturn": {
+		A: 9.53667594, E: 0.05386179, I: 2.48599187, L: 49.95424423, LongPeri: 92.59887831, LongNode: 113.66242448,
+		ADot: -0.00125060, EDot: -0.00050991, IDot: 0.00193609, LDot: 1222.49362201, LongPeriDot: -0.41897216, LongNodeDot: -0.28867794,
+	},
+	"Uranus": {
+		A: 19.18916464, E: 0.04725744, I: 0.77263783, L: 313.23810451, LongPeri: 170.95427630, LongNode: 74.01692503,
+		ADot: -0.00196176, EDot: -0.00004397, IDot: -0.00242939, LDot: 428.48202785, LongPeriDot: 0.40805281, LongNodeDot: 0.04240589,
+	},
+	"Neptune": {
+		A: 30.06992276, E: 0.00859048, I: 1.77004347, L: -55.12002969, LongPeri: 44.96476227, LongNode: 131.78422574,
+		ADot: 0.00026291, EDot: 0.00005105, IDot: 0.00035372, LDot: 218.45945325, LongPeriDot: -0.32241464, LongNodeDot: -0.00508664,
+	},
+}
+
+// at returns the elements with their rates applied for time t; the rates
+// are zeroed.
+func (e OrbitalElements) at(t time.Time) OrbitalElements {
+	T := (julianDate(t) - 2451545.0) / 36525.0
+	return OrbitalElements{
+		A:        e.A + e.ADot*T,
+		E:        e.E + e.EDot*T,
+		I:        e.I + e.IDot*T,
+		L:        e.L + e.LDot*T,
+		LongPeri: e.LongPeri + e.LongPeriDot*T,
+		LongNode: e.LongNode + e.LongNodeDot*T,
+	}
+}
+
+// toEcliptic rotates a point (x, y) in the orbital plane, x toward
+// perihelion, into heliocentric ecliptic coordinates.
+func (e OrbitalElements) toEcliptic(x, y float64) Vec3 {
+	w := degToRad(e.LongPeri - e.LongNode) // argument of perihelion
+	node := degToRad(e.LongNode)
+	inc := degToRad(e.I)
+
+	cw, sw := math.Cos(w), math.Sin(w)
+	cn, sn := math.Cos(node), math.Sin(node)
+	ci, si := math.Cos(inc), math.Sin(inc)
+	return Vec3{
+		X: (cw*cn-sw*sn*ci)*x + (-sw*cn-cw*sn*ci)*y,
+		Y: (cw*sn+sw*cn*ci)*x + (-sw*sn+cw*cn*ci)*y,
+		Z: sw*si*x + cw*si*y,
+	}
+}
+
+// Position returns the heliocentric ecliptic position in AU at time t,
+// solving Kepler's equation for the eccentric anomaly.
+func (e OrbitalElements) Position(t time.Time) Vec3 {
+	el := e.at(t)
+	M := degToRad(normalizeAngle360(el.L-el.LongPeri+180) - 180)
+
+	E := M + el.E*math.Sin(M)
+	for range 20 {
+		dE := (E - el.E*math.Sin(E) - M) / (1 - el.E*math.Cos(E))
+		E -= dE
+		if math.Abs(dE) < 1e-12 {
+			break
+		}
+	}
+
+	x := el.A * (math.Cos(E) - el.E)
+	y := el.A * math.Sqrt(1-el.E*el.E) * math.Sin(E)
+	return el.toEcliptic(x, y)
+}
+
+// Orbit returns n points evenly spaced in eccentric anomaly around the
+// orbit ellipse at time t, starting at perihelion.
+func (e OrbitalElements) Orbit(t time.Time, n int) []Vec3 {
+	el := e.at(t)
+	b := el.A * math.Sqrt(1-el.E*el.E)
+	points := make([]Vec3, n)
+	for i := range points {
+		E := 2 * math.Pi * float64(i) / float64(n)
+		points[i] = el.toEcliptic(el.A*(math.Cos(E)-el.E), b*math.Sin(E))
+	}
+	return points
+}
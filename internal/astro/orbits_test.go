@@ -0,0 +1,55 @@
+package astro
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestOrbitalElements_Position(t *testing.T) {
+	j2000 := time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC)
+	// Heliocentric ecliptic positions at J2000 from the DE ephemerides
+	tests := []struct {
+		name string
+		want Vec3
+	}{
+		{"Earth", Vec3{X: -0.177, Y: 0.967, Z: 0}},
+		{"Mars", Vec3{X: 1.391, Y: -0.013, Z: -0.034}},
+		{"Jupiter", Vec3{X: 4.001, Y: 2.939, Z: -0.102}},
+	}
+	for _, tt := range tests {
+		got := PlanetElements[tt.name].Position(j2000)
+		if d := got.Sub(tt.want).Norm(); d > 0.02 {
+			t.Errorf("%s at J2000 = %+v, want %+v (off by %.3f AU)", tt.name, got, tt.want, d)
+		}
+	}
+}
+
+func TestOrbitalElements_Orbit(t *testing.T) {
+	at := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	for name, el := range PlanetElements {
+		points := el.Orbit(at, 360)
+		if len(points) != 360 {
+			t.Fatalf("%s: got %d points, want 360", name, len(points))
+		}
+
+		// Starts at perihelion; aphelion halfway round
+		cur := el.at(at)
+		if r := points[0].Norm(); math.Abs(r-cur.A*(1-cur.E)) > 1e-9 {
+			t.Errorf("%s: perihelion %v AU, want %v", name, r, cur.A*(1-cur.E))
+		}
+		if r := points[180].Norm(); math.Abs(r-cur.A*(1+cur.E)) > 1e-9 {
+			t.Errorf("%s: aphelion %v AU, want %v", name, r, cur.A*(1+cur.E))
+		}
+
+		// The planet lies on its orbit
+		pos := el.Position(at)
+		nearest := math.Inf(1)
+		for _, p := range points {
+			nearest = math.Min(nearest, p.Sub(pos).Norm())
+		}
+		if nearest > 2*math.Pi*cur.A/360 {
+			t.Errorf("%s: position %.4f AU from its orbit", name, nearest)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package astro
+
+import "math"
+
+// refractionFloorDeg is the lowest true elevation the refraction formula
+// is evaluated at; below it the correction is held at its value there.
+const refractionFloorDeg = -1.0
+
+// Refraction returns how many degrees a standard atmosphere (10 °C,
+// 1010 hPa) raises an object at true (airless) elevation elDeg, by
+// Sæmundsson's formula: about half a degree at the horizon, 0.09° at 10°,
+// and under a hundredth of a degree above 45°.
+func Refraction(elDeg float64) float64 {
+	h := max(elDeg, refractionFloorDeg)
+	arcmin := 1.02 / math.Tan(degToRad(h+10.3/(h+5.11)))
+	return max(0, arcmin/60)
+}
+
+// SeaLevelAltitude returns the observer's height above mean sea level in
+// meters: its ellipsoid altitude less the geoid height.
+func (o Observer) SeaLevelAltitude() float64 {
+	return o.AltitudeM - o.GeoidM
+}
+
+// refractionScale is how much refraction at altitudeM above sea level is
+// of that at sea level, from the pressure and temperature of the standard
+// atmosphere: about 0.9 at 1 km, where the DSN complexes are.
+func refractionScale(altitudeM float64) float64 {
+	h := max(altitudeM, 0)
+	pressure := math.Pow(1-2.25577e-5*h, 5.25588)
+	temperature := 288.15 / (288.15 - 0.0065*h)
+	return pressure * temperature
+}
+
+// refraction is Refraction at the observer's altitude.
+func (o Observer) refraction(elDeg float64) float64 {
+	return Refraction(elDeg) * refractionScale(o.SeaLevelAltitude())
+}
+
+// unrefract returns the true elevation that refraction at the observer's
+// altitude lifts to the apparent elevation elDeg.
+func (o Observer) unrefract(elDeg float64) float64 {
+	h := elDeg
+	for range 50 {
+		next := elDeg - o.refraction(h)
+		if math.Abs(next-h) < 1e-10 {
+			break
+		}
+		h = next
+	}
+	return h
+}
+
+// HorizonDip returns how many degrees the visible horizon lies below the
+// horizontal for an observer heightM above the surrounding terrain or sea:
+// about 0.1° from a 10 m rooftop, half a degree from a 300 m hill.
+// With refracted set, refraction of the horizon itself is allowed for.
+func HorizonDip(heightM float64, refracted bool) float64 {
+	if heightM <= 0 {
+		return 0
+	}
+	arcmin := 1.93 // geometric, arcminutes per √m
+	if refracted {
+		arcmin = 1.76
+	}
+	return arcmin * math.Sqrt(heightM) / 60
+}
+
+// Horizon returns the elevation of the observer's visible horizon:
+// MinElevation lowered by the dip for its height.
+func (o Observer) Horizon() float64 {
+	return MinElevation - HorizonDip(o.HeightM, o.Refraction)
+}
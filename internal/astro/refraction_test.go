@@ -0,0 +1,121 @@
+package astro
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestRefraction(t *testing.T) {
+	tests := []struct {
+		elDeg   float64
+		wantMin float64 // arcminutes
+		wantMax float64
+	}{
+		{-0.57, 33, 36}, // an object on the apparent horizon
+		{0, 28, 30},
+		{10, 5, 6},
+		{45, 0.9, 1.1},
+		{90, 0, 0.01},
+		{-30, 38, 40}, // held at its value at -1°
+	}
+	for _, tt := range tests {
+		got := Refraction(tt.elDeg) * 60
+		if got < tt.wantMin || got > tt.wantMax {
+			t.Errorf("Refraction(%v) = %.2f', want %v-%v'", tt.elDeg, got, tt.wantMin, tt.wantMax)
+		}
+	}
+
+	for _, el := range []float64{-2, -0.5, 0, 0.5, 5, 30, 89} {
+		if got := (Observer{}).unrefract(el + Refraction(el)); math.Abs(got-el) > 1e-6 {
+			t.Errorf("unrefract(%v + refraction) = %v", el, got)
+		}
+	}
+}
+
+func TestRefraction_Altitude(t *testing.T) {
+	sea := Observer{}
+	dish := Observer{AltitudeM: 1002, GeoidM: -31} // DSS-14
+	if got := dish.SeaLevelAltitude(); got != 1033 {
+		t.Errorf("SeaLevelAltitude() = %v, want 1033", got)
+	}
+	if got := sea.refraction(1); got != Refraction(1) {
+		t.Errorf("sea-level refraction = %v, want %v", got, Refraction(1))
+	}
+	if ratio := dish.refraction(1) / sea.refraction(1); ratio < 0.89 || ratio > 0.92 {
+		t.Errorf("refraction at 1 km / sea level = %.3f, want ~0.9", ratio)
+	}
+	if got := dish.unrefract(1 + dish.refraction(1)); math.Abs(got-1) > 1e-6 {
+		t.Errorf("unrefract at altitude = %v, want 1", got)
+	}
+}
+
+func TestEquatorialToHorizontal_Refraction(t *testing.T) {
+	airlessObs := testObservers["goldstone"]
+	obs := airlessObs
+	obs.Refraction = true
+	star := testStars["vega"]
+	at := time.Date(2024, 7, 15, 0, 0, 0, 0, time.UTC)
+	coord := SkyCoord{RAdeg: star.RAdeg, DecDeg: star.DecDeg}
+
+	apparent := EquatorialToHorizontal(coord, obs, at)
+	airless := EquatorialToHorizontal(coord, airlessObs, at)
+
+	if d := apparent.ElDeg - airless.ElDeg; math.Abs(d-Refraction(airless.ElDeg)) > 1e-9 {
+		t.Errorf("refracted - airless = %v, want %v", d, Refraction(airless.ElDeg))
+	}
+	if apparent.AzDeg != airless.AzDeg {
+		t.Errorf("refraction moved azimuth: %v vs %v", apparent.AzDeg, airless.AzDeg)
+	}
+
+	// Each direction inverts with the setting it was made under
+	for _, on := range []bool{false, true} {
+		obs.Refraction = on
+		low := SkyCoord{AzDeg: 120, ElDeg: 0.2}
+		back := EquatorialToHorizontal(HorizontalToEquatorial(low, obs, at), obs, at)
+		if math.Abs(back.ElDeg-low.ElDeg) > 1e-6 || math.Abs(back.AzDeg-low.AzDeg) > 1e-6 {
+			t.Errorf("refraction %v: round trip %+v, want Az 120 El 0.2", on, back)
+		}
+	}
+}
+
+func TestHorizonDip(t *testing.T) {
+	if got := HorizonDip(0, true); got != 0 {
+		t.Errorf("HorizonDip(0) = %v, want 0", got)
+	}
+	if got := HorizonDip(100, true) * 60; math.Abs(got-17.6) > 0.1 {
+		t.Errorf("HorizonDip(100) = %.2f', want 17.6'", got)
+	}
+	if got := HorizonDip(100, false) * 60; math.Abs(got-19.3) > 0.1 {
+		t.Errorf("airless HorizonDip(100) = %.2f', want 19.3'", got)
+	}
+}
+
+func TestRiseSet_HorizonDip(t *testing.T) {
+	star := testStars["vega"]
+	baseTime := time.Date(2024, 7, 15, 0, 0, 0, 0, time.UTC)
+	var samples []RADecAtTime
+	for i := 0; i <= 48*6; i++ {
+		samples = append(samples, RADecAtTime{
+			Time:   baseTime.Add(time.Duration(i) * 10 * time.Minute),
+			RAdeg:  star.RAdeg,
+			DecDeg: star.DecDeg,
+		})
+	}
+
+	ground := testObservers["goldstone"]
+	hill := ground
+	hill.HeightM = 500
+	low, err := RiseSet(ground, samples)
+	if err != nil {
+		t.Fatal(err)
+	}
+	high, err := RiseSet(hill, samples)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !high.Rise.Before(low.Rise) || !high.Set.After(low.Set) {
+		t.Errorf("from 500 m: rise %v set %v, want before %v and after %v",
+			high.Rise, high.Set, low.Rise, low.Set)
+	}
+}
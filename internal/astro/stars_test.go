@@ -1,9 +1,24 @@
 package astro
 
 import (
+	"bytes"
+	"compress/gzip"
+	"math"
+	"strings"
 	"testing"
 )
 
+func TestDefaultStarCatalog_Parses(t *testing.T) {
+	// DefaultStarCatalog drops parse errors; the embedded file must have none
+	cat, err := ReadStarCatalog(bytes.NewReader(starsCSV))
+	if err != nil {
+		t.Fatalf("built-in star catalog: %v", err)
+	}
+	if len(cat.Stars) != len(DefaultStarCatalog().Stars) {
+		t.Errorf("parsed %d stars, DefaultStarCatalog has %d", len(cat.Stars), len(DefaultStarCatalog().Stars))
+	}
+}
+
 func TestDefaultStarCatalog_NonEmpty(t *testing.T) {
 	cat := DefaultStarCatalog()
 
@@ -131,3 +146,60 @@ func TestDefaultStarCatalog_BrightestFirst(t *testing.T) {
 		}
 	}
 }
+
+// hygSample is a few rows in the HYG database's CSV layout, Sun included.
+const hygSample = `id,hip,hd,hr,gl,bf,proper,ra,dec,dist,mag
+0,,,,,,Sol,0.000000,0.000000,0.0000,-26.700
+25,,224700,,,,,0.2000,-30.0,100,6.1
+32263,32349,48915,2491,Gl 244A,9Alp CMa,Sirius,6.752481,-16.716116,2.6371,-1.440
+1,1,224700,,,,,0.000060,1.089009,219.7802,9.100
+100,100,,,,  33    Psc,,0.0224,-5.7,40,4.6
+`
+
+func TestReadStarCatalog(t *testing.T) {
+	cat, err := ReadStarCatalog(strings.NewReader(hygSample))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, s := range cat.Stars {
+		names = append(names, s.Name)
+	}
+	if got := strings.Join(names, ","); got != "Sirius,33 Psc,,HIP 1" {
+		t.Fatalf("stars = %q, want the Sun skipped, named by proper, bf, then HIP, brightest first", got)
+	}
+	if s := cat.Stars[0]; math.Abs(s.RAdeg-101.287) > 0.001 || s.DecDeg != -16.716116 {
+		t.Errorf("Sirius at %v, %v; RA should be converted from hours", s.RAdeg, s.DecDeg)
+	}
+
+	if got := len(cat.Brighter(6.5).Stars); got != 3 {
+		t.Errorf("Brighter(6.5) kept %d stars, want 3", got)
+	}
+}
+
+func TestReadStarCatalog_Gzip(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	gz.Write([]byte(hygSample))
+	gz.Close()
+
+	cat, err := ReadStarCatalog(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cat.Stars) != 4 {
+		t.Errorf("got %d stars, want 4", len(cat.Stars))
+	}
+}
+
+func TestReadStarCatalog_Errors(t *testing.T) {
+	for name, csv := range map[string]string{
+		"no mag column": "proper,ra,dec\nVega,18.6,38.8\n",
+		"bad number":    "proper,ra,dec,mag\nVega,18.6,north,0.03\n",
+		"empty":         "",
+	} {
+		if _, err := ReadStarCatalog(strings.NewReader(csv)); err == nil {
+			t.Errorf("%s: no error", name)
+		}
+	}
+}
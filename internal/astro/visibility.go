@@ -26,8 +26,9 @@ type VisibilityWindow struct {
 	NeverVisible  bool      // Object never rises
 }
 
-// MinElevation is the threshold for considering an object "visible".
-// We use a small positive value to account for atmospheric refraction.
+// MinElevation is the threshold for considering an object "visible":
+// the horizon of an observer at ground level. Refraction is already in
+// the elevations (see Refraction).
 const MinElevation = 0.0
 
 // Errors for visibility calculations.
@@ -40,12 +41,15 @@ var (
 // The samples must be in chronological order and span sufficient time to capture
 // a complete visibility cycle (typically 12-24 hours for most objects).
 //
-// The function uses linear interpolation between samples to find horizon crossings.
+// Rise and set are crossings of the observer's visible horizon (see
+// Observer.Horizon). The function uses linear interpolation between samples
+// to find them.
 // For deep space objects with slowly-changing positions, this provides good accuracy.
 func RiseSet(obs Observer, samples []RADecAtTime) (VisibilityWindow, error) {
 	if len(samples) < 3 {
 		return VisibilityWindow{}, ErrInsufficientSamples
 	}
+	horizon := obs.Horizon()
 
 	// Convert all samples to Az/El
 	type elSample struct {
@@ -73,7 +77,7 @@ func RiseSet(obs Observer, samples []RADecAtTime) (VisibilityWindow, error) {
 	}
 
 	// Check for circumpolar or never-visible objects
-	if minEl > MinElevation {
+	if minEl > horizon {
 		// Always visible - never sets
 		return VisibilityWindow{
 			Transit:       elSamples[maxElIdx].t,
@@ -82,7 +86,7 @@ func RiseSet(obs Observer, samples []RADecAtTime) (VisibilityWindow, error) {
 			AlwaysVisible: true,
 		}, nil
 	}
-	if maxEl < MinElevation {
+	if maxEl < horizon {
 		// Never visible - never rises
 		return VisibilityWindow{
 			Valid:        true,
@@ -97,9 +101,9 @@ func RiseSet(obs Observer, samples []RADecAtTime) (VisibilityWindow, error) {
 		prev := elSamples[i-1]
 		curr := elSamples[i]
 
-		if prev.elDeg <= MinElevation && curr.elDeg > MinElevation {
+		if prev.elDeg <= horizon && curr.elDeg > horizon {
 			// Interpolate to find crossing time
-			riseTime = interpolateCrossing(prev.t, curr.t, prev.elDeg, curr.elDeg, MinElevation)
+			riseTime = interpolateCrossing(prev.t, curr.t, prev.elDeg, curr.elDeg, horizon)
 			riseFound = true
 			break
 		}
@@ -123,8 +127,8 @@ func RiseSet(obs Observer, samples []RADecAtTime) (VisibilityWindow, error) {
 		prev := elSamples[i-1]
 		curr := elSamples[i]
 
-		if prev.elDeg > MinElevation && curr.elDeg <= MinElevation {
-			setTime = interpolateCrossing(prev.t, curr.t, prev.elDeg, curr.elDeg, MinElevation)
+		if prev.elDeg > horizon && curr.elDeg <= horizon {
+			setTime = interpolateCrossing(prev.t, curr.t, prev.elDeg, curr.elDeg, horizon)
 			setFound = true
 			break
 		}
@@ -132,7 +136,7 @@ func RiseSet(obs Observer, samples []RADecAtTime) (VisibilityWindow, error) {
 
 	// If no rise found, try to find it after the start of samples
 	// (object may already be up)
-	if !riseFound && elSamples[0].elDeg > MinElevation {
+	if !riseFound && elSamples[0].elDeg > horizon {
 		// Object is already visible - look for previous rise before sample window
 		// In this case, we can still report transit and set
 		riseTime = time.Time{} // Unknown rise time
@@ -143,7 +147,7 @@ func RiseSet(obs Observer, samples []RADecAtTime) (VisibilityWindow, error) {
 	transitEl := maxEl
 
 	// Refine transit time if we have a window
-	if riseFound || elSamples[0].elDeg > MinElevation {
+	if riseFound || elSamples[0].elDeg > horizon {
 		transitTime, transitEl = MaxElevation(obs, samples)
 	}
 
@@ -152,7 +156,7 @@ func RiseSet(obs Observer, samples []RADecAtTime) (VisibilityWindow, error) {
 		Transit:      transitTime,
 		Set:          setTime,
 		MaxElevation: transitEl,
-		Valid:        riseFound || setFound || elSamples[0].elDeg > MinElevation,
+		Valid:        riseFound || setFound || elSamples[0].elDeg > horizon,
 	}, nil
 }
 
@@ -0,0 +1,149 @@
+// Package backup bundles the user's ls-horizons files (config, profiles,
+// notes, bookmarks, and persistent stats) into one archive for moving to
+// another machine, and restores them from it.
+//
+// A bundle is a gzipped tar of plain files named by role ("config.toml",
+// "notes.jsonl", ...) rather than by path, so it restores to wherever the
+// new machine keeps them.
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/sandbox"
+)
+
+// MaxFileSize caps each file read from a bundle, so a corrupt or hostile
+// archive can't fill the disk.
+const MaxFileSize = 256 << 20
+
+// Item is one file in a bundle: Name in the archive, Path on this machine.
+type Item struct {
+	Name string
+	Path string
+}
+
+// Write writes the items whose files exist to w as a bundle, returning
+// the names written. Missing files are skipped: a user without bookmarks
+// has nothing to back up.
+func Write(w io.Writer, items []Item) ([]string, error) {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	var written []string
+	for _, it := range items {
+		data, err := os.ReadFile(it.Path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return written, err
+		}
+		modTime := time.Now()
+		if info, err := os.Stat(it.Path); err == nil {
+			modTime = info.ModTime()
+		}
+		hdr := &tar.Header{Name: it.Name, Mode: 0o644, Size: int64(len(data)), ModTime: modTime}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return written, err
+		}
+		if _, err := tw.Write(data); err != nil {
+			return written, err
+		}
+		written = append(written, it.Name)
+	}
+	if err := tw.Close(); err != nil {
+		return written, err
+	}
+	return written, gz.Close()
+}
+
+// Options control Restore.
+type Options struct {
+	Overwrite bool // replace files that already exist
+	DryRun    bool // report what would be restored without writing
+}
+
+// Result reports what Restore did with each entry, by name.
+type Result struct {
+	Restored []string
+	Existing []string // left alone because the file exists and Overwrite is off
+	Unknown  []string // not a file this version knows where to put
+}
+
+// Restore reads a bundle from r and writes each entry to the path dest
+// gives for its name. Entries dest doesn't know are skipped, so a bundle
+// from a newer version restores what it can.
+func Restore(r io.Reader, dest func(name string) (string, bool), opts Options) (Result, error) {
+	var res Result
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return res, fmt.Errorf("not a backup bundle: %w", err)
+	}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return res, nil
+		}
+		if err != nil {
+			return res, fmt.Errorf("read bundle: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		path, ok := dest(hdr.Name)
+		if !ok {
+			res.Unknown = append(res.Unknown, hdr.Name)
+			continue
+		}
+		if hdr.Size > MaxFileSize {
+			return res, fmt.Errorf("%s: %d bytes is over the %d limit", hdr.Name, hdr.Size, MaxFileSize)
+		}
+		if _, err := os.Stat(path); err == nil && !opts.Overwrite {
+			res.Existing = append(res.Existing, hdr.Name)
+			continue
+		}
+		if !opts.DryRun {
+			if err := writeFile(path, io.LimitReader(tr, MaxFileSize)); err != nil {
+				return res, fmt.Errorf("restore %s: %w", hdr.Name, err)
+			}
+		}
+		res.Restored = append(res.Restored, hdr.Name)
+	}
+}
+
+// writeFile writes r to path through a temporary file, so an interrupted
+// restore leaves the old file intact.
+func writeFile(path string, r io.Reader) error {
+	if err := sandbox.CheckWrite(path); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, r)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Chmod(f.Name(), 0o644)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
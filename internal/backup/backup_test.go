@@ -0,0 +1,97 @@
+package backup
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWriteAndRestore(t *testing.T) {
+	src := t.TempDir()
+	writeTestFile(t, filepath.Join(src, "config.toml"), "follow = \"VGR1\"\n")
+	writeTestFile(t, filepath.Join(src, "notes.jsonl"), "{}\n")
+	items := []Item{
+		{Name: "config.toml", Path: filepath.Join(src, "config.toml")},
+		{Name: "notes.jsonl", Path: filepath.Join(src, "notes.jsonl")},
+		{Name: "bookmarks.jsonl", Path: filepath.Join(src, "bookmarks.jsonl")}, // missing
+	}
+
+	var buf bytes.Buffer
+	written, err := Write(&buf, items)
+	if err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if !slices.Equal(written, []string{"config.toml", "notes.jsonl"}) {
+		t.Errorf("written = %v, want the two existing files", written)
+	}
+
+	dst := t.TempDir()
+	dest := func(name string) (string, bool) {
+		return filepath.Join(dst, "sub", name), name == "config.toml" || name == "notes.jsonl"
+	}
+	writeTestFile(t, filepath.Join(dst, "sub", "notes.jsonl"), "mine\n")
+
+	bundle := buf.Bytes()
+	res, err := Restore(bytes.NewReader(bundle), dest, Options{})
+	if err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if !slices.Equal(res.Restored, []string{"config.toml"}) || !slices.Equal(res.Existing, []string{"notes.jsonl"}) {
+		t.Errorf("result = %+v, want config restored and notes kept", res)
+	}
+	if got, _ := os.ReadFile(filepath.Join(dst, "sub", "config.toml")); string(got) != "follow = \"VGR1\"\n" {
+		t.Errorf("restored config = %q", got)
+	}
+	if got, _ := os.ReadFile(filepath.Join(dst, "sub", "notes.jsonl")); string(got) != "mine\n" {
+		t.Errorf("existing notes overwritten: %q", got)
+	}
+
+	res, err = Restore(bytes.NewReader(bundle), dest, Options{Overwrite: true})
+	if err != nil || len(res.Restored) != 2 {
+		t.Fatalf("Restore(Overwrite) = %+v, %v; want both restored", res, err)
+	}
+	if got, _ := os.ReadFile(filepath.Join(dst, "sub", "notes.jsonl")); string(got) != "{}\n" {
+		t.Errorf("notes after overwrite = %q", got)
+	}
+}
+
+func TestRestore_DryRunAndUnknown(t *testing.T) {
+	src := t.TempDir()
+	writeTestFile(t, filepath.Join(src, "a"), "a")
+	writeTestFile(t, filepath.Join(src, "b"), "b")
+	var buf bytes.Buffer
+	if _, err := Write(&buf, []Item{{Name: "a", Path: filepath.Join(src, "a")}, {Name: "future.db", Path: filepath.Join(src, "b")}}); err != nil {
+		t.Fatal(err)
+	}
+
+	dst := filepath.Join(t.TempDir(), "a")
+	dest := func(name string) (string, bool) { return dst, name == "a" }
+	res, err := Restore(&buf, dest, Options{DryRun: true})
+	if err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if !slices.Equal(res.Restored, []string{"a"}) || !slices.Equal(res.Unknown, []string{"future.db"}) {
+		t.Errorf("result = %+v, want a restorable and future.db unknown", res)
+	}
+	if _, err := os.Stat(dst); !os.IsNotExist(err) {
+		t.Errorf("dry run wrote %s", dst)
+	}
+}
+
+func TestRestore_NotABundle(t *testing.T) {
+	if _, err := Restore(bytes.NewReader([]byte("follow = \"VGR1\"\n")), func(string) (string, bool) { return "", false }, Options{}); err == nil {
+		t.Error("Restore of a plain file succeeded")
+	}
+}
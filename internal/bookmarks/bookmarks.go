@@ -0,0 +1,154 @@
+// Package bookmarks saves interesting moments for later: the DSN snapshot
+// on screen, the view it was shown in, and the focused spacecraft, with an
+// optional note.
+//
+// Bookmarks are stored locally as JSON Lines, one bookmark per line,
+// appended as they are taken. Each carries a full dsn.SnapshotExport, so
+// it can be replayed without the recording it came from.
+package bookmarks
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+	"github.com/litescript/ls-horizons/internal/sandbox"
+)
+
+// maxLine bounds one bookmark line; a snapshot of the full network is
+// well under this.
+const maxLine = 16 << 20
+
+// Bookmark is one saved moment.
+type Bookmark struct {
+	Time       time.Time           `json:"time"`                 // when the bookmark was taken
+	View       string              `json:"view"`                 // dashboard, mission, sky, orbit, or events
+	Spacecraft string              `json:"spacecraft,omitempty"` // focused spacecraft code, e.g. "VGR1"
+	Note       string              `json:"note,omitempty"`
+	Snapshot   *dsn.SnapshotExport `json:"snapshot"`
+}
+
+// FeedTime returns the time of the bookmarked DSN data.
+func (b Bookmark) FeedTime() time.Time {
+	if b.Snapshot == nil {
+		return time.Time{}
+	}
+	if !b.Snapshot.Timestamp.IsZero() {
+		return b.Snapshot.Timestamp
+	}
+	return b.Snapshot.FetchedAt
+}
+
+// DefaultPath returns $XDG_DATA_HOME/ls-horizons/bookmarks.jsonl, falling
+// back to ~/.local/share.
+func DefaultPath() string {
+	dir := os.Getenv("XDG_DATA_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(dir, "ls-horizons", "bookmarks.jsonl")
+}
+
+// Store is the bookmarks file and the bookmarks read from it.
+type Store struct {
+	path string
+
+	mu        sync.Mutex
+	bookmarks []Bookmark
+}
+
+// Open reads the bookmarks at path. A missing file has no bookmarks.
+func Open(path string) (*Store, error) {
+	s := &Store{path: path}
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open bookmarks: %w", err)
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	sc.Buffer(nil, maxLine)
+	for lineNo := 1; sc.Scan(); lineNo++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		var b Bookmark
+		if err := json.Unmarshal([]byte(line), &b); err != nil {
+			return nil, fmt.Errorf("%s: line %d: %w", path, lineNo, err)
+		}
+		if b.Snapshot == nil {
+			return nil, fmt.Errorf("%s: line %d: no snapshot", path, lineNo)
+		}
+		s.bookmarks = append(s.bookmarks, b)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("read bookmarks: %w", err)
+	}
+	return s, nil
+}
+
+// Add appends b, creating the file and its directory if needed.
+func (s *Store) Add(b Bookmark) (Bookmark, error) {
+	b.Time = b.Time.UTC()
+	b.Spacecraft = strings.ToUpper(b.Spacecraft)
+	b.Note = strings.TrimSpace(b.Note)
+	if b.Snapshot == nil {
+		return b, errors.New("no data to bookmark yet")
+	}
+	if err := sandbox.CheckWrite(s.path); err != nil {
+		return b, err
+	}
+	line, err := json.Marshal(b)
+	if err != nil {
+		return b, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return b, fmt.Errorf("create bookmarks directory: %w", err)
+	}
+	f, err := os.OpenFile(s.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return b, fmt.Errorf("open bookmarks: %w", err)
+	}
+	_, err = f.Write(append(line, '\n'))
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return b, fmt.Errorf("write bookmark: %w", err)
+	}
+
+	s.bookmarks = append(s.bookmarks, b)
+	return b, nil
+}
+
+// List returns the bookmarks, newest first.
+func (s *Store) List() []Bookmark {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]Bookmark, len(s.bookmarks))
+	for i, b := range s.bookmarks {
+		result[len(result)-1-i] = b
+	}
+	return result
+}
@@ -0,0 +1,81 @@
+package bookmarks
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+)
+
+func TestStore_AddAndReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", "bookmarks.jsonl")
+	s, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open missing file: %v", err)
+	}
+
+	feed := time.Date(2025, 12, 5, 6, 30, 0, 0, time.UTC)
+	snap := &dsn.SnapshotExport{
+		Timestamp: feed,
+		Links:     []dsn.LinkExport{{Spacecraft: "VGR1", AntennaID: "DSS63"}},
+	}
+	if _, err := s.Add(Bookmark{Time: feed.Add(time.Hour), View: "mission", Spacecraft: "vgr1", Note: " handoff ", Snapshot: snap}); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if _, err := s.Add(Bookmark{Time: feed.Add(2 * time.Hour), View: "sky", Snapshot: snap}); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	got := reopened.List()
+	if len(got) != 2 {
+		t.Fatalf("List = %+v, want 2 bookmarks", got)
+	}
+	if got[0].View != "sky" {
+		t.Errorf("List()[0].View = %q, want the newest bookmark first", got[0].View)
+	}
+	b := got[1]
+	if b.Spacecraft != "VGR1" || b.Note != "handoff" || b.View != "mission" {
+		t.Errorf("bookmark = %+v", b)
+	}
+	if !b.FeedTime().Equal(feed) || len(b.Snapshot.Links) != 1 || b.Snapshot.Links[0].AntennaID != "DSS63" {
+		t.Errorf("snapshot = %+v, want the bookmarked links at %v", b.Snapshot, feed)
+	}
+}
+
+func TestStore_AddNoData(t *testing.T) {
+	s, _ := Open(filepath.Join(t.TempDir(), "bookmarks.jsonl"))
+	if _, err := s.Add(Bookmark{Time: time.Now(), View: "dashboard"}); err == nil {
+		t.Error("bookmark without a snapshot should be rejected")
+	}
+	if len(s.List()) != 0 {
+		t.Error("rejected bookmark was kept")
+	}
+}
+
+func TestOpen_Malformed(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"bad json", `{"view":"sky","snapshot":{}}` + "\n\n{not json\n"},
+		{"no snapshot", `{"view":"sky","snapshot":{}}` + "\n\n" + `{"view":"sky"}` + "\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "bookmarks.jsonl")
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := Open(path); err == nil || !strings.Contains(err.Error(), "line 3") {
+				t.Errorf("Open err = %v, want line 3", err)
+			}
+		})
+	}
+}
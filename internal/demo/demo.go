@@ -0,0 +1,124 @@
+// Package demo provides an offline data source: a bundled DSN feed and
+// synthetic ephemerides consistent with it, so the full TUI runs with no
+// network.
+package demo
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/astro"
+	"github.com/litescript/ls-horizons/internal/dsn"
+	"github.com/litescript/ls-horizons/internal/ephem"
+)
+
+// FeedURL is the URL the demo fetcher reports. Requests never leave the
+// process.
+const FeedURL = "demo://dsn.xml"
+
+//go:embed feed.xml
+var feedXML []byte
+
+var (
+	// dishRe matches a dish element with its pointing and contents.
+	dishRe = regexp.MustCompile(`(?s)<dish name="(\w+)" azimuthAngle="[^"]*" elevationAngle="[^"]*"(.*?</dish>)`)
+	// targetIDRe matches a target's DSN ID within a dish.
+	targetIDRe = regexp.MustCompile(`<target name="[^"]*" id="(\d+)"`)
+	// timeRe matches the feed and station clocks.
+	timeRe = regexp.MustCompile(`timeUTC="\d+"|<timestamp>\d+</timestamp>`)
+)
+
+// Source serves the bundled feed as if it were live. Each spacecraft is
+// fixed in RA/Dec where its dish points in the bundled feed at the epoch,
+// so as time passes dishes track it across the sky and the feed, sky view,
+// and pass plans stay consistent with each other.
+type Source struct {
+	epoch  time.Time
+	coords map[ephem.TargetID]astro.SkyCoord
+}
+
+// New anchors the bundled feed at epoch, normally the start of the session.
+func New(epoch time.Time) (*Source, error) {
+	data, err := dsn.Parse(feedXML)
+	if err != nil {
+		return nil, fmt.Errorf("parse demo feed: %w", err)
+	}
+
+	s := &Source{epoch: epoch, coords: make(map[ephem.TargetID]astro.SkyCoord)}
+	for _, st := range data.Stations {
+		for _, ant := range st.Antennas {
+			obs := dsn.ObserverForAntenna(ant.ID, st.Complex)
+			for _, tgt := range ant.Targets {
+				id := ephem.TargetID(-tgt.ID)
+				if _, ok := s.coords[id]; ok {
+					continue
+				}
+				hz := astro.SkyCoord{AzDeg: ant.Azimuth, ElDeg: ant.Elevation, RangeKm: tgt.DownlegRange}
+				s.coords[id] = astro.HorizontalToEquatorial(hz, obs, epoch)
+			}
+		}
+	}
+	return s, nil
+}
+
+// Feed returns the bundled DSN XML as of t: clocks read t and each
+// tracking dish points at its first target.
+func (s *Source) Feed(t time.Time) []byte {
+	ms := strconv.FormatInt(t.UnixMilli(), 10)
+	out := timeRe.ReplaceAllFunc(feedXML, func(m []byte) []byte {
+		if bytes.HasPrefix(m, []byte("timeUTC")) {
+			return []byte(`timeUTC="` + ms + `"`)
+		}
+		return []byte("<timestamp>" + ms + "</timestamp>")
+	})
+
+	return dishRe.ReplaceAllFunc(out, func(m []byte) []byte {
+		sub := dishRe.FindSubmatch(m)
+		name, rest := string(sub[1]), sub[2]
+		tm := targetIDRe.FindSubmatch(rest)
+		if tm == nil {
+			return m // idle dish: leave it parked
+		}
+		id, _ := strconv.Atoi(string(tm[1]))
+		coord, ok := s.coords[ephem.TargetID(-id)]
+		if !ok {
+			return m
+		}
+		hz := astro.EquatorialToHorizontal(coord, dsn.ObserverForAntenna(name, ""), t)
+		return fmt.Appendf(nil, `<dish name="%s" azimuthAngle="%.1f" elevationAngle="%.1f"%s`,
+			name, hz.AzDeg, hz.ElDeg, rest)
+	})
+}
+
+// RoundTrip implements http.RoundTripper, answering every request with
+// the feed as of now.
+func (s *Source) RoundTrip(req *http.Request) (*http.Response, error) {
+	body := s.Feed(time.Now())
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {"application/xml"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// Fetcher returns a DSN fetcher that reads the demo feed.
+func (s *Source) Fetcher() *dsn.Fetcher {
+	return dsn.NewFetcher(dsn.WithURL(FeedURL), dsn.WithHTTPClient(&http.Client{Transport: s}))
+}
+
+// Ephemeris returns the synthetic ephemeris for the demo spacecraft.
+func (s *Source) Ephemeris() *Ephemeris {
+	return &Ephemeris{coords: s.coords}
+}
@@ -0,0 +1,120 @@
+package demo
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+	"github.com/litescript/ls-horizons/internal/ephem"
+)
+
+var testEpoch = time.Date(2025, 12, 4, 15, 0, 0, 0, time.UTC)
+
+func antenna(t *testing.T, data *dsn.DSNData, id string) dsn.Antenna {
+	t.Helper()
+	for _, st := range data.Stations {
+		for _, ant := range st.Antennas {
+			if ant.ID == id {
+				return ant
+			}
+		}
+	}
+	t.Fatalf("antenna %s not in feed", id)
+	return dsn.Antenna{}
+}
+
+func TestFeed(t *testing.T) {
+	src, err := New(testEpoch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bundled, _ := dsn.Parse(feedXML)
+
+	// At the epoch, dishes point where the bundled feed has them
+	atEpoch, err := dsn.Parse(src.Feed(testEpoch))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !atEpoch.Timestamp.Equal(testEpoch) {
+		t.Errorf("Timestamp = %v, want %v", atEpoch.Timestamp, testEpoch)
+	}
+	if len(atEpoch.Links) != len(bundled.Links) {
+		t.Errorf("links = %d, want %d", len(atEpoch.Links), len(bundled.Links))
+	}
+	for _, id := range []string{"DSS14", "DSS43", "DSS63"} {
+		got, want := antenna(t, atEpoch, id), antenna(t, bundled, id)
+		if math.Abs(got.Azimuth-want.Azimuth) > 0.15 || math.Abs(got.Elevation-want.Elevation) > 0.15 {
+			t.Errorf("%s at epoch = %.1f/%.1f, want %.1f/%.1f", id, got.Azimuth, got.Elevation, want.Azimuth, want.Elevation)
+		}
+	}
+
+	// An hour later the clocks have moved and tracking dishes have turned,
+	// while the idle one stays parked
+	later, err := dsn.Parse(src.Feed(testEpoch.Add(time.Hour)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !later.Timestamp.Equal(testEpoch.Add(time.Hour)) || !later.Stations[0].TimeUTC.Equal(testEpoch.Add(time.Hour)) {
+		t.Errorf("clocks = %v / %v, want an hour after the epoch", later.Timestamp, later.Stations[0].TimeUTC)
+	}
+	if a, b := antenna(t, later, "DSS14"), antenna(t, bundled, "DSS14"); a.Azimuth == b.Azimuth {
+		t.Error("DSS14 didn't move in an hour")
+	}
+	if a, b := antenna(t, later, "DSS34"), antenna(t, bundled, "DSS34"); a.Azimuth != b.Azimuth || a.Elevation != b.Elevation {
+		t.Error("idle DSS34 moved")
+	}
+}
+
+func TestFetcher(t *testing.T) {
+	src, err := New(time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := src.Fetcher().Fetch(context.Background())
+	if result.Error != nil {
+		t.Fatalf("Fetch: %v", result.Error)
+	}
+	if len(result.Data.Links) == 0 {
+		t.Error("demo feed has no links")
+	}
+	if time.Since(result.Data.Timestamp) > time.Minute {
+		t.Errorf("Timestamp = %v, want about now", result.Data.Timestamp)
+	}
+}
+
+func TestEphemeris(t *testing.T) {
+	src, err := New(testEpoch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	eph := src.Ephemeris()
+
+	if !eph.Available(ephem.NAIFVoyager1) || eph.Available(ephem.NAIFHubble) {
+		t.Error("Available should cover exactly the demo spacecraft")
+	}
+
+	// The synthetic position matches the dish pointing at any time
+	at := testEpoch.Add(3 * time.Hour)
+	feed, _ := dsn.Parse(src.Feed(at))
+	dss63 := antenna(t, feed, "DSS63")
+	point, err := eph.GetPosition(ephem.NAIFVoyager1, at, dsn.ObserverForAntenna("DSS63", dsn.ComplexMadrid))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(point.Coord.AzDeg-dss63.Azimuth) > 0.15 || math.Abs(point.Coord.ElDeg-dss63.Elevation) > 0.15 {
+		t.Errorf("VGR1 = %.1f/%.1f, DSS63 points at %.1f/%.1f", point.Coord.AzDeg, point.Coord.ElDeg, dss63.Azimuth, dss63.Elevation)
+	}
+
+	samples, err := eph.GetRADecPath(ephem.NAIFVoyager1, testEpoch, testEpoch.Add(24*time.Hour), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(samples) != 25 {
+		t.Errorf("samples = %d, want 25", len(samples))
+	}
+	if _, err := eph.GetRADecPath(ephem.NAIFHubble, testEpoch, testEpoch.Add(time.Hour), time.Hour); err == nil {
+		t.Error("GetRADecPath succeeded for a target not in the demo")
+	}
+}
@@ -0,0 +1,75 @@
+package demo
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/astro"
+	"github.com/litescript/ls-horizons/internal/ephem"
+)
+
+var errStep = errors.New("step must be positive")
+
+// Ephemeris is a synthetic ephemeris provider: each demo spacecraft sits
+// at a fixed RA/Dec, which is close enough for distant spacecraft over a
+// session. It implements ephem.Provider and ephem.RADecProvider.
+type Ephemeris struct {
+	coords map[ephem.TargetID]astro.SkyCoord
+}
+
+// Name implements ephem.Provider.
+func (e *Ephemeris) Name() string {
+	return "Demo"
+}
+
+// GetPosition implements ephem.Provider.
+func (e *Ephemeris) GetPosition(target ephem.TargetID, t time.Time, obs astro.Observer) (ephem.EphemerisPoint, error) {
+	coord, ok := e.coords[target]
+	if !ok {
+		return ephem.EphemerisPoint{Valid: false}, fmt.Errorf("target %d not in demo data", target)
+	}
+	return ephem.EphemerisPoint{
+		Time:  t,
+		Coord: astro.EquatorialToHorizontal(coord, obs, t),
+		Valid: true,
+	}, nil
+}
+
+// GetPath implements ephem.Provider.
+func (e *Ephemeris) GetPath(target ephem.TargetID, start, end time.Time, step time.Duration, obs astro.Observer) (ephem.EphemerisPath, error) {
+	if step <= 0 {
+		return ephem.EphemerisPath{}, errStep
+	}
+	path := ephem.EphemerisPath{TargetID: target, Start: start, End: end}
+	for t := start; !t.After(end); t = t.Add(step) {
+		point, err := e.GetPosition(target, t, obs)
+		if err != nil {
+			return ephem.EphemerisPath{}, err
+		}
+		path.Points = append(path.Points, point)
+	}
+	return path, nil
+}
+
+// Available implements ephem.Provider.
+func (e *Ephemeris) Available(target ephem.TargetID) bool {
+	_, ok := e.coords[target]
+	return ok
+}
+
+// GetRADecPath implements ephem.RADecProvider.
+func (e *Ephemeris) GetRADecPath(target ephem.TargetID, start, end time.Time, step time.Duration) ([]astro.RADecAtTime, error) {
+	coord, ok := e.coords[target]
+	if !ok {
+		return nil, fmt.Errorf("target %d not in demo data", target)
+	}
+	if step <= 0 {
+		return nil, errStep
+	}
+	var samples []astro.RADecAtTime
+	for t := start; !t.After(end); t = t.Add(step) {
+		samples = append(samples, astro.RADecAtTime{Time: t, RAdeg: coord.RAdeg, DecDeg: coord.DecDeg})
+	}
+	return samples, nil
+}
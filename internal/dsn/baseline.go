@@ -0,0 +1,53 @@
+package dsn
+
+import "fmt"
+
+// RateBand identifies a spacecraft's downlink on one band, the unit rate
+// baselines are kept per: the same spacecraft can run kbps engineering
+// telemetry on S band and Mbps science on Ka.
+type RateBand struct {
+	Spacecraft string // canonical code
+	Band       string
+}
+
+// NewRateBand returns the RateBand for a spacecraft code and band.
+func NewRateBand(spacecraft, band string) RateBand {
+	return RateBand{Spacecraft: CanonicalCode(spacecraft), Band: band}
+}
+
+// bundledRateBaselines are typical downlink rates, in bps, for spacecraft
+// whose rates are well known and stable. Learned baselines (see the
+// sightings package) take over once there is enough history.
+var bundledRateBaselines = map[RateBand]float64{
+	{"VGR1", "X"}:  160,
+	{"VGR2", "X"}:  160,
+	{"JWST", "S"}:  40e3,
+	{"JWST", "Ka"}: 28e6,
+	{"MRO", "X"}:   2e6,
+}
+
+// BundledRateBaseline returns the bundled typical rate for a spacecraft
+// on a band.
+func BundledRateBaseline(spacecraft, band string) (float64, bool) {
+	bps, ok := bundledRateBaselines[NewRateBand(spacecraft, band)]
+	return bps, ok
+}
+
+// RateShortfallFlag is how far below its baseline (0-1) a link's rate
+// must be before it is flagged.
+const RateShortfallFlag = 0.75
+
+// RateShortfall returns how far below baseline a rate is, from 0 (at or
+// above it) to 1 (nothing). Unknown baselines and zero rates, which are
+// carrier locks or idle links rather than slow ones, give 0.
+func RateShortfall(rate, baseline float64) float64 {
+	if baseline <= 0 || rate <= 0 || rate >= baseline {
+		return 0
+	}
+	return 1 - rate/baseline
+}
+
+// FormatRateShortfall describes a shortfall: "rate 80% below normal".
+func FormatRateShortfall(shortfall float64) string {
+	return fmt.Sprintf("rate %.0f%% below normal", shortfall*100)
+}
@@ -0,0 +1,44 @@
+package dsn
+
+import "testing"
+
+func TestRateShortfall(t *testing.T) {
+	tests := []struct {
+		rate, baseline, want float64
+	}{
+		{32, 160, 0.8},
+		{160, 160, 0},
+		{320, 160, 0},
+		{0, 160, 0}, // carrier lock or idle, not slow
+		{32, 0, 0},  // no baseline
+	}
+	for _, tt := range tests {
+		if got := RateShortfall(tt.rate, tt.baseline); got != tt.want {
+			t.Errorf("RateShortfall(%v, %v) = %v, want %v", tt.rate, tt.baseline, got, tt.want)
+		}
+	}
+	if got, want := FormatRateShortfall(0.8), "rate 80% below normal"; got != want {
+		t.Errorf("FormatRateShortfall = %q, want %q", got, want)
+	}
+}
+
+func TestBundledRateBaseline(t *testing.T) {
+	if bps, ok := BundledRateBaseline("vgr1", "X"); !ok || bps != 160 {
+		t.Errorf("VGR1 X = %v, %v; want 160", bps, ok)
+	}
+	ka, _ := BundledRateBaseline("JWST", "Ka")
+	s, _ := BundledRateBaseline("JWST", "S")
+	if ka <= s {
+		t.Errorf("JWST Ka %v, S %v: baselines should be per band", ka, s)
+	}
+	if _, ok := BundledRateBaseline("VGR1", "S"); ok {
+		t.Error("baseline for a band not bundled")
+	}
+
+	views := BuildSpacecraftViews(&DSNData{Links: []Link{
+		{Spacecraft: "VGR1", SpacecraftID: 31, AntennaID: "DSS43", Band: "X", DataRate: 32},
+	}}, nil)
+	if len(views) != 1 || views[0].Links[0].Baseline != 160 || views[0].Links[0].RateShortfall() != 0.8 {
+		t.Errorf("views = %+v, want VGR1 80%% below its 160 bps baseline", views)
+	}
+}
@@ -0,0 +1,148 @@
+package dsn
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultMinContention is how many spacecraft must peak together at a
+// complex for the period to count as contended.
+const DefaultMinContention = 2
+
+// Contention is a period when several spacecraft are near the peak of
+// their passes over the same complex at once, and so compete for its
+// best-placed antennas.
+type Contention struct {
+	Complex    Complex
+	Start      time.Time
+	End        time.Time
+	Spacecraft []string // every spacecraft peaking during the period, by peak time
+	MaxAtOnce  int      // the most peaking at the same moment
+}
+
+// PeakWindowHalfWidth is how long either side of its peak a pass counts
+// as peaking: the spacecraft is near its highest, and the pass most worth
+// scheduling. Deep-space passes last most of a day, so overlapping whole
+// passes would flag nearly everything.
+const PeakWindowHalfWidth = time.Hour
+
+// peakWindow returns when p counts as peaking, within the pass.
+func peakWindow(p Pass) (time.Time, time.Time) {
+	from, to := p.Peak.Add(-PeakWindowHalfWidth), p.Peak.Add(PeakWindowHalfWidth)
+	if from.Before(p.Start) {
+		from = p.Start
+	}
+	if to.After(p.End) {
+		to = p.End
+	}
+	return from, to
+}
+
+// FindContention overlays the peak windows of every plan's passes per
+// complex and returns the periods when at least atOnce spacecraft peak at
+// once, by complex (in ComplexOrder) and then start time. Overlapping
+// periods are merged, so one long crunch is reported once.
+func FindContention(plans []*PassPlan, atOnce int) []Contention {
+	atOnce = max(atOnce, 2)
+
+	type edge struct {
+		at    time.Time
+		delta int // +1 as a window opens, -1 as it closes
+		code  string
+		peak  time.Time
+	}
+	var out []Contention
+	for _, c := range ComplexOrder {
+		var edges []edge
+		for _, plan := range plans {
+			if plan == nil {
+				continue
+			}
+			for _, p := range plan.Passes {
+				// A peak at either end of the pass is where the plan
+				// window cut it off, not when it really peaks
+				if p.Complex != c || !p.Peak.After(p.Start) || !p.End.After(p.Peak) {
+					continue
+				}
+				from, to := peakWindow(p)
+				edges = append(edges,
+					edge{at: from, delta: 1, code: plan.SpacecraftCode, peak: p.Peak},
+					edge{at: to, delta: -1, code: plan.SpacecraftCode, peak: p.Peak})
+			}
+		}
+		// Close windows before opening others at the same instant, so
+		// back-to-back passes don't count as overlapping
+		sort.Slice(edges, func(i, j int) bool {
+			if !edges[i].at.Equal(edges[j].at) {
+				return edges[i].at.Before(edges[j].at)
+			}
+			return edges[i].delta < edges[j].delta
+		})
+
+		type peaking struct {
+			code string
+			peak time.Time
+		}
+		var open []peaking // windows open now
+		var cur *Contention
+		var involved []peaking
+		for _, e := range edges {
+			if e.delta > 0 {
+				open = append(open, peaking{e.code, e.peak})
+			} else if i := slices.Index(open, peaking{e.code, e.peak}); i >= 0 {
+				open = slices.Delete(open, i, i+1)
+			}
+
+			switch {
+			case len(open) >= atOnce && cur == nil:
+				cur = &Contention{Complex: c, Start: e.at}
+				involved = slices.Clone(open)
+			case len(open) >= atOnce && e.delta > 0:
+				involved = append(involved, open[len(open)-1])
+			case len(open) < atOnce && cur != nil:
+				cur.End = e.at
+				sort.SliceStable(involved, func(i, j int) bool { return involved[i].peak.Before(involved[j].peak) })
+				for _, p := range involved {
+					if !slices.Contains(cur.Spacecraft, p.code) {
+						cur.Spacecraft = append(cur.Spacecraft, p.code)
+					}
+				}
+				out = append(out, *cur)
+				cur = nil
+			}
+			if cur != nil {
+				cur.MaxAtOnce = max(cur.MaxAtOnce, len(open))
+			}
+		}
+	}
+	return out
+}
+
+// WriteContention prints the contention periods between from and to, one
+// section per complex, with times in loc.
+func WriteContention(w io.Writer, periods []Contention, from, to time.Time, atOnce int, loc *time.Location) {
+	fmt.Fprintf(w, "Pass contention  %s → %s  (%d+ spacecraft peaking together)\n",
+		from.In(loc).Format("Mon 15:04"), to.In(loc).Format("Mon 15:04 MST"), max(atOnce, 2))
+	fmt.Fprintln(w, strings.Repeat("─", 60))
+
+	for _, c := range ComplexOrder {
+		fmt.Fprintln(w, siteComplexName(c))
+		n := 0
+		for _, p := range periods {
+			if p.Complex != c {
+				continue
+			}
+			n++
+			fmt.Fprintf(w, "  %s–%s  %-7s %d at once  %s\n",
+				p.Start.In(loc).Format("Mon 15:04"), p.End.In(loc).Format("15:04"),
+				formatSessionLength(p.End.Sub(p.Start)), p.MaxAtOnce, strings.Join(p.Spacecraft, " "))
+		}
+		if n == 0 {
+			fmt.Fprintln(w, "  No contention")
+		}
+	}
+}
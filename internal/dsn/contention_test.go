@@ -0,0 +1,98 @@
+package dsn
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFindContention(t *testing.T) {
+	base := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	at := func(h float64) time.Time { return base.Add(time.Duration(h * float64(time.Hour))) }
+	// pass peaks at peak in a pass eight hours long, so it counts as
+	// peaking from an hour before to an hour after
+	pass := func(c Complex, peak float64) Pass {
+		return Pass{Complex: c, Start: at(peak - 4), Peak: at(peak), End: at(peak + 4)}
+	}
+	plans := []*PassPlan{
+		{SpacecraftCode: "VGR1", Passes: []Pass{pass(ComplexGoldstone, 3)}}, // peaking 2–4
+		{SpacecraftCode: "JWST", Passes: []Pass{pass(ComplexGoldstone, 4)}}, // peaking 3–5
+		{SpacecraftCode: "MRO", Passes: []Pass{
+			pass(ComplexGoldstone, 3.5), // peaking 2:30–4:30
+			pass(ComplexCanberra, 14),   // peaking 13–15
+		}},
+		{SpacecraftCode: "NHPC", Passes: []Pass{pass(ComplexCanberra, 17)}}, // peaking 16–18: no overlap
+		nil,
+	}
+
+	got := FindContention(plans, 2)
+	if len(got) != 1 {
+		t.Fatalf("got %d periods, want 1: %+v", len(got), got)
+	}
+	c := got[0]
+	if c.Complex != ComplexGoldstone || !c.Start.Equal(at(2.5)) || !c.End.Equal(at(4.5)) {
+		t.Errorf("period = %s %v–%v, want Goldstone 02:30–04:30", c.Complex, c.Start, c.End)
+	}
+	if !slices.Equal(c.Spacecraft, []string{"VGR1", "MRO", "JWST"}) {
+		t.Errorf("spacecraft = %v, want by peak time", c.Spacecraft)
+	}
+	if c.MaxAtOnce != 3 {
+		t.Errorf("MaxAtOnce = %d, want 3", c.MaxAtOnce)
+	}
+
+	if got := FindContention(plans, 3); len(got) != 1 || !got[0].Start.Equal(at(3)) || !got[0].End.Equal(at(4)) {
+		t.Errorf("3 at once = %+v, want 03:00–04:00", got)
+	}
+	if got := FindContention(plans, 4); len(got) != 0 {
+		t.Errorf("4 at once = %+v, want none", got)
+	}
+}
+
+func TestFindContention_BackToBack(t *testing.T) {
+	base := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	plans := []*PassPlan{
+		{SpacecraftCode: "A", Passes: []Pass{{Complex: ComplexMadrid, Start: base, Peak: base.Add(2 * time.Hour), End: base.Add(6 * time.Hour)}}},
+		// Peaking from 03:00, as A stops
+		{SpacecraftCode: "B", Passes: []Pass{{Complex: ComplexMadrid, Start: base.Add(2 * time.Hour), Peak: base.Add(4 * time.Hour), End: base.Add(6 * time.Hour)}}},
+	}
+	if got := FindContention(plans, 2); len(got) != 0 {
+		t.Errorf("back-to-back peaks reported as contention: %+v", got)
+	}
+}
+
+func TestWriteContention(t *testing.T) {
+	start := time.Date(2025, 3, 1, 4, 0, 0, 0, time.UTC)
+	periods := []Contention{{
+		Complex:    ComplexGoldstone,
+		Start:      start,
+		End:        start.Add(3 * time.Hour),
+		Spacecraft: []string{"VGR1", "MRO", "JWST"},
+		MaxAtOnce:  3,
+	}}
+	var buf bytes.Buffer
+	WriteContention(&buf, periods, start, start.Add(24*time.Hour), 2, time.UTC)
+	out := buf.String()
+
+	for _, want := range []string{"2+ spacecraft", "Goldstone", "Sat 04:00–07:00", "3 at once  VGR1 MRO JWST"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+	if strings.Count(out, "No contention") != 2 {
+		t.Errorf("Canberra and Madrid should report no contention:\n%s", out)
+	}
+}
+
+func TestFindContention_CutOffPeak(t *testing.T) {
+	base := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	plans := []*PassPlan{
+		{SpacecraftCode: "A", Passes: []Pass{{Complex: ComplexMadrid, Start: base, Peak: base.Add(time.Hour), End: base.Add(3 * time.Hour)}}},
+		// Already setting when the plan starts: its peak is the plan's edge
+		{SpacecraftCode: "B", Passes: []Pass{{Complex: ComplexMadrid, Start: base, Peak: base, End: base.Add(2 * time.Hour)}}},
+	}
+	if got := FindContention(plans, 2); len(got) != 0 {
+		t.Errorf("cut-off peak reported as contention: %+v", got)
+	}
+}
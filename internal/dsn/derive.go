@@ -37,74 +37,78 @@ const (
 	HealthGood     Health = "GOOD"
 	HealthMarginal Health = "MARGINAL"
 	HealthPoor     Health = "POOR"
+
+	// HealthCarrier marks a carrier lock: the link is up with no data
+	// modulated, which is normal and says nothing about link margin.
+	HealthCarrier Health = "CARRIER"
 )
 
-// StruggleIndex calculates a difficulty metric for a communication link.
-// Returns a value from 0 (easy) to 1 (difficult).
-//
-// Factors and weights:
-//   - Distance (40%): log scale from 100k km (0) to 10B km (1)
-//   - Data rate (30%): log scale from 1 Mbps (0) to 100 bps (1)
-//   - Elevation (20%): 45°+ is easy (0), 0° is hard (1)
-//   - Signal quality (10%): if available, inverted 0-1 scale
-func StruggleIndex(link Link, elevation float64) float64 {
-	var score float64
-
-	// Distance factor: farther = harder
-	// Use log scale since distances vary enormously (Moon vs Voyager)
-	if link.Distance > 0 {
-		// Normalize: Moon ~384,400 km = 0.1, Mars ~225M km = 0.5, Voyager ~24B km = 1.0
-		logDist := math.Log10(link.Distance)
-		distFactor := clamp((logDist-5)/(10-5), 0, 1) // log(100k) to log(10B)
-		score += distFactor * 0.4
-	}
+// CarrierLockLabel is shown in place of a data rate on carrier-only links.
+const CarrierLockLabel = "carrier lock"
 
-	// Data rate factor: lower rate often indicates struggling link
-	if link.DataRate > 0 {
-		// Normalize: 1 Mbps+ = easy, 100 bps = hard
-		logRate := math.Log10(link.DataRate)
-		rateFactor := 1 - clamp((logRate-2)/(6-2), 0, 1) // log(100) to log(1M)
-		score += rateFactor * 0.3
-	}
+// InferredLabel marks a link identified by NAIF ID or recent history
+// because the feed's target and signals didn't match up.
+const InferredLabel = "inferred"
 
-	// Elevation factor: low elevation = harder (more atmosphere)
-	if elevation >= 0 {
-		elevFactor := 1 - clamp(elevation/45, 0, 1) // 0-45 degrees
-		score += elevFactor * 0.2
-	}
+// UplinkBadge marks a spacecraft with an active data uplink (commanding).
+const UplinkBadge = "⬆"
 
-	// Signal quality factor (if available)
-	if link.SignalQuality > 0 {
-		score += (1 - link.SignalQuality) * 0.1
-	} else {
-		// Default medium difficulty if no signal quality data
-		score += 0.05
+// Link directions, as exported: the antenna is receiving, commanding, or
+// both.
+const (
+	DirectionDown = "down"
+	DirectionUp   = "up"
+	DirectionBoth = "both"
+)
+
+// Direction returns the link's direction, or "" with neither an active
+// downlink nor a commanding uplink.
+func (l Link) Direction() string {
+	return linkDirection(l.Downlink, l.Uplink)
+}
+
+func linkDirection(down, up bool) string {
+	switch {
+	case down && up:
+		return DirectionBoth
+	case down:
+		return DirectionDown
+	case up:
+		return DirectionUp
+	default:
+		return ""
 	}
+}
 
-	return clamp(score, 0, 1)
+// StruggleIndex calculates a difficulty metric for a communication link
+// under the default health model. Returns a value from
+// 0 (easy) to 1 (difficult). It depends only on its arguments and the
+// model, so replayed snapshots score exactly as they did live.
+//
+// Default model factors and weights:
+//   - Distance (40%): log scale from 100k km (0) to 10B km (1)
+//   - Data rate (30%): log scale from 1 Mbps (0) to 100 bps (1)
+//   - Elevation (20%): 45°+ is easy (0), 0° is hard (1)
+//   - Signal quality (10%): downlink Pr/N0 from WeakSNR (1) to StrongSNR (0)
+func StruggleIndex(link Link, elevation float64) float64 {
+	return DefaultHealthModel().Struggle(link, elevation)
 }
 
-// ClassifyHealth converts a struggle index to a health classification.
+// ClassifyHealth converts a struggle index to a health classification
+// using the default health model's thresholds.
 //
-// Thresholds:
+// Default thresholds:
 //   - GOOD: struggle < 0.3 (strong signal, close, high rate, good elevation)
 //   - MARGINAL: 0.3 <= struggle < 0.6 (moderate conditions)
 //   - POOR: struggle >= 0.6 (weak signal, far, low rate, low elevation)
 func ClassifyHealth(struggle float64) Health {
-	switch {
-	case struggle < 0.3:
-		return HealthGood
-	case struggle < 0.6:
-		return HealthMarginal
-	default:
-		return HealthPoor
-	}
+	return DefaultHealthModel().Classify(struggle)
 }
 
-// LinkHealth computes struggle index and health for a link.
+// LinkHealth computes struggle index and health for a link under the
+// default health model (see HealthModel.LinkHealth).
 func LinkHealth(link Link, elevation float64) (float64, Health) {
-	struggle := StruggleIndex(link, elevation)
-	return struggle, ClassifyHealth(struggle)
+	return DefaultHealthModel().LinkHealth(link, elevation)
 }
 
 // IsRealSpacecraft returns true if the target name is a real spacecraft,
@@ -118,7 +122,8 @@ func IsRealSpacecraft(name string) bool {
 	}
 }
 
-// ComplexUtilization calculates load metrics for each DSN complex.
+// ComplexUtilization calculates load metrics for each DSN complex. Like
+// the other derived metrics it reads only data, never the clock.
 func ComplexUtilization(data *DSNData) map[Complex]ComplexLoad {
 	loads := make(map[Complex]ComplexLoad)
 
@@ -250,6 +255,15 @@ func FormatDataRate(bps float64) string {
 	}
 }
 
+// FormatLinkRate returns the link's data rate, or CarrierLockLabel for a
+// carrier-only link.
+func FormatLinkRate(link Link) string {
+	if link.CarrierOnly() {
+		return CarrierLockLabel
+	}
+	return FormatDataRate(link.DataRate)
+}
+
 // FormatRTLT returns a human-readable round-trip light time string.
 func FormatRTLT(seconds float64) string {
 	switch {
@@ -452,3 +452,22 @@ func TestLinkHealth(t *testing.T) {
 		t.Errorf("Hard link health = %q, want POOR (struggle=%.2f)", health, struggle)
 	}
 }
+
+func TestLinkHealth_CarrierOnly(t *testing.T) {
+	carrier := Link{Distance: 2.4e10, SignalType: SignalCarrier, Band: "X"}
+
+	if _, health := LinkHealth(carrier, 5); health != HealthCarrier {
+		t.Errorf("carrier-only health = %s, want %s", health, HealthCarrier)
+	}
+	if got := FormatLinkRate(carrier); got != CarrierLockLabel {
+		t.Errorf("FormatLinkRate(carrier) = %q, want %q", got, CarrierLockLabel)
+	}
+
+	data := Link{Distance: 2.4e10, SignalType: SignalData, DataRate: 160, Band: "X"}
+	if _, health := LinkHealth(data, 5); health == HealthCarrier {
+		t.Error("data link classed as carrier lock")
+	}
+	if got := FormatLinkRate(data); got != "160 bps" {
+		t.Errorf("FormatLinkRate(data) = %q, want 160 bps", got)
+	}
+}
@@ -0,0 +1,332 @@
+package dsn
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/astro"
+)
+
+// Digest defaults.
+const (
+	DigestWindow    = 7 * 24 * time.Hour  // recorded history a digest covers
+	DigestLookahead = 30 * 24 * time.Hour // how far ahead to look for conjunctions
+	ConjunctionStep = 6 * time.Hour       // ephemeris step for conjunction searches
+
+	// ConjunctionSeparation is the Sun-spacecraft angle (degrees) inside
+	// which a spacecraft counts as in solar conjunction: the Sun's
+	// warning tier, where plasma noise degrades the link.
+	ConjunctionSeparation = 10.0
+
+	// RareDayFraction: a spacecraft tracked on at most this fraction of
+	// the recorded days is a rare appearance.
+	RareDayFraction = 0.2
+
+	digestMaxPasses = 5
+)
+
+// Digest summarizes a window of recorded history.
+type Digest struct {
+	Start, End   time.Time
+	Snapshots    int      // recorded snapshots in the window
+	Spacecraft   []string // codes tracked in the window
+	Passes       []DigestPass
+	Rare         []DigestAppearance
+	Conjunctions []Conjunction
+}
+
+// DigestPass is a notable tracking session.
+type DigestPass struct {
+	Spacecraft string
+	Antenna    string
+	Complex    string
+	Band       string
+	Start, End time.Time
+	PeakRate   float64 // bps
+	MaxElDeg   float64
+	Note       string // why it's notable: "longest", "highest rate"
+}
+
+// DigestAppearance is a spacecraft the DSN rarely tracks that it tracked
+// during the window.
+type DigestAppearance struct {
+	Spacecraft   string
+	Sessions     int // tracking sessions in the window
+	Tracked      time.Duration
+	DaysSeen     int // recorded days it was tracked on, window included
+	DaysRecorded int
+	FirstSeen    bool // never tracked in the recordings before the window
+}
+
+// Conjunction is a spacecraft passing within ConjunctionSeparation of the
+// Sun.
+type Conjunction struct {
+	Spacecraft string
+	Start, End time.Time // inside ConjunctionSeparation
+	Closest    time.Time
+	MinSepDeg  float64
+}
+
+// BuildDigest finds the notable tracking sessions in history between start
+// and end, and the spacecraft tracked then that the recordings rarely
+// show. Conjunctions come from ephemerides; see FindConjunction.
+func BuildDigest(history []*SnapshotExport, start, end time.Time) *Digest {
+	d := &Digest{Start: start, End: end}
+
+	days := make(map[string]bool)                // every recorded day
+	seenDays := make(map[string]map[string]bool) // per spacecraft
+	seenBefore := make(map[string]bool)
+	recordedBefore := false
+	var codes []string
+	for _, s := range history {
+		t := snapshotTime(s)
+		if t.After(end) {
+			continue
+		}
+		day := t.UTC().Format(time.DateOnly)
+		days[day] = true
+		inWindow := !t.Before(start)
+		if inWindow {
+			d.Snapshots++
+		} else {
+			recordedBefore = true
+		}
+		for _, link := range s.Links {
+			code := strings.ToUpper(link.Spacecraft)
+			if code == "" {
+				continue
+			}
+			if seenDays[code] == nil {
+				seenDays[code] = make(map[string]bool)
+			}
+			seenDays[code][day] = true
+			if !inWindow {
+				seenBefore[code] = true
+			} else if !slices.Contains(codes, code) {
+				codes = append(codes, code)
+			}
+		}
+	}
+	sort.Strings(codes)
+	d.Spacecraft = codes
+
+	var sessions []DigestPass
+	for _, code := range codes {
+		runs := sessionRuns(spacecraftSamples(history, code, start, end))
+		var tracked time.Duration
+		for _, r := range runs {
+			tracked += r.last.Time.Sub(r.first.Time)
+			sessions = append(sessions, DigestPass{
+				Spacecraft: code,
+				Antenna:    r.first.Antenna,
+				Complex:    siteComplexName(Complex(r.first.Complex)),
+				Band:       r.first.Band,
+				Start:      r.first.Time,
+				End:        r.last.Time,
+				PeakRate:   r.peakRate,
+				MaxElDeg:   r.maxEl,
+			})
+		}
+
+		// "First seen" only means something if the recordings go back
+		// further than the window
+		first := recordedBefore && !seenBefore[code]
+		seen := len(seenDays[code])
+		if first || float64(seen) <= RareDayFraction*float64(len(days)) {
+			d.Rare = append(d.Rare, DigestAppearance{
+				Spacecraft:   code,
+				Sessions:     len(runs),
+				Tracked:      tracked,
+				DaysSeen:     seen,
+				DaysRecorded: len(days),
+				FirstSeen:    first,
+			})
+		}
+	}
+	sort.SliceStable(d.Rare, func(i, j int) bool {
+		a, b := d.Rare[i], d.Rare[j]
+		if a.FirstSeen != b.FirstSeen {
+			return a.FirstSeen
+		}
+		return a.DaysSeen < b.DaysSeen
+	})
+	d.Passes = notablePasses(sessions)
+	return d
+}
+
+// LoadDigestHistory loads what BuildDigest needs from the recordings at
+// path: every snapshot between start and end, and for each earlier day
+// a single stand-in snapshot listing the spacecraft tracked that day.
+// Rarity only asks which days a spacecraft was seen on, so months of
+// recordings cost a few entries per day rather than every snapshot.
+func LoadDigestHistory(path string, start, end time.Time) ([]*SnapshotExport, error) {
+	var history []*SnapshotExport
+	earlier := make(map[time.Time]map[string]bool) // day -> codes tracked
+	err := eachSnapshot(path, time.Time{}, end, func(s *SnapshotExport) {
+		t := snapshotTime(s)
+		if !t.Before(start) {
+			history = append(history, s)
+			return
+		}
+		day := t.UTC().Truncate(24 * time.Hour)
+		if earlier[day] == nil {
+			earlier[day] = make(map[string]bool)
+		}
+		for _, link := range s.Links {
+			if code := strings.ToUpper(link.Spacecraft); code != "" {
+				earlier[day][code] = true
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for day, codes := range earlier {
+		summary := &SnapshotExport{Timestamp: day}
+		for code := range codes {
+			summary.Links = append(summary.Links, LinkExport{Spacecraft: code})
+		}
+		history = append(history, summary)
+	}
+	if len(history) == 0 {
+		return nil, fmt.Errorf("no snapshots found in %s", path)
+	}
+	sort.SliceStable(history, func(i, j int) bool {
+		return snapshotTime(history[i]).Before(snapshotTime(history[j]))
+	})
+	return history, nil
+}
+
+// notablePasses picks each spacecraft's longest session, longest first,
+// and the window's highest-rate session, up to digestMaxPasses in all.
+func notablePasses(sessions []DigestPass) []DigestPass {
+	length := func(p DigestPass) time.Duration { return p.End.Sub(p.Start) }
+
+	var longest []DigestPass
+	var fastest *DigestPass
+	for i, p := range sessions {
+		if fastest == nil || p.PeakRate > fastest.PeakRate {
+			fastest = &sessions[i]
+		}
+		k := slices.IndexFunc(longest, func(l DigestPass) bool { return l.Spacecraft == p.Spacecraft })
+		switch {
+		case k < 0:
+			longest = append(longest, p)
+		case length(p) > length(longest[k]):
+			longest[k] = p
+		}
+	}
+	sort.SliceStable(longest, func(i, j int) bool { return length(longest[i]) > length(longest[j]) })
+
+	isFastest := func(p DigestPass) bool {
+		return fastest != nil && fastest.PeakRate > 0 && p.Spacecraft == fastest.Spacecraft && p.Start.Equal(fastest.Start)
+	}
+	var notable []DigestPass
+	for _, p := range longest {
+		if len(notable) >= digestMaxPasses-1 {
+			break
+		}
+		p.Note = "longest"
+		if isFastest(p) {
+			p.Note = "highest rate"
+		}
+		notable = append(notable, p)
+	}
+	if fastest != nil && isFastest(*fastest) && !slices.ContainsFunc(notable, isFastest) {
+		p := *fastest
+		p.Note = "highest rate"
+		notable = append(notable, p)
+	}
+	return notable
+}
+
+// FindConjunction looks for the spacecraft's closest approach to the Sun
+// in samples. ok is false if it stays outside ConjunctionSeparation.
+func FindConjunction(code string, samples []astro.RADecAtTime) (c Conjunction, ok bool) {
+	c = Conjunction{Spacecraft: code, MinSepDeg: math.Inf(1)}
+	closest := -1
+	seps := make([]float64, len(samples))
+	for i, s := range samples {
+		seps[i] = astro.SunSeparation(s.RAdeg, s.DecDeg, s.Time)
+		if seps[i] < c.MinSepDeg {
+			c.MinSepDeg = seps[i]
+			closest = i
+		}
+	}
+	if closest < 0 || c.MinSepDeg >= ConjunctionSeparation {
+		return Conjunction{}, false
+	}
+	c.Closest = samples[closest].Time
+
+	// The stretch inside the threshold around the closest approach
+	first, last := closest, closest
+	for first > 0 && seps[first-1] < ConjunctionSeparation {
+		first--
+	}
+	for last < len(seps)-1 && seps[last+1] < ConjunctionSeparation {
+		last++
+	}
+	c.Start, c.End = samples[first].Time, samples[last].Time
+	return c, true
+}
+
+// DigestSubject is the digest's one-line title, for an email subject.
+func DigestSubject(d *Digest) string {
+	return fmt.Sprintf("DSN digest: %s – %s",
+		d.Start.UTC().Format("Jan 02"), d.End.UTC().Format("Jan 02"))
+}
+
+// WriteDigest prints the digest as plain text, times in loc.
+func WriteDigest(w io.Writer, d *Digest, loc *time.Location) {
+	fmt.Fprintln(w, DigestSubject(d))
+	fmt.Fprintln(w, strings.Repeat("─", 60))
+	fmt.Fprintf(w, "%d recorded snapshots, %s → %s\n",
+		d.Snapshots, d.Start.In(loc).Format("Mon Jan 02 15:04"), d.End.In(loc).Format("Mon Jan 02 15:04 MST"))
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Notable passes")
+	if len(d.Passes) == 0 {
+		fmt.Fprintln(w, "  No tracking sessions recorded")
+	}
+	for _, p := range d.Passes {
+		el := ""
+		if p.MaxElDeg > 0 {
+			el = fmt.Sprintf(", %.0f° max el", p.MaxElDeg)
+		}
+		fmt.Fprintf(w, "  %-8s %-10s %-10s %s, %s  peak %s%s  (%s)\n",
+			p.Spacecraft, p.Antenna, p.Complex,
+			p.Start.In(loc).Format("Mon Jan 02 15:04"), formatSessionLength(p.End.Sub(p.Start)),
+			FormatDataRate(p.PeakRate), el, p.Note)
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Rare appearances")
+	if len(d.Rare) == 0 {
+		fmt.Fprintln(w, "  None")
+	}
+	for _, r := range d.Rare {
+		seen := fmt.Sprintf("tracked on %d of %d recorded days", r.DaysSeen, r.DaysRecorded)
+		if r.FirstSeen {
+			seen = "first time in the recordings"
+		}
+		fmt.Fprintf(w, "  %-8s %-24s %d session(s), %s  %s\n",
+			r.Spacecraft, GetSpacecraftName(r.Spacecraft), r.Sessions, formatSessionLength(r.Tracked), seen)
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Solar conjunctions (within %.0f° of the Sun)\n", ConjunctionSeparation)
+	if len(d.Conjunctions) == 0 {
+		fmt.Fprintln(w, "  None upcoming")
+	}
+	for _, c := range d.Conjunctions {
+		fmt.Fprintf(w, "  %-8s %s → %s  closest %.1f° on %s\n",
+			c.Spacecraft, c.Start.In(loc).Format("Jan 02"), c.End.In(loc).Format("Jan 02"),
+			c.MinSepDeg, c.Closest.In(loc).Format("Jan 02"))
+	}
+}
@@ -0,0 +1,133 @@
+package dsn
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/astro"
+)
+
+func TestBuildDigest(t *testing.T) {
+	end := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	start := end.Add(-DigestWindow)
+
+	// Two weeks of recordings: VGR2 every day, a first-ever NHPC session
+	// and a JNO session this week, and JNO once the week before
+	var history []*SnapshotExport
+	record := func(at time.Time, links ...Link) {
+		history = append(history, ExportSnapshot(&DSNData{Timestamp: at, Links: links}, at))
+	}
+	vgr2 := Link{Complex: ComplexCanberra, AntennaID: "DSS43", Spacecraft: "VGR2", DataRate: 160}
+	for day := 14; day >= 1; day-- {
+		for m := 0; m <= 60; m += 5 {
+			at := end.Add(-time.Duration(day)*24*time.Hour + time.Duration(m)*time.Minute)
+			record(at, vgr2)
+		}
+	}
+	for m := 0; m <= 180; m += 5 {
+		record(end.Add(-3*24*time.Hour+2*time.Hour+time.Duration(m)*time.Minute),
+			Link{Complex: ComplexMadrid, AntennaID: "DSS63", Spacecraft: "NHPC", DataRate: 1000})
+	}
+	for m := 0; m <= 30; m += 5 {
+		record(end.Add(-2*24*time.Hour+6*time.Hour+time.Duration(m)*time.Minute),
+			Link{Complex: ComplexGoldstone, AntennaID: "DSS14", Spacecraft: "JNO", DataRate: 1e6})
+	}
+	record(end.Add(-10*24*time.Hour+6*time.Hour), Link{Complex: ComplexGoldstone, AntennaID: "DSS14", Spacecraft: "JNO"})
+
+	d := BuildDigest(history, start, end)
+
+	if len(d.Passes) == 0 || d.Passes[0].Spacecraft != "NHPC" || d.Passes[0].Note != "longest" {
+		t.Fatalf("passes = %+v, want NHPC's 3h session first", d.Passes)
+	}
+	if got := d.Passes[0].End.Sub(d.Passes[0].Start); got != 3*time.Hour {
+		t.Errorf("NHPC session = %v, want 3h", got)
+	}
+	var fastest *DigestPass
+	for i := range d.Passes {
+		if d.Passes[i].Spacecraft == "JNO" {
+			fastest = &d.Passes[i]
+		}
+	}
+	if fastest == nil || fastest.Note != "highest rate" || fastest.PeakRate != 1e6 {
+		t.Errorf("passes = %+v, want JNO's session for its rate", d.Passes)
+	}
+
+	if len(d.Rare) != 2 {
+		t.Fatalf("rare = %+v, want NHPC and JNO", d.Rare)
+	}
+	if r := d.Rare[0]; r.Spacecraft != "NHPC" || !r.FirstSeen || r.Sessions != 1 {
+		t.Errorf("rare[0] = %+v, want NHPC first seen", r)
+	}
+	if r := d.Rare[1]; r.Spacecraft != "JNO" || r.FirstSeen || r.DaysSeen != 2 || r.DaysRecorded != 14 {
+		t.Errorf("rare[1] = %+v, want JNO on 2 of 14 days", r)
+	}
+
+	var out bytes.Buffer
+	WriteDigest(&out, d, time.UTC)
+	for _, want := range []string{
+		"DSN digest: Jan 08 – Jan 15",
+		"NHPC     DSS63      Madrid     Fri Jan 12 02:00, 3h",
+		"(highest rate)",
+		"first time in the recordings",
+		"tracked on 2 of 14 recorded days",
+		"None upcoming",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("digest missing %q:\n%s", want, out.String())
+		}
+	}
+
+	// Loading the same history from disk keeps a day summary before the
+	// window and digests the same
+	var recorded bytes.Buffer
+	for _, s := range history {
+		if err := s.WriteJSONLine(&recorded); err != nil {
+			t.Fatal(err)
+		}
+	}
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	if err := os.WriteFile(path, recorded.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadDigestHistory(path, start, end)
+	if err != nil {
+		t.Fatalf("LoadDigestHistory: %v", err)
+	}
+	if len(loaded) >= len(history) {
+		t.Errorf("loaded %d snapshots, want fewer than the %d recorded", len(loaded), len(history))
+	}
+	if got := BuildDigest(loaded, start, end); !reflect.DeepEqual(got.Rare, d.Rare) || len(got.Passes) != len(d.Passes) {
+		t.Errorf("digest from disk = %+v, want %+v", got, d)
+	}
+}
+
+func TestFindConjunction(t *testing.T) {
+	start := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	// A target drifting through the Sun: 20° north, 2° at closest, 20° south
+	var samples []astro.RADecAtTime
+	for i := range 21 {
+		at := start.Add(time.Duration(i) * 24 * time.Hour)
+		ra, dec := astro.SunPosition(at)
+		samples = append(samples, astro.RADecAtTime{Time: at, RAdeg: ra, DecDeg: dec + 20 - 2*float64(i) + 0.1})
+	}
+
+	c, ok := FindConjunction("VGR2", samples)
+	if !ok {
+		t.Fatal("no conjunction found")
+	}
+	if c.Closest != start.Add(10*24*time.Hour) {
+		t.Errorf("closest = %v", c.Closest)
+	}
+	if c.Start != start.Add(6*24*time.Hour) || c.End != start.Add(15*24*time.Hour) {
+		t.Errorf("window = %v → %v", c.Start, c.End)
+	}
+
+	if _, ok := FindConjunction("VGR2", samples[:3]); ok {
+		t.Error("a target 16° or more from the Sun isn't in conjunction")
+	}
+}
@@ -0,0 +1,53 @@
+package dsn
+
+import (
+	"math"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/astro"
+)
+
+// Pointing divergence defaults: how far, in degrees beyond what the local
+// sky math and parallax explain, a dish may point from where the
+// ephemeris puts its spacecraft, and for how many fetches in a row, before
+// it is reported. A few fetches ride out a dish slewing onto its target.
+const (
+	DefaultDivergenceDeg     = 2.0
+	DefaultDivergenceFetches = 3
+)
+
+// PointingDivergence returns the great-circle angle, in degrees, between
+// where a link's dish points and where the RA/Dec samples put its
+// spacecraft at t, seen from the dish. The samples are geocentric, so the
+// parallax of a near-Earth spacecraft at the link's range is allowed for.
+// It returns false when the link has no pointing or the samples don't
+// cover t.
+func PointingDivergence(link Link, samples []astro.RADecAtTime, t time.Time) (float64, bool) {
+	if !link.Pointing.Valid || len(samples) == 0 ||
+		t.Before(samples[0].Time) || t.After(samples[len(samples)-1].Time) {
+		return 0, false
+	}
+	ra, dec := raDecAt(samples, t)
+	obs := ObserverForAntenna(link.AntennaID, link.Complex)
+	h := astro.EquatorialToHorizontal(astro.SkyCoord{RAdeg: ra, DecDeg: dec}, obs, t)
+	sep := astro.AngularSeparation(h.AzDeg, h.ElDeg, link.Pointing.AzDeg, link.Pointing.ElDeg)
+	return max(0, sep-parallaxDeg(linkRange(link))), true
+}
+
+// linkRange is the link's distance in km, from RTLT if not derived yet.
+func linkRange(link Link) float64 {
+	if link.Distance > 0 {
+		return link.Distance
+	}
+	return DistanceFromRTLT(link.RTLT)
+}
+
+// parallaxDeg is the largest shift between geocentric and topocentric
+// directions for a target rangeKm away: negligible for deep space, about
+// a degree at the Moon. Unknown range gets no allowance.
+func parallaxDeg(rangeKm float64) float64 {
+	if rangeKm <= EarthRadius {
+		return 0
+	}
+	return math.Asin(EarthRadius/rangeKm) * 180 / math.Pi
+}
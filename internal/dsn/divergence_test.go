@@ -0,0 +1,46 @@
+package dsn
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/astro"
+)
+
+func TestPointingDivergence(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	samples := generateSamples(now.Add(-time.Hour), 2*time.Hour, 10*time.Minute, func(time.Time) (ra, dec float64) {
+		return 90, 10
+	})
+	h := astro.EquatorialToHorizontal(astro.SkyCoord{RAdeg: 90, DecDeg: 10}, ObserverForAntenna("DSS14", ComplexGoldstone), now)
+	link := Link{
+		AntennaID: "DSS14",
+		Complex:   ComplexGoldstone,
+		RTLT:      2 * 3600, // deep space: no parallax
+		Pointing:  Pointing{AzDeg: h.AzDeg, ElDeg: h.ElDeg, Valid: true},
+	}
+
+	if d, ok := PointingDivergence(link, samples, now); !ok || d > 0.01 {
+		t.Errorf("on target: %v, %v, want ~0", d, ok)
+	}
+
+	link.Pointing.ElDeg += 5
+	if d, ok := PointingDivergence(link, samples, now); !ok || math.Abs(d-5) > 0.01 {
+		t.Errorf("5° high: %v, %v, want 5", d, ok)
+	}
+
+	// Near the Moon, parallax explains about a degree
+	link.RTLT = 2 * 384400 / SpeedOfLight
+	if d, _ := PointingDivergence(link, samples, now); d > 4.1 || d < 3.9 {
+		t.Errorf("5° high at lunar range: %v, want ~4", d)
+	}
+
+	if _, ok := PointingDivergence(link, samples, now.Add(2*time.Hour)); ok {
+		t.Error("divergence outside the samples")
+	}
+	link.Pointing.Valid = false
+	if _, ok := PointingDivergence(link, samples, now); ok {
+		t.Error("divergence with no pointing")
+	}
+}
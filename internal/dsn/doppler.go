@@ -122,6 +122,77 @@ func ComputeDopplerFromRaDec(obs astro.Observer, raDeg, decDeg, rangeKm, rangeRa
 	}
 }
 
+// RTLTSample is a round-trip light time reading from the feed.
+type RTLTSample struct {
+	Time time.Time
+	RTLT float64 // seconds
+}
+
+// DopplerWindow is how much recent RTLT history a Doppler estimate is
+// fitted to.
+const DopplerWindow = 30 * time.Minute
+
+// minDopplerSpan is the shortest history an estimate is made from. Over
+// a few fetches the feed's RTLT rounding swamps the change in range.
+const minDopplerSpan = 2 * time.Minute
+
+// RangeRateFromRTLT estimates the range rate in km/s (positive =
+// receding) from the slope of a least-squares line through the samples
+// in the last DopplerWindow, which averages out the feed's rounding
+// better than the two-point VelocityFromRTLTDelta. ok is false with
+// fewer than three samples or less than two minutes of history.
+func RangeRateFromRTLT(samples []RTLTSample) (kmPerSec float64, ok bool) {
+	if len(samples) == 0 {
+		return 0, false
+	}
+	latest := samples[len(samples)-1].Time
+	var recent []RTLTSample
+	for _, s := range samples {
+		if s.RTLT > 0 && latest.Sub(s.Time) <= DopplerWindow {
+			recent = append(recent, s)
+		}
+	}
+	if len(recent) < 3 || latest.Sub(recent[0].Time) < minDopplerSpan {
+		return 0, false
+	}
+
+	// Fit range against seconds since the first sample
+	t0 := recent[0].Time
+	var sumT, sumR, sumTT, sumTR float64
+	for _, s := range recent {
+		t := s.Time.Sub(t0).Seconds()
+		r := DistanceFromRTLT(s.RTLT)
+		sumT += t
+		sumR += r
+		sumTT += t * t
+		sumTR += t * r
+	}
+	n := float64(len(recent))
+	denom := n*sumTT - sumT*sumT
+	if denom == 0 {
+		return 0, false
+	}
+	return (n*sumTR - sumT*sumR) / denom, true
+}
+
+// DopplerFromRTLT estimates the Doppler shift of a band's downlink
+// carrier from the range rate in the RTLT history. The result is
+// invalid until RangeRateFromRTLT has enough history.
+func DopplerFromRTLT(samples []RTLTSample, band string) DopplerResult {
+	carrierFreqMHz := GetBandFrequency(band)
+	rate, ok := RangeRateFromRTLT(samples)
+	if !ok {
+		return DopplerResult{CarrierFreqMHz: carrierFreqMHz}
+	}
+	return DopplerResult{
+		LOSVelocity:    rate,
+		DopplerShift:   carrierFreqMHz * 1e6 * rate / SpeedOfLight,
+		CarrierFreqMHz: carrierFreqMHz,
+		Range:          DistanceFromRTLT(samples[len(samples)-1].RTLT),
+		Valid:          true,
+	}
+}
+
 // observerToECEF converts observer geodetic coordinates to ECEF position.
 func observerToECEF(obs astro.Observer, t time.Time) [3]float64 {
 	// Convert to radians
@@ -256,3 +256,65 @@ func TestGetBandFrequency(t *testing.T) {
 		})
 	}
 }
+
+func TestRangeRateFromRTLT(t *testing.T) {
+	start := time.Date(2025, 12, 5, 12, 0, 0, 0, time.UTC)
+	// Receding at 17 km/s: RTLT grows by 2*17/c seconds per second.
+	// Readings are rounded to 1 ms, as in the feed.
+	series := func(n int, step time.Duration) []RTLTSample {
+		var samples []RTLTSample
+		for i := range n {
+			dt := time.Duration(i) * step
+			rtlt := 160000 + 2*17/SpeedOfLight*dt.Seconds()
+			samples = append(samples, RTLTSample{Time: start.Add(dt), RTLT: math.Round(rtlt*1000) / 1000})
+		}
+		return samples
+	}
+
+	rate, ok := RangeRateFromRTLT(series(120, 5*time.Second))
+	if !ok || math.Abs(rate-17) > 0.5 {
+		t.Errorf("RangeRateFromRTLT = %.2f, %v; want ~17 km/s", rate, ok)
+	}
+
+	// Samples older than the window are ignored
+	old := series(60, time.Minute)
+	old[0].RTLT = 1 // far off the line
+	if rate, ok := RangeRateFromRTLT(old); !ok || math.Abs(rate-17) > 0.5 {
+		t.Errorf("with stale sample: %.2f, %v; want ~17 km/s", rate, ok)
+	}
+
+	for name, samples := range map[string][]RTLTSample{
+		"none":        nil,
+		"two samples": series(2, time.Minute),
+		"too short":   series(10, 5*time.Second),
+	} {
+		if _, ok := RangeRateFromRTLT(samples); ok {
+			t.Errorf("%s: want no estimate", name)
+		}
+	}
+}
+
+func TestDopplerFromRTLT(t *testing.T) {
+	start := time.Date(2025, 12, 5, 12, 0, 0, 0, time.UTC)
+	var samples []RTLTSample
+	for i := range 10 {
+		// Approaching at 0.5 km/s
+		samples = append(samples, RTLTSample{
+			Time: start.Add(time.Duration(i) * time.Minute),
+			RTLT: 2.5 - 2*0.5/SpeedOfLight*float64(i*60),
+		})
+	}
+
+	d := DopplerFromRTLT(samples, "X")
+	if !d.Valid || math.Abs(d.LOSVelocity+0.5) > 1e-6 {
+		t.Fatalf("DopplerFromRTLT = %+v, want -0.5 km/s", d)
+	}
+	// Same convention as ComputeDopplerFromRaDec: f * v / c
+	if want := FreqXBand * 1e6 * -0.5 / SpeedOfLight; math.Abs(d.DopplerShift-want) > 1 {
+		t.Errorf("DopplerShift = %.1f Hz, want %.1f", d.DopplerShift, want)
+	}
+
+	if d := DopplerFromRTLT(samples[:2], "Ka"); d.Valid || d.CarrierFreqMHz != FreqKaBand {
+		t.Errorf("short history = %+v, want invalid at the Ka carrier", d)
+	}
+}
@@ -1,6 +1,8 @@
 package dsn
 
 import (
+	"hash/fnv"
+	"math"
 	"time"
 
 	"github.com/litescript/ls-horizons/internal/astro"
@@ -16,6 +18,7 @@ type ElevationSample struct {
 type ElevationTrace struct {
 	SpacecraftCode string
 	Complex        Complex
+	Antenna        string // Observing antenna ID; empty for the complex reference point
 	Samples        []ElevationSample
 	GeneratedAt    time.Time
 	WindowStart    time.Time
@@ -28,6 +31,28 @@ const ElevationTraceWindow = 2 * time.Hour
 // ElevationTraceSampleInterval is the time between samples.
 const ElevationTraceSampleInterval = 5 * time.Minute
 
+// GeometryQuantumDeg is the RA/Dec resolution used by GeometryHash. Paths
+// that agree to this precision give indistinguishable elevation traces.
+const GeometryQuantumDeg = 0.01
+
+// GeometryHash returns a hash of an RA/Dec path with positions quantized to
+// GeometryQuantumDeg. Sample times are left out, so refetching the same
+// geometry over a slightly shifted window yields the same hash.
+func GeometryHash(samples []astro.RADecAtTime) uint64 {
+	h := fnv.New64a()
+	var buf [16]byte
+	for _, s := range samples {
+		ra := int64(math.Round(s.RAdeg / GeometryQuantumDeg))
+		dec := int64(math.Round(s.DecDeg / GeometryQuantumDeg))
+		for i := 0; i < 8; i++ {
+			buf[i] = byte(ra >> (8 * i))
+			buf[8+i] = byte(dec >> (8 * i))
+		}
+		h.Write(buf[:])
+	}
+	return h.Sum64()
+}
+
 // ComputeElevationTrace computes elevation samples for a spacecraft as seen from
 // a DSN complex over a ±2 hour window centered on 'now'.
 // The samples slice contains RA/Dec positions from the ephemeris provider.
@@ -36,17 +61,31 @@ func ComputeElevationTrace(
 	complex Complex,
 	samples []astro.RADecAtTime,
 	now time.Time,
+) *ElevationTrace {
+	return ComputeAntennaElevationTrace(scCode, complex, "", samples, now)
+}
+
+// ComputeAntennaElevationTrace is ComputeElevationTrace as seen from a
+// specific antenna (e.g. the one carrying the link). Unknown or empty
+// antenna IDs use the complex reference point.
+func ComputeAntennaElevationTrace(
+	scCode string,
+	complex Complex,
+	antennaID string,
+	samples []astro.RADecAtTime,
+	now time.Time,
 ) *ElevationTrace {
 	if len(samples) == 0 {
 		return &ElevationTrace{
 			SpacecraftCode: scCode,
 			Complex:        complex,
+			Antenna:        antennaID,
 			GeneratedAt:    now,
 			Samples:        nil,
 		}
 	}
 
-	obs := ObserverForComplex(complex)
+	obs := ObserverForAntenna(antennaID, complex)
 	windowStart := now.Add(-ElevationTraceWindow)
 	windowEnd := now.Add(ElevationTraceWindow)
 
@@ -71,6 +110,7 @@ func ComputeElevationTrace(
 	return &ElevationTrace{
 		SpacecraftCode: scCode,
 		Complex:        complex,
+		Antenna:        antennaID,
 		Samples:        elevSamples,
 		GeneratedAt:    now,
 		WindowStart:    windowStart,
@@ -170,3 +170,29 @@ func TestElevationTrace_CurrentElevation_Empty(t *testing.T) {
 		t.Errorf("expected nil for empty trace, got %+v", current)
 	}
 }
+
+func TestGeometryHash(t *testing.T) {
+	base := time.Date(2025, 12, 5, 0, 0, 0, 0, time.UTC)
+	path := func(offset time.Duration, raJitter float64) []astro.RADecAtTime {
+		var samples []astro.RADecAtTime
+		for i := 0; i < 4; i++ {
+			samples = append(samples, astro.RADecAtTime{
+				Time:   base.Add(offset + time.Duration(i)*ElevationTraceSampleInterval),
+				RAdeg:  261.03212 + float64(i)*0.1 + raJitter,
+				DecDeg: -32.87803,
+			})
+		}
+		return samples
+	}
+
+	h := GeometryHash(path(0, 0))
+	if got := GeometryHash(path(time.Hour, 0)); got != h {
+		t.Error("hash should ignore sample times")
+	}
+	if got := GeometryHash(path(0, GeometryQuantumDeg/10)); got != h {
+		t.Error("hash should ignore changes below the quantum")
+	}
+	if got := GeometryHash(path(0, GeometryQuantumDeg*5)); got == h {
+		t.Error("hash should change when the geometry moves")
+	}
+}
@@ -0,0 +1,90 @@
+package dsn
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Error classes. Failures wrap one of these (with %w, keeping their own
+// detail) so they can be told apart with errors.Is, shown with a short
+// code, and explained with a remediation hint, whatever the wording of
+// the underlying error.
+var (
+	ErrFeedUnavailable     = errors.New("DSN feed unavailable")
+	ErrHorizonsRateLimited = errors.New("Horizons rate limited")
+	ErrUnknownTarget       = errors.New("unknown target")
+	ErrParse               = errors.New("parse error")
+)
+
+// ErrorCode is a short, stable code for an error class, for filtering logs
+// and exports and for looking up translated hints.
+type ErrorCode string
+
+const (
+	CodeFeedUnavailable ErrorCode = "FEED"
+	CodeRateLimited     ErrorCode = "RATE"
+	CodeUnknownTarget   ErrorCode = "TARGET"
+	CodeParse           ErrorCode = "PARSE"
+	CodeOther           ErrorCode = "ERR" // not one of the classes above
+)
+
+// errorClasses maps each class to its code and English hint.
+var errorClasses = []struct {
+	err  error
+	code ErrorCode
+	hint string
+}{
+	{ErrFeedUnavailable, CodeFeedUnavailable, "check the network; the last data is kept and fetching retries"},
+	{ErrHorizonsRateLimited, CodeRateLimited, "wait for the quota to refill, or raise --horizons-per-hour/--horizons-per-day"},
+	{ErrUnknownTarget, CodeUnknownTarget, "no ephemeris for this spacecraft; check its name or NAIF ID"},
+	{ErrParse, CodeParse, "the data was malformed; it is usually transient, or the format changed (update ls-horizons)"},
+}
+
+// ErrorCodeOf returns the code for err's class, CodeOther if it has none,
+// or "" for nil.
+func ErrorCodeOf(err error) ErrorCode {
+	if err == nil {
+		return ""
+	}
+	for _, c := range errorClasses {
+		if errors.Is(err, c.err) {
+			return c.code
+		}
+	}
+	return CodeOther
+}
+
+// Hint returns the English remediation hint for the code, or "".
+func (c ErrorCode) Hint() string {
+	for _, ec := range errorClasses {
+		if ec.code == c {
+			return ec.hint
+		}
+	}
+	return ""
+}
+
+// ErrorInfo is an error as shown to users and in exports: its code, full
+// message, and remediation hint.
+type ErrorInfo struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+	Hint    string    `json:"hint,omitempty"`
+}
+
+// DescribeError returns err's code, message, and hint, or nil for nil.
+func DescribeError(err error) *ErrorInfo {
+	if err == nil {
+		return nil
+	}
+	code := ErrorCodeOf(err)
+	return &ErrorInfo{Code: code, Message: err.Error(), Hint: code.Hint()}
+}
+
+// String formats the error for a log line: "[FEED] message (hint)".
+func (e ErrorInfo) String() string {
+	if e.Hint == "" {
+		return fmt.Sprintf("[%s] %s", e.Code, e.Message)
+	}
+	return fmt.Sprintf("[%s] %s (%s)", e.Code, e.Message, e.Hint)
+}
@@ -0,0 +1,67 @@
+package dsn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestErrorCodeOf(t *testing.T) {
+	tests := []struct {
+		err  error
+		want ErrorCode
+	}{
+		{nil, ""},
+		{errors.New("boom"), CodeOther},
+		{fmt.Errorf("%w: status 503", ErrFeedUnavailable), CodeFeedUnavailable},
+		{fmt.Errorf("passes: %w", fmt.Errorf("%w by the quota", ErrHorizonsRateLimited)), CodeRateLimited},
+		{fmt.Errorf("%w: spacecraft XYZ", ErrUnknownTarget), CodeUnknownTarget},
+		{fmt.Errorf("DSN feed: %w", ErrParse), CodeParse},
+	}
+	for _, tt := range tests {
+		if got := ErrorCodeOf(tt.err); got != tt.want {
+			t.Errorf("ErrorCodeOf(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestDescribeError(t *testing.T) {
+	if DescribeError(nil) != nil {
+		t.Error("DescribeError(nil) != nil")
+	}
+	info := DescribeError(fmt.Errorf("%w: status 503", ErrFeedUnavailable))
+	if info.Code != CodeFeedUnavailable || info.Message != "DSN feed unavailable: status 503" || info.Hint == "" {
+		t.Errorf("info = %+v", info)
+	}
+	if got, want := info.String(), "[FEED] DSN feed unavailable: status 503 ("+info.Hint+")"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got := DescribeError(errors.New("boom")).String(); got != "[ERR] boom" {
+		t.Errorf("unclassified String() = %q", got)
+	}
+}
+
+func TestFetch_ErrorClasses(t *testing.T) {
+	status, body := http.StatusOK, "<dsn><bad"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+	f := NewFetcher(WithURL(srv.URL))
+
+	if err := f.Fetch(context.Background()).Error; !errors.Is(err, ErrParse) {
+		t.Errorf("malformed feed: %v, want ErrParse", err)
+	}
+	status = http.StatusServiceUnavailable
+	if err := f.Fetch(context.Background()).Error; !errors.Is(err, ErrFeedUnavailable) {
+		t.Errorf("503: %v, want ErrFeedUnavailable", err)
+	}
+	srv.Close()
+	if err := f.Fetch(context.Background()).Error; !errors.Is(err, ErrFeedUnavailable) {
+		t.Errorf("server gone: %v, want ErrFeedUnavailable", err)
+	}
+}
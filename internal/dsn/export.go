@@ -1,11 +1,17 @@
 package dsn
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
+
+	"github.com/litescript/ls-horizons/internal/notes"
 )
 
 // SnapshotExport is the JSON-serializable representation of DSN state.
@@ -15,6 +21,8 @@ type SnapshotExport struct {
 	Stations     []StationExport `json:"stations"`
 	Links        []LinkExport    `json:"links"`
 	ComplexLoads []ComplexLoad   `json:"complex_loads"`
+	HealthModel  string          `json:"health_model,omitempty"` // Model that scored struggle and health
+	Error        *ErrorInfo      `json:"error,omitempty"`        // Last fetch failed: the data is stale
 }
 
 // StationExport is a JSON-friendly station representation.
@@ -35,13 +43,25 @@ type AntennaExport struct {
 
 // LinkExport is a JSON-friendly link with derived fields.
 type LinkExport struct {
-	Complex       string  `json:"complex"`
-	StationID     string  `json:"station_id"`
-	AntennaID     string  `json:"antenna_id"`
-	Spacecraft    string  `json:"spacecraft"`
-	SpacecraftID  int     `json:"spacecraft_id"`
+	Complex      string `json:"complex"`
+	StationID    string `json:"station_id"`
+	AntennaID    string `json:"antenna_id"`
+	Spacecraft   string `json:"spacecraft"`
+	SpacecraftID int    `json:"spacecraft_id"`
+	SpacecraftRef
+	SignalType    string  `json:"signal_type,omitempty"`
+	Uplink        bool    `json:"uplink,omitempty"`
+	Inferred      bool    `json:"inferred,omitempty"` // identified by NAIF ID or recent history (see Link.Inferred)
+	Downlink      bool    `json:"downlink,omitempty"`
+	Direction     string  `json:"direction,omitempty"` // down, up, or both
+	TrackingMode  string  `json:"tracking_mode,omitempty"`
 	Band          string  `json:"band"`
 	DataRate      float64 `json:"data_rate_bps"`
+	DownRate      float64 `json:"down_rate_bps,omitempty"`
+	UpRate        float64 `json:"up_rate_bps,omitempty"`
+	DownPower     float64 `json:"down_power_dbm,omitempty"` // received downlink power
+	UpPower       float64 `json:"up_power_kw,omitempty"`    // uplink transmitter power
+	SNR           float64 `json:"snr_dbhz,omitempty"`       // estimated downlink Pr/N0
 	Distance      float64 `json:"distance_km"`
 	RTLT          float64 `json:"rtlt_seconds"`
 	Elevation     float64 `json:"elevation"`
@@ -56,8 +76,9 @@ func ExportSnapshot(data *DSNData, fetchedAt time.Time) *SnapshotExport {
 	}
 
 	export := &SnapshotExport{
-		Timestamp: data.Timestamp,
-		FetchedAt: fetchedAt,
+		Timestamp:   data.Timestamp,
+		FetchedAt:   fetchedAt,
+		HealthModel: data.ActiveHealthModel().Name,
 	}
 
 	// Build elevation map for struggle calculations
@@ -82,16 +103,29 @@ func ExportSnapshot(data *DSNData, fetchedAt time.Time) *SnapshotExport {
 
 	// Export links with derived metrics
 	for _, link := range data.Links {
-		elev := elevMap[link.AntennaID]
-		struggle, health := LinkHealth(link, elev)
+		elev := LinkElevation(link, elevMap)
+		struggle, health := data.ActiveHealthModel().LinkHealth(link, elev)
+		snr, _ := link.SNR()
 		export.Links = append(export.Links, LinkExport{
 			Complex:       string(link.Complex),
 			StationID:     link.StationID,
 			AntennaID:     link.AntennaID,
 			Spacecraft:    link.Spacecraft,
 			SpacecraftID:  link.SpacecraftID,
+			SpacecraftRef: GetSpacecraftRef(link.Spacecraft),
+			SignalType:    link.SignalType,
+			Uplink:        link.Uplink,
+			Inferred:      link.Inferred,
+			TrackingMode:  string(link.TrackingMode),
 			Band:          link.Band,
 			DataRate:      link.DataRate,
+			DownRate:      link.DownRate,
+			UpRate:        link.UpRate,
+			Downlink:      link.Downlink,
+			Direction:     link.Direction(),
+			DownPower:     link.DownPower,
+			UpPower:       link.UpPower,
+			SNR:           math.Round(snr*10) / 10,
 			Distance:      link.Distance,
 			RTLT:          link.RTLT,
 			Elevation:     elev,
@@ -100,11 +134,13 @@ func ExportSnapshot(data *DSNData, fetchedAt time.Time) *SnapshotExport {
 		})
 	}
 
-	// Add complex loads
-	loads := ComplexUtilization(data)
-	for _, load := range loads {
+	// Add complex loads, sorted so repeated exports of the same data match
+	for _, load := range ComplexUtilization(data) {
 		export.ComplexLoads = append(export.ComplexLoads, load)
 	}
+	sort.Slice(export.ComplexLoads, func(i, j int) bool {
+		return export.ComplexLoads[i].Complex < export.ComplexLoads[j].Complex
+	})
 
 	return export
 }
@@ -116,50 +152,149 @@ func (s *SnapshotExport) WriteJSON(w io.Writer) error {
 	return enc.Encode(s)
 }
 
+// WriteJSONLine writes the snapshot as compact JSON on a single line, so
+// a stream of snapshots is newline-delimited JSON (NDJSON).
+func (s *SnapshotExport) WriteJSONLine(w io.Writer) error {
+	return json.NewEncoder(w).Encode(s)
+}
+
+// CSVHeader is the column row written by WriteCSV.
+var CSVHeader = []string{
+	"timestamp", "fetched_at", "complex", "station_id", "antenna_id",
+	"spacecraft", "spacecraft_id", "naif_id", "signal_type", "uplink",
+	"tracking_mode", "band", "data_rate_bps", "distance_km", "rtlt_seconds", "elevation",
+	"struggle_index", "health", "health_model",
+	"down_power_dbm", "up_power_kw", "snr_dbhz",
+	"direction", "down_rate_bps", "up_rate_bps",
+	"inferred",
+}
+
+// WriteCSV writes the snapshot as CSV, one row per link, for spreadsheet
+// analysis. The header row is written only if header is set, so repeated
+// snapshots can be appended to one stream.
+func (s *SnapshotExport) WriteCSV(w io.Writer, header bool) error {
+	cw := csv.NewWriter(w)
+	if header {
+		if err := cw.Write(CSVHeader); err != nil {
+			return err
+		}
+	}
+
+	float := func(f float64) string { return strconv.FormatFloat(f, 'f', -1, 64) }
+	// Power and SNR are blank when the feed doesn't report them
+	optFloat := func(f float64) string {
+		if f == 0 {
+			return ""
+		}
+		return float(f)
+	}
+	for _, l := range s.Links {
+		naif := ""
+		if l.NAIFID != 0 {
+			naif = strconv.Itoa(l.NAIFID)
+		}
+		row := []string{
+			s.Timestamp.UTC().Format(time.RFC3339),
+			s.FetchedAt.UTC().Format(time.RFC3339),
+			l.Complex,
+			l.StationID,
+			l.AntennaID,
+			l.Spacecraft,
+			strconv.Itoa(l.SpacecraftID),
+			naif,
+			l.SignalType,
+			strconv.FormatBool(l.Uplink),
+			l.TrackingMode,
+			l.Band,
+			float(l.DataRate),
+			strconv.FormatFloat(l.Distance, 'f', 1, 64),
+			float(l.RTLT),
+			float(l.Elevation),
+			strconv.FormatFloat(l.StruggleIndex, 'f', 4, 64),
+			l.Health,
+			s.HealthModel,
+			optFloat(l.DownPower),
+			optFloat(l.UpPower),
+			optFloat(l.SNR),
+			l.Direction,
+			optFloat(l.DownRate),
+			optFloat(l.UpRate),
+			strconv.FormatBool(l.Inferred),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
 // SummaryRow represents one row in the summary table.
 type SummaryRow struct {
 	Complex    string
 	Station    string
 	Antenna    string
 	Spacecraft string
+	Uplink     bool
+	Inferred   bool
 	Band       string
 	Rate       string
+	SNR        string
 	Distance   string
 	Struggle   float64
 	Health     Health
+	MSPA       bool    // Antenna shared with other spacecraft
+	Share      float64 // Part of the antenna's combined data rate (1 when dedicated)
 }
 
-// GenerateSummaryRows creates summary rows from DSN data.
+// GenerateSummaryRows creates summary rows from DSN data, one per link in
+// feed order, with the links of each antenna kept together.
 func GenerateSummaryRows(data *DSNData) []SummaryRow {
 	if data == nil {
 		return nil
 	}
 
-	// Build elevation map
-	elevMap := make(map[string]float64)
-	for _, station := range data.Stations {
-		for _, ant := range station.Antennas {
-			elevMap[ant.ID] = ant.Elevation
-		}
-	}
+	elevMap := BuildElevationMap(data)
+	mspa := mspaByAntenna(data)
 
 	var rows []SummaryRow
 	for _, link := range data.Links {
-		elev := elevMap[link.AntennaID]
-		struggle, health := LinkHealth(link, elev)
+		elev := LinkElevation(link, elevMap)
+		struggle, health := data.ActiveHealthModel().LinkHealth(link, elev)
+		g, shared := mspa[link.AntennaID]
+		share := 1.0
+		if shared && IsRealSpacecraft(link.Spacecraft) {
+			share = g.Share(link)
+		} else {
+			shared = false
+		}
 
 		rows = append(rows, SummaryRow{
 			Complex:    string(link.Complex),
 			Station:    link.StationID,
 			Antenna:    link.AntennaID,
 			Spacecraft: link.Spacecraft,
+			Uplink:     link.Uplink,
+			Inferred:   link.Inferred,
 			Band:       link.Band,
-			Rate:       FormatDataRate(link.DataRate),
+			Rate:       FormatLinkRate(link),
+			SNR:        FormatSNR(link),
 			Distance:   FormatDistance(link.Distance),
 			Struggle:   struggle,
 			Health:     health,
+			MSPA:       shared,
+			Share:      share,
 		})
 	}
+
+	first := make(map[string]int)
+	for i, r := range rows {
+		if _, ok := first[r.Antenna]; !ok {
+			first[r.Antenna] = i
+		}
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return first[rows[i].Antenna] < first[rows[j].Antenna] })
 	return rows
 }
 
@@ -168,7 +303,7 @@ func WriteSummaryTable(w io.Writer, data *DSNData, timestamp time.Time) {
 	rows := GenerateSummaryRows(data)
 
 	fmt.Fprintf(w, "DSN Status @ %s\n", timestamp.Format(time.RFC3339))
-	fmt.Fprintln(w, strings.Repeat("─", 90))
+	fmt.Fprintln(w, strings.Repeat("─", 112))
 
 	if len(rows) == 0 {
 		fmt.Fprintln(w, "No active links")
@@ -176,36 +311,50 @@ func WriteSummaryTable(w io.Writer, data *DSNData, timestamp time.Time) {
 	}
 
 	// Header
-	fmt.Fprintf(w, "%-8s %-8s %-8s %-14s %-4s %-10s %-12s %-6s %-8s\n",
-		"Complex", "Station", "Antenna", "Spacecraft", "Band", "Rate", "Distance", "Strug", "Health")
-	fmt.Fprintln(w, strings.Repeat("─", 90))
-
-	// Rows
-	for _, r := range rows {
-		fmt.Fprintf(w, "%-8s %-8s %-8s %-14s %-4s %-10s %-12s %5.0f%% %-8s\n",
-			truncateStr(r.Complex, 8),
-			truncateStr(r.Station, 8),
-			truncateStr(r.Antenna, 8),
-			truncateStr(r.Spacecraft, 14),
+	fmt.Fprintf(w, "%-8s %-8s %-8s %-14s %-4s %-12s %-10s %-10s %-12s %-6s %-8s\n",
+		"Complex", "Station", "Antenna", "Spacecraft", "Band", "Rate", "Share", "SNR", "Distance", "Strug", "Health")
+	fmt.Fprintln(w, strings.Repeat("─", 112))
+
+	// Rows; further spacecraft on an MSPA antenna are grouped under its
+	// first row
+	for i, r := range rows {
+		name := r.Spacecraft
+		if r.Inferred {
+			name += "?"
+		}
+		if r.Uplink {
+			name += " " + UplinkBadge
+		}
+		complex, station, antenna := truncateStr(r.Complex, 8), truncateStr(r.Station, 8), truncateStr(r.Antenna, 8)
+		if r.MSPA && i > 0 && rows[i-1].MSPA && rows[i-1].Antenna == r.Antenna {
+			complex, station, antenna = "", "", "└"
+		}
+		fmt.Fprintf(w, "%-8s %-8s %s %s %-4s %-12s %-10s %-10s %-12s %5.0f%% %-8s\n",
+			complex,
+			station,
+			PadWidth(antenna, 8, ".."),
+			PadWidth(name, 14, ".."),
 			r.Band,
 			r.Rate,
+			FormatShare(r.MSPA, r.Share),
+			r.SNR,
 			r.Distance,
 			r.Struggle*100,
 			r.Health,
 		)
 	}
 
-	fmt.Fprintf(w, "\nTotal: %d active links\n", len(rows))
+	fmt.Fprintf(w, "\nTotal: %d active links (health model: %s)\n", len(rows), data.ActiveHealthModel().Name)
+	for _, r := range rows {
+		if r.Inferred {
+			fmt.Fprintln(w, "? inferred: spacecraft identified by NAIF ID or recent history, not the feed's target")
+			break
+		}
+	}
 }
 
 func truncateStr(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
-	}
-	if maxLen <= 3 {
-		return s[:maxLen]
-	}
-	return s[:maxLen-2] + ".."
+	return TruncateWidth(s, maxLen, "..")
 }
 
 // MiniSkyConfig configures the mini sky view.
@@ -297,31 +446,31 @@ func WriteNowPlaying(w io.Writer, data *DSNData) {
 		return
 	}
 
-	// Build elevation map
-	elevMap := make(map[string]float64)
-	for _, station := range data.Stations {
-		for _, ant := range station.Antennas {
-			elevMap[ant.ID] = ant.Elevation
-		}
-	}
+	elevMap := BuildElevationMap(data)
 
 	var parts []string
 	for _, link := range data.Links {
-		elev := elevMap[link.AntennaID]
-		_, health := LinkHealth(link, elev)
+		elev := LinkElevation(link, elevMap)
+		_, health := data.ActiveHealthModel().LinkHealth(link, elev)
 		healthIcon := "●"
 		switch health {
 		case HealthMarginal:
 			healthIcon = "◐"
 		case HealthPoor:
 			healthIcon = "○"
+		case HealthCarrier:
+			healthIcon = "◇"
+		}
+		name := truncateStr(link.Spacecraft, 10)
+		if link.Uplink {
+			name += UplinkBadge
 		}
 		parts = append(parts, fmt.Sprintf("%s %s→%s %s %s",
 			healthIcon,
 			link.AntennaID,
-			truncateStr(link.Spacecraft, 10),
+			name,
 			FormatRTLT(link.RTLT),
-			FormatDataRate(link.DataRate),
+			FormatLinkRate(link),
 		))
 	}
 	fmt.Fprintln(w, strings.Join(parts, " | "))
@@ -329,53 +478,120 @@ func WriteNowPlaying(w io.Writer, data *DSNData) {
 
 // SpacecraftCard holds data for a single spacecraft card view.
 type SpacecraftCard struct {
-	Name     string
-	Links    []Link
-	Distance string
-	RTLT     string
-	Rate     string
-	Health   Health
-	Struggle float64
-	Band     string
-	Antenna  string
-	Complex  string
+	Name     string  `json:"name"`
+	Links    []Link  `json:"-"`
+	Distance string  `json:"distance"`
+	RTLT     string  `json:"rtlt"`
+	Rate     string  `json:"rate"`
+	Health   Health  `json:"health"`
+	Struggle float64 `json:"struggle_index"`
+	Band     string  `json:"band"`
+	Antenna  string  `json:"antenna"`
+	Complex  string  `json:"complex"`
+	Uplink   bool    `json:"uplink,omitempty"`
+	Tracking string  `json:"tracking_mode,omitempty"`
+	SpacecraftRef
+
+	Notes []notes.Note `json:"notes,omitempty"` // the user's journal, when attached
 }
 
-// WriteSpacecraftCard prints a vertical card for a single spacecraft.
-func WriteSpacecraftCard(w io.Writer, data *DSNData, name string, events []Event) {
+// FindSpacecraftCard builds the card for the first link to the named
+// spacecraft, or returns nil if it is not currently tracked.
+func FindSpacecraftCard(data *DSNData, name string) *SpacecraftCard {
 	if data == nil {
-		fmt.Fprintf(w, "Spacecraft %q not found\n", name)
-		return
+		return nil
 	}
 
-	// Find links for this spacecraft
-	var card *SpacecraftCard
-	elevMap := make(map[string]float64)
-	for _, station := range data.Stations {
-		for _, ant := range station.Antennas {
-			elevMap[ant.ID] = ant.Elevation
-		}
-	}
+	elevMap := BuildElevationMap(data)
 
 	for _, link := range data.Links {
 		if strings.EqualFold(link.Spacecraft, name) {
-			elev := elevMap[link.AntennaID]
-			struggle, health := LinkHealth(link, elev)
-			card = &SpacecraftCard{
-				Name:     link.Spacecraft,
-				Distance: FormatDistance(link.Distance),
-				RTLT:     FormatRTLT(link.RTLT),
-				Rate:     FormatDataRate(link.DataRate),
-				Health:   health,
-				Struggle: struggle,
-				Band:     link.Band,
-				Antenna:  link.AntennaID,
-				Complex:  string(link.Complex),
+			elev := LinkElevation(link, elevMap)
+			struggle, health := data.ActiveHealthModel().LinkHealth(link, elev)
+			return &SpacecraftCard{
+				Name:          link.Spacecraft,
+				Distance:      FormatDistance(link.Distance),
+				RTLT:          FormatRTLT(link.RTLT),
+				Rate:          FormatLinkRate(link),
+				Health:        health,
+				Struggle:      struggle,
+				Band:          link.Band,
+				Antenna:       link.AntennaID,
+				Complex:       string(link.Complex),
+				Uplink:        link.Uplink,
+				Tracking:      string(link.TrackingMode),
+				SpacecraftRef: GetSpacecraftRef(link.Spacecraft),
 			}
-			break
 		}
 	}
+	return nil
+}
 
+// WriteJSON writes the card as JSON to the given writer.
+func (c *SpacecraftCard) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(c)
+}
+
+// PassPlanExport is the JSON-serializable representation of a pass plan.
+type PassPlanExport struct {
+	Spacecraft  string       `json:"spacecraft"`
+	GeneratedAt time.Time    `json:"generated_at"`
+	WindowStart time.Time    `json:"window_start"`
+	WindowEnd   time.Time    `json:"window_end"`
+	Passes      []PassExport `json:"passes"`
+}
+
+// PassExport is a JSON-friendly pass representation.
+type PassExport struct {
+	Complex   string    `json:"complex"`
+	Start     time.Time `json:"start"`
+	Peak      time.Time `json:"peak"`
+	End       time.Time `json:"end"`
+	MaxElDeg  float64   `json:"max_elevation"`
+	SunMinSep float64   `json:"sun_min_separation"`
+	Status    string    `json:"status"`
+}
+
+// ExportPassPlan converts a pass plan to its JSON-friendly form.
+func ExportPassPlan(plan *PassPlan) *PassPlanExport {
+	export := &PassPlanExport{
+		Spacecraft:  plan.SpacecraftCode,
+		GeneratedAt: plan.GeneratedAt,
+		WindowStart: plan.WindowStart,
+		WindowEnd:   plan.WindowEnd,
+		Passes:      make([]PassExport, 0, len(plan.Passes)),
+	}
+	for _, p := range plan.Passes {
+		export.Passes = append(export.Passes, PassExport{
+			Complex:   string(p.Complex),
+			Start:     p.Start,
+			Peak:      p.Peak,
+			End:       p.End,
+			MaxElDeg:  p.MaxElDeg,
+			SunMinSep: p.SunMinSep,
+			Status:    p.Status.String(),
+		})
+	}
+	return export
+}
+
+// WriteJSON writes the pass plan as JSON to the given writer.
+func (e *PassPlanExport) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(e)
+}
+
+// WriteSpacecraftCard prints a vertical card for a single spacecraft.
+func WriteSpacecraftCard(w io.Writer, data *DSNData, name string, events []Event) {
+	if data == nil {
+		fmt.Fprintf(w, "Spacecraft %q not found\n", name)
+		return
+	}
+
+	card := FindSpacecraftCard(data, name)
 	if card == nil {
 		fmt.Fprintf(w, "Spacecraft %q not currently tracked\n", name)
 		return
@@ -383,7 +599,7 @@ func WriteSpacecraftCard(w io.Writer, data *DSNData, name string, events []Event
 
 	// Render card
 	fmt.Fprintln(w, "┌────────────────────────┐")
-	fmt.Fprintf(w, "│ %-22s │\n", truncateStr(card.Name, 22))
+	fmt.Fprintf(w, "│ %s │\n", PadWidth(card.Name, 22, ".."))
 	fmt.Fprintln(w, "├────────────────────────┤")
 	fmt.Fprintf(w, "│ Distance: %-12s │\n", card.Distance)
 	fmt.Fprintf(w, "│ RTT:      %-12s │\n", card.RTLT)
@@ -392,6 +608,15 @@ func WriteSpacecraftCard(w io.Writer, data *DSNData, name string, events []Event
 	fmt.Fprintf(w, "│ Health:   %-12s │\n", card.Health)
 	fmt.Fprintf(w, "│ Antenna:  %-12s │\n", card.Antenna)
 	fmt.Fprintf(w, "│ Complex:  %-12s │\n", card.Complex)
+	if card.Uplink {
+		fmt.Fprintf(w, "│ Uplink:   %s │\n", PadWidth(UplinkBadge+" commanding", 12, ".."))
+	}
+	if card.Tracking != "" {
+		fmt.Fprintf(w, "│ Mode:     %-12s │\n", card.Tracking)
+	}
+	if card.NAIFID != 0 {
+		fmt.Fprintf(w, "│ NAIF ID:  %-12d │\n", card.NAIFID)
+	}
 	fmt.Fprintln(w, "└────────────────────────┘")
 
 	// Recent events
@@ -502,7 +727,7 @@ func WriteDiff(w io.Writer, diff DiffResult, timestamp time.Time) {
 	fmt.Fprintf(w, "[%s] Changes:\n", timestamp.Format("15:04:05"))
 
 	for _, l := range diff.NewLinks {
-		fmt.Fprintf(w, "  + NEW: %s on %s (%s)\n", l.Spacecraft, l.AntennaID, FormatDataRate(l.DataRate))
+		fmt.Fprintf(w, "  + NEW: %s on %s (%s)\n", l.Spacecraft, l.AntennaID, FormatLinkRate(l))
 	}
 	for _, l := range diff.LostLinks {
 		fmt.Fprintf(w, "  - LOST: %s (was on %s)\n", l.Spacecraft, l.AntennaID)
@@ -532,11 +757,15 @@ func WriteEvents(w io.Writer, events []Event, limit int) {
 	count := 0
 	for i := len(events) - 1; i >= 0 && count < limit; i-- {
 		e := events[i]
+		detail := formatEventDetail(e)
+		if e.FeedLatency >= time.Second {
+			detail += fmt.Sprintf(" (feed +%s)", e.FeedLatency.Round(time.Second))
+		}
 		fmt.Fprintf(w, "  %s %-10s %-14s %s\n",
 			formatEventType(e.Type),
 			relativeTime(e.Timestamp),
 			truncateStr(e.Spacecraft, 14),
-			formatEventDetail(e),
+			detail,
 		)
 		count++
 	}
@@ -552,6 +781,20 @@ func formatEventType(t EventType) string {
 		return "○LOST"
 	case EventLinkResumed:
 		return "◐RESU"
+	case EventUplinkStart:
+		return "⬆UPLK"
+	case EventUplinkEnd:
+		return "·UPLK"
+	case EventRareAcquisition:
+		return "★RARE"
+	case EventComplexQuiet:
+		return "◌QUIE"
+	case EventComplexActive:
+		return "◉ACTV"
+	case EventWindRisk:
+		return "≋WIND"
+	case EventPointingDivergence:
+		return "⌖DIVG"
 	default:
 		return "?    "
 	}
@@ -567,11 +810,42 @@ func formatEventDetail(e Event) string {
 		return fmt.Sprintf("was %s", e.OldStation)
 	case EventLinkResumed:
 		return fmt.Sprintf("on %s", e.NewStation)
+	case EventUplinkStart:
+		return fmt.Sprintf("commanding via %s", e.AntennaID)
+	case EventUplinkEnd:
+		return fmt.Sprintf("ended on %s", e.AntennaID)
+	case EventRareAcquisition:
+		if e.LastSeen.IsZero() {
+			return fmt.Sprintf("on %s, first seen", e.NewStation)
+		}
+		return fmt.Sprintf("on %s, %dd unseen", e.NewStation, int(e.Timestamp.Sub(e.LastSeen).Hours()/24))
+	case EventComplexQuiet:
+		return fmt.Sprintf("%s: nothing tracked for %s", siteComplexName(Complex(e.Complex)), formatSessionLength(e.Timestamp.Sub(e.LastSeen)))
+	case EventComplexActive:
+		return fmt.Sprintf("%s: tracking after %s quiet", siteComplexName(Complex(e.Complex)), formatSessionLength(e.Timestamp.Sub(e.LastSeen)))
+	case EventWindRisk:
+		return fmt.Sprintf("%s wind %s", e.AntennaID, formatWindRisk(e.Wind, e.WindStow))
+	case EventPointingDivergence:
+		return fmt.Sprintf("%s %.1f° off ephemeris", e.AntennaID, e.Divergence)
 	default:
 		return ""
 	}
 }
 
+// formatWindRisk formats a WIND_RISK's wind against the stow limit in
+// force when it was raised. The event is only raised from caution up, so
+// below stow is near it.
+func formatWindRisk(kmh, stow float64) string {
+	switch {
+	case stow <= 0:
+		return fmt.Sprintf("%.0f km/h", kmh)
+	case kmh >= stow:
+		return fmt.Sprintf("%.0f km/h (stow)", kmh)
+	default:
+		return fmt.Sprintf("%.0f km/h (near stow)", kmh)
+	}
+}
+
 func relativeTime(t time.Time) string {
 	d := time.Since(t)
 	switch {
@@ -592,6 +866,15 @@ const (
 	EventHandoff     EventType = "HANDOFF"
 	EventLinkLost    EventType = "LINK_LOST"
 	EventLinkResumed EventType = "LINK_RESUMED"
+	EventUplinkStart EventType = "UPLINK_START"
+	EventUplinkEnd   EventType = "UPLINK_END"
+
+	EventRareAcquisition EventType = "RARE_ACQUISITION"
+	EventComplexQuiet    EventType = "COMPLEX_QUIET"
+	EventComplexActive   EventType = "COMPLEX_ACTIVE"
+	EventWindRisk        EventType = "WIND_RISK"
+
+	EventPointingDivergence EventType = "POINTING_DIVERGENCE"
 )
 
 // Event represents a state change event.
@@ -603,4 +886,72 @@ type Event struct {
 	NewStation string
 	AntennaID  string
 	Complex    string
+	LastSeen   time.Time // RARE_ACQUISITION, COMPLEX_*: last tracked before (zero if never)
+	Wind       float64   // WIND_RISK: wind at the antenna, km/h
+	WindStow   float64   // WIND_RISK: stow limit when raised, km/h
+	Divergence float64   // POINTING_DIVERGENCE: dish vs ephemeris, degrees
+
+	FeedLatency time.Duration // Fetch time minus the event's feed time
+}
+
+// WriteParseReport pretty-prints a parsed feed with diagnostics: the
+// station/antenna/target tree, the flattened links, parse warnings and
+// data quality issues. Intended for debugging feed parsing problems.
+func WriteParseReport(w io.Writer, data *DSNData) {
+	if data == nil {
+		fmt.Fprintln(w, "No data")
+		return
+	}
+
+	fmt.Fprintf(w, "Feed timestamp: %s\n", formatReportTime(data.Timestamp))
+	fmt.Fprintln(w, strings.Repeat("─", 60))
+
+	// Station / antenna / target tree
+	for _, stn := range data.Stations {
+		fmt.Fprintf(w, "Station %s (%s) complex=%s time=%s\n",
+			stn.Name, stn.FriendlyName, stn.Complex, formatReportTime(stn.TimeUTC))
+		for _, ant := range stn.Antennas {
+			fmt.Fprintf(w, "  %-6s az=%6.1f el=%5.1f wind=%5.1f activity=%q\n",
+				ant.ID, ant.Azimuth, ant.Elevation, ant.WindSpeed, ant.Activity)
+			for _, t := range ant.Targets {
+				fmt.Fprintf(w, "    target %-10s id=%-4d range=%.0fkm rtlt=%.1fs\n",
+					t.Name, t.ID, t.DownlegRange, t.RTLT)
+			}
+			for _, s := range ant.DownSignals {
+				fmt.Fprintf(w, "    down   %-10s active=%-5t band=%-2s rate=%s\n",
+					s.Spacecraft, s.Active, s.Band, FormatDataRate(s.DataRate))
+			}
+			for _, s := range ant.UpSignals {
+				fmt.Fprintf(w, "    up     %-10s active=%-5t band=%-2s rate=%s\n",
+					s.Spacecraft, s.Active, s.Band, FormatDataRate(s.DataRate))
+			}
+		}
+	}
+
+	// Flattened links
+	fmt.Fprintf(w, "\nLinks: %d\n", len(data.Links))
+	for _, l := range data.Links {
+		fmt.Fprintf(w, "  %-6s %-5s %-10s band=%-2s rate=%s rtlt=%.1fs\n",
+			l.AntennaID, l.Complex, l.Spacecraft, l.Band, FormatDataRate(l.DataRate), l.RTLT)
+	}
+
+	// Parse warnings
+	fmt.Fprintf(w, "\nParse warnings: %d\n", len(data.Errors))
+	for _, e := range data.Errors {
+		fmt.Fprintf(w, "  %s\n", e)
+	}
+
+	// Data quality issues (latency is meaningless for a static file)
+	report := AssessQuality(data, data.Timestamp)
+	fmt.Fprintf(w, "\nQuality issues: %d\n", report.Total())
+	for _, issue := range report.Issues {
+		fmt.Fprintf(w, "  [%s] %s: %s\n", issue.Category, issue.Subject, issue.Detail)
+	}
+}
+
+func formatReportTime(t time.Time) string {
+	if t.IsZero() {
+		return "(missing)"
+	}
+	return t.UTC().Format(time.RFC3339)
 }
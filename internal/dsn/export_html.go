@@ -0,0 +1,271 @@
+package dsn
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"strings"
+	"time"
+)
+
+// Mini sky SVG geometry: azimuth across, elevation up, in SVG units.
+const (
+	skySVGWidth  = 720
+	skySVGHeight = 200
+	skySVGMargin = 20 // room for compass labels below the horizon
+)
+
+// htmlCard is a spacecraft card with its recent events, for the page.
+type htmlCard struct {
+	*SpacecraftCard
+	FullName string
+	Link     string // the spacecraft's page in a published site
+	Events   []htmlEvent
+}
+
+// htmlEvent is an event line on a card, in UTC so the page doesn't age.
+type htmlEvent struct {
+	Time   string
+	Type   string
+	Detail string
+}
+
+// skyMark is one spacecraft on the mini sky SVG.
+type skyMark struct {
+	X, Y  float64
+	Label string
+	Title string // hover text
+}
+
+// skyStar is a background star on the mini sky SVG.
+type skyStar struct {
+	X, Y, R float64
+}
+
+// htmlPage is everything the status page template renders.
+type htmlPage struct {
+	Generated   string
+	Refresh     int // seconds between reloads (0 = none)
+	HealthModel string
+	Rows        []SummaryRow
+	Cards       []htmlCard
+	Width       int
+	Height      int
+	Horizon     float64
+	Stars       []skyStar
+	Marks       []skyMark
+	Compass     []skyMark
+
+	// Site pages link to each other; Root is the path back to the top
+	Site bool
+	Root string
+}
+
+// WriteHTML writes a self-contained status page: the summary table, a
+// card per tracked spacecraft with its recent events, and the mini sky
+// as inline SVG. It needs no scripts or external assets, so it can be
+// written by cron and served as a static file. A positive refresh makes
+// browsers reload it at that interval.
+func WriteHTML(w io.Writer, data *DSNData, fetchedAt time.Time, events []Event, refresh time.Duration) error {
+	return htmlTemplate.Execute(w, newHTMLPage(data, fetchedAt, events, refresh))
+}
+
+// newHTMLPage lays out the status page.
+func newHTMLPage(data *DSNData, fetchedAt time.Time, events []Event, refresh time.Duration) htmlPage {
+	page := htmlPage{
+		Generated:   fetchedAt.UTC().Format("2006-01-02 15:04:05 UTC"),
+		Refresh:     int(math.Ceil(refresh.Seconds())),
+		HealthModel: data.ActiveHealthModel().Name,
+		Rows:        GenerateSummaryRows(data),
+		Width:       skySVGWidth,
+		Height:      skySVGHeight + skySVGMargin,
+		Horizon:     skySVGHeight,
+	}
+	page.Cards = htmlCards(data, events)
+	page.Stars, page.Marks, page.Compass = miniSkySVG(data)
+	return page
+}
+
+// htmlCards returns a card per tracked spacecraft, in feed order.
+func htmlCards(data *DSNData, events []Event) []htmlCard {
+	if data == nil {
+		return nil
+	}
+	var cards []htmlCard
+	seen := make(map[string]bool)
+	for _, link := range data.Links {
+		if seen[link.Spacecraft] {
+			continue
+		}
+		seen[link.Spacecraft] = true
+		card := FindSpacecraftCard(data, link.Spacecraft)
+		if card == nil {
+			continue
+		}
+		hc := htmlCard{SpacecraftCard: card, FullName: GetSpacecraftName(card.Name)}
+		for i := len(events) - 1; i >= 0 && len(hc.Events) < 5; i-- {
+			e := events[i]
+			if strings.EqualFold(e.Spacecraft, card.Name) {
+				hc.Events = append(hc.Events, htmlEvent{
+					Time:   e.Timestamp.UTC().Format("Jan 02 15:04"),
+					Type:   string(e.Type),
+					Detail: formatEventDetail(e),
+				})
+			}
+		}
+		cards = append(cards, hc)
+	}
+	return cards
+}
+
+// miniSkySVG lays out the mini sky: the same seeded starfield and
+// azimuth/elevation mapping as WriteMiniSky, at SVG resolution.
+func miniSkySVG(data *DSNData) (stars []skyStar, marks []skyMark, compass []skyMark) {
+	for x := 0; x < skySVGWidth; x += 12 {
+		for y := 0; y < skySVGHeight; y += 10 {
+			switch {
+			case (x/12*7+y/10*13)%23 == 0:
+				stars = append(stars, skyStar{X: float64(x), Y: float64(y), R: 1.2})
+			case (x/12*11+y/10*17)%37 == 0:
+				stars = append(stars, skyStar{X: float64(x), Y: float64(y), R: 0.7})
+			}
+		}
+	}
+	for _, c := range []struct {
+		az    float64
+		label string
+	}{{0, "N"}, {90, "E"}, {180, "S"}, {270, "W"}} {
+		compass = append(compass, skyMark{X: c.az / 360 * skySVGWidth, Y: skySVGHeight + 15, Label: c.label})
+	}
+	if data == nil {
+		return stars, nil, compass
+	}
+	for _, obj := range data.SkyObjects() {
+		el := math.Max(0, math.Min(90, obj.Elevation))
+		marks = append(marks, skyMark{
+			X:     math.Mod(obj.Azimuth, 360) / 360 * skySVGWidth,
+			Y:     skySVGHeight - 8 - el/90*(skySVGHeight-16),
+			Label: obj.Spacecraft,
+			Title: fmt.Sprintf("%s via %s (%s): az %.0f°, el %.0f°",
+				GetSpacecraftName(obj.Spacecraft), obj.AntennaID, obj.Complex, obj.Azimuth, obj.Elevation),
+		})
+	}
+	return stars, marks, compass
+}
+
+var htmlTemplate = template.Must(template.New("status").Funcs(template.FuncMap{
+	"lower":   strings.ToLower,
+	"percent": func(f float64) string { return fmt.Sprintf("%.0f%%", f*100) },
+}).Parse(`{{define "style"}}<style>
+body { background: #0d0b1a; color: #d0c8ff; font: 14px/1.4 ui-monospace, Menlo, Consolas, monospace; margin: 2em; }
+h1, h2 { color: #ff5fd7; font-weight: bold; }
+h1 { font-size: 1.4em; } h2 { font-size: 1.1em; margin-top: 2em; }
+.dim { color: #6a6a7a; }
+table { border-collapse: collapse; }
+th { color: #00afff; background: #262626; text-align: left; }
+th, td { padding: 0.2em 0.8em; }
+tr:nth-child(even) td { background: #15122a; }
+.num { text-align: right; }
+.good { color: #5fd75f; } .marginal { color: #ffd75f; } .poor { color: #ff5f5f; } .carrier { color: #87afd7; }
+.uplink { color: #ffaf00; font-weight: bold; }
+.cards { display: flex; flex-wrap: wrap; gap: 1em; }
+.card { border: 1px solid #3a3450; padding: 0.6em 1em; min-width: 16em; }
+.card h3 { margin: 0 0 0.4em; color: #ffffaf; font-size: 1em; }
+.card dl { display: grid; grid-template-columns: auto 1fr; gap: 0 1em; margin: 0; }
+.card dt { color: #8a8a9a; } .card dd { margin: 0; }
+.card ul { margin: 0.4em 0 0; padding-left: 1.2em; }
+svg { max-width: 100%; height: auto; background: #080614; }
+svg .star { fill: #6a6a7a; } svg .horizon { stroke: #3a3450; }
+svg .sc { fill: #ff5fd7; } svg text { fill: #d0c8ff; font: 11px ui-monospace, monospace; }
+svg .line { fill: none; stroke: #00afff; stroke-width: 1.5; } svg .axis { stroke: #3a3450; }
+a { color: #00afff; } nav { margin-bottom: 1em; }
+</style>{{end}}
+{{- define "nav"}}<nav><a href="{{.Root}}index.html">Status</a> · <a href="{{.Root}}passes.html">Pass schedule</a></nav>{{end}}
+{{- define "card"}}<div class="card">
+<h3>{{if .Link}}<a href="{{.Link}}">{{.Name}}</a>{{else}}{{.Name}}{{end}}{{if ne .FullName .Name}} · {{.FullName}}{{end}}</h3>
+<dl>
+<dt>Distance</dt><dd>{{.Distance}}</dd>
+<dt>RTT</dt><dd>{{.RTLT}}</dd>
+<dt>Rate</dt><dd>{{.Rate}}</dd>
+<dt>Band</dt><dd>{{.Band}}</dd>
+<dt>Health</dt><dd class="{{lower (print .Health)}}">{{.Health}}</dd>
+<dt>Antenna</dt><dd>{{.Antenna}} ({{.Complex}})</dd>
+{{- if .Uplink}}
+<dt>Uplink</dt><dd class="uplink">⬆ commanding</dd>
+{{- end}}
+{{- if .Tracking}}
+<dt>Mode</dt><dd>{{.Tracking}}</dd>
+{{- end}}
+</dl>
+{{- if .Events}}
+<ul>
+{{- range .Events}}
+<li><span class="dim">{{.Time}}</span> {{.Type}} {{.Detail}}</li>
+{{- end}}
+</ul>
+{{- end}}
+</div>{{end -}}
+<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+{{- if .Refresh}}
+<meta http-equiv="refresh" content="{{.Refresh}}">
+{{- end}}
+<title>DSN Status @ {{.Generated}}</title>
+{{template "style"}}
+</head>
+<body>
+{{- if .Site}}
+{{template "nav" .}}
+{{- end}}
+<h1>DSN Status</h1>
+<p class="dim">Deep Space Network activity @ {{.Generated}} · health model: {{.HealthModel}}</p>
+
+<h2>Active links</h2>
+{{- if .Rows}}
+<table>
+<tr><th>Complex</th><th>Station</th><th>Antenna</th><th>Spacecraft</th><th>Band</th><th>Rate</th><th>Distance</th><th class="num">Struggle</th><th>Health</th></tr>
+{{- range .Rows}}
+<tr><td>{{.Complex}}</td><td>{{.Station}}</td><td>{{.Antenna}}</td><td>{{.Spacecraft}}{{if .Uplink}} <span class="uplink" title="commanding">⬆</span>{{end}}</td><td>{{.Band}}</td><td>{{.Rate}}</td><td>{{.Distance}}</td><td class="num">{{percent .Struggle}}</td><td class="{{lower (print .Health)}}">{{.Health}}</td></tr>
+{{- end}}
+</table>
+<p class="dim">Total: {{len .Rows}} active links</p>
+{{- else}}
+<p class="dim">No active links</p>
+{{- end}}
+
+<h2>Sky</h2>
+<svg viewBox="0 0 {{.Width}} {{.Height}}" width="{{.Width}}" height="{{.Height}}" role="img" aria-label="Spacecraft by azimuth and elevation">
+{{- range .Stars}}
+<circle class="star" cx="{{.X}}" cy="{{.Y}}" r="{{.R}}"/>
+{{- end}}
+<line class="horizon" x1="0" y1="{{.Horizon}}" x2="{{.Width}}" y2="{{.Horizon}}"/>
+{{- range .Compass}}
+<text x="{{.X}}" y="{{.Y}}" text-anchor="middle">{{.Label}}</text>
+{{- end}}
+{{- range .Marks}}
+<g><title>{{.Title}}</title><circle class="sc" cx="{{.X}}" cy="{{.Y}}" r="4"/><text x="{{.X}}" y="{{.Y}}" dx="6" dy="4">{{.Label}}</text></g>
+{{- end}}
+</svg>
+{{- if not .Marks}}
+<p class="dim">No spacecraft in view</p>
+{{- end}}
+
+<h2>Spacecraft</h2>
+{{- if .Cards}}
+<div class="cards">
+{{- range .Cards}}
+{{template "card" .}}
+{{- end}}
+</div>
+{{- else}}
+<p class="dim">No spacecraft tracked</p>
+{{- end}}
+
+<p class="dim">Generated by ls-horizons from NASA DSN Now.</p>
+</body>
+</html>
+`))
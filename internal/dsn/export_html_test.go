@@ -0,0 +1,96 @@
+package dsn
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+)
+
+func htmlTestData() *DSNData {
+	return &DSNData{
+		Stations: []Station{
+			{
+				Complex: ComplexCanberra,
+				Antennas: []Antenna{{
+					ID: "DSS43", Azimuth: 180, Elevation: 45,
+					Targets: []Target{{ID: 32, Name: "VGR2", RTLT: 160000}},
+				}},
+			},
+			{
+				Complex: ComplexMadrid,
+				Antennas: []Antenna{{
+					ID: "DSS63", Azimuth: 90, Elevation: 10,
+					Targets: []Target{{ID: 99, Name: "<b>X"}},
+				}},
+			},
+		},
+		Links: []Link{
+			{Complex: ComplexCanberra, AntennaID: "DSS43", Spacecraft: "VGR2", Band: "S", DataRate: 160, Uplink: true, Distance: 20e9},
+			{Complex: ComplexMadrid, AntennaID: "DSS63", Spacecraft: "<b>X", Band: "X", DataRate: 2000},
+		},
+	}
+}
+
+func TestWriteHTML(t *testing.T) {
+	events := []Event{
+		{Type: EventNewLink, Timestamp: time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC), Spacecraft: "VGR2", NewStation: "DSS43"},
+		{Type: EventHandoff, Timestamp: time.Date(2024, 1, 15, 9, 5, 0, 0, time.UTC), Spacecraft: "MRO", OldStation: "DSS14", NewStation: "DSS63"},
+	}
+	var buf bytes.Buffer
+	fetched := time.Date(2024, 1, 15, 10, 30, 0, 0, time.FixedZone("PST", -8*3600))
+	if err := WriteHTML(&buf, htmlTestData(), fetched, events, 30*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"<!DOCTYPE html>",
+		`<meta http-equiv="refresh" content="30">`,
+		"DSN Status @ 2024-01-15 18:30:00 UTC",
+		"<td>VGR2 <span class=\"uplink\"",
+		"Total: 2 active links",
+		"<h3>VGR2 · Voyager 2</h3>",
+		"Jan 15 09:00</span> NEW_LINK on DSS43",
+		"&lt;b&gt;X",
+		// VGR2 at az 180°, el 45°: mid-width, halfway up the sky
+		`cx="360" cy="100" r="4"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("page missing %q", want)
+		}
+	}
+	if strings.Contains(out, "<b>X") {
+		t.Error("spacecraft names should be escaped")
+	}
+	if strings.Contains(out, "MRO") {
+		t.Error("events for untracked spacecraft shouldn't get a card")
+	}
+
+	// The inline SVG is well-formed XML
+	start, end := strings.Index(out, "<svg"), strings.Index(out, "</svg>")
+	if start < 0 || end < 0 {
+		t.Fatal("page has no SVG")
+	}
+	var svg struct{}
+	if err := xml.Unmarshal([]byte(out[start:end+len("</svg>")]), &svg); err != nil {
+		t.Errorf("SVG is not well-formed: %v", err)
+	}
+}
+
+func TestWriteHTML_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteHTML(&buf, nil, time.Now(), nil, 0); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{"No active links", "No spacecraft in view", "No spacecraft tracked"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("empty page missing %q", want)
+		}
+	}
+	if strings.Contains(out, "http-equiv") {
+		t.Error("no refresh without an interval")
+	}
+}
@@ -0,0 +1,640 @@
+package dsn
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// History chart geometry, in SVG units: the plot area plus margins for
+// the value labels on the left and the time labels below.
+const (
+	chartWidth   = 600
+	chartHeight  = 120
+	chartLeft    = 80
+	chartTop     = 10
+	chartBottom  = 24
+	chartColumns = 240 // samples are averaged into this many columns
+)
+
+// siteGap is the longest gap between samples that a chart line bridges
+// and a tracking session spans; anything longer is a break in coverage.
+const siteGap = 15 * time.Minute
+
+// siteMaxSessions caps the tracking sessions listed on a spacecraft page.
+const siteMaxSessions = 20
+
+// Site is everything a published site is generated from.
+type Site struct {
+	Data      *DSNData
+	FetchedAt time.Time
+	Events    []Event
+
+	// History is recorded snapshots (oldest first) charted on the
+	// spacecraft pages over the HistoryWindow before FetchedAt.
+	History       []*SnapshotExport
+	HistoryWindow time.Duration
+
+	// Passes are upcoming pass plans, one per spacecraft.
+	Passes []*PassPlan
+
+	Refresh time.Duration // browser reload interval (0 = none)
+}
+
+// chartLabel is an axis label on a history chart.
+type chartLabel struct {
+	X, Y float64
+	Text string
+}
+
+// htmlChart is a line chart of one metric over the history window.
+type htmlChart struct {
+	Title   string
+	Latest  string
+	Width   int
+	Height  int
+	Left    int
+	Bottom  float64 // y of the time axis
+	Lines   []string
+	YLabels []chartLabel
+	XLabels []chartLabel
+}
+
+// htmlSession is a run of consecutive snapshots with the same antennas
+// tracking a spacecraft.
+type htmlSession struct {
+	Antenna  string
+	Complex  string
+	Band     string
+	Start    string
+	End      string
+	Duration string
+}
+
+// htmlPass is a row of a pass table.
+type htmlPass struct {
+	Spacecraft string
+	Link       string
+	Complex    string
+	Start      string
+	Peak       string
+	End        string
+	MaxEl      string
+	Status     string
+}
+
+// scPage is a spacecraft page.
+type scPage struct {
+	Site      bool
+	Root      string
+	Refresh   int
+	Generated string
+	Code      string
+	FullName  string
+	Card      *htmlCard
+	Window    string
+	Charts    []htmlChart
+	Sessions  []htmlSession
+	Passes    []htmlPass
+	Events    []htmlEvent
+}
+
+// passesPage is the pass schedule page.
+type passesPage struct {
+	Site      bool
+	Root      string
+	Refresh   int
+	Generated string
+	Passes    []htmlPass
+}
+
+// scSample is one recorded snapshot's view of a spacecraft.
+type scSample struct {
+	Time      time.Time
+	Rate      float64 // bps, best link
+	Elevation float64 // degrees, highest antenna
+	Distance  float64 // km
+	Antenna   string  // antennas tracking it, joined with "+"
+	Complex   string
+	Band      string
+}
+
+// WriteSite generates a static status site in dir: index.html with the
+// current status, passes.html with the upcoming pass schedule, and a page
+// per spacecraft under sc/ with charts of its recorded history, its
+// tracking sessions, and its next passes. Files are replaced whole, so a
+// web server never serves half a page.
+func WriteSite(dir string, site Site) error {
+	if err := os.MkdirAll(filepath.Join(dir, "sc"), 0o755); err != nil {
+		return err
+	}
+	refresh := int(math.Ceil(site.Refresh.Seconds()))
+	generated := site.FetchedAt.UTC().Format("2006-01-02 15:04:05 UTC")
+
+	index := newHTMLPage(site.Data, site.FetchedAt, site.Events, site.Refresh)
+	index.Site = true
+	for i := range index.Cards {
+		index.Cards[i].Link = "sc/" + SitePageName(index.Cards[i].Name) + ".html"
+	}
+	if err := writeSiteFile(filepath.Join(dir, "index.html"), func(w io.Writer) error {
+		return htmlTemplate.Execute(w, index)
+	}); err != nil {
+		return err
+	}
+
+	schedule := passesPage{Site: true, Refresh: refresh, Generated: generated,
+		Passes: htmlPasses(site.Passes, site.FetchedAt, "sc/")}
+	if err := writeSiteFile(filepath.Join(dir, "passes.html"), func(w io.Writer) error {
+		return siteTemplate.ExecuteTemplate(w, "passes", schedule)
+	}); err != nil {
+		return err
+	}
+
+	start := site.FetchedAt.Add(-site.HistoryWindow)
+	for _, code := range siteSpacecraft(site) {
+		page := scPage{
+			Site:      true,
+			Root:      "../",
+			Refresh:   refresh,
+			Generated: generated,
+			Code:      code,
+			FullName:  GetSpacecraftName(code),
+			Window:    formatSiteWindow(site.HistoryWindow),
+		}
+		for _, card := range htmlCards(site.Data, site.Events) {
+			if strings.EqualFold(card.Name, code) {
+				page.Card = &card
+				break
+			}
+		}
+		samples := spacecraftSamples(site.History, code, start, site.FetchedAt)
+		if len(samples) > 0 {
+			page.Charts = spacecraftCharts(samples, start, site.FetchedAt)
+		}
+		page.Sessions = trackingSessions(samples)
+		for _, plan := range site.Passes {
+			if strings.EqualFold(plan.SpacecraftCode, code) {
+				page.Passes = htmlPasses([]*PassPlan{plan}, site.FetchedAt, "")
+			}
+		}
+		for i := len(site.Events) - 1; i >= 0 && len(page.Events) < 20; i-- {
+			if e := site.Events[i]; strings.EqualFold(e.Spacecraft, code) {
+				page.Events = append(page.Events, htmlEvent{
+					Time:   e.Timestamp.UTC().Format("Jan 02 15:04"),
+					Type:   string(e.Type),
+					Detail: formatEventDetail(e),
+				})
+			}
+		}
+
+		name := filepath.Join(dir, "sc", SitePageName(code)+".html")
+		if err := writeSiteFile(name, func(w io.Writer) error {
+			return siteTemplate.ExecuteTemplate(w, "spacecraft", page)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SitePageName is a spacecraft's page name in a published site: its code
+// with anything but letters, digits, '-' and '_' replaced.
+func SitePageName(code string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, code)
+}
+
+// siteSpacecraft returns the codes that get a page: tracked now, in the
+// history window, or with a pass plan, sorted.
+func siteSpacecraft(site Site) []string {
+	seen := make(map[string]bool)
+	var codes []string
+	add := func(code string) {
+		if code != "" && !seen[strings.ToUpper(code)] {
+			seen[strings.ToUpper(code)] = true
+			codes = append(codes, code)
+		}
+	}
+	if site.Data != nil {
+		for _, link := range site.Data.Links {
+			add(link.Spacecraft)
+		}
+	}
+	start := site.FetchedAt.Add(-site.HistoryWindow)
+	for _, s := range site.History {
+		if t := snapshotTime(s); t.Before(start) || t.After(site.FetchedAt) {
+			continue
+		}
+		for _, link := range s.Links {
+			add(link.Spacecraft)
+		}
+	}
+	for _, plan := range site.Passes {
+		add(plan.SpacecraftCode)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// spacecraftSamples extracts a spacecraft's samples from the snapshots
+// between start and end.
+func spacecraftSamples(history []*SnapshotExport, code string, start, end time.Time) []scSample {
+	var samples []scSample
+	for _, s := range history {
+		t := snapshotTime(s)
+		if t.Before(start) || t.After(end) {
+			continue
+		}
+		sample := scSample{Time: t}
+		var antennas []string
+		for _, link := range s.Links {
+			if !strings.EqualFold(link.Spacecraft, code) {
+				continue
+			}
+			sample.Rate = math.Max(sample.Rate, link.DataRate)
+			sample.Elevation = math.Max(sample.Elevation, link.Elevation)
+			if sample.Distance == 0 {
+				sample.Distance = link.Distance
+			}
+			if !slices.Contains(antennas, link.AntennaID) {
+				antennas = append(antennas, link.AntennaID)
+			}
+			if sample.Complex == "" {
+				sample.Complex = link.Complex
+				sample.Band = link.Band
+			}
+		}
+		if len(antennas) == 0 {
+			continue
+		}
+		sort.Strings(antennas)
+		sample.Antenna = strings.Join(antennas, "+")
+		samples = append(samples, sample)
+	}
+	return samples
+}
+
+// spacecraftCharts charts data rate, elevation and distance.
+func spacecraftCharts(samples []scSample, start, end time.Time) []htmlChart {
+	latest := samples[len(samples)-1]
+	return []htmlChart{
+		newChart("Data rate", FormatDataRate(latest.Rate), samples, start, end, 0, 0,
+			func(s scSample) float64 { return s.Rate }, FormatDataRate),
+		newChart("Elevation", fmt.Sprintf("%.0f°", latest.Elevation), samples, start, end, 0, 90,
+			func(s scSample) float64 { return s.Elevation }, func(v float64) string { return fmt.Sprintf("%.0f°", v) }),
+		newChart("Distance", FormatDistance(latest.Distance), samples, start, end, math.NaN(), 0,
+			func(s scSample) float64 { return s.Distance }, FormatDistance),
+	}
+}
+
+// newChart draws value over [start, end], averaging samples into columns.
+// The value axis runs from lo to hi; hi <= lo scales to the data, and a
+// NaN lo starts the axis at the smallest value instead of zero. The line
+// breaks wherever samples are more than siteGap apart.
+func newChart(title, latest string, samples []scSample, start, end time.Time, lo, hi float64,
+	value func(scSample) float64, format func(float64) string) htmlChart {
+	chart := htmlChart{
+		Title:  title,
+		Latest: latest,
+		Width:  chartLeft + chartWidth + 10,
+		Height: chartTop + chartHeight + chartBottom,
+		Left:   chartLeft,
+		Bottom: chartTop + chartHeight,
+	}
+
+	window := end.Sub(start)
+	sums := make([]float64, chartColumns)
+	counts := make([]int, chartColumns)
+	for _, s := range samples {
+		offset := s.Time.Sub(start)
+		if window <= 0 || offset < 0 || offset > window {
+			continue
+		}
+		i := min(chartColumns-1, int(float64(offset)/float64(window)*chartColumns))
+		sums[i] += value(s)
+		counts[i]++
+	}
+
+	if math.IsNaN(lo) || hi <= lo {
+		fromZero := !math.IsNaN(lo)
+		lo, hi = math.Inf(1), math.Inf(-1)
+		for i, n := range counts {
+			if n > 0 {
+				lo = math.Min(lo, sums[i]/float64(n))
+				hi = math.Max(hi, sums[i]/float64(n))
+			}
+		}
+		if fromZero || lo > hi {
+			lo = 0
+		}
+		if hi <= lo {
+			// A flat series sits mid-chart
+			pad := math.Max(1, math.Abs(hi)*0.01)
+			lo, hi = lo-pad, hi+pad
+		}
+	}
+
+	colWidth := float64(window) / chartColumns
+	maxGap := max(2, int(math.Ceil(float64(siteGap)/colWidth)))
+	var line []string
+	var x, y float64
+	last := -maxGap - 1
+	flush := func() {
+		if len(line) == 1 {
+			// A lone point still needs a visible stroke
+			line = []string{fmt.Sprintf("%.1f,%.1f", x-1, y), fmt.Sprintf("%.1f,%.1f", x+1, y)}
+		}
+		if len(line) > 0 {
+			chart.Lines = append(chart.Lines, strings.Join(line, " "))
+		}
+		line = nil
+	}
+	for i, n := range counts {
+		if n == 0 {
+			continue
+		}
+		if i-last > maxGap {
+			flush()
+		}
+		last = i
+		v := sums[i] / float64(n)
+		x = chartLeft + (float64(i)+0.5)/chartColumns*chartWidth
+		y = chartTop + chartHeight - (v-lo)/(hi-lo)*chartHeight
+		line = append(line, fmt.Sprintf("%.1f,%.1f", x, y))
+	}
+	flush()
+
+	chart.YLabels = []chartLabel{
+		{X: chartLeft - 6, Y: chartTop + 4, Text: format(hi)},
+		{X: chartLeft - 6, Y: chartTop + chartHeight, Text: format(lo)},
+	}
+	for i := range 4 {
+		t := start.Add(time.Duration(i) * window / 3)
+		chart.XLabels = append(chart.XLabels, chartLabel{
+			X:    chartLeft + float64(i)/3*chartWidth,
+			Y:    chartTop + chartHeight + 16,
+			Text: t.UTC().Format("Jan 02 15:04"),
+		})
+	}
+	return chart
+}
+
+// trackingSessions groups samples into runs on the same antennas, most
+// recent first.
+func trackingSessions(samples []scSample) []htmlSession {
+	runs := sessionRuns(samples)
+	var sessions []htmlSession
+	for i := len(runs) - 1; i >= 0 && len(sessions) < siteMaxSessions; i-- {
+		r := runs[i]
+		sessions = append(sessions, htmlSession{
+			Antenna:  r.first.Antenna,
+			Complex:  siteComplexName(Complex(r.first.Complex)),
+			Band:     r.first.Band,
+			Start:    r.first.Time.UTC().Format("Jan 02 15:04"),
+			End:      r.last.Time.UTC().Format("Jan 02 15:04"),
+			Duration: formatSessionLength(r.last.Time.Sub(r.first.Time)),
+		})
+	}
+	return sessions
+}
+
+// sessionRun is a run of samples on the same antennas.
+type sessionRun struct {
+	first, last scSample
+	peakRate    float64
+	maxEl       float64
+}
+
+// sessionRuns groups samples into runs on the same antennas with no gap
+// longer than siteGap, oldest first.
+func sessionRuns(samples []scSample) []sessionRun {
+	var runs []sessionRun
+	for _, s := range samples {
+		if n := len(runs); n > 0 && runs[n-1].last.Antenna == s.Antenna && s.Time.Sub(runs[n-1].last.Time) <= siteGap {
+			r := &runs[n-1]
+			r.last = s
+			r.peakRate = math.Max(r.peakRate, s.Rate)
+			r.maxEl = math.Max(r.maxEl, s.Elevation)
+			continue
+		}
+		runs = append(runs, sessionRun{first: s, last: s, peakRate: s.Rate, maxEl: s.Elevation})
+	}
+	return runs
+}
+
+// htmlPasses lists the passes that haven't ended by now, soonest first.
+// Spacecraft link to their pages under prefix.
+func htmlPasses(plans []*PassPlan, now time.Time, prefix string) []htmlPass {
+	type entry struct {
+		code string
+		pass Pass
+	}
+	var entries []entry
+	for _, plan := range plans {
+		if plan == nil {
+			continue
+		}
+		for _, p := range plan.Passes {
+			if p.End.After(now) {
+				entries = append(entries, entry{plan.SpacecraftCode, p})
+			}
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].pass.Start.Before(entries[j].pass.Start) })
+
+	const layout = "Mon Jan 02 15:04"
+	rows := make([]htmlPass, len(entries))
+	for i, e := range entries {
+		status := ""
+		if !e.pass.Start.After(now) {
+			status = "in progress"
+		}
+		rows[i] = htmlPass{
+			Spacecraft: e.code,
+			Link:       prefix + SitePageName(e.code) + ".html",
+			Complex:    siteComplexName(e.pass.Complex),
+			Start:      e.pass.Start.UTC().Format(layout),
+			Peak:       e.pass.Peak.UTC().Format("15:04"),
+			End:        e.pass.End.UTC().Format(layout),
+			MaxEl:      fmt.Sprintf("%.0f°", e.pass.MaxElDeg),
+			Status:     status,
+		}
+	}
+	return rows
+}
+
+// writeSiteFile replaces name with what write produces.
+func writeSiteFile(name string, write func(io.Writer) error) error {
+	f, err := os.CreateTemp(filepath.Dir(name), "."+filepath.Base(name)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if err := write(f); err != nil {
+		f.Close()
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	// CreateTemp makes the file 0600; the site is meant to be served
+	if err := f.Chmod(0o644); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), name)
+}
+
+// siteComplexName is a complex's full name, or its short name if unknown.
+func siteComplexName(c Complex) string {
+	if info, ok := KnownComplexes[c]; ok {
+		return info.Name
+	}
+	return ComplexShortName(c)
+}
+
+// formatSessionLength formats a tracking session's length, or a quiet
+// spell's, as 2h 15m.
+func formatSessionLength(d time.Duration) string {
+	d = d.Round(time.Minute)
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+}
+
+// formatSiteWindow formats the history window as hours or days.
+func formatSiteWindow(d time.Duration) string {
+	if d >= 48*time.Hour && d%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%dd", d/(24*time.Hour))
+	}
+	return fmt.Sprintf("%.0fh", d.Hours())
+}
+
+var siteTemplate = template.Must(template.Must(htmlTemplate.Clone()).Parse(`
+{{- define "passtable"}}
+{{- if .}}
+<table>
+<tr><th>Spacecraft</th><th>Complex</th><th>Rises</th><th>Peak</th><th class="num">Max el</th><th>Sets</th><th></th></tr>
+{{- range .}}
+<tr><td><a href="{{.Link}}">{{.Spacecraft}}</a></td><td>{{.Complex}}</td><td>{{.Start}}</td><td>{{.Peak}}</td><td class="num">{{.MaxEl}}</td><td>{{.End}}</td><td class="good">{{.Status}}</td></tr>
+{{- end}}
+</table>
+{{- else}}
+<p class="dim">No upcoming passes computed</p>
+{{- end}}
+{{- end}}
+
+{{- define "passes"}}<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+{{- if .Refresh}}
+<meta http-equiv="refresh" content="{{.Refresh}}">
+{{- end}}
+<title>DSN Pass Schedule @ {{.Generated}}</title>
+{{template "style"}}
+</head>
+<body>
+{{template "nav" .}}
+<h1>Pass Schedule</h1>
+<p class="dim">Upcoming passes at each DSN complex, above each spacecraft's minimum elevation, times UTC @ {{.Generated}}</p>
+{{template "passtable" .Passes}}
+
+<p class="dim">Generated by ls-horizons from NASA DSN Now and JPL Horizons.</p>
+</body>
+</html>
+{{end}}
+
+{{- define "spacecraft"}}<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+{{- if .Refresh}}
+<meta http-equiv="refresh" content="{{.Refresh}}">
+{{- end}}
+<title>{{.Code}} @ {{.Generated}}</title>
+{{template "style"}}
+</head>
+<body>
+{{template "nav" .}}
+<h1>{{.Code}}{{if ne .FullName .Code}} · {{.FullName}}{{end}}</h1>
+<p class="dim">@ {{.Generated}}</p>
+
+<h2>Now</h2>
+{{- if .Card}}
+<div class="cards">
+{{template "card" .Card}}
+</div>
+{{- else}}
+<p class="dim">Not tracked by the DSN right now</p>
+{{- end}}
+
+<h2>History (last {{.Window}})</h2>
+{{- range .Charts}}
+<h3>{{.Title}} <span class="dim">now {{.Latest}}</span></h3>
+<svg viewBox="0 0 {{.Width}} {{.Height}}" width="{{.Width}}" height="{{.Height}}" role="img" aria-label="{{.Title}} over time">
+<line class="axis" x1="{{.Left}}" y1="{{.Bottom}}" x2="{{.Width}}" y2="{{.Bottom}}"/>
+{{- range .YLabels}}
+<text x="{{.X}}" y="{{.Y}}" text-anchor="end">{{.Text}}</text>
+{{- end}}
+{{- range .XLabels}}
+<text x="{{.X}}" y="{{.Y}}" text-anchor="middle">{{.Text}}</text>
+{{- end}}
+{{- range .Lines}}
+<polyline class="line" points="{{.}}"/>
+{{- end}}
+</svg>
+{{- else}}
+<p class="dim">No recorded history: run ls-horizons with --record to collect it</p>
+{{- end}}
+
+<h2>Tracking sessions</h2>
+{{- if .Sessions}}
+<table>
+<tr><th>Antenna</th><th>Complex</th><th>Band</th><th>Start</th><th>End</th><th class="num">Duration</th></tr>
+{{- range .Sessions}}
+<tr><td>{{.Antenna}}</td><td>{{.Complex}}</td><td>{{.Band}}</td><td>{{.Start}}</td><td>{{.End}}</td><td class="num">{{.Duration}}</td></tr>
+{{- end}}
+</table>
+{{- else}}
+<p class="dim">No recorded sessions</p>
+{{- end}}
+
+<h2>Upcoming passes</h2>
+{{template "passtable" .Passes}}
+
+<h2>Recent events</h2>
+{{- if .Events}}
+<ul>
+{{- range .Events}}
+<li><span class="dim">{{.Time}}</span> {{.Type}} {{.Detail}}</li>
+{{- end}}
+</ul>
+{{- else}}
+<p class="dim">No recent events</p>
+{{- end}}
+
+<p class="dim">Generated by ls-horizons from NASA DSN Now.</p>
+</body>
+</html>
+{{end}}`))
@@ -0,0 +1,131 @@
+package dsn
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteSite(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	// VGR2 on DSS43 for the last two hours, then a recording gap, then
+	// an hour on DSS43 earlier in the day; CAS only in the history
+	var history []*SnapshotExport
+	record := func(at time.Time, data *DSNData) {
+		data.Timestamp = at
+		history = append(history, ExportSnapshot(data, at))
+	}
+	for m := 0; m <= 60; m += 5 {
+		record(now.Add(-6*time.Hour+time.Duration(m)*time.Minute), htmlTestData())
+	}
+	for m := 0; m <= 120; m += 5 {
+		data := htmlTestData()
+		data.Links[0].DataRate = float64(100 + m)
+		record(now.Add(-2*time.Hour+time.Duration(m)*time.Minute), data)
+	}
+	record(now.Add(-3*time.Hour), &DSNData{Links: []Link{{Complex: ComplexMadrid, AntennaID: "DSS54", Spacecraft: "CAS", DataRate: 1000}}})
+	record(now.Add(-48*time.Hour), &DSNData{Links: []Link{{Complex: ComplexMadrid, AntennaID: "DSS54", Spacecraft: "OLD"}}})
+
+	plan := &PassPlan{SpacecraftCode: "VGR2", Passes: []Pass{
+		{Complex: ComplexCanberra, Start: now.Add(-time.Hour), Peak: now.Add(3 * time.Hour), End: now.Add(7 * time.Hour), MaxElDeg: 62},
+		{Complex: ComplexGoldstone, Start: now.Add(-20 * time.Hour), End: now.Add(-10 * time.Hour)}, // over
+	}}
+
+	dir := t.TempDir()
+	err := WriteSite(dir, Site{
+		Data:          htmlTestData(),
+		FetchedAt:     now,
+		Events:        []Event{{Type: EventNewLink, Timestamp: now.Add(-2 * time.Hour), Spacecraft: "VGR2", NewStation: "DSS43"}},
+		History:       history,
+		HistoryWindow: 24 * time.Hour,
+		Passes:        []*PassPlan{plan},
+		Refresh:       5 * time.Minute,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	read := func(name string) string {
+		t.Helper()
+		raw, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(raw)
+	}
+	contains := func(name, page string, wants ...string) {
+		t.Helper()
+		for _, want := range wants {
+			if !strings.Contains(page, want) {
+				t.Errorf("%s missing %q", name, want)
+			}
+		}
+	}
+
+	contains("index.html", read("index.html"),
+		`<a href="index.html">Status</a>`,
+		`<a href="sc/VGR2.html">VGR2</a>`,
+		`<meta http-equiv="refresh" content="300">`)
+
+	contains("passes.html", read("passes.html"),
+		`<a href="sc/VGR2.html">VGR2</a></td><td>Canberra</td><td>Mon Jan 15 11:00</td>`,
+		"62°", "in progress")
+	if strings.Contains(read("passes.html"), "Goldstone") {
+		t.Error("passes that have ended shouldn't be listed")
+	}
+
+	vgr2 := read("sc/VGR2.html")
+	contains("sc/VGR2.html", vgr2,
+		`<a href="../index.html">Status</a>`,
+		"<h1>VGR2 · Voyager 2</h1>",
+		"History (last 24h)", "Data rate", "now 220 bps",
+		"<td>DSS43</td><td>Canberra</td><td>S</td><td>Jan 15 10:00</td><td>Jan 15 12:00</td><td class=\"num\">2h</td>",
+		"<td>Jan 15 06:00</td><td>Jan 15 07:00</td>",
+		`<a href="VGR2.html">VGR2</a>`,
+		"NEW_LINK on DSS43")
+	// The gap between sessions breaks the chart lines
+	if n := strings.Count(vgr2, `<polyline class="line" points="`); n != 6 {
+		t.Errorf("got %d chart lines, want 2 per chart", n)
+	}
+	start, end := strings.Index(vgr2, "<svg"), strings.Index(vgr2, "</svg>")
+	var svg struct{}
+	if err := xml.Unmarshal([]byte(vgr2[start:end+len("</svg>")]), &svg); err != nil {
+		t.Errorf("chart SVG is not well-formed: %v", err)
+	}
+
+	contains("sc/CAS.html", read("sc/CAS.html"), "Not tracked by the DSN right now", "DSS54", "No upcoming passes computed")
+	contains("sc/_b_X.html", read("sc/_b_X.html"), "&lt;b&gt;X")
+	if _, err := os.Stat(filepath.Join(dir, "sc", "OLD.html")); err == nil {
+		t.Error("spacecraft only seen before the history window shouldn't get a page")
+	}
+}
+
+func TestNewChart(t *testing.T) {
+	start := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+	samples := []scSample{
+		{Time: start.Add(time.Hour), Elevation: 45},
+		{Time: start.Add(12 * time.Hour), Elevation: 90},
+	}
+	chart := newChart("Elevation", "", samples, start, end, 0, 90,
+		func(s scSample) float64 { return s.Elevation }, func(v float64) string { return "" })
+	// Two isolated points: two short strokes, at half and full height
+	if len(chart.Lines) != 2 {
+		t.Fatalf("lines = %q, want 2", chart.Lines)
+	}
+	if !strings.HasSuffix(chart.Lines[0], ",70.0") || !strings.HasSuffix(chart.Lines[1], ",10.0") {
+		t.Errorf("lines = %q", chart.Lines)
+	}
+}
+
+func TestSitePageName(t *testing.T) {
+	for code, want := range map[string]string{"VGR2": "VGR2", "MRO": "MRO", "../x": "___x", "EM-1": "EM-1"} {
+		if got := SitePageName(code); got != want {
+			t.Errorf("SitePageName(%q) = %q, want %q", code, got, want)
+		}
+	}
+}
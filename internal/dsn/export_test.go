@@ -2,7 +2,9 @@ package dsn
 
 import (
 	"bytes"
+	"encoding/csv"
 	"encoding/json"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -108,6 +110,103 @@ func TestSnapshotExport_WriteJSON(t *testing.T) {
 	}
 }
 
+func TestSnapshotExport_WriteJSONLine(t *testing.T) {
+	var buf bytes.Buffer
+	for i := range 3 {
+		export := &SnapshotExport{
+			FetchedAt: time.Date(2024, 1, 15, 10, 30, i*5, 0, time.UTC),
+			Links:     []LinkExport{{Spacecraft: "VGR1", DataRate: 160, Health: "POOR"}},
+		}
+		if err := export.WriteJSONLine(&buf); err != nil {
+			t.Fatalf("WriteJSONLine failed: %v", err)
+		}
+	}
+
+	// One compact object per line
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("lines = %d, want 3", len(lines))
+	}
+	for _, line := range lines {
+		var parsed map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &parsed); err != nil {
+			t.Errorf("line is not valid JSON: %v", err)
+		}
+	}
+
+	// The stream reads back as snapshots
+	snaps, err := ReadSnapshots(&buf)
+	if err != nil {
+		t.Fatalf("ReadSnapshots failed: %v", err)
+	}
+	if len(snaps) != 3 || snaps[2].FetchedAt.Second() != 10 {
+		t.Errorf("read back %d snapshots", len(snaps))
+	}
+}
+
+func TestSnapshotExport_WriteCSV(t *testing.T) {
+	data, err := Parse([]byte(realisticXML))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	export := ExportSnapshot(data, data.Timestamp.Add(2*time.Second))
+
+	var buf bytes.Buffer
+	if err := export.WriteCSV(&buf, true); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Output is not valid CSV: %v", err)
+	}
+	if len(records) != len(data.Links)+1 {
+		t.Fatalf("rows = %d, want header + %d links", len(records), len(data.Links))
+	}
+	if !slices.Equal(records[0], CSVHeader) {
+		t.Errorf("header = %v", records[0])
+	}
+
+	col := func(row []string, name string) string {
+		return row[slices.Index(CSVHeader, name)]
+	}
+	var vgr1 []string
+	for _, row := range records[1:] {
+		if col(row, "spacecraft") == "VGR1" {
+			vgr1 = row
+		}
+	}
+	if vgr1 == nil {
+		t.Fatal("no VGR1 row")
+	}
+	for name, want := range map[string]string{
+		"antenna_id":     "DSS65",
+		"naif_id":        "-31",
+		"data_rate_bps":  "160",
+		"health":         string(HealthPoor),
+		"fetched_at":     "2025-12-04T15:02:57Z",
+		"down_power_dbm": "-155",
+		"up_power_kw":    "",
+		"snr_dbhz":       "29.6",
+		"direction":      DirectionDown,
+		"down_rate_bps":  "160",
+		"up_rate_bps":    "",
+		"inferred":       "false",
+	} {
+		if got := col(vgr1, name); got != want {
+			t.Errorf("VGR1 %s = %q, want %q", name, got, want)
+		}
+	}
+
+	// Without the header, only link rows are written
+	buf.Reset()
+	if err := export.WriteCSV(&buf, false); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+	if n := strings.Count(buf.String(), "\n"); n != len(data.Links) {
+		t.Errorf("headerless lines = %d, want %d", n, len(data.Links))
+	}
+}
+
 func TestGenerateSummaryRows(t *testing.T) {
 	data := &DSNData{
 		Stations: []Station{
@@ -173,6 +272,7 @@ func TestWriteSummaryTable(t *testing.T) {
 				Spacecraft: "EMM",
 				Band:       "X",
 				DataRate:   240000,
+				DownPower:  -120,
 				Distance:   300e6,
 			},
 		},
@@ -194,6 +294,9 @@ func TestWriteSummaryTable(t *testing.T) {
 	if !strings.Contains(output, "EMM") {
 		t.Error("Output should contain spacecraft name")
 	}
+	if !strings.Contains(output, "64.6 dB-Hz") {
+		t.Error("Output should contain the estimated SNR")
+	}
 	if !strings.Contains(output, "Madrid") || !strings.Contains(output, "MDSCC") || !strings.Contains(output, "1 active") {
 		// At least should have active links count
 		if !strings.Contains(output, "1 active") {
@@ -464,6 +567,24 @@ func TestWriteEvents(t *testing.T) {
 	}
 }
 
+func TestWriteEvents_FeedLatency(t *testing.T) {
+	events := []Event{
+		{Type: EventHandoff, Timestamp: time.Now(), Spacecraft: "Test", OldStation: "gdscc", NewStation: "cdscc", FeedLatency: 4200 * time.Millisecond},
+		{Type: EventNewLink, Timestamp: time.Now(), Spacecraft: "Fresh", NewStation: "mdscc", FeedLatency: 300 * time.Millisecond},
+	}
+
+	var buf bytes.Buffer
+	WriteEvents(&buf, events, 10)
+	output := buf.String()
+
+	if !strings.Contains(output, "gdscc→cdscc (feed +4s)") {
+		t.Errorf("handoff should show feed latency:\n%s", output)
+	}
+	if strings.Count(output, "feed +") != 1 {
+		t.Errorf("sub-second latency should be omitted:\n%s", output)
+	}
+}
+
 func TestWriteEvents_Empty(t *testing.T) {
 	var buf bytes.Buffer
 	WriteEvents(&buf, nil, 10)
@@ -480,6 +601,9 @@ func TestFormatEventType(t *testing.T) {
 		{EventNewLink, "●NEW "},
 		{EventHandoff, "→HAND"},
 		{EventLinkLost, "○LOST"},
+		{EventUplinkStart, "⬆UPLK"},
+		{EventUplinkEnd, "·UPLK"},
+		{EventRareAcquisition, "★RARE"},
 	}
 	for _, tt := range tests {
 		if got := formatEventType(tt.t); got != tt.want {
@@ -487,3 +611,160 @@ func TestFormatEventType(t *testing.T) {
 		}
 	}
 }
+
+func TestWriteParseReport(t *testing.T) {
+	data, err := Parse([]byte(realisticXML))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	data.Errors = append(data.Errors, "parse elevation: bad value")
+
+	var buf bytes.Buffer
+	WriteParseReport(&buf, data)
+	out := buf.String()
+
+	for _, want := range []string{
+		"Feed timestamp:",
+		"Station gdscc",
+		"Links: ",
+		"Parse warnings: 1",
+		"parse elevation: bad value",
+		"Quality issues: 1",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteParseReport_Nil(t *testing.T) {
+	var buf bytes.Buffer
+	WriteParseReport(&buf, nil)
+	if !strings.Contains(buf.String(), "No data") {
+		t.Errorf("unexpected output for nil data: %q", buf.String())
+	}
+}
+
+func TestWriteSummaryTable_WideNames(t *testing.T) {
+	data := &DSNData{
+		Links: []Link{
+			{Complex: ComplexCanberra, AntennaID: "DSS-43", Spacecraft: "VGR1", Band: "X"},
+			{Complex: ComplexMadrid, AntennaID: "DSS-63", Spacecraft: "はやぶさ2 (Hayabusa2)", Band: "X"},
+		},
+	}
+
+	var buf bytes.Buffer
+	WriteSummaryTable(&buf, data, time.Now())
+
+	// The Band column must start at the same cell offset on every row
+	var offsets []int
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if i := strings.Index(line, " X "); i >= 0 {
+			offsets = append(offsets, DisplayWidth(line[:i]))
+		}
+	}
+	if len(offsets) != 2 || offsets[0] != offsets[1] {
+		t.Errorf("Band column offsets = %v, want two equal offsets", offsets)
+	}
+}
+
+func TestExportSnapshot_SpacecraftRef(t *testing.T) {
+	data := &DSNData{
+		Links: []Link{
+			{Complex: ComplexGoldstone, AntennaID: "DSS-14", Spacecraft: "JNO", SpacecraftID: 61},
+			{Complex: ComplexMadrid, AntennaID: "DSS-63", Spacecraft: "TEST", SpacecraftID: 99},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := ExportSnapshot(data, time.Now()).WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON error: %v", err)
+	}
+
+	var parsed struct {
+		Links []map[string]any `json:"links"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &parsed); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if len(parsed.Links) != 2 {
+		t.Fatalf("links = %d, want 2", len(parsed.Links))
+	}
+
+	juno := parsed.Links[0]
+	if juno["code"] != "JUNO" {
+		t.Errorf("code = %v, want JUNO", juno["code"])
+	}
+	if juno["naif_id"] != float64(-61) {
+		t.Errorf("naif_id = %v, want -61", juno["naif_id"])
+	}
+	if url, _ := juno["horizons_url"].(string); !strings.Contains(url, "COMMAND='-61'") {
+		t.Errorf("horizons_url = %q, want COMMAND='-61'", url)
+	}
+	if url, _ := juno["nssdc_url"].(string); !strings.HasSuffix(url, "id=2011-040A") {
+		t.Errorf("nssdc_url = %q, want id=2011-040A", url)
+	}
+
+	// Unknown spacecraft omit the reference fields
+	for _, key := range []string{"code", "naif_id", "horizons_url", "nssdc_url"} {
+		if _, ok := parsed.Links[1][key]; ok {
+			t.Errorf("unknown spacecraft has %q", key)
+		}
+	}
+}
+
+func TestSpacecraftCard_WriteJSON(t *testing.T) {
+	data := &DSNData{
+		Links: []Link{
+			{Complex: ComplexCanberra, AntennaID: "DSS-43", Spacecraft: "VGR2", Band: "S"},
+		},
+	}
+
+	card := FindSpacecraftCard(data, "vgr2")
+	if card == nil {
+		t.Fatal("FindSpacecraftCard returned nil")
+	}
+
+	var buf bytes.Buffer
+	if err := card.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON error: %v", err)
+	}
+	var parsed map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &parsed); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if parsed["name"] != "VGR2" || parsed["antenna"] != "DSS-43" {
+		t.Errorf("card = %v", parsed)
+	}
+	if parsed["naif_id"] != float64(-32) || parsed["cospar_id"] != "1977-076A" {
+		t.Errorf("naif_id/cospar_id = %v/%v, want -32/1977-076A", parsed["naif_id"], parsed["cospar_id"])
+	}
+
+	if FindSpacecraftCard(data, "JWST") != nil {
+		t.Error("FindSpacecraftCard(JWST) should be nil when not tracked")
+	}
+}
+
+func TestExportPassPlan(t *testing.T) {
+	start := time.Date(2025, 12, 5, 10, 0, 0, 0, time.UTC)
+	plan := &PassPlan{
+		SpacecraftCode: "VGR1",
+		GeneratedAt:    start,
+		Passes: []Pass{
+			{Complex: ComplexCanberra, Start: start, Peak: start.Add(4 * time.Hour), End: start.Add(8 * time.Hour), MaxElDeg: 52.5, Status: PassNow},
+		},
+	}
+
+	export := ExportPassPlan(plan)
+	if export.Spacecraft != "VGR1" || len(export.Passes) != 1 {
+		t.Fatalf("export = %+v", export)
+	}
+	if p := export.Passes[0]; p.Complex != "cdscc" || p.Status != "NOW" || p.MaxElDeg != 52.5 {
+		t.Errorf("pass = %+v", p)
+	}
+
+	// No passes still encodes as an empty list
+	if got := ExportPassPlan(&PassPlan{}).Passes; got == nil {
+		t.Error("Passes = nil, want empty slice")
+	}
+}
@@ -93,9 +93,12 @@ func (f *Fetcher) Fetch(ctx context.Context) FetchResult {
 
 	data, err := Parse(rawData)
 	if err != nil {
-		result.Error = fmt.Errorf("parse DSN data: %w", err)
+		result.Error = fmt.Errorf("DSN feed: %w", err)
 		return result
 	}
+	if data.Timestamp.IsZero() {
+		data.Timestamp = start.UTC()
+	}
 	result.Data = data
 
 	return result
@@ -117,17 +120,17 @@ func (f *Fetcher) fetchRaw(ctx context.Context) ([]byte, error) {
 
 	resp, err := f.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("fetch DSN XML: %w", err)
+		return nil, fmt.Errorf("%w: %w", ErrFeedUnavailable, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return nil, fmt.Errorf("%w: status %d", ErrFeedUnavailable, resp.StatusCode)
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("read response body: %w", err)
+		return nil, fmt.Errorf("%w: read response body: %w", ErrFeedUnavailable, err)
 	}
 
 	return body, nil
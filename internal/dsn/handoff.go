@@ -0,0 +1,108 @@
+package dsn
+
+import (
+	"fmt"
+	"time"
+)
+
+// HandoffPrediction estimates when a link's complex will hand its
+// spacecraft off as the Earth turns, and which complex picks it up.
+type HandoffPrediction struct {
+	From Complex
+	To   Complex
+	At   time.Time     // estimated handoff
+	Gap  time.Duration // time without coverage after At before To's pass begins; 0 if the passes overlap
+}
+
+// PredictHandoff estimates link's next handoff from the spacecraft's pass
+// plan. The link's complex loses the spacecraft at the end of its current
+// pass, or earlier if trace (for that complex) drops below the plan's
+// minimum pass elevation there first. When another complex's pass
+// overlaps that end, the handoff is taken at the middle of the overlap,
+// where DSN schedules usually put it; otherwise it is at the end of the
+// pass, followed by a gap until the next complex rises. It returns false
+// when the link's complex has no pass in progress or no other complex
+// rises within the plan.
+func PredictHandoff(link Link, plan *PassPlan, trace *ElevationTrace, now time.Time) (HandoffPrediction, bool) {
+	if plan == nil {
+		return HandoffPrediction{}, false
+	}
+	var current *Pass
+	for i, p := range plan.Passes {
+		if p.Complex == link.Complex && !p.Start.After(now) && p.End.After(now) {
+			current = &plan.Passes[i]
+			break
+		}
+	}
+	if current == nil {
+		return HandoffPrediction{}, false
+	}
+	end := current.End
+	if trace != nil && trace.Complex == link.Complex {
+		minEl := plan.MinElevations.For(trace.SpacecraftCode, trace.Complex)
+		if set, ok := traceSetting(trace, minEl, now); ok && set.Before(end) {
+			end = set
+		}
+	}
+
+	// The incoming complex is the one up at end with the most pass left,
+	// else the first to rise after it
+	var next *Pass
+	for i, p := range plan.Passes {
+		if p.Complex == link.Complex || !p.End.After(end) {
+			continue
+		}
+		switch {
+		case next == nil:
+			next = &plan.Passes[i]
+		case !p.Start.After(end):
+			if next.Start.After(end) || p.End.After(next.End) {
+				next = &plan.Passes[i]
+			}
+		case next.Start.After(end) && p.Start.Before(next.Start):
+			next = &plan.Passes[i]
+		}
+	}
+	if next == nil {
+		return HandoffPrediction{}, false
+	}
+
+	h := HandoffPrediction{From: link.Complex, To: next.Complex, At: end}
+	if next.Start.After(end) {
+		h.Gap = next.Start.Sub(end)
+	} else {
+		overlapStart := next.Start
+		if overlapStart.Before(now) {
+			overlapStart = now
+		}
+		h.At = overlapStart.Add(end.Sub(overlapStart) / 2)
+	}
+	return h, true
+}
+
+// traceSetting returns when trace next drops below minEl after now,
+// interpolated between samples.
+func traceSetting(trace *ElevationTrace, minEl float64, now time.Time) (time.Time, bool) {
+	for i := 1; i < len(trace.Samples); i++ {
+		prev, s := trace.Samples[i-1], trace.Samples[i]
+		if !s.Time.After(now) {
+			continue
+		}
+		if prev.Elevation >= minEl && s.Elevation < minEl {
+			return interpolateCrossing(prev.Time, s.Time, prev.Elevation, s.Elevation, minEl), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Describe summarizes the prediction as of now:
+//
+//	handoff in ~42m → Madrid
+//	handoff in ~1h 5m → Canberra after a 20m gap
+func (h HandoffPrediction) Describe(now time.Time) string {
+	s := fmt.Sprintf("handoff in ~%s → %s", formatSessionLength(max(h.At.Sub(now), 0)), siteComplexName(h.To))
+	if h.Gap > 0 {
+		s += fmt.Sprintf(" after a %s gap", formatSessionLength(h.Gap))
+	}
+	return s
+}
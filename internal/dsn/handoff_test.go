@@ -0,0 +1,76 @@
+package dsn
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPredictHandoff(t *testing.T) {
+	now := time.Date(2025, 12, 5, 12, 0, 0, 0, time.UTC)
+	link := Link{Spacecraft: "MRO", Complex: ComplexGoldstone}
+	plan := &PassPlan{Passes: []Pass{
+		{Complex: ComplexGoldstone, Start: now.Add(-3 * time.Hour), End: now.Add(2 * time.Hour)},
+		{Complex: ComplexCanberra, Start: now.Add(10 * time.Hour), End: now.Add(18 * time.Hour)},
+		{Complex: ComplexMadrid, Start: now.Add(time.Hour), End: now.Add(9 * time.Hour)},
+	}}
+
+	// Madrid rises an hour before Goldstone sets: hand off mid-overlap
+	h, ok := PredictHandoff(link, plan, nil, now)
+	if !ok || h.To != ComplexMadrid || !h.At.Equal(now.Add(90*time.Minute)) || h.Gap != 0 {
+		t.Fatalf("PredictHandoff = %+v, %v; want Madrid at +1h30m", h, ok)
+	}
+	if got, want := h.Describe(now), "handoff in ~1h 30m → Madrid"; got != want {
+		t.Errorf("Describe = %q, want %q", got, want)
+	}
+
+	// The elevation trace sets Goldstone at +110m, before the planned end
+	h, _ = PredictHandoff(link, plan, setTrace(now), now)
+	if !h.At.Equal(now.Add(85 * time.Minute)) {
+		t.Errorf("with trace, At = %v, want +85m", h.At.Sub(now))
+	}
+
+	// A trace for another complex is ignored
+	trace := setTrace(now)
+	trace.Complex = ComplexMadrid
+	if h, _ = PredictHandoff(link, plan, trace, now); !h.At.Equal(now.Add(90 * time.Minute)) {
+		t.Errorf("with another complex's trace, At = %v, want +1h30m", h.At.Sub(now))
+	}
+}
+
+func TestPredictHandoff_Gap(t *testing.T) {
+	now := time.Date(2025, 12, 5, 12, 0, 0, 0, time.UTC)
+	link := Link{Spacecraft: "VGR1", Complex: ComplexCanberra}
+	plan := &PassPlan{Passes: []Pass{
+		{Complex: ComplexCanberra, Start: now.Add(-time.Hour), End: now.Add(2 * time.Hour)},
+		{Complex: ComplexGoldstone, Start: now.Add(5 * time.Hour), End: now.Add(8 * time.Hour)},
+		{Complex: ComplexMadrid, Start: now.Add(3 * time.Hour), End: now.Add(6 * time.Hour)},
+	}}
+
+	h, ok := PredictHandoff(link, plan, nil, now)
+	if !ok || h.To != ComplexMadrid || !h.At.Equal(now.Add(2*time.Hour)) || h.Gap != time.Hour {
+		t.Fatalf("PredictHandoff = %+v, %v; want Madrid after a 1h gap", h, ok)
+	}
+	if got, want := h.Describe(now), "handoff in ~2h → Madrid after a 1h gap"; got != want {
+		t.Errorf("Describe = %q, want %q", got, want)
+	}
+}
+
+func TestPredictHandoff_NoPass(t *testing.T) {
+	now := time.Date(2025, 12, 5, 12, 0, 0, 0, time.UTC)
+	link := Link{Spacecraft: "VGR1", Complex: ComplexCanberra}
+	plan := &PassPlan{Passes: []Pass{
+		{Complex: ComplexCanberra, Start: now.Add(time.Hour), End: now.Add(4 * time.Hour)},
+		{Complex: ComplexMadrid, Start: now.Add(3 * time.Hour), End: now.Add(6 * time.Hour)},
+	}}
+	if h, ok := PredictHandoff(link, plan, nil, now); ok {
+		t.Errorf("PredictHandoff = %+v, want none with no pass in progress", h)
+	}
+	plan.Passes = plan.Passes[:1]
+	plan.Passes[0].Start = now.Add(-time.Hour)
+	if h, ok := PredictHandoff(link, plan, nil, now); ok {
+		t.Errorf("PredictHandoff = %+v, want none with no other complex rising", h)
+	}
+	if _, ok := PredictHandoff(link, nil, nil, now); ok {
+		t.Error("PredictHandoff with no plan succeeded")
+	}
+}
@@ -0,0 +1,219 @@
+package dsn
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// HealthModel weights the struggle index factors and sets the struggle
+// thresholds between health classes. Weights are relative: each factor
+// contributes its share of their sum, so they need not add up to 1.
+type HealthModel struct {
+	Name string
+
+	DistanceWeight  float64
+	RateWeight      float64
+	ElevationWeight float64
+	QualityWeight   float64
+	BandWeight      float64 // 0 in the built-in models
+
+	MarginalAt float64 // Struggle at or above which a link is MARGINAL
+	PoorAt     float64 // Struggle at or above which a link is POOR
+
+	// BandRates scores data rate against the usual range for the link's
+	// band instead of one range for all bands, so slow S-band telemetry
+	// isn't judged against Ka-band science downlinks.
+	BandRates bool
+}
+
+// Built-in health model names.
+const (
+	HealthModelDefault   = "default"
+	HealthModelElevation = "elevation"
+	HealthModelBandRate  = "band-rate"
+)
+
+// healthModels are the built-in models.
+var healthModels = map[string]HealthModel{
+	// The original heuristic: distance and rate dominate
+	HealthModelDefault: {
+		Name:           HealthModelDefault,
+		DistanceWeight: 0.4, RateWeight: 0.3, ElevationWeight: 0.2, QualityWeight: 0.1,
+		MarginalAt: 0.3, PoorAt: 0.6,
+	},
+	// Elevation dominates: far, slow links that are well above the
+	// horizon are nominal
+	HealthModelElevation: {
+		Name:           HealthModelElevation,
+		DistanceWeight: 0.2, RateWeight: 0.2, ElevationWeight: 0.5, QualityWeight: 0.1,
+		MarginalAt: 0.3, PoorAt: 0.6,
+	},
+	// Default weights with data rate judged per band
+	HealthModelBandRate: {
+		Name:           HealthModelBandRate,
+		DistanceWeight: 0.4, RateWeight: 0.3, ElevationWeight: 0.2, QualityWeight: 0.1,
+		MarginalAt: 0.3, PoorAt: 0.6,
+		BandRates: true,
+	},
+}
+
+// bandFactor is how hard each band is on the struggle index's 0-1 scale:
+// higher frequencies lose more to water vapor and rain, so Ka-band margins
+// are the first to go in bad weather or at low elevation.
+var bandFactor = map[string]float64{
+	"S":  0,
+	"X":  0.3,
+	"Ka": 1,
+}
+
+// bandRateRange is the log10(bps) range scored from hard (low) to easy
+// (high) for each band when BandRates is set.
+var bandRateRange = map[string][2]float64{
+	"S":  {1, 5}, // 10 bps - 100 kbps
+	"X":  {1, 7}, // 10 bps - 10 Mbps
+	"Ka": {3, 8}, // 1 kbps - 100 Mbps
+}
+
+// defaultRateRange is used for all bands without BandRates, and for
+// unknown bands with it: 100 bps - 1 Mbps.
+var defaultRateRange = [2]float64{2, 6}
+
+// HealthModelNames returns the built-in model names, sorted.
+func HealthModelNames() []string {
+	names := make([]string, 0, len(healthModels))
+	for name := range healthModels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LookupHealthModel returns the built-in model with the given name.
+func LookupHealthModel(name string) (HealthModel, error) {
+	m, ok := healthModels[name]
+	if !ok {
+		return HealthModel{}, fmt.Errorf("unknown health model %q (want %s)", name, strings.Join(HealthModelNames(), ", "))
+	}
+	return m, nil
+}
+
+// DefaultHealthModel returns the original struggle heuristic.
+func DefaultHealthModel() HealthModel {
+	return healthModels[HealthModelDefault]
+}
+
+// Validate checks that weights are non-negative and not all zero, and
+// that 0 < MarginalAt < PoorAt <= 1.
+func (m HealthModel) Validate() error {
+	weights := []float64{m.DistanceWeight, m.RateWeight, m.ElevationWeight, m.QualityWeight, m.BandWeight}
+	var sum float64
+	for _, w := range weights {
+		if w < 0 || math.IsNaN(w) {
+			return errors.New("health weights must not be negative")
+		}
+		sum += w
+	}
+	if sum == 0 {
+		return errors.New("at least one health weight must be positive")
+	}
+	if !(m.MarginalAt > 0 && m.MarginalAt < m.PoorAt && m.PoorAt <= 1) {
+		return fmt.Errorf("health thresholds must satisfy 0 < marginal (%g) < poor (%g) <= 1", m.MarginalAt, m.PoorAt)
+	}
+	return nil
+}
+
+// Struggle computes the struggle index for a link under this model.
+// Each factor is 0 (easy) to 1 (hard):
+//   - Distance: log scale from 100k km to 10B km
+//   - Data rate: log scale from 1 Mbps down to 100 bps (or the band's range)
+//   - Elevation: 45°+ is easy, 0° is hard
+//   - Signal quality: inverted; from the downlink Pr/N0 (see SNRQuality)
+//     when the feed reports received power, else 0.5
+//   - Band: weather sensitivity, S (0) to Ka (1); 0.5 for unknown bands
+func (m HealthModel) Struggle(link Link, elevation float64) float64 {
+	total := m.DistanceWeight + m.RateWeight + m.ElevationWeight + m.QualityWeight + m.BandWeight
+	if total <= 0 {
+		return 0
+	}
+
+	var score float64
+
+	// Use log scale since distances vary enormously (Moon vs Voyager)
+	if link.Distance > 0 {
+		distFactor := clamp((math.Log10(link.Distance)-5)/(10-5), 0, 1)
+		score += distFactor * m.DistanceWeight
+	}
+
+	if link.DataRate > 0 {
+		r := defaultRateRange
+		if m.BandRates {
+			if br, ok := bandRateRange[link.Band]; ok {
+				r = br
+			}
+		}
+		rateFactor := 1 - clamp((math.Log10(link.DataRate)-r[0])/(r[1]-r[0]), 0, 1)
+		score += rateFactor * m.RateWeight
+	}
+
+	// Low elevation = more atmosphere
+	if elevation >= 0 {
+		elevFactor := 1 - clamp(elevation/45, 0, 1)
+		score += elevFactor * m.ElevationWeight
+	}
+
+	snr, hasSNR := link.SNR()
+	switch {
+	case link.SignalQuality > 0:
+		score += (1 - link.SignalQuality) * m.QualityWeight
+	case hasSNR:
+		score += (1 - SNRQuality(snr)) * m.QualityWeight
+	default:
+		// Medium difficulty if no signal quality data
+		score += 0.5 * m.QualityWeight
+	}
+
+	if m.BandWeight > 0 {
+		f, ok := bandFactor[link.Band]
+		if !ok {
+			f = 0.5
+		}
+		score += f * m.BandWeight
+	}
+
+	return clamp(score/total, 0, 1)
+}
+
+// Classify converts a struggle index to a health class under this model.
+func (m HealthModel) Classify(struggle float64) Health {
+	switch {
+	case struggle < m.MarginalAt:
+		return HealthGood
+	case struggle < m.PoorAt:
+		return HealthMarginal
+	default:
+		return HealthPoor
+	}
+}
+
+// ActiveHealthModel returns the model d's links are scored under: its
+// HealthModel, or the default if none was set.
+func (d *DSNData) ActiveHealthModel() HealthModel {
+	if d == nil || d.HealthModel.Name == "" {
+		return DefaultHealthModel()
+	}
+	return d.HealthModel
+}
+
+// LinkHealth computes struggle index and health for a link under this
+// model. Carrier-only links are classed HealthCarrier rather than judged
+// on their zero rate.
+func (m HealthModel) LinkHealth(link Link, elevation float64) (float64, Health) {
+	struggle := m.Struggle(link, elevation)
+	if link.CarrierOnly() {
+		return struggle, HealthCarrier
+	}
+	return struggle, m.Classify(struggle)
+}
@@ -0,0 +1,121 @@
+package dsn
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHealthModel_DefaultMatchesBuiltin(t *testing.T) {
+	link := Link{Distance: 2.25e8, DataRate: 2e6, Band: "X"}
+	m := DefaultHealthModel()
+	if got, want := m.Struggle(link, 30), StruggleIndex(link, 30); got != want {
+		t.Errorf("default Struggle = %v, StruggleIndex = %v", got, want)
+	}
+}
+
+func TestHealthModel_Alternatives(t *testing.T) {
+	// Voyager-like: very far and slow, but high in the sky
+	voyager := Link{Distance: 2.4e10, DataRate: 160, Band: "X"}
+
+	def := DefaultHealthModel()
+	if h := def.Classify(def.Struggle(voyager, 60)); h != HealthPoor {
+		t.Fatalf("default health = %s, want POOR", h)
+	}
+
+	for _, name := range []string{HealthModelElevation, HealthModelBandRate} {
+		m, err := LookupHealthModel(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, base := m.Struggle(voyager, 60), def.Struggle(voyager, 60); got >= base {
+			t.Errorf("%s struggle = %v, want below default %v", name, got, base)
+		}
+	}
+
+	// Band rates only matter for known bands
+	bandRate, _ := LookupHealthModel(HealthModelBandRate)
+	unknown := Link{Distance: 2.4e10, DataRate: 160, Band: "L"}
+	if got, want := bandRate.Struggle(unknown, 60), def.Struggle(unknown, 60); got != want {
+		t.Errorf("band-rate unknown band = %v, want default %v", got, want)
+	}
+}
+
+func TestHealthModel_WeightsAreRelative(t *testing.T) {
+	link := Link{Distance: 2.25e8, DataRate: 2e6}
+	m := DefaultHealthModel()
+	doubled := m
+	doubled.DistanceWeight *= 2
+	doubled.RateWeight *= 2
+	doubled.ElevationWeight *= 2
+	doubled.QualityWeight *= 2
+
+	if a, b := m.Struggle(link, 30), doubled.Struggle(link, 30); a-b > 1e-12 || b-a > 1e-12 {
+		t.Errorf("doubled weights: %v, want %v", b, a)
+	}
+}
+
+func TestHealthModel_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*HealthModel)
+		wantErr bool
+	}{
+		{"default", func(*HealthModel) {}, false},
+		{"negative weight", func(m *HealthModel) { m.RateWeight = -0.1 }, true},
+		{"all zero", func(m *HealthModel) { *m = HealthModel{MarginalAt: 0.3, PoorAt: 0.6} }, true},
+		{"thresholds reversed", func(m *HealthModel) { m.MarginalAt, m.PoorAt = 0.6, 0.3 }, true},
+		{"poor above 1", func(m *HealthModel) { m.PoorAt = 1.5 }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := DefaultHealthModel()
+			tt.modify(&m)
+			if err := m.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDSNData_ActiveHealthModel(t *testing.T) {
+	if got := (&DSNData{}).ActiveHealthModel().Name; got != HealthModelDefault {
+		t.Errorf("unset ActiveHealthModel = %q, want %q", got, HealthModelDefault)
+	}
+
+	m, _ := LookupHealthModel(HealthModelElevation)
+	data := &DSNData{HealthModel: m}
+	if got := data.ActiveHealthModel().Name; got != HealthModelElevation {
+		t.Errorf("ActiveHealthModel = %q, want %q", got, HealthModelElevation)
+	}
+	if got := ExportSnapshot(data, time.Time{}).HealthModel; got != HealthModelElevation {
+		t.Errorf("export health_model = %q", got)
+	}
+
+	if _, err := LookupHealthModel("nope"); err == nil {
+		t.Error("LookupHealthModel(nope) succeeded")
+	}
+}
+
+func TestHealthModel_BandWeight(t *testing.T) {
+	m := DefaultHealthModel()
+	ka := Link{Distance: 2.25e8, DataRate: 2e6, Band: "Ka"}
+	s := ka
+	s.Band = "S"
+	if m.Struggle(ka, 30) != m.Struggle(s, 30) {
+		t.Error("band matters without a band weight")
+	}
+
+	m.BandWeight = 0.2
+	if err := m.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if m.Struggle(ka, 30) <= m.Struggle(s, 30) {
+		t.Errorf("Ka struggle %v, want above S %v", m.Struggle(ka, 30), m.Struggle(s, 30))
+	}
+
+	// Band alone is a valid model
+	if err := (HealthModel{BandWeight: 1, MarginalAt: 0.3, PoorAt: 0.6}).Validate(); err != nil {
+		t.Errorf("band-only model: %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+package dsn
+
+import (
+	"fmt"
+	"time"
+)
+
+// MarginForecast projects a link's struggle index over the rest of its
+// pass: elevation follows the spacecraft's elevation trace, and the data
+// rate follows its recent trend, with distance and signal quality held at
+// their current values.
+type MarginForecast struct {
+	Struggle  float64   // now
+	Health    Health    // class of Struggle under the forecast's model
+	AtEnd     float64   // projected at PassEnd
+	PassEnd   time.Time // end of the pass, or of the trace's usable geometry
+	GoodUntil time.Time // last projected time the link is GOOD; zero if it isn't now
+	Degrades  bool      // the link is projected to leave GOOD before PassEnd
+}
+
+// marginShrinkTolerance is how much the projected struggle must rise by
+// the end of the pass before the margin counts as shrinking.
+const marginShrinkTolerance = 0.02
+
+// ForecastMargin projects link over its remaining pass under health model
+// m. rateTrend is the data rate's change in bps per second
+// (see state.SpacecraftHistory.RateTrend). passEnd is the scheduled end
+// of the pass; if zero, the pass is taken to end when the trace drops
+// below minEl, the spacecraft's minimum pass elevation at the link's
+// complex. It returns false for carrier-only links, which carry no data
+// to lose, and when the trace has no samples left in the pass.
+func (m HealthModel) ForecastMargin(link Link, trace *ElevationTrace, rateTrend float64, passEnd time.Time, minEl float64, now time.Time) (MarginForecast, bool) {
+	if trace == nil || link.CarrierOnly() {
+		return MarginForecast{}, false
+	}
+	current := trace.CurrentElevation(now)
+	if current == nil {
+		return MarginForecast{}, false
+	}
+
+	f := MarginForecast{Struggle: m.Struggle(link, current.Elevation)}
+	f.AtEnd = f.Struggle
+	f.Health = m.Classify(f.Struggle)
+	good := f.Health == HealthGood
+	if good {
+		f.GoodUntil = now
+	}
+
+	var projected bool
+	for _, s := range trace.Samples {
+		if !s.Time.After(now) {
+			continue
+		}
+		if !passEnd.IsZero() && s.Time.After(passEnd) {
+			break
+		}
+		if passEnd.IsZero() && s.Elevation < minEl {
+			break
+		}
+		projected = true
+		f.PassEnd = s.Time
+
+		l := link
+		if l.DataRate > 0 {
+			// A rate trending to nothing scores as the slowest link
+			l.DataRate = max(l.DataRate+rateTrend*s.Time.Sub(now).Seconds(), 1)
+		}
+		f.AtEnd = m.Struggle(l, s.Elevation)
+		if !good || f.Degrades {
+			continue
+		}
+		if m.Classify(f.AtEnd) == HealthGood {
+			f.GoodUntil = s.Time
+		} else {
+			f.Degrades = true
+		}
+	}
+	if !projected {
+		return MarginForecast{}, false
+	}
+	if !passEnd.IsZero() {
+		f.PassEnd = passEnd
+	}
+	return f, true
+}
+
+// Shrinking reports whether the link is projected to struggle more by the
+// end of the pass than it does now.
+func (f MarginForecast) Shrinking() bool {
+	return f.AtEnd > f.Struggle+marginShrinkTolerance
+}
+
+// Describe summarizes the forecast as of now:
+//
+//	margin shrinking, ~40 min of good geometry left
+//	margin shrinking, good to end of pass (~1h 5m)
+//	margin steady, good to end of pass (~1h 5m)
+//	already MARGINAL, pass ends in ~25 min
+func (f MarginForecast) Describe(now time.Time) string {
+	if f.GoodUntil.IsZero() {
+		return fmt.Sprintf("already %s, pass ends in %s", f.Health, formatMarginLeft(f.PassEnd.Sub(now)))
+	}
+	if f.Degrades {
+		return fmt.Sprintf("margin shrinking, %s of good geometry left", formatMarginLeft(f.GoodUntil.Sub(now)))
+	}
+	trend := "steady"
+	if f.Shrinking() {
+		trend = "shrinking"
+	}
+	return fmt.Sprintf("margin %s, good to end of pass (%s)", trend, formatMarginLeft(f.PassEnd.Sub(now)))
+}
+
+// formatMarginLeft formats a remaining time: "~40 min", "~1h", "~1h 5m".
+func formatMarginLeft(d time.Duration) string {
+	d = max(d, 0).Round(time.Minute)
+	if d < time.Hour {
+		return fmt.Sprintf("~%d min", int(d.Minutes()))
+	}
+	if d%time.Hour == 0 {
+		return fmt.Sprintf("~%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("~%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+}
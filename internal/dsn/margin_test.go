@@ -0,0 +1,76 @@
+package dsn
+
+import (
+	"testing"
+	"time"
+)
+
+// setTrace descends from 60° at now by half a degree a minute, sampled
+// every 5 minutes for two hours.
+func setTrace(now time.Time) *ElevationTrace {
+	trace := &ElevationTrace{SpacecraftCode: "MRO", Complex: ComplexGoldstone}
+	for m := -10; m <= 120; m += 5 {
+		trace.Samples = append(trace.Samples, ElevationSample{
+			Time:      now.Add(time.Duration(m) * time.Minute),
+			Elevation: 60 - float64(m)*0.5,
+		})
+	}
+	return trace
+}
+
+func TestForecastMargin(t *testing.T) {
+	now := time.Date(2025, 12, 5, 12, 0, 0, 0, time.UTC)
+	trace := setTrace(now)
+	// Close and fast: GOOD until the last few degrees above the horizon
+	link := Link{Spacecraft: "MRO", Complex: ComplexGoldstone, Distance: 1e6, DataRate: 1e6}
+
+	f, ok := DefaultHealthModel().ForecastMargin(link, trace, 0, time.Time{}, MinPassElevation, now)
+	if !ok {
+		t.Fatal("no forecast")
+	}
+	if !f.Degrades || !f.GoodUntil.Equal(now.Add(105*time.Minute)) || !f.PassEnd.Equal(now.Add(110*time.Minute)) {
+		t.Errorf("forecast = %+v, want GOOD until +105m of a pass ending +110m", f)
+	}
+	if got, want := f.Describe(now), "margin shrinking, ~1h 45m of good geometry left"; got != want {
+		t.Errorf("Describe = %q, want %q", got, want)
+	}
+
+	// Scheduled to end while still high
+	f, _ = DefaultHealthModel().ForecastMargin(link, trace, 0, now.Add(time.Hour), MinPassElevation, now)
+	if f.Degrades || !f.Shrinking() || !f.PassEnd.Equal(now.Add(time.Hour)) {
+		t.Errorf("forecast = %+v, want shrinking but GOOD to the end", f)
+	}
+	if got, want := f.Describe(now), "margin shrinking, good to end of pass (~1h)"; got != want {
+		t.Errorf("Describe = %q, want %q", got, want)
+	}
+
+	// A falling rate degrades it sooner
+	falling, _ := DefaultHealthModel().ForecastMargin(link, trace, -1e6/3600, now.Add(time.Hour), MinPassElevation, now)
+	if !falling.Degrades || !falling.GoodUntil.Before(now.Add(time.Hour)) {
+		t.Errorf("forecast with falling rate = %+v, want degraded before the end", falling)
+	}
+
+	// Already struggling
+	far := link
+	far.Distance = 1e10
+	f, _ = DefaultHealthModel().ForecastMargin(far, trace, 0, now.Add(25*time.Minute), MinPassElevation, now)
+	if got, want := f.Describe(now), "already MARGINAL, pass ends in ~25 min"; got != want {
+		t.Errorf("Describe = %q, want %q", got, want)
+	}
+}
+
+func TestForecastMargin_NoProjection(t *testing.T) {
+	now := time.Date(2025, 12, 5, 12, 0, 0, 0, time.UTC)
+	link := Link{Spacecraft: "MRO", Distance: 1e6, DataRate: 1e6}
+	if _, ok := DefaultHealthModel().ForecastMargin(link, nil, 0, time.Time{}, MinPassElevation, now); ok {
+		t.Error("forecast without a trace")
+	}
+	if _, ok := DefaultHealthModel().ForecastMargin(link, setTrace(now), 0, time.Time{}, MinPassElevation, now.Add(3*time.Hour)); ok {
+		t.Error("forecast past the end of the trace")
+	}
+	carrier := link
+	carrier.SignalType = SignalCarrier
+	if _, ok := DefaultHealthModel().ForecastMargin(carrier, setTrace(now), 0, time.Time{}, MinPassElevation, now); ok {
+		t.Error("forecast for a carrier lock")
+	}
+}
@@ -13,18 +13,21 @@ const (
 )
 
 // ComplexInfo contains metadata about a DSN complex.
+// The reference point is the complex's 70 m antenna.
 type ComplexInfo struct {
 	ID        Complex
 	Name      string
 	Latitude  float64
 	Longitude float64
+	HeightM   float64 // Height above the WGS84 ellipsoid in meters
+	GeoidM    float64 // EGM96 geoid height (sea level above the ellipsoid), to a few meters
 }
 
 // KnownComplexes maps complex IDs to their full information.
 var KnownComplexes = map[Complex]ComplexInfo{
-	ComplexGoldstone: {ID: ComplexGoldstone, Name: "Goldstone", Latitude: 35.4267, Longitude: -116.8900},
-	ComplexCanberra:  {ID: ComplexCanberra, Name: "Canberra", Latitude: -35.4014, Longitude: 148.9817},
-	ComplexMadrid:    {ID: ComplexMadrid, Name: "Madrid", Latitude: 40.4314, Longitude: -4.2481},
+	ComplexGoldstone: {ID: ComplexGoldstone, Name: "Goldstone", Latitude: 35.4267, Longitude: -116.8900, HeightM: 1002, GeoidM: -31},
+	ComplexCanberra:  {ID: ComplexCanberra, Name: "Canberra", Latitude: -35.4014, Longitude: 148.9817, HeightM: 689, GeoidM: 20},
+	ComplexMadrid:    {ID: ComplexMadrid, Name: "Madrid", Latitude: 40.4314, Longitude: -4.2481, HeightM: 865, GeoidM: 51},
 }
 
 // Station represents a DSN station within a complex.
@@ -77,6 +80,12 @@ type Signal struct {
 	Spacecraft   string
 }
 
+// Signal types reported by the feed for a link.
+const (
+	SignalData    = "data"    // Data-modulated
+	SignalCarrier = "carrier" // Carrier only: locked, but no data flowing
+)
+
 // Spacecraft represents a spacecraft entity with aggregated info.
 type Spacecraft struct {
 	ID       int
@@ -95,11 +104,20 @@ type Link struct {
 	Spacecraft   string
 
 	// Signal characteristics
-	Band     string  // e.g., "X", "S", "Ka"
-	DataRate float64 // bits per second (highest of up/down)
-	DownRate float64 // downlink rate bps
-	UpRate   float64 // uplink rate bps
-	Power    float64 // signal power
+	SignalType string  // SignalData, SignalCarrier, or "" with no active signal
+	Band       string  // e.g., "X", "S", "Ka"
+	DataRate   float64 // bits per second (highest of up/down)
+	DownRate   float64 // downlink rate bps
+	UpRate     float64 // uplink rate bps
+	Uplink     bool    // Active data uplink: the spacecraft is being commanded
+	Downlink   bool    // Active downlink: the antenna is receiving from the spacecraft
+	DownPower  float64 // received downlink power, dBm (0 = not reported)
+	UpPower    float64 // uplink transmitter power, kW
+
+	TrackingMode TrackingMode // 1-way/2-way/3-way; empty with no active downlink
+
+	// Pointing of the dish carrying this link
+	Pointing Pointing
 
 	// Timing
 	RTLT      float64   // Round-Trip Light Time in seconds
@@ -109,6 +127,27 @@ type Link struct {
 	// Derived
 	Distance      float64 // km, derived from RTLT
 	SignalQuality float64 // 0-1 quality indicator
+
+	// Inferred is set when the feed's target and signals didn't match up
+	// and the spacecraft was identified by NAIF ID or recent history
+	// instead
+	Inferred bool
+}
+
+// Pointing is where a dish is aimed. The feed gives one pointing per dish,
+// so spacecraft sharing an MSPA aperture share it. A link copies it from
+// its dish element when parsed, so it travels with the link through
+// export and replay rather than being looked up again by antenna ID.
+type Pointing struct {
+	AzDeg float64
+	ElDeg float64
+	Valid bool // false when the link was built without a dish
+}
+
+// CarrierOnly reports whether the link is a carrier lock with no data, so
+// its zero data rate is expected rather than a sign of trouble.
+func (l Link) CarrierOnly() bool {
+	return l.SignalType == SignalCarrier
 }
 
 // DSNData represents a complete snapshot of DSN state at a point in time.
@@ -117,6 +156,13 @@ type DSNData struct {
 	Stations  []Station
 	Links     []Link   // All active links (flattened view)
 	Errors    []string // Any parse warnings/errors
+
+	// HealthModel scores the links; zero means DefaultHealthModel (see
+	// ActiveHealthModel).
+	HealthModel HealthModel
+	// WindLimits judge the antennas' wind; zero means DefaultWindLimits
+	// (see ActiveWindLimits).
+	WindLimits WindLimits
 }
 
 // ComplexLoad represents utilization metrics for a complex.
@@ -166,7 +212,7 @@ func (d *DSNData) SkyObjects() []SkyObject {
 					if link.Spacecraft == target.Name && link.AntennaID == ant.ID {
 						obj.Band = link.Band
 						obj.DataRate = link.DataRate
-						obj.StruggleIndex = StruggleIndex(link, ant.Elevation)
+						obj.StruggleIndex = d.ActiveHealthModel().Struggle(link, ant.Elevation)
 						break
 					}
 				}
@@ -0,0 +1,110 @@
+package dsn
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// MoonDef defines a major moon drawn around its planet.
+type MoonDef struct {
+	Name        string
+	Parent      string // Code of the planet it orbits (see Planets)
+	NAIFID      int
+	SemiMajorAU float64 // Approximate orbit radius about the planet
+}
+
+// Moons are the major moons of the planets missions orbit.
+var Moons = []MoonDef{
+	{Name: "Phobos", Parent: "MARS", NAIFID: 401, SemiMajorAU: 0.0000627},
+	{Name: "Deimos", Parent: "MARS", NAIFID: 402, SemiMajorAU: 0.0001568},
+	{Name: "Io", Parent: "JUP", NAIFID: 501, SemiMajorAU: 0.002819},
+	{Name: "Europa", Parent: "JUP", NAIFID: 502, SemiMajorAU: 0.004486},
+	{Name: "Ganymede", Parent: "JUP", NAIFID: 503, SemiMajorAU: 0.007155},
+	{Name: "Callisto", Parent: "JUP", NAIFID: 504, SemiMajorAU: 0.012585},
+	{Name: "Enceladus", Parent: "SAT", NAIFID: 602, SemiMajorAU: 0.001591},
+	{Name: "Tethys", Parent: "SAT", NAIFID: 603, SemiMajorAU: 0.001969},
+	{Name: "Dione", Parent: "SAT", NAIFID: 604, SemiMajorAU: 0.002523},
+	{Name: "Rhea", Parent: "SAT", NAIFID: 605, SemiMajorAU: 0.003523},
+	{Name: "Titan", Parent: "SAT", NAIFID: 606, SemiMajorAU: 0.008168},
+}
+
+// MoonsOf returns the major moons of the planet with the given code.
+func MoonsOf(parent string) []MoonDef {
+	var moons []MoonDef
+	for _, moon := range Moons {
+		if moon.Parent == parent {
+			moons = append(moons, moon)
+		}
+	}
+	return moons
+}
+
+// SystemRadiusAU returns the radius of a planet's moon system: its farthest
+// major moon's orbit, or zero if it has none.
+func SystemRadiusAU(parent string) float64 {
+	r := 0.0
+	for _, moon := range MoonsOf(parent) {
+		r = math.Max(r, moon.SemiMajorAU)
+	}
+	return r
+}
+
+// PlanetSystem is a planet's neighborhood at one time: its major moons and
+// nearby spacecraft, with Pos relative to the planet (ecliptic axes, AU).
+type PlanetSystem struct {
+	Parent string
+	Time   time.Time
+	Bodies []EclipticBody // BodyMoon or BodySpacecraft
+}
+
+// FetchPlanetSystem queries p for the positions of a planet's moons and of
+// the given spacecraft at t, relative to the planet. Spacecraft without a
+// NAIF ID, and moons or spacecraft whose query fails, are left out; only a
+// failure to locate the planet itself is an error.
+func FetchPlanetSystem(p SolarSystemProvider, parent string, spacecraft []EclipticBody, t time.Time) (PlanetSystem, error) {
+	naifID := 0
+	for _, planet := range Planets {
+		if planet.Code == parent {
+			naifID = planet.NAIFID
+		}
+	}
+	if naifID == 0 {
+		return PlanetSystem{}, fmt.Errorf("unknown planet %q", parent)
+	}
+	center, err := p.GetHeliocentricPosition(naifID, t)
+	if err != nil {
+		return PlanetSystem{}, fmt.Errorf("locating %s: %w", parent, err)
+	}
+
+	sys := PlanetSystem{Parent: parent, Time: t}
+	for _, moon := range MoonsOf(parent) {
+		pos, err := p.GetHeliocentricPosition(moon.NAIFID, t)
+		if err != nil {
+			continue
+		}
+		sys.Bodies = append(sys.Bodies, EclipticBody{
+			Name: moon.Name,
+			Code: moon.Name,
+			Kind: BodyMoon,
+			Pos:  pos.Sub(center),
+		})
+	}
+	for _, sc := range spacecraft {
+		info := GetSpacecraftInfo(sc.Code)
+		if info == nil || info.NAIFID == 0 {
+			continue
+		}
+		pos, err := p.GetHeliocentricPosition(info.NAIFID, t)
+		if err != nil {
+			continue
+		}
+		sys.Bodies = append(sys.Bodies, EclipticBody{
+			Name: sc.Name,
+			Code: sc.Code,
+			Kind: BodySpacecraft,
+			Pos:  pos.Sub(center),
+		})
+	}
+	return sys, nil
+}
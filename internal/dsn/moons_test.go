@@ -0,0 +1,72 @@
+package dsn
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/astro"
+)
+
+// fakeSystemProvider places each body at a fixed heliocentric position.
+type fakeSystemProvider map[int]astro.Vec3
+
+func (f fakeSystemProvider) GetHeliocentricPosition(naifID int, t time.Time) (astro.Vec3, error) {
+	pos, ok := f[naifID]
+	if !ok {
+		return astro.Vec3{}, errors.New("no ephemeris")
+	}
+	return pos, nil
+}
+
+func TestMoonsOf(t *testing.T) {
+	if got := len(MoonsOf("JUP")); got != 4 {
+		t.Errorf("Jupiter has %d moons, want 4 Galileans", got)
+	}
+	if got := MoonsOf("VEN"); got != nil {
+		t.Errorf("Venus moons = %v, want none", got)
+	}
+	if got := SystemRadiusAU("JUP"); got != 0.012585 {
+		t.Errorf("Jupiter system radius = %v, want Callisto's 0.012585", got)
+	}
+}
+
+func TestFetchPlanetSystem(t *testing.T) {
+	provider := fakeSystemProvider{
+		499: {X: 1.5},           // Mars
+		401: {X: 1.5, Y: 6e-5},  // Phobos
+		-74: {X: 1.5, Z: -2e-5}, // MRO
+		// Deimos unavailable
+	}
+	at := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	spacecraft := []EclipticBody{
+		{Name: "Mars Reconnaissance Orbiter", Code: "MRO", Kind: BodySpacecraft},
+		{Name: "Unknown", Code: "ZZZ", Kind: BodySpacecraft}, // no NAIF ID
+	}
+
+	sys, err := FetchPlanetSystem(provider, "MARS", spacecraft, at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sys.Parent != "MARS" || !sys.Time.Equal(at) {
+		t.Errorf("system %s at %v", sys.Parent, sys.Time)
+	}
+	if len(sys.Bodies) != 2 {
+		t.Fatalf("got %d bodies, want Phobos and MRO: %+v", len(sys.Bodies), sys.Bodies)
+	}
+	phobos, mro := sys.Bodies[0], sys.Bodies[1]
+	if phobos.Name != "Phobos" || phobos.Kind != BodyMoon || math.Abs(phobos.Pos.Y-6e-5) > 1e-12 || math.Abs(phobos.Pos.X) > 1e-12 {
+		t.Errorf("Phobos = %+v, want kind moon at Y 6e-5 from Mars", phobos)
+	}
+	if mro.Code != "MRO" || mro.Kind != BodySpacecraft || math.Abs(mro.Pos.Z+2e-5) > 1e-12 {
+		t.Errorf("MRO = %+v, want Z -2e-5 from Mars", mro)
+	}
+
+	if _, err := FetchPlanetSystem(fakeSystemProvider{}, "MARS", nil, at); err == nil {
+		t.Error("expected an error when Mars can't be located")
+	}
+	if _, err := FetchPlanetSystem(provider, "PLUTO", nil, at); err == nil {
+		t.Error("expected an error for an unknown planet")
+	}
+}
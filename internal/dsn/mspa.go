@@ -0,0 +1,94 @@
+package dsn
+
+import (
+	"fmt"
+	"sort"
+)
+
+// MSPABadge marks an antenna tracking several spacecraft at once (Multiple
+// Spacecraft Per Aperture).
+const MSPABadge = "MSPA"
+
+// MSPAGroup is one antenna tracking two or more spacecraft. Each has its
+// own receiver, so their downlinks share the aperture but not a data
+// channel: a link's share is its part of the antenna's combined rate.
+type MSPAGroup struct {
+	AntennaID string
+	Complex   Complex
+	Links     []Link // in feed order
+}
+
+// TotalRate returns the antenna's combined data rate in bps.
+func (g MSPAGroup) TotalRate() float64 {
+	var total float64
+	for _, l := range g.Links {
+		total += l.DataRate
+	}
+	return total
+}
+
+// Share returns the link's part (0-1) of the antenna's combined data rate,
+// split evenly when no link reports a rate.
+func (g MSPAGroup) Share(link Link) float64 {
+	if len(g.Links) == 0 {
+		return 0
+	}
+	total := g.TotalRate()
+	if total <= 0 {
+		return 1 / float64(len(g.Links))
+	}
+	return link.DataRate / total
+}
+
+// Spacecraft returns the codes of the spacecraft sharing the antenna.
+func (g MSPAGroup) Spacecraft() []string {
+	codes := make([]string, len(g.Links))
+	for i, l := range g.Links {
+		codes[i] = l.Spacecraft
+	}
+	return codes
+}
+
+// MSPAGroups returns the antennas tracking two or more real spacecraft,
+// sorted by antenna ID. Internal DSN targets don't count.
+func MSPAGroups(data *DSNData) []MSPAGroup {
+	byAntenna := mspaByAntenna(data)
+	groups := make([]MSPAGroup, 0, len(byAntenna))
+	for _, g := range byAntenna {
+		groups = append(groups, g)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].AntennaID < groups[j].AntennaID })
+	return groups
+}
+
+// mspaByAntenna returns MSPAGroups keyed by antenna ID.
+func mspaByAntenna(data *DSNData) map[string]MSPAGroup {
+	all := make(map[string]MSPAGroup)
+	if data == nil {
+		return all
+	}
+	for _, l := range data.Links {
+		if !IsRealSpacecraft(l.Spacecraft) {
+			continue
+		}
+		g := all[l.AntennaID]
+		g.AntennaID, g.Complex = l.AntennaID, l.Complex
+		g.Links = append(g.Links, l)
+		all[l.AntennaID] = g
+	}
+	for id, g := range all {
+		if len(g.Links) < 2 {
+			delete(all, id)
+		}
+	}
+	return all
+}
+
+// FormatShare formats a link's rate allocation: "shared 80%" on an MSPA
+// antenna, "dedicated" otherwise.
+func FormatShare(mspa bool, share float64) string {
+	if !mspa {
+		return "dedicated"
+	}
+	return fmt.Sprintf("shared %.0f%%", share*100)
+}
@@ -0,0 +1,76 @@
+package dsn
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func mspaTestData() *DSNData {
+	return &DSNData{Links: []Link{
+		{Complex: ComplexMadrid, StationID: "mdscc", AntennaID: "DSS54", Spacecraft: "MRO", SpacecraftID: 74, DataRate: 2e6, Band: "X"},
+		{Complex: ComplexMadrid, StationID: "mdscc", AntennaID: "DSS63", Spacecraft: "VGR1", SpacecraftID: 31, DataRate: 160, Band: "X"},
+		{Complex: ComplexMadrid, StationID: "mdscc", AntennaID: "DSS54", Spacecraft: "MVN", SpacecraftID: 202, DataRate: 5e5, Band: "X"},
+		{Complex: ComplexMadrid, StationID: "mdscc", AntennaID: "DSS54", Spacecraft: "DSN"},
+	}}
+}
+
+func TestMSPAGroups(t *testing.T) {
+	groups := MSPAGroups(mspaTestData())
+	if len(groups) != 1 {
+		t.Fatalf("groups = %+v, want DSS54 only", groups)
+	}
+	g := groups[0]
+	if g.AntennaID != "DSS54" || strings.Join(g.Spacecraft(), ",") != "MRO,MVN" {
+		t.Errorf("group = %s %v, want DSS54 MRO,MVN (internal targets left out)", g.AntennaID, g.Spacecraft())
+	}
+	if g.TotalRate() != 2.5e6 || g.Share(g.Links[0]) != 0.8 {
+		t.Errorf("total %v, MRO share %v; want 2.5e6, 0.8", g.TotalRate(), g.Share(g.Links[0]))
+	}
+
+	// No rates reported: an even split
+	idle := MSPAGroup{Links: []Link{{Spacecraft: "A"}, {Spacecraft: "B"}}}
+	if got := idle.Share(idle.Links[0]); got != 0.5 {
+		t.Errorf("share without rates = %v, want 0.5", got)
+	}
+}
+
+func TestBuildSpacecraftViews_MSPA(t *testing.T) {
+	views := BuildSpacecraftViews(mspaTestData(), nil)
+	byCode := make(map[string]LinkView)
+	for _, v := range views {
+		byCode[v.Code] = v.PrimaryLink
+	}
+	if lv := byCode["MVN"]; !lv.MSPA || lv.Share != 0.2 || strings.Join(lv.SharedWith, ",") != "MRO" {
+		t.Errorf("MVN = %+v, want 20%% of DSS54 shared with MRO", lv)
+	}
+	if lv := byCode["VGR1"]; lv.MSPA || lv.Share != 1 || lv.FormatShare() != "dedicated" {
+		t.Errorf("VGR1 = %+v, want a dedicated antenna", lv)
+	}
+}
+
+func TestWriteSummaryTable_MSPA(t *testing.T) {
+	var buf bytes.Buffer
+	WriteSummaryTable(&buf, mspaTestData(), mspaTestData().Timestamp)
+	lines := strings.Split(buf.String(), "\n")
+
+	// DSS54's links are grouped, in feed order, ahead of DSS63's
+	var rows []string
+	for _, l := range lines {
+		if strings.Contains(l, "shared") || strings.Contains(l, "dedicated") {
+			rows = append(rows, l)
+		}
+	}
+	if len(rows) != 4 {
+		t.Fatalf("rows = %q", rows)
+	}
+	if !strings.Contains(rows[0], "DSS54") || !strings.Contains(rows[0], "MRO") || !strings.Contains(rows[0], "shared 80%") {
+		t.Errorf("row 0 = %q, want DSS54 MRO shared 80%%", rows[0])
+	}
+	if !strings.Contains(rows[1], "└") || !strings.Contains(rows[1], "MVN") || strings.Contains(rows[1], "DSS54") {
+		t.Errorf("row 1 = %q, want MVN grouped under DSS54", rows[1])
+	}
+	if !strings.Contains(rows[3], "VGR1") || !strings.Contains(rows[3], "dedicated") {
+		t.Errorf("row 3 = %q, want VGR1 dedicated", rows[3])
+	}
+}
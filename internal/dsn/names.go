@@ -0,0 +1,94 @@
+package dsn
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// Spacecraft names reach us in several scripts and spellings: "Hayabusa2"
+// and "はやぶさ2", "Chang'e" with a typographic apostrophe, Latin names with
+// diacritics. FoldName reduces them to a matching key, and the width
+// helpers below measure terminal cells rather than bytes so wide (CJK) or
+// combining characters don't break column alignment.
+
+// latinFold maps accented Latin letters (already lowercased) to ASCII.
+var latinFold = map[rune]string{
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a", 'ā': "a", 'ă': "a", 'ą': "a",
+	'æ': "ae", 'ç': "c", 'ć': "c", 'č': "c", 'ď': "d", 'đ': "d",
+	'è': "e", 'é': "e", 'ê': "e", 'ë': "e", 'ē': "e", 'ė': "e", 'ę': "e", 'ě': "e",
+	'ğ': "g", 'ì': "i", 'í': "i", 'î': "i", 'ï': "i", 'ī': "i", 'ı': "i",
+	'ł': "l", 'ñ': "n", 'ń': "n", 'ň': "n",
+	'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ö': "o", 'ø': "o", 'ō': "o", 'ő': "o", 'œ': "oe",
+	'ř': "r", 'ś': "s", 'š': "s", 'ş': "s", 'ß': "ss", 'ť': "t", 'ţ': "t",
+	'ù': "u", 'ú': "u", 'û': "u", 'ü': "u", 'ū': "u", 'ů': "u", 'ű': "u",
+	'ý': "y", 'ÿ': "y", 'ź': "z", 'ż': "z", 'ž': "z",
+}
+
+// FoldName returns a case- and script-insensitive key for matching a
+// spacecraft name: lowercased, Latin diacritics removed, typographic
+// apostrophes and dashes replaced with ASCII ones, fullwidth forms
+// narrowed, and runs of whitespace collapsed. Non-Latin scripts are kept
+// as-is so native names can be matched as aliases.
+func FoldName(name string) string {
+	var b strings.Builder
+	b.Grow(len(name))
+	space := false
+
+	for _, r := range strings.TrimSpace(name) {
+		// Fullwidth ASCII variants (U+FF01..U+FF5E)
+		if r >= 0xFF01 && r <= 0xFF5E {
+			r -= 0xFF01 - '!'
+		}
+		r = unicode.ToLower(r)
+
+		switch {
+		case unicode.IsSpace(r):
+			if !space {
+				b.WriteByte(' ')
+			}
+			space = true
+			continue
+		case r == '‘' || r == '’' || r == 'ʼ' || r == '´' || r == '`':
+			r = '\''
+		case r == '‐' || r == '‑' || r == '‒' || r == '–' || r == '—' || r == '−':
+			r = '-'
+		}
+		space = false
+
+		if s, ok := latinFold[r]; ok {
+			b.WriteString(s)
+			continue
+		}
+		if unicode.Is(unicode.Mn, r) {
+			continue // combining marks left over from decomposed input
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// DisplayWidth returns the number of terminal cells s occupies.
+func DisplayWidth(s string) int {
+	return runewidth.StringWidth(s)
+}
+
+// TruncateWidth shortens s to at most width cells, ending it with tail
+// when cut. It never splits a multi-byte or double-width character.
+func TruncateWidth(s string, width int, tail string) string {
+	if DisplayWidth(s) <= width {
+		return s
+	}
+	if DisplayWidth(tail) >= width {
+		tail = ""
+	}
+	return runewidth.Truncate(s, width, tail)
+}
+
+// PadWidth truncates or pads s with spaces to exactly width cells.
+func PadWidth(s string, width int, tail string) string {
+	s = TruncateWidth(s, width, tail)
+	return s + strings.Repeat(" ", max(0, width-DisplayWidth(s)))
+}
@@ -0,0 +1,47 @@
+package dsn
+
+import "testing"
+
+func TestFoldName(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"Voyager 1", "voyager 1"},
+		{"Chang’e-6", "chang'e-6"},
+		{"Chang'e 6", "chang'e 6"},
+		{"Chang´e—6", "chang'e-6"},
+		{"Rosétta", "rosetta"},
+		{"Rosétta", "rosetta"}, // decomposed accent
+		{"ＨＡＹＡＢＵＳＡ２", "hayabusa2"},
+		{"  Mars   Express ", "mars express"},
+		{"はやぶさ2", "はやぶさ2"},
+	}
+	for _, tt := range tests {
+		if got := FoldName(tt.in); got != tt.want {
+			t.Errorf("FoldName(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestPadWidth(t *testing.T) {
+	tests := []struct {
+		in    string
+		width int
+		want  string
+	}{
+		{"VGR1", 6, "VGR1  "},
+		{"はやぶさ2", 10, "はやぶさ2 "},
+		{"はやぶさ2", 7, "はや.. "}, // never splits a wide character
+		{"Chang'e", 5, "Cha.."},
+		{"James Webb", 2, "Ja"},
+	}
+	for _, tt := range tests {
+		got := PadWidth(tt.in, tt.width, "..")
+		if got != tt.want {
+			t.Errorf("PadWidth(%q, %d) = %q, want %q", tt.in, tt.width, got, tt.want)
+		}
+		if w := DisplayWidth(got); w != tt.width {
+			t.Errorf("DisplayWidth(PadWidth(%q, %d)) = %d", tt.in, tt.width, w)
+		}
+	}
+}
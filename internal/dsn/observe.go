@@ -0,0 +1,305 @@
+package dsn
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/astro"
+)
+
+// ObservationLead is how long before AOS the checklist has the antenna
+// pointed at the rise point.
+const ObservationLead = 5 * time.Minute
+
+// ObservationTrackInterval is the spacing of the pointing updates between
+// AOS and LOS.
+const ObservationTrackInterval = 30 * time.Minute
+
+// rangeRateSpan is the time either side of an epoch over which the
+// geocentric range rate is differenced.
+const rangeRateSpan = 5 * time.Minute
+
+// earthNAIFID is Earth's center in the heliocentric vector queries.
+const earthNAIFID = 399
+
+// ObservationStepKind is the role of a checklist step.
+type ObservationStepKind string
+
+const (
+	StepPoint ObservationStepKind = "POINT" // antenna on the rise point by T-5
+	StepAOS   ObservationStepKind = "AOS"
+	StepTrack ObservationStepKind = "TRACK"
+	StepPeak  ObservationStepKind = "PEAK"
+	StepLOS   ObservationStepKind = "LOS"
+)
+
+// ObservationStep is one line of a pass-observation checklist: where to
+// point and what to tune to at a moment in the pass.
+type ObservationStep struct {
+	Kind  ObservationStepKind
+	At    time.Time
+	AzDeg float64
+	ElDeg float64
+
+	// Offset is the received carrier's offset from nominal in Hz: the
+	// Doppler shift, negative while the range is growing
+	Offset float64
+}
+
+// ObservationPlan is a step-by-step timeline for watching one pass of a
+// spacecraft from a personal station.
+type ObservationPlan struct {
+	Spacecraft string
+	Observer   astro.Observer
+	Pass       Pass
+	Band       string
+	FreqMHz    float64
+	Steps      []ObservationStep
+
+	// RangeRateKnown is false when the spacecraft's own motion couldn't
+	// be looked up, so offsets cover Earth's rotation only
+	RangeRateKnown bool
+
+	samples   []astro.RADecAtTime
+	rangeRate func(time.Time) float64
+}
+
+// PlanObservation builds the checklist for pass, with pointing from the
+// RA/Dec samples and Doppler from the geocentric range rate (km/s,
+// positive receding) at a time, plus the observer's own motion with the
+// Earth's rotation. rangeRate may be nil when the range rate is unknown.
+func PlanObservation(code string, obs astro.Observer, pass Pass, samples []astro.RADecAtTime, band string, rangeRate func(time.Time) float64) ObservationPlan {
+	p := ObservationPlan{
+		Spacecraft:     code,
+		Observer:       obs,
+		Pass:           pass,
+		Band:           band,
+		FreqMHz:        GetBandFrequency(band),
+		RangeRateKnown: rangeRate != nil,
+		samples:        samples,
+		rangeRate:      rangeRate,
+	}
+
+	p.Steps = append(p.Steps, p.stepAt(StepPoint, pass.Start.Add(-ObservationLead)), p.stepAt(StepAOS, pass.Start))
+	// The rise point, not where the spacecraft sits below the horizon
+	p.Steps[0].AzDeg, p.Steps[0].ElDeg = p.Steps[1].AzDeg, math.Max(p.Steps[1].ElDeg, 0)
+	p.Steps[0].Offset = p.Steps[1].Offset
+
+	peakAdded := false
+	for t := pass.Start.Add(ObservationTrackInterval); t.Before(pass.End); t = t.Add(ObservationTrackInterval) {
+		if !peakAdded && !pass.Peak.After(t) {
+			p.Steps = append(p.Steps, p.stepAt(StepPeak, pass.Peak))
+			peakAdded = true
+		}
+		if pass.End.Sub(t) < ObservationTrackInterval/2 || absDuration(t.Sub(pass.Peak)) < ObservationTrackInterval/2 {
+			continue
+		}
+		p.Steps = append(p.Steps, p.stepAt(StepTrack, t))
+	}
+	if !peakAdded {
+		p.Steps = append(p.Steps, p.stepAt(StepPeak, pass.Peak))
+	}
+	p.Steps = append(p.Steps, p.stepAt(StepLOS, pass.End))
+	return p
+}
+
+// stepAt returns a step with pointing and Doppler at t.
+func (p ObservationPlan) stepAt(kind ObservationStepKind, t time.Time) ObservationStep {
+	s := ObservationStep{Kind: kind, At: t}
+	s.AzDeg, s.ElDeg, s.Offset = p.At(t)
+	return s
+}
+
+// At returns the pointing and carrier offset at t.
+func (p ObservationPlan) At(t time.Time) (azDeg, elDeg, offsetHz float64) {
+	ra, dec := raDecAt(p.samples, t)
+	h := astro.EquatorialToHorizontal(astro.SkyCoord{RAdeg: ra, DecDeg: dec}, p.Observer, t)
+	var geo float64
+	if p.rangeRate != nil {
+		geo = p.rangeRate(t)
+	}
+	return h.AzDeg, h.ElDeg, CarrierOffset(p.FreqMHz, TopocentricRangeRate(geo, p.Observer, h.AzDeg, h.ElDeg))
+}
+
+// Next returns the index of the first step at or after now, or len(Steps)
+// once the pass is over.
+func (p ObservationPlan) Next(now time.Time) int {
+	for i, s := range p.Steps {
+		if !s.At.Before(now) {
+			return i
+		}
+	}
+	return len(p.Steps)
+}
+
+// TopocentricRangeRate adds an observer's motion with Earth's rotation to
+// a geocentric range rate (km/s, positive receding) for a target at the
+// given azimuth and elevation. The observer moves east, so a target in
+// the east is being approached.
+func TopocentricRangeRate(geocentric float64, obs astro.Observer, azDeg, elDeg float64) float64 {
+	v := EarthRadius * math.Cos(obs.LatDeg*math.Pi/180) * EarthAngularVelocity
+	east := math.Cos(elDeg*math.Pi/180) * math.Sin(azDeg*math.Pi/180)
+	return geocentric - v*east
+}
+
+// CarrierOffset returns the received frequency's offset in Hz from a
+// carrier of freqMHz at a range rate in km/s (positive receding).
+func CarrierOffset(freqMHz, rangeRate float64) float64 {
+	return -freqMHz * 1e6 * rangeRate / SpeedOfLight
+}
+
+// GeocentricRangeRates returns the geocentric range rate in km/s
+// (positive receding) of a spacecraft at each of times, differenced from
+// heliocentric positions rangeRateSpan either side.
+func GeocentricRangeRates(p SolarSystemSeriesProvider, naifID int, times []time.Time) ([]float64, error) {
+	epochs := make([]time.Time, 0, 2*len(times))
+	for _, t := range times {
+		epochs = append(epochs, t.Add(-rangeRateSpan), t.Add(rangeRateSpan))
+	}
+	sc, err := p.GetHeliocentricPositions(naifID, epochs)
+	if err != nil {
+		return nil, err
+	}
+	earth, err := p.GetHeliocentricPositions(earthNAIFID, epochs)
+	if err != nil {
+		return nil, err
+	}
+	if len(sc) != len(epochs) || len(earth) != len(epochs) {
+		return nil, fmt.Errorf("got %d and %d positions for %d epochs", len(sc), len(earth), len(epochs))
+	}
+
+	rates := make([]float64, len(times))
+	for i := range times {
+		before := astro.AUToKm(sc[2*i].Sub(earth[2*i]).Norm())
+		after := astro.AUToKm(sc[2*i+1].Sub(earth[2*i+1]).Norm())
+		rates[i] = (after - before) / (2 * rangeRateSpan).Seconds()
+	}
+	return rates, nil
+}
+
+// PassRangeRate looks up the geocentric range rate every
+// ObservationTrackInterval from the pointing step to LOS and returns it
+// interpolated, for PlanObservation.
+func PassRangeRate(p SolarSystemSeriesProvider, naifID int, pass Pass) (func(time.Time) float64, error) {
+	var times []time.Time
+	for t := pass.Start.Add(-ObservationLead); t.Before(pass.End); t = t.Add(ObservationTrackInterval) {
+		times = append(times, t)
+	}
+	times = append(times, pass.End)
+	rates, err := GeocentricRangeRates(p, naifID, times)
+	if err != nil {
+		return nil, err
+	}
+	return func(t time.Time) float64 {
+		i := sort.Search(len(times), func(i int) bool { return times[i].After(t) })
+		switch {
+		case i == 0:
+			return rates[0]
+		case i == len(times):
+			return rates[len(rates)-1]
+		}
+		f := float64(t.Sub(times[i-1])) / float64(times[i].Sub(times[i-1]))
+		return rates[i-1] + f*(rates[i]-rates[i-1])
+	}, nil
+}
+
+// raDecAt interpolates RA/Dec samples at t, taking the short way around
+// at RA 0/360. Times outside the samples get the nearest sample.
+func raDecAt(samples []astro.RADecAtTime, t time.Time) (raDeg, decDeg float64) {
+	if len(samples) == 0 {
+		return 0, 0
+	}
+	if !t.After(samples[0].Time) {
+		return samples[0].RAdeg, samples[0].DecDeg
+	}
+	for i := 1; i < len(samples); i++ {
+		a, b := samples[i-1], samples[i]
+		if t.After(b.Time) {
+			continue
+		}
+		f := float64(t.Sub(a.Time)) / float64(b.Time.Sub(a.Time))
+		dRA := math.Remainder(b.RAdeg-a.RAdeg, 360)
+		return math.Mod(a.RAdeg+f*dRA+360, 360), a.DecDeg + f*(b.DecDeg-a.DecDeg)
+	}
+	last := samples[len(samples)-1]
+	return last.RAdeg, last.DecDeg
+}
+
+// WriteObservationPlan prints the checklist with times in loc, marking
+// steps done (✓) and next (▶) as of now.
+// Format:
+//
+//	VGR1 pass from 34.200°, -118.200°  X band, 8420.000 MHz
+//	────────────────────────────────────────────────────────────
+//	✓ T-5m   21:05  Point antenna at Az 112° El 0°; tune 8420.000 MHz +12.34 kHz
+//	▶ AOS    21:10  Rises at Az 112°; expect +12.34 kHz (8420.01234 MHz)
+//	  TRACK  21:40  Az 120° El 6°, +12.30 kHz
+//	  PEAK   03:12  Peak El 54° at Az 180°, +10.50 kHz
+//	  LOS    09:20  Sets at Az 248°, +8.10 kHz; pass lasted 12h 10m
+func WriteObservationPlan(w io.Writer, p ObservationPlan, now time.Time, loc *time.Location) {
+	fmt.Fprintf(w, "%s pass from %.3f°, %.3f°  %s band, %.3f MHz\n",
+		p.Spacecraft, p.Observer.LatDeg, p.Observer.LonDeg, p.Band, p.FreqMHz)
+	fmt.Fprintln(w, strings.Repeat("─", 60))
+	next := p.Next(now)
+	for i, s := range p.Steps {
+		mark := " "
+		switch {
+		case i < next:
+			mark = "✓"
+		case i == next:
+			mark = "▶"
+		}
+		fmt.Fprintf(w, "%s %s\n", mark, FormatObservationStep(p, s, loc))
+	}
+	if !p.RangeRateKnown {
+		fmt.Fprintln(w, "\nDoppler covers Earth's rotation only: the spacecraft's own motion wasn't available")
+	}
+}
+
+// FormatObservationStep formats one checklist line, without its mark.
+func FormatObservationStep(p ObservationPlan, s ObservationStep, loc *time.Location) string {
+	label := string(s.Kind)
+	if s.Kind == StepPoint {
+		label = "T-" + formatSessionLength(ObservationLead)
+	}
+	var text string
+	switch s.Kind {
+	case StepPoint:
+		text = fmt.Sprintf("Point antenna at Az %.0f° El %.0f°; tune %.3f MHz %s", s.AzDeg, s.ElDeg, p.FreqMHz, formatCarrierOffset(s.Offset))
+	case StepAOS:
+		text = fmt.Sprintf("Rises at Az %.0f°; expect %s (%.5f MHz)", s.AzDeg, formatCarrierOffset(s.Offset), p.FreqMHz+s.Offset/1e6)
+	case StepPeak:
+		text = fmt.Sprintf("Peak El %.0f° at Az %.0f°, %s", s.ElDeg, s.AzDeg, formatCarrierOffset(s.Offset))
+	case StepLOS:
+		text = fmt.Sprintf("Sets at Az %.0f°, %s; pass lasted %s", s.AzDeg, formatCarrierOffset(s.Offset), formatSessionLength(p.Pass.End.Sub(p.Pass.Start)))
+	default:
+		text = fmt.Sprintf("Az %.0f° El %.0f°, %s", s.AzDeg, s.ElDeg, formatCarrierOffset(s.Offset))
+	}
+	return fmt.Sprintf("%-6s %s  %s", label, s.At.In(loc).Format("15:04"), text)
+}
+
+// FormatObservationNow describes the pass at now: "Az 130.2° El 12.4°,
+// +10.21 kHz, LOS in 7h 10m", or how long until AOS.
+func FormatObservationNow(p ObservationPlan, now time.Time) string {
+	if now.Before(p.Pass.Start) {
+		return fmt.Sprintf("AOS in %s", formatSessionLength(p.Pass.Start.Sub(now)))
+	}
+	if !now.Before(p.Pass.End) {
+		return "Pass over"
+	}
+	az, el, offset := p.At(now)
+	return fmt.Sprintf("Az %.1f° El %.1f°, %s, LOS in %s", az, el, formatCarrierOffset(offset), formatSessionLength(p.Pass.End.Sub(now)))
+}
+
+// formatCarrierOffset formats a signed frequency offset: "+12.34 kHz".
+func formatCarrierOffset(hz float64) string {
+	s := FormatDopplerShift(hz)
+	if hz >= 0 {
+		s = "+" + s
+	}
+	return s
+}
@@ -0,0 +1,194 @@
+package dsn
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/astro"
+)
+
+func TestTopocentricRangeRate(t *testing.T) {
+	equator := astro.Observer{}
+	rotation := EarthRadius * EarthAngularVelocity // ~0.465 km/s
+
+	// Rising in the east: the observer turns toward it
+	if got := TopocentricRangeRate(10, equator, 90, 0); math.Abs(got-(10-rotation)) > 1e-9 {
+		t.Errorf("east at horizon = %.4f, want %.4f", got, 10-rotation)
+	}
+	// Setting in the west: turning away
+	if got := TopocentricRangeRate(10, equator, 270, 0); math.Abs(got-(10+rotation)) > 1e-9 {
+		t.Errorf("west at horizon = %.4f, want %.4f", got, 10+rotation)
+	}
+	// Overhead and at the pole, rotation adds nothing
+	if got := TopocentricRangeRate(10, equator, 90, 90); math.Abs(got-10) > 1e-9 {
+		t.Errorf("overhead = %.4f, want 10", got)
+	}
+	if got := TopocentricRangeRate(10, astro.Observer{LatDeg: 90}, 90, 0); math.Abs(got-10) > 1e-9 {
+		t.Errorf("at the pole = %.4f, want 10", got)
+	}
+}
+
+func TestCarrierOffset(t *testing.T) {
+	// Receding at 1 km/s lowers an X-band carrier by ~28 kHz
+	if got := CarrierOffset(FreqXBand, 1); math.Abs(got+28086) > 1 {
+		t.Errorf("CarrierOffset(X, 1 km/s) = %.0f Hz, want about -28086", got)
+	}
+	if got := CarrierOffset(FreqXBand, -1); got <= 0 {
+		t.Errorf("approaching offset = %.0f, want positive", got)
+	}
+}
+
+func TestRaDecAt_WrapsRA(t *testing.T) {
+	t0 := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	samples := []astro.RADecAtTime{
+		{Time: t0, RAdeg: 359, DecDeg: 10},
+		{Time: t0.Add(10 * time.Minute), RAdeg: 1, DecDeg: 12},
+	}
+	ra, dec := raDecAt(samples, t0.Add(5*time.Minute))
+	if math.Abs(math.Remainder(ra, 360)) > 1e-9 || math.Abs(dec-11) > 1e-9 {
+		t.Errorf("midpoint = %.3f, %.3f; want 0, 11", ra, dec)
+	}
+	if ra, _ := raDecAt(samples, t0.Add(time.Hour)); ra != 1 {
+		t.Errorf("after the samples RA = %.3f, want the last sample's", ra)
+	}
+}
+
+// equatorialPass returns a day of samples of a fixed point on the
+// celestial equator and its first full pass over obs.
+func equatorialPass(t *testing.T, obs astro.Observer) ([]astro.RADecAtTime, Pass) {
+	t.Helper()
+	start := time.Date(2024, 6, 21, 3, 0, 0, 0, time.UTC)
+	var samples []astro.RADecAtTime
+	for i := 0; i <= 36*12; i++ {
+		samples = append(samples, astro.RADecAtTime{Time: start.Add(time.Duration(i) * PassSampleInterval), RAdeg: 180})
+	}
+	for _, p := range ComputeObserverPasses(obs, samples, start) {
+		if p.Start.After(start) {
+			return samples, p
+		}
+	}
+	t.Fatal("no full pass")
+	return nil, Pass{}
+}
+
+func TestPlanObservation(t *testing.T) {
+	la := astro.Observer{LatDeg: 34.05, LonDeg: -118.25}
+	samples, pass := equatorialPass(t, la)
+	plan := PlanObservation("VGR1", la, pass, samples, "X", func(time.Time) float64 { return 20 })
+
+	steps := plan.Steps
+	if steps[0].Kind != StepPoint || steps[1].Kind != StepAOS || steps[len(steps)-1].Kind != StepLOS {
+		t.Fatalf("steps = %v, want POINT, AOS, ..., LOS", kinds(steps))
+	}
+	if !steps[0].At.Equal(pass.Start.Add(-ObservationLead)) || steps[0].AzDeg != steps[1].AzDeg {
+		t.Errorf("POINT = %+v, want the AOS azimuth %s early", steps[0], ObservationLead)
+	}
+	peaks := 0
+	for i, s := range steps {
+		if i > 0 && s.At.Before(steps[i-1].At) {
+			t.Errorf("step %d (%s) out of order", i, s.Kind)
+		}
+		if s.Kind == StepPeak {
+			peaks++
+			if math.Abs(s.ElDeg-pass.MaxElDeg) > 1 {
+				t.Errorf("PEAK El = %.1f, want ~%.1f", s.ElDeg, pass.MaxElDeg)
+			}
+		}
+	}
+	if peaks != 1 {
+		t.Errorf("%d PEAK steps, want 1", peaks)
+	}
+
+	// An equatorial object rises due east and sets due west, so the
+	// observer's rotation lowers the receding offset at AOS and raises
+	// it at LOS
+	aos, los := steps[1], steps[len(steps)-1]
+	if math.Abs(aos.AzDeg-90) > 3 || math.Abs(los.AzDeg-270) > 3 {
+		t.Errorf("AOS Az %.0f, LOS Az %.0f; want ~90 and ~270", aos.AzDeg, los.AzDeg)
+	}
+	recede := CarrierOffset(FreqXBand, 20)
+	if !(aos.Offset > recede && los.Offset < recede) {
+		t.Errorf("offsets AOS %.0f, LOS %.0f around %.0f: rotation not applied", aos.Offset, los.Offset, recede)
+	}
+}
+
+func TestPlanObservation_NoRangeRate(t *testing.T) {
+	la := astro.Observer{LatDeg: 34.05, LonDeg: -118.25}
+	samples, pass := equatorialPass(t, la)
+	plan := PlanObservation("VGR1", la, pass, samples, "Ka", nil)
+	if plan.RangeRateKnown || plan.FreqMHz != FreqKaBand {
+		t.Errorf("plan = known %v at %.0f MHz, want unknown at Ka", plan.RangeRateKnown, plan.FreqMHz)
+	}
+
+	var buf bytes.Buffer
+	WriteObservationPlan(&buf, plan, pass.Start.Add(time.Minute), time.UTC)
+	out := buf.String()
+	for _, want := range []string{"VGR1 pass from 34.050°, -118.250°", "✓ T-5m", "✓ AOS", "Earth's rotation only"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Count(out, "▶") != 1 {
+		t.Errorf("want exactly one next step:\n%s", out)
+	}
+}
+
+func TestFormatObservationNow(t *testing.T) {
+	la := astro.Observer{LatDeg: 34.05, LonDeg: -118.25}
+	samples, pass := equatorialPass(t, la)
+	plan := PlanObservation("VGR1", la, pass, samples, "X", nil)
+
+	if got := FormatObservationNow(plan, pass.Start.Add(-90*time.Minute)); got != "AOS in 1h 30m" {
+		t.Errorf("before = %q", got)
+	}
+	if got := FormatObservationNow(plan, pass.Peak); !strings.Contains(got, "LOS in") || !strings.HasPrefix(got, "Az ") {
+		t.Errorf("during = %q", got)
+	}
+	if got := FormatObservationNow(plan, pass.End); got != "Pass over" {
+		t.Errorf("after = %q", got)
+	}
+}
+
+// fakeVectors places Earth at the origin and a spacecraft receding along
+// x at 10 km/s.
+type fakeVectors struct{}
+
+func (fakeVectors) GetHeliocentricPosition(naifID int, t time.Time) (astro.Vec3, error) {
+	return astro.Vec3{}, nil
+}
+
+func (fakeVectors) GetHeliocentricPositions(naifID int, times []time.Time) ([]astro.Vec3, error) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	out := make([]astro.Vec3, len(times))
+	for i, t := range times {
+		if naifID != earthNAIFID {
+			out[i] = astro.Vec3{X: astro.KmToAU(1e9 + 10*t.Sub(t0).Seconds())}
+		}
+	}
+	return out, nil
+}
+
+func TestPassRangeRate(t *testing.T) {
+	start := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	pass := Pass{Start: start, Peak: start.Add(2 * time.Hour), End: start.Add(4 * time.Hour)}
+	rate, err := PassRangeRate(fakeVectors{}, -31, pass)
+	if err != nil {
+		t.Fatalf("PassRangeRate: %v", err)
+	}
+	for _, at := range []time.Time{start.Add(-time.Hour), pass.Peak.Add(7 * time.Minute), pass.End.Add(time.Hour)} {
+		if got := rate(at); math.Abs(got-10) > 1e-3 {
+			t.Errorf("rate at %v = %.4f km/s, want 10", at, got)
+		}
+	}
+}
+
+func kinds(steps []ObservationStep) []ObservationStepKind {
+	out := make([]ObservationStepKind, len(steps))
+	for i, s := range steps {
+		out[i] = s.Kind
+	}
+	return out
+}
@@ -1,9 +1,74 @@
 package dsn
 
 import (
+	"strconv"
+	"strings"
+
 	"github.com/litescript/ls-horizons/internal/astro"
 )
 
+// AntennaSite is the surveyed location of an individual DSN antenna.
+// Antennas within a complex sit up to ~10 km apart, which shifts rise and
+// set times by tens of seconds relative to the complex reference point.
+type AntennaSite struct {
+	DSS       int     // Deep Space Station number, e.g. 14
+	Complex   Complex // Owning complex
+	Latitude  float64 // Geodetic latitude in degrees (north positive)
+	Longitude float64 // Longitude in degrees (east positive)
+	HeightM   float64 // Height above the WGS84 ellipsoid in meters
+	Diameter  float64 // Dish diameter in meters
+}
+
+// KnownAntennas maps DSS numbers to antenna sites.
+// Coordinates from DSN Telecommunications Link Design Handbook 810-005, module 301.
+var KnownAntennas = map[int]AntennaSite{
+	// Goldstone
+	13: {DSS: 13, Complex: ComplexGoldstone, Latitude: 35.2472, Longitude: -116.7945, HeightM: 1071, Diameter: 34},
+	14: {DSS: 14, Complex: ComplexGoldstone, Latitude: 35.4259, Longitude: -116.8895, HeightM: 1002, Diameter: 70},
+	24: {DSS: 24, Complex: ComplexGoldstone, Latitude: 35.3399, Longitude: -116.8748, HeightM: 952, Diameter: 34},
+	25: {DSS: 25, Complex: ComplexGoldstone, Latitude: 35.3376, Longitude: -116.8754, HeightM: 960, Diameter: 34},
+	26: {DSS: 26, Complex: ComplexGoldstone, Latitude: 35.3357, Longitude: -116.8730, HeightM: 969, Diameter: 34},
+
+	// Canberra
+	34: {DSS: 34, Complex: ComplexCanberra, Latitude: -35.3985, Longitude: 148.9820, HeightM: 692, Diameter: 34},
+	35: {DSS: 35, Complex: ComplexCanberra, Latitude: -35.3957, Longitude: 148.9815, HeightM: 694, Diameter: 34},
+	36: {DSS: 36, Complex: ComplexCanberra, Latitude: -35.3951, Longitude: 148.9786, HeightM: 685, Diameter: 34},
+	43: {DSS: 43, Complex: ComplexCanberra, Latitude: -35.4024, Longitude: 148.9813, HeightM: 689, Diameter: 70},
+
+	// Madrid
+	53: {DSS: 53, Complex: ComplexMadrid, Latitude: 40.4273, Longitude: -4.2496, HeightM: 827, Diameter: 34},
+	54: {DSS: 54, Complex: ComplexMadrid, Latitude: 40.4256, Longitude: -4.2541, HeightM: 837, Diameter: 34},
+	55: {DSS: 55, Complex: ComplexMadrid, Latitude: 40.4243, Longitude: -4.2526, HeightM: 819, Diameter: 34},
+	56: {DSS: 56, Complex: ComplexMadrid, Latitude: 40.4312, Longitude: -4.2510, HeightM: 835, Diameter: 34},
+	63: {DSS: 63, Complex: ComplexMadrid, Latitude: 40.4312, Longitude: -4.2480, HeightM: 865, Diameter: 70},
+	65: {DSS: 65, Complex: ComplexMadrid, Latitude: 40.4272, Longitude: -4.2507, HeightM: 834, Diameter: 34},
+}
+
+// ParseDSSNumber extracts the station number from an antenna ID such as
+// "DSS14", "DSS-14", or "dss 14".
+func ParseDSSNumber(antennaID string) (int, bool) {
+	s := strings.ToUpper(strings.TrimSpace(antennaID))
+	if !strings.HasPrefix(s, "DSS") {
+		return 0, false
+	}
+	s = strings.TrimLeft(s[3:], "- ")
+	n, err := strconv.Atoi(s)
+	if err != nil || n <= 0 {
+		return 0, false
+	}
+	return n, true
+}
+
+// GetAntennaSite returns the site for an antenna ID, if it is known.
+func GetAntennaSite(antennaID string) (AntennaSite, bool) {
+	n, ok := ParseDSSNumber(antennaID)
+	if !ok {
+		return AntennaSite{}, false
+	}
+	site, ok := KnownAntennas[n]
+	return site, ok
+}
+
 // ObserverForComplex returns an astro.Observer for the given DSN complex.
 // Defaults to Goldstone for unknown or zero values.
 func ObserverForComplex(c Complex) astro.Observer {
@@ -14,8 +79,29 @@ func ObserverForComplex(c Complex) astro.Observer {
 	}
 
 	return astro.Observer{
-		LatDeg: info.Latitude,
-		LonDeg: info.Longitude,
-		Name:   info.Name,
+		LatDeg:    info.Latitude,
+		LonDeg:    info.Longitude,
+		Name:      info.Name,
+		AltitudeM: info.HeightM,
+		GeoidM:    info.GeoidM,
+	}
+}
+
+// ObserverForAntenna returns an astro.Observer at the given antenna's
+// surveyed location and altitude, with its complex's geoid height.
+// Unknown antennas fall back to the complex reference point (see
+// ObserverForComplex).
+func ObserverForAntenna(antennaID string, c Complex) astro.Observer {
+	site, ok := GetAntennaSite(antennaID)
+	if !ok {
+		return ObserverForComplex(c)
+	}
+
+	return astro.Observer{
+		LatDeg:    site.Latitude,
+		LonDeg:    site.Longitude,
+		Name:      "DSS-" + strconv.Itoa(site.DSS),
+		AltitudeM: site.HeightM,
+		GeoidM:    KnownComplexes[site.Complex].GeoidM,
 	}
 }
@@ -0,0 +1,103 @@
+package dsn
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/astro"
+)
+
+func TestParseDSSNumber(t *testing.T) {
+	tests := []struct {
+		id   string
+		want int
+		ok   bool
+	}{
+		{"DSS14", 14, true},
+		{"DSS-43", 43, true},
+		{"dss 63", 63, true},
+		{"DSS", 0, false},
+		{"DSS-0", 0, false},
+		{"MDSCC", 0, false},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseDSSNumber(tt.id)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ParseDSSNumber(%q) = %d, %v, want %d, %v", tt.id, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestKnownAntennas_NearTheirComplex(t *testing.T) {
+	for dss, site := range KnownAntennas {
+		if site.DSS != dss {
+			t.Errorf("KnownAntennas[%d].DSS = %d", dss, site.DSS)
+		}
+		ref, ok := KnownComplexes[site.Complex]
+		if !ok {
+			t.Errorf("DSS-%d: unknown complex %q", dss, site.Complex)
+			continue
+		}
+		// Equirectangular distance is plenty at this scale
+		const kmPerDeg = 111.32
+		dLat := (site.Latitude - ref.Latitude) * kmPerDeg
+		dLon := (site.Longitude - ref.Longitude) * kmPerDeg * math.Cos(ref.Latitude*math.Pi/180)
+		if d := math.Hypot(dLat, dLon); d > 25 {
+			t.Errorf("DSS-%d is %.1f km from %s, want < 25 km", dss, d, ref.Name)
+		}
+		if dh := math.Abs(site.HeightM - ref.HeightM); dh > 200 {
+			t.Errorf("DSS-%d is %.0f m above or below %s, want < 200 m", dss, dh, ref.Name)
+		}
+	}
+}
+
+func TestObserverForAntenna(t *testing.T) {
+	obs := ObserverForAntenna("DSS-43", ComplexCanberra)
+	if obs.LatDeg != KnownAntennas[43].Latitude || obs.Name != "DSS-43" {
+		t.Errorf("ObserverForAntenna(DSS-43) = %+v", obs)
+	}
+	if obs.AltitudeM != KnownAntennas[43].HeightM || obs.GeoidM != KnownComplexes[ComplexCanberra].GeoidM {
+		t.Errorf("ObserverForAntenna(DSS-43) altitude %v, geoid %v", obs.AltitudeM, obs.GeoidM)
+	}
+	if h := ObserverForAntenna("DSS14", ComplexGoldstone).SeaLevelAltitude(); h < 1000 || h > 1050 {
+		t.Errorf("DSS-14 sea-level altitude = %v, want ~1033 m", h)
+	}
+
+	// Unknown antennas use the complex reference point
+	if got, want := ObserverForAntenna("DSS99", ComplexMadrid), ObserverForComplex(ComplexMadrid); got != want {
+		t.Errorf("ObserverForAntenna(DSS99) = %+v, want %+v", got, want)
+	}
+}
+
+func TestComputeAntennaElevationTrace(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	var samples []astro.RADecAtTime
+	for i := -24; i <= 24; i++ {
+		samples = append(samples, astro.RADecAtTime{
+			Time:   now.Add(time.Duration(i) * ElevationTraceSampleInterval),
+			RAdeg:  120,
+			DecDeg: 20,
+		})
+	}
+
+	site := ComputeElevationTrace("TEST", ComplexGoldstone, samples, now)
+	ant := ComputeAntennaElevationTrace("TEST", ComplexGoldstone, "DSS13", samples, now)
+
+	if ant.Antenna != "DSS13" || site.Antenna != "" {
+		t.Errorf("Antenna = %q / %q, want DSS13 / empty", ant.Antenna, site.Antenna)
+	}
+	if len(ant.Samples) != len(site.Samples) {
+		t.Fatalf("sample counts differ: %d vs %d", len(ant.Samples), len(site.Samples))
+	}
+
+	// DSS-13 is ~10 km from the complex reference: different, but close
+	var maxDiff float64
+	for i := range ant.Samples {
+		maxDiff = math.Max(maxDiff, math.Abs(ant.Samples[i].Elevation-site.Samples[i].Elevation))
+	}
+	if maxDiff == 0 || maxDiff > 0.5 {
+		t.Errorf("max elevation difference = %.4f°, want (0, 0.5]", maxDiff)
+	}
+}
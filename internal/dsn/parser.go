@@ -61,18 +61,18 @@ type xmlSignal struct {
 	SpacecraftID string `xml:"spacecraftID,attr"` // capital ID in real feed
 }
 
-// Parse parses DSN XML data and returns a DSNData structure.
+// Parse parses DSN XML data and returns a DSNData structure. Parse never
+// consults the clock: Timestamp is zero if the feed carries no time.
 func Parse(data []byte) (*DSNData, error) {
 	var raw xmlDSN
 	if err := xml.Unmarshal(data, &raw); err != nil {
-		return nil, fmt.Errorf("unmarshal DSN XML: %w", err)
+		return nil, fmt.Errorf("%w: unmarshal DSN XML: %w", ErrParse, err)
 	}
 
 	result := &DSNData{
-		Timestamp: time.Now().UTC(),
-		Stations:  make([]Station, 0, len(raw.Stations)),
-		Links:     make([]Link, 0),
-		Errors:    make([]string, 0),
+		Stations: make([]Station, 0, len(raw.Stations)),
+		Links:    make([]Link, 0),
+		Errors:   make([]string, 0),
 	}
 
 	// Parse timestamp if available
@@ -93,9 +93,11 @@ func Parse(data []byte) (*DSNData, error) {
 	}
 
 	// Associate dishes with stations by complex (inferred from antenna ID)
+	antennas := make([]Antenna, 0, len(raw.Dishes))
 	for _, xmlDish := range raw.Dishes {
 		complex := inferComplex(xmlDish.Name)
 		antenna, links, errs := parseDish(xmlDish, complex, string(complex))
+		antennas = append(antennas, antenna)
 		result.Links = append(result.Links, links...)
 		result.Errors = append(result.Errors, errs...)
 
@@ -108,6 +110,20 @@ func Parse(data []byte) (*DSNData, error) {
 		}
 	}
 
+	// Tracking modes depend on signals across antennas and complexes
+	assignTrackingModes(result.Links, antennas)
+
+	// Without a feed timestamp, fall back to the newest station clock so the
+	// result depends only on the XML; the fetcher fills in fetch time if
+	// that is missing too.
+	if result.Timestamp.IsZero() {
+		for _, st := range result.Stations {
+			if st.TimeUTC.After(result.Timestamp) {
+				result.Timestamp = st.TimeUTC
+			}
+		}
+	}
+
 	return result, nil
 }
 
@@ -221,57 +237,181 @@ func parseSignal(xmlSig xmlSignal, errors *[]string) Signal {
 
 func buildLinks(antenna Antenna, complex Complex, stationName string) []Link {
 	var links []Link
+	// The feed points dishes, not targets: every link here shares the
+	// dish's angles
+	newLink := func() Link {
+		return Link{
+			StationID: stationName,
+			AntennaID: antenna.ID,
+			Complex:   complex,
+			Pointing:  Pointing{AzDeg: antenna.Azimuth, ElDeg: antenna.Elevation, Valid: true},
+		}
+	}
+
+	// Signals name their spacecraft like the targets they belong to, but
+	// carry its NAIF ID negated (target 62, signal -62). A signal is
+	// claimed by the target it names, else by the target with its ID.
+	claimed := func(sig Signal) bool {
+		for _, target := range antenna.Targets {
+			if sig.Spacecraft == target.Name || signalMatchesID(sig, target.ID) {
+				return true
+			}
+		}
+		return false
+	}
 
 	// Create a link for each target with signals
 	for _, target := range antenna.Targets {
-		link := Link{
-			StationID:    stationName,
-			AntennaID:    antenna.ID,
-			Complex:      complex,
-			SpacecraftID: target.ID,
-			Spacecraft:   target.Name,
-			RTLT:         target.RTLT,
-			Distance:     DistanceFromRTLT(target.RTLT),
+		link := newLink()
+		link.SpacecraftID = target.ID
+		link.Spacecraft = target.Name
+		link.RTLT = target.RTLT
+		link.Distance = DistanceFromRTLT(target.RTLT)
+
+		// Find matching signals for this target by name, falling back to
+		// its NAIF ID when the feed names them differently
+		match := func(sig Signal) bool { return sig.Spacecraft == target.Name }
+		if !hasSignal(antenna, match) {
+			byID := func(sig Signal) bool { return signalMatchesID(sig, target.ID) }
+			if hasSignal(antenna, byID) {
+				match = byID
+				link.Inferred = true
+			}
 		}
-
-		// Find matching signals for this target
-		// Match by spacecraft name (ID in XML target is positive, signal ID is negative)
 		for _, sig := range antenna.DownSignals {
-			if sig.Spacecraft == target.Name {
-				link.DownRate = sig.DataRate
-				if sig.Band != "" {
-					link.Band = sig.Band
-				} else if sig.Frequency > 0 {
-					link.Band = inferBand(sig.Frequency)
-				}
-				if sig.DataRate > link.DataRate {
-					link.DataRate = sig.DataRate
-				}
+			if match(sig) {
+				applyDownSignal(&link, sig)
 			}
 		}
 		for _, sig := range antenna.UpSignals {
-			if sig.Spacecraft == target.Name {
-				link.UpRate = sig.DataRate
-				link.Power = sig.Power
-				if link.Band == "" {
-					if sig.Band != "" {
-						link.Band = sig.Band
-					} else if sig.Frequency > 0 {
-						link.Band = inferBand(sig.Frequency)
-					}
-				}
-				if sig.DataRate > link.DataRate {
-					link.DataRate = sig.DataRate
-				}
+			if match(sig) {
+				applyUpSignal(&link, sig)
 			}
 		}
 
 		links = append(links, link)
 	}
 
+	// An active signal with no target still shows the dish talking to a
+	// spacecraft: keep it as an inferred link, identified by the signal's
+	// name or NAIF ID. Its range is unknown until the state manager fills
+	// it in from recent history.
+	orphans := make(map[string]int) // spacecraft → index in links
+	orphan := func(sig Signal) *Link {
+		if !sig.Active || claimed(sig) {
+			return nil
+		}
+		name := sig.Spacecraft
+		if !IsRealSpacecraft(name) {
+			name, _ = SpacecraftCodeForNAIF(sig.SpacecraftID)
+		}
+		if name == "" && sig.SpacecraftID == 0 {
+			return nil
+		}
+		key := name + "/" + strconv.Itoa(sig.SpacecraftID)
+		if i, ok := orphans[key]; ok {
+			return &links[i]
+		}
+		link := newLink()
+		link.Spacecraft = name
+		link.SpacecraftID = max(sig.SpacecraftID, -sig.SpacecraftID)
+		link.Inferred = true
+		orphans[key] = len(links)
+		links = append(links, link)
+		return &links[len(links)-1]
+	}
+	for _, sig := range antenna.DownSignals {
+		if link := orphan(sig); link != nil {
+			applyDownSignal(link, sig)
+		}
+	}
+	for _, sig := range antenna.UpSignals {
+		if link := orphan(sig); link != nil {
+			applyUpSignal(link, sig)
+		}
+	}
+
 	return links
 }
 
+// signalMatchesID reports whether sig carries the NAIF ID of target id.
+func signalMatchesID(sig Signal, id int) bool {
+	return id != 0 && (sig.SpacecraftID == -id || sig.SpacecraftID == id)
+}
+
+// hasSignal reports whether any of antenna's signals satisfies match.
+func hasSignal(antenna Antenna, match func(Signal) bool) bool {
+	for _, sig := range antenna.DownSignals {
+		if match(sig) {
+			return true
+		}
+	}
+	for _, sig := range antenna.UpSignals {
+		if match(sig) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyDownSignal folds a downlink signal into link.
+func applyDownSignal(link *Link, sig Signal) {
+	link.SignalType = mergeSignalType(link.SignalType, sig)
+	link.DownRate = sig.DataRate
+	if sig.Active {
+		link.Downlink = true
+	}
+	if sig.Band != "" {
+		link.Band = sig.Band
+	} else if sig.Frequency > 0 {
+		link.Band = inferBand(sig.Frequency)
+	}
+	if sig.DataRate > link.DataRate {
+		link.DataRate = sig.DataRate
+	}
+	if sig.Power != 0 && (sig.Active || link.DownPower == 0) {
+		link.DownPower = sig.Power
+	}
+}
+
+// applyUpSignal folds an uplink signal into link.
+func applyUpSignal(link *Link, sig Signal) {
+	link.SignalType = mergeSignalType(link.SignalType, sig)
+	link.UpRate = sig.DataRate
+	if sig.Active && (sig.SignalType == SignalData || sig.DataRate > 0) {
+		link.Uplink = true
+	}
+	if sig.Power != 0 && (sig.Active || link.UpPower == 0) {
+		link.UpPower = sig.Power
+	}
+	if link.Band == "" {
+		if sig.Band != "" {
+			link.Band = sig.Band
+		} else if sig.Frequency > 0 {
+			link.Band = inferBand(sig.Frequency)
+		}
+	}
+	if sig.DataRate > link.DataRate {
+		link.DataRate = sig.DataRate
+	}
+}
+
+// mergeSignalType folds an active signal into a link's signal type. Any
+// data signal (or nonzero rate) makes it a data link; a link is carrier
+// only when every active signal is.
+func mergeSignalType(current string, sig Signal) string {
+	if !sig.Active {
+		return current
+	}
+	if sig.SignalType == SignalData || sig.DataRate > 0 {
+		return SignalData
+	}
+	if sig.SignalType == SignalCarrier && current == "" {
+		return SignalCarrier
+	}
+	return current
+}
+
 func inferComplex(stationName string) Complex {
 	// DSN station naming: DSSXX or DSS-XX where XX indicates complex
 	// 1x, 2x = Goldstone, 3x, 4x = Canberra, 5x, 6x = Madrid
@@ -1,7 +1,9 @@
 package dsn
 
 import (
+	"io"
 	"testing"
+	"time"
 )
 
 // Realistic DSN XML sample based on actual feed format
@@ -121,6 +123,88 @@ func TestParse_LinkExtraction(t *testing.T) {
 	}
 }
 
+func TestParse_SignalType(t *testing.T) {
+	data, err := Parse([]byte(`<dsn>
+  <dish name="DSS43" elevationAngle="40">
+    <downSignal active="true" signalType="carrier" dataRate="0" band="X" spacecraft="VGR2" spacecraftID="-32"/>
+    <upSignal active="true" signalType="carrier" dataRate="0" band="S" spacecraft="VGR2" spacecraftID="-32"/>
+    <target name="VGR2" id="32" rtlt="150000"/>
+    <downSignal active="true" signalType="data" dataRate="40" band="X" spacecraft="NHPC" spacecraftID="-98"/>
+    <upSignal active="true" signalType="carrier" dataRate="0" band="X" spacecraft="NHPC" spacecraftID="-98"/>
+    <target name="NHPC" id="98" rtlt="60000"/>
+    <downSignal active="false" signalType="none" dataRate="0" band="X" spacecraft="JUNO" spacecraftID="-61"/>
+    <target name="JUNO" id="61" rtlt="5000"/>
+  </dish>
+</dsn>`))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	want := map[string]string{"VGR2": SignalCarrier, "NHPC": SignalData, "JUNO": ""}
+	for _, l := range data.Links {
+		if l.SignalType != want[l.Spacecraft] {
+			t.Errorf("%s SignalType = %q, want %q", l.Spacecraft, l.SignalType, want[l.Spacecraft])
+		}
+		if l.CarrierOnly() != (l.Spacecraft == "VGR2") {
+			t.Errorf("%s CarrierOnly = %v", l.Spacecraft, l.CarrierOnly())
+		}
+	}
+}
+
+func TestParse_Uplink(t *testing.T) {
+	data, err := Parse([]byte(`<dsn>
+  <dish name="DSS43" elevationAngle="40">
+    <downSignal active="true" signalType="data" dataRate="160" band="X" spacecraft="VGR2" spacecraftID="-32"/>
+    <upSignal active="true" signalType="data" dataRate="16" band="S" spacecraft="VGR2" spacecraftID="-32"/>
+    <target name="VGR2" id="32" rtlt="150000"/>
+    <downSignal active="true" signalType="data" dataRate="40" band="X" spacecraft="NHPC" spacecraftID="-98"/>
+    <upSignal active="true" signalType="carrier" dataRate="0" band="X" spacecraft="NHPC" spacecraftID="-98"/>
+    <target name="NHPC" id="98" rtlt="60000"/>
+    <downSignal active="true" signalType="data" dataRate="18000" band="X" spacecraft="JUNO" spacecraftID="-61"/>
+    <upSignal active="false" signalType="data" dataRate="250" band="X" spacecraft="JUNO" spacecraftID="-61"/>
+    <target name="JUNO" id="61" rtlt="5000"/>
+  </dish>
+</dsn>`))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	// Only an active data uplink is commanding; a carrier uplink (ranging,
+	// two-way Doppler) or an inactive one isn't
+	for _, l := range data.Links {
+		if want := l.Spacecraft == "VGR2"; l.Uplink != want {
+			t.Errorf("%s Uplink = %v, want %v", l.Spacecraft, l.Uplink, want)
+		}
+	}
+	for _, l := range data.Links {
+		want := DirectionDown
+		if l.Spacecraft == "VGR2" {
+			want = DirectionBoth
+		}
+		if !l.Downlink || l.Direction() != want {
+			t.Errorf("%s direction = %q (downlink %v), want %q", l.Spacecraft, l.Direction(), l.Downlink, want)
+		}
+	}
+}
+
+func TestParse_Power(t *testing.T) {
+	data, err := Parse([]byte(realisticXML))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	for _, l := range data.Links {
+		if l.Spacecraft != "EMM" {
+			continue
+		}
+		if l.DownPower != -120 || l.UpPower != 18 {
+			t.Errorf("EMM power = %v dBm down, %v kW up; want -120, 18", l.DownPower, l.UpPower)
+		}
+		return
+	}
+	t.Fatal("EMM link not found")
+}
+
 func TestParse_VoyagerLink(t *testing.T) {
 	data, err := Parse([]byte(realisticXML))
 	if err != nil {
@@ -148,6 +232,60 @@ func TestParse_VoyagerLink(t *testing.T) {
 	}
 }
 
+func TestParse_InferredLinks(t *testing.T) {
+	data, err := Parse([]byte(`<dsn>
+  <dish name="DSS14" elevationAngle="40">
+    <downSignal active="true" signalType="data" dataRate="2000000" band="X" spacecraft="M20" spacecraftID="-168"/>
+    <target name="MARS2020" id="168" rtlt="1500"/>
+    <downSignal active="true" signalType="data" dataRate="6000" band="X" spacecraft="" spacecraftID="-74"/>
+    <upSignal active="true" signalType="data" dataRate="2000" band="X" spacecraft="KPLO" spacecraftID="-155"/>
+    <downSignal active="true" signalType="data" dataRate="10" band="S" spacecraft="DSN" spacecraftID=""/>
+    <downSignal active="false" signalType="none" dataRate="0" band="X" spacecraft="JUNO" spacecraftID="-61"/>
+  </dish>
+</dsn>`))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	links := make(map[string]Link)
+	for _, l := range data.Links {
+		links[l.Spacecraft] = l
+	}
+	if len(data.Links) != 3 {
+		t.Fatalf("links = %+v, want MARS2020, MRO, and KPLO", data.Links)
+	}
+	// The target's signal carries another name but the same NAIF ID
+	if l := links["MARS2020"]; !l.Inferred || l.DownRate != 2e6 || l.RTLT != 1500 {
+		t.Errorf("MARS2020 = %+v, want the M20 signal matched by ID", l)
+	}
+	// Signals without targets, named by NAIF ID or by the signal
+	if l := links["MRO"]; !l.Inferred || l.SpacecraftID != 74 || l.DownRate != 6000 || l.RTLT != 0 {
+		t.Errorf("MRO = %+v, want an inferred link from the catalog's NAIF ID", l)
+	}
+	if l := links["KPLO"]; !l.Inferred || !l.Uplink || l.Band != "X" {
+		t.Errorf("KPLO = %+v, want an inferred uplink", l)
+	}
+
+	// A target whose signals match by name is not inferred
+	data, _ = Parse([]byte(realisticXML))
+	for _, l := range data.Links {
+		if l.Inferred {
+			t.Errorf("%s inferred in a feed where everything matches", l.Spacecraft)
+		}
+	}
+}
+
+func TestSpacecraftCodeForNAIF(t *testing.T) {
+	for id, want := range map[int]string{-74: "MRO", 74: "MRO", -202: "MAVEN", -61: "JUNO", -96: "SPP"} {
+		if got, ok := SpacecraftCodeForNAIF(id); !ok || got != want {
+			t.Errorf("SpacecraftCodeForNAIF(%d) = %q, %v; want %q", id, got, ok, want)
+		}
+	}
+	if got, ok := SpacecraftCodeForNAIF(-99999); ok {
+		t.Errorf("unknown ID = %q, want none", got)
+	}
+}
+
 func TestInferComplex(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -221,3 +359,64 @@ func TestParse_TimestampMillis(t *testing.T) {
 		t.Errorf("Timestamp year = %d, expected >= 2025", data.Timestamp.Year())
 	}
 }
+
+func TestParse_NoTimestamp(t *testing.T) {
+	data, err := Parse([]byte(`<dsn>
+  <station name="mdscc" friendlyName="Madrid" timeUTC="1764860570000"/>
+  <station name="gdscc" friendlyName="Goldstone" timeUTC="1764860575000"/>
+</dsn>`))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	// Falls back to the newest station clock, never the wall clock
+	if want := time.UnixMilli(1764860575000); !data.Timestamp.Equal(want) {
+		t.Errorf("Timestamp = %v, want %v", data.Timestamp, want)
+	}
+
+	data, err = Parse([]byte(`<dsn></dsn>`))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if !data.Timestamp.IsZero() {
+		t.Errorf("Timestamp = %v, want zero without any feed time", data.Timestamp)
+	}
+}
+
+// FuzzParse checks that malformed feed data never panics the parser and
+// that successful parses uphold basic invariants. Real-world oddities seen in
+// the feed live in testdata/fuzz/FuzzParse.
+func FuzzParse(f *testing.F) {
+	f.Add([]byte(realisticXML))
+	f.Add([]byte(`<dsn><timestamp>0</timestamp></dsn>`))
+	f.Add([]byte(`not valid xml`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		result, err := Parse(data)
+		if err != nil {
+			if result != nil {
+				t.Errorf("Parse returned data alongside error %v", err)
+			}
+			return
+		}
+		if result == nil {
+			t.Fatal("Parse returned nil data without error")
+		}
+
+		// Downstream consumers must cope with whatever was parsed
+		AssessQuality(result, time.Now())
+		BuildSpacecraftViews(result, BuildElevationMap(result))
+		WriteParseReport(io.Discard, result)
+	})
+}
+
+func BenchmarkParse(b *testing.B) {
+	data := []byte(realisticXML)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		if _, err := Parse(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package dsn
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PassElevations are the minimum elevations, in degrees, at which passes
+// start and end. Some missions can't close their link low on the horizon,
+// and some complexes have terrain or policy limits, so the built-in
+// MinPassElevation can be raised (or lowered) per spacecraft and per
+// complex.
+type PassElevations struct {
+	Default    *float64            // nil uses MinPassElevation
+	Complex    map[Complex]float64 // per complex
+	Spacecraft map[string]float64  // per canonical spacecraft code
+}
+
+// For returns the minimum elevation for a spacecraft at a complex: the
+// higher of its spacecraft and complex limits when both are set, since
+// neither can be tracked below, else whichever is set, else the default.
+func (e PassElevations) For(scCode string, c Complex) float64 {
+	sc, scOK := e.Spacecraft[CanonicalCode(scCode)]
+	cx, cxOK := e.Complex[c]
+	switch {
+	case scOK && cxOK:
+		return max(sc, cx)
+	case scOK:
+		return sc
+	case cxOK:
+		return cx
+	case e.Default != nil:
+		return *e.Default
+	default:
+		return MinPassElevation
+	}
+}
+
+// Validate reports elevations outside 0° to 90°, which no pass could
+// start at, and unknown complexes.
+func (e PassElevations) Validate() error {
+	check := func(what string, el float64) error {
+		if el < 0 || el >= 90 {
+			return fmt.Errorf("minimum pass elevation for %s (%g°) must be from 0° to below 90°", what, el)
+		}
+		return nil
+	}
+	if e.Default != nil {
+		if err := check("all passes", *e.Default); err != nil {
+			return err
+		}
+	}
+	for c, el := range e.Complex {
+		if _, ok := ParseComplex(string(c)); !ok {
+			return fmt.Errorf("minimum pass elevation: unknown complex %q", c)
+		}
+		if err := check(ComplexShortName(c), el); err != nil {
+			return err
+		}
+	}
+	for code, el := range e.Spacecraft {
+		if err := check(code, el); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ParseComplex parses a complex by ID ("gdscc"), short name ("GDS"), or
+// site ("Goldstone"), in any case.
+func ParseComplex(s string) (Complex, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "gdscc", "gds", "goldstone":
+		return ComplexGoldstone, true
+	case "cdscc", "cds", "canberra":
+		return ComplexCanberra, true
+	case "mdscc", "mds", "madrid":
+		return ComplexMadrid, true
+	}
+	return "", false
+}
@@ -0,0 +1,94 @@
+package dsn
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPassElevations_For(t *testing.T) {
+	e := PassElevations{
+		Complex:    map[Complex]float64{ComplexMadrid: 10},
+		Spacecraft: map[string]float64{"JUNO": 15, "VGR1": 8},
+	}
+	tests := []struct {
+		sc      string
+		complex Complex
+		want    float64
+	}{
+		{"MRO", ComplexGoldstone, MinPassElevation},
+		{"MRO", ComplexMadrid, 10},
+		{"JNO", ComplexGoldstone, 15}, // alias of JUNO
+		{"VGR1", ComplexMadrid, 10},   // the complex can't go as low
+		{"JUNO", ComplexMadrid, 15},
+	}
+	for _, tt := range tests {
+		if got := e.For(tt.sc, tt.complex); got != tt.want {
+			t.Errorf("For(%s, %s) = %v, want %v", tt.sc, tt.complex, got, tt.want)
+		}
+	}
+
+	for _, def := range []float64{2, 0} {
+		e.Default = &def
+		if got := e.For("MRO", ComplexCanberra); got != def {
+			t.Errorf("with a default of %g°, For = %v", def, got)
+		}
+	}
+}
+
+func TestPassElevations_Validate(t *testing.T) {
+	if err := (PassElevations{}).Validate(); err != nil {
+		t.Errorf("zero value: %v", err)
+	}
+	below := -1.0
+	for _, e := range []PassElevations{
+		{Default: &below},
+		{Complex: map[Complex]float64{"xdscc": 10}},
+		{Complex: map[Complex]float64{ComplexCanberra: 90}},
+		{Spacecraft: map[string]float64{"VGR1": -5}},
+	} {
+		if e.Validate() == nil {
+			t.Errorf("Validate(%+v) = nil, want an error", e)
+		}
+	}
+	if got := (PassElevations{}).For("VGR1", ComplexCanberra); got != MinPassElevation {
+		t.Errorf("unset elevations = %v, want the default", got)
+	}
+}
+
+func TestComputePassPlan_PerSpacecraftElevation(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	// A fixed point on the celestial equator rises and sets once a day
+	samples := generateSamples(now, 36*time.Hour, 5*time.Minute, func(time.Time) (ra, dec float64) {
+		return 90, 0
+	})
+
+	span := func(code string, c Complex, elev PassElevations) time.Duration {
+		for _, p := range ComputePassPlan(code, elev, samples, now).GetPassesForComplex(c) {
+			if p.Start.After(now) && p.End.Before(now.Add(36*time.Hour)) {
+				return p.End.Sub(p.Start)
+			}
+		}
+		t.Fatalf("%s: no complete pass over %s", code, c)
+		return 0
+	}
+	before := span("JUNO", ComplexGoldstone, PassElevations{})
+
+	elev := PassElevations{Spacecraft: map[string]float64{"JUNO": 20}}
+	if after := span("JUNO", ComplexGoldstone, elev); after >= before-time.Hour {
+		t.Errorf("pass above 20° lasts %v, want well under the %v above 5°", after, before)
+	}
+	if other := span("MRO", ComplexGoldstone, elev); other != before {
+		t.Errorf("another spacecraft's pass changed: %v, want %v", other, before)
+	}
+}
+
+func TestParseComplex(t *testing.T) {
+	for s, want := range map[string]Complex{"gdscc": ComplexGoldstone, "CDS": ComplexCanberra, " Madrid ": ComplexMadrid} {
+		if got, ok := ParseComplex(s); !ok || got != want {
+			t.Errorf("ParseComplex(%q) = %q, %v", s, got, ok)
+		}
+	}
+	if _, ok := ParseComplex("usuda"); ok {
+		t.Error("ParseComplex(usuda) succeeded")
+	}
+}
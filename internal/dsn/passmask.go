@@ -0,0 +1,70 @@
+package dsn
+
+// Geometric passes (PassElevations) say when a spacecraft is above the
+// horizon; whether an antenna can actually track it depends on the dish's
+// limits. The minimums are planning floors, not mechanical stops: the 34m
+// dishes can point down to about 6° (DSN 810-005) but get a conservative
+// 10° here, and the 70m keeps 6°. Az-el mounts also lose lock briefly
+// near zenith where azimuth rates blow up.
+
+// ElevationMask is an antenna's trackable elevation range (degrees).
+type ElevationMask struct {
+	MinElDeg float64
+	MaxElDeg float64
+}
+
+// Elevation masks per antenna type.
+var (
+	Mask70m = ElevationMask{MinElDeg: 6.0, MaxElDeg: 88.0}  // DSS-14/43/63
+	Mask34m = ElevationMask{MinElDeg: 10.0, MaxElDeg: 89.0} // BWG and HEF
+)
+
+// MarginalPassMarginDeg is how far above the minimum elevation a pass must
+// peak to be considered comfortably trackable.
+const MarginalPassMarginDeg = 3.0
+
+// ElevationMaskFor returns the elevation mask for an antenna ID. Unknown
+// or empty IDs get the 34m mask, the most common assignment.
+func ElevationMaskFor(antennaID string) ElevationMask {
+	if site, ok := GetAntennaSite(antennaID); ok && site.Diameter >= 70 {
+		return Mask70m
+	}
+	return Mask34m
+}
+
+// PassFeasibility classifies whether a geometric pass is trackable.
+type PassFeasibility int
+
+const (
+	PassTrackable   PassFeasibility = iota // Clears the mask with margin
+	PassMarginal                           // Peaks just above the mask, or crosses the zenith keyhole
+	PassUntrackable                        // Never rises above the mask
+)
+
+// String returns the feasibility name.
+func (f PassFeasibility) String() string {
+	switch f {
+	case PassTrackable:
+		return "OK"
+	case PassMarginal:
+		return "MARGINAL"
+	case PassUntrackable:
+		return "NO"
+	default:
+		return "?"
+	}
+}
+
+// Feasibility classifies the pass against an antenna's elevation mask.
+func (p Pass) Feasibility(mask ElevationMask) PassFeasibility {
+	switch {
+	case p.MaxElDeg < mask.MinElDeg:
+		return PassUntrackable
+	case p.MaxElDeg < mask.MinElDeg+MarginalPassMarginDeg:
+		return PassMarginal
+	case p.MaxElDeg > mask.MaxElDeg:
+		return PassMarginal
+	default:
+		return PassTrackable
+	}
+}
@@ -0,0 +1,47 @@
+package dsn
+
+import "testing"
+
+func TestElevationMaskFor(t *testing.T) {
+	tests := []struct {
+		antenna string
+		want    ElevationMask
+	}{
+		{"DSS14", Mask70m},
+		{"DSS-43", Mask70m},
+		{"DSS63", Mask70m},
+		{"DSS24", Mask34m},
+		{"DSS-55", Mask34m},
+		{"", Mask34m},
+		{"DSS99", Mask34m},
+	}
+	for _, tt := range tests {
+		if got := ElevationMaskFor(tt.antenna); got != tt.want {
+			t.Errorf("ElevationMaskFor(%q) = %+v, want %+v", tt.antenna, got, tt.want)
+		}
+	}
+}
+
+func TestPass_Feasibility(t *testing.T) {
+	tests := []struct {
+		name  string
+		maxEl float64
+		mask  ElevationMask
+		want  PassFeasibility
+	}{
+		{"high pass", 45, Mask34m, PassTrackable},
+		{"below 34m mask", 8, Mask34m, PassUntrackable},
+		{"trackable by 70m only", 8, Mask70m, PassMarginal},
+		{"just above 34m mask", 11, Mask34m, PassMarginal},
+		{"clears 70m mask", 9.5, Mask70m, PassTrackable},
+		{"zenith keyhole", 88.5, Mask70m, PassMarginal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := Pass{MaxElDeg: tt.maxEl}
+			if got := p.Feasibility(tt.mask); got != tt.want {
+				t.Errorf("Feasibility() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package dsn
 
 import (
+	"fmt"
 	"sort"
 	"time"
 
@@ -51,9 +52,11 @@ type PassPlan struct {
 	WindowStart    time.Time
 	WindowEnd      time.Time
 	Passes         []Pass
+	MinElevations  PassElevations // thresholds the passes were found with
 }
 
-// MinPassElevation is the threshold for pass start/end (degrees).
+// MinPassElevation is the default threshold for pass start/end (degrees);
+// see PassElevations for per-spacecraft and per-complex thresholds.
 const MinPassElevation = 5.0
 
 // PassSampleInterval is the time between elevation samples.
@@ -62,10 +65,40 @@ const PassSampleInterval = 5 * time.Minute
 // PassWindowDuration is the default forecast window.
 const PassWindowDuration = 24 * time.Hour
 
-// ComputePassPlan computes passes for a spacecraft over the given time window.
-// Takes pre-computed RA/Dec samples (from ephem.Provider.GetPath or similar).
+// MaxPassWindowDuration is the longest forecast window a pass plan covers.
+const MaxPassWindowDuration = 7 * 24 * time.Hour
+
+// PassWindow is how far ahead a pass plan looks and how finely it samples
+// the spacecraft's position.
+type PassWindow struct {
+	Span time.Duration
+	Step time.Duration
+}
+
+// DefaultPassWindow is a PassWindowDuration plan sampled every
+// PassSampleInterval.
+func DefaultPassWindow() PassWindow {
+	return PassWindow{Span: PassWindowDuration, Step: PassSampleInterval}
+}
+
+// Validate checks that the window is 1h to MaxPassWindowDuration long and
+// the step a whole number of minutes up to an hour, as Horizons takes it.
+func (w PassWindow) Validate() error {
+	switch {
+	case w.Span < time.Hour || w.Span > MaxPassWindowDuration:
+		return fmt.Errorf("pass window %s: want 1h to %s", w.Span, MaxPassWindowDuration)
+	case w.Step < time.Minute || w.Step > time.Hour || w.Step%time.Minute != 0:
+		return fmt.Errorf("pass step %s: want whole minutes from 1m to 1h", w.Step)
+	}
+	return nil
+}
+
+// ComputePassPlan computes passes for a spacecraft over the given time window,
+// above its minimum elevations in elev. Takes pre-computed RA/Dec samples
+// (from ephem.Provider.GetPath or similar).
 func ComputePassPlan(
 	scCode string,
+	elev PassElevations,
 	samples []astro.RADecAtTime,
 	now time.Time,
 ) *PassPlan {
@@ -75,9 +108,11 @@ func ComputePassPlan(
 			SpacecraftCode: scCode,
 			GeneratedAt:    now,
 			Passes:         nil,
+			MinElevations:  elev,
 		}
 	}
 
+	samples = clampPassWindow(samples)
 	windowStart := samples[0].Time
 	windowEnd := samples[len(samples)-1].Time
 
@@ -86,7 +121,7 @@ func ComputePassPlan(
 	complexes := []Complex{ComplexGoldstone, ComplexCanberra, ComplexMadrid}
 
 	for _, c := range complexes {
-		passes := computePassesForComplex(c, samples, now)
+		passes := computePassesForComplex(scCode, c, elev, samples, now)
 		allPasses = append(allPasses, passes...)
 	}
 
@@ -104,12 +139,111 @@ func ComputePassPlan(
 		WindowStart:    windowStart,
 		WindowEnd:      windowEnd,
 		Passes:         allPasses,
+		MinElevations:  elev,
 	}
 }
 
-// computePassesForComplex finds all passes for a single complex.
-func computePassesForComplex(complex Complex, samples []astro.RADecAtTime, now time.Time) []Pass {
-	obs := ObserverForComplex(complex)
+// clampPassWindow drops samples more than MaxPassWindowDuration after
+// the first.
+func clampPassWindow(samples []astro.RADecAtTime) []astro.RADecAtTime {
+	limit := samples[0].Time.Add(MaxPassWindowDuration)
+	n := sort.Search(len(samples), func(i int) bool { return samples[i].Time.After(limit) })
+	return samples[:n]
+}
+
+// ReusableSamples returns the cached samples, taken every step, that still
+// fall in a window starting at now (plus one earlier sample for crossing
+// interpolation), and the time from which new samples must be fetched.
+// With nothing reusable the whole window is needed and from is now.
+func ReusableSamples(cached []astro.RADecAtTime, now time.Time, step time.Duration) (kept []astro.RADecAtTime, from time.Time) {
+	cutoff := now.Add(-step)
+	for i, s := range cached {
+		if !s.Time.Before(cutoff) {
+			kept = cached[i:]
+			break
+		}
+	}
+	if len(kept) == 0 {
+		return nil, now
+	}
+	return kept, kept[len(kept)-1].Time.Add(step)
+}
+
+// ExtendPassPlan recomputes a plan after its window has slid forward.
+// samples must be prev's samples with the head trimmed and a new tail
+// appended (see ReusableSamples). Passes that closed before prev's window
+// end are kept as-is; each complex is only re-scanned from its pass still
+// open at the old window end, or from the old window end itself.
+func ExtendPassPlan(
+	scCode string,
+	elev PassElevations,
+	prev *PassPlan,
+	samples []astro.RADecAtTime,
+	now time.Time,
+) *PassPlan {
+	if prev == nil || prev.WindowEnd.IsZero() || len(samples) < 3 {
+		return ComputePassPlan(scCode, elev, samples, now)
+	}
+
+	samples = clampPassWindow(samples)
+	windowStart := samples[0].Time
+	windowEnd := samples[len(samples)-1].Time
+
+	var allPasses []Pass
+	for _, c := range []Complex{ComplexGoldstone, ComplexCanberra, ComplexMadrid} {
+		resume := prev.WindowEnd
+		open := false
+		for _, p := range prev.Passes {
+			if p.Complex != c {
+				continue
+			}
+			if !p.End.Before(prev.WindowEnd) {
+				// Truncated by the old window: recompute in full
+				resume = p.Start
+				open = true
+				continue
+			}
+			if !p.End.Before(windowStart) {
+				allPasses = append(allPasses, p)
+			}
+		}
+
+		from := sort.Search(len(samples), func(i int) bool {
+			return !samples[i].Time.Before(resume)
+		})
+		if open && from > 0 {
+			from-- // include the sub-threshold sample before the rise
+		}
+		if from < len(samples) {
+			allPasses = append(allPasses, computePassesForComplex(scCode, c, elev, samples[from:], now)...)
+		}
+	}
+
+	sort.Slice(allPasses, func(i, j int) bool {
+		return allPasses[i].Start.Before(allPasses[j].Start)
+	})
+	classifyPasses(allPasses, now)
+
+	return &PassPlan{
+		SpacecraftCode: scCode,
+		GeneratedAt:    now,
+		WindowStart:    windowStart,
+		WindowEnd:      windowEnd,
+		Passes:         allPasses,
+		MinElevations:  elev,
+	}
+}
+
+// computePassesForComplex finds all of a spacecraft's passes over a
+// single complex, above its minimum elevation there.
+func computePassesForComplex(scCode string, complex Complex, elev PassElevations, samples []astro.RADecAtTime, now time.Time) []Pass {
+	return computePasses(complex, ObserverForComplex(complex), elev.For(scCode, complex), samples, now)
+}
+
+// computePasses finds all intervals where the target is at or above
+// minEl as seen by obs. Passes are labelled with complex, which may be
+// empty for observers that aren't DSN sites.
+func computePasses(complex Complex, obs astro.Observer, minEl float64, samples []astro.RADecAtTime, now time.Time) []Pass {
 
 	// Convert samples to elevation series
 	type elSample struct {
@@ -131,7 +265,7 @@ func computePassesForComplex(complex Complex, samples []astro.RADecAtTime, now t
 		}
 	}
 
-	// Find passes: contiguous intervals where elevation >= MinPassElevation
+	// Find passes: contiguous intervals where elevation >= minEl
 	var passes []Pass
 	inPass := false
 	var passStart time.Time
@@ -141,7 +275,7 @@ func computePassesForComplex(complex Complex, samples []astro.RADecAtTime, now t
 
 	for i := 0; i < len(elSamples); i++ {
 		curr := elSamples[i]
-		aboveThreshold := curr.elDeg >= MinPassElevation
+		aboveThreshold := curr.elDeg >= minEl
 
 		if !inPass && aboveThreshold {
 			// Pass starts
@@ -154,8 +288,8 @@ func computePassesForComplex(complex Complex, samples []astro.RADecAtTime, now t
 			// Interpolate actual crossing if we have a previous sample
 			if i > 0 {
 				prev := elSamples[i-1]
-				if prev.elDeg < MinPassElevation {
-					passStart = interpolateCrossing(prev.t, curr.t, prev.elDeg, curr.elDeg, MinPassElevation)
+				if prev.elDeg < minEl {
+					passStart = interpolateCrossing(prev.t, curr.t, prev.elDeg, curr.elDeg, minEl)
 				}
 			}
 		}
@@ -179,7 +313,7 @@ func computePassesForComplex(complex Complex, samples []astro.RADecAtTime, now t
 				passEnd := curr.t
 				if i > 0 {
 					prev := elSamples[i-1]
-					passEnd = interpolateCrossing(prev.t, curr.t, prev.elDeg, curr.elDeg, MinPassElevation)
+					passEnd = interpolateCrossing(prev.t, curr.t, prev.elDeg, curr.elDeg, minEl)
 				}
 
 				passes = append(passes, Pass{
@@ -277,6 +411,39 @@ func (p *PassPlan) GetNextPass() *Pass {
 	return nil
 }
 
+// PassesStartingBetween returns the passes that begin after from and no
+// later than to. Passes already in progress at from are not included.
+func (p *PassPlan) PassesStartingBetween(from, to time.Time) []Pass {
+	var result []Pass
+	for _, pass := range p.Passes {
+		if pass.Start.After(from) && !pass.Start.After(to) {
+			result = append(result, pass)
+		}
+	}
+	return result
+}
+
+// PassDay is the passes starting on one calendar day.
+type PassDay struct {
+	Date   time.Time // midnight starting the day
+	Passes []Pass
+}
+
+// Days groups the plan's passes by the calendar day in loc they start on,
+// in order. Days without passes are left out.
+func (p *PassPlan) Days(loc *time.Location) []PassDay {
+	var days []PassDay
+	for _, pass := range p.Passes {
+		y, m, d := pass.Start.In(loc).Date()
+		date := time.Date(y, m, d, 0, 0, 0, 0, loc)
+		if n := len(days); n == 0 || !days[n-1].Date.Equal(date) {
+			days = append(days, PassDay{Date: date})
+		}
+		days[len(days)-1].Passes = append(days[len(days)-1].Passes, pass)
+	}
+	return days
+}
+
 // ComplexShortName returns the short display name for a complex.
 func ComplexShortName(c Complex) string {
 	switch c {
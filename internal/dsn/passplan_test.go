@@ -54,7 +54,7 @@ func TestComputePassPlan_SinglePass(t *testing.T) {
 	}
 
 	samples := generateSamples(now, 24*time.Hour, 5*time.Minute, raDecFunc)
-	plan := ComputePassPlan("TEST", samples, now)
+	plan := ComputePassPlan("TEST", PassElevations{}, samples, now)
 
 	if plan == nil {
 		t.Fatal("expected non-nil plan")
@@ -86,7 +86,7 @@ func TestComputePassPlan_InsufficientSamples(t *testing.T) {
 		{Time: now.Add(time.Hour), RAdeg: 15, DecDeg: 0},
 	}
 
-	plan := ComputePassPlan("TEST", samples, now)
+	plan := ComputePassPlan("TEST", PassElevations{}, samples, now)
 
 	if plan == nil {
 		t.Fatal("expected non-nil plan")
@@ -106,7 +106,7 @@ func TestComputePassPlan_NoPasses(t *testing.T) {
 	}
 
 	samples := generateSamples(now, 24*time.Hour, 5*time.Minute, raDecFunc)
-	plan := ComputePassPlan("TEST", samples, now)
+	plan := ComputePassPlan("TEST", PassElevations{}, samples, now)
 
 	if plan == nil {
 		t.Fatal("expected non-nil plan")
@@ -137,7 +137,7 @@ func TestComputePassPlan_ClassificationNow(t *testing.T) {
 	}
 
 	samples := generateSamples(now, 24*time.Hour, 5*time.Minute, raDecFunc)
-	plan := ComputePassPlan("TEST", samples, now)
+	plan := ComputePassPlan("TEST", PassElevations{}, samples, now)
 
 	// Should have at least one pass
 	if len(plan.Passes) == 0 {
@@ -166,7 +166,7 @@ func TestComputePassPlan_ClassificationNext(t *testing.T) {
 	}
 
 	samples := generateSamples(now, 24*time.Hour, 5*time.Minute, raDecFunc)
-	plan := ComputePassPlan("TEST", samples, now)
+	plan := ComputePassPlan("TEST", PassElevations{}, samples, now)
 
 	// Count classification types
 	nextCount := 0
@@ -191,7 +191,7 @@ func TestComputePassPlan_SunSeparation(t *testing.T) {
 	}
 
 	samples := generateSamples(now, 24*time.Hour, 5*time.Minute, raDecFunc)
-	plan := ComputePassPlan("TEST", samples, now)
+	plan := ComputePassPlan("TEST", PassElevations{}, samples, now)
 
 	for _, p := range plan.Passes {
 		// Sun separation should be a valid angle
@@ -212,7 +212,7 @@ func TestComputePassPlan_MaxElevation(t *testing.T) {
 	}
 
 	samples := generateSamples(now, 24*time.Hour, 5*time.Minute, raDecFunc)
-	plan := ComputePassPlan("TEST", samples, now)
+	plan := ComputePassPlan("TEST", PassElevations{}, samples, now)
 
 	for _, p := range plan.Passes {
 		// Max elevation should be >= MinPassElevation (5°)
@@ -238,7 +238,7 @@ func TestGetPassesForComplex(t *testing.T) {
 	}
 
 	samples := generateSamples(now, 24*time.Hour, 5*time.Minute, raDecFunc)
-	plan := ComputePassPlan("TEST", samples, now)
+	plan := ComputePassPlan("TEST", PassElevations{}, samples, now)
 
 	gdsPasses := plan.GetPassesForComplex(ComplexGoldstone)
 	for _, p := range gdsPasses {
@@ -248,6 +248,24 @@ func TestGetPassesForComplex(t *testing.T) {
 	}
 }
 
+func TestPassesStartingBetween(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	plan := &PassPlan{Passes: []Pass{
+		{Complex: ComplexGoldstone, Start: now.Add(-time.Hour)},
+		{Complex: ComplexCanberra, Start: now.Add(20 * time.Minute)},
+		{Complex: ComplexMadrid, Start: now.Add(30 * time.Minute)},
+		{Complex: ComplexGoldstone, Start: now.Add(31 * time.Minute)},
+	}}
+
+	got := plan.PassesStartingBetween(now, now.Add(30*time.Minute))
+	if len(got) != 2 || got[0].Complex != ComplexCanberra || got[1].Complex != ComplexMadrid {
+		t.Errorf("PassesStartingBetween = %+v, want the Canberra and Madrid passes", got)
+	}
+	if got := plan.PassesStartingBetween(now, now.Add(10*time.Minute)); len(got) != 0 {
+		t.Errorf("no pass starts in the first 10 minutes, got %+v", got)
+	}
+}
+
 func TestInterpolateCrossing(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -434,3 +452,144 @@ func TestPassPlanHelpers_NilCases(t *testing.T) {
 		t.Error("GetNextPass should return nil when no NEXT pass")
 	}
 }
+
+func TestReusableSamples(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	fixed := func(time.Time) (float64, float64) { return 100, 10 }
+	cached := generateSamples(start, 24*time.Hour, PassSampleInterval, fixed)
+
+	now := start.Add(3 * time.Hour)
+	kept, from := ReusableSamples(cached, now, PassSampleInterval)
+	if len(kept) == 0 {
+		t.Fatal("expected overlapping samples to be kept")
+	}
+	if want := now.Add(-PassSampleInterval); !kept[0].Time.Equal(want) {
+		t.Errorf("first kept sample = %v, want %v", kept[0].Time, want)
+	}
+	if want := start.Add(24*time.Hour + PassSampleInterval); !from.Equal(want) {
+		t.Errorf("fetch from = %v, want %v", from, want)
+	}
+
+	// Window slid past all cached samples
+	kept, from = ReusableSamples(cached, start.Add(48*time.Hour), PassSampleInterval)
+	if kept != nil || !from.Equal(start.Add(48*time.Hour)) {
+		t.Errorf("ReusableSamples after expiry = (%d samples, %v), want (0, now)", len(kept), from)
+	}
+}
+
+func TestExtendPassPlan_MatchesFullRecompute(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	fixed := func(time.Time) (float64, float64) { return 100, 10 }
+
+	prevSamples := generateSamples(start, PassWindowDuration, PassSampleInterval, fixed)
+	prev := ComputePassPlan("TEST", PassElevations{}, prevSamples, start)
+
+	for _, slide := range []time.Duration{10 * time.Minute, 3 * time.Hour, 11 * time.Hour} {
+		now := start.Add(slide)
+		kept, from := ReusableSamples(prevSamples, now, PassSampleInterval)
+		tail := generateSamples(from, now.Add(PassWindowDuration).Sub(from), PassSampleInterval, fixed)
+		samples := append(kept[:len(kept):len(kept)], tail...)
+
+		got := ExtendPassPlan("TEST", PassElevations{}, prev, samples, now)
+		want := ComputePassPlan("TEST", PassElevations{}, samples, now)
+
+		// Passes already in progress at the new window start keep their
+		// original start in the incremental plan; compare the rest.
+		filter := func(passes []Pass) []Pass {
+			var out []Pass
+			for _, p := range passes {
+				if p.Start.After(samples[0].Time) {
+					out = append(out, p)
+				}
+			}
+			return out
+		}
+		g, w := filter(got.Passes), filter(want.Passes)
+		if len(g) != len(w) {
+			t.Fatalf("slide %v: %d passes, want %d", slide, len(g), len(w))
+		}
+		for i := range g {
+			if g[i].Complex != w[i].Complex || !g[i].Start.Equal(w[i].Start) || !g[i].End.Equal(w[i].End) || g[i].Status != w[i].Status {
+				t.Errorf("slide %v pass %d = %+v, want %+v", slide, i, g[i], w[i])
+			}
+		}
+		if !got.WindowEnd.Equal(want.WindowEnd) {
+			t.Errorf("slide %v: WindowEnd = %v, want %v", slide, got.WindowEnd, want.WindowEnd)
+		}
+	}
+}
+
+func TestExtendPassPlan_NoPrevious(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	samples := generateSamples(now, PassWindowDuration, PassSampleInterval, func(time.Time) (float64, float64) { return 100, 10 })
+
+	got := ExtendPassPlan("TEST", PassElevations{}, nil, samples, now)
+	want := ComputePassPlan("TEST", PassElevations{}, samples, now)
+	if len(got.Passes) != len(want.Passes) {
+		t.Errorf("passes = %d, want %d", len(got.Passes), len(want.Passes))
+	}
+}
+
+func BenchmarkComputePassPlan(b *testing.B) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	samples := generateSamples(now, PassWindowDuration, PassSampleInterval, func(time.Time) (float64, float64) { return 100, 10 })
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ComputePassPlan("TEST", PassElevations{}, samples, now)
+	}
+}
+
+func TestPassWindow_Validate(t *testing.T) {
+	for _, w := range []PassWindow{
+		DefaultPassWindow(),
+		{Span: MaxPassWindowDuration, Step: time.Hour},
+		{Span: time.Hour, Step: time.Minute},
+	} {
+		if err := w.Validate(); err != nil {
+			t.Errorf("%+v: %v", w, err)
+		}
+	}
+	for _, w := range []PassWindow{
+		{Span: MaxPassWindowDuration + time.Hour, Step: PassSampleInterval},
+		{Span: 30 * time.Minute, Step: PassSampleInterval},
+		{Span: PassWindowDuration, Step: 90 * time.Second},
+		{Span: PassWindowDuration, Step: 2 * time.Hour},
+	} {
+		if err := w.Validate(); err == nil {
+			t.Errorf("%+v validated", w)
+		}
+	}
+}
+
+func TestComputePassPlan_Week(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	fixed := func(time.Time) (float64, float64) { return 100, 10 }
+	// Eight days of samples: the plan stops at seven
+	samples := generateSamples(start, 8*24*time.Hour, 10*time.Minute, fixed)
+
+	plan := ComputePassPlan("TEST", PassElevations{}, samples, start)
+	if want := start.Add(MaxPassWindowDuration); !plan.WindowEnd.Equal(want) {
+		t.Errorf("WindowEnd = %v, want %v", plan.WindowEnd, want)
+	}
+
+	days := plan.Days(time.UTC)
+	if len(days) != 7 {
+		t.Fatalf("%d days, want 7", len(days))
+	}
+	total := 0
+	for i, d := range days {
+		if want := start.AddDate(0, 0, i); !d.Date.Equal(want) {
+			t.Errorf("day %d = %v, want %v", i, d.Date, want)
+		}
+		for _, p := range d.Passes {
+			if p.Start.Before(d.Date) || !p.Start.Before(d.Date.AddDate(0, 0, 1)) {
+				t.Errorf("pass starting %v grouped under %v", p.Start, d.Date)
+			}
+		}
+		total += len(d.Passes)
+	}
+	if total != len(plan.Passes) {
+		t.Errorf("days hold %d passes, plan has %d", total, len(plan.Passes))
+	}
+}
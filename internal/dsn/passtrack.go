@@ -0,0 +1,73 @@
+package dsn
+
+import (
+	"math"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/astro"
+)
+
+// PassTrackPoint is where a spacecraft sits in a complex's sky at one
+// moment of a pass.
+type PassTrackPoint struct {
+	Time  time.Time
+	AzDeg float64 // 0 = north, 90 = east
+	ElDeg float64
+}
+
+// PassTrack returns the path of p across its complex's sky, from the
+// RA/Dec samples its plan was computed from: the rise at p.Start, each
+// sample during the pass, and the set at p.End. Rise and set are
+// interpolated between the samples either side, as the pass times are.
+// It returns nil if the samples don't cover the pass.
+func PassTrack(p Pass, samples []astro.RADecAtTime) []PassTrackPoint {
+	obs := ObserverForComplex(p.Complex)
+	var track []PassTrackPoint
+	var prev PassTrackPoint
+	for i, s := range samples {
+		h := astro.EquatorialToHorizontal(astro.SkyCoord{RAdeg: s.RAdeg, DecDeg: s.DecDeg}, obs, s.Time)
+		pt := PassTrackPoint{Time: s.Time, AzDeg: h.AzDeg, ElDeg: h.ElDeg}
+		switch {
+		case s.Time.Before(p.Start):
+		case !s.Time.After(p.End):
+			if len(track) == 0 && i > 0 && s.Time.After(p.Start) {
+				track = append(track, interpolateTrack(prev, pt, p.Start))
+			}
+			track = append(track, pt)
+		default:
+			if len(track) > 0 && prev.Time.Before(p.End) {
+				track = append(track, interpolateTrack(prev, pt, p.End))
+			}
+			return track
+		}
+		prev = pt
+	}
+	return track
+}
+
+// TrackAt returns the position along track at t, and false if t is
+// outside it.
+func TrackAt(track []PassTrackPoint, t time.Time) (PassTrackPoint, bool) {
+	for i := 1; i < len(track); i++ {
+		if !t.Before(track[i-1].Time) && !t.After(track[i].Time) {
+			return interpolateTrack(track[i-1], track[i], t), true
+		}
+	}
+	return PassTrackPoint{}, false
+}
+
+// interpolateTrack returns the point at t between a and b, taking the
+// short way round in azimuth.
+func interpolateTrack(a, b PassTrackPoint, t time.Time) PassTrackPoint {
+	span := b.Time.Sub(a.Time)
+	if span <= 0 {
+		return a
+	}
+	f := float64(t.Sub(a.Time)) / float64(span)
+	dAz := math.Mod(b.AzDeg-a.AzDeg+540, 360) - 180
+	return PassTrackPoint{
+		Time:  t,
+		AzDeg: math.Mod(a.AzDeg+f*dAz+360, 360),
+		ElDeg: a.ElDeg + f*(b.ElDeg-a.ElDeg),
+	}
+}
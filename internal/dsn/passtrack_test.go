@@ -0,0 +1,84 @@
+package dsn
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestPassTrack(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	// A fixed point north of the equator rises in the east and sets in
+	// the west at every complex
+	samples := generateSamples(now, 24*time.Hour, PassSampleInterval, func(time.Time) (float64, float64) { return 100, 20 })
+	plan := ComputePassPlan("TEST", PassElevations{}, samples, now)
+	passes := plan.GetPassesForComplex(ComplexGoldstone)
+	var p Pass
+	for _, cand := range passes {
+		if cand.Start.After(now) && cand.End.Before(plan.WindowEnd) {
+			p = cand
+			break
+		}
+	}
+	if p.Start.IsZero() {
+		t.Fatal("no complete Goldstone pass in the window")
+	}
+
+	track := PassTrack(p, samples)
+	if len(track) < 3 {
+		t.Fatalf("track has %d points", len(track))
+	}
+	rise, set := track[0], track[len(track)-1]
+	if !rise.Time.Equal(p.Start) || !set.Time.Equal(p.End) {
+		t.Errorf("track runs %v–%v, want the pass %v–%v", rise.Time, set.Time, p.Start, p.End)
+	}
+	if math.Abs(rise.ElDeg-MinPassElevation) > 0.5 || math.Abs(set.ElDeg-MinPassElevation) > 0.5 {
+		t.Errorf("rise/set elevations %.2f/%.2f, want about %.0f", rise.ElDeg, set.ElDeg, MinPassElevation)
+	}
+	if rise.AzDeg <= 0 || rise.AzDeg >= 180 || set.AzDeg <= 180 {
+		t.Errorf("rises at az %.0f and sets at az %.0f, want east then west", rise.AzDeg, set.AzDeg)
+	}
+	peak := 0.0
+	for _, pt := range track {
+		peak = math.Max(peak, pt.ElDeg)
+	}
+	if math.Abs(peak-p.MaxElDeg) > 0.01 {
+		t.Errorf("track peaks at %.2f°, pass at %.2f°", peak, p.MaxElDeg)
+	}
+
+	if got := PassTrack(p, samples[:1]); got != nil {
+		t.Errorf("samples before the pass gave %d points", len(got))
+	}
+}
+
+func TestInterpolateTrack_WrapsAzimuth(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	a := PassTrackPoint{Time: t0, AzDeg: 350, ElDeg: 10}
+	b := PassTrackPoint{Time: t0.Add(time.Minute), AzDeg: 10, ElDeg: 20}
+	got := interpolateTrack(a, b, t0.Add(30*time.Second))
+	if math.Abs(got.AzDeg) > 1e-9 && math.Abs(got.AzDeg-360) > 1e-9 {
+		t.Errorf("az = %v, want 0 (through north)", got.AzDeg)
+	}
+	if got.ElDeg != 15 {
+		t.Errorf("el = %v, want 15", got.ElDeg)
+	}
+}
+
+func TestTrackAt(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	track := []PassTrackPoint{
+		{Time: t0, AzDeg: 90, ElDeg: 6},
+		{Time: t0.Add(time.Hour), AzDeg: 120, ElDeg: 30},
+		{Time: t0.Add(2 * time.Hour), AzDeg: 180, ElDeg: 50},
+	}
+	got, ok := TrackAt(track, t0.Add(90*time.Minute))
+	if !ok || got.AzDeg != 150 || got.ElDeg != 40 {
+		t.Errorf("TrackAt mid-segment = %+v, %v; want az 150 el 40", got, ok)
+	}
+	if _, ok := TrackAt(track, t0.Add(-time.Minute)); ok {
+		t.Error("before the track should be out of it")
+	}
+	if _, ok := TrackAt(track, t0.Add(3*time.Hour)); ok {
+		t.Error("after the track should be out of it")
+	}
+}
@@ -0,0 +1,143 @@
+package dsn
+
+import (
+	"fmt"
+	"time"
+)
+
+// QualityCategory classifies a data quality issue found in a feed snapshot.
+type QualityCategory string
+
+const (
+	QualityParseError      QualityCategory = "PARSE_ERROR"      // Value could not be parsed
+	QualityMissingField    QualityCategory = "MISSING_FIELD"    // Required field absent or empty
+	QualityImpossibleValue QualityCategory = "IMPOSSIBLE_VALUE" // Value outside its physical range
+)
+
+// QualityCategories lists categories in display order.
+var QualityCategories = []QualityCategory{
+	QualityParseError,
+	QualityMissingField,
+	QualityImpossibleValue,
+}
+
+// QualityIssue describes a single problem found in the feed.
+type QualityIssue struct {
+	Category QualityCategory
+	Subject  string // Antenna, station, or spacecraft the issue relates to
+	Detail   string
+}
+
+// QualityReport summarizes feed quality for one fetch.
+type QualityReport struct {
+	AssessedAt  time.Time
+	FeedLatency time.Duration // Fetch time minus feed timestamp
+	Issues      []QualityIssue
+}
+
+// Count returns the number of issues in a category.
+func (r QualityReport) Count(c QualityCategory) int {
+	n := 0
+	for _, issue := range r.Issues {
+		if issue.Category == c {
+			n++
+		}
+	}
+	return n
+}
+
+// Total returns the total number of issues.
+func (r QualityReport) Total() int {
+	return len(r.Issues)
+}
+
+// AssessQuality inspects parsed DSN data for problems that the parser
+// tolerates silently: parse warnings, missing identifiers, out-of-range
+// values and zero timestamps. fetchedAt is used to compute feed latency.
+func AssessQuality(data *DSNData, fetchedAt time.Time) QualityReport {
+	report := QualityReport{AssessedAt: fetchedAt}
+	if data == nil {
+		return report
+	}
+
+	add := func(c QualityCategory, subject, format string, args ...interface{}) {
+		report.Issues = append(report.Issues, QualityIssue{
+			Category: c,
+			Subject:  subject,
+			Detail:   fmt.Sprintf(format, args...),
+		})
+	}
+
+	// Parse warnings collected by Parse
+	for _, e := range data.Errors {
+		add(QualityParseError, "feed", "%s", e)
+	}
+
+	if data.Timestamp.IsZero() {
+		add(QualityMissingField, "feed", "zero feed timestamp")
+	} else {
+		report.FeedLatency = fetchedAt.Sub(data.Timestamp)
+	}
+
+	for _, station := range data.Stations {
+		subject := station.Name
+		if subject == "" {
+			subject = "station"
+			add(QualityMissingField, subject, "station without name")
+		}
+		if station.TimeUTC.IsZero() {
+			add(QualityMissingField, subject, "zero station timeUTC")
+		}
+
+		for _, ant := range station.Antennas {
+			if ant.ID == "" {
+				add(QualityMissingField, subject, "antenna without name")
+				continue
+			}
+			if ant.Azimuth < 0 || ant.Azimuth > 360 {
+				add(QualityImpossibleValue, ant.ID, "azimuth %.1f° outside 0-360", ant.Azimuth)
+			}
+			if ant.Elevation > 90 {
+				add(QualityImpossibleValue, ant.ID, "elevation %.1f° above zenith", ant.Elevation)
+			}
+			// Stowed antennas can legitimately point below the horizon;
+			// only flag negative elevation while tracking a spacecraft.
+			if ant.Elevation < 0 && hasRealTarget(ant) {
+				add(QualityImpossibleValue, ant.ID, "elevation %.1f° while tracking", ant.Elevation)
+			}
+			if ant.WindSpeed < 0 {
+				add(QualityImpossibleValue, ant.ID, "wind speed %.1f km/h", ant.WindSpeed)
+			}
+		}
+	}
+
+	for _, link := range data.Links {
+		subject := link.AntennaID
+		if link.Complex == "" {
+			add(QualityMissingField, subject, "cannot infer complex for antenna")
+		}
+		if link.Spacecraft == "" {
+			add(QualityMissingField, subject, "target without spacecraft name")
+			continue
+		}
+		if link.DataRate < 0 {
+			add(QualityImpossibleValue, link.Spacecraft, "data rate %.0f bps", link.DataRate)
+		}
+		// The feed uses -1 as a "no range" sentinel; anything else negative is bogus
+		if link.RTLT < 0 && link.RTLT != -1 {
+			add(QualityImpossibleValue, link.Spacecraft, "RTLT %.1f s", link.RTLT)
+		}
+	}
+
+	return report
+}
+
+// hasRealTarget returns true if the antenna is tracking at least one real spacecraft.
+func hasRealTarget(ant Antenna) bool {
+	for _, t := range ant.Targets {
+		if IsRealSpacecraft(t.Name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package dsn
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAssessQuality_Nil(t *testing.T) {
+	now := time.Now()
+	report := AssessQuality(nil, now)
+	if report.Total() != 0 {
+		t.Errorf("Total = %d, want 0", report.Total())
+	}
+	if !report.AssessedAt.Equal(now) {
+		t.Errorf("AssessedAt = %v, want %v", report.AssessedAt, now)
+	}
+}
+
+func TestAssessQuality_CleanFeed(t *testing.T) {
+	data, err := Parse([]byte(realisticXML))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	fetchedAt := data.Timestamp.Add(3 * time.Second)
+	report := AssessQuality(data, fetchedAt)
+
+	if report.Total() != 0 {
+		t.Errorf("Total = %d, want 0 (issues: %+v)", report.Total(), report.Issues)
+	}
+	if report.FeedLatency != 3*time.Second {
+		t.Errorf("FeedLatency = %v, want 3s", report.FeedLatency)
+	}
+}
+
+func TestAssessQuality_DetectsIssues(t *testing.T) {
+	now := time.Now()
+	data := &DSNData{
+		Timestamp: now,
+		Errors:    []string{"parse azimuth: bad"},
+		Stations: []Station{
+			{
+				Name:    "gdscc",
+				Complex: ComplexGoldstone,
+				// TimeUTC deliberately zero
+				Antennas: []Antenna{
+					{ID: "DSS14", Azimuth: 400, Elevation: -3, Targets: []Target{{Name: "VGR1"}}},
+					{ID: "DSS24", Elevation: -10}, // stowed, no target: not an issue
+				},
+			},
+		},
+		Links: []Link{
+			{AntennaID: "DSS14", Complex: ComplexGoldstone, Spacecraft: "VGR1", RTLT: -1},
+			{AntennaID: "XYZ", Spacecraft: "MRO", DataRate: -5},
+		},
+	}
+
+	report := AssessQuality(data, now)
+
+	tests := []struct {
+		category QualityCategory
+		want     int
+	}{
+		{QualityParseError, 1},
+		{QualityMissingField, 2},    // zero timeUTC + missing complex
+		{QualityImpossibleValue, 3}, // azimuth, tracking elevation, data rate
+	}
+	for _, tt := range tests {
+		if got := report.Count(tt.category); got != tt.want {
+			t.Errorf("Count(%s) = %d, want %d", tt.category, got, tt.want)
+		}
+	}
+}
+
+func TestAssessQuality_ZeroTimestamp(t *testing.T) {
+	report := AssessQuality(&DSNData{}, time.Now())
+	if report.Count(QualityMissingField) != 1 {
+		t.Errorf("expected zero feed timestamp to be flagged, got %+v", report.Issues)
+	}
+	if report.FeedLatency != 0 {
+		t.Errorf("FeedLatency = %v, want 0 for missing timestamp", report.FeedLatency)
+	}
+}
@@ -0,0 +1,281 @@
+package dsn
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MaxReplayGap caps the wait between replayed snapshots, so gaps in a
+// recording (the laptop slept, the recorder was stopped) don't stall
+// playback.
+const MaxReplayGap = 30 * time.Second
+
+// ImportSnapshot rebuilds DSNData from an exported snapshot for replay.
+// Exports carry only links and antenna pointing, so antenna targets and
+// signals are reconstructed from the links on each antenna.
+func ImportSnapshot(s *SnapshotExport) *DSNData {
+	data := &DSNData{Timestamp: s.Timestamp}
+	if data.Timestamp.IsZero() {
+		data.Timestamp = s.FetchedAt
+	}
+
+	azimuths := make(map[string]float64)
+	for _, st := range s.Stations {
+		for _, a := range st.Antennas {
+			azimuths[a.ID] = a.Azimuth
+		}
+	}
+
+	linksByAntenna := make(map[string][]LinkExport)
+	for _, l := range s.Links {
+		if l.Direction == "" && l.DownRate == 0 && l.UpRate == 0 {
+			// Recorded before directions were exported: the data rate
+			// was the downlink's
+			l.DownRate = l.DataRate
+			l.Downlink = l.DataRate > 0 || l.SignalType == SignalCarrier
+		}
+		linksByAntenna[l.AntennaID] = append(linksByAntenna[l.AntennaID], l)
+		data.Links = append(data.Links, Link{
+			StationID:    l.StationID,
+			AntennaID:    l.AntennaID,
+			Complex:      Complex(l.Complex),
+			SpacecraftID: l.SpacecraftID,
+			Spacecraft:   l.Spacecraft,
+			SignalType:   l.SignalType,
+			Uplink:       l.Uplink,
+			Downlink:     l.Downlink,
+			TrackingMode: TrackingMode(l.TrackingMode),
+			Band:         l.Band,
+			DataRate:     l.DataRate,
+			DownRate:     l.DownRate,
+			UpRate:       l.UpRate,
+			DownPower:    l.DownPower,
+			UpPower:      l.UpPower,
+			RTLT:         l.RTLT,
+			Distance:     l.Distance,
+			Pointing:     Pointing{AzDeg: azimuths[l.AntennaID], ElDeg: l.Elevation, Valid: true},
+		})
+	}
+
+	for _, st := range s.Stations {
+		station := Station{
+			Complex:      Complex(st.Complex),
+			Name:         st.Name,
+			FriendlyName: st.FriendlyName,
+			TimeUTC:      data.Timestamp,
+		}
+		for _, a := range st.Antennas {
+			ant := Antenna{
+				ID:        a.ID,
+				Name:      a.ID,
+				Azimuth:   a.Azimuth,
+				Elevation: a.Elevation,
+				Activity:  a.Activity,
+				Updated:   data.Timestamp,
+			}
+			if site, ok := GetAntennaSite(a.ID); ok {
+				ant.Diameter = site.Diameter
+			}
+			for _, l := range linksByAntenna[a.ID] {
+				ant.Targets = append(ant.Targets, Target{
+					ID:           l.SpacecraftID,
+					Name:         l.Spacecraft,
+					DownlegRange: l.Distance,
+					UplegRange:   l.Distance,
+					RTLT:         l.RTLT,
+				})
+				signalType := l.SignalType
+				if signalType == "" {
+					signalType = SignalData
+				}
+				ant.DownSignals = append(ant.DownSignals, Signal{
+					Active:       l.Downlink,
+					SignalType:   signalType,
+					DataRate:     l.DownRate,
+					Band:         l.Band,
+					Power:        l.DownPower,
+					SpacecraftID: l.SpacecraftID,
+					Spacecraft:   l.Spacecraft,
+				})
+				if l.Uplink {
+					ant.UpSignals = append(ant.UpSignals, Signal{
+						Active:       true,
+						SignalType:   SignalData,
+						DataRate:     l.UpRate,
+						Band:         l.Band,
+						Power:        l.UpPower,
+						SpacecraftID: l.SpacecraftID,
+						Spacecraft:   l.Spacecraft,
+					})
+				}
+			}
+			station.Antennas = append(station.Antennas, ant)
+		}
+		data.Stations = append(data.Stations, station)
+	}
+
+	return data
+}
+
+// ReadSnapshots decodes one or more JSON snapshots from r, as written by
+// --snapshot-path (a single object, or a stream of them with --watch).
+func ReadSnapshots(r io.Reader) ([]*SnapshotExport, error) {
+	dec := json.NewDecoder(r)
+	var snaps []*SnapshotExport
+	for {
+		var s SnapshotExport
+		if err := dec.Decode(&s); err != nil {
+			if errors.Is(err, io.EOF) {
+				return snaps, nil
+			}
+			return snaps, err
+		}
+		snaps = append(snaps, &s)
+	}
+}
+
+// LoadSnapshots reads snapshots from a file, or from every *.json,
+// *.jsonl, and *.jsonl.gz file in a directory (such as a --record
+// directory), and returns them oldest first.
+func LoadSnapshots(path string) ([]*SnapshotExport, error) {
+	return LoadSnapshotsBetween(path, time.Time{}, time.Time{})
+}
+
+// LoadSnapshotsBetween is LoadSnapshots for the snapshots taken between
+// start and end (a zero time leaves that side open). Daily --record files
+// (dsn-20251205.jsonl.gz) outside the window are skipped unread, so a
+// short window doesn't decompress the whole recording directory.
+func LoadSnapshotsBetween(path string, start, end time.Time) ([]*SnapshotExport, error) {
+	var snaps []*SnapshotExport
+	err := eachSnapshot(path, start, end, func(s *SnapshotExport) {
+		snaps = append(snaps, s)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(snaps) == 0 {
+		return nil, fmt.Errorf("no snapshots found in %s", path)
+	}
+
+	sort.SliceStable(snaps, func(i, j int) bool {
+		return snapshotTime(snaps[i]).Before(snapshotTime(snaps[j]))
+	})
+	return snaps, nil
+}
+
+// eachSnapshot calls fn with each snapshot between start and end in a
+// file or directory, as LoadSnapshotsBetween finds them, decoding one at
+// a time and in no particular order.
+func eachSnapshot(path string, start, end time.Time, fn func(*SnapshotExport)) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		files = nil
+		for _, pattern := range []string{"*.json", "*.jsonl", "*.jsonl.gz"} {
+			matches, err := filepath.Glob(filepath.Join(path, pattern))
+			if err != nil {
+				return err
+			}
+			for _, name := range matches {
+				if day, ok := recordDay(name); ok && !dayOverlaps(day, start, end) {
+					continue
+				}
+				files = append(files, name)
+			}
+		}
+	}
+
+	for _, name := range files {
+		err := eachSnapshotInFile(name, func(snap *SnapshotExport) {
+			t := snapshotTime(snap)
+			if (start.IsZero() || !t.Before(start)) && (end.IsZero() || !t.After(end)) {
+				fn(snap)
+			}
+		})
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// recordDay returns the UTC day in the name of a daily --record file.
+func recordDay(name string) (time.Time, bool) {
+	base, ok := strings.CutPrefix(filepath.Base(name), "dsn-")
+	if !ok || len(base) < len("20060102") {
+		return time.Time{}, false
+	}
+	day, err := time.Parse("20060102", base[:len("20060102")])
+	return day, err == nil
+}
+
+// dayOverlaps reports whether the UTC day starting at day overlaps the
+// window from start to end (zero times are open).
+func dayOverlaps(day, start, end time.Time) bool {
+	return (start.IsZero() || day.Add(24*time.Hour).After(start)) && (end.IsZero() || !day.After(end))
+}
+
+// eachSnapshotInFile decodes the snapshots in one file in turn,
+// decompressing it if its name ends in .gz.
+func eachSnapshotInFile(name string, fn func(*SnapshotExport)) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(name, ".gz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		r = gz
+	}
+	dec := json.NewDecoder(r)
+	for {
+		var s SnapshotExport
+		if err := dec.Decode(&s); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		fn(&s)
+	}
+}
+
+// ReplayDelay returns how long to wait between two snapshots when playing
+// back at speed (2 = twice as fast), capped at MaxReplayGap.
+func ReplayDelay(prev, next *SnapshotExport, speed float64) time.Duration {
+	if speed <= 0 {
+		speed = 1
+	}
+	gap := snapshotTime(next).Sub(snapshotTime(prev))
+	if gap <= 0 {
+		return 0
+	}
+	return min(time.Duration(float64(gap)/speed), MaxReplayGap)
+}
+
+// snapshotTime is the feed timestamp, or the fetch time for snapshots
+// exported without one.
+func snapshotTime(s *SnapshotExport) time.Time {
+	if !s.Timestamp.IsZero() {
+		return s.Timestamp
+	}
+	return s.FetchedAt
+}
@@ -0,0 +1,229 @@
+package dsn
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func replayTestData(ts time.Time, antenna string) *DSNData {
+	return &DSNData{
+		Timestamp: ts,
+		Stations: []Station{
+			{
+				Name:         "gdscc",
+				FriendlyName: "Goldstone",
+				Complex:      ComplexGoldstone,
+				Antennas: []Antenna{
+					{ID: antenna, Azimuth: 180, Elevation: 45, Activity: "track",
+						Targets: []Target{{ID: 31, Name: "VGR1", RTLT: 160200}}},
+				},
+			},
+		},
+		Links: []Link{
+			{
+				Complex:      ComplexGoldstone,
+				StationID:    "gdscc",
+				AntennaID:    antenna,
+				Spacecraft:   "VGR1",
+				SpacecraftID: 31,
+				Band:         "X",
+				DataRate:     160,
+				DownRate:     160,
+				Downlink:     true,
+				Distance:     24e9,
+				RTLT:         160200,
+			},
+		},
+	}
+}
+
+func TestImportSnapshot_RoundTrip(t *testing.T) {
+	ts := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	orig := replayTestData(ts, "DSS14")
+
+	got := ImportSnapshot(ExportSnapshot(orig, ts.Add(5*time.Second)))
+
+	if !got.Timestamp.Equal(ts) {
+		t.Errorf("Timestamp = %v, want %v", got.Timestamp, ts)
+	}
+	if len(got.Links) != 1 || got.Links[0] != (Link{
+		StationID: "gdscc", AntennaID: "DSS14", Complex: ComplexGoldstone,
+		SpacecraftID: 31, Spacecraft: "VGR1", Band: "X",
+		DataRate: 160, DownRate: 160, Downlink: true, RTLT: 160200, Distance: 24e9,
+		Pointing: Pointing{AzDeg: 180, ElDeg: 45, Valid: true},
+	}) {
+		t.Errorf("Links = %+v", got.Links)
+	}
+	if len(got.Stations) != 1 || len(got.Stations[0].Antennas) != 1 {
+		t.Fatalf("Stations = %+v, want one station with one antenna", got.Stations)
+	}
+
+	ant := got.Stations[0].Antennas[0]
+	if ant.Azimuth != 180 || ant.Elevation != 45 || ant.Diameter != 70 {
+		t.Errorf("antenna = az %v el %v dia %v, want 180/45/70", ant.Azimuth, ant.Elevation, ant.Diameter)
+	}
+	if len(ant.Targets) != 1 || ant.Targets[0].Name != "VGR1" {
+		t.Errorf("Targets = %+v, want VGR1", ant.Targets)
+	}
+	if len(ant.DownSignals) != 1 || ant.DownSignals[0].DataRate != 160 {
+		t.Errorf("DownSignals = %+v, want one at 160 bps", ant.DownSignals)
+	}
+}
+
+func TestImportSnapshot_BeforeDirections(t *testing.T) {
+	// Recordings from before links had a direction carry only the data rate
+	s := &SnapshotExport{Links: []LinkExport{
+		{AntennaID: "DSS14", Spacecraft: "VGR1", DataRate: 160},
+		{AntennaID: "DSS43", Spacecraft: "VGR2", SignalType: SignalCarrier},
+	}}
+	got := ImportSnapshot(s)
+	if l := got.Links[0]; !l.Downlink || l.DownRate != 160 || l.Direction() != DirectionDown {
+		t.Errorf("VGR1 = %+v, want receiving at 160 bps", l)
+	}
+	if l := got.Links[1]; !l.Downlink {
+		t.Errorf("VGR2 carrier = %+v, want receiving", l)
+	}
+	if s.Links[0].Direction != "" {
+		t.Error("ImportSnapshot modified its argument")
+	}
+}
+
+func TestImportSnapshot_ReexportMatches(t *testing.T) {
+	ts := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	fetchedAt := ts.Add(5 * time.Second)
+
+	// Derived metrics read only the data, so a replayed snapshot exports
+	// exactly what was recorded live
+	live := ExportSnapshot(replayTestData(ts, "DSS14"), fetchedAt)
+	replayed := ExportSnapshot(ImportSnapshot(live), fetchedAt)
+
+	var a, b bytes.Buffer
+	if err := live.WriteJSON(&a); err != nil {
+		t.Fatal(err)
+	}
+	if err := replayed.WriteJSON(&b); err != nil {
+		t.Fatal(err)
+	}
+	if a.String() != b.String() {
+		t.Errorf("re-export differs:\nlive:\n%s\nreplayed:\n%s", a.String(), b.String())
+	}
+}
+
+func TestReadSnapshots_Stream(t *testing.T) {
+	var buf bytes.Buffer
+	t0 := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		ts := t0.Add(time.Duration(i) * 5 * time.Second)
+		if err := ExportSnapshot(replayTestData(ts, "DSS14"), ts).WriteJSON(&buf); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	snaps, err := ReadSnapshots(&buf)
+	if err != nil {
+		t.Fatalf("ReadSnapshots: %v", err)
+	}
+	if len(snaps) != 3 {
+		t.Fatalf("got %d snapshots, want 3", len(snaps))
+	}
+
+	if _, err := ReadSnapshots(strings.NewReader(`{"timestamp": nope}`)); err == nil {
+		t.Error("expected error for malformed JSON")
+	}
+}
+
+func TestLoadSnapshots_Dir(t *testing.T) {
+	dir := t.TempDir()
+	t0 := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	// Written out of order; LoadSnapshots sorts by feed time
+	for i, name := range []string{"b.json", "a.json", "c.json"} {
+		ts := t0.Add(time.Duration(2-i) * time.Minute)
+		var buf bytes.Buffer
+		if err := ExportSnapshot(replayTestData(ts, "DSS14"), ts).WriteJSON(&buf); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), buf.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	snaps, err := LoadSnapshots(dir)
+	if err != nil {
+		t.Fatalf("LoadSnapshots: %v", err)
+	}
+	if len(snaps) != 3 {
+		t.Fatalf("got %d snapshots, want 3", len(snaps))
+	}
+	for i := 1; i < len(snaps); i++ {
+		if !snaps[i].Timestamp.After(snaps[i-1].Timestamp) {
+			t.Errorf("snapshots not sorted: %v then %v", snaps[i-1].Timestamp, snaps[i].Timestamp)
+		}
+	}
+
+	if _, err := LoadSnapshots(t.TempDir()); err == nil {
+		t.Error("expected error for a directory with no snapshots")
+	}
+}
+
+func TestLoadSnapshotsBetween(t *testing.T) {
+	dir := t.TempDir()
+	day := time.Date(2025, 12, 5, 0, 0, 0, 0, time.UTC)
+
+	var buf bytes.Buffer
+	for _, h := range []int{6, 20} {
+		ts := day.Add(time.Duration(h) * time.Hour)
+		if err := ExportSnapshot(replayTestData(ts, "DSS14"), ts).WriteJSONLine(&buf); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "dsn-20251205.jsonl"), buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// An older day's file is skipped without being read
+	if err := os.WriteFile(filepath.Join(dir, "dsn-20251201.jsonl"), []byte("not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	snaps, err := LoadSnapshotsBetween(dir, day.Add(12*time.Hour), day.Add(36*time.Hour))
+	if err != nil {
+		t.Fatalf("LoadSnapshotsBetween: %v", err)
+	}
+	if len(snaps) != 1 || !snaps[0].Timestamp.Equal(day.Add(20*time.Hour)) {
+		t.Errorf("got %d snapshots, want the 20:00 one", len(snaps))
+	}
+
+	if _, err := LoadSnapshots(dir); err == nil {
+		t.Error("LoadSnapshots should read every file, including the bad one")
+	}
+}
+
+func TestReplayDelay(t *testing.T) {
+	t0 := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	at := func(d time.Duration) *SnapshotExport { return &SnapshotExport{Timestamp: t0.Add(d)} }
+
+	tests := []struct {
+		name  string
+		gap   time.Duration
+		speed float64
+		want  time.Duration
+	}{
+		{"real time", 5 * time.Second, 1, 5 * time.Second},
+		{"10x", 5 * time.Second, 10, 500 * time.Millisecond},
+		{"zero speed is real time", 5 * time.Second, 0, 5 * time.Second},
+		{"long gap capped", time.Hour, 1, MaxReplayGap},
+		{"out of order", -time.Second, 1, 0},
+	}
+	for _, tt := range tests {
+		if got := ReplayDelay(at(0), at(tt.gap), tt.speed); got != tt.want {
+			t.Errorf("%s: ReplayDelay = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package dsn
+
+import (
+	"fmt"
+	"math"
+)
+
+// Downlink signal-to-noise thresholds, as received power to noise
+// spectral density (Pr/N0, dB-Hz). They bound the struggle index's signal
+// quality factor: at or below WeakSNR it is hardest, at or above
+// StrongSNR easiest.
+const (
+	WeakSNR   = 25.0 // around where a carrier lock is still held
+	StrongSNR = 55.0 // comfortable for high-rate telemetry
+)
+
+// boltzmannDBm is Boltzmann's constant in dBm/K/Hz.
+const boltzmannDBm = -198.6
+
+// systemNoiseTemp is a typical system noise temperature (K) of a DSN
+// antenna at zenith, by band; unknown bands use X-band's.
+var systemNoiseTemp = map[string]float64{
+	"S":  30,
+	"X":  25,
+	"Ka": 35,
+}
+
+// SNR estimates the downlink's Pr/N0 in dB-Hz from the received power the
+// feed reports and a typical system noise temperature for the band. It is
+// a rough figure, not the station's measured SNR. ok is false when the
+// feed reports no downlink power.
+func (l Link) SNR() (dbHz float64, ok bool) {
+	if l.DownPower >= 0 {
+		return 0, false // received power is always negative dBm; 0 means absent
+	}
+	temp, known := systemNoiseTemp[l.Band]
+	if !known {
+		temp = systemNoiseTemp["X"]
+	}
+	return l.DownPower - (boltzmannDBm + 10*math.Log10(temp)), true
+}
+
+// SNRQuality maps Pr/N0 onto the 0 (weak) to 1 (strong) signal quality
+// scale between WeakSNR and StrongSNR.
+func SNRQuality(dbHz float64) float64 {
+	return clamp((dbHz-WeakSNR)/(StrongSNR-WeakSNR), 0, 1)
+}
+
+// FormatSNR formats a link's Pr/N0 as "29.6 dB-Hz", or "-" when the feed
+// reports no downlink power.
+func FormatSNR(l Link) string {
+	snr, ok := l.SNR()
+	if !ok {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f dB-Hz", snr)
+}
+
+// FormatPower formats received downlink power as "-155.2 dBm", or "-"
+// when not reported.
+func FormatPower(dBm float64) string {
+	if dBm == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f dBm", dBm)
+}
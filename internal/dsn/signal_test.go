@@ -0,0 +1,64 @@
+package dsn
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLink_SNR(t *testing.T) {
+	tests := []struct {
+		name string
+		link Link
+		want float64
+		ok   bool
+	}{
+		{"voyager X-band", Link{Band: "X", DownPower: -155}, 29.6, true},
+		{"mars orbiter Ka-band", Link{Band: "Ka", DownPower: -120}, 63.2, true},
+		{"unknown band uses X", Link{Band: "K", DownPower: -155}, 29.6, true},
+		{"not reported", Link{Band: "X"}, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.link.SNR()
+			if ok != tt.ok || math.Abs(got-tt.want) > 0.05 {
+				t.Errorf("SNR() = %.2f, %v; want %.1f, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestSNRQuality(t *testing.T) {
+	for _, tt := range []struct{ snr, want float64 }{
+		{10, 0}, {WeakSNR, 0}, {40, 0.5}, {StrongSNR, 1}, {80, 1},
+	} {
+		if got := SNRQuality(tt.snr); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("SNRQuality(%v) = %v, want %v", tt.snr, got, tt.want)
+		}
+	}
+}
+
+func TestStruggle_SNR(t *testing.T) {
+	m := DefaultHealthModel()
+	weak := Link{Band: "X", Distance: 1e9, DataRate: 1000, DownPower: -160}
+	strong := weak
+	strong.DownPower = -110
+	unknown := weak
+	unknown.DownPower = 0
+
+	ws, ss, us := m.Struggle(weak, 30), m.Struggle(strong, 30), m.Struggle(unknown, 30)
+	if !(ws > us && us > ss) {
+		t.Errorf("struggle weak %.3f, unknown %.3f, strong %.3f; want weak > unknown > strong", ws, us, ss)
+	}
+}
+
+func TestFormatSNR(t *testing.T) {
+	if got := FormatSNR(Link{Band: "X", DownPower: -155}); got != "29.6 dB-Hz" {
+		t.Errorf("FormatSNR = %q", got)
+	}
+	if got := FormatSNR(Link{Band: "X"}); got != "-" {
+		t.Errorf("FormatSNR without power = %q, want -", got)
+	}
+	if got := FormatPower(-155.24); got != "-155.2 dBm" {
+		t.Errorf("FormatPower = %q", got)
+	}
+}
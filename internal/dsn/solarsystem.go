@@ -1,8 +1,11 @@
 package dsn
 
 import (
+	"errors"
+	"fmt"
 	"math"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/litescript/ls-horizons/internal/astro"
@@ -15,6 +18,7 @@ const (
 	BodySun BodyKind = iota
 	BodyPlanet
 	BodySpacecraft
+	BodyMoon
 )
 
 // String returns the body kind name.
@@ -26,6 +30,8 @@ func (k BodyKind) String() string {
 		return "planet"
 	case BodySpacecraft:
 		return "spacecraft"
+	case BodyMoon:
+		return "moon"
 	default:
 		return "unknown"
 	}
@@ -47,6 +53,21 @@ type EclipticBody struct {
 	Class PlanetClass       // For planets: inner or giant
 	Pos   astro.Vec3        // Position in AU (heliocentric ecliptic)
 	Meta  map[string]string // Additional metadata
+
+	// ApparentPos is where Earth sees the body: its position when the
+	// light now arriving left it. LightTime is that one-way light time.
+	// Both are zero when no vector series allows the correction.
+	ApparentPos astro.Vec3
+	LightTime   time.Duration
+}
+
+// Position returns the body's apparent (light-time corrected) position if
+// apparent is set and one was computed, else its geometric position.
+func (b EclipticBody) Position(apparent bool) astro.Vec3 {
+	if apparent && b.LightTime > 0 {
+		return b.ApparentPos
+	}
+	return b.Pos
 }
 
 // DistanceAU returns the heliocentric distance in AU.
@@ -64,15 +85,17 @@ func (b EclipticBody) EclipticLonDeg() float64 {
 	return astro.EclipticLongitude(b.Pos)
 }
 
-// LightTimeSec returns the one-way light time in seconds.
+// LightTimeSec returns the one-way light time from the Sun in seconds.
 func (b EclipticBody) LightTimeSec() float64 {
 	return astro.LightTimeFromAU(b.DistanceAU())
 }
 
 // SolarSystemSnapshot represents the current state of the solar system.
 type SolarSystemSnapshot struct {
-	GeneratedAt time.Time
-	Bodies      []EclipticBody
+	GeneratedAt       time.Time
+	PlanetsUpdated    time.Time // last planet refresh (zero if never)
+	SpacecraftUpdated time.Time // last spacecraft refresh (zero if never)
+	Bodies            []EclipticBody
 }
 
 // GetBody returns a body by code, or nil if not found.
@@ -137,6 +160,19 @@ type SolarSystemCache struct {
 	lastPlanetUpdate time.Time
 	lastSCUpdate     time.Time
 
+	// Planet vector series by body code, interpolated between refreshes
+	planetSeries map[string]vectorSeries
+
+	// Spacecraft vector series by code, for the spacecraft in the last
+	// DSN update (code to NAIF ID), and when each was last fetched
+	scSeries          map[string]vectorSeries
+	scTracked         map[string]int
+	scFetched         map[string]time.Time
+	scVectorsInFlight atomic.Bool
+
+	cfg             SolarSystemConfig
+	planetsInFlight atomic.Bool // guards against overlapping planet fetches
+
 	// Provider interface for Horizons queries
 	provider SolarSystemProvider
 }
@@ -147,16 +183,101 @@ type SolarSystemProvider interface {
 	GetHeliocentricPosition(naifID int, t time.Time) (astro.Vec3, error)
 }
 
-// Planet cache TTL (planets move slowly)
-const PlanetCacheTTL = 10 * time.Minute
+// SolarSystemSeriesProvider is optionally implemented by a SolarSystemProvider
+// that can return positions for many epochs in one query.
+type SolarSystemSeriesProvider interface {
+	// GetHeliocentricPositions returns heliocentric ecliptic positions in AU,
+	// one per requested time.
+	GetHeliocentricPositions(naifID int, times []time.Time) ([]astro.Vec3, error)
+}
+
+// PlanetCacheTTL is the default planet refresh interval (planets move slowly).
+const PlanetCacheTTL = 6 * time.Hour
+
+// Planet vector series window. Each refresh fetches samples from
+// PlanetSeriesLookback before now to PlanetSeriesLookahead after, and the
+// cache only refreshes again once half the lookahead has elapsed.
+const (
+	PlanetSeriesStep      = 6 * time.Hour
+	PlanetSeriesLookback  = 12 * time.Hour
+	PlanetSeriesLookahead = 48 * time.Hour
+)
+
+// SpacecraftSeriesLookback is how far back spacecraft vector series reach:
+// past the longest one-way light time (Voyager 1, about a day), so where
+// Earth sees a spacecraft can be interpolated too. They share the planet
+// series' step and lookahead.
+const SpacecraftSeriesLookback = 36 * time.Hour
+
+// vectorSeries is a time series of positions for one body.
+type vectorSeries struct {
+	times []time.Time
+	pos   []astro.Vec3
+}
+
+// at linearly interpolates the series at t. It returns false if t is
+// outside the series window.
+func (s vectorSeries) at(t time.Time) (astro.Vec3, bool) {
+	n := len(s.times)
+	if n == 0 || t.Before(s.times[0]) || t.After(s.times[n-1]) {
+		return astro.Vec3{}, false
+	}
+	for i := 1; i < n; i++ {
+		if t.After(s.times[i]) {
+			continue
+		}
+		span := s.times[i].Sub(s.times[i-1])
+		if span <= 0 {
+			return s.pos[i], true
+		}
+		f := float64(t.Sub(s.times[i-1])) / float64(span)
+		return s.pos[i-1].Add(s.pos[i].Sub(s.pos[i-1]).Scale(f)), true
+	}
+	return s.pos[n-1], true
+}
+
+// planetSeriesEpochs returns the sample times for a series fetched at now.
+func planetSeriesEpochs(now time.Time) []time.Time {
+	return seriesEpochs(now, PlanetSeriesLookback)
+}
+
+// seriesEpochs returns the sample times for a series fetched at now that
+// reaches lookback into the past.
+func seriesEpochs(now time.Time, lookback time.Duration) []time.Time {
+	start := now.Add(-lookback).Truncate(PlanetSeriesStep)
+	end := now.Add(PlanetSeriesLookahead)
+	var times []time.Time
+	for t := start; !t.After(end); t = t.Add(PlanetSeriesStep) {
+		times = append(times, t)
+	}
+	return times
+}
+
+// SpacecraftCacheTTL is the default spacecraft refresh interval.
+const SpacecraftCacheTTL = 1 * time.Minute
 
-// Spacecraft cache TTL
-const SpacecraftCacheTTL = 5 * time.Minute
+// SolarSystemConfig controls how often cached positions are refreshed.
+// Planets and spacecraft refresh independently: planet positions come from
+// Horizons and change slowly, while spacecraft positions are derived from
+// the DSN feed.
+type SolarSystemConfig struct {
+	PlanetRefresh     time.Duration
+	SpacecraftRefresh time.Duration
+}
+
+// DefaultSolarSystemConfig returns the default refresh intervals.
+func DefaultSolarSystemConfig() SolarSystemConfig {
+	return SolarSystemConfig{
+		PlanetRefresh:     PlanetCacheTTL,
+		SpacecraftRefresh: SpacecraftCacheTTL,
+	}
+}
 
 // NewSolarSystemCache creates a new cache.
 func NewSolarSystemCache(provider SolarSystemProvider) *SolarSystemCache {
 	return &SolarSystemCache{
 		provider: provider,
+		cfg:      DefaultSolarSystemConfig(),
 		snapshot: SolarSystemSnapshot{
 			Bodies: []EclipticBody{
 				// Sun at origin (always present)
@@ -166,29 +287,208 @@ func NewSolarSystemCache(provider SolarSystemProvider) *SolarSystemCache {
 	}
 }
 
-// GetSnapshot returns the current cached snapshot.
+// SetConfig updates the refresh intervals. Non-positive values keep the
+// defaults.
+func (c *SolarSystemCache) SetConfig(cfg SolarSystemConfig) {
+	def := DefaultSolarSystemConfig()
+	if cfg.PlanetRefresh <= 0 {
+		cfg.PlanetRefresh = def.PlanetRefresh
+	}
+	if cfg.SpacecraftRefresh <= 0 {
+		cfg.SpacecraftRefresh = def.SpacecraftRefresh
+	}
+
+	c.mu.Lock()
+	c.cfg = cfg
+	c.mu.Unlock()
+}
+
+// Config returns the current refresh intervals.
+func (c *SolarSystemCache) Config() SolarSystemConfig {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.cfg
+}
+
+// GetSnapshot returns the current cached snapshot, with planet positions
+// interpolated to the current time where a vector series is available.
 func (c *SolarSystemCache) GetSnapshot() SolarSystemSnapshot {
+	return c.SnapshotAt(time.Now())
+}
+
+// SnapshotAt returns the cached snapshot with planet and spacecraft
+// positions interpolated to t. Bodies without a series covering t keep
+// their last position. Where Earth and the body both have a series, the
+// body's apparent position and light time are filled in too.
+func (c *SolarSystemCache) SnapshotAt(t time.Time) SolarSystemSnapshot {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
-	return c.snapshot
+
+	if len(c.planetSeries) == 0 && len(c.scSeries) == 0 {
+		return c.snapshot
+	}
+
+	bodies := make([]EclipticBody, len(c.snapshot.Bodies))
+	copy(bodies, c.snapshot.Bodies)
+	for i, b := range bodies {
+		if pos, ok := c.seriesFor(b).at(t); ok {
+			bodies[i].Pos = pos
+		}
+	}
+
+	if earth, ok := c.planetSeries["EARTH"].at(t); ok {
+		for i, b := range bodies {
+			if b.Kind == BodySun || b.Code == "EARTH" {
+				continue
+			}
+			if pos, lt, ok := apparentPosition(c.seriesFor(b), earth, t); ok {
+				bodies[i].ApparentPos = pos
+				bodies[i].LightTime = lt
+			}
+		}
+	}
+
+	snap := c.snapshot
+	snap.Bodies = bodies
+	return snap
 }
 
-// NeedsPlanetRefresh returns true if planet data needs refreshing.
+// seriesFor returns the vector series for a body, empty if it has none.
+// Caller must hold the lock.
+func (c *SolarSystemCache) seriesFor(b EclipticBody) vectorSeries {
+	switch b.Kind {
+	case BodyPlanet:
+		return c.planetSeries[b.Code]
+	case BodySpacecraft:
+		return c.scSeries[b.Code]
+	}
+	return vectorSeries{}
+}
+
+// apparentPosition corrects a body's position for one-way light time:
+// where Earth, at earth, sees it at t is where it was when that light
+// left, t−τ, with τ its distance then over c. It returns the position and
+// τ, or false if the series doesn't reach back to t−τ.
+func apparentPosition(s vectorSeries, earth astro.Vec3, t time.Time) (astro.Vec3, time.Duration, bool) {
+	pos, ok := s.at(t)
+	if !ok {
+		return astro.Vec3{}, 0, false
+	}
+	var lt time.Duration
+	for range 3 { // converges to well under a second in two
+		lt = time.Duration(astro.LightTimeFromAU(pos.Sub(earth).Norm()) * float64(time.Second))
+		if pos, ok = s.at(t.Add(-lt)); !ok {
+			return astro.Vec3{}, 0, false
+		}
+	}
+	return pos, lt, true
+}
+
+// NeedsPlanetRefresh returns true if planet data needs refreshing: the
+// configured interval has elapsed, or the vector series is about to run
+// out. Returns false while a planet fetch is already in progress.
 func (c *SolarSystemCache) NeedsPlanetRefresh() bool {
+	if c.planetsInFlight.Load() {
+		return false
+	}
+
 	c.mu.RLock()
 	defer c.mu.RUnlock()
-	return time.Since(c.lastPlanetUpdate) > PlanetCacheTTL
+	elapsed := time.Since(c.lastPlanetUpdate)
+	if len(c.planetSeries) > 0 && elapsed > PlanetSeriesLookahead/2 {
+		return true
+	}
+	return elapsed > c.cfg.PlanetRefresh
+}
+
+// NeedsSpacecraftVectorRefresh reports whether a spacecraft in the last
+// DSN update has no Horizons vector series yet, or one due for a refresh
+// (as planets are). Returns false without a series provider or while a
+// fetch is in progress.
+func (c *SolarSystemCache) NeedsSpacecraftVectorRefresh() bool {
+	if _, ok := c.provider.(SolarSystemSeriesProvider); !ok || c.scVectorsInFlight.Load() {
+		return false
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.dueSpacecraftVectors(time.Now())) > 0
+}
+
+// dueSpacecraftVectors returns the tracked spacecraft (code to NAIF ID)
+// whose series was never fetched or is due for a refresh. Caller must hold
+// the lock.
+func (c *SolarSystemCache) dueSpacecraftVectors(now time.Time) map[string]int {
+	refresh := min(c.cfg.PlanetRefresh, PlanetSeriesLookahead/2)
+	due := make(map[string]int)
+	for code, naifID := range c.scTracked {
+		if fetched, ok := c.scFetched[code]; !ok || now.Sub(fetched) > refresh {
+			due[code] = naifID
+		}
+	}
+	return due
+}
+
+// UpdateSpacecraftVectors fetches Horizons vector series for the tracked
+// spacecraft that need one, so the Orbit view plots them from their
+// ephemeris rather than the DSN feed. A spacecraft whose fetch fails keeps
+// its DSN position until the next refresh. If a fetch is already running,
+// it returns immediately.
+func (c *SolarSystemCache) UpdateSpacecraftVectors() error {
+	seriesProvider, ok := c.provider.(SolarSystemSeriesProvider)
+	if !ok || !c.scVectorsInFlight.CompareAndSwap(false, true) {
+		return nil
+	}
+	defer c.scVectorsInFlight.Store(false)
+
+	now := time.Now()
+	c.mu.RLock()
+	due := c.dueSpacecraftVectors(now)
+	c.mu.RUnlock()
+
+	epochs := seriesEpochs(now, SpacecraftSeriesLookback)
+	fetched := make(map[string]vectorSeries, len(due))
+	var errs []error
+	for code, naifID := range due {
+		vecs, err := seriesProvider.GetHeliocentricPositions(naifID, epochs)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", code, err))
+			continue
+		}
+		fetched[code] = vectorSeries{times: epochs, pos: vecs}
+	}
+
+	c.mu.Lock()
+	if c.scSeries == nil {
+		c.scSeries = make(map[string]vectorSeries)
+		c.scFetched = make(map[string]time.Time)
+	}
+	for code := range due {
+		c.scFetched[code] = now
+		if s, ok := fetched[code]; ok {
+			c.scSeries[code] = s
+		}
+	}
+	c.mu.Unlock()
+
+	return errors.Join(errs...)
 }
 
 // NeedsSpacecraftRefresh returns true if spacecraft data needs refreshing.
 func (c *SolarSystemCache) NeedsSpacecraftRefresh() bool {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
-	return time.Since(c.lastSCUpdate) > SpacecraftCacheTTL
+	return time.Since(c.lastSCUpdate) > c.cfg.SpacecraftRefresh
 }
 
-// UpdatePlanets fetches fresh planet positions from the provider.
+// UpdatePlanets fetches fresh planet positions from the provider. If a fetch
+// is already running, it returns immediately.
 func (c *SolarSystemCache) UpdatePlanets() error {
+	if !c.planetsInFlight.CompareAndSwap(false, true) {
+		return nil
+	}
+	defer c.planetsInFlight.Store(false)
+
 	if c.provider == nil {
 		// Use static fallback positions
 		return c.updatePlanetsStatic()
@@ -196,8 +496,30 @@ func (c *SolarSystemCache) UpdatePlanets() error {
 
 	now := time.Now()
 	var planets []EclipticBody
+	series := make(map[string]vectorSeries)
+
+	seriesProvider, hasSeries := c.provider.(SolarSystemSeriesProvider)
+	epochs := planetSeriesEpochs(now)
 
 	for _, p := range Planets {
+		// Prefer one multi-epoch query per body; interpolate locally after
+		if hasSeries {
+			vecs, err := seriesProvider.GetHeliocentricPositions(p.NAIFID, epochs)
+			if err == nil && len(vecs) == len(epochs) {
+				s := vectorSeries{times: epochs, pos: vecs}
+				series[p.Code] = s
+				pos, _ := s.at(now)
+				planets = append(planets, EclipticBody{
+					Name:  p.Name,
+					Code:  p.Code,
+					Kind:  BodyPlanet,
+					Class: p.Class,
+					Pos:   pos,
+				})
+				continue
+			}
+		}
+
 		pos, err := c.provider.GetHeliocentricPosition(p.NAIFID, now)
 		if err != nil {
 			// Use approximate position based on semi-major axis
@@ -226,9 +548,12 @@ func (c *SolarSystemCache) UpdatePlanets() error {
 	}
 
 	c.snapshot = SolarSystemSnapshot{
-		GeneratedAt: now,
-		Bodies:      newBodies,
+		GeneratedAt:       now,
+		PlanetsUpdated:    now,
+		SpacecraftUpdated: c.lastSCUpdate,
+		Bodies:            newBodies,
 	}
+	c.planetSeries = series
 	c.lastPlanetUpdate = now
 	c.mu.Unlock()
 
@@ -263,18 +588,26 @@ func (c *SolarSystemCache) updatePlanetsStatic() error {
 	}
 
 	c.snapshot = SolarSystemSnapshot{
-		GeneratedAt: now,
-		Bodies:      newBodies,
+		GeneratedAt:       now,
+		PlanetsUpdated:    now,
+		SpacecraftUpdated: c.lastSCUpdate,
+		Bodies:            newBodies,
 	}
+	c.planetSeries = nil
 	c.lastPlanetUpdate = now
 	c.mu.Unlock()
 
 	return nil
 }
 
-// approximatePlanetPosition generates a rough position based on orbital period.
-// This is a placeholder for when Horizons is unavailable.
+// approximatePlanetPosition returns a planet's position from its mean
+// Keplerian elements, for when Horizons is unavailable. Planets without
+// elements are placed on a circular orbit.
 func approximatePlanetPosition(p PlanetDef, t time.Time) astro.Vec3 {
+	if elements, ok := astro.PlanetElements[p.Name]; ok {
+		return elements.Position(t)
+	}
+
 	// Orbital period in years (Kepler's 3rd law approximation)
 	periodYears := p.SemiMajorAU * p.SemiMajorAU * p.SemiMajorAU
 	periodYears = math.Sqrt(periodYears)
@@ -306,7 +639,12 @@ func (c *SolarSystemCache) UpdateSpacecraft(dsnData *DSNData) error {
 	scViews := BuildSpacecraftViews(dsnData, elevMap)
 
 	var spacecraft []EclipticBody
+	tracked := make(map[string]int)
 	for _, sv := range scViews {
+		if info := GetSpacecraftInfo(sv.Code); info != nil && info.NAIFID != 0 {
+			tracked[sv.Code] = info.NAIFID
+		}
+
 		// Convert DSN distance to AU
 		distanceAU := astro.KmToAU(sv.PrimaryLink.DistanceKm)
 		if distanceAU < 0.001 {
@@ -341,10 +679,13 @@ func (c *SolarSystemCache) UpdateSpacecraft(dsnData *DSNData) error {
 	newBodies = append(newBodies, spacecraft...)
 
 	c.snapshot = SolarSystemSnapshot{
-		GeneratedAt: now,
-		Bodies:      newBodies,
+		GeneratedAt:       now,
+		PlanetsUpdated:    c.lastPlanetUpdate,
+		SpacecraftUpdated: now,
+		Bodies:            newBodies,
 	}
 	c.lastSCUpdate = now
+	c.scTracked = tracked
 	c.mu.Unlock()
 
 	return nil
@@ -0,0 +1,240 @@
+package dsn
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/astro"
+)
+
+// fakeSeriesProvider returns X = hours since epoch for every body, so
+// interpolated positions are easy to check.
+type fakeSeriesProvider struct {
+	epoch        time.Time
+	singleCalls  int
+	seriesCalls  int
+	failSeriesID int
+}
+
+func (f *fakeSeriesProvider) GetHeliocentricPosition(naifID int, t time.Time) (astro.Vec3, error) {
+	f.singleCalls++
+	return astro.Vec3{X: t.Sub(f.epoch).Hours()}, nil
+}
+
+func (f *fakeSeriesProvider) GetHeliocentricPositions(naifID int, times []time.Time) ([]astro.Vec3, error) {
+	f.seriesCalls++
+	if naifID == f.failSeriesID {
+		return nil, errTestSeries
+	}
+	vecs := make([]astro.Vec3, len(times))
+	for i, t := range times {
+		vecs[i] = astro.Vec3{X: t.Sub(f.epoch).Hours()}
+	}
+	return vecs, nil
+}
+
+var errTestSeries = errors.New("series unavailable")
+
+func TestVectorSeriesAt(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := vectorSeries{
+		times: []time.Time{t0, t0.Add(6 * time.Hour), t0.Add(12 * time.Hour)},
+		pos:   []astro.Vec3{{X: 0}, {X: 6}, {X: 18}},
+	}
+
+	tests := []struct {
+		name   string
+		t      time.Time
+		wantX  float64
+		wantOK bool
+	}{
+		{"start", t0, 0, true},
+		{"mid first segment", t0.Add(3 * time.Hour), 3, true},
+		{"mid second segment", t0.Add(9 * time.Hour), 12, true},
+		{"end", t0.Add(12 * time.Hour), 18, true},
+		{"before", t0.Add(-time.Minute), 0, false},
+		{"after", t0.Add(13 * time.Hour), 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pos, ok := s.at(tt.t)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && math.Abs(pos.X-tt.wantX) > 1e-9 {
+				t.Errorf("X = %v, want %v", pos.X, tt.wantX)
+			}
+		})
+	}
+}
+
+func TestSolarSystemCache_UpdatePlanetsSeries(t *testing.T) {
+	epoch := time.Now().Add(-24 * time.Hour)
+	provider := &fakeSeriesProvider{epoch: epoch}
+	cache := NewSolarSystemCache(provider)
+
+	if err := cache.UpdatePlanets(); err != nil {
+		t.Fatalf("UpdatePlanets: %v", err)
+	}
+	if provider.seriesCalls != len(Planets) {
+		t.Errorf("series calls = %d, want %d", provider.seriesCalls, len(Planets))
+	}
+	if provider.singleCalls != 0 {
+		t.Errorf("single calls = %d, want 0", provider.singleCalls)
+	}
+
+	// Positions interpolate to the requested time
+	at := time.Now().Add(5 * time.Hour)
+	snap := cache.SnapshotAt(at)
+	mars := snap.GetBody("MARS")
+	if mars == nil {
+		t.Fatal("MARS missing from snapshot")
+	}
+	want := at.Sub(epoch).Hours()
+	if math.Abs(mars.Pos.X-want) > 1e-6 {
+		t.Errorf("MARS X = %v, want %v", mars.Pos.X, want)
+	}
+
+	// Full series coverage defers the next refresh
+	if cache.NeedsPlanetRefresh() {
+		t.Error("NeedsPlanetRefresh should be false right after a series update")
+	}
+}
+
+func TestSolarSystemCache_UpdatePlanetsSeriesFallback(t *testing.T) {
+	provider := &fakeSeriesProvider{epoch: time.Now(), failSeriesID: 499}
+	cache := NewSolarSystemCache(provider)
+
+	if err := cache.UpdatePlanets(); err != nil {
+		t.Fatalf("UpdatePlanets: %v", err)
+	}
+	if provider.singleCalls != 1 {
+		t.Errorf("single calls = %d, want 1 (Mars fallback)", provider.singleCalls)
+	}
+	if cache.GetSnapshot().GetBody("MARS") == nil {
+		t.Error("MARS should still be present via single-epoch fallback")
+	}
+
+	// Partial coverage keeps the short TTL
+	cache.mu.Lock()
+	cache.lastPlanetUpdate = time.Now().Add(-PlanetCacheTTL - time.Second)
+	cache.mu.Unlock()
+	if !cache.NeedsPlanetRefresh() {
+		t.Error("NeedsPlanetRefresh should use PlanetCacheTTL without full series coverage")
+	}
+}
+
+func TestSolarSystemCache_SetConfig(t *testing.T) {
+	cache := NewSolarSystemCache(nil)
+
+	cache.SetConfig(SolarSystemConfig{PlanetRefresh: 2 * time.Hour})
+	cfg := cache.Config()
+	if cfg.PlanetRefresh != 2*time.Hour {
+		t.Errorf("PlanetRefresh = %v, want 2h", cfg.PlanetRefresh)
+	}
+	if cfg.SpacecraftRefresh != SpacecraftCacheTTL {
+		t.Errorf("SpacecraftRefresh = %v, want default %v", cfg.SpacecraftRefresh, SpacecraftCacheTTL)
+	}
+
+	if err := cache.UpdatePlanets(); err != nil {
+		t.Fatalf("UpdatePlanets: %v", err)
+	}
+	if cache.NeedsPlanetRefresh() {
+		t.Error("NeedsPlanetRefresh should be false right after update")
+	}
+
+	cache.mu.Lock()
+	cache.lastPlanetUpdate = time.Now().Add(-3 * time.Hour)
+	cache.mu.Unlock()
+	if !cache.NeedsPlanetRefresh() {
+		t.Error("NeedsPlanetRefresh should be true once the configured interval elapses")
+	}
+	if cache.GetSnapshot().PlanetsUpdated.IsZero() {
+		t.Error("PlanetsUpdated should be set after UpdatePlanets")
+	}
+}
+
+func TestApparentPosition(t *testing.T) {
+	// A body 10 AU from Earth moving 0.01 AU/h along Y: light takes about
+	// 83 minutes, so Earth sees it that much further back
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	var s vectorSeries
+	for h := 0; h <= 12; h++ {
+		s.times = append(s.times, t0.Add(time.Duration(h)*time.Hour))
+		s.pos = append(s.pos, astro.Vec3{X: 11, Y: 0.01 * float64(h)})
+	}
+	earth := astro.Vec3{X: 1}
+	at := t0.Add(6 * time.Hour)
+
+	pos, lt, ok := apparentPosition(s, earth, at)
+	if !ok {
+		t.Fatal("no apparent position")
+	}
+	wantLT := time.Duration(astro.LightTimeFromAU(10) * float64(time.Second))
+	if d := lt - wantLT; d < -time.Second || d > time.Second {
+		t.Errorf("light time = %v, want %v", lt, wantLT)
+	}
+	wantY := 0.01 * (6 - lt.Hours())
+	if math.Abs(pos.Y-wantY) > 1e-9 {
+		t.Errorf("apparent Y = %v, want %v", pos.Y, wantY)
+	}
+
+	// The series must reach back past the light time
+	if _, _, ok := apparentPosition(s, earth, t0.Add(time.Hour)); ok {
+		t.Error("apparent position from before the series")
+	}
+}
+
+func TestSolarSystemCache_UpdateSpacecraftVectors(t *testing.T) {
+	epoch := time.Now().Add(-48 * time.Hour)
+	provider := &fakeSeriesProvider{epoch: epoch}
+	cache := NewSolarSystemCache(provider)
+	data := &DSNData{Links: []Link{
+		{Complex: ComplexCanberra, AntennaID: "DSS43", Spacecraft: "VGR2", SpacecraftID: 32, Band: "S", DataRate: 160, Distance: 20e9},
+	}}
+
+	if err := cache.UpdateSpacecraft(data); err != nil {
+		t.Fatal(err)
+	}
+	if !cache.NeedsSpacecraftVectorRefresh() {
+		t.Fatal("a tracked spacecraft without vectors should need a refresh")
+	}
+	if err := cache.UpdateSpacecraftVectors(); err != nil {
+		t.Fatalf("UpdateSpacecraftVectors: %v", err)
+	}
+	if provider.seriesCalls != 1 {
+		t.Errorf("series calls = %d, want 1", provider.seriesCalls)
+	}
+	if cache.NeedsSpacecraftVectorRefresh() {
+		t.Error("NeedsSpacecraftVectorRefresh should be false right after an update")
+	}
+
+	// Plotted from its vectors rather than the feed
+	at := time.Now().Add(time.Hour)
+	vgr := cache.SnapshotAt(at).GetBody("VGR2")
+	if vgr == nil {
+		t.Fatal("VGR2 missing from snapshot")
+	}
+	if want := at.Sub(epoch).Hours(); math.Abs(vgr.Pos.X-want) > 1e-6 {
+		t.Errorf("VGR2 X = %v, want %v", vgr.Pos.X, want)
+	}
+
+	// A newly tracked spacecraft is fetched alone; a failed fetch is
+	// reported and not retried until the next refresh
+	provider.failSeriesID = -31
+	cache.UpdateSpacecraft(&DSNData{Links: []Link{
+		{Complex: ComplexCanberra, AntennaID: "DSS43", Spacecraft: "VGR2", SpacecraftID: 32, Band: "S", DataRate: 160, Distance: 20e9},
+		{Complex: ComplexMadrid, AntennaID: "DSS63", Spacecraft: "VGR1", SpacecraftID: 31, Band: "X", DataRate: 160, Distance: 24e9},
+	}})
+	if err := cache.UpdateSpacecraftVectors(); !errors.Is(err, errTestSeries) {
+		t.Errorf("VGR1 failure: %v", err)
+	}
+	if provider.seriesCalls != 2 {
+		t.Errorf("series calls = %d, want 2 (VGR1 only)", provider.seriesCalls)
+	}
+	if cache.NeedsSpacecraftVectorRefresh() {
+		t.Error("a failed fetch should wait for the next refresh")
+	}
+}
@@ -1,72 +1,80 @@
 // Package dsn spacecraft catalog with full names and metadata.
 package dsn
 
+import (
+	"fmt"
+	"strings"
+)
+
 // SpacecraftInfo contains metadata about a spacecraft.
 type SpacecraftInfo struct {
 	Name   string // Full mission name
 	Agency string // Operating agency (NASA, ESA, JAXA, etc.)
 	Target string // Mission target (Mars, Jupiter, Deep Space, etc.)
 	Launch string // Launch year
+	NAIFID int    // NAIF SPICE ID (negative for spacecraft), 0 if unknown
+	COSPAR string // COSPAR international designator, as used by NSSDC
 }
 
 // SpacecraftCatalog maps short codes to spacecraft info.
 // Data sourced from NASA DSN Now and mission pages.
 var SpacecraftCatalog = map[string]SpacecraftInfo{
 	// Active Deep Space Missions
-	"JWST":  {Name: "James Webb Space Telescope", Agency: "NASA/ESA/CSA", Target: "L2 Orbit", Launch: "2021"},
-	"VGR1":  {Name: "Voyager 1", Agency: "NASA", Target: "Interstellar", Launch: "1977"},
-	"VGR2":  {Name: "Voyager 2", Agency: "NASA", Target: "Interstellar", Launch: "1977"},
-	"MRO":   {Name: "Mars Reconnaissance Orbiter", Agency: "NASA", Target: "Mars", Launch: "2005"},
-	"MAVEN": {Name: "Mars Atmosphere & Volatile Evolution", Agency: "NASA", Target: "Mars", Launch: "2013"},
-	"MSL":   {Name: "Curiosity Rover", Agency: "NASA", Target: "Mars", Launch: "2011"},
-	"M20":   {Name: "Perseverance Rover", Agency: "NASA", Target: "Mars", Launch: "2020"},
-	"MVN":   {Name: "Mars Atmosphere & Volatile Evolution", Agency: "NASA", Target: "Mars", Launch: "2013"},
-	"ODY":   {Name: "Mars Odyssey", Agency: "NASA", Target: "Mars", Launch: "2001"},
-	"JUNO":  {Name: "Juno", Agency: "NASA", Target: "Jupiter", Launch: "2011"},
-	"JNO":   {Name: "Juno", Agency: "NASA", Target: "Jupiter", Launch: "2011"},
-	"NHPC":  {Name: "New Horizons", Agency: "NASA", Target: "Kuiper Belt", Launch: "2006"},
-	"NH":    {Name: "New Horizons", Agency: "NASA", Target: "Kuiper Belt", Launch: "2006"},
-	"LUCY":  {Name: "Lucy", Agency: "NASA", Target: "Trojan Asteroids", Launch: "2021"},
-	"PSYC":  {Name: "Psyche", Agency: "NASA", Target: "16 Psyche Asteroid", Launch: "2023"},
-	"EURC":  {Name: "Europa Clipper", Agency: "NASA", Target: "Jupiter/Europa", Launch: "2024"},
-	"EMM":   {Name: "Hope Mars Mission", Agency: "UAE", Target: "Mars", Launch: "2020"},
-	"TGO":   {Name: "ExoMars Trace Gas Orbiter", Agency: "ESA/Roscosmos", Target: "Mars", Launch: "2016"},
-	"MEX":   {Name: "Mars Express", Agency: "ESA", Target: "Mars", Launch: "2003"},
-	"ROSE":  {Name: "Rosetta", Agency: "ESA", Target: "Comet", Launch: "2004"},
-	"GAIA":  {Name: "Gaia", Agency: "ESA", Target: "L2 Orbit", Launch: "2013"},
-	"BEPI":  {Name: "BepiColombo", Agency: "ESA/JAXA", Target: "Mercury", Launch: "2018"},
-	"SOLO":  {Name: "Solar Orbiter", Agency: "ESA/NASA", Target: "Sun", Launch: "2020"},
-	"JUICE": {Name: "Jupiter Icy Moons Explorer", Agency: "ESA", Target: "Jupiter", Launch: "2023"},
+	"JWST":  {Name: "James Webb Space Telescope", Agency: "NASA/ESA/CSA", Target: "L2 Orbit", Launch: "2021", NAIFID: -170, COSPAR: "2021-130A"},
+	"VGR1":  {Name: "Voyager 1", Agency: "NASA", Target: "Interstellar", Launch: "1977", NAIFID: -31, COSPAR: "1977-084A"},
+	"VGR2":  {Name: "Voyager 2", Agency: "NASA", Target: "Interstellar", Launch: "1977", NAIFID: -32, COSPAR: "1977-076A"},
+	"MRO":   {Name: "Mars Reconnaissance Orbiter", Agency: "NASA", Target: "Mars", Launch: "2005", NAIFID: -74, COSPAR: "2005-029A"},
+	"MAVEN": {Name: "Mars Atmosphere & Volatile Evolution", Agency: "NASA", Target: "Mars", Launch: "2013", NAIFID: -202, COSPAR: "2013-063A"},
+	"MSL":   {Name: "Curiosity Rover", Agency: "NASA", Target: "Mars", Launch: "2011", NAIFID: -76, COSPAR: "2011-070A"},
+	"M20":   {Name: "Perseverance Rover", Agency: "NASA", Target: "Mars", Launch: "2020", NAIFID: -168, COSPAR: "2020-052A"},
+	"MVN":   {Name: "Mars Atmosphere & Volatile Evolution", Agency: "NASA", Target: "Mars", Launch: "2013", NAIFID: -202, COSPAR: "2013-063A"},
+	"ODY":   {Name: "Mars Odyssey", Agency: "NASA", Target: "Mars", Launch: "2001", NAIFID: -53, COSPAR: "2001-014A"},
+	"JUNO":  {Name: "Juno", Agency: "NASA", Target: "Jupiter", Launch: "2011", NAIFID: -61, COSPAR: "2011-040A"},
+	"JNO":   {Name: "Juno", Agency: "NASA", Target: "Jupiter", Launch: "2011", NAIFID: -61, COSPAR: "2011-040A"},
+	"NHPC":  {Name: "New Horizons", Agency: "NASA", Target: "Kuiper Belt", Launch: "2006", NAIFID: -98, COSPAR: "2006-001A"},
+	"NH":    {Name: "New Horizons", Agency: "NASA", Target: "Kuiper Belt", Launch: "2006", NAIFID: -98, COSPAR: "2006-001A"},
+	"LUCY":  {Name: "Lucy", Agency: "NASA", Target: "Trojan Asteroids", Launch: "2021", NAIFID: -49, COSPAR: "2021-093A"},
+	"PSYC":  {Name: "Psyche", Agency: "NASA", Target: "16 Psyche Asteroid", Launch: "2023", NAIFID: -255, COSPAR: "2023-157A"},
+	"EURC":  {Name: "Europa Clipper", Agency: "NASA", Target: "Jupiter/Europa", Launch: "2024", NAIFID: -159, COSPAR: "2024-182A"},
+	"EMM":   {Name: "Hope Mars Mission", Agency: "UAE", Target: "Mars", Launch: "2020", NAIFID: -211, COSPAR: "2020-047A"},
+	"TGO":   {Name: "ExoMars Trace Gas Orbiter", Agency: "ESA/Roscosmos", Target: "Mars", Launch: "2016", NAIFID: -143, COSPAR: "2016-017A"},
+	"MEX":   {Name: "Mars Express", Agency: "ESA", Target: "Mars", Launch: "2003", NAIFID: -41, COSPAR: "2003-022A"},
+	"ROSE":  {Name: "Rosetta", Agency: "ESA", Target: "Comet", Launch: "2004", NAIFID: -226, COSPAR: "2004-006A"},
+	"GAIA":  {Name: "Gaia", Agency: "ESA", Target: "L2 Orbit", Launch: "2013", NAIFID: -123, COSPAR: "2013-074A"},
+	"BEPI":  {Name: "BepiColombo", Agency: "ESA/JAXA", Target: "Mercury", Launch: "2018", NAIFID: -121, COSPAR: "2018-080A"},
+	"HYB2":  {Name: "Hayabusa2", Agency: "JAXA", Target: "Asteroid 1998 KY26", Launch: "2014", NAIFID: -37, COSPAR: "2014-076A"},
+	"SOLO":  {Name: "Solar Orbiter", Agency: "ESA/NASA", Target: "Sun", Launch: "2020", NAIFID: -144, COSPAR: "2020-010A"},
+	"JUICE": {Name: "Jupiter Icy Moons Explorer", Agency: "ESA", Target: "Jupiter", Launch: "2023", NAIFID: -28, COSPAR: "2023-053A"},
 
 	// Solar & Heliophysics
-	"SOHO": {Name: "Solar & Heliospheric Observatory", Agency: "ESA/NASA", Target: "Sun/L1", Launch: "1995"},
-	"ACE":  {Name: "Advanced Composition Explorer", Agency: "NASA", Target: "L1 Orbit", Launch: "1997"},
-	"WIND": {Name: "WIND", Agency: "NASA", Target: "L1 Orbit", Launch: "1994"},
-	"DSCO": {Name: "Deep Space Climate Observatory", Agency: "NASA/NOAA", Target: "L1 Orbit", Launch: "2015"},
-	"STA":  {Name: "STEREO-A", Agency: "NASA", Target: "Solar Orbit", Launch: "2006"},
-	"STB":  {Name: "STEREO-B", Agency: "NASA", Target: "Solar Orbit", Launch: "2006"},
-	"SPP":  {Name: "Parker Solar Probe", Agency: "NASA", Target: "Sun", Launch: "2018"},
-	"PSP":  {Name: "Parker Solar Probe", Agency: "NASA", Target: "Sun", Launch: "2018"},
+	"SOHO": {Name: "Solar & Heliospheric Observatory", Agency: "ESA/NASA", Target: "Sun/L1", Launch: "1995", NAIFID: -21, COSPAR: "1995-065A"},
+	"ACE":  {Name: "Advanced Composition Explorer", Agency: "NASA", Target: "L1 Orbit", Launch: "1997", NAIFID: -92, COSPAR: "1997-045A"},
+	"WIND": {Name: "WIND", Agency: "NASA", Target: "L1 Orbit", Launch: "1994", NAIFID: -8, COSPAR: "1994-071A"},
+	"DSCO": {Name: "Deep Space Climate Observatory", Agency: "NASA/NOAA", Target: "L1 Orbit", Launch: "2015", NAIFID: -146, COSPAR: "2015-007A"},
+	"STA":  {Name: "STEREO-A", Agency: "NASA", Target: "Solar Orbit", Launch: "2006", NAIFID: -234, COSPAR: "2006-047A"},
+	"STB":  {Name: "STEREO-B", Agency: "NASA", Target: "Solar Orbit", Launch: "2006", NAIFID: -235, COSPAR: "2006-047B"},
+	"SPP":  {Name: "Parker Solar Probe", Agency: "NASA", Target: "Sun", Launch: "2018", NAIFID: -96, COSPAR: "2018-065A"},
+	"PSP":  {Name: "Parker Solar Probe", Agency: "NASA", Target: "Sun", Launch: "2018", NAIFID: -96, COSPAR: "2018-065A"},
 
 	// Lunar Missions
-	"LRO":   {Name: "Lunar Reconnaissance Orbiter", Agency: "NASA", Target: "Moon", Launch: "2009"},
-	"LCROS": {Name: "Lunar Crater Observation", Agency: "NASA", Target: "Moon", Launch: "2009"},
-	"KPLO":  {Name: "Korea Pathfinder Lunar Orbiter", Agency: "KARI", Target: "Moon", Launch: "2022"},
-	"SLIM":  {Name: "Smart Lander for Investigating Moon", Agency: "JAXA", Target: "Moon", Launch: "2023"},
-	"CH2":   {Name: "Chandrayaan-2 Orbiter", Agency: "ISRO", Target: "Moon", Launch: "2019"},
-	"CH3":   {Name: "Chandrayaan-3", Agency: "ISRO", Target: "Moon", Launch: "2023"},
-	"CAPS":  {Name: "Capstone", Agency: "NASA", Target: "Moon", Launch: "2022"},
+	"LRO":   {Name: "Lunar Reconnaissance Orbiter", Agency: "NASA", Target: "Moon", Launch: "2009", NAIFID: -85, COSPAR: "2009-031A"},
+	"LCROS": {Name: "Lunar Crater Observation", Agency: "NASA", Target: "Moon", Launch: "2009", COSPAR: "2009-031B"},
+	"KPLO":  {Name: "Korea Pathfinder Lunar Orbiter", Agency: "KARI", Target: "Moon", Launch: "2022", NAIFID: -155, COSPAR: "2022-094A"},
+	"SLIM":  {Name: "Smart Lander for Investigating Moon", Agency: "JAXA", Target: "Moon", Launch: "2023", NAIFID: -157, COSPAR: "2023-137D"},
+	"CH2":   {Name: "Chandrayaan-2 Orbiter", Agency: "ISRO", Target: "Moon", Launch: "2019", COSPAR: "2019-042A"},
+	"CH3":   {Name: "Chandrayaan-3", Agency: "ISRO", Target: "Moon", Launch: "2023", NAIFID: -158, COSPAR: "2023-098A"},
+	"CAPS":  {Name: "Capstone", Agency: "NASA", Target: "Moon", Launch: "2022", NAIFID: -186, COSPAR: "2022-070A"},
 
 	// Earth Orbiters tracked by DSN
-	"TESS": {Name: "Transiting Exoplanet Survey Satellite", Agency: "NASA", Target: "Earth Orbit", Launch: "2018"},
-	"IXPE": {Name: "Imaging X-ray Polarimetry Explorer", Agency: "NASA", Target: "Earth Orbit", Launch: "2021"},
-	"SWOT": {Name: "Surface Water & Ocean Topography", Agency: "NASA/CNES", Target: "Earth Orbit", Launch: "2022"},
+	"TESS": {Name: "Transiting Exoplanet Survey Satellite", Agency: "NASA", Target: "Earth Orbit", Launch: "2018", NAIFID: -95, COSPAR: "2018-038A"},
+	"IXPE": {Name: "Imaging X-ray Polarimetry Explorer", Agency: "NASA", Target: "Earth Orbit", Launch: "2021", NAIFID: -196, COSPAR: "2021-121A"},
+	"SWOT": {Name: "Surface Water & Ocean Topography", Agency: "NASA/CNES", Target: "Earth Orbit", Launch: "2022", NAIFID: -152, COSPAR: "2022-173A"},
 
 	// Historic/Inactive (may still appear in DSN)
-	"CAS":    {Name: "Cassini", Agency: "NASA/ESA", Target: "Saturn", Launch: "1997"},
-	"DAWN":   {Name: "Dawn", Agency: "NASA", Target: "Vesta/Ceres", Launch: "2007"},
-	"KEPLER": {Name: "Kepler", Agency: "NASA", Target: "Earth Orbit", Launch: "2009"},
-	"SPITZ":  {Name: "Spitzer Space Telescope", Agency: "NASA", Target: "Earth Orbit", Launch: "2003"},
+	"CAS":    {Name: "Cassini", Agency: "NASA/ESA", Target: "Saturn", Launch: "1997", NAIFID: -82, COSPAR: "1997-061A"},
+	"DAWN":   {Name: "Dawn", Agency: "NASA", Target: "Vesta/Ceres", Launch: "2007", NAIFID: -203, COSPAR: "2007-043A"},
+	"KEPLER": {Name: "Kepler", Agency: "NASA", Target: "Earth Orbit", Launch: "2009", COSPAR: "2009-011A"},
+	"SPITZ":  {Name: "Spitzer Space Telescope", Agency: "NASA", Target: "Earth Orbit", Launch: "2003", NAIFID: -79, COSPAR: "2003-038A"},
 }
 
 // GetSpacecraftInfo returns info for a spacecraft code, or nil if unknown.
@@ -84,3 +92,85 @@ func GetSpacecraftName(code string) string {
 	}
 	return code
 }
+
+// catalogAliases maps alternate DSN codes to the canonical catalog code.
+var catalogAliases = map[string]string{
+	"MVN": "MAVEN",
+	"JNO": "JUNO",
+	"NH":  "NHPC",
+	"PSP": "SPP",
+}
+
+// CanonicalCode returns the canonical catalog code for a DSN spacecraft
+// code, resolving alternate codes (e.g. "JNO" → "JUNO").
+func CanonicalCode(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if canon, ok := catalogAliases[code]; ok {
+		return canon
+	}
+	return code
+}
+
+// SpacecraftCodeForNAIF returns the canonical catalog code of the
+// spacecraft with a NAIF ID, given with either sign as the feed's signals
+// and targets do.
+func SpacecraftCodeForNAIF(id int) (string, bool) {
+	if id == 0 {
+		return "", false
+	}
+	if id > 0 {
+		id = -id
+	}
+	var code string
+	for c, info := range SpacecraftCatalog {
+		if info.NAIFID != id {
+			continue
+		}
+		// Aliases share their canonical entry's ID; prefer the canonical
+		// code, and the first name otherwise so the answer is stable
+		if _, alias := catalogAliases[c]; !alias && (code == "" || c < code) {
+			code = c
+		}
+	}
+	return code, code != ""
+}
+
+// Reference URL templates for external spacecraft datasets.
+const (
+	horizonsLookupURL = "https://ssd.jpl.nasa.gov/api/horizons.api?format=text&COMMAND='%d'&OBJ_DATA=YES&MAKE_EPHEM=NO"
+	nssdcLookupURL    = "https://nssdc.gsfc.nasa.gov/nmc/spacecraft/display.action?id=%s"
+)
+
+// SpacecraftRef holds stable identifiers for joining a spacecraft against
+// external datasets. Fields are empty when the spacecraft is not in the
+// catalog or the identifier is unknown.
+type SpacecraftRef struct {
+	Code        string `json:"code,omitempty"`
+	NAIFID      int    `json:"naif_id,omitempty"`
+	COSPAR      string `json:"cospar_id,omitempty"`
+	HorizonsURL string `json:"horizons_url,omitempty"`
+	NSSDCURL    string `json:"nssdc_url,omitempty"`
+}
+
+// GetSpacecraftRef returns the stable identifiers and reference URLs for a
+// DSN spacecraft code.
+func GetSpacecraftRef(code string) SpacecraftRef {
+	canon := CanonicalCode(code)
+	info, ok := SpacecraftCatalog[canon]
+	if !ok {
+		return SpacecraftRef{}
+	}
+
+	ref := SpacecraftRef{
+		Code:   canon,
+		NAIFID: info.NAIFID,
+		COSPAR: info.COSPAR,
+	}
+	if info.NAIFID != 0 {
+		ref.HorizonsURL = fmt.Sprintf(horizonsLookupURL, info.NAIFID)
+	}
+	if info.COSPAR != "" {
+		ref.NSSDCURL = fmt.Sprintf(nssdcLookupURL, info.COSPAR)
+	}
+	return ref
+}
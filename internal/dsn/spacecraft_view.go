@@ -2,6 +2,7 @@
 package dsn
 
 import (
+	"fmt"
 	"sort"
 	"strings"
 
@@ -14,14 +15,27 @@ type SkyCoord = astro.SkyCoord
 
 // LinkView represents a single antenna-to-spacecraft link.
 type LinkView struct {
-	Station    string  // e.g., "DSS34"
-	Complex    Complex // e.g., ComplexCanberra
-	Band       string  // e.g., "X", "S", "Ka"
-	Rate       float64 // Data rate in bps
-	DistanceKm float64 // Distance in km
-	Struggle   float64 // Struggle index 0-1 (lower = healthier)
-	AzDeg      float64 // Azimuth from this antenna
-	ElDeg      float64 // Elevation from this antenna
+	Station    string   // e.g., "DSS34"
+	Complex    Complex  // e.g., ComplexCanberra
+	Band       string   // e.g., "X", "S", "Ka"
+	Rate       float64  // Data rate in bps
+	Carrier    bool     // Carrier lock only; Rate is zero by design
+	Uplink     bool     // Active data uplink (commanding)
+	Downlink   bool     // Active downlink (receiving)
+	Inferred   bool     // Identified by NAIF ID or recent history (see Link.Inferred)
+	DownRate   float64  // Downlink data rate in bps
+	UpRate     float64  // Uplink data rate in bps
+	DistanceKm float64  // Distance in km
+	SNR        float64  // Estimated downlink Pr/N0 in dB-Hz (0 = no power reported)
+	MSPA       bool     // Antenna shared with other spacecraft (see MSPAGroup)
+	Share      float64  // Part of the antenna's combined data rate (1 when dedicated)
+	SharedWith []string // Other spacecraft on an MSPA antenna
+	Wind       float64  // Wind at the antenna in km/h
+	WindRisk   WindRisk // Wind under the data's WindLimits
+	Baseline   float64  // Typical rate for the spacecraft on this band in bps (0 = unknown)
+	Struggle   float64  // Struggle index 0-1 (lower = healthier)
+	AzDeg      float64  // Azimuth from this antenna
+	ElDeg      float64  // Elevation from this antenna
 }
 
 // SpacecraftView represents a single spacecraft with all its active links.
@@ -35,6 +49,68 @@ type SpacecraftView struct {
 	PrimaryLink LinkView   // The link used for summary/position (highest priority)
 }
 
+// RateShortfall returns how far below its baseline the link's rate is
+// (see RateShortfall).
+func (lv LinkView) RateShortfall() float64 {
+	return RateShortfall(lv.Rate, lv.Baseline)
+}
+
+// FormatSNR returns the link's estimated Pr/N0, or "-" when the feed
+// reports no downlink power.
+func (lv LinkView) FormatSNR() string {
+	if lv.SNR == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f dB-Hz", lv.SNR)
+}
+
+// FormatRate returns the link's data rate, or CarrierLockLabel for a
+// carrier lock.
+func (lv LinkView) FormatRate() string {
+	if lv.Carrier {
+		return CarrierLockLabel
+	}
+	return FormatDataRate(lv.Rate)
+}
+
+// FormatDown returns the link's downlink with an arrow, "↓ 160 bps" or
+// "↓ carrier lock", or "-" when the antenna isn't receiving.
+func (lv LinkView) FormatDown() string {
+	switch {
+	case lv.Carrier:
+		return "↓ " + CarrierLockLabel
+	case lv.Downlink || lv.DownRate > 0:
+		return "↓ " + FormatDataRate(lv.DownRate)
+	default:
+		return "-"
+	}
+}
+
+// FormatUp returns the link's commanding uplink with an arrow, "↑ 16.0 bps"
+// or "↑ cmd" without a reported rate, or "-" when not commanding.
+func (lv LinkView) FormatUp() string {
+	switch {
+	case !lv.Uplink:
+		return "-"
+	case lv.UpRate > 0:
+		return "↑ " + FormatDataRate(lv.UpRate)
+	default:
+		return "↑ cmd"
+	}
+}
+
+// Direction returns the link's direction (see Link.Direction); a carrier
+// lock counts as receiving.
+func (lv LinkView) Direction() string {
+	return linkDirection(lv.Downlink || lv.Carrier, lv.Uplink)
+}
+
+// FormatShare returns the link's rate allocation, "shared 80%" or
+// "dedicated".
+func (lv LinkView) FormatShare() string {
+	return FormatShare(lv.MSPA, lv.Share)
+}
+
 // Coord returns the sky coordinates for this spacecraft.
 // Currently derived from PrimaryLink az/el; future implementations
 // may use JPL Horizons or other ephemeris sources.
@@ -64,6 +140,16 @@ func (sv SpacecraftView) AntennaList() string {
 	return strings.Join(stations, "+")
 }
 
+// Uplinking reports whether any antenna is commanding this spacecraft.
+func (sv SpacecraftView) Uplinking() bool {
+	for _, l := range sv.Links {
+		if l.Uplink {
+			return true
+		}
+	}
+	return false
+}
+
 // IsArrayed returns true if multiple antennas are tracking this spacecraft.
 func (sv SpacecraftView) IsArrayed() bool {
 	return len(sv.Links) > 1
@@ -87,6 +173,13 @@ func BuildSpacecraftViews(data *DSNData, elevationMap map[string]float64) []Spac
 
 	// Group links by spacecraft
 	groups := make(map[int]*SpacecraftView)
+	mspa := mspaByAntenna(data)
+	wind := make(map[string]float64)
+	for _, st := range data.Stations {
+		for _, ant := range st.Antennas {
+			wind[ant.ID] = ant.WindSpeed
+		}
+	}
 
 	for _, link := range data.Links {
 		// Skip internal DSN/DSS targets
@@ -94,8 +187,8 @@ func BuildSpacecraftViews(data *DSNData, elevationMap map[string]float64) []Spac
 			continue
 		}
 
-		// Get elevation for this antenna
-		elevation := elevationMap[link.AntennaID]
+		// Get elevation for this link
+		elevation := LinkElevation(link, elevationMap)
 
 		// Skip below horizon
 		if elevation < 0 {
@@ -103,7 +196,7 @@ func BuildSpacecraftViews(data *DSNData, elevationMap map[string]float64) []Spac
 		}
 
 		// Calculate struggle index
-		struggle := StruggleIndex(link, elevation)
+		struggle := data.ActiveHealthModel().Struggle(link, elevation)
 
 		// Create LinkView
 		lv := LinkView{
@@ -111,19 +204,44 @@ func BuildSpacecraftViews(data *DSNData, elevationMap map[string]float64) []Spac
 			Complex:    link.Complex,
 			Band:       link.Band,
 			Rate:       link.DataRate,
+			Carrier:    link.CarrierOnly(),
+			Uplink:     link.Uplink,
+			Downlink:   link.Downlink,
+			Inferred:   link.Inferred,
+			DownRate:   link.DownRate,
+			UpRate:     link.UpRate,
 			DistanceKm: link.Distance,
 			Struggle:   struggle,
 			AzDeg:      elevation, // Will be set from antenna data
 			ElDeg:      elevation,
 		}
+		if snr, ok := link.SNR(); ok {
+			lv.SNR = snr
+		}
+		lv.Wind = wind[link.AntennaID]
+		lv.WindRisk = data.ActiveWindLimits().Risk(lv.Wind)
+		lv.Baseline, _ = BundledRateBaseline(link.Spacecraft, link.Band)
+		lv.Share = 1
+		if g, ok := mspa[link.AntennaID]; ok {
+			lv.MSPA, lv.Share = true, g.Share(link)
+			for _, code := range g.Spacecraft() {
+				if code != link.Spacecraft {
+					lv.SharedWith = append(lv.SharedWith, code)
+				}
+			}
+		}
 
-		// Get azimuth from antenna data if available
-		for _, station := range data.Stations {
-			for _, ant := range station.Antennas {
-				if ant.ID == link.AntennaID {
-					lv.AzDeg = ant.Azimuth
-					lv.ElDeg = ant.Elevation
-					break
+		// Get azimuth from the link's dish, or antenna data if available
+		if link.Pointing.Valid {
+			lv.AzDeg = link.Pointing.AzDeg
+		} else {
+			for _, station := range data.Stations {
+				for _, ant := range station.Antennas {
+					if ant.ID == link.AntennaID {
+						lv.AzDeg = ant.Azimuth
+						lv.ElDeg = ant.Elevation
+						break
+					}
 				}
 			}
 		}
@@ -201,6 +319,16 @@ func linkBetter(a, b LinkView) bool {
 	return a.Station < b.Station
 }
 
+// LinkElevation returns the elevation of the dish carrying a link's target,
+// falling back to elevMap (from BuildElevationMap) by antenna ID for links
+// built without pointing.
+func LinkElevation(link Link, elevMap map[string]float64) float64 {
+	if link.Pointing.Valid {
+		return link.Pointing.ElDeg
+	}
+	return elevMap[link.AntennaID]
+}
+
 // BuildElevationMap creates a map of antenna ID to elevation from DSN data.
 func BuildElevationMap(data *DSNData) map[string]float64 {
 	elevMap := make(map[string]float64)
@@ -1,6 +1,7 @@
 package dsn
 
 import (
+	"os"
 	"testing"
 )
 
@@ -273,6 +274,33 @@ func TestSpacecraftView_Coord(t *testing.T) {
 	}
 }
 
+func TestLinkView_Directions(t *testing.T) {
+	tests := []struct {
+		name           string
+		lv             LinkView
+		down, up, want string
+	}{
+		{"receiving", LinkView{Downlink: true, DownRate: 160}, "↓ 160 bps", "-", DirectionDown},
+		{"both", LinkView{Downlink: true, DownRate: 160, Uplink: true, UpRate: 16}, "↓ 160 bps", "↑ 16.0 bps", DirectionBoth},
+		{"commanding only", LinkView{Uplink: true}, "-", "↑ cmd", DirectionUp},
+		{"carrier lock", LinkView{Carrier: true}, "↓ " + CarrierLockLabel, "-", DirectionDown},
+		{"idle", LinkView{}, "-", "-", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.lv.FormatDown(); got != tt.down {
+				t.Errorf("FormatDown = %q, want %q", got, tt.down)
+			}
+			if got := tt.lv.FormatUp(); got != tt.up {
+				t.Errorf("FormatUp = %q, want %q", got, tt.up)
+			}
+			if got := tt.lv.Direction(); got != tt.want {
+				t.Errorf("Direction = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestBuildElevationMap(t *testing.T) {
 	data := &DSNData{
 		Stations: []Station{
@@ -312,3 +340,64 @@ func TestBuildElevationMap_Nil(t *testing.T) {
 		t.Errorf("expected empty map, got %d entries", len(elevMap))
 	}
 }
+
+func TestLinkElevation_MSPAFeed(t *testing.T) {
+	raw, err := os.ReadFile("testdata/mspa.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := Parse(raw)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	// The fixture, hand-written in the feed's format, has two MSPA
+	// apertures sharing MVN, a two-dish array on NHPC, and VGR1 at Madrid
+	dishElevation := map[string]float64{
+		"DSS24": 36.2, "DSS26": 36.1, "DSS35": 28.3, "DSS43": 28.6, "DSS65": 12.8,
+	}
+	elevMap := BuildElevationMap(data)
+	for _, link := range data.Links {
+		if got, want := LinkElevation(link, elevMap), dishElevation[link.AntennaID]; got != want {
+			t.Errorf("%s on %s: elevation = %v, want %v", link.Spacecraft, link.AntennaID, got, want)
+		}
+	}
+
+	for _, link := range data.Links {
+		if link.Spacecraft == "VGR1" && link.Complex != ComplexMadrid {
+			t.Errorf("VGR1 complex = %q, want Madrid", link.Complex)
+		}
+	}
+
+	export := ExportSnapshot(data, data.Timestamp)
+	for _, l := range export.Links {
+		if l.Spacecraft == "VGR1" && l.Elevation != 12.8 {
+			t.Errorf("VGR1 export elevation = %v, want 12.8 from its dish", l.Elevation)
+		}
+	}
+
+	views := BuildSpacecraftViews(data, elevMap)
+	for _, sv := range views {
+		for _, lv := range sv.Links {
+			if lv.ElDeg != dishElevation[lv.Station] {
+				t.Errorf("%s view on %s: El = %v, want %v", sv.Code, lv.Station, lv.ElDeg, dishElevation[lv.Station])
+			}
+		}
+		if sv.Code == "VGR1" && sv.PrimaryLink.AzDeg != 251.7 {
+			t.Errorf("VGR1 Az = %v, want 251.7", sv.PrimaryLink.AzDeg)
+		}
+	}
+}
+
+func TestLinkElevation_Fallback(t *testing.T) {
+	elevMap := map[string]float64{"DSS14": 40}
+
+	// Links built without a dish fall back to the antenna lookup
+	if got := LinkElevation(Link{AntennaID: "DSS14"}, elevMap); got != 40 {
+		t.Errorf("fallback elevation = %v, want 40", got)
+	}
+	link := Link{AntennaID: "DSS14", Pointing: Pointing{ElDeg: 12, Valid: true}}
+	if got := LinkElevation(link, elevMap); got != 12 {
+		t.Errorf("pointed elevation = %v, want 12", got)
+	}
+}
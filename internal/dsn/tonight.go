@@ -0,0 +1,123 @@
+package dsn
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/astro"
+)
+
+// NightSunElevation is the Sun elevation below which it counts as night
+// (end of civil twilight, degrees).
+const NightSunElevation = -6.0
+
+// NightWindow returns tonight's dark period for an observer: from now if it
+// is already dark (otherwise from the next dusk) until the following dawn.
+// ok is false if the Sun stays up for the next 24h (polar summer).
+func NightWindow(obs astro.Observer, now time.Time) (start, end time.Time, ok bool) {
+	dark := func(t time.Time) bool {
+		ra, dec := astro.SunPosition(t)
+		h := astro.EquatorialToHorizontal(astro.SkyCoord{RAdeg: ra, DecDeg: dec}, obs, t)
+		return h.ElDeg < NightSunElevation
+	}
+
+	limit := now.Add(PassWindowDuration)
+	t := now
+	for !dark(t) {
+		if t.After(limit) {
+			return time.Time{}, time.Time{}, false
+		}
+		t = t.Add(PassSampleInterval)
+	}
+	start = t
+
+	// Polar night: cap the window at a day
+	limit = start.Add(PassWindowDuration)
+	for dark(t) && t.Before(limit) {
+		t = t.Add(PassSampleInterval)
+	}
+	return start, t, true
+}
+
+// ComputeObserverPasses finds when a spacecraft is above the visible
+// horizon of an arbitrary observer, lowered by the dip for its height.
+// Passes have no Complex.
+func ComputeObserverPasses(obs astro.Observer, samples []astro.RADecAtTime, now time.Time) []Pass {
+	if len(samples) < 3 {
+		return nil
+	}
+	passes := computePasses("", obs, obs.Horizon(), samples, now)
+	classifyPasses(passes, now)
+	return passes
+}
+
+// TonightRow is one spacecraft's passes over a personal observer.
+type TonightRow struct {
+	Spacecraft string
+	Passes     []Pass
+	Err        error // ephemeris unavailable
+}
+
+// WriteTonight prints which tracked spacecraft are above the observer's
+// horizon during the night window, with rise and set times in loc.
+func WriteTonight(w io.Writer, obs astro.Observer, start, end time.Time, rows []TonightRow, loc *time.Location) {
+	fmt.Fprintf(w, "Tonight from %.3f°, %.3f°  %s → %s\n",
+		obs.LatDeg, obs.LonDeg, start.In(loc).Format("Mon 15:04"), end.In(loc).Format("Mon 15:04 MST"))
+	fmt.Fprintln(w, strings.Repeat("─", 60))
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		return firstRise(rows[i]).Before(firstRise(rows[j]))
+	})
+
+	fmt.Fprintf(w, "%-16s %-7s %-7s %-7s %s\n", "Spacecraft", "Rise", "Peak", "Set", "Max El")
+	up := 0
+	var below, failed []string
+	for _, r := range rows {
+		if r.Err != nil {
+			failed = append(failed, r.Spacecraft)
+			continue
+		}
+		if len(r.Passes) == 0 {
+			below = append(below, r.Spacecraft)
+			continue
+		}
+		up++
+		for i, p := range r.Passes {
+			name := ""
+			if i == 0 {
+				name = r.Spacecraft
+			}
+			rise := p.Start.In(loc).Format("15:04")
+			if !p.Start.After(start) {
+				rise = "up"
+			}
+			set := p.End.In(loc).Format("15:04")
+			if !p.End.Before(end) {
+				set = "—"
+			}
+			fmt.Fprintf(w, "%s %-7s %-7s %-7s %3.0f°\n",
+				PadWidth(name, 16, ".."), rise, p.Peak.In(loc).Format("15:04"), set, p.MaxElDeg)
+		}
+	}
+
+	if up == 0 {
+		fmt.Fprintln(w, "No tracked spacecraft above your horizon tonight")
+	}
+	if len(below) > 0 {
+		fmt.Fprintf(w, "\nBelow your horizon: %s\n", strings.Join(below, ", "))
+	}
+	if len(failed) > 0 {
+		fmt.Fprintf(w, "No ephemeris: %s\n", strings.Join(failed, ", "))
+	}
+}
+
+// firstRise returns the start of a row's first pass, or the far future.
+func firstRise(r TonightRow) time.Time {
+	if len(r.Passes) == 0 {
+		return time.Unix(1<<62, 0)
+	}
+	return r.Passes[0].Start
+}
@@ -0,0 +1,110 @@
+package dsn
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/astro"
+)
+
+func TestNightWindow(t *testing.T) {
+	// Los Angeles, midday local (19:00 UTC) on the June solstice
+	la := astro.Observer{LatDeg: 34.05, LonDeg: -118.25}
+	noon := time.Date(2024, 6, 20, 19, 0, 0, 0, time.UTC)
+
+	start, end, ok := NightWindow(la, noon)
+	if !ok {
+		t.Fatal("NightWindow returned !ok for Los Angeles")
+	}
+	// Civil dusk ~20:35 PDT (03:35 UTC), dawn ~05:10 PDT (12:10 UTC)
+	if start.Before(noon.Add(8*time.Hour)) || start.After(noon.Add(9*time.Hour)) {
+		t.Errorf("start = %v, want about 03:35 UTC", start)
+	}
+	if d := end.Sub(start); d < 8*time.Hour || d > 9*time.Hour {
+		t.Errorf("night length = %v, want 8-9h", d)
+	}
+
+	// Already dark: the window starts now
+	midnight := time.Date(2024, 6, 21, 7, 0, 0, 0, time.UTC)
+	if start, _, _ := NightWindow(la, midnight); !start.Equal(midnight) {
+		t.Errorf("start = %v, want now (%v)", start, midnight)
+	}
+
+	// Midnight sun in Tromsø
+	tromso := astro.Observer{LatDeg: 69.65, LonDeg: 18.96}
+	if _, _, ok := NightWindow(tromso, noon); ok {
+		t.Error("NightWindow should report no night for Tromsø in June")
+	}
+}
+
+func TestComputeObserverPasses(t *testing.T) {
+	la := astro.Observer{LatDeg: 34.05, LonDeg: -118.25}
+	start := time.Date(2024, 6, 21, 3, 0, 0, 0, time.UTC)
+
+	// A fixed point on the celestial equator rises and sets once a day
+	var samples []astro.RADecAtTime
+	for i := 0; i <= 24*12; i++ {
+		samples = append(samples, astro.RADecAtTime{
+			Time:   start.Add(time.Duration(i) * PassSampleInterval),
+			RAdeg:  180,
+			DecDeg: 0,
+		})
+	}
+
+	passes := ComputeObserverPasses(la, samples, start)
+	if len(passes) == 0 {
+		t.Fatal("expected at least one pass")
+	}
+	for _, p := range passes {
+		if p.Complex != "" {
+			t.Errorf("Complex = %q, want empty", p.Complex)
+		}
+		// An equatorial object peaks at 90° - latitude
+		full := p.Start.After(start) && p.End.Before(samples[len(samples)-1].Time)
+		if full && (p.MaxElDeg < 54 || p.MaxElDeg > 57) {
+			t.Errorf("MaxElDeg = %.1f, want ~56", p.MaxElDeg)
+		}
+		if span := p.End.Sub(p.Start); span > 13*time.Hour {
+			t.Errorf("pass span = %v, want ~12h", span)
+		}
+	}
+
+	if ComputeObserverPasses(la, samples[:2], start) != nil {
+		t.Error("too few samples should give no passes")
+	}
+}
+
+func TestWriteTonight(t *testing.T) {
+	obs := astro.Observer{LatDeg: 51.48, LonDeg: 0}
+	start := time.Date(2024, 1, 10, 17, 0, 0, 0, time.UTC)
+	end := start.Add(13 * time.Hour)
+
+	rows := []TonightRow{
+		{Spacecraft: "VGR2"},
+		{Spacecraft: "JWST", Passes: []Pass{
+			{Start: start.Add(4 * time.Hour), Peak: start.Add(8 * time.Hour), End: end, MaxElDeg: 40},
+		}},
+		{Spacecraft: "MRO", Passes: []Pass{
+			{Start: start, Peak: start.Add(time.Hour), End: start.Add(3 * time.Hour), MaxElDeg: 25},
+		}},
+		{Spacecraft: "EMM", Err: errTestSeries},
+	}
+
+	var buf bytes.Buffer
+	WriteTonight(&buf, obs, start, end, rows, time.UTC)
+	out := buf.String()
+
+	// Sorted by rise: MRO (already up) before JWST
+	mro := strings.Index(out, "MRO")
+	jwst := strings.Index(out, "JWST")
+	if mro < 0 || jwst < 0 || mro > jwst {
+		t.Errorf("rows not sorted by rise time:\n%s", out)
+	}
+	for _, want := range []string{"up", "21:00", "Below your horizon: VGR2", "No ephemeris: EMM"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
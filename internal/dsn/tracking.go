@@ -0,0 +1,78 @@
+package dsn
+
+// TrackingMode describes how a link's downlink relates to the uplink the
+// spacecraft is locked to, which determines what can be measured (one-way
+// Doppler only, or coherent two-way/three-way Doppler and ranging).
+type TrackingMode string
+
+const (
+	// TrackingOneWay is a downlink with no uplink to the spacecraft from
+	// any antenna: the spacecraft's own oscillator sets the frequency.
+	TrackingOneWay TrackingMode = "1-way"
+
+	// TrackingTwoWay is a downlink received at the complex that is also
+	// transmitting the uplink, on the same or a sibling antenna sharing
+	// the complex's frequency reference.
+	TrackingTwoWay TrackingMode = "2-way"
+
+	// TrackingThreeWay is a downlink received at one complex while another
+	// complex transmits the uplink, as during a handover between sites.
+	TrackingThreeWay TrackingMode = "3-way"
+)
+
+// signalKey identifies a spacecraft's signals on one antenna.
+type signalKey struct {
+	antenna    string
+	spacecraft string
+}
+
+// assignTrackingModes sets TrackingMode on every link from the active up
+// and down signals across all antennas. Links with no active downlink
+// (an uplink-only antenna, or an idle target) are left without a mode.
+//
+// This compares signals in one feed snapshot, but the downlink received
+// now is coherent with the uplink transmitted one RTLT ago. Until a
+// round-trip light time has passed since an uplink handover, the label
+// reflects the new uplink rather than the one the spacecraft is locked to.
+func assignTrackingModes(links []Link, antennas []Antenna) {
+	down := make(map[signalKey]bool)
+	up := make(map[signalKey]bool)
+	// uplinkComplexes lists the complexes transmitting to each spacecraft
+	uplinkComplexes := make(map[string]map[Complex]bool)
+
+	for _, ant := range antennas {
+		complex := inferComplex(ant.ID)
+		for _, sig := range ant.DownSignals {
+			if sig.Active {
+				down[signalKey{ant.ID, sig.Spacecraft}] = true
+			}
+		}
+		for _, sig := range ant.UpSignals {
+			if !sig.Active {
+				continue
+			}
+			up[signalKey{ant.ID, sig.Spacecraft}] = true
+			if uplinkComplexes[sig.Spacecraft] == nil {
+				uplinkComplexes[sig.Spacecraft] = make(map[Complex]bool)
+			}
+			uplinkComplexes[sig.Spacecraft][complex] = true
+		}
+	}
+
+	for i := range links {
+		link := &links[i]
+		key := signalKey{link.AntennaID, link.Spacecraft}
+		if !down[key] {
+			continue
+		}
+		complexes := uplinkComplexes[link.Spacecraft]
+		switch {
+		case up[key] || complexes[link.Complex]:
+			link.TrackingMode = TrackingTwoWay
+		case len(complexes) > 0:
+			link.TrackingMode = TrackingThreeWay
+		default:
+			link.TrackingMode = TrackingOneWay
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package dsn
+
+import "testing"
+
+func TestParse_TrackingModes(t *testing.T) {
+	data, err := Parse([]byte(`<dsn>
+  <dish name="DSS14" elevationAngle="40">
+    <downSignal active="true" signalType="data" dataRate="2000" band="X" spacecraft="M20" spacecraftID="-168"/>
+    <upSignal active="true" signalType="carrier" dataRate="0" band="X" spacecraft="M20" spacecraftID="-168"/>
+    <target name="M20" id="168" rtlt="1500"/>
+    <upSignal active="true" signalType="data" dataRate="2000" band="X" spacecraft="PSYC" spacecraftID="-255"/>
+    <target name="PSYC" id="255" rtlt="2700"/>
+  </dish>
+  <dish name="DSS24" elevationAngle="35">
+    <downSignal active="true" signalType="data" dataRate="3500000" band="Ka" spacecraft="M20" spacecraftID="-168"/>
+    <target name="M20" id="168" rtlt="1500"/>
+  </dish>
+  <dish name="DSS43" elevationAngle="30">
+    <downSignal active="true" signalType="data" dataRate="160" band="X" spacecraft="VGR2" spacecraftID="-32"/>
+    <upSignal active="false" signalType="data" dataRate="16" band="S" spacecraft="VGR2" spacecraftID="-32"/>
+    <target name="VGR2" id="32" rtlt="150000"/>
+  </dish>
+  <dish name="DSS63" elevationAngle="10">
+    <downSignal active="true" signalType="carrier" dataRate="0" band="X" spacecraft="PSYC" spacecraftID="-255"/>
+    <target name="PSYC" id="255" rtlt="2700"/>
+  </dish>
+</dsn>`))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	tests := []struct {
+		antenna    string
+		spacecraft string
+		want       TrackingMode
+	}{
+		{"DSS14", "M20", TrackingTwoWay},    // up and down on one antenna
+		{"DSS24", "M20", TrackingTwoWay},    // sibling antenna at the uplinking complex
+		{"DSS14", "PSYC", ""},               // uplink only
+		{"DSS43", "VGR2", TrackingOneWay},   // inactive uplink doesn't count
+		{"DSS63", "PSYC", TrackingThreeWay}, // Goldstone transmits, Madrid receives
+	}
+
+	for _, tt := range tests {
+		found := false
+		for _, l := range data.Links {
+			if l.AntennaID != tt.antenna || l.Spacecraft != tt.spacecraft {
+				continue
+			}
+			found = true
+			if l.TrackingMode != tt.want {
+				t.Errorf("%s %s TrackingMode = %q, want %q", tt.antenna, tt.spacecraft, l.TrackingMode, tt.want)
+			}
+		}
+		if !found {
+			t.Errorf("no %s link on %s", tt.spacecraft, tt.antenna)
+		}
+	}
+}
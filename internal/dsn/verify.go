@@ -0,0 +1,156 @@
+package dsn
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/astro"
+)
+
+// Local sky math converts J2000 RA/Dec straight to Az/El and ignores
+// precession, nutation, and aberration. Like the pass planner it uses
+// airless elevations, and Horizons is asked for airless Az/El to match
+// (see astro.Observer.Refraction). These tolerances are
+// what that simplification is expected to stay within for deep-space
+// targets; verify-astro reports a failure beyond them.
+const (
+	AstroMaxSepDeg     = 1.0             // Az/El great-circle error
+	AstroMaxRiseSetErr = 5 * time.Minute // Rise/set time error at MinPassElevation
+)
+
+// AzElSample is a reference topocentric position, e.g. from a Horizons
+// observer table.
+type AzElSample struct {
+	Time  time.Time
+	AzDeg float64
+	ElDeg float64
+}
+
+// AstroCheck compares local Az/El and pass boundaries for one target and
+// complex against reference positions.
+type AstroCheck struct {
+	Spacecraft string
+	Complex    Complex
+	Samples    int           // Samples present in both series
+	MaxSepDeg  float64       // Worst great-circle error between local and reference Az/El
+	MaxElErr   float64       // Worst elevation error in degrees
+	Crossings  int           // Rise/set crossings found in the reference
+	MaxTimeErr time.Duration // Worst rise/set time error
+	Missed     int           // Reference crossings with no local counterpart
+	Err        error         // Reference data unavailable
+}
+
+// OK reports whether the check is within AstroMaxSepDeg and
+// AstroMaxRiseSetErr with no missed crossings.
+func (c AstroCheck) OK() bool {
+	return c.Err == nil && c.Samples > 0 && c.MaxSepDeg <= AstroMaxSepDeg &&
+		c.MaxTimeErr <= AstroMaxRiseSetErr && c.Missed == 0
+}
+
+// VerifyAzEl computes Az/El from samples for the complex with the same
+// math the pass planner uses and compares it with ref at matching times.
+// Rise and set crossings of MinPassElevation are compared too.
+func VerifyAzEl(spacecraft string, complex Complex, samples []astro.RADecAtTime, ref []AzElSample) AstroCheck {
+	check := AstroCheck{Spacecraft: spacecraft, Complex: complex}
+	obs := ObserverForComplex(complex)
+
+	byTime := make(map[int64]AzElSample, len(ref))
+	for _, r := range ref {
+		byTime[r.Time.Unix()] = r
+	}
+
+	var times []time.Time
+	var localEl, refEl []float64
+	for _, s := range samples {
+		r, ok := byTime[s.Time.Unix()]
+		if !ok {
+			continue
+		}
+		local := astro.EquatorialToHorizontal(astro.SkyCoord{RAdeg: s.RAdeg, DecDeg: s.DecDeg}, obs, s.Time)
+
+		check.Samples++
+		check.MaxSepDeg = math.Max(check.MaxSepDeg, astro.AngularSeparation(local.AzDeg, local.ElDeg, r.AzDeg, r.ElDeg))
+		check.MaxElErr = math.Max(check.MaxElErr, math.Abs(local.ElDeg-r.ElDeg))
+
+		times = append(times, s.Time)
+		localEl = append(localEl, local.ElDeg)
+		refEl = append(refEl, r.ElDeg)
+	}
+
+	localX := elevationCrossings(times, localEl, MinPassElevation)
+	for _, rx := range elevationCrossings(times, refEl, MinPassElevation) {
+		check.Crossings++
+		best := time.Duration(-1)
+		for _, lx := range localX {
+			if d := absDuration(lx.Sub(rx)); best < 0 || d < best {
+				best = d
+			}
+		}
+		if best < 0 || best > 2*AstroMaxRiseSetErr {
+			check.Missed++
+			continue
+		}
+		check.MaxTimeErr = max(check.MaxTimeErr, best)
+	}
+
+	return check
+}
+
+// elevationCrossings returns the interpolated times at which an elevation
+// series crosses minEl in either direction.
+func elevationCrossings(times []time.Time, els []float64, minEl float64) []time.Time {
+	var out []time.Time
+	for i := 1; i < len(els); i++ {
+		if (els[i-1] >= minEl) != (els[i] >= minEl) {
+			out = append(out, interpolateCrossing(times[i-1], times[i], els[i-1], els[i], minEl))
+		}
+	}
+	return out
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// WriteAstroChecks prints one line per check and a closing verdict.
+func WriteAstroChecks(w io.Writer, checks []AstroCheck) {
+	fmt.Fprintf(w, "%-10s %-4s %7s %9s %8s %9s %11s  %s\n",
+		"Target", "Site", "Samples", "Max sep", "Max ΔEl", "Rise/set", "Max Δt", "Result")
+	fmt.Fprintln(w, strings.Repeat("─", 74))
+
+	failed := 0
+	for _, c := range checks {
+		site := ComplexShortName(c.Complex)
+		if c.Err != nil {
+			failed++
+			fmt.Fprintf(w, "%-10s %-4s %s\n", c.Spacecraft, site, "no reference: "+c.Err.Error())
+			continue
+		}
+		result := "ok"
+		if !c.OK() {
+			failed++
+			result = "FAIL"
+			if c.Missed > 0 {
+				result = fmt.Sprintf("FAIL (%d missed)", c.Missed)
+			}
+		}
+		fmt.Fprintf(w, "%-10s %-4s %7d %8.3f° %7.3f° %9d %11s  %s\n",
+			c.Spacecraft, site, c.Samples, c.MaxSepDeg, c.MaxElErr, c.Crossings,
+			c.MaxTimeErr.Round(time.Second), result)
+	}
+
+	fmt.Fprintln(w)
+	if failed == 0 {
+		fmt.Fprintf(w, "All %d checks within %.1f° and %s of Horizons\n",
+			len(checks), AstroMaxSepDeg, AstroMaxRiseSetErr)
+	} else {
+		fmt.Fprintf(w, "%d of %d checks outside %.1f° / %s of Horizons\n",
+			failed, len(checks), AstroMaxSepDeg, AstroMaxRiseSetErr)
+	}
+}
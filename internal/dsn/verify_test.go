@@ -0,0 +1,90 @@
+package dsn
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/astro"
+)
+
+// referenceAzEl computes Az/El for samples with the RA shifted by raOffset,
+// standing in for a Horizons table that disagrees by a known amount.
+func referenceAzEl(c Complex, samples []astro.RADecAtTime, raOffset float64) []AzElSample {
+	obs := ObserverForComplex(c)
+	ref := make([]AzElSample, len(samples))
+	for i, s := range samples {
+		h := astro.EquatorialToHorizontal(astro.SkyCoord{RAdeg: s.RAdeg + raOffset, DecDeg: s.DecDeg}, obs, s.Time)
+		ref[i] = AzElSample{Time: s.Time, AzDeg: h.AzDeg, ElDeg: h.ElDeg}
+	}
+	return ref
+}
+
+func TestVerifyAzEl(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	samples := generateSamples(start, 24*time.Hour, 5*time.Minute, func(time.Time) (float64, float64) {
+		return 120, 10
+	})
+
+	tests := []struct {
+		name     string
+		raOffset float64
+		wantOK   bool
+	}{
+		{"identical", 0, true},
+		// 0.25° of RA is one minute of sidereal time
+		{"small offset", 0.25, true},
+		{"large offset", 5, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ref := referenceAzEl(ComplexGoldstone, samples, tt.raOffset)
+			c := VerifyAzEl("TEST", ComplexGoldstone, samples, ref)
+
+			if c.Samples != len(samples) {
+				t.Errorf("Samples = %d, want %d", c.Samples, len(samples))
+			}
+			if c.Crossings != 2 {
+				t.Errorf("Crossings = %d, want 2 (one rise, one set)", c.Crossings)
+			}
+			if c.MaxSepDeg > tt.raOffset+1e-6 {
+				t.Errorf("MaxSepDeg = %v, want <= %v", c.MaxSepDeg, tt.raOffset)
+			}
+			if c.OK() != tt.wantOK {
+				t.Errorf("OK() = %v, want %v (sep %.3f°, Δt %s)", c.OK(), tt.wantOK, c.MaxSepDeg, c.MaxTimeErr)
+			}
+		})
+	}
+}
+
+func TestVerifyAzEl_NoOverlap(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	samples := generateSamples(start, time.Hour, 5*time.Minute, func(time.Time) (float64, float64) {
+		return 120, 10
+	})
+	ref := []AzElSample{{Time: start.Add(time.Minute), AzDeg: 0, ElDeg: 0}}
+
+	c := VerifyAzEl("TEST", ComplexGoldstone, samples, ref)
+	if c.Samples != 0 || c.OK() {
+		t.Errorf("Samples = %d, OK = %v; want 0, false", c.Samples, c.OK())
+	}
+}
+
+func TestWriteAstroChecks(t *testing.T) {
+	checks := []AstroCheck{
+		{Spacecraft: "VGR1", Complex: ComplexCanberra, Samples: 145, MaxSepDeg: 0.31, Crossings: 2, MaxTimeErr: 70 * time.Second},
+		{Spacecraft: "JWST", Complex: ComplexMadrid, Samples: 145, MaxSepDeg: 2.5},
+	}
+
+	var buf bytes.Buffer
+	WriteAstroChecks(&buf, checks)
+	out := buf.String()
+
+	for _, want := range []string{"VGR1", "CDS", "0.310°", "1m10s", "ok", "FAIL", "1 of 2 checks outside"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package dsn
+
+import "strings"
+
+// Watchlist is the set of spacecraft the user follows, as canonical
+// catalog codes. An empty watchlist follows every spacecraft.
+type Watchlist []string
+
+// ParseWatchlist parses a comma-separated list of spacecraft codes such
+// as "VGR1,JWST,MRO". Aliases (MVN, MAVEN) name the same spacecraft.
+func ParseWatchlist(s string) Watchlist {
+	var w Watchlist
+	for _, code := range strings.Split(s, ",") {
+		code = CanonicalCode(code)
+		if code != "" && !w.has(code) {
+			w = append(w, code)
+		}
+	}
+	return w
+}
+
+// Follows reports whether the spacecraft with the given DSN code is on
+// the watchlist.
+func (w Watchlist) Follows(code string) bool {
+	return len(w) == 0 || w.has(CanonicalCode(code))
+}
+
+func (w Watchlist) has(canon string) bool {
+	for _, c := range w {
+		if c == canon {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter returns data with only the links to followed spacecraft.
+// Stations and antennas are kept. data itself is not modified.
+func (w Watchlist) Filter(data *DSNData) *DSNData {
+	if len(w) == 0 || data == nil {
+		return data
+	}
+	filtered := *data
+	filtered.Links = nil
+	for _, link := range data.Links {
+		if w.Follows(link.Spacecraft) {
+			filtered.Links = append(filtered.Links, link)
+		}
+	}
+	return &filtered
+}
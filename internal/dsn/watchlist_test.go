@@ -0,0 +1,57 @@
+package dsn
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseWatchlist(t *testing.T) {
+	got := ParseWatchlist(" vgr1, JWST,,mvn,MAVEN ")
+	if want := (Watchlist{"VGR1", "JWST", "MAVEN"}); !slices.Equal(got, want) {
+		t.Errorf("ParseWatchlist = %v, want %v", got, want)
+	}
+	if got := ParseWatchlist(""); len(got) != 0 {
+		t.Errorf("ParseWatchlist(\"\") = %v, want empty", got)
+	}
+}
+
+func TestWatchlist_Follows(t *testing.T) {
+	w := ParseWatchlist("VGR1,MAVEN")
+	tests := []struct {
+		code string
+		want bool
+	}{
+		{"VGR1", true},
+		{"vgr1", true},
+		{"MVN", true}, // alias of MAVEN
+		{"JWST", false},
+	}
+	for _, tt := range tests {
+		if got := w.Follows(tt.code); got != tt.want {
+			t.Errorf("Follows(%s) = %v, want %v", tt.code, got, tt.want)
+		}
+	}
+	if !Watchlist(nil).Follows("JWST") {
+		t.Error("an empty watchlist should follow everything")
+	}
+}
+
+func TestWatchlist_Filter(t *testing.T) {
+	data := &DSNData{
+		Stations: []Station{{Name: "gdscc"}},
+		Links:    []Link{{Spacecraft: "VGR1"}, {Spacecraft: "JWST"}, {Spacecraft: "MVN"}},
+	}
+	got := ParseWatchlist("VGR1,MAVEN").Filter(data)
+	if len(got.Links) != 2 || got.Links[0].Spacecraft != "VGR1" || got.Links[1].Spacecraft != "MVN" {
+		t.Errorf("Filter links = %+v", got.Links)
+	}
+	if len(got.Stations) != 1 {
+		t.Error("Filter should keep stations")
+	}
+	if len(data.Links) != 3 {
+		t.Error("Filter modified its input")
+	}
+	if Watchlist(nil).Filter(data) != data {
+		t.Error("an empty watchlist should return data as is")
+	}
+}
@@ -0,0 +1,88 @@
+package dsn
+
+import (
+	"errors"
+	"fmt"
+)
+
+// WindLimits are the wind speeds, in km/h, that put a dish's pass at
+// risk. DSN antennas are driven to stow (pointed at zenith and locked)
+// in high wind, which ends any pass in progress; Caution is where the
+// wind is getting close.
+type WindLimits struct {
+	Caution float64
+	Stow    float64
+}
+
+// DefaultWindLimits returns the built-in limits: caution from 50 km/h,
+// stow from 72 km/h (45 mph, about where 34 m dishes stop tracking).
+func DefaultWindLimits() WindLimits {
+	return WindLimits{Caution: 50, Stow: 72}
+}
+
+// Validate reports limits that can't be used: both must be positive,
+// with Caution below Stow.
+func (w WindLimits) Validate() error {
+	switch {
+	case w.Caution <= 0 || w.Stow <= 0:
+		return errors.New("wind limits must be positive")
+	case w.Caution >= w.Stow:
+		return fmt.Errorf("wind caution (%.0f km/h) must be below stow (%.0f km/h)", w.Caution, w.Stow)
+	}
+	return nil
+}
+
+// WindRisk is how close a dish's wind is to its stow limit.
+type WindRisk int
+
+const (
+	WindCalm    WindRisk = iota // below Caution
+	WindCaution                 // Caution or above: approaching stow
+	WindStow                    // Stow or above: the dish may be stowed
+)
+
+// String returns "calm", "caution", or "stow".
+func (r WindRisk) String() string {
+	switch r {
+	case WindCaution:
+		return "caution"
+	case WindStow:
+		return "stow"
+	default:
+		return "calm"
+	}
+}
+
+// Risk classifies a wind speed in km/h.
+func (w WindLimits) Risk(kmh float64) WindRisk {
+	switch {
+	case kmh >= w.Stow:
+		return WindStow
+	case kmh >= w.Caution:
+		return WindCaution
+	default:
+		return WindCalm
+	}
+}
+
+// ActiveWindLimits returns the limits d's wind is judged against: its
+// WindLimits, or the defaults if none were set.
+func (d *DSNData) ActiveWindLimits() WindLimits {
+	if d == nil || d.WindLimits.Validate() != nil {
+		return DefaultWindLimits()
+	}
+	return d.WindLimits
+}
+
+// Format formats a wind speed with its risk: "58 km/h (near stow)",
+// "75 km/h (stow)", or just "12 km/h" when calm.
+func (w WindLimits) Format(kmh float64) string {
+	switch w.Risk(kmh) {
+	case WindStow:
+		return fmt.Sprintf("%.0f km/h (stow)", kmh)
+	case WindCaution:
+		return fmt.Sprintf("%.0f km/h (near stow)", kmh)
+	default:
+		return fmt.Sprintf("%.0f km/h", kmh)
+	}
+}
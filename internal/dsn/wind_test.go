@@ -0,0 +1,57 @@
+package dsn
+
+import "testing"
+
+func TestWindLimits_Risk(t *testing.T) {
+	w := DefaultWindLimits()
+	tests := []struct {
+		kmh  float64
+		want WindRisk
+	}{
+		{0, WindCalm},
+		{49.9, WindCalm},
+		{50, WindCaution},
+		{71, WindCaution},
+		{72, WindStow},
+		{110, WindStow},
+	}
+	for _, tt := range tests {
+		if got := w.Risk(tt.kmh); got != tt.want {
+			t.Errorf("Risk(%v) = %v, want %v", tt.kmh, got, tt.want)
+		}
+	}
+}
+
+func TestWindLimits_Validate(t *testing.T) {
+	if err := DefaultWindLimits().Validate(); err != nil {
+		t.Errorf("defaults: %v", err)
+	}
+	for _, w := range []WindLimits{{0, 72}, {50, -1}, {72, 72}, {80, 72}} {
+		if w.Validate() == nil {
+			t.Errorf("Validate(%+v) = nil, want an error", w)
+		}
+		if got := (&DSNData{WindLimits: w}).ActiveWindLimits(); got != DefaultWindLimits() {
+			t.Errorf("bad limits %+v replaced the defaults: %+v", w, got)
+		}
+	}
+	custom := WindLimits{Caution: 40, Stow: 60}
+	if got := (&DSNData{WindLimits: custom}).ActiveWindLimits(); got != custom {
+		t.Errorf("ActiveWindLimits = %+v, want %+v", got, custom)
+	}
+}
+
+func TestWindLimits_Format(t *testing.T) {
+	tests := []struct {
+		kmh  float64
+		want string
+	}{
+		{12.4, "12 km/h"},
+		{58, "58 km/h (near stow)"},
+		{75, "75 km/h (stow)"},
+	}
+	for _, tt := range tests {
+		if got := DefaultWindLimits().Format(tt.kmh); got != tt.want {
+			t.Errorf("Format(%v) = %q, want %q", tt.kmh, got, tt.want)
+		}
+	}
+}
@@ -63,7 +63,7 @@ func (p *DSNProvider) GetPosition(target TargetID, t time.Time, obs astro.Observ
 	// Find spacecraft by DSN ID
 	scID := p.findSpacecraftID(target)
 	if scID == 0 {
-		return EphemerisPoint{Valid: false}, fmt.Errorf("target %d not found in DSN data", target)
+		return EphemerisPoint{Valid: false}, fmt.Errorf("%w: target %d not found in DSN data", dsn.ErrUnknownTarget, target)
 	}
 
 	sv, ok := p.viewsMap[scID]
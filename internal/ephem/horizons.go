@@ -3,7 +3,6 @@ package ephem
 import (
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -12,12 +11,16 @@ import (
 	"time"
 
 	"github.com/litescript/ls-horizons/internal/astro"
+	"github.com/litescript/ls-horizons/internal/dsn"
 )
 
 const (
 	// HorizonsAPIURL is the JPL Horizons JSON API endpoint.
 	HorizonsAPIURL = "https://ssd.jpl.nasa.gov/api/horizons.api"
 
+	// HorizonsFileAPIURL is the JPL Horizons file-input (POST) API endpoint.
+	HorizonsFileAPIURL = "https://ssd.jpl.nasa.gov/api/horizons_file.api"
+
 	// DefaultPathDuration is the default time span for trajectory paths.
 	DefaultPathDuration = 24 * time.Hour
 
@@ -33,7 +36,10 @@ const (
 
 // HorizonsProvider queries JPL Horizons for spacecraft ephemerides.
 type HorizonsProvider struct {
-	client *http.Client
+	client     *http.Client
+	apiURL     string // GET endpoint
+	fileAPIURL string // POST file-input endpoint
+	usage      *Usage // quota and attribution of requests sent
 
 	// Path cache
 	mu        sync.RWMutex
@@ -47,14 +53,35 @@ type cachedPath struct {
 	fetchedAt time.Time
 }
 
-// NewHorizonsProvider creates a new Horizons API client.
-func NewHorizonsProvider() *HorizonsProvider {
-	return &HorizonsProvider{
+// HorizonsOption configures a HorizonsProvider.
+type HorizonsOption func(*HorizonsProvider)
+
+// WithUsage counts the provider's requests against u, so providers that
+// share a tracker share its quota.
+func WithUsage(u *Usage) HorizonsOption {
+	return func(p *HorizonsProvider) {
+		p.usage = u
+	}
+}
+
+// NewHorizonsProvider creates a new Horizons API client. Without
+// WithUsage it tracks its own requests under DefaultHorizonsQuota.
+func NewHorizonsProvider(opts ...HorizonsOption) *HorizonsProvider {
+	p := &HorizonsProvider{
 		client: &http.Client{
 			Timeout: RequestTimeout,
 		},
-		pathCache: make(map[TargetID]*cachedPath),
+		apiURL:     HorizonsAPIURL,
+		fileAPIURL: HorizonsFileAPIURL,
+		pathCache:  make(map[TargetID]*cachedPath),
+	}
+	for _, opt := range opts {
+		opt(p)
 	}
+	if p.usage == nil {
+		p.usage = NewUsage(DefaultHorizonsQuota())
+	}
+	return p
 }
 
 // Name implements Provider.
@@ -72,7 +99,7 @@ func (p *HorizonsProvider) GetPosition(target TargetID, t time.Time, obs astro.O
 	}
 
 	if len(path.Points) == 0 {
-		return EphemerisPoint{Valid: false}, fmt.Errorf("no data returned for target %d", target)
+		return EphemerisPoint{Valid: false}, fmt.Errorf("%w: no data returned for target %d", dsn.ErrUnknownTarget, target)
 	}
 
 	return path.Points[0], nil
@@ -133,27 +160,18 @@ func (p *HorizonsProvider) queryHorizons(target TargetID, start, end time.Time,
 	params.Set("EPHEM_TYPE", "OBSERVER")
 	params.Set("CENTER", "'coord@399'")
 	params.Set("COORD_TYPE", "GEODETIC")
-	params.Set("SITE_COORD", fmt.Sprintf("'%.4f,%.4f,0.1'", obs.LonDeg, obs.LatDeg))
+	params.Set("SITE_COORD", fmt.Sprintf("'%.4f,%.4f,%.3f'", obs.LonDeg, obs.LatDeg, obs.AltitudeM/1000)) // km above the ellipsoid
 	params.Set("START_TIME", fmt.Sprintf("'%s'", formatHorizonsTime(start)))
 	params.Set("STOP_TIME", fmt.Sprintf("'%s'", formatHorizonsTime(end)))
 	params.Set("STEP_SIZE", fmt.Sprintf("'%s'", formatStepSize(step)))
 	params.Set("QUANTITIES", "'4'") // 4=Apparent Az/El
-
-	reqURL := HorizonsAPIURL + "?" + params.Encode()
-
-	resp, err := p.client.Get(reqURL)
-	if err != nil {
-		return EphemerisPath{}, fmt.Errorf("horizons request failed: %w", err)
+	if obs.Refraction {
+		params.Set("APPARENT", "REFRACTED") // as local math, not airless
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	body, err := p.doQuery(FeaturePaths, params, nil)
 	if err != nil {
-		return EphemerisPath{}, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return EphemerisPath{}, fmt.Errorf("Horizons returned status %d (service may be unavailable)", resp.StatusCode)
+		return EphemerisPath{}, fmt.Errorf("horizons request failed: %w", err)
 	}
 
 	return parseHorizonsResponse(target, body, obs)
@@ -180,7 +198,7 @@ func parseHorizonsResponse(target TargetID, body []byte, obs astro.Observer) (Ep
 
 	var resp horizonsResponse
 	if err := json.Unmarshal(body, &resp); err != nil {
-		return EphemerisPath{}, fmt.Errorf("failed to parse Horizons response as JSON")
+		return EphemerisPath{}, fmt.Errorf("%w: Horizons response is not JSON", dsn.ErrParse)
 	}
 
 	// The actual ephemeris data is in resp.Result as a text blob
@@ -204,25 +222,17 @@ func parseHorizonsResponse(target TargetID, body []byte, obs astro.Observer) (Ep
 
 // parseEphemerisTable extracts ephemeris points from the Horizons text output.
 func parseEphemerisTable(result string, obs astro.Observer) ([]EphemerisPoint, error) {
-	var points []EphemerisPoint
-
-	// Find the data section between $$SOE and $$EOE markers
-	soeIdx := strings.Index(result, "$$SOE")
-	eoeIdx := strings.Index(result, "$$EOE")
-	if soeIdx == -1 || eoeIdx == -1 || soeIdx >= eoeIdx {
-		return nil, fmt.Errorf("could not find ephemeris data markers")
+	hdr, lines, err := splitHorizonsTable(result)
+	if err != nil {
+		return nil, err
+	}
+	if hdr.column("Azi", "Elev") < 0 {
+		return nil, fmt.Errorf("no Az/El column in Horizons output")
 	}
 
-	dataSection := result[soeIdx+5 : eoeIdx]
-	lines := strings.Split(dataSection, "\n")
-
+	var points []EphemerisPoint
 	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
-		}
-
-		point, err := parseEphemerisLine(line, obs)
+		point, err := parseEphemerisLine(line, hdr, obs)
 		if err != nil {
 			continue // Skip unparseable lines
 		}
@@ -232,43 +242,25 @@ func parseEphemerisTable(result string, obs astro.Observer) ([]EphemerisPoint, e
 	return points, nil
 }
 
-// parseEphemerisLine parses a single ephemeris data line.
+// parseEphemerisLine parses a single ephemeris data line using the column
+// layout from the table header.
 // Format for QUANTITIES='4' (Az/El):
-// 2025-Dec-05 00:00 *   261.032124  32.878027
-// Fields: date, time, flags, azimuth, elevation
-func parseEphemerisLine(line string, obs astro.Observer) (EphemerisPoint, error) {
-	fields := strings.Fields(line)
-	if len(fields) < 4 {
-		return EphemerisPoint{}, fmt.Errorf("insufficient fields: %d", len(fields))
-	}
-
-	// Parse date/time (first two fields)
-	dateStr := fields[0] + " " + fields[1]
-	t, err := parseHorizonsDateTime(dateStr)
+//
+//	Date__(UT)__HR:MN       Azi_(a-app)_Elev
+//	2025-Dec-05 00:00 *   261.03212 32.87802
+func parseEphemerisLine(line string, hdr horizonsHeader, obs astro.Observer) (EphemerisPoint, error) {
+	t, err := hdr.time(line)
 	if err != nil {
 		return EphemerisPoint{}, err
 	}
 
-	// Find Az/El values - they're the last two numeric fields
-	// Skip any flag fields (like *, *m, Cm, Nm, Am, etc.)
-	var az, el float64
-	numericCount := 0
-
-	for i := 2; i < len(fields); i++ {
-		val, err := strconv.ParseFloat(fields[i], 64)
-		if err == nil {
-			numericCount++
-			if numericCount == 1 {
-				az = val
-			} else if numericCount == 2 {
-				el = val
-				break
-			}
-		}
+	idx := hdr.column("Azi", "Elev")
+	if idx < 0 {
+		return EphemerisPoint{}, fmt.Errorf("no Az/El column in header")
 	}
-
-	if numericCount < 2 {
-		return EphemerisPoint{}, fmt.Errorf("could not find Az/El values")
+	az, el, err := hdr.anglePair(line, idx, false)
+	if err != nil {
+		return EphemerisPoint{}, err
 	}
 
 	return EphemerisPoint{
@@ -322,7 +314,7 @@ func observerMatch(a, b astro.Observer) bool {
 	if abs(a.LonDeg-b.LonDeg) > tolerance {
 		return false
 	}
-	return true
+	return a.Refraction == b.Refraction
 }
 
 // RADecCacheTTL is how long to cache RA/Dec path data.
@@ -349,7 +341,9 @@ func (p *HorizonsProvider) GetRADecPath(target TargetID, start, end time.Time, s
 	raDecCache.RUnlock()
 
 	if ok && time.Since(cached.fetchedAt) < RADecCacheTTL {
-		return cached.samples, nil
+		if samples, covered := samplesInRange(cached.samples, start, end, step); covered {
+			return samples, nil
+		}
 	}
 
 	// Query fresh data
@@ -369,6 +363,29 @@ func (p *HorizonsProvider) GetRADecPath(target TargetID, start, end time.Time, s
 	return samples, nil
 }
 
+// samplesInRange returns the samples within [start, end] if they cover the
+// whole range to within one step. Pass plans, elevation traces, and
+// incremental tail fetches all share the per-target cache, so a hit must
+// actually span the requested range.
+func samplesInRange(samples []astro.RADecAtTime, start, end time.Time, step time.Duration) ([]astro.RADecAtTime, bool) {
+	if len(samples) == 0 {
+		return nil, false
+	}
+	if samples[0].Time.After(start.Add(step)) || samples[len(samples)-1].Time.Before(end.Add(-step)) {
+		return nil, false
+	}
+
+	lo := 0
+	for lo < len(samples) && samples[lo].Time.Before(start.Add(-time.Minute)) {
+		lo++
+	}
+	hi := len(samples)
+	for hi > lo && samples[hi-1].Time.After(end.Add(time.Minute)) {
+		hi--
+	}
+	return samples[lo:hi], true
+}
+
 // queryRADec queries Horizons for RA/Dec over a time range.
 func (p *HorizonsProvider) queryRADec(target TargetID, start, end time.Time, step time.Duration) ([]astro.RADecAtTime, error) {
 	// Build request parameters for geocentric RA/Dec
@@ -383,23 +400,12 @@ func (p *HorizonsProvider) queryRADec(target TargetID, start, end time.Time, ste
 	params.Set("STOP_TIME", fmt.Sprintf("'%s'", formatHorizonsTime(end)))
 	params.Set("STEP_SIZE", fmt.Sprintf("'%s'", formatStepSize(step)))
 	params.Set("QUANTITIES", "'1'") // 1 = Astrometric RA/Dec
+	params.Set("ANG_FORMAT", "DEG")
 
-	reqURL := HorizonsAPIURL + "?" + params.Encode()
-
-	resp, err := p.client.Get(reqURL)
+	body, err := p.doQuery(FeaturePasses, params, nil)
 	if err != nil {
 		return nil, fmt.Errorf("horizons RA/Dec request failed: %w", err)
 	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Horizons returned status %d (service may be unavailable)", resp.StatusCode)
-	}
 
 	return parseRADecResponse(body)
 }
@@ -417,28 +423,21 @@ func parseRADecResponse(body []byte) ([]astro.RADecAtTime, error) {
 	var resp horizonsResponse
 	if err := json.Unmarshal(body, &resp); err != nil {
 		// Don't include the body in error to avoid dumping HTML/garbage
-		return nil, fmt.Errorf("failed to parse Horizons response as JSON")
+		return nil, fmt.Errorf("%w: Horizons response is not JSON", dsn.ErrParse)
 	}
 
-	// Find the data section between $$SOE and $$EOE markers
-	soeIdx := strings.Index(resp.Result, "$$SOE")
-	eoeIdx := strings.Index(resp.Result, "$$EOE")
-	if soeIdx == -1 || eoeIdx == -1 || soeIdx >= eoeIdx {
-		return nil, fmt.Errorf("could not find RA/Dec data markers")
+	hdr, lines, err := splitHorizonsTable(resp.Result)
+	if err != nil {
+		return nil, err
+	}
+	if hdr.column("R.A.", "DEC") < 0 {
+		return nil, fmt.Errorf("no RA/Dec column in Horizons output")
 	}
-
-	dataSection := resp.Result[soeIdx+5 : eoeIdx]
-	lines := strings.Split(dataSection, "\n")
 
 	var samples []astro.RADecAtTime
 
 	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
-		}
-
-		sample, err := parseRADecLine(line)
+		sample, err := parseRADecLine(line, hdr)
 		if err != nil {
 			continue // Skip unparseable lines
 		}
@@ -448,43 +447,26 @@ func parseRADecResponse(body []byte) ([]astro.RADecAtTime, error) {
 	return samples, nil
 }
 
-// parseRADecLine parses a single RA/Dec data line.
+// parseRADecLine parses a single RA/Dec data line using the column layout
+// from the table header. Both decimal degrees (ANG_FORMAT='DEG') and
+// sexagesimal HMS/DMS values are accepted.
 // Format for QUANTITIES='1' (Astrometric RA/Dec):
-// 2025-Dec-05 00:00 *   261.032124  32.878027
-// Fields: date, time, flags, RA (deg), Dec (deg)
-func parseRADecLine(line string) (astro.RADecAtTime, error) {
-	fields := strings.Fields(line)
-	if len(fields) < 4 {
-		return astro.RADecAtTime{}, fmt.Errorf("insufficient fields: %d", len(fields))
-	}
-
-	// Parse date/time (first two fields)
-	dateStr := fields[0] + " " + fields[1]
-	t, err := parseHorizonsDateTime(dateStr)
+//
+//	Date__(UT)__HR:MN     R.A.___(ICRF)___DEC
+//	2025-Dec-05 00:00     261.03212 -32.87803
+func parseRADecLine(line string, hdr horizonsHeader) (astro.RADecAtTime, error) {
+	t, err := hdr.time(line)
 	if err != nil {
 		return astro.RADecAtTime{}, err
 	}
 
-	// Find RA/Dec values - they're the last two numeric fields
-	// Skip any flag fields (like *, *m, Cm, Nm, Am, etc.)
-	var ra, dec float64
-	numericCount := 0
-
-	for i := 2; i < len(fields); i++ {
-		val, err := strconv.ParseFloat(fields[i], 64)
-		if err == nil {
-			numericCount++
-			if numericCount == 1 {
-				ra = val
-			} else if numericCount == 2 {
-				dec = val
-				break
-			}
-		}
+	idx := hdr.column("R.A.", "DEC")
+	if idx < 0 {
+		return astro.RADecAtTime{}, fmt.Errorf("no RA/Dec column in header")
 	}
-
-	if numericCount < 2 {
-		return astro.RADecAtTime{}, fmt.Errorf("could not find RA/Dec values")
+	ra, dec, err := hdr.anglePair(line, idx, true)
+	if err != nil {
+		return astro.RADecAtTime{}, err
 	}
 
 	return astro.RADecAtTime{
@@ -571,22 +553,10 @@ func (p *HorizonsProvider) queryHeliocentricVectors(naifID int, t time.Time) (as
 	params.Set("STOP_TIME", fmt.Sprintf("'%s'", formatHorizonsTime(t.Add(time.Minute))))
 	params.Set("STEP_SIZE", "'1 m'")
 
-	reqURL := HorizonsAPIURL + "?" + params.Encode()
-
-	resp, err := p.client.Get(reqURL)
+	body, err := p.doQuery(FeatureVectors, params, nil)
 	if err != nil {
 		return astro.Vec3{}, fmt.Errorf("horizons vector request failed: %w", err)
 	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return astro.Vec3{}, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return astro.Vec3{}, fmt.Errorf("Horizons returned status %d (service may be unavailable)", resp.StatusCode)
-	}
 
 	return parseVectorResponse(body)
 }
@@ -603,14 +573,14 @@ func parseVectorResponse(body []byte) (astro.Vec3, error) {
 
 	var resp horizonsResponse
 	if err := json.Unmarshal(body, &resp); err != nil {
-		return astro.Vec3{}, fmt.Errorf("failed to parse Horizons response as JSON")
+		return astro.Vec3{}, fmt.Errorf("%w: Horizons response is not JSON", dsn.ErrParse)
 	}
 
 	// Find the data section between $$SOE and $$EOE markers
 	soeIdx := strings.Index(resp.Result, "$$SOE")
 	eoeIdx := strings.Index(resp.Result, "$$EOE")
 	if soeIdx == -1 || eoeIdx == -1 || soeIdx >= eoeIdx {
-		return astro.Vec3{}, fmt.Errorf("could not find vector data markers")
+		return astro.Vec3{}, missingTableError(resp.Result, "vector data")
 	}
 
 	dataSection := resp.Result[soeIdx+5 : eoeIdx]
@@ -655,8 +625,13 @@ func parseVectorLabeled(line string) (astro.Vec3, error) {
 	}
 
 	// parts[1] contains "X_value Y", parts[2] contains "Y_value Z", parts[3] contains "Z_value"
-	xStr := strings.Fields(parts[1])[0]
-	yStr := strings.Fields(parts[2])[0]
+	xFields := strings.Fields(parts[1])
+	yFields := strings.Fields(parts[2])
+	if len(xFields) == 0 || len(yFields) == 0 {
+		return astro.Vec3{}, fmt.Errorf("invalid labeled format")
+	}
+	xStr := xFields[0]
+	yStr := yFields[0]
 	zStr := strings.TrimSpace(parts[3])
 
 	var err error
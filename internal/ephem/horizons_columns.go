@@ -0,0 +1,250 @@
+package ephem
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+)
+
+// Horizons observer tables are fixed-width: the column header line sits
+// between two rows of asterisks just above $$SOE, and each header label is
+// right-aligned with the values beneath it. For example (QUANTITIES='1,4'):
+//
+//	 Date__(UT)__HR:MN     R.A.___(ICRF)___DEC  Azi_(a-app)_Elev
+//	***************************************************************
+//	$$SOE
+//	 2025-Dec-05 00:00 *m  261.03212 -32.87803  101.2345 12.3456
+//	$$EOE
+//
+// Parsing by column span rather than by counting numeric fields keeps extra
+// quantities, numeric flags, or a different column order from being misread
+// as Az/El or RA/Dec.
+
+// horizonsColumn is one labelled column of a Horizons table.
+type horizonsColumn struct {
+	label string
+	start int // byte offset where this column's data may begin
+	end   int // byte offset one past the end of the label
+}
+
+// horizonsHeader is the parsed column layout of a Horizons table.
+type horizonsHeader struct {
+	columns []horizonsColumn
+}
+
+var headerLabelRe = regexp.MustCompile(`\S+`)
+
+// splitHorizonsTable returns the column header and the raw data lines
+// between $$SOE and $$EOE. Data lines are not trimmed, since column
+// positions are significant.
+func splitHorizonsTable(result string) (horizonsHeader, []string, error) {
+	soeIdx := strings.Index(result, "$$SOE")
+	eoeIdx := strings.Index(result, "$$EOE")
+	if soeIdx == -1 || eoeIdx == -1 || soeIdx >= eoeIdx {
+		return horizonsHeader{}, nil, missingTableError(result, "ephemeris data")
+	}
+
+	hdr, err := parseColumnHeader(result[:soeIdx])
+	if err != nil {
+		return horizonsHeader{}, nil, err
+	}
+
+	var lines []string
+	for _, line := range strings.Split(result[soeIdx+5:eoeIdx], "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	return hdr, lines, nil
+}
+
+// unknownTargetMarkers are what Horizons says instead of a table when it
+// can't resolve the target.
+var unknownTargetMarkers = []string{
+	"No matches found",
+	"No ephemeris for target",
+	"Multiple major-bodies match",
+	"Matching small-bodies",
+}
+
+// missingTableError explains a Horizons result with no $$SOE/$$EOE table:
+// dsn.ErrUnknownTarget if Horizons couldn't resolve the target, else
+// dsn.ErrParse.
+func missingTableError(result, what string) error {
+	for _, line := range strings.Split(result, "\n") {
+		line = strings.TrimSpace(line)
+		for _, marker := range unknownTargetMarkers {
+			if strings.Contains(line, marker) {
+				return fmt.Errorf("%w: %s", dsn.ErrUnknownTarget, line)
+			}
+		}
+	}
+	return fmt.Errorf("%w: could not find %s markers", dsn.ErrParse, what)
+}
+
+// parseColumnHeader finds the column header line in the text preceding
+// $$SOE: the last non-blank line that isn't a row of asterisks.
+func parseColumnHeader(preamble string) (horizonsHeader, error) {
+	lines := strings.Split(preamble, "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := strings.TrimRight(lines[i], "\r")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.Trim(trimmed, "*") == "" {
+			continue
+		}
+		if !strings.HasPrefix(trimmed, "Date") {
+			break
+		}
+		return newHorizonsHeader(line), nil
+	}
+	return horizonsHeader{}, fmt.Errorf("could not find column header")
+}
+
+// newHorizonsHeader splits a header line into columns. Each column spans
+// from the end of the previous label to the end of its own label, since
+// Horizons right-aligns values under their labels.
+func newHorizonsHeader(line string) horizonsHeader {
+	var hdr horizonsHeader
+	prevEnd := 0
+	for _, loc := range headerLabelRe.FindAllStringIndex(line, -1) {
+		hdr.columns = append(hdr.columns, horizonsColumn{
+			label: line[loc[0]:loc[1]],
+			start: prevEnd,
+			end:   loc[1],
+		})
+		prevEnd = loc[1]
+	}
+	return hdr
+}
+
+// column returns the index of the first column whose label contains all of
+// the given substrings (case-insensitive), or -1.
+func (h horizonsHeader) column(parts ...string) int {
+	for i, c := range h.columns {
+		label := strings.ToUpper(c.label)
+		match := true
+		for _, p := range parts {
+			if !strings.Contains(label, strings.ToUpper(p)) {
+				match = false
+				break
+			}
+		}
+		if match {
+			return i
+		}
+	}
+	return -1
+}
+
+// field returns the text of column i in a data line. The last column
+// extends to the end of the line.
+func (h horizonsHeader) field(line string, i int) string {
+	c := h.columns[i]
+	if c.start >= len(line) {
+		return ""
+	}
+	end := c.end
+	if i == len(h.columns)-1 || end > len(line) {
+		end = len(line)
+	}
+	return line[c.start:end]
+}
+
+// time parses the observation time from the Date column of a data line.
+func (h horizonsHeader) time(line string) (time.Time, error) {
+	idx := h.column("Date")
+	if idx < 0 {
+		return time.Time{}, fmt.Errorf("no Date column in header")
+	}
+	fields := strings.Fields(h.field(line, idx))
+	if len(fields) < 2 {
+		return time.Time{}, fmt.Errorf("insufficient date fields: %d", len(fields))
+	}
+	return parseHorizonsDateTime(fields[0] + " " + fields[1])
+}
+
+// anglePair parses a two-quantity column such as Azi/Elev or R.A./DEC.
+// Values may be decimal degrees (two fields) or sexagesimal (six fields);
+// for sexagesimal input, hoursFirst converts the first value from hours
+// to degrees.
+func (h horizonsHeader) anglePair(line string, idx int, hoursFirst bool) (float64, float64, error) {
+	fields := strings.Fields(h.field(line, idx))
+
+	// Drop leading flag markers (e.g. "*", "Cm") that fall inside the span
+	for len(fields) > 0 {
+		if _, err := strconv.ParseFloat(fields[0], 64); err == nil {
+			break
+		}
+		if !isFlagField(fields[0]) {
+			break
+		}
+		fields = fields[1:]
+	}
+
+	switch len(fields) {
+	case 2:
+		a, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("parse %s: %w", h.columns[idx].label, err)
+		}
+		b, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("parse %s: %w", h.columns[idx].label, err)
+		}
+		return a, b, nil
+	case 6:
+		a, err := parseSexagesimal(fields[0:3])
+		if err != nil {
+			return 0, 0, fmt.Errorf("parse %s: %w", h.columns[idx].label, err)
+		}
+		b, err := parseSexagesimal(fields[3:6])
+		if err != nil {
+			return 0, 0, fmt.Errorf("parse %s: %w", h.columns[idx].label, err)
+		}
+		if hoursFirst {
+			a *= 15
+		}
+		return a, b, nil
+	default:
+		return 0, 0, fmt.Errorf("%s: expected 2 or 6 values, got %d", h.columns[idx].label, len(fields))
+	}
+}
+
+// isFlagField reports whether s looks like a Horizons solar/lunar presence
+// flag rather than a value.
+func isFlagField(s string) bool {
+	for _, r := range s {
+		switch r {
+		case '*', 'C', 'N', 'A', 'm', 'r', 't', 's', 'e', 'x':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// parseSexagesimal parses "HH MM SS.ss" or "-DD MM SS.s" into a decimal
+// value. The sign is taken from the first field's text so "-00" works.
+func parseSexagesimal(fields []string) (float64, error) {
+	var parts [3]float64
+	for i, f := range fields {
+		v, err := strconv.ParseFloat(f, 64)
+		if err != nil {
+			return 0, err
+		}
+		parts[i] = math.Abs(v)
+	}
+	v := parts[0] + parts[1]/60 + parts[2]/3600
+	if strings.HasPrefix(fields[0], "-") {
+		v = -v
+	}
+	return v, nil
+}
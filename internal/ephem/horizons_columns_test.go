@@ -0,0 +1,159 @@
+package ephem
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/litescript/ls-horizons/internal/astro"
+	"github.com/litescript/ls-horizons/internal/dsn"
+)
+
+func TestSplitHorizonsTable(t *testing.T) {
+	result := `
+*******************************************************************************
+ Date__(UT)__HR:MN     Azi_(a-app)_Elev
+*******************************************************************************
+$$SOE
+ 2025-Dec-05 00:00 *   261.03212 32.87802
+ 2025-Dec-05 00:10 *   262.10000 31.50000
+$$EOE
+`
+	hdr, lines, err := splitHorizonsTable(result)
+	if err != nil {
+		t.Fatalf("splitHorizonsTable failed: %v", err)
+	}
+	if len(hdr.columns) != 2 {
+		t.Fatalf("columns = %d, want 2", len(hdr.columns))
+	}
+	if hdr.column("Azi", "Elev") != 1 {
+		t.Errorf("Az/El column = %d, want 1", hdr.column("Azi", "Elev"))
+	}
+	if len(lines) != 2 {
+		t.Errorf("lines = %d, want 2", len(lines))
+	}
+}
+
+func TestSplitHorizonsTable_NoHeader(t *testing.T) {
+	result := "$$SOE\n 2025-Dec-05 00:00 *   261.03212 32.87802\n$$EOE"
+	if _, _, err := splitHorizonsTable(result); err == nil {
+		t.Error("expected error when column header is missing")
+	}
+}
+
+func TestParseEphemerisTable_ExtraColumns(t *testing.T) {
+	// RA/Dec precedes Az/El, and trailing quantities add more numbers; the
+	// old "first two numeric fields" heuristic would have returned RA/Dec.
+	result := `
+ Date__(UT)__HR:MN     R.A.___(ICRF)___DEC  Azi_(a-app)_Elev  a-mass mag_ex
+***************************************************************************
+$$SOE
+ 2025-Dec-05 00:00 *m  261.03212 -32.87803  101.2345 12.3456   4.621  0.879
+ 2025-Dec-05 00:10 *m  261.04000 -32.88000  102.0000 10.5000     n.a.   n.a.
+$$EOE
+`
+	points, err := parseEphemerisTable(result, astro.Observer{})
+	if err != nil {
+		t.Fatalf("parseEphemerisTable failed: %v", err)
+	}
+	if len(points) != 2 {
+		t.Fatalf("points = %d, want 2", len(points))
+	}
+
+	tests := []struct {
+		az, el float64
+	}{
+		{101.2345, 12.3456},
+		{102.0, 10.5},
+	}
+	for i, tt := range tests {
+		if points[i].Coord.AzDeg != tt.az || points[i].Coord.ElDeg != tt.el {
+			t.Errorf("point %d = Az %v El %v, want Az %v El %v",
+				i, points[i].Coord.AzDeg, points[i].Coord.ElDeg, tt.az, tt.el)
+		}
+	}
+}
+
+func TestParseEphemerisTable_NoAzElColumn(t *testing.T) {
+	result := `
+ Date__(UT)__HR:MN     R.A.___(ICRF)___DEC
+*******************************************
+$$SOE
+ 2025-Dec-05 00:00     261.03212 -32.87803
+$$EOE
+`
+	if _, err := parseEphemerisTable(result, astro.Observer{}); err == nil {
+		t.Error("expected error when Az/El column is missing")
+	}
+}
+
+func TestParseRADecLine(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		line    string
+		wantRA  float64
+		wantDec float64
+		wantErr bool
+	}{
+		{
+			name:    "degrees",
+			header:  " Date__(UT)__HR:MN     R.A.___(ICRF)___DEC",
+			line:    " 2025-Dec-05 00:00     261.03212 -32.87803",
+			wantRA:  261.03212,
+			wantDec: -32.87803,
+		},
+		{
+			name:    "sexagesimal",
+			header:  " Date__(UT)__HR:MN     R.A._____(ICRF)_____DEC",
+			line:    " 2025-Dec-05 00:00     17 24 07.69 -22 05 10.2",
+			wantRA:  (17 + 24.0/60 + 7.69/3600) * 15,
+			wantDec: -(22 + 5.0/60 + 10.2/3600),
+		},
+		{
+			name:    "negative zero degrees",
+			header:  " Date__(UT)__HR:MN     R.A._____(ICRF)_____DEC",
+			line:    " 2025-Dec-05 00:00     00 00 36.00 -00 30 00.0",
+			wantRA:  0.15,
+			wantDec: -0.5,
+		},
+		{
+			name:    "not available",
+			header:  " Date__(UT)__HR:MN     R.A.___(ICRF)___DEC",
+			line:    " 2025-Dec-05 00:00          n.a.      n.a.",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sample, err := parseRADecLine(tt.line, newHorizonsHeader(tt.header))
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if math.Abs(sample.RAdeg-tt.wantRA) > 1e-9 {
+				t.Errorf("RA = %v, want %v", sample.RAdeg, tt.wantRA)
+			}
+			if math.Abs(sample.DecDeg-tt.wantDec) > 1e-9 {
+				t.Errorf("Dec = %v, want %v", sample.DecDeg, tt.wantDec)
+			}
+		})
+	}
+}
+
+func TestSplitHorizonsTable_UnknownTarget(t *testing.T) {
+	result := "\n Horizons> -99999\n No matches found.\n"
+	_, _, err := splitHorizonsTable(result)
+	if !errors.Is(err, dsn.ErrUnknownTarget) {
+		t.Fatalf("err = %v, want dsn.ErrUnknownTarget", err)
+	}
+	if _, _, err := splitHorizonsTable("garbage"); !errors.Is(err, dsn.ErrParse) {
+		t.Errorf("err = %v, want dsn.ErrParse", err)
+	}
+}
@@ -0,0 +1,231 @@
+package ephem
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/astro"
+	"github.com/litescript/ls-horizons/internal/dsn"
+)
+
+// maxGETURLLength is the longest query URL sent via GET; longer queries
+// (and any query with a TLIST) go through the POST file-input API instead.
+const maxGETURLLength = 2000
+
+// doQuery runs a Horizons query and returns the response body. Short
+// queries use the GET API; long ones, or ones with an explicit list of
+// epochs (TLIST), are sent as an input file to the POST API, which has no
+// URL-length limit. The request counts against the quota for feature,
+// and isn't sent if the quota is used up.
+func (p *HorizonsProvider) doQuery(feature Feature, params url.Values, tlist []time.Time) ([]byte, error) {
+	if p.usage != nil {
+		if err := p.usage.Take(feature, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+
+	var (
+		resp *http.Response
+		err  error
+	)
+
+	reqURL := p.apiURL + "?" + params.Encode()
+	if len(tlist) == 0 && len(reqURL) <= maxGETURLLength {
+		resp, err = p.client.Get(reqURL)
+	} else {
+		resp, err = p.postFileInput(params, tlist)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusTooManyRequests:
+		return nil, fmt.Errorf("%w: Horizons returned status %d", dsn.ErrHorizonsRateLimited, resp.StatusCode)
+	default:
+		return nil, fmt.Errorf("Horizons returned status %d (service may be unavailable)", resp.StatusCode)
+	}
+
+	return body, nil
+}
+
+// postFileInput sends the query as a multipart "input" file to the Horizons
+// file API.
+func (p *HorizonsProvider) postFileInput(params url.Values, tlist []time.Time) (*http.Response, error) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+
+	format := params.Get("format")
+	if format == "" {
+		format = "json"
+	}
+	if err := mw.WriteField("format", format); err != nil {
+		return nil, fmt.Errorf("build POST body: %w", err)
+	}
+
+	fw, err := mw.CreateFormFile("input", "input.txt")
+	if err != nil {
+		return nil, fmt.Errorf("build POST body: %w", err)
+	}
+	if _, err := io.WriteString(fw, buildFileInput(params, tlist)); err != nil {
+		return nil, fmt.Errorf("build POST body: %w", err)
+	}
+	if err := mw.Close(); err != nil {
+		return nil, fmt.Errorf("build POST body: %w", err)
+	}
+
+	return p.client.Post(p.fileAPIURL, mw.FormDataContentType(), &buf)
+}
+
+// buildFileInput renders query parameters in the Horizons batch-file format:
+//
+//	!$$SOF
+//	COMMAND='499'
+//	EPHEM_TYPE='VECTORS'
+//	TLIST_TYPE='JD'
+//	TLIST=
+//	'2460651.500000000'
+//	'2460651.541666667'
+//
+// Keys are sorted for a stable file. "format" is sent as a form field rather
+// than in the file, and START/STOP/STEP are dropped when a TLIST is given.
+func buildFileInput(params url.Values, tlist []time.Time) string {
+	var b strings.Builder
+	b.WriteString("!$$SOF\n")
+
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		if k == "format" {
+			continue
+		}
+		if len(tlist) > 0 && (k == "START_TIME" || k == "STOP_TIME" || k == "STEP_SIZE") {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		fmt.Fprintf(&b, "%s=%s\n", k, quoteFileValue(params.Get(k)))
+	}
+
+	if len(tlist) > 0 {
+		b.WriteString("TLIST_TYPE='JD'\n")
+		b.WriteString("TLIST=\n")
+		for _, t := range tlist {
+			fmt.Fprintf(&b, "'%.9f'\n", julianDate(t))
+		}
+	}
+
+	return b.String()
+}
+
+// quoteFileValue wraps a value in single quotes unless it already is.
+func quoteFileValue(v string) string {
+	if strings.HasPrefix(v, "'") && strings.HasSuffix(v, "'") && len(v) >= 2 {
+		return v
+	}
+	return "'" + v + "'"
+}
+
+// julianDate converts a time to a Julian Date.
+func julianDate(t time.Time) float64 {
+	return float64(t.UnixNano())/float64(24*time.Hour) + 2440587.5
+}
+
+// GetHeliocentricPositions returns heliocentric ecliptic positions in AU for
+// a body at each of the given times, using a single TLIST query.
+func (p *HorizonsProvider) GetHeliocentricPositions(naifID int, times []time.Time) ([]astro.Vec3, error) {
+	if len(times) == 0 {
+		return nil, nil
+	}
+
+	params := url.Values{}
+	params.Set("format", "json")
+	params.Set("COMMAND", fmt.Sprintf("'%d'", naifID))
+	params.Set("OBJ_DATA", "NO")
+	params.Set("MAKE_EPHEM", "YES")
+	params.Set("EPHEM_TYPE", "VECTORS")
+	params.Set("CENTER", "'@10'")
+	params.Set("REF_PLANE", "ECLIPTIC")
+	params.Set("REF_SYSTEM", "ICRF")
+	params.Set("VEC_TABLE", "'1'") // Position only: one vector line per epoch
+	params.Set("VEC_LABELS", "NO")
+	params.Set("OUT_UNITS", "'AU-D'")
+	params.Set("TIME_TYPE", "UT")
+
+	body, err := p.doQuery(FeatureVectors, params, times)
+	if err != nil {
+		return nil, fmt.Errorf("horizons vector request failed: %w", err)
+	}
+
+	vecs, err := parseVectorSeriesResponse(body)
+	if err != nil {
+		return nil, err
+	}
+	if len(vecs) != len(times) {
+		return nil, fmt.Errorf("horizons returned %d vectors for %d epochs", len(vecs), len(times))
+	}
+	return vecs, nil
+}
+
+// parseVectorSeriesResponse parses every state vector in a Horizons VECTORS
+// response, in epoch order.
+func parseVectorSeriesResponse(body []byte) ([]astro.Vec3, error) {
+	bodyStr := strings.TrimSpace(string(body))
+	if strings.HasPrefix(bodyStr, "<!DOCTYPE") ||
+		strings.HasPrefix(bodyStr, "<html") ||
+		strings.HasPrefix(bodyStr, "<HTML") {
+		return nil, fmt.Errorf("Horizons API returned HTML error page (service may be unavailable)")
+	}
+
+	var resp horizonsResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("%w: Horizons response is not JSON", dsn.ErrParse)
+	}
+
+	soeIdx := strings.Index(resp.Result, "$$SOE")
+	eoeIdx := strings.Index(resp.Result, "$$EOE")
+	if soeIdx == -1 || eoeIdx == -1 || soeIdx >= eoeIdx {
+		return nil, missingTableError(resp.Result, "vector data")
+	}
+
+	var vecs []astro.Vec3
+	for _, line := range strings.Split(resp.Result[soeIdx+5:eoeIdx], "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.Contains(line, "A.D.") {
+			continue
+		}
+
+		var (
+			vec astro.Vec3
+			err error
+		)
+		if strings.Contains(line, "X =") {
+			vec, err = parseVectorLabeled(line)
+		} else {
+			vec, err = parseVectorUnlabeled(line)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parse vector line: %w", err)
+		}
+		vecs = append(vecs, vec)
+	}
+
+	return vecs, nil
+}
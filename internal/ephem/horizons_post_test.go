@@ -0,0 +1,128 @@
+package ephem
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildFileInput(t *testing.T) {
+	params := url.Values{}
+	params.Set("format", "json")
+	params.Set("COMMAND", "'499'")
+	params.Set("EPHEM_TYPE", "VECTORS")
+	params.Set("START_TIME", "'2025-12-05 00:00'")
+
+	t0 := time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC) // J2000.0 = JD 2451545.0
+	got := buildFileInput(params, []time.Time{t0, t0.Add(24 * time.Hour)})
+
+	want := "!$$SOF\n" +
+		"COMMAND='499'\n" +
+		"EPHEM_TYPE='VECTORS'\n" +
+		"TLIST_TYPE='JD'\n" +
+		"TLIST=\n" +
+		"'2451545.000000000'\n" +
+		"'2451546.000000000'\n"
+	if got != want {
+		t.Errorf("buildFileInput =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestBuildFileInput_NoTLIST(t *testing.T) {
+	params := url.Values{}
+	params.Set("START_TIME", "'2025-12-05 00:00'")
+
+	got := buildFileInput(params, nil)
+	if !strings.Contains(got, "START_TIME='2025-12-05 00:00'\n") {
+		t.Errorf("START_TIME should be kept without TLIST, got:\n%s", got)
+	}
+}
+
+const vectorSeriesResult = `$$SOE
+2451545.000000000 = A.D. 2000-Jan-01 12:00:00.0000 TDB
+ 1.000000000000000E+00  2.000000000000000E+00  3.000000000000000E-01
+2451546.000000000 = A.D. 2000-Jan-02 12:00:00.0000 TDB
+ 1.100000000000000E+00  2.100000000000000E+00  3.100000000000000E-01
+$$EOE`
+
+func TestDoQuery_PostsLongAndTLISTQueries(t *testing.T) {
+	var gotMethod, gotInput, gotFormat string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		if r.Method == http.MethodPost {
+			if err := r.ParseMultipartForm(1 << 20); err != nil {
+				t.Errorf("ParseMultipartForm: %v", err)
+			}
+			gotFormat = r.FormValue("format")
+			f, _, err := r.FormFile("input")
+			if err != nil {
+				t.Errorf("missing input file: %v", err)
+			} else {
+				b, _ := io.ReadAll(f)
+				gotInput = string(b)
+			}
+		}
+		fmt.Fprintf(w, `{"result":%q}`, vectorSeriesResult)
+	}))
+	defer srv.Close()
+
+	p := NewHorizonsProvider()
+	p.apiURL = srv.URL
+	p.fileAPIURL = srv.URL
+
+	// Short query without TLIST uses GET
+	params := url.Values{}
+	params.Set("format", "json")
+	params.Set("COMMAND", "'499'")
+	if _, err := p.doQuery(FeaturePasses, params, nil); err != nil {
+		t.Fatalf("doQuery: %v", err)
+	}
+	if gotMethod != http.MethodGet {
+		t.Errorf("short query method = %s, want GET", gotMethod)
+	}
+
+	// Over-long query switches to POST
+	params.Set("EXTRA", strings.Repeat("x", maxGETURLLength))
+	if _, err := p.doQuery(FeaturePasses, params, nil); err != nil {
+		t.Fatalf("doQuery: %v", err)
+	}
+	if gotMethod != http.MethodPost {
+		t.Errorf("long query method = %s, want POST", gotMethod)
+	}
+
+	// Multi-epoch query goes through TLIST
+	t0 := time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC)
+	vecs, err := p.GetHeliocentricPositions(499, []time.Time{t0, t0.Add(24 * time.Hour)})
+	if err != nil {
+		t.Fatalf("GetHeliocentricPositions: %v", err)
+	}
+	if gotMethod != http.MethodPost || gotFormat != "json" {
+		t.Errorf("TLIST query = %s format=%q, want POST format=json", gotMethod, gotFormat)
+	}
+	if !strings.Contains(gotInput, "TLIST=\n'2451545.000000000'\n") {
+		t.Errorf("input file missing TLIST:\n%s", gotInput)
+	}
+	if len(vecs) != 2 || vecs[1].X != 1.1 {
+		t.Errorf("vectors = %+v, want 2 with second X=1.1", vecs)
+	}
+}
+
+func TestGetHeliocentricPositions_CountMismatch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"result":%q}`, vectorSeriesResult)
+	}))
+	defer srv.Close()
+
+	p := NewHorizonsProvider()
+	p.fileAPIURL = srv.URL
+
+	_, err := p.GetHeliocentricPositions(499, []time.Time{time.Now()})
+	if err == nil {
+		t.Error("expected error when vector count doesn't match epochs")
+	}
+}
@@ -61,8 +61,12 @@ func TestHorizonsProvider_GetPath_Integration(t *testing.T) {
 	}
 }
 
+// azElHeader is the column header Horizons returns for QUANTITIES='4'.
+const azElHeader = " Date__(UT)__HR:MN     Azi_(a-app)_Elev"
+
 func TestParseEphemerisLine(t *testing.T) {
 	obs := astro.Observer{LatDeg: 35.0, LonDeg: -117.0}
+	hdr := newHorizonsHeader(azElHeader)
 
 	tests := []struct {
 		line    string
@@ -71,19 +75,23 @@ func TestParseEphemerisLine(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			line:   "2025-Dec-05 00:00 *   261.032124  32.878027",
-			wantAz: 261.032124,
-			wantEl: 32.878027,
+			line:   " 2025-Dec-05 00:00 *   261.03212 32.87802",
+			wantAz: 261.03212,
+			wantEl: 32.87802,
 		},
 		{
-			line:   "2025-Dec-05 01:00 Cm  270.255103  20.668754",
-			wantAz: 270.255103,
-			wantEl: 20.668754,
+			line:   " 2025-Dec-05 01:00 Cm  270.25510 20.66875",
+			wantAz: 270.25510,
+			wantEl: 20.66875,
 		},
 		{
-			line:   "2025-Dec-05 02:50  m  285.908122  -1.510301",
-			wantAz: 285.908122,
-			wantEl: -1.510301,
+			line:   " 2025-Dec-05 02:50  m  285.90812 -1.51030",
+			wantAz: 285.90812,
+			wantEl: -1.51030,
+		},
+		{
+			line:    " 2025-Dec-05 03:00  m        n.a.     n.a.",
+			wantErr: true,
 		},
 		{
 			line:    "invalid",
@@ -97,7 +105,7 @@ func TestParseEphemerisLine(t *testing.T) {
 			name = name[:20]
 		}
 		t.Run(name, func(t *testing.T) {
-			pt, err := parseEphemerisLine(tc.line, obs)
+			pt, err := parseEphemerisLine(tc.line, hdr, obs)
 			if tc.wantErr {
 				if err == nil {
 					t.Error("Expected error, got nil")
@@ -116,3 +124,88 @@ func TestParseEphemerisLine(t *testing.T) {
 		})
 	}
 }
+
+// Fuzz targets for the Horizons text-table parsers. Seeds for odd lines seen
+// in real responses live in testdata/fuzz.
+
+func FuzzParseEphemerisLine(f *testing.F) {
+	f.Add(" 2025-Dec-05 00:00 *   261.03212 32.87802")
+	f.Add(" 2025-Dec-05 02:50  m  285.90812 -1.51030")
+	f.Add("invalid")
+
+	obs := astro.Observer{LatDeg: 35.0, LonDeg: -117.0}
+	hdr := newHorizonsHeader(azElHeader)
+	f.Fuzz(func(t *testing.T, line string) {
+		pt, err := parseEphemerisLine(line, hdr, obs)
+		if err != nil {
+			return
+		}
+		if pt.Time.IsZero() || !pt.Valid {
+			t.Errorf("parseEphemerisLine(%q) = %+v, want valid timed point", line, pt)
+		}
+	})
+}
+
+func FuzzParseRADecLine(f *testing.F) {
+	f.Add(" 2025-Dec-05 00:00     261.03212 -32.87803")
+	f.Add(" 2025-Dec-05 00:00     17 24 07.69 -22 05 10.2")
+	f.Add(" 2025-Dec-05 00:00")
+
+	hdr := newHorizonsHeader(" Date__(UT)__HR:MN     R.A.___(ICRF)___DEC")
+	f.Fuzz(func(t *testing.T, line string) {
+		sample, err := parseRADecLine(line, hdr)
+		if err != nil {
+			return
+		}
+		if sample.Time.IsZero() {
+			t.Errorf("parseRADecLine(%q) returned zero time without error", line)
+		}
+	})
+}
+
+func FuzzParseVectorResponse(f *testing.F) {
+	f.Add([]byte(`{"result":"$$SOE\n2460651.500000000 = A.D. 2024-Dec-05 00:00:00.0000 TDB\n X = 1.0E+00 Y = 2.0E+00 Z = 3.0E-01\n$$EOE"}`))
+	f.Add([]byte(`{"result":"$$SOE\n 1.0E+00  2.0E+00  3.0E-01\n$$EOE"}`))
+
+	f.Fuzz(func(t *testing.T, body []byte) {
+		_, _ = parseVectorResponse(body)
+	})
+}
+
+func TestSamplesInRange(t *testing.T) {
+	start := time.Date(2025, 12, 5, 0, 0, 0, 0, time.UTC)
+	step := 5 * time.Minute
+	var samples []astro.RADecAtTime
+	for i := 0; i <= 48; i++ { // 4h
+		samples = append(samples, astro.RADecAtTime{Time: start.Add(time.Duration(i) * step)})
+	}
+
+	tests := []struct {
+		name       string
+		from, to   time.Time
+		wantOK     bool
+		wantFirst  time.Time
+		wantLength int
+	}{
+		{"full range", start, start.Add(4 * time.Hour), true, start, 49},
+		{"inner range", start.Add(time.Hour), start.Add(2 * time.Hour), true, start.Add(time.Hour), 13},
+		{"seconds past the minute", start.Add(time.Hour + 30*time.Second), start.Add(2 * time.Hour), true, start.Add(time.Hour), 13},
+		{"extends past end", start.Add(3 * time.Hour), start.Add(6 * time.Hour), false, time.Time{}, 0},
+		{"starts before", start.Add(-time.Hour), start.Add(time.Hour), false, time.Time{}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := samplesInRange(samples, tt.from, tt.to, step)
+			if ok != tt.wantOK {
+				t.Fatalf("covered = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if len(got) != tt.wantLength || !got[0].Time.Equal(tt.wantFirst) {
+				t.Errorf("got %d samples from %v, want %d from %v", len(got), got[0].Time, tt.wantLength, tt.wantFirst)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package ephem
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/astro"
+)
+
+// Lookup is a one-shot position of a target as seen by an observer.
+type Lookup struct {
+	Target    TargetInfo
+	Time      time.Time
+	Observer  astro.Observer
+	Sky       astro.SkyCoord // Geocentric RA/Dec with Az/El for the observer
+	RangeKm   float64        // Geocentric distance
+	LightTime float64        // One-way light time in seconds
+}
+
+// naifEarth is the NAIF ID Horizons uses for Earth's center.
+const naifEarth = 399
+
+// Lookup queries Horizons for a target's geocentric RA/Dec and distance at
+// t, and converts to Az/El for obs locally. It bypasses the path and
+// vector caches so the answer is always for exactly t.
+func (p *HorizonsProvider) Lookup(target TargetInfo, t time.Time, obs astro.Observer) (Lookup, error) {
+	samples, err := p.queryRADec(target.NAIFID, t, t.Add(time.Minute), time.Minute)
+	if err != nil {
+		return Lookup{}, err
+	}
+	if len(samples) == 0 {
+		return Lookup{}, fmt.Errorf("no data returned for target %d", target.NAIFID)
+	}
+
+	sc, err := p.queryHeliocentricVectors(int(target.NAIFID), t)
+	if err != nil {
+		return Lookup{}, err
+	}
+	earth, err := p.queryHeliocentricVectors(naifEarth, t)
+	if err != nil {
+		return Lookup{}, err
+	}
+	rangeAU := sc.Sub(earth).Norm()
+
+	eq := astro.SkyCoord{RAdeg: samples[0].RAdeg, DecDeg: samples[0].DecDeg}
+	sky := astro.EquatorialToHorizontal(eq, obs, t)
+	sky.RangeKm = astro.AUToKm(rangeAU)
+
+	return Lookup{
+		Target:    target,
+		Time:      t,
+		Observer:  obs,
+		Sky:       sky,
+		RangeKm:   sky.RangeKm,
+		LightTime: astro.LightTimeFromAU(rangeAU),
+	}, nil
+}
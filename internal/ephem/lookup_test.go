@@ -0,0 +1,79 @@
+package ephem
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/astro"
+)
+
+func TestLookup(t *testing.T) {
+	const raDecResult = `
+ Date__(UT)__HR:MN     R.A.___(ICRF)___DEC
+*******************************************
+$$SOE
+ 2025-Dec-05 00:00     261.03212 -32.87803
+ 2025-Dec-05 00:01     261.03213 -32.87804
+$$EOE
+`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		var result string
+		switch {
+		case q.Get("EPHEM_TYPE") == "OBSERVER":
+			result = raDecResult
+		case q.Get("COMMAND") == "'399'":
+			result = "$$SOE\n 1.0E+00  0.0E+00  0.0E+00\n$$EOE"
+		default:
+			result = "$$SOE\n 4.0E+00  4.0E+00  0.0E+00\n$$EOE"
+		}
+		fmt.Fprintf(w, `{"result":%q}`, result)
+	}))
+	defer srv.Close()
+
+	p := NewHorizonsProvider()
+	p.apiURL = srv.URL
+
+	target, _ := GetTargetByCode("VGR1")
+	at := time.Date(2025, 12, 5, 0, 0, 0, 0, time.UTC)
+	obs := astro.Observer{LatDeg: 35.4267, LonDeg: -116.89}
+
+	got, err := p.Lookup(target, at, obs)
+	if err != nil {
+		t.Fatalf("Lookup: %v", err)
+	}
+
+	if got.Sky.RAdeg != 261.03212 || got.Sky.DecDeg != -32.87803 {
+		t.Errorf("RA/Dec = %v/%v, want 261.03212/-32.87803", got.Sky.RAdeg, got.Sky.DecDeg)
+	}
+	want := astro.EquatorialToHorizontal(astro.SkyCoord{RAdeg: 261.03212, DecDeg: -32.87803}, obs, at)
+	if got.Sky.AzDeg != want.AzDeg || got.Sky.ElDeg != want.ElDeg {
+		t.Errorf("Az/El = %v/%v, want %v/%v", got.Sky.AzDeg, got.Sky.ElDeg, want.AzDeg, want.ElDeg)
+	}
+	// Earth at (1,0,0) and target at (4,4,0): 5 AU apart
+	if math.Abs(got.RangeKm-astro.AUToKm(5)) > 1 {
+		t.Errorf("RangeKm = %v, want %v", got.RangeKm, astro.AUToKm(5))
+	}
+	if math.Abs(got.LightTime-astro.LightTimeFromAU(5)) > 1e-6 {
+		t.Errorf("LightTime = %v, want %v", got.LightTime, astro.LightTimeFromAU(5))
+	}
+}
+
+func TestLookup_NoData(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"result":"No ephemeris for target"}`)
+	}))
+	defer srv.Close()
+
+	p := NewHorizonsProvider()
+	p.apiURL = srv.URL
+
+	target, _ := GetTargetByCode("VGR1")
+	if _, err := p.Lookup(target, time.Now(), astro.Observer{}); err == nil {
+		t.Error("expected error when Horizons returns no table")
+	}
+}
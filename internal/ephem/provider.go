@@ -42,6 +42,13 @@ type Provider interface {
 	Available(target TargetID) bool
 }
 
+// RADecProvider is implemented by providers that can return geocentric
+// RA/Dec samples over a time range, as pass planning and elevation traces
+// need.
+type RADecProvider interface {
+	GetRADecPath(target TargetID, start, end time.Time, step time.Duration) ([]astro.RADecAtTime, error)
+}
+
 // Mode represents which ephemeris source to use.
 type Mode int
 
@@ -0,0 +1,197 @@
+package ephem
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+)
+
+// Feature is what a Horizons request was made for, so usage can be
+// attributed.
+type Feature string
+
+const (
+	FeaturePaths   Feature = "paths"   // topocentric Az/El sky paths
+	FeaturePasses  Feature = "passes"  // geocentric RA/Dec for pass plans and traces
+	FeatureVectors Feature = "vectors" // heliocentric state vectors for the Orbit view
+)
+
+// Features lists every feature in display order.
+var Features = []Feature{FeaturePaths, FeaturePasses, FeatureVectors}
+
+// ErrQuotaExceeded is returned for requests refused by the self-imposed
+// quota; they are not sent to JPL. It is a dsn.ErrHorizonsRateLimited.
+var ErrQuotaExceeded = fmt.Errorf("%w by the self-imposed quota", dsn.ErrHorizonsRateLimited)
+
+// QuotaWarnFraction is the share of either quota at which usage is
+// worth a warning.
+const QuotaWarnFraction = 0.8
+
+// HorizonsQuota is a self-imposed limit on Horizons requests, so a heavy
+// session doesn't get its address blocked by JPL. Each limit is a token
+// bucket: a full bucket allows a burst of that many requests, and it
+// refills evenly over the hour or day. Zero means no limit.
+type HorizonsQuota struct {
+	PerHour int
+	PerDay  int
+}
+
+// DefaultHorizonsQuota returns the built-in quota: 300 requests an hour
+// and 2000 a day, well above normal use.
+func DefaultHorizonsQuota() HorizonsQuota {
+	return HorizonsQuota{PerHour: 300, PerDay: 2000}
+}
+
+// Validate reports a quota that can't be used: limits can't be negative,
+// and an hourly limit above the daily one would never apply.
+func (q HorizonsQuota) Validate() error {
+	switch {
+	case q.PerHour < 0 || q.PerDay < 0:
+		return errors.New("Horizons quota must not be negative")
+	case q.PerHour > 0 && q.PerDay > 0 && q.PerHour > q.PerDay:
+		return fmt.Errorf("Horizons hourly quota (%d) must not exceed the daily quota (%d)", q.PerHour, q.PerDay)
+	}
+	return nil
+}
+
+// tokenBucket holds up to a limit's worth of tokens, refilling at the
+// limit per period.
+type tokenBucket struct {
+	tokens float64
+	at     time.Time
+}
+
+// take refills the bucket to now and spends a token if one is left.
+func (b *tokenBucket) take(limit int, period time.Duration, now time.Time) bool {
+	if limit <= 0 {
+		return true
+	}
+	if b.at.IsZero() {
+		b.tokens = float64(limit)
+	} else if elapsed := now.Sub(b.at); elapsed > 0 {
+		b.tokens = min(float64(limit), b.tokens+float64(limit)*elapsed.Seconds()/period.Seconds())
+	}
+	b.at = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// usageEntry is one request, sent or refused.
+type usageEntry struct {
+	at      time.Time
+	feature Feature
+	refused bool
+}
+
+// Usage tracks Horizons requests against a quota.
+type Usage struct {
+	mu        sync.Mutex
+	quota     HorizonsQuota
+	hour, day tokenBucket
+	log       []usageEntry // the last day's requests, oldest first
+}
+
+// NewUsage creates a tracker enforcing q.
+func NewUsage(q HorizonsQuota) *Usage {
+	return &Usage{quota: q}
+}
+
+// Take records a request for f at now, or refuses it with
+// ErrQuotaExceeded if either bucket is empty.
+func (u *Usage) Take(f Feature, now time.Time) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.prune(now)
+	// Check both before spending from either, so a refusal costs nothing
+	hour, day := u.hour, u.day
+	ok := hour.take(u.quota.PerHour, time.Hour, now) && day.take(u.quota.PerDay, 24*time.Hour, now)
+	u.log = append(u.log, usageEntry{at: now, feature: f, refused: !ok})
+	if !ok {
+		return ErrQuotaExceeded
+	}
+	u.hour, u.day = hour, day
+	return nil
+}
+
+// prune drops requests more than a day old.
+func (u *Usage) prune(now time.Time) {
+	cutoff := now.Add(-24 * time.Hour)
+	i := 0
+	for i < len(u.log) && !u.log[i].at.After(cutoff) {
+		i++
+	}
+	u.log = u.log[i:]
+}
+
+// UsageStats are the requests sent in the last hour and day, in total and
+// by feature, against the quota in effect.
+type UsageStats struct {
+	Quota   HorizonsQuota
+	Hour    int
+	Day     int
+	HourBy  map[Feature]int
+	DayBy   map[Feature]int
+	Refused int // requests refused by the quota in the last day
+}
+
+// Stats returns usage as of now.
+func (u *Usage) Stats(now time.Time) UsageStats {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.prune(now)
+	s := UsageStats{
+		Quota:  u.quota,
+		HourBy: make(map[Feature]int),
+		DayBy:  make(map[Feature]int),
+	}
+	hourAgo := now.Add(-time.Hour)
+	for _, e := range u.log {
+		if e.refused {
+			s.Refused++
+			continue
+		}
+		s.Day++
+		s.DayBy[e.feature]++
+		if e.at.After(hourAgo) {
+			s.Hour++
+			s.HourBy[e.feature]++
+		}
+	}
+	return s
+}
+
+// Warning describes usage at or above QuotaWarnFraction of either limit,
+// or returns "" below it.
+func (s UsageStats) Warning() string {
+	used, limit, window := s.Hour, s.Quota.PerHour, "hour"
+	if s.fraction(s.Day, s.Quota.PerDay) > s.fraction(used, limit) {
+		used, limit, window = s.Day, s.Quota.PerDay, "day"
+	}
+	switch {
+	case limit == 0 || s.fraction(used, limit) < QuotaWarnFraction:
+		if s.Refused > 0 {
+			return fmt.Sprintf("Horizons quota: %d requests refused in the last day", s.Refused)
+		}
+		return ""
+	case used >= limit:
+		return fmt.Sprintf("Horizons quota reached: %d/%d this %s", used, limit, window)
+	default:
+		return fmt.Sprintf("Horizons quota: %d/%d this %s", used, limit, window)
+	}
+}
+
+// fraction is used/limit, or 0 with no limit.
+func (s UsageStats) fraction(used, limit int) float64 {
+	if limit <= 0 {
+		return 0
+	}
+	return float64(used) / float64(limit)
+}
@@ -0,0 +1,142 @@
+package ephem
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+)
+
+func TestUsage_TokenBucket(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	u := NewUsage(HorizonsQuota{PerHour: 4, PerDay: 10})
+
+	// A full bucket allows a burst of the hourly limit
+	for i := 0; i < 4; i++ {
+		if err := u.Take(FeaturePasses, t0); err != nil {
+			t.Fatalf("request %d: %v", i, err)
+		}
+	}
+	if err := u.Take(FeaturePaths, t0); !errors.Is(err, ErrQuotaExceeded) || !errors.Is(err, dsn.ErrHorizonsRateLimited) {
+		t.Fatalf("5th request = %v, want ErrQuotaExceeded, rate limited", err)
+	}
+
+	// One token back every 15 minutes
+	if err := u.Take(FeaturePaths, t0.Add(10*time.Minute)); err == nil {
+		t.Error("request after 10 minutes allowed, want refused")
+	}
+	if err := u.Take(FeatureVectors, t0.Add(15*time.Minute)); err != nil {
+		t.Errorf("request after 15 minutes: %v", err)
+	}
+
+	s := u.Stats(t0.Add(30 * time.Minute))
+	if s.Hour != 5 || s.Day != 5 || s.Refused != 2 {
+		t.Errorf("stats = %+v, want 5 sent, 2 refused", s)
+	}
+	if s.HourBy[FeaturePasses] != 4 || s.HourBy[FeatureVectors] != 1 || s.HourBy[FeaturePaths] != 0 {
+		t.Errorf("by feature = %v", s.HourBy)
+	}
+
+	// The hourly count rolls off; the daily one doesn't
+	s = u.Stats(t0.Add(2 * time.Hour))
+	if s.Hour != 0 || s.Day != 5 {
+		t.Errorf("two hours later: hour %d day %d, want 0 and 5", s.Hour, s.Day)
+	}
+	if s = u.Stats(t0.Add(25 * time.Hour)); s.Day != 0 || s.Refused != 0 {
+		t.Errorf("a day later: %+v, want nothing left", s)
+	}
+}
+
+func TestUsage_DailyLimit(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	u := NewUsage(HorizonsQuota{PerDay: 2})
+	for i := 0; i < 2; i++ {
+		if err := u.Take(FeaturePasses, t0.Add(time.Duration(i)*time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := u.Take(FeaturePasses, t0.Add(3*time.Hour)); err == nil {
+		t.Error("3rd request in the day allowed, want refused")
+	}
+	// A refusal spends nothing: the token spent at 0h is back by 13h
+	if err := u.Take(FeaturePasses, t0.Add(13*time.Hour)); err != nil {
+		t.Errorf("request 13 hours in: %v", err)
+	}
+}
+
+func TestUsageStats_Warning(t *testing.T) {
+	q := HorizonsQuota{PerHour: 100, PerDay: 1000}
+	tests := []struct {
+		name string
+		s    UsageStats
+		want string
+	}{
+		{"quiet", UsageStats{Quota: q, Hour: 10, Day: 100}, ""},
+		{"near hourly", UsageStats{Quota: q, Hour: 85, Day: 100}, "Horizons quota: 85/100 this hour"},
+		{"near daily", UsageStats{Quota: q, Hour: 10, Day: 900}, "Horizons quota: 900/1000 this day"},
+		{"reached", UsageStats{Quota: q, Hour: 100, Day: 500}, "Horizons quota reached: 100/100 this hour"},
+		{"refused", UsageStats{Quota: q, Hour: 10, Day: 20, Refused: 3}, "Horizons quota: 3 requests refused in the last day"},
+		{"no limit", UsageStats{Hour: 5000, Day: 9000}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.s.Warning(); got != tt.want {
+				t.Errorf("Warning() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHorizonsQuota_Validate(t *testing.T) {
+	for _, q := range []HorizonsQuota{DefaultHorizonsQuota(), {}, {PerHour: 10}, {PerDay: 10}} {
+		if err := q.Validate(); err != nil {
+			t.Errorf("%+v: %v", q, err)
+		}
+	}
+	for _, q := range []HorizonsQuota{{PerHour: -1}, {PerHour: 20, PerDay: 10}} {
+		if err := q.Validate(); err == nil {
+			t.Errorf("%+v: no error", q)
+		}
+	}
+}
+
+func TestDoQuery_Quota(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `{"result":""}`)
+	}))
+	defer srv.Close()
+
+	p := NewHorizonsProvider(WithUsage(NewUsage(HorizonsQuota{PerHour: 1})))
+	p.apiURL = srv.URL
+
+	if _, err := p.doQuery(FeaturePaths, url.Values{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.doQuery(FeaturePaths, url.Values{}, nil); !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("second query = %v, want ErrQuotaExceeded", err)
+	}
+	if requests != 1 {
+		t.Errorf("server saw %d requests, want 1", requests)
+	}
+	if s := p.usage.Stats(time.Now()); s.HourBy[FeaturePaths] != 1 || s.Refused != 1 {
+		t.Errorf("stats = %+v", s)
+	}
+
+	// JPL's own rate limit is classified the same way
+	limited := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer limited.Close()
+	p.apiURL = limited.URL
+	p.usage = NewUsage(HorizonsQuota{})
+	if _, err := p.doQuery(FeaturePaths, url.Values{}, nil); !errors.Is(err, dsn.ErrHorizonsRateLimited) {
+		t.Errorf("429 = %v, want dsn.ErrHorizonsRateLimited", err)
+	}
+}
@@ -1,5 +1,7 @@
 package ephem
 
+import "github.com/litescript/ls-horizons/internal/dsn"
+
 // TargetInfo contains mapping information for a spacecraft.
 type TargetInfo struct {
 	Code     string   // DSN short code (e.g., "VGR1")
@@ -59,6 +61,7 @@ const (
 	NAIFXMM              TargetID = -125
 	NAIFINTEGRAL         TargetID = -130
 	NAIFFermi            TargetID = -160
+	NAIFHayabusa2        TargetID = -37
 )
 
 // Targets is the canonical list of tracked spacecraft with their NAIF mappings.
@@ -89,6 +92,8 @@ var Targets = []TargetInfo{
 	{Code: "LUCY", Name: "Lucy", NAIFID: NAIFLucy},
 	{Code: "PSYC", Name: "Psyche", NAIFID: NAIFPsyche},
 
+	{Code: "HYB2", Name: "Hayabusa2", NAIFID: NAIFHayabusa2, Aliases: []string{"HAYABUSA2"}},
+
 	// Mercury
 	{Code: "BEPI", Name: "BepiColombo", NAIFID: NAIFBepiColombo},
 
@@ -199,21 +204,22 @@ var TargetsByName = func() map[string]TargetInfo {
 	addVariation("HOPE", "EMM")      // Hope/Emirates Mars Mission
 	addVariation("AL-AMAL", "EMM")   // Hope (Arabic name)
 	addVariation("CAPSTONE", "CAPS") // Capstone
+
+	// Native-script and transliterated names
+	addVariation("HAYABUSA 2", "HYB2")   // Hayabusa2
+	addVariation("はやぶさ2", "HYB2")        // Hayabusa2 (Japanese)
+	addVariation("다누리", "KPLO")          // Danuri (Korean)
+	addVariation("الأمل", "EMM")         // Hope (Arabic)
+	addVariation("مسبار الأمل", "EMM")   // Hope Probe (Arabic)
+	addVariation("चंद्रयान-3", "CH3")    // Chandrayaan-3 (Hindi)
+	addVariation("CHANDRAYAAN-3", "CH3") // Chandrayaan-3
 	return m
 }()
 
-// normalizeName converts a spacecraft name to lowercase for matching.
+// normalizeName folds a spacecraft name for matching: case, diacritics,
+// typographic punctuation, and fullwidth forms are ignored.
 func normalizeName(name string) string {
-	// Simple lowercase, handles most cases
-	result := make([]byte, 0, len(name))
-	for i := 0; i < len(name); i++ {
-		c := name[i]
-		if c >= 'A' && c <= 'Z' {
-			c = c + ('a' - 'A')
-		}
-		result = append(result, c)
-	}
-	return string(result)
+	return dsn.FoldName(name)
 }
 
 // GetNAIFID returns the NAIF ID for a DSN spacecraft code, or 0 if unknown.
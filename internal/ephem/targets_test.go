@@ -1,6 +1,10 @@
 package ephem
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+)
 
 func TestGetNAIFID_KnownSpacecraft(t *testing.T) {
 	tests := []struct {
@@ -91,3 +95,48 @@ func TestGetTargetByNAIF(t *testing.T) {
 		t.Errorf("Name = %q, want %q", info.Name, "Voyager 2")
 	}
 }
+
+func TestGetTargetByName_LocalizedForms(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Hayabusa2", "HYB2"},
+		{"HAYABUSA 2", "HYB2"},
+		{"はやぶさ2", "HYB2"},
+		{"はやぶさ２", "HYB2"}, // fullwidth digit
+		{"다누리", "KPLO"},
+		{"الأمل", "EMM"},
+		{"Chandrayaan–3", "CH3"}, // en dash
+		{"  voyager   1 ", "VGR1"},
+	}
+	for _, tt := range tests {
+		got, ok := GetTargetByName(tt.name)
+		if !ok {
+			t.Errorf("GetTargetByName(%q) not found, want %s", tt.name, tt.want)
+			continue
+		}
+		if got.Code != tt.want {
+			t.Errorf("GetTargetByName(%q) = %s, want %s", tt.name, got.Code, tt.want)
+		}
+	}
+}
+
+func TestTargets_MatchSpacecraftCatalog(t *testing.T) {
+	// The DSN catalog carries NAIF IDs for export; keep it in step with
+	// the ephemeris target table.
+	for _, target := range Targets {
+		info := dsn.GetSpacecraftInfo(target.Code)
+		if info == nil || info.NAIFID == 0 {
+			continue
+		}
+		if TargetID(info.NAIFID) != target.NAIFID {
+			t.Errorf("%s: catalog NAIF ID %d, targets %d", target.Code, info.NAIFID, target.NAIFID)
+		}
+		for _, alias := range target.Aliases {
+			if got := dsn.CanonicalCode(alias); dsn.GetSpacecraftInfo(alias) != nil && got != target.Code {
+				t.Errorf("CanonicalCode(%s) = %s, want %s", alias, got, target.Code)
+			}
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// Package eventlog keeps detected events on disk so they outlast the
+// in-memory event log and survive restarts.
+//
+// Events are stored as JSON Lines, one event per line, appended as they
+// are detected, so the file can be tailed, grepped, or fed to jq.
+package eventlog
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/logging"
+	"github.com/litescript/ls-horizons/internal/sandbox"
+	"github.com/litescript/ls-horizons/internal/state"
+)
+
+// DefaultPath returns $XDG_DATA_HOME/ls-horizons/events.jsonl, falling
+// back to ~/.local/share.
+func DefaultPath() string {
+	dir := os.Getenv("XDG_DATA_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(dir, "ls-horizons", "events.jsonl")
+}
+
+// Append writes events to the end of the log at path, creating it and
+// its directory if needed.
+func Append(path string, events []state.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	if err := sandbox.CheckWrite(path); err != nil {
+		return err
+	}
+	var buf []byte
+	for _, e := range events {
+		line, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		buf = append(append(buf, line...), '\n')
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create event log directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("open event log: %w", err)
+	}
+	_, err = f.Write(buf)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("write event log: %w", err)
+	}
+	return nil
+}
+
+// Since reads the events in the log at path detected at or after t,
+// oldest first. A missing log has no events.
+func Since(path string, t time.Time) ([]state.Event, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open event log: %w", err)
+	}
+	defer f.Close()
+
+	var events []state.Event
+	sc := bufio.NewScanner(f)
+	for lineNo := 1; sc.Scan(); lineNo++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		var e state.Event
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			return nil, fmt.Errorf("%s: line %d: %w", path, lineNo, err)
+		}
+		if !e.Timestamp.Before(t) {
+			events = append(events, e)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("read event log: %w", err)
+	}
+	return events, nil
+}
+
+// Run appends the events in each update to the log at path until ctx is
+// cancelled or updates closes. Like notifications, it skips the first
+// update: links already up at startup were logged by an earlier run, or
+// weren't seen being acquired. Write failures are logged, not returned,
+// so a full disk doesn't stop the feed.
+func Run(ctx context.Context, path string, updates <-chan state.Update, logger *logging.Logger) {
+	primed := false
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case u, ok := <-updates:
+			if !ok {
+				return
+			}
+			if !primed {
+				primed = true
+				continue
+			}
+			if err := Append(path, u.Events); err != nil {
+				logger.Warn("Event log: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package eventlog
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/logging"
+	"github.com/litescript/ls-horizons/internal/state"
+)
+
+func TestAppendAndSince(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", "events.jsonl")
+	if events, err := Since(path, time.Time{}); err != nil || len(events) != 0 {
+		t.Fatalf("Since on missing log = %v, %v; want none", events, err)
+	}
+
+	t0 := time.Date(2025, 12, 5, 6, 30, 0, 0, time.UTC)
+	if err := Append(path, []state.Event{
+		{Type: state.EventNewLink, Timestamp: t0, Spacecraft: "VGR1", AntennaID: "DSS43"},
+		{Type: state.EventHandoff, Timestamp: t0.Add(time.Hour), Spacecraft: "JWST", OldStation: "gdscc", NewStation: "mdscc"},
+	}); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	if err := Append(path, []state.Event{{Type: state.EventLinkLost, Timestamp: t0.Add(2 * time.Hour), Spacecraft: "VGR1"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	all, err := Since(path, time.Time{})
+	if err != nil {
+		t.Fatalf("Since: %v", err)
+	}
+	if len(all) != 3 || all[0].AntennaID != "DSS43" || all[2].Type != state.EventLinkLost {
+		t.Errorf("Since(zero) = %+v, want all 3 in order", all)
+	}
+	recent, _ := Since(path, t0.Add(time.Hour))
+	if len(recent) != 2 || recent[0].Spacecraft != "JWST" {
+		t.Errorf("Since(t0+1h) = %+v, want the handoff and the loss", recent)
+	}
+}
+
+func TestSince_Malformed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	content := `{"type":"NEW_LINK","timestamp":"2025-12-05T06:30:00Z","spacecraft":"VGR1"}` + "\n\n{not json\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Since(path, time.Time{}); err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Errorf("err = %v, want a line 3 error", err)
+	}
+}
+
+func TestRun_SkipsFirstUpdate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	t0 := time.Date(2025, 12, 5, 6, 30, 0, 0, time.UTC)
+	updates := make(chan state.Update, 2)
+	updates <- state.Update{Events: []state.Event{{Type: state.EventNewLink, Timestamp: t0, Spacecraft: "VGR1"}}}
+	updates <- state.Update{Events: []state.Event{{Type: state.EventLinkLost, Timestamp: t0.Add(time.Minute), Spacecraft: "VGR1"}}}
+	close(updates)
+
+	Run(context.Background(), path, updates, logging.Discard())
+
+	events, err := Since(path, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].Type != state.EventLinkLost {
+		t.Errorf("logged %+v, want only the LINK_LOST after startup", events)
+	}
+}
@@ -0,0 +1,249 @@
+// Package focussync shares the focused spacecraft between ls-horizons
+// instances on one machine, so selecting a spacecraft in one terminal
+// (say, the dashboard) selects it in the others (the sky view on another
+// monitor).
+//
+// Instances meet on a Unix socket. The first to start listens on it and
+// relays every message to the other peers; later instances connect to
+// it. When the listening instance exits, the others race to take its
+// place. Messages are JSON lines: {"code":"VGR1"}.
+package focussync
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/logging"
+)
+
+// writeTimeout bounds each message to a peer, so a stalled instance
+// can't hold up the others.
+const writeTimeout = time.Second
+
+// RetryDelay is how long a bus waits before trying to rejoin after losing
+// its peers or failing to reach the socket.
+const RetryDelay = time.Second
+
+// message is one focus change on the wire.
+type message struct {
+	Code string `json:"code"`
+}
+
+// DefaultPath returns $XDG_RUNTIME_DIR/ls-horizons.sock, falling back to
+// a per-user socket in the temp directory.
+func DefaultPath() string {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return filepath.Join(dir, "ls-horizons.sock")
+	}
+	return filepath.Join(os.TempDir(), fmt.Sprintf("ls-horizons-%d.sock", os.Getuid()))
+}
+
+// Bus is one instance's connection to the shared focus.
+type Bus struct {
+	path   string
+	logger *logging.Logger
+
+	out chan string // local focus changes, latest only
+	in  chan string // focus changes from other instances
+}
+
+// New returns a bus meeting on the socket at path. Nothing happens until
+// Run is called.
+func New(path string, logger *logging.Logger) *Bus {
+	return &Bus{
+		path:   path,
+		logger: logger,
+		out:    make(chan string, 1),
+		in:     make(chan string, 16),
+	}
+}
+
+// Publish shares a local focus change. It never blocks: if the previous
+// change hasn't been sent yet, it is replaced.
+func (b *Bus) Publish(code string) {
+	for {
+		select {
+		case b.out <- code:
+			return
+		default:
+		}
+		select {
+		case <-b.out:
+		default:
+		}
+	}
+}
+
+// Focus returns the spacecraft codes focused in other instances.
+func (b *Bus) Focus() <-chan string {
+	return b.in
+}
+
+// Run joins the other instances, as a peer of the listening one or as
+// the listener, until ctx is cancelled.
+func (b *Bus) Run(ctx context.Context) {
+	for ctx.Err() == nil {
+		if err := b.session(ctx); err != nil && ctx.Err() == nil {
+			b.logger.Debug("Focus sync: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(RetryDelay):
+		}
+	}
+}
+
+// session connects to the listening instance, or becomes it when there is
+// none, and returns when the connection or listener is lost.
+func (b *Bus) session(ctx context.Context) error {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "unix", b.path)
+	if err == nil {
+		b.logger.Debug("Focus sync: joined %s", b.path)
+		return b.peer(ctx, conn)
+	}
+
+	// Nobody is listening: a socket left by a crashed instance is removed.
+	// Dialing a regular file is refused too, and that file is left alone.
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		fi, err := os.Lstat(b.path)
+		switch {
+		case err == nil && fi.Mode()&fs.ModeSocket == 0:
+			return fmt.Errorf("%s exists and is not a socket", b.path)
+		case err == nil:
+			if err := os.Remove(b.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+		case !errors.Is(err, fs.ErrNotExist):
+			return err
+		}
+	}
+	ln, err := net.Listen("unix", b.path)
+	if err != nil {
+		return err
+	}
+	b.logger.Debug("Focus sync: listening on %s", b.path)
+	return b.relay(ctx, ln)
+}
+
+// peer exchanges focus changes with the listening instance.
+func (b *Bus) peer(ctx context.Context, conn net.Conn) error {
+	defer conn.Close()
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	lost := make(chan error, 1)
+	go func() {
+		lost <- b.read(conn, nil)
+	}()
+
+	enc := json.NewEncoder(conn)
+	for {
+		select {
+		case err := <-lost:
+			return err
+		case code := <-b.out:
+			if err := enc.Encode(message{Code: code}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// relay listens for other instances, passing each message to every peer
+// but its sender, and exchanges focus changes with them.
+func (b *Bus) relay(ctx context.Context, ln net.Listener) error {
+	var (
+		mu    sync.Mutex
+		peers = make(map[net.Conn]*json.Encoder)
+	)
+	broadcast := func(code string, from net.Conn) {
+		mu.Lock()
+		defer mu.Unlock()
+		for conn, enc := range peers {
+			if conn == from {
+				continue
+			}
+			conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if err := enc.Encode(message{Code: code}); err != nil {
+				conn.Close()
+				delete(peers, conn)
+			}
+		}
+	}
+
+	defer func() {
+		mu.Lock()
+		defer mu.Unlock()
+		for conn := range peers {
+			conn.Close()
+		}
+	}()
+	stop := context.AfterFunc(ctx, func() { ln.Close() })
+	defer stop()
+
+	accepted := make(chan error, 1)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				accepted <- err
+				return
+			}
+			mu.Lock()
+			peers[conn] = json.NewEncoder(conn)
+			mu.Unlock()
+			go func() {
+				b.read(conn, func(code string) { broadcast(code, conn) })
+				mu.Lock()
+				delete(peers, conn)
+				mu.Unlock()
+				conn.Close()
+			}()
+		}
+	}()
+
+	for {
+		select {
+		case err := <-accepted:
+			ln.Close()
+			return err
+		case code := <-b.out:
+			broadcast(code, nil)
+		}
+	}
+}
+
+// read delivers focus changes from conn until it closes, passing each to
+// forward as well when it is set.
+func (b *Bus) read(conn net.Conn, forward func(code string)) error {
+	sc := bufio.NewScanner(conn)
+	for sc.Scan() {
+		var msg message
+		if err := json.Unmarshal(sc.Bytes(), &msg); err != nil || msg.Code == "" {
+			continue
+		}
+		if forward != nil {
+			forward(msg.Code)
+		}
+		select {
+		case b.in <- msg.Code:
+		default:
+			// Nobody is reading; drop rather than stall the connection
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+	return errors.New("connection closed")
+}
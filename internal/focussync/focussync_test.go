@@ -0,0 +1,151 @@
+package focussync
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/logging"
+)
+
+// startBus runs a bus on path until the returned stop is called.
+func startBus(t *testing.T, path string) (*Bus, context.CancelFunc) {
+	t.Helper()
+	b := New(path, logging.Discard())
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		b.Run(ctx)
+	}()
+	stop := func() {
+		cancel()
+		<-done
+	}
+	t.Cleanup(stop)
+	return b, stop
+}
+
+// socketPath returns a short socket path; sun_path is ~100 bytes.
+func socketPath(t *testing.T) string {
+	dir, err := os.MkdirTemp("", "lsh")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return filepath.Join(dir, "s.sock")
+}
+
+// waitListening waits until someone accepts on path.
+func waitListening(t *testing.T, path string) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if conn, err := net.Dial("unix", path); err == nil {
+			conn.Close()
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("nobody listening on", path)
+}
+
+// publishUntil publishes code from b until want receives it: a peer that
+// just connected may miss the first message. Repeats of earlier codes,
+// left over from previous retries, are skipped.
+func publishUntil(t *testing.T, b *Bus, code string, want *Bus) {
+	t.Helper()
+	deadline := time.After(5 * time.Second)
+	tick := time.NewTicker(20 * time.Millisecond)
+	defer tick.Stop()
+	for {
+		b.Publish(code)
+		select {
+		case got := <-want.Focus():
+			if got == code {
+				return
+			}
+		case <-tick.C:
+		case <-deadline:
+			t.Fatalf("%q never arrived", code)
+		}
+	}
+}
+
+func TestBus_SharesFocus(t *testing.T) {
+	path := socketPath(t)
+	hub, _ := startBus(t, path)
+	waitListening(t, path)
+	a, _ := startBus(t, path)
+	b, _ := startBus(t, path)
+
+	publishUntil(t, a, "VGR1", b)
+	publishUntil(t, a, "JWST", hub)
+	publishUntil(t, hub, "MRO", a)
+	publishUntil(t, b, "MVN", a)
+
+	select {
+	case got := <-b.Focus():
+		if got == "MVN" {
+			t.Error("a bus received its own focus change")
+		}
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestBus_TakesOverWhenListenerExits(t *testing.T) {
+	path := socketPath(t)
+	_, stopHub := startBus(t, path)
+	waitListening(t, path)
+	a, _ := startBus(t, path)
+	b, _ := startBus(t, path)
+	publishUntil(t, a, "VGR1", b)
+
+	stopHub()
+	waitListening(t, path)
+	publishUntil(t, a, "JWST", b)
+	publishUntil(t, b, "MRO", a)
+}
+
+func TestBus_RemovesStaleSocket(t *testing.T) {
+	path := socketPath(t)
+	// A socket file nobody listens on, as a crashed instance leaves
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ln.(*net.UnixListener).SetUnlinkOnClose(false)
+	ln.Close()
+
+	a, _ := startBus(t, path)
+	waitListening(t, path)
+	b, _ := startBus(t, path)
+	publishUntil(t, a, "VGR1", b)
+}
+
+func TestBus_KeepsFileAtSocketPath(t *testing.T) {
+	path := socketPath(t)
+	if err := os.WriteFile(path, []byte("notes\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	b := New(path, logging.Discard())
+	if err := b.session(context.Background()); err == nil {
+		t.Error("session on a regular file succeeded, want an error")
+	}
+	if data, err := os.ReadFile(path); err != nil || string(data) != "notes\n" {
+		t.Errorf("file at socket path = %q, %v; want it untouched", data, err)
+	}
+}
+
+func TestPublish_LatestOnly(t *testing.T) {
+	b := New(socketPath(t), logging.Discard())
+	b.Publish("VGR1")
+	b.Publish("JWST")
+	if got := <-b.out; got != "JWST" {
+		t.Errorf("queued %q, want the latest", got)
+	}
+}
@@ -0,0 +1,123 @@
+// Package metrics exposes DSN state as Prometheus gauges in the text
+// exposition format, for graphing with Prometheus and Grafana.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+	"github.com/litescript/ls-horizons/internal/state"
+)
+
+// ContentType is the Prometheus text exposition format version 0.0.4.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// gauge is one metric family: its help text and samples.
+type gauge struct {
+	name    string
+	help    string
+	samples []sample
+}
+
+type sample struct {
+	labels string // pre-rendered {k="v",...}
+	value  float64
+}
+
+// Write renders the exported snapshot as Prometheus gauges: per-link data
+// rate, RTLT, elevation, and struggle index, plus per-complex utilization.
+func Write(w io.Writer, snap *dsn.SnapshotExport) error {
+	rate := gauge{name: "dsn_link_data_rate_bps", help: "Link data rate in bits per second."}
+	rtlt := gauge{name: "dsn_link_rtlt_seconds", help: "Link round-trip light time in seconds."}
+	elev := gauge{name: "dsn_link_elevation_degrees", help: "Elevation of the antenna serving the link."}
+	struggle := gauge{name: "dsn_link_struggle_index", help: "Link struggle index (0 = easy, 1 = marginal)."}
+	for _, l := range snap.Links {
+		lbl := labels("spacecraft", l.Spacecraft, "antenna", l.AntennaID, "complex", l.Complex, "band", l.Band)
+		rate.samples = append(rate.samples, sample{lbl, l.DataRate})
+		rtlt.samples = append(rtlt.samples, sample{lbl, l.RTLT})
+		elev.samples = append(elev.samples, sample{lbl, l.Elevation})
+		struggle.samples = append(struggle.samples, sample{lbl, l.StruggleIndex})
+	}
+
+	util := gauge{name: "dsn_complex_utilization_ratio", help: "Fraction of a complex's antennas with active links."}
+	active := gauge{name: "dsn_complex_active_links", help: "Active spacecraft links at a complex."}
+	antennas := gauge{name: "dsn_complex_antennas", help: "Antennas reported at a complex."}
+	loads := append([]dsn.ComplexLoad(nil), snap.ComplexLoads...)
+	sort.Slice(loads, func(i, j int) bool { return loads[i].Complex < loads[j].Complex })
+	for _, c := range loads {
+		lbl := labels("complex", string(c.Complex))
+		util.samples = append(util.samples, sample{lbl, c.Utilization})
+		active.samples = append(active.samples, sample{lbl, float64(c.ActiveLinks)})
+		antennas.samples = append(antennas.samples, sample{lbl, float64(c.TotalAntennas)})
+	}
+
+	links := gauge{name: "dsn_links", help: "Active links in the latest snapshot.",
+		samples: []sample{{"", float64(len(snap.Links))}}}
+
+	var feed gauge
+	if !snap.Timestamp.IsZero() {
+		feed = gauge{name: "dsn_feed_timestamp_seconds", help: "Feed timestamp of the latest snapshot (Unix seconds).",
+			samples: []sample{{"", float64(snap.Timestamp.Unix())}}}
+	}
+
+	for _, g := range []gauge{rate, rtlt, elev, struggle, util, active, antennas, links, feed} {
+		if err := g.write(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g gauge) write(w io.Writer) error {
+	if len(g.samples) == 0 {
+		return nil
+	}
+	if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n", g.name, g.help, g.name); err != nil {
+		return err
+	}
+	for _, s := range g.samples {
+		if _, err := fmt.Fprintf(w, "%s%s %s\n", g.name, s.labels, strconv.FormatFloat(s.value, 'g', -1, 64)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// labels renders name/value pairs as a Prometheus label set.
+func labels(kv ...string) string {
+	var b strings.Builder
+	b.WriteByte('{')
+	for i := 0; i+1 < len(kv); i += 2 {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(kv[i])
+		b.WriteString(`="`)
+		b.WriteString(labelEscaper.Replace(kv[i+1]))
+		b.WriteByte('"')
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// Handler serves the latest state from mgr at each scrape. Before the
+// first successful fetch it returns 503 so Prometheus records the target
+// as down rather than as having no links.
+func Handler(mgr *state.Manager) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		snap := mgr.Snapshot()
+		if snap.Data == nil {
+			http.Error(w, "no data yet", http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", ContentType)
+		_ = Write(w, dsn.ExportSnapshot(snap.Data, snap.LastFetch))
+	})
+}
@@ -0,0 +1,94 @@
+package metrics
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+	"github.com/litescript/ls-horizons/internal/state"
+)
+
+func TestWrite(t *testing.T) {
+	snap := &dsn.SnapshotExport{
+		Timestamp: time.Unix(1733360000, 0),
+		Links: []dsn.LinkExport{
+			{Spacecraft: "VGR1", AntennaID: "DSS43", Complex: "cdscc", Band: "X",
+				DataRate: 160, RTLT: 165000.5, Elevation: 32.5, StruggleIndex: 0.75},
+			{Spacecraft: `Odd "name"`, AntennaID: "DSS14", Complex: "gdscc", Band: "S", DataRate: 2e6},
+		},
+		ComplexLoads: []dsn.ComplexLoad{
+			{Complex: dsn.ComplexMadrid, ActiveLinks: 1, TotalAntennas: 4, Utilization: 0.25},
+			{Complex: dsn.ComplexCanberra, ActiveLinks: 2, TotalAntennas: 4, Utilization: 0.5},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, snap); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"# TYPE dsn_link_data_rate_bps gauge\n",
+		`dsn_link_data_rate_bps{spacecraft="VGR1",antenna="DSS43",complex="cdscc",band="X"} 160` + "\n",
+		`dsn_link_rtlt_seconds{spacecraft="VGR1",antenna="DSS43",complex="cdscc",band="X"} 165000.5` + "\n",
+		`dsn_link_elevation_degrees{spacecraft="VGR1",antenna="DSS43",complex="cdscc",band="X"} 32.5` + "\n",
+		`dsn_link_struggle_index{spacecraft="VGR1",antenna="DSS43",complex="cdscc",band="X"} 0.75` + "\n",
+		`dsn_link_data_rate_bps{spacecraft="Odd \"name\"",antenna="DSS14",complex="gdscc",band="S"} 2e+06` + "\n",
+		`dsn_complex_utilization_ratio{complex="cdscc"} 0.5` + "\n",
+		`dsn_complex_active_links{complex="mdscc"} 1` + "\n",
+		"dsn_links 2\n",
+		"dsn_feed_timestamp_seconds 1.73336e+09\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	// Complexes are sorted for stable output
+	if strings.Index(out, `complex="cdscc"} 0.5`) > strings.Index(out, `complex="mdscc"} 0.25`) {
+		t.Errorf("complexes not sorted:\n%s", out)
+	}
+}
+
+func TestWrite_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, &dsn.SnapshotExport{}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if got := buf.String(); got != "# HELP dsn_links Active links in the latest snapshot.\n# TYPE dsn_links gauge\ndsn_links 0\n" {
+		t.Errorf("empty snapshot output = %q", got)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	mgr := state.NewManager(state.DefaultConfig())
+	h := Handler(mgr)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("before first fetch: status = %d, want 503", rec.Code)
+	}
+
+	mgr.Update(&dsn.DSNData{
+		Timestamp: time.Now(),
+		Links:     []dsn.Link{{Spacecraft: "VGR1", AntennaID: "DSS43", Complex: dsn.ComplexCanberra, DataRate: 160}},
+	}, 0, nil)
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != ContentType {
+		t.Errorf("Content-Type = %q, want %q", ct, ContentType)
+	}
+	if !strings.Contains(rec.Body.String(), `spacecraft="VGR1"`) {
+		t.Errorf("body missing VGR1 link:\n%s", rec.Body.String())
+	}
+}
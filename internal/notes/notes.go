@@ -0,0 +1,143 @@
+// Package notes keeps the user's journal of timestamped notes on
+// spacecraft ("caught the safe-mode recovery pass here").
+//
+// Notes are stored locally as JSON Lines, one note per line, appended as
+// they are written so the file can be read or edited by hand.
+package notes
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/sandbox"
+)
+
+// Note is one journal entry on a spacecraft.
+type Note struct {
+	Spacecraft string    `json:"spacecraft"` // DSN code, e.g. "VGR1"
+	Time       time.Time `json:"time"`
+	Text       string    `json:"text"`
+}
+
+// DefaultPath returns $XDG_DATA_HOME/ls-horizons/notes.jsonl, falling
+// back to ~/.local/share.
+func DefaultPath() string {
+	dir := os.Getenv("XDG_DATA_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(dir, "ls-horizons", "notes.jsonl")
+}
+
+// Store is the journal file and the notes read from it.
+type Store struct {
+	path string
+
+	mu    sync.Mutex
+	notes []Note
+}
+
+// Open reads the journal at path. A missing file is an empty journal.
+func Open(path string) (*Store, error) {
+	s := &Store{path: path}
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open notes: %w", err)
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	for lineNo := 1; sc.Scan(); lineNo++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		var n Note
+		if err := json.Unmarshal([]byte(line), &n); err != nil {
+			return nil, fmt.Errorf("%s: line %d: %w", path, lineNo, err)
+		}
+		s.notes = append(s.notes, n)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("read notes: %w", err)
+	}
+	return s, nil
+}
+
+// Add appends a note on spacecraft written at t, creating the journal
+// and its directory if needed.
+func (s *Store) Add(spacecraft, text string, t time.Time) (Note, error) {
+	n := Note{Spacecraft: strings.ToUpper(spacecraft), Time: t.UTC(), Text: strings.TrimSpace(text)}
+	if n.Spacecraft == "" || n.Text == "" {
+		return n, errors.New("empty note")
+	}
+	if err := sandbox.CheckWrite(s.path); err != nil {
+		return n, err
+	}
+	line, err := json.Marshal(n)
+	if err != nil {
+		return n, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return n, fmt.Errorf("create notes directory: %w", err)
+	}
+	f, err := os.OpenFile(s.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return n, fmt.Errorf("open notes: %w", err)
+	}
+	_, err = f.Write(append(line, '\n'))
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return n, fmt.Errorf("write note: %w", err)
+	}
+
+	s.notes = append(s.notes, n)
+	return n, nil
+}
+
+// For returns the notes on spacecraft, in the order they were written.
+func (s *Store) For(spacecraft string) []Note {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var result []Note
+	for _, n := range s.notes {
+		if strings.EqualFold(n.Spacecraft, spacecraft) {
+			result = append(result, n)
+		}
+	}
+	return result
+}
+
+// Write prints notes as dated lines in loc, under a "Notes:" heading.
+func Write(w io.Writer, notes []Note, loc *time.Location) {
+	if len(notes) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "Notes:")
+	for _, n := range notes {
+		fmt.Fprintf(w, "  %s  %s\n", n.Time.In(loc).Format("2006-01-02 15:04"), n.Text)
+	}
+}
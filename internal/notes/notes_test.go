@@ -0,0 +1,76 @@
+package notes
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStore_AddAndReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", "notes.jsonl")
+	s, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open missing file: %v", err)
+	}
+
+	t0 := time.Date(2025, 12, 5, 6, 30, 0, 0, time.UTC)
+	if _, err := s.Add("vgr1", "  caught the safe-mode recovery pass here ", t0); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if _, err := s.Add("JWST", "first light", t0.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Add("VGR1", "back to nominal", t0.Add(2*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	got := reopened.For("Vgr1")
+	if len(got) != 2 {
+		t.Fatalf("For(VGR1) = %+v, want 2 notes", got)
+	}
+	want := Note{Spacecraft: "VGR1", Time: t0, Text: "caught the safe-mode recovery pass here"}
+	if got[0] != want || got[1].Text != "back to nominal" {
+		t.Errorf("notes = %+v, want %+v first", got, want)
+	}
+}
+
+func TestStore_AddEmpty(t *testing.T) {
+	s, _ := Open(filepath.Join(t.TempDir(), "notes.jsonl"))
+	if _, err := s.Add("VGR1", "   ", time.Now()); err == nil {
+		t.Error("blank note should be rejected")
+	}
+	if len(s.For("VGR1")) != 0 {
+		t.Error("rejected note was kept")
+	}
+}
+
+func TestOpen_Malformed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notes.jsonl")
+	content := `{"spacecraft":"VGR1","time":"2025-12-05T06:30:00Z","text":"ok"}` + "\n\n{not json\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(path); err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Errorf("Open err = %v, want line 3", err)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	var buf bytes.Buffer
+	Write(&buf, nil, time.UTC)
+	if buf.Len() != 0 {
+		t.Errorf("no notes should print nothing, got %q", buf.String())
+	}
+
+	Write(&buf, []Note{{Spacecraft: "VGR1", Time: time.Date(2025, 12, 5, 6, 30, 0, 0, time.UTC), Text: "safe mode"}}, time.UTC)
+	if want := "Notes:\n  2025-12-05 06:30  safe mode\n"; buf.String() != want {
+		t.Errorf("Write = %q, want %q", buf.String(), want)
+	}
+}
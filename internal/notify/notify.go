@@ -0,0 +1,366 @@
+// Package notify turns link events into desktop notifications, user hook
+// commands, and webhook posts, so a handoff or a lost signal is noticed
+// without watching the screen.
+//
+// Desktop notifications go through notify-send on Linux and the BSDs and
+// osascript on macOS. A hook command runs with sh -c and gets the event
+// in LSH_* environment variables. A webhook receives the event as JSON,
+// with text and content fields so Slack and Discord incoming webhooks
+// accept it as is.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+	"github.com/litescript/ls-horizons/internal/logging"
+	"github.com/litescript/ls-horizons/internal/state"
+)
+
+// CommandTimeout bounds each desktop notification, hook command, and
+// webhook request.
+const CommandTimeout = 10 * time.Second
+
+// DefaultEvents are the events notified when none are configured.
+var DefaultEvents = []state.EventType{state.EventNewLink, state.EventHandoff, state.EventLinkLost}
+
+// ErrUnsupported is returned for desktop notifications on a platform
+// without a supported notifier.
+var ErrUnsupported = errors.New("desktop notifications are not supported on " + runtime.GOOS)
+
+// Config selects which events notify and how.
+type Config struct {
+	Desktop bool   // Show OS desktop notifications
+	Command string // Run with sh -c for each event
+	Webhook string // URL to POST each event to as JSON
+
+	// Events notify for every spacecraft not listed in Spacecraft. Nil
+	// means DefaultEvents.
+	Events []state.EventType
+
+	// Spacecraft overrides Events by canonical spacecraft code (see
+	// dsn.CanonicalCode). An empty list mutes that spacecraft.
+	Spacecraft map[string][]state.EventType
+}
+
+// Enabled reports whether any notification channel is configured.
+func (c Config) Enabled() bool {
+	return c.Desktop || c.Command != "" || c.Webhook != ""
+}
+
+// Wants reports whether e should be notified.
+func (c Config) Wants(e state.Event) bool {
+	events, ok := c.Spacecraft[dsn.CanonicalCode(e.Spacecraft)]
+	if !ok {
+		events = c.Events
+		if events == nil {
+			events = DefaultEvents
+		}
+	}
+	return slices.Contains(events, e.Type)
+}
+
+// ParseEvents parses a comma-separated list of event types such as
+// "handoff,link_lost". "none" yields an empty, non-nil list.
+func ParseEvents(s string) ([]state.EventType, error) {
+	events := []state.EventType{}
+	if strings.TrimSpace(s) == "none" {
+		return events, nil
+	}
+	for _, name := range strings.Split(s, ",") {
+		t := state.EventType(strings.ToUpper(strings.TrimSpace(name)))
+		if !slices.Contains(state.EventTypes, t) {
+			return nil, fmt.Errorf("unknown event %q", strings.TrimSpace(name))
+		}
+		if !slices.Contains(events, t) {
+			events = append(events, t)
+		}
+	}
+	return events, nil
+}
+
+// runFunc runs a command to completion. env is added to the process
+// environment.
+type runFunc func(ctx context.Context, name string, args, env []string) error
+
+// Notifier delivers wanted events to the configured channels.
+type Notifier struct {
+	cfg    Config
+	logger *logging.Logger
+	goos   string
+	run    runFunc
+	client *http.Client
+}
+
+// New returns a notifier for cfg.
+func New(cfg Config, logger *logging.Logger) *Notifier {
+	return &Notifier{
+		cfg:    cfg,
+		logger: logger,
+		goos:   runtime.GOOS,
+		run:    execRun,
+		client: &http.Client{Timeout: CommandTimeout},
+	}
+}
+
+// Run notifies the events of each update until ctx is cancelled or
+// updates is closed. The first update only sets the baseline: its events
+// are the links that were already up at startup.
+func (n *Notifier) Run(ctx context.Context, updates <-chan state.Update) {
+	primed := false
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case u, ok := <-updates:
+			if !ok {
+				return
+			}
+			if !primed {
+				primed = true
+				continue
+			}
+			for _, e := range u.Events {
+				n.Notify(ctx, e)
+			}
+		}
+	}
+}
+
+// Notify delivers e if the config wants it. Failures are logged, not
+// returned, so one broken channel doesn't silence the others.
+func (n *Notifier) Notify(ctx context.Context, e state.Event) {
+	if !n.cfg.Wants(e) {
+		return
+	}
+	title, message := Title(e), Message(e)
+
+	if n.cfg.Desktop {
+		name, args, err := desktopCommand(n.goos, title, message)
+		if err == nil {
+			err = n.run(ctx, name, args, nil)
+		}
+		if err != nil {
+			n.logger.Warn("notify: desktop: %v", err)
+		}
+	}
+	if n.cfg.Command != "" {
+		if err := n.run(ctx, "sh", []string{"-c", n.cfg.Command}, Env(e)); err != nil {
+			n.logger.Warn("notify: command: %v", err)
+		}
+	}
+	if n.cfg.Webhook != "" {
+		if err := n.post(ctx, e); err != nil {
+			n.logger.Warn("notify: webhook: %v", err)
+		}
+	}
+}
+
+// WebhookPayload is the JSON body posted to a webhook: the event, plus
+// its message in the fields chat services display.
+type WebhookPayload struct {
+	state.Event
+	SpacecraftName string `json:"spacecraft_name"`
+	Text           string `json:"text"`    // Slack
+	Content        string `json:"content"` // Discord
+}
+
+// NewWebhookPayload returns the webhook body for e.
+func NewWebhookPayload(e state.Event) WebhookPayload {
+	text := Title(e) + ": " + Message(e)
+	return WebhookPayload{
+		Event:          e,
+		SpacecraftName: dsn.GetSpacecraftName(e.Spacecraft),
+		Text:           text,
+		Content:        text,
+	}
+}
+
+// post sends e to the webhook, failing on any non-2xx response.
+func (n *Notifier) post(ctx context.Context, e state.Event) error {
+	body, err := json.Marshal(NewWebhookPayload(e))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.cfg.Webhook, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("POST returned %s", resp.Status)
+	}
+	return nil
+}
+
+// Title returns the notification title for e: the spacecraft, or the
+// complex for network-level events.
+func Title(e state.Event) string {
+	if e.Spacecraft == "" {
+		return "DSN " + complexName(e.Complex)
+	}
+	return dsn.GetSpacecraftName(e.Spacecraft)
+}
+
+// Message returns the notification body for e.
+func Message(e state.Event) string {
+	site := complexName(e.Complex)
+	switch e.Type {
+	case state.EventNewLink:
+		return fmt.Sprintf("Acquired on %s at %s", e.AntennaID, site)
+	case state.EventHandoff:
+		return fmt.Sprintf("Handed off from %s to %s (%s)", complexName(e.OldStation), complexName(e.NewStation), e.AntennaID)
+	case state.EventLinkLost:
+		return fmt.Sprintf("Signal lost at %s", site)
+	case state.EventLinkResumed:
+		return fmt.Sprintf("Resumed on %s at %s", e.AntennaID, site)
+	case state.EventUplinkStart:
+		return fmt.Sprintf("Commanding via %s", e.AntennaID)
+	case state.EventUplinkEnd:
+		return fmt.Sprintf("Uplink ended on %s", e.AntennaID)
+	case state.EventRareAcquisition:
+		return fmt.Sprintf("Rare acquisition on %s at %s: %s", e.AntennaID, site, LastSeen(e))
+	case state.EventComplexQuiet:
+		return fmt.Sprintf("Nothing tracked at %s for %s: possible outage or maintenance", site, quietFor(e))
+	case state.EventComplexActive:
+		return fmt.Sprintf("%s is tracking again after %s quiet", site, quietFor(e))
+	case state.EventWindRisk:
+		return windMessage(e)
+	case state.EventPointingDivergence:
+		return fmt.Sprintf("%s at %s points %.1f° from the ephemeris: stale ephemeris or mislabeled feed", e.AntennaID, site, e.Divergence)
+	default:
+		return string(e.Type)
+	}
+}
+
+// LastSeen describes how long a rare acquisition's spacecraft had gone
+// untracked: "last tracked 45 days ago" or "never tracked before".
+func LastSeen(e state.Event) string {
+	if e.LastSeen.IsZero() {
+		return "never tracked before"
+	}
+	days := int(e.Timestamp.Sub(e.LastSeen).Hours() / 24)
+	if days == 1 {
+		return "last tracked 1 day ago"
+	}
+	return fmt.Sprintf("last tracked %d days ago", days)
+}
+
+// windMessage describes a WIND_RISK against the stow limit it was raised
+// under.
+func windMessage(e state.Event) string {
+	if e.Wind >= e.WindStow {
+		return fmt.Sprintf("Wind %.0f km/h at %s (%s), past the %.0f km/h stow limit: the pass may end", e.Wind, e.AntennaID, complexName(e.Complex), e.WindStow)
+	}
+	return fmt.Sprintf("Wind %.0f km/h at %s (%s), approaching the %.0f km/h stow limit", e.Wind, e.AntennaID, complexName(e.Complex), e.WindStow)
+}
+
+// quietFor is how long a COMPLEX_* event's complex had tracked nothing,
+// to the minute.
+func quietFor(e state.Event) string {
+	d := e.Timestamp.Sub(e.LastSeen).Round(time.Minute)
+	if d < time.Hour {
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+	return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+// Env returns the LSH_* variables describing e for a hook command.
+func Env(e state.Event) []string {
+	return []string{
+		"LSH_EVENT=" + string(e.Type),
+		"LSH_SPACECRAFT=" + e.Spacecraft,
+		"LSH_SPACECRAFT_NAME=" + dsn.GetSpacecraftName(e.Spacecraft),
+		"LSH_OLD_STATION=" + e.OldStation,
+		"LSH_NEW_STATION=" + e.NewStation,
+		"LSH_ANTENNA=" + e.AntennaID,
+		"LSH_COMPLEX=" + e.Complex,
+		"LSH_TIME=" + e.Timestamp.UTC().Format(time.RFC3339),
+		"LSH_LAST_SEEN=" + lastSeenEnv(e),
+		"LSH_WIND=" + windEnv(e),
+		"LSH_DIVERGENCE=" + divergenceEnv(e),
+		"LSH_TITLE=" + Title(e),
+		"LSH_MESSAGE=" + Message(e),
+	}
+}
+
+// lastSeenEnv formats LastSeen for LSH_LAST_SEEN, empty when unset.
+func lastSeenEnv(e state.Event) string {
+	if e.LastSeen.IsZero() {
+		return ""
+	}
+	return e.LastSeen.UTC().Format(time.RFC3339)
+}
+
+// windEnv is a WIND_RISK's wind speed in km/h, or empty.
+func windEnv(e state.Event) string {
+	if e.Wind == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(e.Wind, 'f', -1, 64)
+}
+
+// divergenceEnv is a POINTING_DIVERGENCE's angle in degrees, or empty.
+func divergenceEnv(e state.Event) string {
+	if e.Divergence == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(e.Divergence, 'f', 2, 64)
+}
+
+// complexName returns the display name for a complex or station ID.
+func complexName(id string) string {
+	if info, ok := dsn.KnownComplexes[dsn.Complex(id)]; ok {
+		return info.Name
+	}
+	return id
+}
+
+// desktopCommand returns the command showing a notification on goos.
+func desktopCommand(goos, title, message string) (string, []string, error) {
+	switch goos {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", appleScriptString(message), appleScriptString(title))
+		return "osascript", []string{"-e", script}, nil
+	case "linux", "freebsd", "openbsd", "netbsd", "dragonfly":
+		return "notify-send", []string{"--app-name=ls-horizons", title, message}, nil
+	default:
+		return "", nil, ErrUnsupported
+	}
+}
+
+// appleScriptString quotes s as an AppleScript string literal.
+func appleScriptString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// execRun runs name with a CommandTimeout deadline, discarding its output.
+func execRun(ctx context.Context, name string, args, env []string) error {
+	ctx, cancel := context.WithTimeout(ctx, CommandTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Env = append(os.Environ(), env...)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,230 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/logging"
+	"github.com/litescript/ls-horizons/internal/state"
+)
+
+// call is one command run by a fake runner.
+type call struct {
+	name string
+	args []string
+	env  []string
+}
+
+// fakeNotifier returns a notifier recording its commands instead of
+// running them.
+func fakeNotifier(cfg Config, goos string, err error) (*Notifier, *[]call) {
+	var calls []call
+	n := New(cfg, logging.Discard())
+	n.goos = goos
+	n.run = func(_ context.Context, name string, args, env []string) error {
+		calls = append(calls, call{name, args, env})
+		return err
+	}
+	return n, &calls
+}
+
+var handoff = state.Event{
+	Type:       state.EventHandoff,
+	Timestamp:  time.Date(2025, 12, 5, 6, 30, 0, 0, time.UTC),
+	Spacecraft: "VGR1",
+	OldStation: "gdscc",
+	NewStation: "mdscc",
+	AntennaID:  "DSS63",
+	Complex:    "mdscc",
+}
+
+func TestConfigWants(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		e    state.Event
+		want bool
+	}{
+		{"default events", Config{}, handoff, true},
+		{"default skips uplink", Config{}, state.Event{Type: state.EventUplinkStart, Spacecraft: "VGR1"}, false},
+		{"event list", Config{Events: []state.EventType{state.EventLinkLost}}, handoff, false},
+		{"spacecraft override", Config{
+			Events:     []state.EventType{},
+			Spacecraft: map[string][]state.EventType{"VGR1": {state.EventHandoff}},
+		}, handoff, true},
+		{"spacecraft muted", Config{
+			Spacecraft: map[string][]state.EventType{"VGR1": {}},
+		}, handoff, false},
+		{"other spacecraft use events", Config{
+			Spacecraft: map[string][]state.EventType{"JWST": {}},
+		}, handoff, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cfg.Wants(tt.e); got != tt.want {
+				t.Errorf("Wants = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseEvents(t *testing.T) {
+	got, err := ParseEvents("handoff, LINK_LOST,handoff")
+	if err != nil || !slices.Equal(got, []state.EventType{state.EventHandoff, state.EventLinkLost}) {
+		t.Errorf("ParseEvents = %v, %v", got, err)
+	}
+	if got, err := ParseEvents("none"); err != nil || got == nil || len(got) != 0 {
+		t.Errorf("ParseEvents(none) = %#v, %v, want an empty list", got, err)
+	}
+	if _, err := ParseEvents("handoff,landing"); err == nil {
+		t.Error("unknown event should fail")
+	}
+}
+
+func TestNotify_Channels(t *testing.T) {
+	n, calls := fakeNotifier(Config{Desktop: true, Command: "echo hi"}, "linux", nil)
+	n.Notify(context.Background(), handoff)
+
+	if len(*calls) != 2 {
+		t.Fatalf("calls = %d, want desktop and command", len(*calls))
+	}
+	desktop := (*calls)[0]
+	if desktop.name != "notify-send" || !slices.Contains(desktop.args, "Voyager 1") ||
+		!slices.Contains(desktop.args, "Handed off from Goldstone to Madrid (DSS63)") {
+		t.Errorf("desktop call = %+v", desktop)
+	}
+	hook := (*calls)[1]
+	if hook.name != "sh" || !slices.Equal(hook.args, []string{"-c", "echo hi"}) {
+		t.Errorf("hook call = %+v", hook)
+	}
+	for _, want := range []string{"LSH_EVENT=HANDOFF", "LSH_SPACECRAFT=VGR1", "LSH_ANTENNA=DSS63", "LSH_TIME=2025-12-05T06:30:00Z"} {
+		if !slices.Contains(hook.env, want) {
+			t.Errorf("hook env missing %s: %v", want, hook.env)
+		}
+	}
+}
+
+func TestNotify_FailureKeepsOtherChannel(t *testing.T) {
+	n, calls := fakeNotifier(Config{Desktop: true, Command: "true"}, "plan9", errors.New("boom"))
+	n.Notify(context.Background(), handoff)
+
+	// No desktop notifier on plan9, but the command still runs
+	if len(*calls) != 1 || (*calls)[0].name != "sh" {
+		t.Errorf("calls = %+v, want only the hook", *calls)
+	}
+}
+
+func TestNotify_Webhook(t *testing.T) {
+	var got map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("request = %s %s", r.Method, r.Header.Get("Content-Type"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Error(err)
+		}
+	}))
+	defer srv.Close()
+
+	n, _ := fakeNotifier(Config{Webhook: srv.URL}, "linux", nil)
+	n.Notify(context.Background(), handoff)
+
+	want := map[string]any{
+		"type":        "HANDOFF",
+		"spacecraft":  "VGR1",
+		"old_station": "gdscc",
+		"new_station": "mdscc",
+		"timestamp":   "2025-12-05T06:30:00Z",
+		"text":        "Voyager 1: Handed off from Goldstone to Madrid (DSS63)",
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("payload %s = %v, want %v", k, got[k], v)
+		}
+	}
+	if got["content"] != got["text"] {
+		t.Errorf("content = %v, want the text for Discord", got["content"])
+	}
+}
+
+func TestPost_ErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "no", http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	n, _ := fakeNotifier(Config{Webhook: srv.URL}, "linux", nil)
+	if err := n.post(context.Background(), handoff); err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("post err = %v, want the 403 status", err)
+	}
+}
+
+func TestRun_SkipsBaseline(t *testing.T) {
+	n, calls := fakeNotifier(Config{Command: "true"}, "linux", nil)
+	updates := make(chan state.Update, 2)
+	startup := handoff
+	startup.Type = state.EventNewLink
+	updates <- state.Update{Events: []state.Event{startup}}
+	updates <- state.Update{Events: []state.Event{handoff}}
+	close(updates)
+
+	n.Run(context.Background(), updates)
+	if len(*calls) != 1 || !slices.Contains((*calls)[0].env, "LSH_EVENT=HANDOFF") {
+		t.Errorf("calls = %+v, want only the handoff after the baseline", *calls)
+	}
+}
+
+func TestDesktopCommand_Darwin(t *testing.T) {
+	name, args, err := desktopCommand("darwin", `Say "hi"`, `a\b`)
+	if err != nil || name != "osascript" {
+		t.Fatalf("desktopCommand = %s, %v", name, err)
+	}
+	if want := `display notification "a\\b" with title "Say \"hi\""`; args[len(args)-1] != want {
+		t.Errorf("script = %s, want %s", args[len(args)-1], want)
+	}
+	if _, _, err := desktopCommand("windows", "t", "m"); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("windows err = %v, want ErrUnsupported", err)
+	}
+}
+
+func TestMessage(t *testing.T) {
+	lost := state.Event{Type: state.EventLinkLost, Spacecraft: "JWST", OldStation: "cdscc", Complex: "cdscc"}
+	if got := Message(lost); !strings.Contains(got, "Canberra") {
+		t.Errorf("Message = %q, want the complex name", got)
+	}
+
+	at := time.Date(2025, 12, 5, 6, 30, 0, 0, time.UTC)
+	rare := state.Event{Type: state.EventRareAcquisition, Spacecraft: "NHPC", AntennaID: "DSS63", Complex: "mdscc", Timestamp: at, LastSeen: at.Add(-45 * 24 * time.Hour)}
+	if got, want := Message(rare), "Rare acquisition on DSS63 at Madrid: last tracked 45 days ago"; got != want {
+		t.Errorf("Message = %q, want %q", got, want)
+	}
+	rare.LastSeen = time.Time{}
+	if got := Message(rare); !strings.HasSuffix(got, "never tracked before") {
+		t.Errorf("Message = %q, want never tracked", got)
+	}
+
+	quiet := state.Event{Type: state.EventComplexQuiet, Complex: "gdscc", Timestamp: at, LastSeen: at.Add(-95 * time.Minute)}
+	if got, want := Title(quiet), "DSN Goldstone"; got != want {
+		t.Errorf("Title = %q, want %q", got, want)
+	}
+	if got, want := Message(quiet), "Nothing tracked at Goldstone for 1h 35m: possible outage or maintenance"; got != want {
+		t.Errorf("Message = %q, want %q", got, want)
+	}
+
+	wind := state.Event{Type: state.EventWindRisk, Spacecraft: "JNO", AntennaID: "DSS14", Complex: "gdscc", Timestamp: at, Wind: 58, WindStow: 72}
+	if got, want := Message(wind), "Wind 58 km/h at DSS14 (Goldstone), approaching the 72 km/h stow limit"; got != want {
+		t.Errorf("Message = %q, want %q", got, want)
+	}
+	wind.Wind = 80
+	if got := Message(wind); !strings.HasSuffix(got, "past the 72 km/h stow limit: the pass may end") {
+		t.Errorf("Message = %q, want past stow", got)
+	}
+}
@@ -0,0 +1,209 @@
+// Package record persists every fetched DSN snapshot to disk for later
+// trend analysis and replay.
+//
+// Snapshots are stored as gzip-compressed JSON Lines, one file per UTC
+// day (dsn-20251205.jsonl.gz). Each record is its own gzip member, so a
+// crash mid-write loses at most the last record and the files can be read
+// with zcat or dsn.LoadSnapshots. Each line is a dsn.SnapshotExport, the
+// same format --snapshot-path writes.
+package record
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+	"github.com/litescript/ls-horizons/internal/sandbox"
+)
+
+const (
+	filePrefix = "dsn-"
+	fileSuffix = ".jsonl.gz"
+	dayLayout  = "20060102"
+)
+
+// Config controls where snapshots are written and how long they are kept.
+type Config struct {
+	Dir      string
+	MaxAge   time.Duration // Delete days older than this (0 = keep forever)
+	MaxBytes int64         // Delete oldest days beyond this total (0 = unlimited)
+}
+
+// DefaultConfig keeps 30 days of recordings, up to 1 GiB, in DefaultDir.
+func DefaultConfig() Config {
+	return Config{
+		Dir:      DefaultDir(),
+		MaxAge:   30 * 24 * time.Hour,
+		MaxBytes: 1 << 30,
+	}
+}
+
+// DefaultDir returns $XDG_DATA_HOME/ls-horizons/recordings, falling back
+// to ~/.local/share.
+func DefaultDir() string {
+	dir := os.Getenv("XDG_DATA_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(dir, "ls-horizons", "recordings")
+}
+
+// Recorder appends snapshots to daily files and applies the retention
+// policy whenever a new day's file is started.
+type Recorder struct {
+	cfg Config
+
+	mu  sync.Mutex
+	day string // day of the file last written
+}
+
+// New creates the recording directory and prunes old recordings.
+func New(cfg Config) (*Recorder, error) {
+	if cfg.Dir == "" {
+		return nil, errors.New("no recording directory")
+	}
+	if err := sandbox.CheckWrite(cfg.Dir); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(cfg.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create recording directory: %w", err)
+	}
+
+	r := &Recorder{cfg: cfg}
+	if err := r.Prune(time.Now()); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Dir returns the recording directory.
+func (r *Recorder) Dir() string {
+	return r.cfg.Dir
+}
+
+// FileName returns the name of the file holding snapshots fetched at t.
+func FileName(t time.Time) string {
+	return filePrefix + t.UTC().Format(dayLayout) + fileSuffix
+}
+
+// Record appends data, fetched at fetchedAt, to that day's file.
+func (r *Recorder) Record(data *dsn.DSNData, fetchedAt time.Time) error {
+	if data == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	name := FileName(fetchedAt)
+	if name != r.day {
+		r.day = name
+		if err := r.prune(fetchedAt); err != nil {
+			return err
+		}
+	}
+
+	f, err := os.OpenFile(filepath.Join(r.cfg.Dir, name), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("open recording: %w", err)
+	}
+
+	gz := gzip.NewWriter(f)
+	err = json.NewEncoder(gz).Encode(dsn.ExportSnapshot(data, fetchedAt))
+	if cerr := gz.Close(); err == nil {
+		err = cerr
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("write recording: %w", err)
+	}
+	return nil
+}
+
+// Prune deletes recordings older than MaxAge, then the oldest remaining
+// days until the total is within MaxBytes. Today's file is never deleted.
+func (r *Recorder) Prune(now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.prune(now)
+}
+
+func (r *Recorder) prune(now time.Time) error {
+	files, err := List(r.cfg.Dir)
+	if err != nil {
+		return err
+	}
+	var total int64
+	for _, f := range files {
+		total += f.Size
+	}
+
+	today := FileName(now)
+	var errs []error
+	for _, f := range files {
+		if filepath.Base(f.Path) == today {
+			break
+		}
+		expired := r.cfg.MaxAge > 0 && now.Sub(f.Day.Add(24*time.Hour)) > r.cfg.MaxAge
+		oversize := r.cfg.MaxBytes > 0 && total > r.cfg.MaxBytes
+		if !expired && !oversize {
+			break
+		}
+		if err := os.Remove(f.Path); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		total -= f.Size
+	}
+	return errors.Join(errs...)
+}
+
+// File is one day's recording.
+type File struct {
+	Path    string
+	Day     time.Time // UTC day the snapshots were fetched
+	Size    int64
+	ModTime time.Time // when the last snapshot was written
+}
+
+// List returns the recordings in dir, oldest day first. Other files are
+// ignored.
+func List(dir string) ([]File, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("list recordings: %w", err)
+	}
+
+	var files []File
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, filePrefix) || !strings.HasSuffix(name, fileSuffix) {
+			continue
+		}
+		day, err := time.Parse(dayLayout, strings.TrimSuffix(strings.TrimPrefix(name, filePrefix), fileSuffix))
+		if err != nil {
+			continue // not one of ours
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, File{Path: filepath.Join(dir, name), Day: day, Size: info.Size(), ModTime: info.ModTime()})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Day.Before(files[j].Day) })
+	return files, nil
+}
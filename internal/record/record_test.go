@@ -0,0 +1,131 @@
+package record
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+)
+
+func testData(ts time.Time) *dsn.DSNData {
+	return &dsn.DSNData{
+		Timestamp: ts,
+		Links: []dsn.Link{
+			{Complex: dsn.ComplexGoldstone, AntennaID: "DSS14", Spacecraft: "VGR1", SpacecraftID: 31, DataRate: 160},
+		},
+	}
+}
+
+func TestRecorder_RecordAndLoad(t *testing.T) {
+	dir := t.TempDir()
+	r, err := New(Config{Dir: dir})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	t0 := time.Date(2025, 12, 5, 23, 59, 50, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		ts := t0.Add(time.Duration(i) * 5 * time.Second)
+		if err := r.Record(testData(ts), ts); err != nil {
+			t.Fatalf("Record: %v", err)
+		}
+	}
+
+	// Two records before midnight, one after
+	for name, want := range map[string]bool{"dsn-20251205.jsonl.gz": true, "dsn-20251206.jsonl.gz": true} {
+		if _, err := os.Stat(filepath.Join(dir, name)); (err == nil) != want {
+			t.Errorf("%s exists = %v, want %v", name, err == nil, want)
+		}
+	}
+
+	snaps, err := dsn.LoadSnapshots(dir)
+	if err != nil {
+		t.Fatalf("LoadSnapshots: %v", err)
+	}
+	if len(snaps) != 3 {
+		t.Fatalf("got %d snapshots, want 3", len(snaps))
+	}
+	if !snaps[0].Timestamp.Equal(t0) || snaps[0].Links[0].Spacecraft != "VGR1" {
+		t.Errorf("first snapshot = %v %+v", snaps[0].Timestamp, snaps[0].Links)
+	}
+}
+
+func TestRecorder_PruneByAge(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2025, 12, 10, 12, 0, 0, 0, time.UTC)
+	for _, name := range []string{"dsn-20251201.jsonl.gz", "dsn-20251208.jsonl.gz", "dsn-20251210.jsonl.gz", "notes.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("x"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := &Recorder{cfg: Config{Dir: dir, MaxAge: 3 * 24 * time.Hour}}
+	if err := r.Prune(now); err != nil {
+		t.Fatalf("Prune: %v", err)
+	}
+
+	for name, want := range map[string]bool{
+		"dsn-20251201.jsonl.gz": false,
+		"dsn-20251208.jsonl.gz": true,
+		"dsn-20251210.jsonl.gz": true,
+		"notes.txt":             true,
+	} {
+		if _, err := os.Stat(filepath.Join(dir, name)); (err == nil) != want {
+			t.Errorf("%s exists = %v, want %v", name, err == nil, want)
+		}
+	}
+}
+
+func TestRecorder_PruneBySize(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2025, 12, 10, 12, 0, 0, 0, time.UTC)
+	for _, name := range []string{"dsn-20251208.jsonl.gz", "dsn-20251209.jsonl.gz", "dsn-20251210.jsonl.gz"} {
+		if err := os.WriteFile(filepath.Join(dir, name), make([]byte, 100), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 300 bytes on disk with room for 150: the two oldest days go
+	r := &Recorder{cfg: Config{Dir: dir, MaxBytes: 150}}
+	if err := r.Prune(now); err != nil {
+		t.Fatalf("Prune: %v", err)
+	}
+
+	for name, want := range map[string]bool{
+		"dsn-20251208.jsonl.gz": false,
+		"dsn-20251209.jsonl.gz": false,
+		"dsn-20251210.jsonl.gz": true,
+	} {
+		if _, err := os.Stat(filepath.Join(dir, name)); (err == nil) != want {
+			t.Errorf("%s exists = %v, want %v", name, err == nil, want)
+		}
+	}
+}
+
+func TestList(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"dsn-20251210.jsonl.gz", "dsn-20251208.jsonl.gz", "notes.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), make([]byte, 10), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, err := List(dir)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("List = %+v, want the two recordings", files)
+	}
+	if files[0].Day.Day() != 8 || files[1].Day.Day() != 10 || files[0].Size != 10 {
+		t.Errorf("List = %+v, want oldest first with sizes", files)
+	}
+}
+
+func TestNew_NoDir(t *testing.T) {
+	if _, err := New(Config{}); err == nil {
+		t.Error("expected error without a directory")
+	}
+}
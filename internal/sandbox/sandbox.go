@@ -0,0 +1,81 @@
+// Package sandbox implements read-only mode: no disk writes and no outbound
+// network traffic except to the NASA DSN feed and JPL Horizons.
+package sandbox
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+// AllowedHosts are the only hosts reachable in read-only mode.
+var AllowedHosts = []string{
+	"eyes.nasa.gov",    // DSN Now XML feed
+	"ssd.jpl.nasa.gov", // JPL Horizons API
+}
+
+// ErrReadOnly is returned for operations blocked by read-only mode.
+var ErrReadOnly = errors.New("disabled in read-only mode")
+
+var enabled atomic.Bool
+
+// Enable turns on read-only mode for the rest of the process.
+func Enable() {
+	enabled.Store(true)
+}
+
+// Enabled reports whether read-only mode is active.
+func Enabled() bool {
+	return enabled.Load()
+}
+
+// CheckWrite returns an error if writing to path is not allowed. Writing to
+// stdout ("-") is always allowed.
+func CheckWrite(path string) error {
+	if !Enabled() || path == "-" {
+		return nil
+	}
+	return fmt.Errorf("write %s: %w", path, ErrReadOnly)
+}
+
+// Check returns an error naming the blocked feature if read-only mode is
+// active.
+func Check(feature string) error {
+	if !Enabled() {
+		return nil
+	}
+	return fmt.Errorf("%s: %w", feature, ErrReadOnly)
+}
+
+// HostAllowed reports whether host may be contacted in read-only mode.
+func HostAllowed(host string) bool {
+	host = strings.ToLower(host)
+	for _, h := range AllowedHosts {
+		if host == h {
+			return true
+		}
+	}
+	return false
+}
+
+// Transport wraps base so requests to hosts outside AllowedHosts fail while
+// read-only mode is active.
+func Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return roundTripper{base: base}
+}
+
+type roundTripper struct {
+	base http.RoundTripper
+}
+
+func (t roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if Enabled() && !HostAllowed(req.URL.Hostname()) {
+		return nil, fmt.Errorf("request to %s: %w", req.URL.Hostname(), ErrReadOnly)
+	}
+	return t.base.RoundTrip(req)
+}
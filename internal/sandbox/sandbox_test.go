@@ -0,0 +1,56 @@
+package sandbox
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+type okTransport struct{}
+
+func (okTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+}
+
+func TestReadOnly(t *testing.T) {
+	defer enabled.Store(false)
+
+	if err := CheckWrite("out.json"); err != nil {
+		t.Errorf("CheckWrite before Enable = %v, want nil", err)
+	}
+
+	Enable()
+
+	if err := CheckWrite("out.json"); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("CheckWrite = %v, want ErrReadOnly", err)
+	}
+	if err := CheckWrite("-"); err != nil {
+		t.Errorf("CheckWrite(stdout) = %v, want nil", err)
+	}
+	if err := Check("update check"); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Check = %v, want ErrReadOnly", err)
+	}
+}
+
+func TestTransport(t *testing.T) {
+	defer enabled.Store(false)
+	Enable()
+
+	rt := Transport(okTransport{})
+	tests := []struct {
+		url     string
+		wantErr bool
+	}{
+		{"https://eyes.nasa.gov/dsn/data/dsn.xml", false},
+		{"https://ssd.jpl.nasa.gov/api/horizons.api", false},
+		{"https://api.github.com/repos/litescript/ls-horizons/releases/latest", true},
+		{"https://eyes.nasa.gov.evil.example/", true},
+	}
+	for _, tt := range tests {
+		req, _ := http.NewRequest(http.MethodGet, tt.url, nil)
+		_, err := rt.RoundTrip(req)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("RoundTrip(%s) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,253 @@
+// Package serve provides the shared HTTP server plumbing for network
+// endpoints (JSON API, metrics, webhooks): TLS and authentication.
+package serve
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultAddr binds to localhost only.
+const DefaultAddr = "127.0.0.1:8080"
+
+// certReloadInterval is how often certificate files are checked for changes.
+const certReloadInterval = 30 * time.Second
+
+// Config configures a network endpoint.
+type Config struct {
+	Addr string
+
+	// TLS: certificate and key files (PEM). Files are re-read when they
+	// change, so certificates renewed by an external ACME client such as
+	// certbot are picked up without a restart.
+	CertFile string
+	KeyFile  string
+
+	// Authentication: a bearer token and/or HTTP basic credentials.
+	Token     string
+	TokenFile string // read the token from a file (keeps it out of ps output)
+	BasicUser string
+	BasicPass string
+
+	// Insecure allows binding a non-loopback address without TLS and auth.
+	Insecure bool
+}
+
+// DefaultConfig returns a localhost-only configuration.
+func DefaultConfig() Config {
+	return Config{Addr: DefaultAddr}
+}
+
+// RegisterFlags adds the shared server flags to fs.
+func (c *Config) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.Addr, "addr", c.Addr, "Listen address")
+	c.RegisterSecurityFlags(fs)
+}
+
+// RegisterSecurityFlags adds the TLS and authentication flags to fs, for
+// programs whose endpoints each take their own address flag.
+func (c *Config) RegisterSecurityFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.CertFile, "tls-cert", c.CertFile, "TLS certificate file (PEM)")
+	fs.StringVar(&c.KeyFile, "tls-key", c.KeyFile, "TLS private key file (PEM)")
+	fs.StringVar(&c.Token, "auth-token", c.Token, "Require this bearer token")
+	fs.StringVar(&c.TokenFile, "auth-token-file", c.TokenFile, "Read the bearer token from a file")
+	fs.StringVar(&c.BasicUser, "auth-user", c.BasicUser, "Require HTTP basic auth with this user")
+	fs.StringVar(&c.BasicPass, "auth-pass", c.BasicPass, "HTTP basic auth password")
+	fs.BoolVar(&c.Insecure, "insecure", c.Insecure, "Allow non-localhost listening without TLS and auth")
+}
+
+// TLSEnabled reports whether a certificate is configured.
+func (c Config) TLSEnabled() bool {
+	return c.CertFile != "" && c.KeyFile != ""
+}
+
+// AuthEnabled reports whether any authentication is configured.
+func (c Config) AuthEnabled() bool {
+	return c.Token != "" || c.BasicUser != ""
+}
+
+// Load resolves TokenFile into Token and validates the configuration.
+func (c *Config) Load() error {
+	if c.TokenFile != "" {
+		data, err := os.ReadFile(c.TokenFile)
+		if err != nil {
+			return fmt.Errorf("read token file: %w", err)
+		}
+		c.Token = strings.TrimSpace(string(data))
+		if c.Token == "" {
+			return fmt.Errorf("token file %s is empty", c.TokenFile)
+		}
+	}
+	return c.Validate()
+}
+
+// Validate checks that the configuration is consistent and safe. Listening
+// beyond loopback requires both TLS and authentication unless Insecure is
+// set.
+func (c Config) Validate() error {
+	if (c.CertFile == "") != (c.KeyFile == "") {
+		return errors.New("both --tls-cert and --tls-key are required for TLS")
+	}
+	if c.BasicUser != "" && c.BasicPass == "" {
+		return errors.New("--auth-user requires --auth-pass")
+	}
+	if c.Insecure || isLoopback(c.Addr) {
+		return nil
+	}
+	if !c.TLSEnabled() || !c.AuthEnabled() {
+		return fmt.Errorf("listening on %s requires TLS and authentication (or --insecure)", c.Addr)
+	}
+	return nil
+}
+
+// isLoopback reports whether addr binds only to a loopback interface.
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// RequireAuth wraps next so requests must present the configured bearer
+// token or basic credentials. With no auth configured, next is returned
+// unchanged.
+func RequireAuth(cfg Config, next http.Handler) http.Handler {
+	if !cfg.AuthEnabled() {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if cfg.Token != "" {
+			if tok, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok && secureEqual(tok, cfg.Token) {
+				next.ServeHTTP(w, r)
+				return
+			}
+		}
+		if cfg.BasicUser != "" {
+			if user, pass, ok := r.BasicAuth(); ok && secureEqual(user, cfg.BasicUser) && secureEqual(pass, cfg.BasicPass) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			w.Header().Set("WWW-Authenticate", `Basic realm="ls-horizons"`)
+		} else {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="ls-horizons"`)
+		}
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+	})
+}
+
+func secureEqual(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// ListenAndServe serves handler with the configured TLS and authentication
+// until ctx is cancelled.
+func ListenAndServe(ctx context.Context, cfg Config, handler http.Handler) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	srv := &http.Server{
+		Addr:              cfg.Addr,
+		Handler:           RequireAuth(cfg, handler),
+		ReadHeaderTimeout: 10 * time.Second,
+		IdleTimeout:       60 * time.Second,
+	}
+
+	if cfg.TLSEnabled() {
+		reloader, err := newCertReloader(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return err
+		}
+		srv.TLSConfig = &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: reloader.GetCertificate,
+		}
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		var err error
+		if srv.TLSConfig != nil {
+			err = srv.ListenAndServeTLS("", "")
+		} else {
+			err = srv.ListenAndServe()
+		}
+		errCh <- err
+	}()
+
+	select {
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return srv.Shutdown(shutdownCtx)
+	}
+}
+
+// certReloader serves a certificate and reloads it when the files change.
+type certReloader struct {
+	certFile, keyFile string
+
+	mu        sync.Mutex
+	cert      *tls.Certificate
+	modTime   time.Time
+	checkedAt time.Time
+}
+
+func newCertReloader(certFile, keyFile string) (*certReloader, error) {
+	r := &certReloader{certFile: certFile, keyFile: keyFile}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *certReloader) load() error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("load TLS certificate: %w", err)
+	}
+	info, err := os.Stat(r.certFile)
+	if err != nil {
+		return fmt.Errorf("stat TLS certificate: %w", err)
+	}
+	r.cert = &cert
+	r.modTime = info.ModTime()
+	r.checkedAt = time.Now()
+	return nil
+}
+
+// GetCertificate implements tls.Config.GetCertificate. A failed reload
+// keeps serving the previous certificate.
+func (r *certReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if time.Since(r.checkedAt) >= certReloadInterval {
+		r.checkedAt = time.Now()
+		if info, err := os.Stat(r.certFile); err == nil && info.ModTime().After(r.modTime) {
+			_ = r.load()
+		}
+	}
+	return r.cert, nil
+}
@@ -0,0 +1,261 @@
+// Package sightings remembers, across runs, when each spacecraft was
+// tracked by the DSN and when ls-horizons was watching, so a spacecraft
+// that turns up after a long absence can be told apart from one that was
+// only missed while the program wasn't running. It also learns each
+// spacecraft's typical downlink rate per band.
+//
+// The log is a small JSON file rewritten whole on Save.
+package sightings
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/sandbox"
+)
+
+// SessionGap is the longest gap between observations that still counts
+// as one stretch of watching, and between sightings of one acquisition.
+const SessionGap = time.Hour
+
+// maxSpans bounds how many watching spans are kept; the oldest are
+// dropped first.
+const maxSpans = 2000
+
+// RateSampleInterval is the least time between rate samples kept for one
+// spacecraft and band, so a fast refresh doesn't make a single pass look
+// like long history.
+const RateSampleInterval = 10 * time.Minute
+
+// Rate learning limits: a learned rate is used once it has minRateSamples,
+// and each new sample weighs at least 1/rateMemory, so older history
+// fades.
+const (
+	minRateSamples = 6
+	rateMemory     = 200
+)
+
+// Sighting is one spacecraft's tracking record.
+type Sighting struct {
+	First        time.Time           `json:"first"`
+	Last         time.Time           `json:"last"`
+	Acquisitions int                 `json:"acquisitions"`    // tracking sessions seen, SessionGap apart
+	Rates        map[string]RateStat `json:"rates,omitempty"` // by band
+}
+
+// RateStat is a spacecraft's learned downlink rate on one band: a running
+// mean of log10(bps), so a few fast or slow passes don't swamp it.
+type RateStat struct {
+	Log10   float64   `json:"log10_bps"`
+	Samples int       `json:"samples"`
+	Updated time.Time `json:"updated"`
+}
+
+// Span is a stretch of time ls-horizons was watching the feed.
+type Span struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// file is the on-disk form of the log.
+type file struct {
+	Watched    []Span              `json:"watched"`
+	Spacecraft map[string]Sighting `json:"spacecraft"`
+}
+
+// DefaultPath returns $XDG_DATA_HOME/ls-horizons/sightings.json, falling
+// back to ~/.local/share.
+func DefaultPath() string {
+	dir := os.Getenv("XDG_DATA_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(dir, "ls-horizons", "sightings.json")
+}
+
+// Log is the sightings file and the records read from it.
+type Log struct {
+	path string
+
+	mu    sync.Mutex
+	data  file
+	dirty bool
+}
+
+// Open reads the log at path. A missing file is an empty log.
+func Open(path string) (*Log, error) {
+	l := &Log{path: path, data: file{Spacecraft: make(map[string]Sighting)}}
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open sightings: %w", err)
+	}
+	if err := json.Unmarshal(raw, &l.data); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if l.data.Spacecraft == nil {
+		l.data.Spacecraft = make(map[string]Sighting)
+	}
+	return l, nil
+}
+
+// Observe records that the spacecraft were tracked at t, and that the
+// feed was being watched then.
+func (l *Log) Observe(t time.Time, spacecraft []string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if n := len(l.data.Watched); n > 0 && !t.After(l.data.Watched[n-1].End) {
+		// Replayed or out-of-order times add nothing
+		if t.Before(l.data.Watched[n-1].Start) {
+			return
+		}
+	} else if n > 0 && t.Sub(l.data.Watched[n-1].End) <= SessionGap {
+		l.data.Watched[n-1].End = t
+	} else {
+		l.data.Watched = append(l.data.Watched, Span{Start: t, End: t})
+		if len(l.data.Watched) > maxSpans {
+			l.data.Watched = l.data.Watched[len(l.data.Watched)-maxSpans:]
+		}
+	}
+
+	for _, sc := range spacecraft {
+		code := strings.ToUpper(sc)
+		s, ok := l.data.Spacecraft[code]
+		switch {
+		case !ok:
+			s = Sighting{First: t, Last: t, Acquisitions: 1}
+		case t.Sub(s.Last) > SessionGap:
+			s.Acquisitions++
+			s.Last = t
+		case t.After(s.Last):
+			s.Last = t
+		}
+		l.data.Spacecraft[code] = s
+	}
+	l.dirty = true
+}
+
+// Unseen returns how long the log watched the feed without the spacecraft
+// being tracked, up to t, and when it was last tracked (zero if never).
+func (l *Log) Unseen(spacecraft string, t time.Time) (watched time.Duration, last time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	last = l.data.Spacecraft[strings.ToUpper(spacecraft)].Last
+	for _, s := range l.data.Watched {
+		start, end := s.Start, s.End
+		if end.After(t) {
+			end = t
+		}
+		if start.Before(last) {
+			start = last
+		}
+		if end.After(start) {
+			watched += end.Sub(start)
+		}
+	}
+	return watched, last
+}
+
+// ObserveRate records a spacecraft's downlink rate on a band at t, at
+// most once per RateSampleInterval. Zero rates (carrier locks, idle
+// links) and spacecraft never passed to Observe are ignored.
+func (l *Log) ObserveRate(spacecraft, band string, bps float64, t time.Time) {
+	if bps <= 0 || band == "" {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	code := strings.ToUpper(spacecraft)
+	s, ok := l.data.Spacecraft[code]
+	if !ok {
+		return
+	}
+	r := s.Rates[band]
+	if r.Samples > 0 && t.Sub(r.Updated) < RateSampleInterval {
+		return
+	}
+	r.Samples++
+	r.Log10 += (math.Log10(bps) - r.Log10) / float64(min(r.Samples, rateMemory))
+	r.Updated = t
+	if s.Rates == nil {
+		s.Rates = make(map[string]RateStat)
+	}
+	s.Rates[band] = r
+	l.data.Spacecraft[code] = s
+	l.dirty = true
+}
+
+// TypicalRate returns the spacecraft's learned downlink rate on a band in
+// bps, once there are enough samples to trust it.
+func (l *Log) TypicalRate(spacecraft, band string) (float64, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	r, ok := l.data.Spacecraft[strings.ToUpper(spacecraft)].Rates[band]
+	if !ok || r.Samples < minRateSamples {
+		return 0, false
+	}
+	return math.Pow(10, r.Log10), true
+}
+
+// Get returns the spacecraft's record.
+func (l *Log) Get(spacecraft string) (Sighting, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	s, ok := l.data.Spacecraft[strings.ToUpper(spacecraft)]
+	return s, ok
+}
+
+// Save writes the log if it changed since it was read or last saved. The
+// file is replaced whole, so a crash never leaves it half written.
+func (l *Log) Save() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.dirty {
+		return nil
+	}
+	if err := sandbox.CheckWrite(l.path); err != nil {
+		return err
+	}
+	raw, err := json.Marshal(l.data)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o755); err != nil {
+		return fmt.Errorf("create sightings directory: %w", err)
+	}
+	f, err := os.CreateTemp(filepath.Dir(l.path), ".sightings-*")
+	if err != nil {
+		return fmt.Errorf("save sightings: %w", err)
+	}
+	defer os.Remove(f.Name())
+	_, err = f.Write(raw)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), l.path)
+	}
+	if err != nil {
+		return fmt.Errorf("save sightings: %w", err)
+	}
+	l.dirty = false
+	return nil
+}
@@ -0,0 +1,124 @@
+package sightings
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLog_ObserveAndUnseen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", "sightings.json")
+	l, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open missing file: %v", err)
+	}
+	t0 := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	// Two days watched with VGR1 on the first morning only, then a week
+	// closed, then another day watched
+	for m := 0; m <= 48*60; m += 5 {
+		at := t0.Add(time.Duration(m) * time.Minute)
+		var tracked []string
+		if m <= 120 {
+			tracked = []string{"vgr1"}
+		}
+		l.Observe(at, tracked)
+	}
+	reopenAt := t0.Add(9 * 24 * time.Hour)
+	for m := 0; m <= 24*60; m += 5 {
+		l.Observe(reopenAt.Add(time.Duration(m)*time.Minute), nil)
+	}
+	if err := l.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	l, err = Open(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	now := reopenAt.Add(24 * time.Hour)
+	watched, last := l.Unseen("VGR1", now)
+	if want := 70 * time.Hour; watched != want {
+		t.Errorf("watched = %v, want %v (the closed week doesn't count)", watched, want)
+	}
+	if !last.Equal(t0.Add(2 * time.Hour)) {
+		t.Errorf("last = %v", last)
+	}
+	if watched, last := l.Unseen("JWST", now); watched != 72*time.Hour || !last.IsZero() {
+		t.Errorf("never seen: watched = %v, last = %v", watched, last)
+	}
+
+	// A second session more than SessionGap later is another acquisition
+	l.Observe(now, []string{"VGR1"})
+	if s, _ := l.Get("vgr1"); s.Acquisitions != 2 || !s.First.Equal(t0) || !s.Last.Equal(now) {
+		t.Errorf("sighting = %+v", s)
+	}
+}
+
+func TestLog_SaveUnchanged(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sightings.json")
+	l, _ := Open(path)
+	if err := l.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); err == nil {
+		t.Error("an unchanged log shouldn't be written")
+	}
+}
+
+func TestOpen_Malformed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sightings.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(path); err == nil || !strings.Contains(err.Error(), path) {
+		t.Errorf("Open err = %v, want the path", err)
+	}
+}
+
+func TestLog_TypicalRate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sightings.json")
+	l, _ := Open(path)
+	t0 := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	// Rates for spacecraft never observed are dropped
+	l.ObserveRate("JWST", "Ka", 28e6, t0)
+	if _, ok := l.Get("JWST"); ok {
+		t.Error("ObserveRate created a sighting")
+	}
+
+	// 100 bps and 1 kbps alternating, every 5 minutes: only every other
+	// fetch is sampled, so the log mean settles between them
+	l.Observe(t0, []string{"VGR1"})
+	for i := 0; i < 2*minRateSamples; i++ {
+		bps := 100.0
+		if i%4 >= 2 {
+			bps = 1000
+		}
+		at := t0.Add(time.Duration(i) * 5 * time.Minute)
+		if i == 2*minRateSamples-2 {
+			if _, ok := l.TypicalRate("VGR1", "X"); ok {
+				t.Errorf("typical rate from %d samples", minRateSamples-1)
+			}
+		}
+		l.ObserveRate("vgr1", "X", bps, at)
+	}
+	got, ok := l.TypicalRate("VGR1", "X")
+	if !ok || got < 99 || got > 1001 {
+		t.Fatalf("TypicalRate = %v, %v; want between 100 and 1000", got, ok)
+	}
+	if _, ok := l.TypicalRate("VGR1", "S"); ok {
+		t.Error("typical rate on an unseen band")
+	}
+
+	// Learned rates survive a restart
+	if err := l.Save(); err != nil {
+		t.Fatal(err)
+	}
+	l, _ = Open(path)
+	if again, ok := l.TypicalRate("VGR1", "X"); !ok || again != got {
+		t.Errorf("reopened TypicalRate = %v, %v; want %v", again, ok, got)
+	}
+}
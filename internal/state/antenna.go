@@ -0,0 +1,56 @@
+package state
+
+import (
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+)
+
+// AntennaSample is one dish's state in one fetch, for the activity
+// history in the antenna detail view.
+type AntennaSample struct {
+	Timestamp time.Time
+	Elevation float64 // degrees
+	Targets   int     // spacecraft tracked
+	DownRate  float64 // bps, summed over active downlinks
+	UpRate    float64 // bps, summed over active uplinks
+}
+
+// Active reports whether the dish was tracking anything.
+func (s AntennaSample) Active() bool {
+	return s.Targets > 0
+}
+
+// antennaHistory returns each antenna's samples across history, oldest
+// first, keyed by antenna ID. An antenna missing from a fetch has no
+// sample for it.
+func antennaHistory(history []HistoryEntry) map[string][]AntennaSample {
+	out := make(map[string][]AntennaSample)
+	for _, entry := range history {
+		if entry.Data == nil {
+			continue
+		}
+		for _, station := range entry.Data.Stations {
+			for _, ant := range station.Antennas {
+				out[ant.ID] = append(out[ant.ID], sampleAntenna(ant, entry.Timestamp))
+			}
+		}
+	}
+	return out
+}
+
+// sampleAntenna summarizes ant at time t.
+func sampleAntenna(ant dsn.Antenna, t time.Time) AntennaSample {
+	s := AntennaSample{Timestamp: t, Elevation: ant.Elevation, Targets: len(ant.Targets)}
+	for _, sig := range ant.DownSignals {
+		if sig.Active {
+			s.DownRate += sig.DataRate
+		}
+	}
+	for _, sig := range ant.UpSignals {
+		if sig.Active {
+			s.UpRate += sig.DataRate
+		}
+	}
+	return s
+}
@@ -0,0 +1,56 @@
+package state
+
+import (
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+)
+
+// RateBaselineLog learns spacecraft's typical downlink rates per band
+// across runs (see the sightings package). A SightingLog that implements
+// it is used for rate baselines too.
+type RateBaselineLog interface {
+	// ObserveRate records a downlink rate on a band at t.
+	ObserveRate(spacecraft, band string, bps float64, t time.Time)
+	// TypicalRate returns the learned rate, if there is enough history.
+	TypicalRate(spacecraft, band string) (float64, bool)
+}
+
+// updateRateBaselines sets the typical rate of each spacecraft and band
+// in data, learned if the sighting log has enough history and bundled
+// otherwise, then teaches the log the current rates. Baselines are looked
+// up first so a slow pass is judged against history, not itself. Caller
+// must hold the lock.
+func (m *Manager) updateRateBaselines(data *dsn.DSNData, fetchedAt time.Time) {
+	rates, _ := m.sightings.(RateBaselineLog)
+	m.rateBaselines = make(map[dsn.RateBand]float64)
+	for _, link := range data.Links {
+		if !dsn.IsRealSpacecraft(link.Spacecraft) || link.Band == "" {
+			continue
+		}
+		key := dsn.NewRateBand(link.Spacecraft, link.Band)
+		if _, done := m.rateBaselines[key]; done {
+			continue
+		}
+		var bps float64
+		var ok bool
+		if rates != nil {
+			bps, ok = rates.TypicalRate(link.Spacecraft, link.Band)
+		}
+		if !ok {
+			bps, ok = dsn.BundledRateBaseline(link.Spacecraft, link.Band)
+		}
+		if ok {
+			m.rateBaselines[key] = bps
+		}
+	}
+	if rates == nil {
+		return
+	}
+	at := feedTime(data, "", fetchedAt)
+	for _, link := range data.Links {
+		if dsn.IsRealSpacecraft(link.Spacecraft) && !link.CarrierOnly() {
+			rates.ObserveRate(link.Spacecraft, link.Band, link.DataRate, at)
+		}
+	}
+}
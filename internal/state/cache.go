@@ -0,0 +1,137 @@
+package state
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// CacheKind names one of the manager's caches, for CacheStats and
+// ClearCache.
+type CacheKind string
+
+const (
+	// CacheEphemeris holds fetched RA/Dec paths, reused for elevation
+	// traces (see elevgeom.go)
+	CacheEphemeris CacheKind = "ephemeris"
+	// CacheTraces holds computed elevation traces
+	CacheTraces CacheKind = "traces"
+	// CachePassPlans holds 24-hour pass plans
+	CachePassPlans CacheKind = "passplans"
+)
+
+// CacheKinds lists every in-memory cache kind.
+var CacheKinds = []CacheKind{CacheEphemeris, CacheTraces, CachePassPlans}
+
+// ParseCacheKind parses a cache kind name.
+func ParseCacheKind(s string) (CacheKind, error) {
+	for _, k := range CacheKinds {
+		if string(k) == s {
+			return k, nil
+		}
+	}
+	return "", fmt.Errorf("unknown cache %q", s)
+}
+
+// CacheEntry describes one cached item.
+type CacheEntry struct {
+	Kind      CacheKind `json:"kind"`
+	Key       string    `json:"key"`     // spacecraft, and complex/antenna for traces
+	Samples   int       `json:"samples"` // RA/Dec or elevation samples, or passes
+	UpdatedAt time.Time `json:"updated_at"`
+	Status    string    `json:"status,omitempty"` // "loading" or the last error
+}
+
+// CacheStats lists every cached item, by kind and then key.
+func (m *Manager) CacheStats() []CacheEntry {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var entries []CacheEntry
+	for id, g := range m.elevGeometry {
+		entries = append(entries, CacheEntry{
+			Kind:      CacheEphemeris,
+			Key:       m.spacecraftName(id),
+			Samples:   len(g.samples),
+			UpdatedAt: g.fetchedAt,
+		})
+	}
+	for id, c := range m.elevTraceCache {
+		e := CacheEntry{Kind: CacheTraces, Key: m.spacecraftName(id), UpdatedAt: c.UpdatedAt, Status: cacheStatus(c.Loading, c.Error)}
+		if c.Trace != nil {
+			e.Key += " @ " + string(c.Trace.Complex)
+			e.Samples = len(c.Trace.Samples)
+		}
+		entries = append(entries, e)
+	}
+	for key, t := range m.elevTraceByGeom {
+		name := m.spacecraftName(key.SpacecraftID) + " @ " + string(key.Complex)
+		if key.Antenna != "" {
+			name += "/" + key.Antenna
+		}
+		entries = append(entries, CacheEntry{Kind: CacheTraces, Key: name, Samples: len(t.Samples), UpdatedAt: t.GeneratedAt})
+	}
+	for id, c := range m.passPlanCache {
+		e := CacheEntry{Kind: CachePassPlans, Key: m.spacecraftName(id), UpdatedAt: c.UpdatedAt, Status: cacheStatus(c.Loading, c.Error)}
+		if c.Plan != nil {
+			e.Samples = len(c.Plan.Passes)
+		}
+		entries = append(entries, e)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Kind != entries[j].Kind {
+			return entries[i].Kind < entries[j].Kind
+		}
+		return entries[i].Key < entries[j].Key
+	})
+	return entries
+}
+
+// ClearCache empties a cache and returns how many items it held. Cleared
+// items are fetched or computed again the next time they're needed.
+func (m *Manager) ClearCache(kind CacheKind) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var n int
+	switch kind {
+	case CacheEphemeris:
+		n = len(m.elevGeometry)
+		clear(m.elevGeometry)
+	case CacheTraces:
+		n = len(m.elevTraceCache) + len(m.elevTraceByGeom)
+		clear(m.elevTraceCache)
+		clear(m.elevTraceByGeom)
+	case CachePassPlans:
+		n = len(m.passPlanCache)
+		clear(m.passPlanCache)
+	}
+	return n
+}
+
+// spacecraftName returns the name of a spacecraft in the current data,
+// or its DSN ID. Caller must hold the lock.
+func (m *Manager) spacecraftName(id int) string {
+	for _, sc := range m.spacecraft {
+		if sc.ID == id {
+			return sc.Name
+		}
+	}
+	if h, ok := m.spacecraftHistory[id]; ok && h.SpacecraftName != "" {
+		return h.SpacecraftName
+	}
+	return "id " + strconv.Itoa(id)
+}
+
+// cacheStatus describes an entry still loading or whose last fetch failed.
+func cacheStatus(loading bool, err error) string {
+	switch {
+	case loading:
+		return "loading"
+	case err != nil:
+		return err.Error()
+	}
+	return ""
+}
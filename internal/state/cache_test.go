@@ -0,0 +1,63 @@
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+)
+
+func TestManager_CacheStats(t *testing.T) {
+	m := NewManager(DefaultConfig())
+	now := time.Now()
+	m.Update(&dsn.DSNData{
+		Timestamp: now,
+		Links:     []dsn.Link{{Spacecraft: "VGR1", SpacecraftID: 31, AntennaID: "DSS43", Complex: dsn.ComplexCanberra}},
+	}, 0, nil)
+
+	hash := m.StoreElevationGeometry(31, raDecPath(now, 4, 261.0))
+	m.StoreElevationTraceFor(ElevTraceKey{SpacecraftID: 31, Complex: dsn.ComplexCanberra, Antenna: "DSS43", Geometry: hash},
+		&dsn.ElevationTrace{Complex: dsn.ComplexCanberra, GeneratedAt: now, Samples: make([]dsn.ElevationSample, 3)})
+	m.UpdatePassPlan(31, &dsn.PassPlan{Passes: make([]dsn.Pass, 2)}, nil)
+	m.SetPassPlanLoading(99, true)
+
+	entries := m.CacheStats()
+	want := []CacheEntry{
+		{Kind: CacheEphemeris, Key: "VGR1", Samples: 4},
+		{Kind: CachePassPlans, Key: "VGR1", Samples: 2},
+		{Kind: CachePassPlans, Key: "id 99", Status: "loading"},
+		{Kind: CacheTraces, Key: "VGR1 @ cdscc/DSS43", Samples: 3},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("CacheStats = %+v, want %d entries", entries, len(want))
+	}
+	for i, w := range want {
+		e := entries[i]
+		if e.Kind != w.Kind || e.Key != w.Key || e.Samples != w.Samples || e.Status != w.Status {
+			t.Errorf("entry %d = %+v, want %+v", i, e, w)
+		}
+	}
+}
+
+func TestManager_ClearCache(t *testing.T) {
+	m := NewManager(DefaultConfig())
+	now := time.Now()
+	m.StoreElevationGeometry(1, raDecPath(now, 4, 261.0))
+	m.StoreElevationGeometry(2, raDecPath(now, 4, 100.0))
+	m.UpdatePassPlan(1, &dsn.PassPlan{}, nil)
+
+	if n := m.ClearCache(CacheEphemeris); n != 2 {
+		t.Errorf("ClearCache(ephemeris) = %d, want 2", n)
+	}
+	if n := m.ClearCache(CacheTraces); n != 0 {
+		t.Errorf("ClearCache(traces) = %d, want 0", n)
+	}
+	for _, e := range m.CacheStats() {
+		if e.Kind == CacheEphemeris {
+			t.Errorf("ephemeris entry %q survived a clear", e.Key)
+		}
+	}
+	if m.GetCachedPassPlan(1) == nil {
+		t.Error("clearing ephemeris shouldn't touch pass plans")
+	}
+}
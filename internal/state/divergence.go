@@ -0,0 +1,86 @@
+package state
+
+import (
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/astro"
+	"github.com/litescript/ls-horizons/internal/dsn"
+)
+
+// pointingKey identifies a dish tracking a spacecraft.
+type pointingKey struct {
+	spacecraft string
+	antenna    string
+}
+
+// divergenceRun is how many fetches in a row a dish has pointed off its
+// spacecraft's ephemeris, and whether that was reported yet.
+type divergenceRun struct {
+	fetches int
+	alerted bool
+}
+
+// detectDivergence raises POINTING_DIVERGENCE when a dish points more than
+// divergenceDeg from where the cached Horizons RA/Dec puts its spacecraft
+// for divergenceFetches fetches in a row. Only spacecraft with an RA/Dec
+// path at hand (from an elevation trace or pass plan) are checked, and
+// no fetch is made for it. Pointing back on target, or the link ending,
+// re-arms it. Caller must hold the lock.
+func (m *Manager) detectDivergence(data *dsn.DSNData, fetchedAt time.Time) {
+	seen := make(map[pointingKey]bool)
+	for _, link := range data.Links {
+		if !dsn.IsRealSpacecraft(link.Spacecraft) {
+			continue
+		}
+		key := pointingKey{link.Spacecraft, link.AntennaID}
+		seen[key] = true
+
+		samples := m.ephemerisFor(link.SpacecraftID)
+		at := feedTime(data, link.StationID, fetchedAt)
+		deg, ok := dsn.PointingDivergence(link, samples, at)
+		if !ok {
+			continue
+		}
+		run := m.divergence[key]
+		if deg <= m.divergenceDeg {
+			delete(m.divergence, key)
+			continue
+		}
+		if run == nil {
+			run = &divergenceRun{}
+			m.divergence[key] = run
+		}
+		run.fetches++
+		if run.alerted || run.fetches < m.divergenceFetches {
+			continue
+		}
+		run.alerted = true
+		m.addEvent(Event{
+			Type:        EventPointingDivergence,
+			Timestamp:   at,
+			Spacecraft:  link.Spacecraft,
+			NewStation:  link.StationID,
+			AntennaID:   link.AntennaID,
+			Complex:     string(link.Complex),
+			Divergence:  deg,
+			FeedLatency: max(0, fetchedAt.Sub(at)),
+		})
+	}
+	for key := range m.divergence {
+		if !seen[key] {
+			delete(m.divergence, key)
+		}
+	}
+}
+
+// ephemerisFor returns the RA/Dec path cached for a spacecraft, from its
+// elevation trace geometry or else its pass plan, or nil.
+func (m *Manager) ephemerisFor(spacecraftID int) []astro.RADecAtTime {
+	if g, ok := m.elevGeometry[spacecraftID]; ok && len(g.samples) > 0 {
+		return g.samples
+	}
+	if cached, ok := m.passPlanCache[spacecraftID]; ok {
+		return cached.Samples
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package state
+
+import (
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/astro"
+	"github.com/litescript/ls-horizons/internal/dsn"
+)
+
+// ElevationGeometryTTL is how long a fetched RA/Dec path is trusted for
+// recomputing elevation traces locally before Horizons is asked again.
+const ElevationGeometryTTL = 30 * time.Minute
+
+// ElevationGeometrySlack is how far past the trace window RA/Dec paths are
+// fetched, so later refreshes are covered without a new request.
+const ElevationGeometrySlack = time.Hour
+
+// ElevTraceKey identifies an elevation trace by what it was computed from:
+// the spacecraft, the observing complex and antenna, and the geometry hash
+// of the RA/Dec path (dsn.GeometryHash).
+type ElevTraceKey struct {
+	SpacecraftID int
+	Complex      dsn.Complex
+	Antenna      string
+	Geometry     uint64
+}
+
+// cachedGeometry is the last RA/Dec path fetched for a spacecraft. RA/Dec is
+// geocentric, so one path serves traces for every complex.
+type cachedGeometry struct {
+	samples   []astro.RADecAtTime
+	hash      uint64
+	fetchedAt time.Time
+}
+
+// ElevationGeometry returns the cached RA/Dec path for a spacecraft and its
+// geometry hash if the path is fresh and covers [start, end].
+func (m *Manager) ElevationGeometry(spacecraftID int, start, end time.Time) ([]astro.RADecAtTime, uint64, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	g, ok := m.elevGeometry[spacecraftID]
+	if !ok || len(g.samples) == 0 || time.Since(g.fetchedAt) > ElevationGeometryTTL {
+		return nil, 0, false
+	}
+
+	// Allow one sample interval of slop at each end
+	first := g.samples[0].Time
+	last := g.samples[len(g.samples)-1].Time
+	if first.After(start.Add(dsn.ElevationTraceSampleInterval)) || last.Before(end.Add(-dsn.ElevationTraceSampleInterval)) {
+		return nil, 0, false
+	}
+
+	return g.samples, g.hash, true
+}
+
+// StoreElevationGeometry caches a freshly fetched RA/Dec path and returns
+// its geometry hash. Traces computed from a different geometry for this
+// spacecraft are dropped; traces for an unchanged geometry are kept.
+func (m *Manager) StoreElevationGeometry(spacecraftID int, samples []astro.RADecAtTime) uint64 {
+	hash := dsn.GeometryHash(samples)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.elevGeometry[spacecraftID] = &cachedGeometry{
+		samples:   samples,
+		hash:      hash,
+		fetchedAt: time.Now(),
+	}
+	for key := range m.elevTraceByGeom {
+		if key.SpacecraftID == spacecraftID && key.Geometry != hash {
+			delete(m.elevTraceByGeom, key)
+		}
+	}
+
+	return hash
+}
+
+// ElevationTraceFor returns the trace cached under key if it is still
+// aligned with now (generated less than one sample interval ago).
+func (m *Manager) ElevationTraceFor(key ElevTraceKey, now time.Time) *dsn.ElevationTrace {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	trace, ok := m.elevTraceByGeom[key]
+	if !ok {
+		return nil
+	}
+	if age := now.Sub(trace.GeneratedAt); age < 0 || age >= dsn.ElevationTraceSampleInterval {
+		return nil
+	}
+	return trace
+}
+
+// StoreElevationTraceFor caches a computed trace under its geometry key.
+func (m *Manager) StoreElevationTraceFor(key ElevTraceKey, trace *dsn.ElevationTrace) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.elevTraceByGeom[key] = trace
+}
@@ -0,0 +1,101 @@
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/astro"
+	"github.com/litescript/ls-horizons/internal/dsn"
+)
+
+func raDecPath(start time.Time, n int, ra float64) []astro.RADecAtTime {
+	samples := make([]astro.RADecAtTime, n)
+	for i := range samples {
+		samples[i] = astro.RADecAtTime{
+			Time:   start.Add(time.Duration(i) * dsn.ElevationTraceSampleInterval),
+			RAdeg:  ra,
+			DecDeg: -32.9,
+		}
+	}
+	return samples
+}
+
+func TestManager_ElevationGeometryCoverage(t *testing.T) {
+	m := NewManager(DefaultConfig())
+	now := time.Now()
+	start := now.Add(-dsn.ElevationTraceWindow)
+	end := now.Add(dsn.ElevationTraceWindow)
+
+	if _, _, ok := m.ElevationGeometry(1, start, end); ok {
+		t.Fatal("expected miss with empty cache")
+	}
+
+	// 5h of samples from the window start covers ±2h
+	samples := raDecPath(start, 61, 261.0)
+	hash := m.StoreElevationGeometry(1, samples)
+
+	got, gotHash, ok := m.ElevationGeometry(1, start, end)
+	if !ok {
+		t.Fatal("expected cached geometry to cover the window")
+	}
+	if gotHash != hash || len(got) != len(samples) {
+		t.Errorf("ElevationGeometry = (%d samples, %x), want (%d, %x)", len(got), gotHash, len(samples), hash)
+	}
+
+	// A window past the cached path is not covered
+	later := now.Add(3 * time.Hour)
+	if _, _, ok := m.ElevationGeometry(1, later.Add(-dsn.ElevationTraceWindow), later.Add(dsn.ElevationTraceWindow)); ok {
+		t.Error("expected miss for a window beyond the cached path")
+	}
+}
+
+func TestManager_ElevationTraceByGeometry(t *testing.T) {
+	m := NewManager(DefaultConfig())
+	now := time.Now()
+	hash := m.StoreElevationGeometry(1, raDecPath(now, 4, 261.0))
+
+	gds := ElevTraceKey{SpacecraftID: 1, Complex: dsn.ComplexGoldstone, Geometry: hash}
+	mad := ElevTraceKey{SpacecraftID: 1, Complex: dsn.ComplexMadrid, Geometry: hash}
+	trace := &dsn.ElevationTrace{Complex: dsn.ComplexGoldstone, GeneratedAt: now}
+	m.StoreElevationTraceFor(gds, trace)
+
+	if got := m.ElevationTraceFor(gds, now.Add(time.Minute)); got != trace {
+		t.Error("expected cached trace for same key")
+	}
+	if got := m.ElevationTraceFor(mad, now); got != nil {
+		t.Error("expected miss for a different complex")
+	}
+	if got := m.ElevationTraceFor(gds, now.Add(dsn.ElevationTraceSampleInterval)); got != nil {
+		t.Error("expected miss once the trace is a full sample out of date")
+	}
+
+	// Same geometry refetched: trace survives
+	if h := m.StoreElevationGeometry(1, raDecPath(now.Add(time.Hour), 4, 261.0)); h != hash {
+		t.Fatalf("hash changed for unchanged geometry")
+	}
+	if got := m.ElevationTraceFor(gds, now); got != trace {
+		t.Error("trace should survive a refetch with unchanged geometry")
+	}
+
+	// Geometry moved: old traces are dropped
+	m.StoreElevationGeometry(1, raDecPath(now, 4, 262.0))
+	if got := m.ElevationTraceFor(gds, now); got != nil {
+		t.Error("trace should be dropped when the geometry changes")
+	}
+}
+
+func TestManager_NeedsElevationTraceRefresh_AntennaHandoff(t *testing.T) {
+	m := NewManager(DefaultConfig())
+	trace := &dsn.ElevationTrace{Complex: dsn.ComplexGoldstone, Antenna: "DSS14"}
+	m.UpdateElevationTrace(31, trace, dsn.ComplexGoldstone, nil)
+
+	if m.NeedsElevationTraceRefresh(31, dsn.ComplexGoldstone, "DSS14") {
+		t.Error("same antenna should not need refresh")
+	}
+	if !m.NeedsElevationTraceRefresh(31, dsn.ComplexGoldstone, "DSS24") {
+		t.Error("handoff to another antenna should need refresh")
+	}
+	if !m.NeedsElevationTraceRefresh(31, dsn.ComplexGoldstone, "") {
+		t.Error("losing the link should fall back to a complex trace")
+	}
+}
@@ -0,0 +1,321 @@
+package state
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+	"github.com/litescript/ls-horizons/internal/logging"
+)
+
+// HistoryDriver is the database/sql driver HistoryDB opens. No driver is
+// linked by default; building with -tags sqlite links a pure-Go one.
+const HistoryDriver = "sqlite"
+
+// historySchema creates the HistoryDB tables. Times are Unix milliseconds
+// so range queries compare integers.
+const historySchema = `
+CREATE TABLE IF NOT EXISTS links (
+	at         INTEGER NOT NULL,
+	spacecraft TEXT    NOT NULL,
+	antenna    TEXT    NOT NULL,
+	complex    TEXT    NOT NULL,
+	band       TEXT    NOT NULL,
+	down_rate  REAL    NOT NULL,
+	up_rate    REAL    NOT NULL,
+	rtlt       REAL    NOT NULL,
+	el_deg     REAL
+);
+CREATE INDEX IF NOT EXISTS links_sc_at ON links (spacecraft, at);
+CREATE TABLE IF NOT EXISTS events (
+	at          INTEGER NOT NULL,
+	type        TEXT    NOT NULL,
+	spacecraft  TEXT    NOT NULL,
+	old_station TEXT    NOT NULL,
+	new_station TEXT    NOT NULL,
+	antenna     TEXT    NOT NULL,
+	complex     TEXT    NOT NULL
+);
+CREATE INDEX IF NOT EXISTS events_at ON events (at);
+CREATE TABLE IF NOT EXISTS passes (
+	spacecraft   TEXT    NOT NULL,
+	complex      TEXT    NOT NULL,
+	start_at     INTEGER NOT NULL,
+	peak_at      INTEGER NOT NULL,
+	end_at       INTEGER NOT NULL,
+	max_el_deg   REAL    NOT NULL,
+	generated_at INTEGER NOT NULL,
+	PRIMARY KEY (spacecraft, complex, start_at)
+);
+`
+
+// HistoryDB keeps link samples, events, and pass plans in a SQLite
+// database, for analysis reaching past the in-memory ring buffers.
+type HistoryDB struct {
+	db *sql.DB
+}
+
+// LinkSample is one link as stored in a HistoryDB.
+type LinkSample struct {
+	At         time.Time
+	Spacecraft string
+	AntennaID  string
+	Complex    dsn.Complex
+	Band       string
+	DownRate   float64 // bps
+	UpRate     float64 // bps
+	RTLT       float64 // seconds
+	ElDeg      float64 // NaN if the dish reported no pointing
+}
+
+// RateSummary aggregates a spacecraft's downlink rate over a span.
+type RateSummary struct {
+	Samples int
+	Min     float64
+	Mean    float64
+	Max     float64
+}
+
+// OpenHistoryDB opens (creating if needed) the history database at path.
+func OpenHistoryDB(path string) (*HistoryDB, error) {
+	if !slices.Contains(sql.Drivers(), HistoryDriver) {
+		return nil, errors.New("history database: built without SQLite support (rebuild with -tags sqlite)")
+	}
+	db, err := sql.Open(HistoryDriver, path)
+	if err != nil {
+		return nil, fmt.Errorf("open history database: %w", err)
+	}
+	// SQLite allows one writer; serialize rather than fail with SQLITE_BUSY
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec(historySchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("create history schema: %w", err)
+	}
+	return &HistoryDB{db: db}, nil
+}
+
+// Close closes the database.
+func (h *HistoryDB) Close() error {
+	return h.db.Close()
+}
+
+// RecordUpdate stores the links and events in u in one transaction.
+func (h *HistoryDB) RecordUpdate(u Update) error {
+	tx, err := h.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if u.Data != nil {
+		at := feedTime(u.Data, "", u.FetchedAt).UnixMilli()
+		for _, l := range u.Data.Links {
+			var el any
+			if l.Pointing.Valid {
+				el = l.Pointing.ElDeg
+			}
+			if _, err := tx.Exec(`INSERT INTO links VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				at, l.Spacecraft, l.AntennaID, string(l.Complex), l.Band, l.DownRate, l.UpRate, l.RTLT, el); err != nil {
+				return fmt.Errorf("record links: %w", err)
+			}
+		}
+	}
+	for _, e := range u.Events {
+		if _, err := tx.Exec(`INSERT INTO events VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			e.Timestamp.UnixMilli(), string(e.Type), e.Spacecraft, e.OldStation, e.NewStation, e.AntennaID, e.Complex); err != nil {
+			return fmt.Errorf("record events: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// RecordPassPlan stores plan's passes, replacing any earlier prediction
+// of the same pass.
+func (h *HistoryDB) RecordPassPlan(plan *dsn.PassPlan) error {
+	tx, err := h.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, p := range plan.Passes {
+		if _, err := tx.Exec(`INSERT OR REPLACE INTO passes VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			plan.SpacecraftCode, string(p.Complex), p.Start.UnixMilli(), p.Peak.UnixMilli(), p.End.UnixMilli(),
+			p.MaxElDeg, plan.GeneratedAt.UnixMilli()); err != nil {
+			return fmt.Errorf("record pass plan: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// Links returns the stored samples of spacecraft's links from from up to
+// to, oldest first.
+func (h *HistoryDB) Links(spacecraft string, from, to time.Time) ([]LinkSample, error) {
+	rows, err := h.db.Query(`SELECT at, spacecraft, antenna, complex, band, down_rate, up_rate, rtlt, el_deg
+		FROM links WHERE spacecraft = ? AND at >= ? AND at < ? ORDER BY at, antenna`,
+		spacecraft, from.UnixMilli(), to.UnixMilli())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []LinkSample
+	for rows.Next() {
+		var s LinkSample
+		var at int64
+		var complex string
+		var el sql.NullFloat64
+		if err := rows.Scan(&at, &s.Spacecraft, &s.AntennaID, &complex, &s.Band, &s.DownRate, &s.UpRate, &s.RTLT, &el); err != nil {
+			return nil, err
+		}
+		s.At = time.UnixMilli(at).UTC()
+		s.Complex = dsn.Complex(complex)
+		s.ElDeg = nanIfNull(el)
+		out = append(out, s)
+	}
+	return out, rows.Err()
+}
+
+// Events returns the stored events from from up to to, oldest first,
+// limited to types if any are given.
+func (h *HistoryDB) Events(from, to time.Time, types ...EventType) ([]Event, error) {
+	rows, err := h.db.Query(`SELECT at, type, spacecraft, old_station, new_station, antenna, complex
+		FROM events WHERE at >= ? AND at < ? ORDER BY at`, from.UnixMilli(), to.UnixMilli())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []Event
+	for rows.Next() {
+		var e Event
+		var at int64
+		var typ string
+		if err := rows.Scan(&at, &typ, &e.Spacecraft, &e.OldStation, &e.NewStation, &e.AntennaID, &e.Complex); err != nil {
+			return nil, err
+		}
+		e.Timestamp = time.UnixMilli(at).UTC()
+		e.Type = EventType(typ)
+		if len(types) == 0 || slices.Contains(types, e.Type) {
+			out = append(out, e)
+		}
+	}
+	return out, rows.Err()
+}
+
+// Passes returns the latest stored prediction of each of spacecraft's
+// passes starting from from up to to, in start order.
+func (h *HistoryDB) Passes(spacecraft string, from, to time.Time) ([]dsn.Pass, error) {
+	rows, err := h.db.Query(`SELECT complex, start_at, peak_at, end_at, max_el_deg
+		FROM passes WHERE spacecraft = ? AND start_at >= ? AND start_at < ? ORDER BY start_at, complex`,
+		spacecraft, from.UnixMilli(), to.UnixMilli())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []dsn.Pass
+	for rows.Next() {
+		var p dsn.Pass
+		var complex string
+		var start, peak, end int64
+		if err := rows.Scan(&complex, &start, &peak, &end, &p.MaxElDeg); err != nil {
+			return nil, err
+		}
+		p.Complex = dsn.Complex(complex)
+		p.Start = time.UnixMilli(start).UTC()
+		p.Peak = time.UnixMilli(peak).UTC()
+		p.End = time.UnixMilli(end).UTC()
+		out = append(out, p)
+	}
+	return out, rows.Err()
+}
+
+// DownRateSummary aggregates spacecraft's nonzero downlink rates from
+// from up to to. Samples is zero if none were stored.
+func (h *HistoryDB) DownRateSummary(spacecraft string, from, to time.Time) (RateSummary, error) {
+	var s RateSummary
+	var lo, mean, hi sql.NullFloat64
+	err := h.db.QueryRow(`SELECT COUNT(*), MIN(down_rate), AVG(down_rate), MAX(down_rate)
+		FROM links WHERE spacecraft = ? AND at >= ? AND at < ? AND down_rate > 0`,
+		spacecraft, from.UnixMilli(), to.UnixMilli()).Scan(&s.Samples, &lo, &mean, &hi)
+	s.Min, s.Mean, s.Max = lo.Float64, mean.Float64, hi.Float64
+	return s, err
+}
+
+// Prune deletes link samples and events from before t, and passes that
+// ended before it, returning how many rows went.
+func (h *HistoryDB) Prune(t time.Time) (int64, error) {
+	var n int64
+	for _, q := range []string{
+		`DELETE FROM links WHERE at < ?`,
+		`DELETE FROM events WHERE at < ?`,
+		`DELETE FROM passes WHERE end_at < ?`,
+	} {
+		res, err := h.db.Exec(q, t.UnixMilli())
+		if err != nil {
+			return n, err
+		}
+		rows, _ := res.RowsAffected()
+		n += rows
+	}
+	return n, nil
+}
+
+// Run stores each update from m, and each pass plan m computes, until
+// ctx is cancelled. Unlike the event log it keeps the first update: link
+// samples are state, not transitions. Write failures are logged, not
+// returned, so a locked or full database doesn't stop the feed.
+func (h *HistoryDB) Run(ctx context.Context, m *Manager, logger *logging.Logger) {
+	updates, cancel := m.Subscribe()
+	defer cancel()
+
+	stored := make(map[int]time.Time) // spacecraft ID → GeneratedAt of the plan stored last
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case u, ok := <-updates:
+			if !ok {
+				return
+			}
+			if err := h.RecordUpdate(u); err != nil {
+				logger.Warn("History database: %v", err)
+			}
+			for id, plan := range m.newPassPlans(stored) {
+				if err := h.RecordPassPlan(plan); err != nil {
+					logger.Warn("History database: %v", err)
+					continue
+				}
+				stored[id] = plan.GeneratedAt
+			}
+		}
+	}
+}
+
+// newPassPlans returns the cached pass plans generated after the times in
+// seen, by spacecraft ID.
+func (m *Manager) newPassPlans(seen map[int]time.Time) map[int]*dsn.PassPlan {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	plans := make(map[int]*dsn.PassPlan)
+	for id, c := range m.passPlanCache {
+		if c.Plan != nil && c.Plan.GeneratedAt.After(seen[id]) {
+			plans[id] = c.Plan
+		}
+	}
+	return plans
+}
+
+func nanIfNull(v sql.NullFloat64) float64 {
+	if !v.Valid {
+		return math.NaN()
+	}
+	return v.Float64
+}
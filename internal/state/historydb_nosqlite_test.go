@@ -0,0 +1,16 @@
+//go:build !sqlite
+
+package state
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestOpenHistoryDB_NoDriver(t *testing.T) {
+	_, err := OpenHistoryDB(filepath.Join(t.TempDir(), "history.db"))
+	if err == nil || !strings.Contains(err.Error(), "-tags sqlite") {
+		t.Errorf("err = %v, want a hint to rebuild with -tags sqlite", err)
+	}
+}
@@ -0,0 +1,139 @@
+//go:build sqlite
+
+package state
+
+import (
+	"context"
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+	"github.com/litescript/ls-horizons/internal/logging"
+)
+
+func openTestHistoryDB(t *testing.T) *HistoryDB {
+	t.Helper()
+	h, err := OpenHistoryDB(filepath.Join(t.TempDir(), "history.db"))
+	if err != nil {
+		t.Fatalf("OpenHistoryDB: %v", err)
+	}
+	t.Cleanup(func() { h.Close() })
+	return h
+}
+
+func TestHistoryDB_LinksAndEvents(t *testing.T) {
+	h := openTestHistoryDB(t)
+	t0 := time.Date(2025, 12, 5, 6, 30, 0, 0, time.UTC)
+	for i, rate := range []float64{160, 0, 40} {
+		u := Update{
+			FetchedAt: t0.Add(time.Duration(i) * time.Minute),
+			Data: &dsn.DSNData{Links: []dsn.Link{
+				{Spacecraft: "VGR1", AntennaID: "DSS43", Complex: dsn.ComplexCanberra, Band: "X", DownRate: rate, Pointing: dsn.Pointing{ElDeg: 30, Valid: i > 0}},
+				{Spacecraft: "JWST", AntennaID: "DSS14", Complex: dsn.ComplexGoldstone, Band: "Ka", DownRate: 28e6},
+			}},
+		}
+		if i == 2 {
+			u.Events = []Event{{Type: EventHandoff, Timestamp: u.FetchedAt, Spacecraft: "VGR1", OldStation: "cdscc", NewStation: "gdscc"}}
+		}
+		if err := h.RecordUpdate(u); err != nil {
+			t.Fatalf("RecordUpdate: %v", err)
+		}
+	}
+
+	links, err := h.Links("VGR1", t0, t0.Add(2*time.Minute))
+	if err != nil {
+		t.Fatalf("Links: %v", err)
+	}
+	if len(links) != 2 || links[0].DownRate != 160 || !math.IsNaN(links[0].ElDeg) || links[1].ElDeg != 30 {
+		t.Errorf("Links = %+v, want the first two VGR1 samples, elevation only on the second", links)
+	}
+
+	sum, err := h.DownRateSummary("VGR1", t0, t0.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("DownRateSummary: %v", err)
+	}
+	if sum != (RateSummary{Samples: 2, Min: 40, Mean: 100, Max: 160}) {
+		t.Errorf("DownRateSummary = %+v, want the two nonzero rates", sum)
+	}
+
+	events, err := h.Events(t0, t0.Add(time.Hour), EventHandoff)
+	if err != nil {
+		t.Fatalf("Events: %v", err)
+	}
+	if len(events) != 1 || events[0].NewStation != "gdscc" || !events[0].Timestamp.Equal(t0.Add(2*time.Minute)) {
+		t.Errorf("Events = %+v, want the handoff", events)
+	}
+	if events, _ := h.Events(t0, t0.Add(time.Hour), EventLinkLost); len(events) != 0 {
+		t.Errorf("Events(LINK_LOST) = %+v, want none", events)
+	}
+
+	n, err := h.Prune(t0.Add(90 * time.Second))
+	if err != nil || n != 4 {
+		t.Errorf("Prune = %d, %v; want the 4 samples before the cutoff", n, err)
+	}
+}
+
+func TestHistoryDB_PassPlans(t *testing.T) {
+	h := openTestHistoryDB(t)
+	start := time.Date(2025, 12, 5, 8, 0, 0, 0, time.UTC)
+	pass := dsn.Pass{Complex: dsn.ComplexMadrid, Start: start, Peak: start.Add(3 * time.Hour), End: start.Add(6 * time.Hour), MaxElDeg: 41}
+	plan := &dsn.PassPlan{SpacecraftCode: "VGR1", GeneratedAt: start.Add(-time.Hour), Passes: []dsn.Pass{pass}}
+	if err := h.RecordPassPlan(plan); err != nil {
+		t.Fatalf("RecordPassPlan: %v", err)
+	}
+	// A later prediction of the same pass replaces the first
+	pass.MaxElDeg = 42
+	plan = &dsn.PassPlan{SpacecraftCode: "VGR1", GeneratedAt: start, Passes: []dsn.Pass{pass}}
+	if err := h.RecordPassPlan(plan); err != nil {
+		t.Fatal(err)
+	}
+
+	passes, err := h.Passes("VGR1", start.Add(-time.Hour), start.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("Passes: %v", err)
+	}
+	if len(passes) != 1 || passes[0].MaxElDeg != 42 || !passes[0].End.Equal(pass.End) {
+		t.Errorf("Passes = %+v, want the latest prediction", passes)
+	}
+}
+
+func TestHistoryDB_Run(t *testing.T) {
+	h := openTestHistoryDB(t)
+	m := NewManager(DefaultConfig())
+	t0 := time.Date(2025, 12, 5, 6, 30, 0, 0, time.UTC)
+	m.UpdatePassPlan(1, &dsn.PassPlan{SpacecraftCode: "VGR1", GeneratedAt: t0,
+		Passes: []dsn.Pass{{Complex: dsn.ComplexMadrid, Start: t0, End: t0.Add(time.Hour)}}}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		h.Run(ctx, m, logging.Discard())
+		close(done)
+	}()
+	// Let Run subscribe before the update
+	for subscribed := false; !subscribed; time.Sleep(time.Millisecond) {
+		m.mu.RLock()
+		subscribed = len(m.subscribers) > 0
+		m.mu.RUnlock()
+	}
+	m.Update(&dsn.DSNData{Links: []dsn.Link{{Spacecraft: "VGR1", AntennaID: "DSS63", Complex: dsn.ComplexMadrid}}}, 0, nil)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		links, _ := h.Links("VGR1", time.Time{}, time.Now().Add(time.Hour))
+		passes, _ := h.Passes("VGR1", time.Time{}, time.Now().Add(time.Hour))
+		if len(links) == 1 && len(passes) == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("stored %d links and %d passes, want the first update's link and the cached plan", len(links), len(passes))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	<-done
+}
@@ -0,0 +1,49 @@
+package state
+
+import (
+	"github.com/litescript/ls-horizons/internal/dsn"
+)
+
+// identifyInferred completes the links the parser could only infer (see
+// dsn.Link.Inferred) from the previous update: an unnamed link takes the
+// spacecraft its antenna was tracking, and one without a range takes the
+// spacecraft's last RTLT. Unnamed links with no such history can't be
+// identified and are dropped. Caller must hold the lock.
+func (m *Manager) identifyInferred(data *dsn.DSNData) {
+	var prev []dsn.Link
+	if m.current != nil {
+		prev = m.current.Links
+	}
+
+	links := data.Links[:0:0]
+	for _, link := range data.Links {
+		if !link.Inferred {
+			links = append(links, link)
+			continue
+		}
+		if link.Spacecraft == "" {
+			named := false
+			for _, p := range prev {
+				if p.AntennaID == link.AntennaID && p.Spacecraft != "" &&
+					(link.SpacecraftID == 0 || p.SpacecraftID == link.SpacecraftID) {
+					link.Spacecraft, link.SpacecraftID = p.Spacecraft, p.SpacecraftID
+					named = true
+					break
+				}
+			}
+			if !named {
+				continue
+			}
+		}
+		if link.RTLT == 0 {
+			for _, p := range prev {
+				if p.Spacecraft == link.Spacecraft && p.RTLT > 0 {
+					link.RTLT, link.Distance = p.RTLT, p.Distance
+					break
+				}
+			}
+		}
+		links = append(links, link)
+	}
+	data.Links = links
+}
@@ -0,0 +1,63 @@
+package state
+
+import (
+	"sort"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+)
+
+// detectQuiet raises COMPLEX_QUIET when no antenna at a complex has
+// tracked anything for quietAfter, and COMPLEX_ACTIVE when one tracks
+// again. A complex's first appearance starts its clock, so a complex that
+// is idle at startup is only reported after quietAfter. Caller must hold
+// the lock.
+func (m *Manager) detectQuiet(data *dsn.DSNData, fetchedAt time.Time) {
+	active := make(map[dsn.Complex]bool)
+	stations := make(map[dsn.Complex]string)
+	for _, st := range data.Stations {
+		stations[st.Complex] = st.Name
+	}
+	for _, link := range data.Links {
+		active[link.Complex] = true
+		if _, ok := stations[link.Complex]; !ok {
+			stations[link.Complex] = link.StationID
+		}
+	}
+
+	// Sorted, so events come out in the same order on every run
+	complexes := make([]dsn.Complex, 0, len(stations))
+	for c := range stations {
+		if c != "" {
+			complexes = append(complexes, c)
+		}
+	}
+	sort.Slice(complexes, func(i, j int) bool { return complexes[i] < complexes[j] })
+
+	for _, c := range complexes {
+		at := feedTime(data, stations[c], fetchedAt)
+		e := Event{
+			Timestamp:   at,
+			Complex:     string(c),
+			FeedLatency: max(0, fetchedAt.Sub(at)),
+		}
+		last, seen := m.complexActive[c]
+		switch {
+		case active[c]:
+			if since, wasQuiet := m.quiet[c]; wasQuiet {
+				e.Type, e.LastSeen = EventComplexActive, since
+				m.addEvent(e)
+				delete(m.quiet, c)
+			}
+			m.complexActive[c] = at
+		case !seen:
+			m.complexActive[c] = at
+		case at.Sub(last) >= m.quietAfter:
+			if _, already := m.quiet[c]; !already {
+				e.Type, e.LastSeen = EventComplexQuiet, last
+				m.addEvent(e)
+				m.quiet[c] = last
+			}
+		}
+	}
+}
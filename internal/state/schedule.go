@@ -0,0 +1,26 @@
+package state
+
+import "time"
+
+// alignSkip is how close a boundary may be before the next one is used
+// instead, so a fetch isn't scheduled for a boundary that is already due.
+const alignSkip = 100 * time.Millisecond
+
+// NextAlignedRefresh returns the next refresh time aligned to the wall
+// clock: a multiple of interval since the Unix epoch. A 5s interval
+// refreshes at :00, :05, :10, and a 2m interval on every even minute.
+// Alignment is in absolute time, so time zones and DST transitions don't
+// shift it. Intervals under a second are treated as one second.
+func NextAlignedRefresh(now time.Time, interval time.Duration) time.Time {
+	interval = max(interval, time.Second)
+
+	into := time.Duration(now.UnixNano() % int64(interval))
+	if into < 0 {
+		into += interval
+	}
+	next := now.Add(interval - into)
+	if next.Sub(now) < alignSkip {
+		next = next.Add(interval)
+	}
+	return next
+}
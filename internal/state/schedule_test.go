@@ -0,0 +1,95 @@
+package state
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextAlignedRefresh(t *testing.T) {
+	at := func(h, m, s, ms int) time.Time {
+		return time.Date(2025, 12, 5, h, m, s, ms*int(time.Millisecond), time.UTC)
+	}
+	tests := []struct {
+		name     string
+		now      time.Time
+		interval time.Duration
+		want     time.Time
+	}{
+		{"1s", at(12, 0, 7, 400), time.Second, at(12, 0, 8, 0)},
+		{"5s", at(12, 0, 7, 0), 5 * time.Second, at(12, 0, 10, 0)},
+		{"5s rolls over the minute", at(12, 0, 58, 0), 5 * time.Second, at(12, 1, 0, 0)},
+		{"30s", at(12, 0, 31, 0), 30 * time.Second, at(12, 1, 0, 0)},
+		{"60s", at(12, 0, 59, 0), time.Minute, at(12, 1, 0, 0)},
+		{"90s", at(12, 0, 45, 0), 90 * time.Second, at(12, 1, 30, 0)},
+		{"90s across the hour", at(12, 58, 40, 0), 90 * time.Second, at(13, 0, 0, 0)},
+		// Before epoch alignment, these landed on odd minutes and past
+		// the next 5m mark
+		{"2m on even minutes", at(12, 1, 30, 0), 2 * time.Minute, at(12, 2, 0, 0)},
+		{"5m", at(12, 3, 10, 0), 5 * time.Minute, at(12, 5, 0, 0)},
+		{"5m across the hour", at(12, 59, 0, 0), 5 * time.Minute, at(13, 0, 0, 0)},
+		{"near boundary skips ahead", at(12, 0, 4, 950), 5 * time.Second, at(12, 0, 10, 0)},
+		{"on a boundary waits a full interval", at(12, 0, 5, 0), 5 * time.Second, at(12, 0, 10, 0)},
+		{"sub-second interval", at(12, 0, 7, 400), 200 * time.Millisecond, at(12, 0, 8, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NextAlignedRefresh(tt.now, tt.interval); !got.Equal(tt.want) {
+				t.Errorf("NextAlignedRefresh(%s, %v) = %s, want %s",
+					tt.now.Format("15:04:05.000"), tt.interval, got.Format("15:04:05.000"), tt.want.Format("15:04:05.000"))
+			}
+		})
+	}
+}
+
+func TestNextAlignedRefresh_Intervals(t *testing.T) {
+	start := time.Date(2025, 12, 5, 23, 58, 0, 0, time.UTC)
+	for _, interval := range []time.Duration{
+		time.Second, 2 * time.Second, 5 * time.Second, 7 * time.Second, 10 * time.Second,
+		15 * time.Second, 30 * time.Second, time.Minute, 90 * time.Second, 2 * time.Minute, 5 * time.Minute,
+	} {
+		for step := time.Duration(0); step < 11*time.Minute; step += 1337 * time.Millisecond {
+			now := start.Add(step)
+			next := NextAlignedRefresh(now, interval)
+			wait := next.Sub(now)
+			if next.UnixNano()%int64(interval) != 0 || wait < alignSkip || wait > interval+alignSkip {
+				t.Fatalf("NextAlignedRefresh(%s, %v) = %s: not the next boundary", now, interval, next)
+			}
+		}
+	}
+}
+
+func TestNextAlignedRefresh_DST(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("no time zone data: %v", err)
+	}
+	tests := []struct {
+		name     string
+		now      time.Time
+		interval time.Duration
+		want     time.Time // UTC
+	}{
+		// 2:00 EST jumps to 3:00 EDT
+		{"spring forward", time.Date(2025, 3, 9, 1, 58, 30, 0, ny), 5 * time.Minute, time.Date(2025, 3, 9, 7, 0, 0, 0, time.UTC)},
+		{"spring forward 90s", time.Date(2025, 3, 9, 1, 59, 50, 0, ny), 90 * time.Second, time.Date(2025, 3, 9, 7, 0, 0, 0, time.UTC)},
+		// 2:00 EDT falls back to 1:00 EST
+		{"fall back", time.Date(2025, 11, 2, 1, 59, 59, 0, ny), time.Second, time.Date(2025, 11, 2, 6, 0, 0, 0, time.UTC)},
+		{"fall back 2m", time.Date(2025, 11, 2, 1, 59, 0, 0, ny), 2 * time.Minute, time.Date(2025, 11, 2, 6, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NextAlignedRefresh(tt.now, tt.interval)
+			if !got.Equal(tt.want) {
+				t.Errorf("NextAlignedRefresh = %s, want %s", got, tt.want.In(ny))
+			}
+			if got.Location() != ny {
+				t.Errorf("location = %v, want the caller's", got.Location())
+			}
+			if wait := got.Sub(tt.now); wait > tt.interval+alignSkip {
+				t.Errorf("waits %v across the transition, want at most %v", wait, tt.interval)
+			}
+		})
+	}
+}
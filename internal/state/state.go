@@ -2,9 +2,12 @@
 package state
 
 import (
+	"maps"
+	"slices"
 	"sync"
 	"time"
 
+	"github.com/litescript/ls-horizons/internal/astro"
 	"github.com/litescript/ls-horizons/internal/dsn"
 )
 
@@ -16,8 +19,59 @@ const (
 	EventHandoff     EventType = "HANDOFF"
 	EventLinkLost    EventType = "LINK_LOST"
 	EventLinkResumed EventType = "LINK_RESUMED"
+	EventUplinkStart EventType = "UPLINK_START"
+	EventUplinkEnd   EventType = "UPLINK_END"
+
+	// EventRareAcquisition accompanies a NEW_LINK for a spacecraft the
+	// sighting log hasn't seen tracked for Config.RareAfter of watching.
+	EventRareAcquisition EventType = "RARE_ACQUISITION"
+
+	// Network-level events, with no spacecraft: a whole complex has
+	// tracked nothing for Config.QuietAfter (an outage or maintenance),
+	// and later tracks again.
+	EventComplexQuiet  EventType = "COMPLEX_QUIET"
+	EventComplexActive EventType = "COMPLEX_ACTIVE"
+
+	// EventWindRisk is raised when the wind at an antenna carrying a pass
+	// rises to the caution or stow limit (see dsn.WindLimits).
+	EventWindRisk EventType = "WIND_RISK"
+
+	// EventPointingDivergence is raised when a dish points away from where
+	// the ephemeris puts its spacecraft for Config.DivergenceFetches
+	// fetches in a row: a stale ephemeris or mislabeled feed data.
+	EventPointingDivergence EventType = "POINTING_DIVERGENCE"
 )
 
+// EventTypes lists every event type.
+var EventTypes = []EventType{
+	EventNewLink, EventHandoff, EventLinkLost,
+	EventLinkResumed, EventUplinkStart, EventUplinkEnd,
+	EventRareAcquisition, EventComplexQuiet, EventComplexActive,
+	EventWindRisk, EventPointingDivergence,
+}
+
+// DefaultMaxEvents is how many events are kept by default: about a day of
+// a busy network.
+const DefaultMaxEvents = 1000
+
+// DefaultRareAfter is how long a spacecraft must go untracked, while the
+// sighting log was watching, for its next acquisition to be rare.
+const DefaultRareAfter = 30 * 24 * time.Hour
+
+// DefaultQuietAfter is how long a complex must track nothing at all
+// before it is reported quiet.
+const DefaultQuietAfter = time.Hour
+
+// SightingLog remembers which spacecraft were tracked when, across runs
+// (see the sightings package).
+type SightingLog interface {
+	// Observe records the spacecraft tracked at t.
+	Observe(t time.Time, spacecraft []string)
+	// Unseen returns how long the log watched without the spacecraft
+	// being tracked, up to t, and when it last was (zero if never).
+	Unseen(spacecraft string, t time.Time) (watched time.Duration, last time.Time)
+}
+
 // Event represents a state change in the DSN network.
 type Event struct {
 	Type       EventType `json:"type"`
@@ -27,6 +81,24 @@ type Event struct {
 	NewStation string    `json:"new_station,omitempty"`
 	AntennaID  string    `json:"antenna_id,omitempty"`
 	Complex    string    `json:"complex,omitempty"`
+
+	// LastSeen is when a RARE_ACQUISITION's spacecraft was last tracked
+	// (zero if never), or when a COMPLEX_QUIET or COMPLEX_ACTIVE event's
+	// complex last tracked anything before going quiet.
+	LastSeen time.Time `json:"last_seen,omitempty"`
+
+	// Wind is a WIND_RISK's wind speed at the antenna, km/h, and
+	// WindStow the stow limit in force when it was raised.
+	Wind     float64 `json:"wind_kmh,omitempty"`
+	WindStow float64 `json:"wind_stow_kmh,omitempty"`
+
+	// Divergence is a POINTING_DIVERGENCE's angle, in degrees, between the
+	// dish and the ephemeris.
+	Divergence float64 `json:"divergence_deg,omitempty"`
+
+	// FeedLatency is how long after the event's feed time it was fetched.
+	// Timestamp is feed time, so recordings and replays keep their times.
+	FeedLatency time.Duration `json:"feed_latency_ns,omitempty"`
 }
 
 // HistoryEntry represents a single point in the history buffer.
@@ -43,6 +115,54 @@ type SpacecraftHistory struct {
 	RateHistory    []TimeSeries
 }
 
+// SpacecraftHistoryWindow is how far back per-spacecraft history reaches
+// when Config.MaxSpacecraftHist is left to be sized from the refresh
+// interval.
+const SpacecraftHistoryWindow = 2 * time.Hour
+
+// copy returns a deep copy of h.
+func (h *SpacecraftHistory) copy() *SpacecraftHistory {
+	c := &SpacecraftHistory{
+		SpacecraftID:   h.SpacecraftID,
+		SpacecraftName: h.SpacecraftName,
+		RTLTHistory:    make([]TimeSeries, len(h.RTLTHistory)),
+		RateHistory:    make([]TimeSeries, len(h.RateHistory)),
+	}
+	copy(c.RTLTHistory, h.RTLTHistory)
+	copy(c.RateHistory, h.RateHistory)
+	return c
+}
+
+// RateTrendWindow is how much recent rate history RateTrend fits.
+const RateTrendWindow = 30 * time.Minute
+
+// RateTrend returns the least-squares slope of the data rate over the last
+// RateTrendWindow of history, in bps per second, or 0 with fewer than
+// three samples.
+func (h *SpacecraftHistory) RateTrend() float64 {
+	if h == nil || len(h.RateHistory) == 0 {
+		return 0
+	}
+	from := h.RateHistory[len(h.RateHistory)-1].Timestamp.Add(-RateTrendWindow)
+	var n, sumT, sumV, sumTT, sumTV float64
+	for _, s := range h.RateHistory {
+		if s.Timestamp.Before(from) {
+			continue
+		}
+		t := s.Timestamp.Sub(from).Seconds()
+		n++
+		sumT += t
+		sumV += s.Value
+		sumTT += t * t
+		sumTV += t * s.Value
+	}
+	den := n*sumTT - sumT*sumT
+	if n < 3 || den == 0 {
+		return 0
+	}
+	return (n*sumTV - sumT*sumV) / den
+}
+
 // TimeSeries is a single data point with timestamp.
 type TimeSeries struct {
 	Timestamp time.Time
@@ -52,6 +172,7 @@ type TimeSeries struct {
 // CachedPassPlan stores a pass plan with metadata.
 type CachedPassPlan struct {
 	Plan      *dsn.PassPlan
+	Samples   []astro.RADecAtTime // RA/Dec the plan was computed from, reused when the window slides
 	UpdatedAt time.Time
 	Error     error
 	Loading   bool // True if currently being fetched
@@ -96,11 +217,22 @@ type Manager struct {
 	events       []Event
 	maxEvents    int
 	eventWriteAt int
+	newEvents    []Event // Events detected by the update in progress
+
+	// Update subscribers (see subscribe.go)
+	subscribers map[chan Update]struct{}
 
 	// Derived/cached data
 	complexLoads map[dsn.Complex]dsn.ComplexLoad
 	spacecraft   []dsn.Spacecraft
 
+	// Data quality reports, one per successful fetch
+	qualityHistory []dsn.QualityReport
+
+	// Per-complex load, one sample per minute (see timeline.go)
+	timeline       []TimelineSample
+	timelineWindow time.Duration
+
 	// Pass planning state
 	focusedSpacecraftID int // Currently focused spacecraft for pass planning
 
@@ -110,8 +242,39 @@ type Manager struct {
 	// Elevation trace cache - stores traces for ALL spacecraft
 	elevTraceCache map[int]*CachedElevationTrace
 
+	// RA/Dec paths and traces keyed by geometry, so revisiting a
+	// spacecraft/complex pair doesn't refetch Horizons (see elevgeom.go)
+	elevGeometry    map[int]*cachedGeometry
+	elevTraceByGeom map[ElevTraceKey]*dsn.ElevationTrace
+
+	// Sighting log for rare acquisitions (nil = none)
+	sightings SightingLog
+	rareAfter time.Duration
+
+	// When each complex last tracked anything, and the quiet ones (see
+	// quiet.go)
+	complexActive map[dsn.Complex]time.Time
+	quiet         map[dsn.Complex]time.Time
+	quietAfter    time.Duration
+
+	// Each antenna's wind risk at the last update (see wind.go)
+	windRisk map[string]dsn.WindRisk
+
+	// Fetches in a row each link's dish has pointed off its ephemeris (see
+	// divergence.go)
+	divergence        map[pointingKey]*divergenceRun
+	divergenceDeg     float64
+	divergenceFetches int
+
+	// Typical rates of the spacecraft in the last update (see baseline.go)
+	rateBaselines map[dsn.RateBand]float64
+
 	// Configuration
 	refreshInterval time.Duration
+	passWindow      dsn.PassWindow
+	healthModel     dsn.HealthModel
+	windLimits      dsn.WindLimits
+	passElevations  dsn.PassElevations
 }
 
 // Config holds configuration for the state manager.
@@ -120,15 +283,32 @@ type Config struct {
 	MaxSpacecraftHist int
 	MaxEvents         int
 	RefreshInterval   time.Duration
+	TimelineWindow    time.Duration      // utilization timeline span
+	RareAfter         time.Duration      // untracked time before an acquisition is rare
+	QuietAfter        time.Duration      // idle time before a complex is quiet
+	PassWindow        dsn.PassWindow     // pass plan span and sample step
+	DivergenceDeg     float64            // pointing error before a dish is off its ephemeris
+	DivergenceFetches int                // fetches off the ephemeris before POINTING_DIVERGENCE
+	HealthModel       dsn.HealthModel    // scores every update's links
+	WindLimits        dsn.WindLimits     // wind speeds that raise WIND_RISK
+	PassElevations    dsn.PassElevations // minimum elevations of planned passes
 }
 
 // DefaultConfig returns sensible default configuration.
 func DefaultConfig() Config {
 	return Config{
-		MaxHistoryLen:     60,  // Keep ~1 hour at 1 fetch/min
-		MaxSpacecraftHist: 120, // 2 hours of per-spacecraft data
-		MaxEvents:         50,  // Last 50 events
+		MaxHistoryLen:     60, // Keep ~1 hour at 1 fetch/min
+		MaxSpacecraftHist: 0,  // Sized to cover SpacecraftHistoryWindow
+		MaxEvents:         DefaultMaxEvents,
 		RefreshInterval:   5 * time.Second,
+		TimelineWindow:    DefaultTimelineWindow,
+		RareAfter:         DefaultRareAfter,
+		QuietAfter:        DefaultQuietAfter,
+		PassWindow:        dsn.DefaultPassWindow(),
+		DivergenceDeg:     dsn.DefaultDivergenceDeg,
+		DivergenceFetches: dsn.DefaultDivergenceFetches,
+		HealthModel:       dsn.DefaultHealthModel(),
+		WindLimits:        dsn.DefaultWindLimits(),
 	}
 }
 
@@ -136,28 +316,95 @@ func DefaultConfig() Config {
 func NewManager(cfg Config) *Manager {
 	maxEvents := cfg.MaxEvents
 	if maxEvents <= 0 {
-		maxEvents = 50
+		maxEvents = DefaultMaxEvents
+	}
+	timelineWindow := cfg.TimelineWindow
+	if timelineWindow <= 0 {
+		timelineWindow = DefaultTimelineWindow
+	}
+	rareAfter := cfg.RareAfter
+	if rareAfter <= 0 {
+		rareAfter = DefaultRareAfter
+	}
+	quietAfter := cfg.QuietAfter
+	if quietAfter <= 0 {
+		quietAfter = DefaultQuietAfter
+	}
+	passWindow := cfg.PassWindow
+	if passWindow.Validate() != nil {
+		passWindow = dsn.DefaultPassWindow()
+	}
+	healthModel := cfg.HealthModel
+	if healthModel.Validate() != nil {
+		healthModel = dsn.DefaultHealthModel()
+	}
+	windLimits := cfg.WindLimits
+	if windLimits.Validate() != nil {
+		windLimits = dsn.DefaultWindLimits()
+	}
+	passElevations := cfg.PassElevations
+	if passElevations.Validate() != nil {
+		passElevations = dsn.PassElevations{}
+	}
+	divergenceDeg := cfg.DivergenceDeg
+	if divergenceDeg <= 0 {
+		divergenceDeg = dsn.DefaultDivergenceDeg
+	}
+	divergenceFetches := cfg.DivergenceFetches
+	if divergenceFetches <= 0 {
+		divergenceFetches = dsn.DefaultDivergenceFetches
+	}
+	maxSpacecraftHist := cfg.MaxSpacecraftHist
+	if maxSpacecraftHist <= 0 {
+		// One sample per fetch, enough to span the window
+		maxSpacecraftHist = 120
+		if cfg.RefreshInterval > 0 {
+			maxSpacecraftHist = max(maxSpacecraftHist, int(SpacecraftHistoryWindow/cfg.RefreshInterval))
+		}
 	}
 	return &Manager{
 		maxHistoryLen:     cfg.MaxHistoryLen,
-		maxSpacecraftHist: cfg.MaxSpacecraftHist,
+		maxSpacecraftHist: maxSpacecraftHist,
 		maxEvents:         maxEvents,
 		events:            make([]Event, 0, maxEvents),
 		refreshInterval:   cfg.RefreshInterval,
+		timelineWindow:    timelineWindow,
+		rareAfter:         rareAfter,
+		quietAfter:        quietAfter,
+		passWindow:        passWindow,
+		healthModel:       healthModel,
+		windLimits:        windLimits,
+		passElevations:    passElevations,
 		spacecraftHistory: make(map[int]*SpacecraftHistory),
 		complexLoads:      make(map[dsn.Complex]dsn.ComplexLoad),
 		prevLinks:         make(map[linkKey]dsn.Link),
 		passPlanCache:     make(map[int]*CachedPassPlan),
 		elevTraceCache:    make(map[int]*CachedElevationTrace),
+		elevGeometry:      make(map[int]*cachedGeometry),
+		elevTraceByGeom:   make(map[ElevTraceKey]*dsn.ElevationTrace),
+		subscribers:       make(map[chan Update]struct{}),
+		complexActive:     make(map[dsn.Complex]time.Time),
+		quiet:             make(map[dsn.Complex]time.Time),
+		windRisk:          make(map[string]dsn.WindRisk),
+		divergence:        make(map[pointingKey]*divergenceRun),
+		divergenceDeg:     divergenceDeg,
+		divergenceFetches: divergenceFetches,
 	}
 }
 
-// Update atomically updates the state with new DSN data.
+// Update atomically updates the state with new DSN data fetched just now.
 func (m *Manager) Update(data *dsn.DSNData, fetchDuration time.Duration, err error) {
+	m.UpdateAt(data, time.Now(), fetchDuration, err)
+}
+
+// UpdateAt is Update with an explicit fetch time. Everything derived from
+// the update (events, quality, loads) is a function of data and fetchedAt
+// alone, so replaying a recording reproduces what was shown live.
+func (m *Manager) UpdateAt(data *dsn.DSNData, fetchedAt time.Time, fetchDuration time.Duration, err error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	m.lastFetch = time.Now()
+	m.lastFetch = fetchedAt
 	m.lastError = err
 	m.fetchDuration = fetchDuration
 
@@ -165,8 +412,18 @@ func (m *Manager) Update(data *dsn.DSNData, fetchDuration time.Duration, err err
 		return
 	}
 
+	data.HealthModel = m.healthModel
+	data.WindLimits = m.windLimits
+	m.identifyInferred(data)
+
 	// Detect events before updating current state
-	m.detectEvents(data)
+	m.newEvents = nil
+	m.detectEvents(data, m.lastFetch)
+	m.detectQuiet(data, m.lastFetch)
+	m.detectWind(data, m.lastFetch)
+	m.detectDivergence(data, m.lastFetch)
+	m.observeSightings(data, fetchedAt)
+	m.updateRateBaselines(data, fetchedAt)
 
 	m.current = data
 
@@ -185,7 +442,14 @@ func (m *Manager) Update(data *dsn.DSNData, fetchDuration time.Duration, err err
 	m.spacecraft = dsn.AggregateSpacecraft(data)
 
 	// Update per-spacecraft history
-	m.updateSpacecraftHistory(data)
+	m.updateSpacecraftHistory(data, fetchedAt)
+	m.recordTimeline(data, fetchedAt)
+
+	// Record data quality for this fetch
+	m.qualityHistory = append(m.qualityHistory, dsn.AssessQuality(data, m.lastFetch))
+	if len(m.qualityHistory) > m.maxHistoryLen {
+		m.qualityHistory = m.qualityHistory[1:]
+	}
 
 	// Update prevLinks for next comparison
 	m.prevLinks = make(map[linkKey]dsn.Link)
@@ -193,11 +457,19 @@ func (m *Manager) Update(data *dsn.DSNData, fetchDuration time.Duration, err err
 		key := linkKey{spacecraft: link.Spacecraft, stationID: link.StationID}
 		m.prevLinks[key] = link
 	}
+
+	m.notify(Update{Data: data, FetchedAt: fetchedAt, Events: m.newEvents})
 }
 
 // detectEvents compares new data with previous state and generates events.
-func (m *Manager) detectEvents(newData *dsn.DSNData) {
-	now := time.Now()
+// Events are stamped with the feed's time for the station involved, not
+// the wall clock, with the delay until fetchedAt kept as FeedLatency.
+func (m *Manager) detectEvents(newData *dsn.DSNData, fetchedAt time.Time) {
+	stamp := func(e Event, stationID string) Event {
+		e.Timestamp = feedTime(newData, stationID, fetchedAt)
+		e.FeedLatency = max(0, fetchedAt.Sub(e.Timestamp))
+		return e
+	}
 
 	// Build current links map
 	newLinks := make(map[linkKey]dsn.Link)
@@ -220,44 +492,144 @@ func (m *Manager) detectEvents(newData *dsn.DSNData) {
 
 		if !wasPrev {
 			// NEW_LINK: spacecraft wasn't tracked before
-			m.addEvent(Event{
+			m.addEvent(stamp(Event{
 				Type:       EventNewLink,
-				Timestamp:  now,
 				Spacecraft: sc,
 				NewStation: newLink.StationID,
 				AntennaID:  newLink.AntennaID,
 				Complex:    string(newLink.Complex),
-			})
+			}, newLink.StationID))
+			m.detectRare(newLink, stamp)
 		} else if prevLink.StationID != newLink.StationID {
 			// HANDOFF: station changed
-			m.addEvent(Event{
+			m.addEvent(stamp(Event{
 				Type:       EventHandoff,
-				Timestamp:  now,
 				Spacecraft: sc,
 				OldStation: prevLink.StationID,
 				NewStation: newLink.StationID,
 				AntennaID:  newLink.AntennaID,
 				Complex:    string(newLink.Complex),
-			})
+			}, newLink.StationID))
 		}
 	}
 
 	// Check for lost links
 	for sc, prevLink := range prevBySpacecraft {
 		if _, exists := newBySpacecraft[sc]; !exists {
-			m.addEvent(Event{
+			m.addEvent(stamp(Event{
 				Type:       EventLinkLost,
-				Timestamp:  now,
 				Spacecraft: sc,
 				OldStation: prevLink.StationID,
 				Complex:    string(prevLink.Complex),
-			})
+			}, prevLink.StationID))
+		}
+	}
+
+	// Uplink sessions start and end per spacecraft, whichever antenna
+	// carries the commands
+	prevUplinks := uplinkingLinks(m.current)
+	newUplinks := uplinkingLinks(newData)
+	for sc, link := range newUplinks {
+		if _, was := prevUplinks[sc]; !was {
+			m.addEvent(stamp(Event{
+				Type:       EventUplinkStart,
+				Spacecraft: sc,
+				NewStation: link.StationID,
+				AntennaID:  link.AntennaID,
+				Complex:    string(link.Complex),
+			}, link.StationID))
+		}
+	}
+	for sc, link := range prevUplinks {
+		if _, still := newUplinks[sc]; !still {
+			m.addEvent(stamp(Event{
+				Type:       EventUplinkEnd,
+				Spacecraft: sc,
+				OldStation: link.StationID,
+				AntennaID:  link.AntennaID,
+				Complex:    string(link.Complex),
+			}, link.StationID))
+		}
+	}
+}
+
+// detectRare adds a RARE_ACQUISITION for a newly tracked spacecraft the
+// sighting log hasn't seen for rareAfter of watching.
+func (m *Manager) detectRare(link dsn.Link, stamp func(Event, string) Event) {
+	if m.sightings == nil {
+		return
+	}
+	e := stamp(Event{
+		Type:       EventRareAcquisition,
+		Spacecraft: link.Spacecraft,
+		NewStation: link.StationID,
+		AntennaID:  link.AntennaID,
+		Complex:    string(link.Complex),
+	}, link.StationID)
+	watched, last := m.sightings.Unseen(link.Spacecraft, e.Timestamp)
+	if watched < m.rareAfter {
+		return
+	}
+	e.LastSeen = last
+	m.addEvent(e)
+}
+
+// observeSightings records the spacecraft tracked in data in the sighting
+// log, after detectEvents has checked them against it.
+func (m *Manager) observeSightings(data *dsn.DSNData, fetchedAt time.Time) {
+	if m.sightings == nil {
+		return
+	}
+	var codes []string
+	for _, link := range data.Links {
+		if link.Spacecraft != "" && !slices.Contains(codes, link.Spacecraft) {
+			codes = append(codes, link.Spacecraft)
+		}
+	}
+	m.sightings.Observe(feedTime(data, "", fetchedAt), codes)
+}
+
+// SetSightings attaches a sighting log, enabling RARE_ACQUISITION events
+// and, if it is also a RateBaselineLog, learned rate baselines. Replays
+// leave it unset so recordings don't rewrite the log.
+func (m *Manager) SetSightings(log SightingLog) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sightings = log
+}
+
+// uplinkingLinks returns the first link with an active uplink for each
+// spacecraft in data.
+func uplinkingLinks(data *dsn.DSNData) map[string]dsn.Link {
+	links := make(map[string]dsn.Link)
+	if data == nil {
+		return links
+	}
+	for _, link := range data.Links {
+		if _, ok := links[link.Spacecraft]; !ok && link.Uplink {
+			links[link.Spacecraft] = link
+		}
+	}
+	return links
+}
+
+// feedTime returns the feed's time for a station: its own timeUTC, else
+// the feed timestamp, else fallback for feeds with neither.
+func feedTime(data *dsn.DSNData, stationID string, fallback time.Time) time.Time {
+	for _, st := range data.Stations {
+		if st.Name == stationID && !st.TimeUTC.IsZero() {
+			return st.TimeUTC
 		}
 	}
+	if !data.Timestamp.IsZero() {
+		return data.Timestamp
+	}
+	return fallback
 }
 
 // addEvent adds an event to the ring buffer.
 func (m *Manager) addEvent(e Event) {
+	m.newEvents = append(m.newEvents, e)
 	if len(m.events) < m.maxEvents {
 		m.events = append(m.events, e)
 	} else {
@@ -266,7 +638,7 @@ func (m *Manager) addEvent(e Event) {
 	}
 }
 
-func (m *Manager) updateSpacecraftHistory(data *dsn.DSNData) {
+func (m *Manager) updateSpacecraftHistory(data *dsn.DSNData, fetchedAt time.Time) {
 	for _, link := range data.Links {
 		hist, ok := m.spacecraftHistory[link.SpacecraftID]
 		if !ok {
@@ -279,10 +651,15 @@ func (m *Manager) updateSpacecraftHistory(data *dsn.DSNData) {
 			m.spacecraftHistory[link.SpacecraftID] = hist
 		}
 
+		// One point per feed update: further links to the spacecraft in
+		// the same feed, or a refetch of an unchanged feed, add nothing
 		ts := data.Timestamp
+		if ts.IsZero() {
+			ts = fetchedAt
+		}
 
 		// Add RTLT data point
-		if link.RTLT > 0 {
+		if n := len(hist.RTLTHistory); link.RTLT > 0 && (n == 0 || hist.RTLTHistory[n-1].Timestamp.Before(ts)) {
 			hist.RTLTHistory = append(hist.RTLTHistory, TimeSeries{Timestamp: ts, Value: link.RTLT})
 			if len(hist.RTLTHistory) > m.maxSpacecraftHist {
 				hist.RTLTHistory = hist.RTLTHistory[1:]
@@ -290,7 +667,7 @@ func (m *Manager) updateSpacecraftHistory(data *dsn.DSNData) {
 		}
 
 		// Add data rate point
-		if link.DataRate > 0 {
+		if n := len(hist.RateHistory); link.DataRate > 0 && (n == 0 || hist.RateHistory[n-1].Timestamp.Before(ts)) {
 			hist.RateHistory = append(hist.RateHistory, TimeSeries{Timestamp: ts, Value: link.DataRate})
 			if len(hist.RateHistory) > m.maxSpacecraftHist {
 				hist.RateHistory = hist.RateHistory[1:]
@@ -311,13 +688,41 @@ type Snapshot struct {
 	SkyObjects    []dsn.SkyObject
 	Events        []Event
 
-	// Pass planning state for focused spacecraft
+	// Complexes that have tracked nothing for Config.QuietAfter, with
+	// when each last did
+	QuietComplexes map[dsn.Complex]time.Time
+
+	// RateBaselines are the typical downlink rates, in bps, of the
+	// spacecraft and bands in Data, where known.
+	RateBaselines map[dsn.RateBand]float64
+
+	// Data quality for the latest fetch and prior fetches (oldest first)
+	Quality        dsn.QualityReport
+	QualityHistory []dsn.QualityReport
+
+	// Each antenna's state over the history buffer (oldest first), keyed
+	// by antenna ID
+	AntennaHistory map[string][]AntennaSample
+
+	// Per-complex load over the timeline window, one sample per minute
+	// (oldest first)
+	Timeline       []TimelineSample
+	TimelineWindow time.Duration
+
+	// Pass planning state for focused spacecraft, and the minimum pass
+	// elevations plans are computed with
+	PassElevations      dsn.PassElevations
 	PassPlan            *dsn.PassPlan
 	PassPlanUpdatedAt   time.Time
 	PassPlanError       error
 	PassPlanLoading     bool
+	PassSamples         []astro.RADecAtTime // RA/Dec the pass plan was computed from (shared; don't modify)
 	FocusedSpacecraftID int
 
+	// RTLT and data rate history of the focused spacecraft (nil until
+	// it has been tracked)
+	SpacecraftHistory *SpacecraftHistory
+
 	// Elevation trace state for focused spacecraft
 	ElevationTrace          *dsn.ElevationTrace
 	ElevationTraceUpdatedAt time.Time
@@ -326,6 +731,41 @@ type Snapshot struct {
 	ElevationTraceComplex   dsn.Complex
 }
 
+// Follow returns the snapshot narrowed to the spacecraft on w: their
+// links, sky objects, and events. Stations, loads, quality, and
+// network-level events are kept whole. An empty watchlist returns s unchanged.
+func (s Snapshot) Follow(w dsn.Watchlist) Snapshot {
+	if len(w) == 0 {
+		return s
+	}
+	s.Data = w.Filter(s.Data)
+
+	var sc []dsn.Spacecraft
+	for _, c := range s.Spacecraft {
+		if w.Follows(c.Name) {
+			sc = append(sc, c)
+		}
+	}
+	s.Spacecraft = sc
+
+	var objs []dsn.SkyObject
+	for _, o := range s.SkyObjects {
+		if w.Follows(o.Spacecraft) {
+			objs = append(objs, o)
+		}
+	}
+	s.SkyObjects = objs
+
+	var events []Event
+	for _, e := range s.Events {
+		if e.Spacecraft == "" || w.Follows(e.Spacecraft) {
+			events = append(events, e)
+		}
+	}
+	s.Events = events
+	return s
+}
+
 // Snapshot returns a consistent snapshot of current state.
 func (m *Manager) Snapshot() Snapshot {
 	m.mu.RLock()
@@ -350,13 +790,31 @@ func (m *Manager) Snapshot() Snapshot {
 	// Copy events in chronological order
 	events := m.getEventsOrdered()
 
+	// Copy quality history
+	qualityHist := make([]dsn.QualityReport, len(m.qualityHistory))
+	copy(qualityHist, m.qualityHistory)
+	var quality dsn.QualityReport
+	if n := len(qualityHist); n > 0 {
+		quality = qualityHist[n-1]
+	}
+
+	quiet := make(map[dsn.Complex]time.Time, len(m.quiet))
+	for c, since := range m.quiet {
+		quiet[c] = since
+	}
+
+	timeline := make([]TimelineSample, len(m.timeline))
+	copy(timeline, m.timeline)
+
 	// Get pass plan for focused spacecraft from cache
 	var passPlan *dsn.PassPlan
 	var passPlanUpdatedAt time.Time
 	var passPlanError error
 	var passPlanLoading bool
+	var passSamples []astro.RADecAtTime
 	if cached, ok := m.passPlanCache[m.focusedSpacecraftID]; ok {
 		passPlan = cached.Plan
+		passSamples = cached.Samples
 		passPlanUpdatedAt = cached.UpdatedAt
 		passPlanError = cached.Error
 		passPlanLoading = cached.Loading
@@ -376,6 +834,11 @@ func (m *Manager) Snapshot() Snapshot {
 		elevTraceComplex = cached.Complex
 	}
 
+	var scHist *SpacecraftHistory
+	if hist, ok := m.spacecraftHistory[m.focusedSpacecraftID]; ok {
+		scHist = hist.copy()
+	}
+
 	return Snapshot{
 		Data:                    m.current,
 		LastFetch:               m.lastFetch,
@@ -386,11 +849,21 @@ func (m *Manager) Snapshot() Snapshot {
 		Spacecraft:              sc,
 		SkyObjects:              skyObjs,
 		Events:                  events,
+		QuietComplexes:          quiet,
+		RateBaselines:           maps.Clone(m.rateBaselines),
+		Quality:                 quality,
+		QualityHistory:          qualityHist,
+		AntennaHistory:          antennaHistory(m.history),
+		Timeline:                timeline,
+		TimelineWindow:          m.timelineWindow,
+		PassElevations:          m.passElevations,
 		PassPlan:                passPlan,
 		PassPlanUpdatedAt:       passPlanUpdatedAt,
 		PassPlanError:           passPlanError,
 		PassPlanLoading:         passPlanLoading,
+		PassSamples:             passSamples,
 		FocusedSpacecraftID:     m.focusedSpacecraftID,
+		SpacecraftHistory:       scHist,
 		ElevationTrace:          elevTrace,
 		ElevationTraceUpdatedAt: elevTraceUpdatedAt,
 		ElevationTraceError:     elevTraceError,
@@ -450,17 +923,7 @@ func (m *Manager) GetSpacecraftHistory(spacecraftID int) *SpacecraftHistory {
 		return nil
 	}
 
-	// Return a copy
-	copyHist := &SpacecraftHistory{
-		SpacecraftID:   hist.SpacecraftID,
-		SpacecraftName: hist.SpacecraftName,
-		RTLTHistory:    make([]TimeSeries, len(hist.RTLTHistory)),
-		RateHistory:    make([]TimeSeries, len(hist.RateHistory)),
-	}
-	copy(copyHist.RTLTHistory, hist.RTLTHistory)
-	copy(copyHist.RateHistory, hist.RateHistory)
-
-	return copyHist
+	return hist.copy()
 }
 
 // EstimateVelocity calculates velocity estimate for a spacecraft from history.
@@ -507,6 +970,17 @@ func (m *Manager) HasData() bool {
 	return m.current != nil
 }
 
+// PassWindow returns the span and sample step of pass plans.
+func (m *Manager) PassWindow() dsn.PassWindow {
+	return m.passWindow
+}
+
+// PassElevations returns the minimum elevations pass plans are computed
+// with.
+func (m *Manager) PassElevations() dsn.PassElevations {
+	return m.passElevations
+}
+
 // PassPlanTTL is how long a computed pass plan remains valid.
 const PassPlanTTL = 5 * time.Minute
 
@@ -542,11 +1016,18 @@ func (m *Manager) SetPassPlanLoading(spacecraftID int, loading bool) {
 
 // UpdatePassPlan sets the cached pass plan for a spacecraft.
 func (m *Manager) UpdatePassPlan(spacecraftID int, plan *dsn.PassPlan, err error) {
+	m.UpdatePassPlanSamples(spacecraftID, plan, nil, err)
+}
+
+// UpdatePassPlanSamples sets the cached pass plan for a spacecraft along
+// with the RA/Dec samples it was computed from.
+func (m *Manager) UpdatePassPlanSamples(spacecraftID int, plan *dsn.PassPlan, samples []astro.RADecAtTime, err error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	m.passPlanCache[spacecraftID] = &CachedPassPlan{
 		Plan:      plan,
+		Samples:   samples,
 		UpdatedAt: time.Now(),
 		Error:     err,
 		Loading:   false,
@@ -562,6 +1043,7 @@ func (m *Manager) GetCachedPassPlan(spacecraftID int) *CachedPassPlan {
 		// Return a copy
 		return &CachedPassPlan{
 			Plan:      cached.Plan,
+			Samples:   cached.Samples,
 			UpdatedAt: cached.UpdatedAt,
 			Error:     cached.Error,
 			Loading:   cached.Loading,
@@ -657,8 +1139,8 @@ func (m *Manager) GetCachedElevationTrace(spacecraftID int) *CachedElevationTrac
 }
 
 // NeedsElevationTraceRefresh returns true if a spacecraft's elevation trace should be recomputed.
-// It also checks if the target complex has changed.
-func (m *Manager) NeedsElevationTraceRefresh(spacecraftID int, targetComplex dsn.Complex) bool {
+// It also checks if the target complex or antenna has changed.
+func (m *Manager) NeedsElevationTraceRefresh(spacecraftID int, targetComplex dsn.Complex, targetAntenna string) bool {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
@@ -684,6 +1166,11 @@ func (m *Manager) NeedsElevationTraceRefresh(spacecraftID int, targetComplex dsn
 		return true
 	}
 
+	// Likewise after a handoff between antennas of one complex
+	if cached.Trace != nil && cached.Trace.Antenna != targetAntenna {
+		return true
+	}
+
 	if time.Since(cached.UpdatedAt) > ElevationTraceTTL {
 		return true // TTL expired
 	}
@@ -1,10 +1,13 @@
 package state
 
 import (
+	"math"
+	"reflect"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/litescript/ls-horizons/internal/astro"
 	"github.com/litescript/ls-horizons/internal/dsn"
 )
 
@@ -104,6 +107,24 @@ func TestManager_HistoryBuffer(t *testing.T) {
 	}
 }
 
+func TestManager_HealthModel(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.HealthModel, _ = dsn.LookupHealthModel(dsn.HealthModelElevation)
+	m := NewManager(cfg)
+	m.Update(&dsn.DSNData{Timestamp: time.Now()}, 0, nil)
+	if got := m.Snapshot().Data.ActiveHealthModel().Name; got != dsn.HealthModelElevation {
+		t.Errorf("health model = %q, want %q", got, dsn.HealthModelElevation)
+	}
+
+	// An invalid model falls back to the default
+	cfg.HealthModel = dsn.HealthModel{}
+	m = NewManager(cfg)
+	m.Update(&dsn.DSNData{Timestamp: time.Now()}, 0, nil)
+	if got := m.Snapshot().Data.ActiveHealthModel().Name; got != dsn.HealthModelDefault {
+		t.Errorf("health model = %q, want %q", got, dsn.HealthModelDefault)
+	}
+}
+
 func TestManager_SpacecraftHistory(t *testing.T) {
 	cfg := DefaultConfig()
 	cfg.MaxSpacecraftHist = 5
@@ -140,6 +161,44 @@ func TestManager_SpacecraftHistory(t *testing.T) {
 	}
 }
 
+func TestManager_SpacecraftHistorySnapshot(t *testing.T) {
+	m := NewManager(DefaultConfig())
+	if want := int(SpacecraftHistoryWindow / DefaultConfig().RefreshInterval); m.maxSpacecraftHist != want {
+		t.Errorf("maxSpacecraftHist = %d, want %d to span the window", m.maxSpacecraftHist, want)
+	}
+
+	feed := time.Date(2025, 12, 5, 12, 0, 0, 0, time.UTC)
+	for i := range 3 {
+		data := &dsn.DSNData{
+			Timestamp: feed.Add(time.Duration(i) * time.Minute),
+			Links: []dsn.Link{
+				{SpacecraftID: 42, Spacecraft: "TestCraft", RTLT: float64(100 + i), DataRate: 1000},
+				{SpacecraftID: 42, Spacecraft: "TestCraft", RTLT: 999, DataRate: 5}, // arrayed: same feed
+			},
+		}
+		m.Update(data, 0, nil)
+		m.Update(data, 0, nil) // unchanged feed refetched
+	}
+
+	if snap := m.Snapshot(); snap.SpacecraftHistory != nil {
+		t.Errorf("history before focus = %+v, want nil", snap.SpacecraftHistory)
+	}
+	m.SetFocusedSpacecraft(42)
+	hist := m.Snapshot().SpacecraftHistory
+	if hist == nil || len(hist.RTLTHistory) != 3 || len(hist.RateHistory) != 3 {
+		t.Fatalf("history = %+v, want one point per feed update", hist)
+	}
+	if hist.RTLTHistory[2].Value != 102 || hist.RateHistory[2].Value != 1000 {
+		t.Errorf("latest = %v / %v, want the first link's values", hist.RTLTHistory[2], hist.RateHistory[2])
+	}
+
+	// The snapshot holds a copy
+	hist.RTLTHistory[0].Value = -1
+	if m.Snapshot().SpacecraftHistory.RTLTHistory[0].Value != 100 {
+		t.Error("snapshot history aliases the manager's")
+	}
+}
+
 func TestManager_EstimateVelocity(t *testing.T) {
 	m := NewManager(DefaultConfig())
 
@@ -314,6 +373,70 @@ func TestManager_EventDetection_NewLink(t *testing.T) {
 	}
 }
 
+func TestManager_IdentifyInferred(t *testing.T) {
+	m := NewManager(DefaultConfig())
+	m.Update(&dsn.DSNData{Links: []dsn.Link{
+		{SpacecraftID: 74, Spacecraft: "MRO", AntennaID: "DSS14", RTLT: 1000, Distance: 1.5e8},
+		{SpacecraftID: 31, Spacecraft: "VGR1", AntennaID: "DSS43", RTLT: 160200, Distance: 2.4e10},
+	}}, 0, nil)
+
+	m.Update(&dsn.DSNData{Links: []dsn.Link{
+		// Signal named by NAIF ID, no target: range from the last update
+		{SpacecraftID: 74, Spacecraft: "MRO", AntennaID: "DSS15", Inferred: true},
+		// Unnamed signal on the dish that was tracking VGR1
+		{AntennaID: "DSS43", Inferred: true, DownRate: 160},
+		// Unnamed, on a dish with no history: can't be identified
+		{AntennaID: "DSS63", Inferred: true},
+	}}, 0, nil)
+
+	links := m.Snapshot().Data.Links
+	if len(links) != 2 {
+		t.Fatalf("links = %+v, want the two identifiable ones", links)
+	}
+	if l := links[0]; l.Spacecraft != "MRO" || l.RTLT != 1000 || l.Distance != 1.5e8 {
+		t.Errorf("MRO = %+v, want the previous range", l)
+	}
+	if l := links[1]; l.Spacecraft != "VGR1" || l.SpacecraftID != 31 || l.RTLT != 160200 || !l.Inferred {
+		t.Errorf("DSS43 link = %+v, want VGR1 from the antenna's history", l)
+	}
+}
+
+func TestManager_EventDetection_FeedTime(t *testing.T) {
+	m := NewManager(DefaultConfig())
+
+	feed := time.Date(2025, 12, 5, 3, 0, 0, 0, time.UTC)
+	stationTime := feed.Add(-2 * time.Second)
+	data := &dsn.DSNData{
+		Timestamp: feed,
+		Stations: []dsn.Station{
+			{Name: "gdscc", Complex: dsn.ComplexGoldstone, TimeUTC: stationTime},
+		},
+		Links: []dsn.Link{
+			{SpacecraftID: 1, Spacecraft: "Voyager", StationID: "gdscc", AntennaID: "DSS-14", Complex: dsn.ComplexGoldstone},
+			{SpacecraftID: 2, Spacecraft: "Juno", StationID: "cdscc", AntennaID: "DSS-43", Complex: dsn.ComplexCanberra},
+		},
+	}
+	m.Update(data, 0, nil)
+
+	events := m.RecentEvents(10)
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+	for _, e := range events {
+		// Station timeUTC when present, else the feed timestamp
+		want := feed
+		if e.NewStation == "gdscc" {
+			want = stationTime
+		}
+		if !e.Timestamp.Equal(want) {
+			t.Errorf("%s timestamp = %v, want feed time %v", e.Spacecraft, e.Timestamp, want)
+		}
+		if lag := m.Snapshot().LastFetch.Sub(want); e.FeedLatency != lag {
+			t.Errorf("%s FeedLatency = %v, want %v", e.Spacecraft, e.FeedLatency, lag)
+		}
+	}
+}
+
 func TestManager_EventDetection_Handoff(t *testing.T) {
 	m := NewManager(DefaultConfig())
 
@@ -403,6 +526,54 @@ func TestManager_EventDetection_LinkLost(t *testing.T) {
 	}
 }
 
+func TestManager_EventDetection_Uplink(t *testing.T) {
+	m := NewManager(DefaultConfig())
+	link := dsn.Link{SpacecraftID: 32, Spacecraft: "VGR2", StationID: "cdscc", AntennaID: "DSS43", Complex: dsn.ComplexCanberra}
+	commanding := link
+	commanding.Uplink = true
+
+	types := func(events []Event) []EventType {
+		var ts []EventType
+		for _, e := range events {
+			if e.Type == EventUplinkStart || e.Type == EventUplinkEnd {
+				ts = append(ts, e.Type)
+			}
+		}
+		return ts
+	}
+
+	steps := []struct {
+		links []dsn.Link
+		want  []EventType
+	}{
+		{[]dsn.Link{link}, nil},
+		{[]dsn.Link{commanding}, []EventType{EventUplinkStart}},
+		{[]dsn.Link{commanding}, nil},
+		{[]dsn.Link{link}, []EventType{EventUplinkEnd}},
+		{[]dsn.Link{commanding}, []EventType{EventUplinkStart}},
+		{nil, []EventType{EventUplinkEnd}},
+	}
+
+	start := time.Now()
+	for i, step := range steps {
+		m.Update(&dsn.DSNData{Timestamp: start.Add(time.Duration(i) * time.Minute), Links: step.links}, 0, nil)
+		if got := types(m.newEvents); !reflect.DeepEqual(got, step.want) {
+			t.Errorf("step %d: uplink events = %v, want %v", i, got, step.want)
+		}
+	}
+
+	var startEvent *Event
+	for _, e := range m.RecentEvents(20) {
+		if e.Type == EventUplinkStart {
+			startEvent = &e
+			break
+		}
+	}
+	if startEvent == nil || startEvent.AntennaID != "DSS43" || startEvent.Complex != string(dsn.ComplexCanberra) {
+		t.Errorf("UPLINK_START = %+v, want DSS43 at cdscc", startEvent)
+	}
+}
+
 func TestManager_EventRingBuffer(t *testing.T) {
 	cfg := DefaultConfig()
 	cfg.MaxEvents = 5
@@ -458,3 +629,499 @@ func TestManager_Snapshot_IncludesEvents(t *testing.T) {
 		t.Errorf("event type = %q, want NEW_LINK", snap.Events[0].Type)
 	}
 }
+
+func TestSnapshot_Follow(t *testing.T) {
+	m := NewManager(DefaultConfig())
+	m.Update(&dsn.DSNData{
+		Timestamp: time.Now(),
+		Links: []dsn.Link{
+			{SpacecraftID: 31, Spacecraft: "VGR1", StationID: "mdscc", Complex: dsn.ComplexMadrid},
+			{SpacecraftID: 170, Spacecraft: "JWST", StationID: "gdscc", Complex: dsn.ComplexGoldstone},
+		},
+	}, 0, nil)
+
+	all := m.Snapshot()
+	snap := all.Follow(dsn.ParseWatchlist("vgr1"))
+	if len(snap.Data.Links) != 1 || snap.Data.Links[0].Spacecraft != "VGR1" {
+		t.Errorf("links = %+v, want only VGR1", snap.Data.Links)
+	}
+	if len(snap.Spacecraft) != 1 || snap.Spacecraft[0].Name != "VGR1" {
+		t.Errorf("spacecraft = %+v, want only VGR1", snap.Spacecraft)
+	}
+	if len(snap.Events) != 1 || snap.Events[0].Spacecraft != "VGR1" {
+		t.Errorf("events = %+v, want VGR1's NEW_LINK", snap.Events)
+	}
+	if len(snap.ComplexLoads) != len(all.ComplexLoads) {
+		t.Error("complex loads should be kept whole")
+	}
+	if len(all.Data.Links) != 2 || len(all.Events) != 2 {
+		t.Error("Follow modified the original snapshot")
+	}
+	if got := all.Follow(nil); len(got.Data.Links) != 2 {
+		t.Error("an empty watchlist should keep everything")
+	}
+}
+
+func TestManager_QualityHistory(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MaxHistoryLen = 2
+	m := NewManager(cfg)
+
+	for i := 0; i < 3; i++ {
+		data := &dsn.DSNData{
+			Timestamp: time.Now(),
+			Errors:    make([]string, i),
+		}
+		m.Update(data, 0, nil)
+	}
+
+	snap := m.Snapshot()
+	if len(snap.QualityHistory) != 2 {
+		t.Fatalf("QualityHistory length = %d, want 2", len(snap.QualityHistory))
+	}
+	if got := snap.Quality.Count(dsn.QualityParseError); got != 2 {
+		t.Errorf("latest parse errors = %d, want 2", got)
+	}
+	if got := snap.QualityHistory[0].Count(dsn.QualityParseError); got != 1 {
+		t.Errorf("oldest parse errors = %d, want 1", got)
+	}
+}
+
+func TestManager_AntennaHistory(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MaxHistoryLen = 3
+	m := NewManager(cfg)
+
+	t0 := time.Date(2025, 12, 5, 6, 0, 0, 0, time.UTC)
+	for i := range 4 {
+		dss14 := dsn.Antenna{ID: "DSS14", Elevation: float64(10 * i)}
+		if i >= 2 {
+			dss14.Targets = []dsn.Target{{ID: 32, Name: "VGR2"}}
+			dss14.DownSignals = []dsn.Signal{
+				{Active: true, DataRate: 160},
+				{Active: false, DataRate: 40},
+			}
+			dss14.UpSignals = []dsn.Signal{{Active: true, DataRate: 16}}
+		}
+		stations := []dsn.Station{{Complex: dsn.ComplexGoldstone, Antennas: []dsn.Antenna{dss14}}}
+		if i == 3 {
+			// DSS43 only appears in the latest fetch
+			stations = append(stations, dsn.Station{Complex: dsn.ComplexCanberra, Antennas: []dsn.Antenna{{ID: "DSS43"}}})
+		}
+		m.Update(&dsn.DSNData{Timestamp: t0.Add(time.Duration(i) * time.Minute), Stations: stations}, 0, nil)
+	}
+
+	hist := m.Snapshot().AntennaHistory
+	dss14 := hist["DSS14"]
+	if len(dss14) != 3 {
+		t.Fatalf("DSS14 samples = %d, want the 3 kept in history", len(dss14))
+	}
+	if dss14[0].Active() || dss14[0].Elevation != 10 || !dss14[0].Timestamp.Equal(t0.Add(time.Minute)) {
+		t.Errorf("oldest sample = %+v, want the idle second fetch", dss14[0])
+	}
+	last := dss14[2]
+	if !last.Active() || last.DownRate != 160 || last.UpRate != 16 {
+		t.Errorf("latest sample = %+v, want active signals only", last)
+	}
+	if len(hist["DSS43"]) != 1 {
+		t.Errorf("DSS43 samples = %d, want 1", len(hist["DSS43"]))
+	}
+}
+
+func TestManager_Timeline(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.TimelineWindow = 10 * time.Minute
+	m := NewManager(cfg)
+
+	// Active links are counted from the dishes' targets, as in the
+	// complex loads
+	data := func(links ...dsn.Link) *dsn.DSNData {
+		d := &dsn.DSNData{Links: links}
+		for _, l := range links {
+			d.Stations = append(d.Stations, dsn.Station{Complex: l.Complex, Antennas: []dsn.Antenna{
+				{ID: l.AntennaID, Targets: []dsn.Target{{Name: l.Spacecraft}}},
+			}})
+		}
+		return d
+	}
+	gold := dsn.Link{Complex: dsn.ComplexGoldstone, Spacecraft: "JWST", DataRate: 1000}
+	mad := dsn.Link{Complex: dsn.ComplexMadrid, Spacecraft: "VGR1", DataRate: 160}
+
+	t0 := time.Date(2025, 12, 5, 6, 0, 0, 0, time.UTC)
+	m.UpdateAt(data(gold), t0.Add(5*time.Second), 0, nil)
+	// A later fetch in the same minute replaces the first
+	m.UpdateAt(data(gold, mad), t0.Add(35*time.Second), 0, nil)
+	m.UpdateAt(data(mad), t0.Add(time.Minute+5*time.Second), 0, nil)
+
+	snap := m.Snapshot()
+	if snap.TimelineWindow != 10*time.Minute {
+		t.Errorf("TimelineWindow = %v", snap.TimelineWindow)
+	}
+	tl := snap.Timeline
+	if len(tl) != 2 {
+		t.Fatalf("timeline has %d samples, want one per minute", len(tl))
+	}
+	if !tl[0].Timestamp.Equal(t0) || tl[0].Links[dsn.ComplexGoldstone] != 1 || tl[0].Links[dsn.ComplexMadrid] != 1 {
+		t.Errorf("first sample = %+v, want the minute's last fetch", tl[0])
+	}
+	if tl[1].Rate[dsn.ComplexMadrid] != 160 || tl[1].Rate[dsn.ComplexGoldstone] != 0 {
+		t.Errorf("second sample rates = %v", tl[1].Rate)
+	}
+
+	// Samples older than the window are dropped
+	m.UpdateAt(data(gold), t0.Add(10*time.Minute), 0, nil)
+	tl = m.Snapshot().Timeline
+	if len(tl) != 2 || !tl[0].Timestamp.Equal(t0.Add(time.Minute)) {
+		t.Errorf("after 10m: %d samples from %v, want the 06:00 sample dropped", len(tl), tl[0].Timestamp)
+	}
+}
+
+func TestManager_UpdateAt(t *testing.T) {
+	m := NewManager(DefaultConfig())
+	ts := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	fetchedAt := ts.Add(7 * time.Second)
+
+	m.UpdateAt(&dsn.DSNData{Timestamp: ts}, fetchedAt, 0, nil)
+
+	snap := m.Snapshot()
+	if !snap.LastFetch.Equal(fetchedAt) {
+		t.Errorf("LastFetch = %v, want %v", snap.LastFetch, fetchedAt)
+	}
+	if snap.Quality.FeedLatency != 7*time.Second {
+		t.Errorf("FeedLatency = %v, want 7s", snap.Quality.FeedLatency)
+	}
+}
+
+func BenchmarkManager_Snapshot(b *testing.B) {
+	m := NewManager(DefaultConfig())
+	for i := 0; i < 60; i++ {
+		data := &dsn.DSNData{
+			Timestamp: time.Now(),
+			Links: []dsn.Link{
+				{Spacecraft: "VGR1", SpacecraftID: 31, AntennaID: "DSS43", Complex: dsn.ComplexCanberra},
+				{Spacecraft: "JWST", SpacecraftID: 170, AntennaID: "DSS26", Complex: dsn.ComplexGoldstone},
+				{Spacecraft: "MRO", SpacecraftID: 74, AntennaID: "DSS55", Complex: dsn.ComplexMadrid},
+			},
+		}
+		m.Update(data, 100*time.Millisecond, nil)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = m.Snapshot()
+	}
+}
+
+// fakeSightings reports fixed unseen times and records observations.
+type fakeSightings struct {
+	unseen   map[string]time.Duration
+	last     map[string]time.Time
+	observed [][]string
+}
+
+func (f *fakeSightings) Observe(t time.Time, spacecraft []string) {
+	f.observed = append(f.observed, spacecraft)
+}
+
+func (f *fakeSightings) Unseen(spacecraft string, t time.Time) (time.Duration, time.Time) {
+	return f.unseen[spacecraft], f.last[spacecraft]
+}
+
+func TestManager_RareAcquisition(t *testing.T) {
+	start := time.Date(2025, 12, 5, 12, 0, 0, 0, time.UTC)
+	lastSeen := start.Add(-45 * 24 * time.Hour)
+	log := &fakeSightings{
+		unseen: map[string]time.Duration{"VGR2": 40 * 24 * time.Hour, "JNO": 2 * time.Hour},
+		last:   map[string]time.Time{"VGR2": lastSeen},
+	}
+	m := NewManager(DefaultConfig())
+	m.SetSightings(log)
+
+	links := []dsn.Link{
+		{Spacecraft: "VGR2", StationID: "cdscc", AntennaID: "DSS43", Complex: dsn.ComplexCanberra},
+		{Spacecraft: "JNO", StationID: "gdscc", AntennaID: "DSS14", Complex: dsn.ComplexGoldstone},
+	}
+	m.Update(&dsn.DSNData{Timestamp: start, Links: links}, 0, nil)
+
+	var rare []Event
+	for _, e := range m.newEvents {
+		if e.Type == EventRareAcquisition {
+			rare = append(rare, e)
+		}
+	}
+	if len(rare) != 1 || rare[0].Spacecraft != "VGR2" || rare[0].AntennaID != "DSS43" || !rare[0].LastSeen.Equal(lastSeen) {
+		t.Fatalf("rare events = %+v, want VGR2 last seen %v", rare, lastSeen)
+	}
+	if len(log.observed) != 1 || len(log.observed[0]) != 2 {
+		t.Errorf("observed = %v, want both spacecraft once", log.observed)
+	}
+
+	// Still tracked: no new acquisition, nothing rare
+	m.Update(&dsn.DSNData{Timestamp: start.Add(time.Minute), Links: links}, 0, nil)
+	for _, e := range m.newEvents {
+		if e.Type == EventRareAcquisition {
+			t.Errorf("rare event without a new link: %+v", e)
+		}
+	}
+
+	// Without a log nothing is rare
+	m = NewManager(DefaultConfig())
+	m.Update(&dsn.DSNData{Timestamp: start, Links: links}, 0, nil)
+	for _, e := range m.newEvents {
+		if e.Type == EventRareAcquisition {
+			t.Errorf("rare event without a sighting log: %+v", e)
+		}
+	}
+}
+
+func TestManager_ComplexQuiet(t *testing.T) {
+	start := time.Date(2025, 12, 5, 12, 0, 0, 0, time.UTC)
+	m := NewManager(Config{MaxHistoryLen: 10, QuietAfter: time.Hour})
+	stations := []dsn.Station{
+		{Complex: dsn.ComplexGoldstone, Name: "gdscc"},
+		{Complex: dsn.ComplexMadrid, Name: "mdscc"},
+	}
+	jno := dsn.Link{Spacecraft: "JNO", StationID: "gdscc", AntennaID: "DSS14", Complex: dsn.ComplexGoldstone}
+	vgr1 := dsn.Link{Spacecraft: "VGR1", StationID: "mdscc", AntennaID: "DSS63", Complex: dsn.ComplexMadrid}
+
+	update := func(at time.Time, links ...dsn.Link) []Event {
+		m.Update(&dsn.DSNData{Timestamp: at, Stations: stations, Links: links}, 0, nil)
+		var events []Event
+		for _, e := range m.newEvents {
+			if e.Type == EventComplexQuiet || e.Type == EventComplexActive {
+				events = append(events, e)
+			}
+		}
+		return events
+	}
+
+	// Madrid tracks until 12:30; Goldstone is idle from the start
+	for i := 0; i <= 30; i += 10 {
+		if ev := update(start.Add(time.Duration(i)*time.Minute), jno, vgr1); len(ev) != 0 {
+			t.Fatalf("events while tracking: %+v", ev)
+		}
+	}
+	madridLast := start.Add(30 * time.Minute)
+	for i := 40; i < 90; i += 10 {
+		if ev := update(start.Add(time.Duration(i)*time.Minute), jno); len(ev) != 0 {
+			t.Fatalf("quiet before QuietAfter: %+v", ev)
+		}
+	}
+
+	ev := update(start.Add(90*time.Minute), jno)
+	if len(ev) != 1 || ev[0].Type != EventComplexQuiet || ev[0].Complex != "mdscc" ||
+		ev[0].Spacecraft != "" || !ev[0].LastSeen.Equal(madridLast) {
+		t.Fatalf("events = %+v, want Madrid quiet since %v", ev, madridLast)
+	}
+	if since, ok := m.Snapshot().QuietComplexes[dsn.ComplexMadrid]; !ok || !since.Equal(madridLast) {
+		t.Errorf("QuietComplexes = %v", m.Snapshot().QuietComplexes)
+	}
+	if ev := update(start.Add(100*time.Minute), jno); len(ev) != 0 {
+		t.Errorf("quiet reported twice: %+v", ev)
+	}
+
+	// Madrid tracks again
+	ev = update(start.Add(110*time.Minute), jno, vgr1)
+	if len(ev) != 1 || ev[0].Type != EventComplexActive || !ev[0].LastSeen.Equal(madridLast) {
+		t.Fatalf("events = %+v, want Madrid active again", ev)
+	}
+	if len(m.Snapshot().QuietComplexes) != 0 {
+		t.Errorf("QuietComplexes = %v, want none", m.Snapshot().QuietComplexes)
+	}
+
+	// Network-level events survive a watchlist
+	snap := m.Snapshot().Follow(dsn.Watchlist{"JNO"})
+	var network int
+	for _, e := range snap.Events {
+		if e.Spacecraft == "" {
+			network++
+		}
+	}
+	if network != 2 {
+		t.Errorf("followed snapshot has %d network events, want 2", network)
+	}
+}
+
+func TestManager_WindRisk(t *testing.T) {
+	start := time.Date(2025, 12, 5, 12, 0, 0, 0, time.UTC)
+	m := NewManager(Config{MaxHistoryLen: 10})
+	jno := dsn.Link{Spacecraft: "JNO", StationID: "gdscc", AntennaID: "DSS14", Complex: dsn.ComplexGoldstone}
+
+	update := func(minute int, wind float64, links ...dsn.Link) []Event {
+		m.Update(&dsn.DSNData{
+			Timestamp: start.Add(time.Duration(minute) * time.Minute),
+			Stations: []dsn.Station{{
+				Complex:  dsn.ComplexGoldstone,
+				Name:     "gdscc",
+				Antennas: []dsn.Antenna{{ID: "DSS14", WindSpeed: wind}},
+			}},
+			Links: links,
+		}, 0, nil)
+		var events []Event
+		for _, e := range m.newEvents {
+			if e.Type == EventWindRisk {
+				events = append(events, e)
+			}
+		}
+		return events
+	}
+
+	if ev := update(0, 30, jno); len(ev) != 0 {
+		t.Fatalf("events in calm wind: %+v", ev)
+	}
+	ev := update(1, 55, jno)
+	if len(ev) != 1 || ev[0].Spacecraft != "JNO" || ev[0].AntennaID != "DSS14" || ev[0].Wind != 55 {
+		t.Fatalf("events = %+v, want one JNO caution", ev)
+	}
+	if ev := update(2, 60, jno); len(ev) != 0 {
+		t.Errorf("caution reported twice: %+v", ev)
+	}
+	if ev := update(3, 80, jno); len(ev) != 1 || ev[0].Wind != 80 {
+		t.Errorf("events = %+v, want a stow warning", ev)
+	}
+
+	// No pass, no event; a pass starting in high wind raises one
+	if ev := update(4, 80); len(ev) != 0 {
+		t.Errorf("events with no pass: %+v", ev)
+	}
+	if ev := update(5, 80, jno); len(ev) != 1 {
+		t.Errorf("events = %+v, want one at pass start", ev)
+	}
+
+	// Calm re-arms
+	update(6, 20, jno)
+	if ev := update(7, 52, jno); len(ev) != 1 || ev[0].WindStow != dsn.DefaultWindLimits().Stow {
+		t.Errorf("events = %+v, want caution again after calm", ev)
+	}
+
+	// Configured limits replace the defaults
+	m = NewManager(Config{MaxHistoryLen: 10, WindLimits: dsn.WindLimits{Caution: 30, Stow: 40}})
+	if ev := update(0, 45, jno); len(ev) != 1 || ev[0].WindStow != 40 {
+		t.Errorf("events = %+v, want a stow warning at 45 km/h", ev)
+	}
+}
+
+func TestManager_PointingDivergence(t *testing.T) {
+	start := time.Date(2025, 12, 5, 12, 0, 0, 0, time.UTC)
+	m := NewManager(Config{MaxHistoryLen: 10, DivergenceFetches: 2})
+
+	// A fixed RA/Dec path for JNO covering the test
+	var samples []astro.RADecAtTime
+	for i := -1; i <= 3; i++ {
+		samples = append(samples, astro.RADecAtTime{Time: start.Add(time.Duration(i) * time.Hour), RAdeg: 90, DecDeg: 10})
+	}
+	m.StoreElevationGeometry(61, samples)
+
+	obs := dsn.ObserverForAntenna("DSS14", dsn.ComplexGoldstone)
+	update := func(minute int, offDeg float64) []Event {
+		at := start.Add(time.Duration(minute) * time.Minute)
+		h := astro.EquatorialToHorizontal(astro.SkyCoord{RAdeg: 90, DecDeg: 10}, obs, at)
+		m.UpdateAt(&dsn.DSNData{
+			Timestamp: at,
+			Links: []dsn.Link{{
+				Spacecraft: "JNO", SpacecraftID: 61, StationID: "gdscc", AntennaID: "DSS14",
+				Complex: dsn.ComplexGoldstone, RTLT: 5000,
+				Pointing: dsn.Pointing{AzDeg: h.AzDeg, ElDeg: h.ElDeg + offDeg, Valid: true},
+			}},
+		}, at, 0, nil)
+		var events []Event
+		for _, e := range m.newEvents {
+			if e.Type == EventPointingDivergence {
+				events = append(events, e)
+			}
+		}
+		return events
+	}
+
+	if ev := update(0, 0.5); len(ev) != 0 {
+		t.Fatalf("events on target: %+v", ev)
+	}
+	if ev := update(1, 6); len(ev) != 0 {
+		t.Fatalf("events after one fetch off target: %+v", ev)
+	}
+	ev := update(2, 6)
+	if len(ev) != 1 || ev[0].Spacecraft != "JNO" || ev[0].AntennaID != "DSS14" || math.Abs(ev[0].Divergence-6) > 0.1 {
+		t.Fatalf("events = %+v, want one 6° divergence", ev)
+	}
+	if ev := update(3, 6); len(ev) != 0 {
+		t.Errorf("divergence reported twice: %+v", ev)
+	}
+
+	// Back on target re-arms
+	update(4, 0)
+	update(5, 6)
+	if ev := update(6, 6); len(ev) != 1 {
+		t.Errorf("events = %+v, want another divergence after recovering", ev)
+	}
+}
+
+func TestSpacecraftHistory_RateTrend(t *testing.T) {
+	start := time.Date(2025, 12, 5, 12, 0, 0, 0, time.UTC)
+	h := &SpacecraftHistory{}
+	if h.RateTrend() != 0 {
+		t.Error("trend without samples")
+	}
+	// An hour of 1 bps/s decline; only the last half hour is fitted, and
+	// the step before it is left out
+	h.RateHistory = append(h.RateHistory, TimeSeries{Timestamp: start.Add(-time.Hour), Value: 1e9})
+	for i := 0; i <= 60; i += 5 {
+		h.RateHistory = append(h.RateHistory, TimeSeries{Timestamp: start.Add(time.Duration(i) * time.Minute), Value: 10000 - float64(i*60)})
+	}
+	if got := h.RateTrend(); math.Abs(got+1) > 1e-9 {
+		t.Errorf("RateTrend = %v, want -1", got)
+	}
+	h.RateHistory = h.RateHistory[:2]
+	if h.RateTrend() != 0 {
+		t.Error("trend from two samples")
+	}
+}
+
+// fakeRateLog is a sighting log that also learns rates.
+type fakeRateLog struct {
+	fakeSightings
+	typical map[dsn.RateBand]float64
+	learned map[dsn.RateBand]float64
+}
+
+func (f *fakeRateLog) ObserveRate(spacecraft, band string, bps float64, t time.Time) {
+	f.learned[dsn.NewRateBand(spacecraft, band)] = bps
+}
+
+func (f *fakeRateLog) TypicalRate(spacecraft, band string) (float64, bool) {
+	bps, ok := f.typical[dsn.NewRateBand(spacecraft, band)]
+	return bps, ok
+}
+
+func TestManager_RateBaselines(t *testing.T) {
+	start := time.Date(2025, 12, 5, 12, 0, 0, 0, time.UTC)
+	links := []dsn.Link{
+		{Spacecraft: "VGR1", StationID: "cdscc", AntennaID: "DSS43", Band: "X", DataRate: 40},
+		{Spacecraft: "MRO", StationID: "mdscc", AntennaID: "DSS54", Band: "X", DataRate: 5e5},
+		{Spacecraft: "JNO", StationID: "gdscc", AntennaID: "DSS14", Band: "X", DataRate: 1e4},
+	}
+
+	// Without a log, only bundled baselines
+	m := NewManager(DefaultConfig())
+	m.Update(&dsn.DSNData{Timestamp: start, Links: links}, 0, nil)
+	got := m.Snapshot().RateBaselines
+	if got[dsn.NewRateBand("VGR1", "X")] != 160 || len(got) != 2 {
+		t.Errorf("bundled baselines = %v, want VGR1 and MRO", got)
+	}
+
+	// Learned rates win, and are looked up before this fetch is learned
+	log := &fakeRateLog{
+		typical: map[dsn.RateBand]float64{dsn.NewRateBand("MRO", "X"): 4e6, dsn.NewRateBand("JNO", "X"): 2e4},
+		learned: make(map[dsn.RateBand]float64),
+	}
+	m = NewManager(DefaultConfig())
+	m.SetSightings(log)
+	m.Update(&dsn.DSNData{Timestamp: start, Links: links}, 0, nil)
+	got = m.Snapshot().RateBaselines
+	if got[dsn.NewRateBand("MRO", "X")] != 4e6 || got[dsn.NewRateBand("JNO", "X")] != 2e4 || got[dsn.NewRateBand("VGR1", "X")] != 160 {
+		t.Errorf("baselines = %v", got)
+	}
+	if log.learned[dsn.NewRateBand("VGR1", "X")] != 40 || len(log.learned) != 3 {
+		t.Errorf("learned = %v, want this fetch's rates", log.learned)
+	}
+}
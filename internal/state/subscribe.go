@@ -0,0 +1,49 @@
+package state
+
+import (
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+)
+
+// SubscriberBuffer is how many updates a subscriber may fall behind
+// before further updates are dropped for it.
+const SubscriberBuffer = 16
+
+// Update is delivered to subscribers after each successful update.
+type Update struct {
+	Data      *dsn.DSNData
+	FetchedAt time.Time
+	Events    []Event // Events detected by this update, oldest first
+}
+
+// Subscribe registers for updates until cancel is called, which closes
+// the channel. Delivery never blocks the fetch loop: a subscriber more
+// than SubscriberBuffer updates behind misses the newer ones.
+func (m *Manager) Subscribe() (updates <-chan Update, cancel func()) {
+	ch := make(chan Update, SubscriberBuffer)
+
+	m.mu.Lock()
+	m.subscribers[ch] = struct{}{}
+	m.mu.Unlock()
+
+	return ch, func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if _, ok := m.subscribers[ch]; ok {
+			delete(m.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// notify sends u to every subscriber with room for it. Called with m.mu
+// held.
+func (m *Manager) notify(u Update) {
+	for ch := range m.subscribers {
+		select {
+		case ch <- u:
+		default:
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+)
+
+func TestManager_Subscribe(t *testing.T) {
+	m := NewManager(DefaultConfig())
+	updates, cancel := m.Subscribe()
+
+	data := &dsn.DSNData{
+		Timestamp: time.Now(),
+		Links: []dsn.Link{
+			{SpacecraftID: 31, Spacecraft: "VGR1", StationID: "cdscc", AntennaID: "DSS43", Complex: dsn.ComplexCanberra},
+		},
+	}
+	m.Update(data, 0, nil)
+	m.Update(data, 0, nil)
+	m.Update(nil, 0, nil) // failed fetches aren't delivered
+
+	u := <-updates
+	if u.Data != data || len(u.Events) != 1 || u.Events[0].Type != EventNewLink {
+		t.Errorf("first update = %+v, want data with one NEW_LINK event", u)
+	}
+	u = <-updates
+	if len(u.Events) != 0 {
+		t.Errorf("second update events = %+v, want none", u.Events)
+	}
+	select {
+	case u := <-updates:
+		t.Errorf("unexpected update %+v", u)
+	default:
+	}
+
+	cancel()
+	if _, ok := <-updates; ok {
+		t.Error("channel still open after cancel")
+	}
+	cancel() // idempotent
+}
+
+func TestManager_Subscribe_SlowSubscriber(t *testing.T) {
+	m := NewManager(DefaultConfig())
+	updates, cancel := m.Subscribe()
+	defer cancel()
+
+	// Updates beyond the buffer are dropped rather than blocking
+	for i := 0; i < SubscriberBuffer+5; i++ {
+		m.Update(&dsn.DSNData{Timestamp: time.Now()}, 0, nil)
+	}
+	if len(updates) != SubscriberBuffer {
+		t.Errorf("buffered updates = %d, want %d", len(updates), SubscriberBuffer)
+	}
+}
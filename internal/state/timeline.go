@@ -0,0 +1,58 @@
+package state
+
+import (
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+)
+
+// DefaultTimelineWindow is how far back the utilization timeline reaches.
+const DefaultTimelineWindow = 6 * time.Hour
+
+// timelineBucket is the timeline's resolution: fetches within one bucket
+// share a sample, so the window holds the same number of samples whatever
+// the refresh interval.
+const timelineBucket = time.Minute
+
+// TimelineSample is the DSN's load in one timeline bucket, per complex.
+type TimelineSample struct {
+	Timestamp time.Time // start of the bucket
+	Links     map[dsn.Complex]int
+	Rate      map[dsn.Complex]float64 // bps, summed over the complex's links
+}
+
+// sampleTimeline summarizes data for the bucket starting at t.
+func sampleTimeline(data *dsn.DSNData, loads map[dsn.Complex]dsn.ComplexLoad, t time.Time) TimelineSample {
+	s := TimelineSample{
+		Timestamp: t,
+		Links:     make(map[dsn.Complex]int, len(loads)),
+		Rate:      make(map[dsn.Complex]float64, len(loads)),
+	}
+	for c, load := range loads {
+		s.Links[c] = load.ActiveLinks
+	}
+	for _, link := range data.Links {
+		s.Rate[link.Complex] += link.DataRate
+	}
+	return s
+}
+
+// recordTimeline adds the latest fetch to the timeline, replacing the
+// sample of an earlier fetch in the same bucket, and drops samples that
+// have left the window. Caller must hold the lock.
+func (m *Manager) recordTimeline(data *dsn.DSNData, fetchedAt time.Time) {
+	bucket := fetchedAt.Truncate(timelineBucket)
+	s := sampleTimeline(data, m.complexLoads, bucket)
+	if n := len(m.timeline); n > 0 && m.timeline[n-1].Timestamp.Equal(bucket) {
+		m.timeline[n-1] = s
+	} else {
+		m.timeline = append(m.timeline, s)
+	}
+
+	cutoff := bucket.Add(-m.timelineWindow)
+	drop := 0
+	for drop < len(m.timeline) && !m.timeline[drop].Timestamp.After(cutoff) {
+		drop++
+	}
+	m.timeline = m.timeline[drop:]
+}
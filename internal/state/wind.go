@@ -0,0 +1,49 @@
+package state
+
+import (
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+)
+
+// detectWind raises WIND_RISK when the wind at an antenna tracking a
+// spacecraft rises into a higher risk level under the configured limits:
+// once on reaching caution, again on reaching stow, including at the
+// start of a pass in high wind. Falling back below caution, or the pass
+// ending, re-arms it. Caller must hold the lock.
+func (m *Manager) detectWind(data *dsn.DSNData, fetchedAt time.Time) {
+	tracking := make(map[string]dsn.Link)
+	for _, link := range data.Links {
+		if _, ok := tracking[link.AntennaID]; !ok && dsn.IsRealSpacecraft(link.Spacecraft) {
+			tracking[link.AntennaID] = link
+		}
+	}
+
+	for _, st := range data.Stations {
+		for _, ant := range st.Antennas {
+			link, active := tracking[ant.ID]
+			if !active {
+				delete(m.windRisk, ant.ID)
+				continue
+			}
+			risk := m.windLimits.Risk(ant.WindSpeed)
+			prev := m.windRisk[ant.ID]
+			m.windRisk[ant.ID] = risk
+			if risk <= prev {
+				continue
+			}
+			at := feedTime(data, st.Name, fetchedAt)
+			m.addEvent(Event{
+				Type:        EventWindRisk,
+				Timestamp:   at,
+				Spacecraft:  link.Spacecraft,
+				NewStation:  link.StationID,
+				AntennaID:   ant.ID,
+				Complex:     string(st.Complex),
+				Wind:        ant.WindSpeed,
+				WindStow:    m.windLimits.Stow,
+				FeedLatency: max(0, fetchedAt.Sub(at)),
+			})
+		}
+	}
+}
@@ -0,0 +1,398 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+	"github.com/litescript/ls-horizons/internal/ephem"
+	"github.com/litescript/ls-horizons/internal/state"
+)
+
+// Language selects the language of in-app documentation.
+type Language string
+
+const (
+	LangEnglish Language = "en"
+	LangSpanish Language = "es"
+)
+
+// ParseLanguage parses a language code such as "es" or "es_MX.UTF-8".
+// "auto" (or anything without a catalog) detects it from the environment,
+// falling back to English.
+func ParseLanguage(s string) Language {
+	if lang, ok := catalogLanguage(s); ok {
+		return lang
+	}
+	return DetectLanguage(os.Getenv)
+}
+
+// DetectLanguage picks the documentation language from the locale. The
+// first locale variable set wins, as in setlocale(3).
+func DetectLanguage(getenv func(string) string) Language {
+	for _, key := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if v := getenv(key); v != "" {
+			if lang, ok := catalogLanguage(v); ok {
+				return lang
+			}
+			return LangEnglish
+		}
+	}
+	return LangEnglish
+}
+
+// catalogLanguage maps a locale name to a language with a catalog.
+func catalogLanguage(locale string) (Language, bool) {
+	code := strings.ToLower(locale)
+	if i := strings.IndexAny(code, "_-.@"); i >= 0 {
+		code = code[:i]
+	}
+	if _, ok := aboutCatalog[Language(code)]; ok {
+		return Language(code), true
+	}
+	return "", false
+}
+
+// aboutSource identifies where a view's data comes from.
+type aboutSource string
+
+const (
+	sourceDSNNow    aboutSource = "dsn-now"
+	sourceHorizons  aboutSource = "horizons"
+	sourceLocalMath aboutSource = "local-math"
+)
+
+// aboutUse is one source on an about page and what the view takes from it.
+type aboutUse struct {
+	source aboutSource
+	what   string // catalog key
+}
+
+// aboutCadence is one refresh interval on an about page: a catalog key
+// whose text formats the interval, and the interval currently in effect.
+type aboutCadence struct {
+	key      string
+	interval func(m Model) time.Duration
+}
+
+// aboutPage is the structured metadata behind a view's "about this data"
+// page. All text lives in aboutCatalog under the keys given here.
+type aboutPage struct {
+	view    string // catalog key for the view name
+	uses    []aboutUse
+	cadence []aboutCadence
+	caveats []string // catalog keys
+}
+
+// feedCadence is the DSN refresh interval in effect.
+var feedCadence = aboutCadence{"cadence.feed", func(m Model) time.Duration {
+	if m.state == nil {
+		return 0
+	}
+	return m.state.RefreshInterval()
+}}
+
+// aboutPages holds the about page for each view.
+var aboutPages = map[ViewMode]aboutPage{
+	ViewDashboard: {
+		view: "view.dashboard",
+		uses: []aboutUse{
+			{sourceDSNNow, "use.dashboard.feed"},
+			{sourceLocalMath, "use.dashboard.math"},
+		},
+		cadence: []aboutCadence{feedCadence},
+		caveats: []string{"caveat.feed-public", "caveat.health-heuristic", "caveat.carrier"},
+	},
+	ViewMissionDetail: {
+		view: "view.mission",
+		uses: []aboutUse{
+			{sourceDSNNow, "use.mission.feed"},
+			{sourceHorizons, "use.mission.horizons"},
+			{sourceLocalMath, "use.mission.math"},
+		},
+		cadence: []aboutCadence{
+			feedCadence,
+			{"cadence.passplan", func(Model) time.Duration { return state.PassPlanTTL }},
+			{"cadence.elevtrace", func(Model) time.Duration { return state.ElevationTraceTTL }},
+		},
+		caveats: []string{"caveat.passes-not-schedule", "caveat.doppler", "caveat.tracking-mode"},
+	},
+	ViewSky: {
+		view: "view.sky",
+		uses: []aboutUse{
+			{sourceDSNNow, "use.sky.feed"},
+			{sourceHorizons, "use.sky.horizons"},
+			{sourceLocalMath, "use.sky.math"},
+		},
+		cadence: []aboutCadence{feedCadence},
+		caveats: []string{"caveat.pointing", "caveat.mspa", "caveat.below-horizon"},
+	},
+	ViewSolarSystem: {
+		view: "view.orbit",
+		uses: []aboutUse{
+			{sourceHorizons, "use.orbit.horizons"},
+			{sourceDSNNow, "use.orbit.feed"},
+			{sourceLocalMath, "use.orbit.math"},
+		},
+		cadence: []aboutCadence{
+			{"cadence.planets", func(m Model) time.Duration { return m.solarConfig().PlanetRefresh }},
+			{"cadence.spacecraft", func(m Model) time.Duration { return m.solarConfig().SpacecraftRefresh }},
+		},
+		caveats: []string{"caveat.light-time", "caveat.geocentric", "caveat.planets-fallback"},
+	},
+	ViewEvents: {
+		view: "view.events",
+		uses: []aboutUse{
+			{sourceDSNNow, "use.events.feed"},
+			{sourceLocalMath, "use.events.math"},
+		},
+		cadence: []aboutCadence{feedCadence},
+		caveats: []string{"caveat.events-feed-time", "caveat.events-retention"},
+	},
+}
+
+// aboutCatalog holds the about page text per language. English is
+// complete; other languages fall back to it key by key.
+var aboutCatalog = map[Language]map[string]string{
+	LangEnglish: {
+		"title":       "About this data: %s",
+		"sources":     "Sources",
+		"cadence":     "Refresh",
+		"caveats":     "Caveats",
+		"in-use":      "In use: %s ephemeris, %s feed",
+		"feed.live":   "live",
+		"feed.replay": "replayed (%g×)",
+		"hint":        "i/esc: close | tab: next view",
+
+		"view.dashboard": "Dashboard",
+		"view.mission":   "Mission",
+		"view.sky":       "Sky",
+		"view.orbit":     "Orbit",
+		"view.events":    "Events",
+
+		"source.dsn-now":    "DSN Now feed",
+		"source.horizons":   "JPL Horizons",
+		"source.local-math": "Local math",
+
+		"use.dashboard.feed":   "antennas, dish pointing and wind, targets, signals, data rates, and round-trip light times",
+		"use.dashboard.math":   "distance from light time, struggle index and health, complex load",
+		"use.mission.feed":     "each antenna's link: band, up/down rates, light time, tracking mode",
+		"use.mission.horizons": "RA/Dec over the next day for pass plans and elevation traces",
+		"use.mission.math":     "rise, peak, and set times, Sun separation, Doppler estimate",
+		"use.sky.feed":         "where each dish is pointing (azimuth and elevation)",
+		"use.sky.horizons":     "sky paths for the focused spacecraft",
+		"use.sky.math":         "RA/Dec to Az/El conversion, sidereal time, visibility cones",
+		"use.orbit.horizons":   "planet and spacecraft position vectors; ±30-day trails for the focused spacecraft; moons of a zoomed-in planet",
+		"use.orbit.feed":       "spacecraft range from light time",
+		"use.orbit.math":       "light-time correction; spacecraft without vectors placed along their sky direction at that range",
+		"use.events.feed":      "links on each antenna, compared fetch to fetch",
+		"use.events.math":      "acquisitions, handoffs, losses, and uplink changes between fetches",
+
+		"cadence.feed":       "DSN feed fetched every %s",
+		"cadence.passplan":   "Pass plans cached for %s, fetched one spacecraft at a time",
+		"cadence.elevtrace":  "Elevation traces cached for %s",
+		"cadence.planets":    "Planet positions refreshed every %s",
+		"cadence.spacecraft": "Spacecraft positions refreshed every %s",
+
+		"caveat.feed-public":         "DSN Now is a public display feed: it can lag, and some sessions are not shown.",
+		"caveat.health-heuristic":    "Health is a heuristic from distance, rate, and elevation, not measured link margin.",
+		"caveat.carrier":             "Carrier-only links have no data rate by design; they are not failing.",
+		"caveat.passes-not-schedule": "Passes are when a spacecraft is above each complex's horizon, not the DSN schedule.",
+		"caveat.doppler":             "Doppler is estimated from band and distance, not measured.",
+		"caveat.tracking-mode":       "Tracking modes are inferred from which antennas transmit and receive now. A downlink answers the uplink sent one round-trip light time earlier, so after an uplink moves the label can be wrong for that long.",
+		"caveat.pointing":            "Positions are where dishes point, so only tracked spacecraft appear.",
+		"caveat.mspa":                "Spacecraft sharing one antenna (MSPA) share its pointing.",
+		"caveat.below-horizon":       "Links below the horizon are hidden.",
+		"caveat.light-time":          "Positions are apparent: where each body was when the light now reaching Earth left it, a day ago for Voyager 1. Press a for geometric positions.",
+		"caveat.geocentric":          "Spacecraft without Horizons vectors use the Earth-centered direction, a close approximation only for distant missions.",
+		"caveat.planets-fallback":    "Without Horizons, planets are placed roughly from their orbital periods.",
+		"caveat.events-feed-time":    "Changes are found by comparing fetches, so a link that comes and goes between two fetches is missed.",
+		"caveat.events-retention":    "Only the most recent events are kept (--event-history), and only while ls-horizons runs.",
+
+		"usage":           "Horizons requests",
+		"usage.hour":      "last hour",
+		"usage.day":       "last day",
+		"usage.of":        "%d of %d",
+		"usage.unlimited": "%d, no limit",
+		"usage.refused":   "%d refused by the quota",
+		"feature.paths":   "paths",
+		"feature.passes":  "passes",
+		"feature.vectors": "vectors",
+	},
+	LangSpanish: {
+		"title":       "Acerca de estos datos: %s",
+		"sources":     "Fuentes",
+		"cadence":     "Actualización",
+		"caveats":     "Advertencias",
+		"in-use":      "En uso: efemérides %s, datos %s",
+		"feed.live":   "en vivo",
+		"feed.replay": "reproducidos (%g×)",
+		"hint":        "i/esc: cerrar | tab: siguiente vista",
+
+		"view.dashboard": "Panel",
+		"view.mission":   "Misión",
+		"view.sky":       "Cielo",
+		"view.orbit":     "Órbita",
+		"view.events":    "Eventos",
+
+		"source.dsn-now":    "Feed DSN Now",
+		"source.horizons":   "JPL Horizons",
+		"source.local-math": "Cálculo local",
+
+		"use.dashboard.feed":   "antenas, apuntamiento y viento de cada plato, objetivos, señales, tasas de datos y tiempos de luz de ida y vuelta",
+		"use.dashboard.math":   "distancia a partir del tiempo de luz, índice de dificultad y salud, carga por complejo",
+		"use.mission.feed":     "enlace de cada antena: banda, tasas de subida y bajada, tiempo de luz, modo de seguimiento",
+		"use.mission.horizons": "AR/Dec del próximo día para planes de pases y trazas de elevación",
+		"use.mission.math":     "horas de salida, culminación y puesta, separación del Sol, estimación Doppler",
+		"use.sky.feed":         "hacia dónde apunta cada antena (azimut y elevación)",
+		"use.sky.horizons":     "trayectorias en el cielo de la nave seleccionada",
+		"use.sky.math":         "conversión AR/Dec a Az/El, tiempo sidéreo, conos de visibilidad",
+		"use.orbit.horizons":   "vectores de posición de planetas y naves; estelas de ±30 días para la nave enfocada; lunas del planeta ampliado",
+		"use.orbit.feed":       "distancia de las naves a partir del tiempo de luz",
+		"use.orbit.math":       "corrección por tiempo de luz; naves sin vectores situadas en su dirección en el cielo a esa distancia",
+		"use.events.feed":      "enlaces de cada antena, comparados entre consultas",
+		"use.events.math":      "adquisiciones, traspasos, pérdidas y cambios de subida entre consultas",
+
+		"cadence.feed":       "Feed DSN consultado cada %s",
+		"cadence.passplan":   "Planes de pases en caché durante %s, una nave a la vez",
+		"cadence.elevtrace":  "Trazas de elevación en caché durante %s",
+		"cadence.planets":    "Posiciones de planetas actualizadas cada %s",
+		"cadence.spacecraft": "Posiciones de naves actualizadas cada %s",
+
+		"caveat.feed-public":         "DSN Now es un feed público de visualización: puede retrasarse y no muestra todas las sesiones.",
+		"caveat.health-heuristic":    "La salud es una heurística de distancia, tasa y elevación, no el margen medido del enlace.",
+		"caveat.carrier":             "Los enlaces de solo portadora no tienen tasa de datos por diseño; no están fallando.",
+		"caveat.passes-not-schedule": "Los pases indican cuándo una nave está sobre el horizonte de cada complejo, no la programación de la DSN.",
+		"caveat.doppler":             "El Doppler se estima a partir de la banda y la distancia; no se mide.",
+		"caveat.tracking-mode":       "Los modos de seguimiento se deducen de qué antenas transmiten y reciben ahora. Un enlace descendente responde al ascendente enviado un tiempo de luz de ida y vuelta antes, así que tras un cambio de ascendente la etiqueta puede ser errónea durante ese tiempo.",
+		"caveat.pointing":            "Las posiciones son hacia donde apuntan las antenas, así que solo aparecen naves en seguimiento.",
+		"caveat.mspa":                "Las naves que comparten antena (MSPA) comparten su apuntamiento.",
+		"caveat.below-horizon":       "Los enlaces bajo el horizonte se ocultan.",
+		"caveat.light-time":          "Las posiciones son aparentes: donde estaba cada cuerpo cuando salió la luz que ahora llega a la Tierra, hace un día para la Voyager 1. Pulsa a para ver las posiciones geométricas.",
+		"caveat.geocentric":          "Las naves sin vectores de Horizons usan la dirección geocéntrica, una buena aproximación solo para misiones lejanas.",
+		"caveat.planets-fallback":    "Sin Horizons, los planetas se sitúan de forma aproximada a partir de sus periodos orbitales.",
+		"caveat.events-feed-time":    "Los cambios se detectan comparando consultas, así que un enlace que aparece y desaparece entre dos consultas no se ve.",
+		"caveat.events-retention":    "Solo se guardan los eventos más recientes (--event-history), y solo mientras ls-horizons está en marcha.",
+
+		"usage":           "Consultas a Horizons",
+		"usage.hour":      "última hora",
+		"usage.day":       "último día",
+		"usage.of":        "%d de %d",
+		"usage.unlimited": "%d, sin límite",
+		"usage.refused":   "%d rechazadas por la cuota",
+		"feature.paths":   "trayectorias",
+		"feature.passes":  "pases",
+		"feature.vectors": "vectores",
+
+		// Error hints by dsn.ErrorCode; English comes from dsn (see errorHint)
+		"error.FEED":   "revise la red; se conservan los últimos datos y se reintenta",
+		"error.RATE":   "espere a que la cuota se recargue, o aumente --horizons-per-hour/--horizons-per-day",
+		"error.TARGET": "sin efemérides para esta nave; revise su nombre o ID NAIF",
+		"error.PARSE":  "datos malformados; suele ser pasajero, o el formato cambió (actualice ls-horizons)",
+	},
+}
+
+// tr returns the catalog text for key in lang, falling back to English
+// and then to the key itself.
+func tr(lang Language, key string) string {
+	if s, ok := aboutCatalog[lang][key]; ok {
+		return s
+	}
+	if s, ok := aboutCatalog[LangEnglish][key]; ok {
+		return s
+	}
+	return key
+}
+
+// solarConfig returns the Orbit view's refresh intervals.
+func (m Model) solarConfig() dsn.SolarSystemConfig {
+	if m.solarCache == nil {
+		return dsn.DefaultSolarSystemConfig()
+	}
+	return m.solarCache.Config()
+}
+
+// renderAbout renders the about page for the current view.
+func (m Model) renderAbout() string {
+	page := aboutPages[m.viewMode]
+	lang := m.lang
+
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("60"))
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("252"))
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(fmt.Sprintf(tr(lang, "title"), tr(lang, page.view))))
+	b.WriteString("\n\n")
+
+	b.WriteString(labelStyle.Render(tr(lang, "sources")))
+	b.WriteString("\n")
+	for _, u := range page.uses {
+		name := tr(lang, "source."+string(u.source))
+		b.WriteString("  " + valueStyle.Render(fmt.Sprintf("%-14s", name)) + " " + tr(lang, u.what))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n" + labelStyle.Render(tr(lang, "cadence")))
+	b.WriteString("\n")
+	for _, c := range page.cadence {
+		b.WriteString("  " + fmt.Sprintf(tr(lang, c.key), formatDuration(c.interval(m))))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n" + labelStyle.Render(tr(lang, "caveats")))
+	b.WriteString("\n")
+	for _, key := range page.caveats {
+		b.WriteString("  • " + tr(lang, key))
+		b.WriteString("\n")
+	}
+
+	if _, ok := m.ephemProvider.(*ephem.HorizonsProvider); ok && m.horizonsUsage != nil {
+		b.WriteString("\n" + renderHorizonsUsage(lang, m.horizonsUsage.Stats(time.Now())))
+	}
+
+	ephemeris := "none"
+	if m.ephemProvider != nil {
+		ephemeris = m.ephemProvider.Name()
+	}
+	feed := tr(lang, "feed.live")
+	if m.replay > 0 {
+		feed = fmt.Sprintf(tr(lang, "feed.replay"), m.replay)
+	}
+	b.WriteString("\n" + dimStyle.Render(fmt.Sprintf(tr(lang, "in-use"), ephemeris, feed)))
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+// renderHorizonsUsage renders the about page's Horizons requests section:
+// counts for the last hour and day against the quota, by feature.
+func renderHorizonsUsage(lang Language, s ephem.UsageStats) string {
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("252"))
+
+	line := func(window string, used, limit int, by map[ephem.Feature]int) string {
+		count := fmt.Sprintf(tr(lang, "usage.unlimited"), used)
+		if limit > 0 {
+			count = fmt.Sprintf(tr(lang, "usage.of"), used, limit)
+		}
+		var parts []string
+		for _, f := range ephem.Features {
+			parts = append(parts, fmt.Sprintf("%s %d", tr(lang, "feature."+string(f)), by[f]))
+		}
+		return "  " + valueStyle.Render(fmt.Sprintf("%-14s", tr(lang, window))) + " " + count + " (" + strings.Join(parts, ", ") + ")\n"
+	}
+
+	var b strings.Builder
+	b.WriteString(labelStyle.Render(tr(lang, "usage")) + "\n")
+	b.WriteString(line("usage.hour", s.Hour, s.Quota.PerHour, s.HourBy))
+	b.WriteString(line("usage.day", s.Day, s.Quota.PerDay, s.DayBy))
+	if s.Refused > 0 {
+		b.WriteString("  " + fmt.Sprintf(tr(lang, "usage.refused"), s.Refused) + "\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,163 @@
+package ui
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/litescript/ls-horizons/internal/ephem"
+	"github.com/litescript/ls-horizons/internal/state"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want Language
+	}{
+		{"spanish locale", map[string]string{"LANG": "es_ES.UTF-8"}, LangSpanish},
+		{"english locale", map[string]string{"LANG": "en_US.UTF-8"}, LangEnglish},
+		{"no catalog", map[string]string{"LANG": "fr_FR.UTF-8"}, LangEnglish},
+		{"LC_ALL overrides LANG", map[string]string{"LC_ALL": "C", "LANG": "es_MX.UTF-8"}, LangEnglish},
+		{"LC_MESSAGES before LANG", map[string]string{"LC_MESSAGES": "es_AR", "LANG": "en_GB"}, LangSpanish},
+		{"nothing set", map[string]string{}, LangEnglish},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DetectLanguage(func(k string) string { return tt.env[k] })
+			if got != tt.want {
+				t.Errorf("DetectLanguage = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseLanguageExplicit(t *testing.T) {
+	if got := ParseLanguage("es"); got != LangSpanish {
+		t.Errorf("ParseLanguage(es) = %q, want es", got)
+	}
+	if got := ParseLanguage("EN"); got != LangEnglish {
+		t.Errorf("ParseLanguage(EN) = %q, want en", got)
+	}
+}
+
+func TestAboutPages_Complete(t *testing.T) {
+	verbs := regexp.MustCompile(`%[a-z]`)
+
+	// Every view has a page, and every key it uses has English text
+	for view := ViewDashboard; view < viewCount; view++ {
+		page, ok := aboutPages[view]
+		if !ok {
+			t.Errorf("view %d has no about page", view)
+			continue
+		}
+		keys := []string{page.view}
+		for _, u := range page.uses {
+			keys = append(keys, u.what, "source."+string(u.source))
+		}
+		for _, c := range page.cadence {
+			keys = append(keys, c.key)
+		}
+		keys = append(keys, page.caveats...)
+		for _, key := range keys {
+			if _, ok := aboutCatalog[LangEnglish][key]; !ok {
+				t.Errorf("view %d: no English text for %q", view, key)
+			}
+		}
+	}
+
+	// Translations cover every key, with the same format verbs
+	for lang, catalog := range aboutCatalog {
+		for key, en := range aboutCatalog[LangEnglish] {
+			text, ok := catalog[key]
+			if !ok {
+				t.Errorf("%s: missing %q", lang, key)
+				continue
+			}
+			if got, want := verbs.FindAllString(text, -1), verbs.FindAllString(en, -1); strings.Join(got, "") != strings.Join(want, "") {
+				t.Errorf("%s %q: format verbs %v, want %v", lang, key, got, want)
+			}
+		}
+	}
+}
+
+func TestRenderAbout(t *testing.T) {
+	mgr := state.NewManager(state.DefaultConfig())
+	m := New(mgr, nil)
+	m.viewMode = ViewMissionDetail
+
+	out := m.renderAbout()
+	for _, want := range []string{
+		"About this data: Mission",
+		"JPL Horizons",
+		"DSN feed fetched every 5s",
+		"Pass plans cached for 5m",
+		"not the DSN schedule",
+		"In use: none ephemeris, live feed",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("about page missing %q:\n%s", want, out)
+		}
+	}
+
+	out = m.SetLanguage(LangSpanish).SetReplay(10).renderAbout()
+	for _, want := range []string{"Acerca de estos datos: Misión", "Advertencias", "reproducidos (10×)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Spanish about page missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestAboutToggle(t *testing.T) {
+	m := New(nil, nil)
+	m.ready = true
+
+	press := func(m Model, key tea.KeyMsg) Model {
+		updated, _ := m.Update(key)
+		return updated.(Model)
+	}
+
+	m = press(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("i")})
+	if !m.about || !strings.Contains(m.View(), "About this data: Dashboard") {
+		t.Fatal("i should open the dashboard about page")
+	}
+
+	// View keys are not passed through; tab moves to the next view's page
+	m = press(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if m.dashboard.showQuality {
+		t.Error("view keys should not reach the dashboard while the about page is open")
+	}
+	m = press(m, tea.KeyMsg{Type: tea.KeyTab})
+	if !strings.Contains(m.View(), "About this data: Mission") {
+		t.Error("tab should show the mission about page")
+	}
+
+	m = press(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.about {
+		t.Error("esc should close the about page")
+	}
+}
+
+func TestRenderHorizonsUsage(t *testing.T) {
+	s := ephem.UsageStats{
+		Quota:   ephem.HorizonsQuota{PerHour: 300},
+		Hour:    12,
+		Day:     40,
+		HourBy:  map[ephem.Feature]int{ephem.FeaturePasses: 9, ephem.FeaturePaths: 3},
+		DayBy:   map[ephem.Feature]int{ephem.FeaturePasses: 30, ephem.FeatureVectors: 10},
+		Refused: 2,
+	}
+	got := renderHorizonsUsage(LangEnglish, s)
+	for _, want := range []string{
+		"12 of 300 (paths 3, passes 9, vectors 0)",
+		"40, no limit (paths 0, passes 30, vectors 10)",
+		"2 refused by the quota",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("usage section missing %q:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package ui
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+)
+
+// Focus is what the user is looking at: the current view and the object
+// focused in it. Screen readers and external tools can follow it through
+// an Announcer without scraping the screen.
+type Focus struct {
+	View string `json:"view"`           // dashboard, mission, sky, orbit, or events
+	Kind string `json:"kind,omitempty"` // spacecraft, planet, or sun
+	Code string `json:"code,omitempty"` // e.g. "VGR1"
+	Name string `json:"name,omitempty"` // e.g. "Voyager 1"
+}
+
+// Announcer receives focus changes as they happen.
+type Announcer interface {
+	Announce(f Focus) error
+}
+
+// AnnounceUserVar is the terminal user variable set by OSCAnnouncer.
+const AnnounceUserVar = "ls_horizons_focus"
+
+// LineAnnouncer writes each focus change as one line of JSON, for a side
+// channel such as an inherited file descriptor or a named pipe.
+type LineAnnouncer struct {
+	w io.Writer
+}
+
+// NewLineAnnouncer returns an announcer writing JSON lines to w.
+func NewLineAnnouncer(w io.Writer) *LineAnnouncer {
+	return &LineAnnouncer{w: w}
+}
+
+// Announce implements Announcer.
+func (a *LineAnnouncer) Announce(f Focus) error {
+	return json.NewEncoder(a.w).Encode(f)
+}
+
+// OSCAnnouncer sets a terminal user variable (OSC 1337 SetUserVar, as
+// understood by iTerm2 and WezTerm) to the focus as base64 JSON, so it
+// travels in-band with the TUI output. Each sequence is one write, so it
+// isn't split by frame rendering.
+type OSCAnnouncer struct {
+	w io.Writer
+}
+
+// NewOSCAnnouncer returns an announcer writing OSC sequences to w,
+// normally the terminal.
+func NewOSCAnnouncer(w io.Writer) *OSCAnnouncer {
+	return &OSCAnnouncer{w: w}
+}
+
+// Announce implements Announcer.
+func (a *OSCAnnouncer) Announce(f Focus) error {
+	payload, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(a.w, "\x1b]1337;SetUserVar=%s=%s\x07",
+		AnnounceUserVar, base64.StdEncoding.EncodeToString(payload))
+	return err
+}
+
+// SetAnnouncer announces focus changes to a (nil disables announcements).
+func (m Model) SetAnnouncer(a Announcer) Model {
+	m.announcer = a
+	return m
+}
+
+// currentFocus returns the view and the object focused in it.
+func (m Model) currentFocus() Focus {
+	f := Focus{View: m.viewMode.String()}
+	switch m.viewMode {
+	case ViewDashboard:
+		if sv := m.dashboard.GetSelectedSpacecraft(); sv != nil {
+			f.Kind, f.Code, f.Name = dsn.BodySpacecraft.String(), sv.Code, sv.Name
+		}
+	case ViewMissionDetail:
+		id := m.missionDetail.SelectedSpacecraftID()
+		for _, sc := range m.snapshot.Spacecraft {
+			if sc.ID == id {
+				f.Kind, f.Code, f.Name = dsn.BodySpacecraft.String(), sc.Name, dsn.GetSpacecraftName(sc.Name)
+				break
+			}
+		}
+	case ViewSky:
+		if sv := m.skyView.FocusedSpacecraft(); sv != nil {
+			f.Kind, f.Code, f.Name = dsn.BodySpacecraft.String(), sv.Code, sv.Name
+		}
+	case ViewSolarSystem:
+		if body := m.solarSystem.FocusedBody(); body != nil {
+			f.Kind, f.Code, f.Name = body.Kind.String(), body.Code, body.Name
+		} else {
+			f.Kind, f.Name = dsn.BodySun.String(), "Sun"
+		}
+	}
+	return f
+}
+
+// announceFocus announces the focus if it changed since the last
+// announcement, and shares a newly focused spacecraft with other
+// instances. A failing channel is dropped rather than retried on every
+// keystroke.
+func (m Model) announceFocus() Model {
+	f := m.currentFocus()
+	if f == m.lastFocus {
+		return m
+	}
+	prev := m.lastFocus
+	m.lastFocus = f
+	m = m.shareFocus(prev, f)
+	if m.announcer == nil {
+		return m
+	}
+	if err := m.announcer.Announce(f); err != nil {
+		m.announcer = nil
+		m.statusMsg = fmt.Sprintf("Focus announcements stopped: %v", err)
+	}
+	return m
+}
+
+// RemoteFocusMsg is a spacecraft focused in another instance.
+type RemoteFocusMsg struct {
+	Code string
+}
+
+// SetFocusSharing shares spacecraft focus changes through publish, and
+// follows those arriving as RemoteFocusMsg (nil publish = off).
+func (m Model) SetFocusSharing(publish func(code string)) Model {
+	m.publishFocus = publish
+	return m
+}
+
+// shareFocus publishes f when it moves to another spacecraft. A focus
+// that arrived from another instance isn't sent back.
+func (m Model) shareFocus(prev, f Focus) Model {
+	if m.publishFocus == nil || f.Kind != dsn.BodySpacecraft.String() || f.Code == prev.Code {
+		return m
+	}
+	if f.Code == m.remoteFocus {
+		m.remoteFocus = ""
+		return m
+	}
+	m.publishFocus(f.Code)
+	return m
+}
+
+// followRemoteFocus focuses a spacecraft chosen in another instance,
+// staying in the current view. A note being typed keeps its spacecraft.
+func (m Model) followRemoteFocus(code string) Model {
+	if m.missionDetail.Editing() {
+		return m
+	}
+	m = m.focusSpacecraft(code)
+	// Remember it only when the view's focus moves to it, so the change
+	// announceFocus sees next is this one
+	if m.currentFocus().Code == code && m.lastFocus.Code != code {
+		m.remoteFocus = code
+	}
+	return m
+}
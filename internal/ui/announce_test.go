@@ -0,0 +1,173 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+	"github.com/litescript/ls-horizons/internal/state"
+)
+
+// recordingAnnouncer collects announcements, failing once err is set.
+type recordingAnnouncer struct {
+	got []Focus
+	err error
+}
+
+func (r *recordingAnnouncer) Announce(f Focus) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.got = append(r.got, f)
+	return nil
+}
+
+func TestAnnounceFocus(t *testing.T) {
+	rec := &recordingAnnouncer{}
+	mgr := state.NewManager(state.DefaultConfig())
+	m := New(mgr, nil).SetAnnouncer(rec)
+
+	send := func(msg tea.Msg) {
+		updated, _ := m.Update(msg)
+		m = updated.(Model)
+	}
+
+	data := &dsn.DSNData{Links: []dsn.Link{
+		{SpacecraftID: 32, Spacecraft: "VGR2", AntennaID: "DSS43", Complex: dsn.ComplexCanberra},
+		{SpacecraftID: 31, Spacecraft: "VGR1", AntennaID: "DSS63", Complex: dsn.ComplexMadrid},
+	}}
+	mgr.Update(data, time.Second, nil)
+	send(DataUpdateMsg{Snapshot: mgr.Snapshot()})
+	if len(rec.got) != 1 || rec.got[0].View != "dashboard" || rec.got[0].Kind != "spacecraft" {
+		t.Fatalf("first data should announce the dashboard selection, got %+v", rec.got)
+	}
+	first := rec.got[0].Code
+
+	// Moving the cursor announces the new selection
+	send(tea.KeyMsg{Type: tea.KeyDown})
+	if len(rec.got) != 2 || rec.got[1].Code == first || rec.got[1].Name == "" {
+		t.Fatalf("cursor move should announce the next spacecraft, got %+v", rec.got)
+	}
+
+	// Unchanged focus is not repeated
+	send(TickMsg{})
+	if len(rec.got) != 2 {
+		t.Errorf("tick repeated an announcement: %+v", rec.got[2:])
+	}
+
+	// Switching views announces the new view
+	send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("4")})
+	last := rec.got[len(rec.got)-1]
+	if last.View != "orbit" || last.Kind != "sun" {
+		t.Errorf("orbit view focus = %+v, want the Sun", last)
+	}
+}
+
+func TestFocusSharing(t *testing.T) {
+	var published []string
+	mgr := state.NewManager(state.DefaultConfig())
+	m := New(mgr, nil).SetFocusSharing(func(code string) { published = append(published, code) })
+
+	send := func(msg tea.Msg) {
+		updated, _ := m.Update(msg)
+		m = updated.(Model)
+	}
+
+	data := &dsn.DSNData{Links: []dsn.Link{
+		{SpacecraftID: 32, Spacecraft: "VGR2", AntennaID: "DSS43", Complex: dsn.ComplexCanberra},
+		{SpacecraftID: 31, Spacecraft: "VGR1", AntennaID: "DSS63", Complex: dsn.ComplexMadrid},
+		{SpacecraftID: -170, Spacecraft: "JWST", AntennaID: "DSS14", Complex: dsn.ComplexGoldstone},
+	}}
+	mgr.Update(data, time.Second, nil)
+	send(DataUpdateMsg{Snapshot: mgr.Snapshot()})
+	if len(published) != 1 {
+		t.Fatalf("first selection should be shared, got %v", published)
+	}
+
+	// A focus from another instance is followed but not sent back
+	target := "JWST"
+	if published[0] == target {
+		target = "VGR1"
+	}
+	send(RemoteFocusMsg{Code: target})
+	if sv := m.dashboard.GetSelectedSpacecraft(); sv == nil || sv.Code != target {
+		t.Fatalf("dashboard selection = %v, want %s", sv, target)
+	}
+	if len(published) != 1 {
+		t.Errorf("remote focus was published back: %v", published)
+	}
+
+	// Switching views keeps the spacecraft without sharing it again
+	send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("3")})
+	if f := m.currentFocus(); f.Code != target {
+		t.Errorf("sky focus = %+v, want %s", f, target)
+	}
+	if len(published) != 1 {
+		t.Errorf("view switch shared focus: %v", published)
+	}
+
+	// A local change is shared
+	send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("1")})
+	send(tea.KeyMsg{Type: tea.KeyDown})
+	if len(published) != 2 || published[1] == target {
+		t.Errorf("cursor move should share the next spacecraft, got %v", published)
+	}
+}
+
+func TestAnnounceFocus_FailureStops(t *testing.T) {
+	rec := &recordingAnnouncer{err: errors.New("broken pipe")}
+	m := New(nil, nil).SetAnnouncer(rec)
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2")})
+	m = updated.(Model)
+	if m.announcer != nil {
+		t.Error("a failing announcer should be dropped")
+	}
+	if !strings.Contains(m.statusMsg, "broken pipe") {
+		t.Errorf("statusMsg = %q, want the error", m.statusMsg)
+	}
+}
+
+func TestLineAnnouncer(t *testing.T) {
+	var buf bytes.Buffer
+	a := NewLineAnnouncer(&buf)
+	_ = a.Announce(Focus{View: "sky", Kind: "spacecraft", Code: "VGR1", Name: "Voyager 1"})
+	_ = a.Announce(Focus{View: "orbit", Kind: "sun", Name: "Sun"})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("lines = %d, want 2", len(lines))
+	}
+	if lines[0] != `{"view":"sky","kind":"spacecraft","code":"VGR1","name":"Voyager 1"}` {
+		t.Errorf("line = %s", lines[0])
+	}
+}
+
+func TestOSCAnnouncer(t *testing.T) {
+	var buf bytes.Buffer
+	want := Focus{View: "mission", Kind: "spacecraft", Code: "JWST", Name: "James Webb Space Telescope"}
+	if err := NewOSCAnnouncer(&buf).Announce(want); err != nil {
+		t.Fatal(err)
+	}
+
+	out := buf.String()
+	prefix := "\x1b]1337;SetUserVar=" + AnnounceUserVar + "="
+	if !strings.HasPrefix(out, prefix) || !strings.HasSuffix(out, "\x07") {
+		t.Fatalf("not an OSC 1337 SetUserVar sequence: %q", out)
+	}
+	payload, err := base64.StdEncoding.DecodeString(strings.TrimSuffix(strings.TrimPrefix(out, prefix), "\x07"))
+	if err != nil {
+		t.Fatalf("payload is not base64: %v", err)
+	}
+	var got Focus
+	if err := json.Unmarshal(payload, &got); err != nil || got != want {
+		t.Errorf("payload = %s (%v), want %+v", payload, err, want)
+	}
+}
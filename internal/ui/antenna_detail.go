@@ -0,0 +1,245 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+	"github.com/litescript/ls-horizons/internal/state"
+)
+
+// antennaIdle marks a fetch in which the dish tracked nothing.
+const antennaIdle = '·'
+
+// AntennaOpen reports whether the antenna detail panel is shown.
+func (m DashboardModel) AntennaOpen() bool {
+	return m.antennaID != ""
+}
+
+// openAntenna shows the dish carrying the selected spacecraft's primary
+// link.
+func (m DashboardModel) openAntenna() DashboardModel {
+	if sc := m.GetSelectedSpacecraft(); sc != nil && sc.PrimaryLink.Station != "" {
+		m.antennaID = sc.PrimaryLink.Station
+	}
+	return m
+}
+
+// stepAntenna moves the panel to the next (delta 1) or previous (-1) dish
+// in feed order, wrapping around.
+func (m DashboardModel) stepAntenna(delta int) DashboardModel {
+	ids := m.antennaIDs()
+	if len(ids) == 0 {
+		return m
+	}
+	i := slices.Index(ids, m.antennaID)
+	if i < 0 {
+		m.antennaID = ids[0]
+		return m
+	}
+	m.antennaID = ids[(i+delta+len(ids))%len(ids)]
+	return m
+}
+
+// antennaIDs returns every dish in the feed, in feed order.
+func (m DashboardModel) antennaIDs() []string {
+	var ids []string
+	if m.snapshot.Data == nil {
+		return ids
+	}
+	for _, station := range m.snapshot.Data.Stations {
+		for _, ant := range station.Antennas {
+			ids = append(ids, ant.ID)
+		}
+	}
+	return ids
+}
+
+// findAntenna returns the open dish and its station from the latest data.
+func (m DashboardModel) findAntenna() (dsn.Antenna, dsn.Station, bool) {
+	if m.snapshot.Data != nil {
+		for _, station := range m.snapshot.Data.Stations {
+			for _, ant := range station.Antennas {
+				if ant.ID == m.antennaID {
+					return ant, station, true
+				}
+			}
+		}
+	}
+	return dsn.Antenna{}, dsn.Station{}, false
+}
+
+// renderAntennaDetail renders the open dish: pointing, wind, flags,
+// targets, signals, and its activity over recent fetches.
+func (m DashboardModel) renderAntennaDetail() string {
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("60"))
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("252"))
+
+	var b strings.Builder
+	ant, station, ok := m.findAntenna()
+	if !ok {
+		b.WriteString(titleStyle.Render(m.antennaID))
+		b.WriteString("\n\n")
+		b.WriteString(dimStyle.Render("  Not in the latest feed (←/→ for other dishes)."))
+		return b.String()
+	}
+
+	ids := m.antennaIDs()
+	title := ant.ID
+	if info, ok := dsn.KnownComplexes[station.Complex]; ok {
+		title += "  " + info.Name
+	}
+	if ant.Diameter > 0 {
+		title += fmt.Sprintf(" · %.0f m", ant.Diameter)
+	}
+	b.WriteString(titleStyle.Render(title))
+	b.WriteString("  " + dimStyle.Render(fmt.Sprintf("dish %d of %d", slices.Index(ids, ant.ID)+1, len(ids))))
+	b.WriteString("\n")
+	if ant.Activity != "" {
+		b.WriteString("  " + dimStyle.Render(ant.Activity) + "\n")
+	}
+	b.WriteString("\n")
+
+	row := func(label, value string) {
+		b.WriteString("  " + labelStyle.Render(pad(label, 10)) + valueStyle.Render(value) + "\n")
+	}
+	row("Pointing", fmt.Sprintf("az %.1f°  el %.1f°", ant.Azimuth, ant.Elevation))
+	row("Wind", m.snapshot.Data.ActiveWindLimits().Format(ant.WindSpeed))
+	row("Modes", antennaFlags(ant))
+	targets := "none"
+	if len(ant.Targets) > 0 {
+		var names []string
+		for _, t := range ant.Targets {
+			names = append(names, t.Name)
+		}
+		targets = strings.Join(names, ", ")
+	}
+	row("Targets", targets)
+
+	b.WriteString("\n")
+	b.WriteString(m.renderSignals("Downlink", ant.DownSignals, "dBm"))
+	b.WriteString(m.renderSignals("Uplink", ant.UpSignals, "kW"))
+
+	b.WriteString("\n")
+	history := m.snapshot.AntennaHistory[ant.ID]
+	if width := m.width - 14; width > 0 && len(history) > width {
+		history = history[len(history)-width:]
+	}
+	if len(history) == 0 {
+		row("Activity", dimStyle.Render("no history yet"))
+	} else {
+		row("Activity", antennaSparkline(history))
+		span := fmt.Sprintf("last %d fetches since %s UTC", len(history), history[0].Timestamp.UTC().Format("15:04"))
+		b.WriteString("  " + pad("", 10) + dimStyle.Render(span+" · height: data rate · "+string(antennaIdle)+" idle") + "\n")
+	}
+	return b.String()
+}
+
+// renderSignals renders the active signals in one direction.
+func (m DashboardModel) renderSignals(label string, signals []dsn.Signal, powerUnit string) string {
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("60"))
+
+	var lines []string
+	for _, sig := range signals {
+		if !sig.Active {
+			continue
+		}
+		band := sig.Band
+		if band == "" {
+			band = "-"
+		}
+		rate := dsn.FormatDataRate(sig.DataRate)
+		if sig.SignalType == dsn.SignalCarrier {
+			rate = dsn.CarrierLockLabel
+		}
+		line := fmt.Sprintf("%s  %s  %s  %s  %s",
+			pad(sig.Spacecraft, 6), pad(band, 3), pad(rate, 12),
+			pad(formatFrequency(sig.Frequency), 12), formatPower(sig.Power, powerUnit))
+		if m.width > 0 {
+			line = truncate(line, m.width-14)
+		}
+		lines = append(lines, line)
+	}
+
+	var b strings.Builder
+	if len(lines) == 0 {
+		b.WriteString("  " + labelStyle.Render(pad(label, 10)) + dimStyle.Render("none active") + "\n")
+		return b.String()
+	}
+	for i, line := range lines {
+		if i == 0 {
+			b.WriteString("  " + labelStyle.Render(pad(label, 10)))
+		} else {
+			b.WriteString("  " + pad("", 10))
+		}
+		b.WriteString(stationStyle.Render(line) + "\n")
+	}
+	return b.String()
+}
+
+// antennaFlags lists the dish's MSPA, array, and DDOR modes.
+func antennaFlags(ant dsn.Antenna) string {
+	var flags []string
+	if ant.IsMSPA {
+		flags = append(flags, "MSPA")
+	}
+	if ant.IsArray {
+		flags = append(flags, "array")
+	}
+	if ant.IsDDOR {
+		flags = append(flags, "DDOR")
+	}
+	if len(flags) == 0 {
+		return "none"
+	}
+	return strings.Join(flags, " · ")
+}
+
+// antennaSparkline renders the dish's total data rate per fetch, scaled
+// to the highest seen. Fetches with nothing tracked show antennaIdle.
+func antennaSparkline(history []state.AntennaSample) string {
+	maxRate := 0.0
+	for _, s := range history {
+		maxRate = max(maxRate, s.DownRate+s.UpRate)
+	}
+
+	var sb strings.Builder
+	for _, s := range history {
+		if !s.Active() {
+			sb.WriteRune(antennaIdle)
+			continue
+		}
+		idx := 0
+		if maxRate > 0 {
+			idx = int((s.DownRate + s.UpRate) * float64(len(sparklineBlocks)-1) / maxRate)
+		}
+		sb.WriteRune(sparklineBlocks[idx])
+	}
+	return sb.String()
+}
+
+// formatFrequency formats a signal frequency in Hz.
+func formatFrequency(hz float64) string {
+	switch {
+	case hz <= 0:
+		return "-"
+	case hz >= 1e9:
+		return fmt.Sprintf("%.3f GHz", hz/1e9)
+	default:
+		return fmt.Sprintf("%.1f MHz", hz/1e6)
+	}
+}
+
+// formatPower formats a signal power, which the feed reports in dBm for
+// downlinks and kW for uplinks.
+func formatPower(p float64, unit string) string {
+	if p == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f %s", p, unit)
+}
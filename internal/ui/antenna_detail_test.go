@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+	"github.com/litescript/ls-horizons/internal/state"
+)
+
+// antennaData returns a feed with VGR2 on DSS43 (an MSPA dish with an
+// uplink) and JWST on DSS14, plus an idle DSS63.
+func antennaData(t0 time.Time) *dsn.DSNData {
+	return &dsn.DSNData{
+		Timestamp: t0,
+		Stations: []dsn.Station{
+			{Complex: dsn.ComplexGoldstone, Antennas: []dsn.Antenna{{
+				ID: "DSS14", Diameter: 70, Azimuth: 120, Elevation: 40,
+				Targets:     []dsn.Target{{ID: -170, Name: "JWST"}},
+				DownSignals: []dsn.Signal{{Active: true, SignalType: dsn.SignalData, DataRate: 28e6, Frequency: 25.9e9, Band: "Ka", Power: -120.5, Spacecraft: "JWST"}},
+			}}},
+			{Complex: dsn.ComplexCanberra, Antennas: []dsn.Antenna{{
+				ID: "DSS43", Diameter: 70, Azimuth: 200.5, Elevation: 55.25, WindSpeed: 12, IsMSPA: true,
+				Activity:    "Spacecraft Telemetry, Tracking, and Command",
+				Targets:     []dsn.Target{{ID: 32, Name: "VGR2"}},
+				DownSignals: []dsn.Signal{{Active: true, SignalType: dsn.SignalData, DataRate: 160, Frequency: 8.4201e9, Band: "X", Power: -155.2, Spacecraft: "VGR2"}},
+				UpSignals:   []dsn.Signal{{Active: true, SignalType: dsn.SignalData, DataRate: 16, Frequency: 7.1634e9, Band: "X", Power: 18, Spacecraft: "VGR2"}},
+			}}},
+			{Complex: dsn.ComplexMadrid, Antennas: []dsn.Antenna{{ID: "DSS63", Azimuth: 90, Elevation: 10}}},
+		},
+		Links: []dsn.Link{
+			{SpacecraftID: -170, Spacecraft: "JWST", AntennaID: "DSS14", Complex: dsn.ComplexGoldstone, Band: "Ka", DataRate: 28e6},
+			{SpacecraftID: 32, Spacecraft: "VGR2", AntennaID: "DSS43", Complex: dsn.ComplexCanberra, Band: "X", DataRate: 160},
+		},
+	}
+}
+
+func TestDashboard_AntennaDetail(t *testing.T) {
+	mgr := state.NewManager(state.DefaultConfig())
+	t0 := time.Date(2025, 12, 5, 6, 0, 0, 0, time.UTC)
+	for i := range 3 {
+		mgr.Update(antennaData(t0.Add(time.Duration(i)*time.Minute)), 0, nil)
+	}
+	m := NewDashboardModel().SetSize(120, 40).UpdateData(mgr.Snapshot())
+	key := func(k tea.KeyMsg) {
+		m, _ = m.Update(k)
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	m = m.SelectSpacecraft("VGR2")
+	key(runes("a"))
+	if m.antennaID != "DSS43" {
+		t.Fatalf("a opened %q, want VGR2's DSS43", m.antennaID)
+	}
+	out := m.View()
+	for _, want := range []string{
+		"DSS43  Canberra · 70 m", "dish 2 of 3", "Spacecraft Telemetry",
+		"az 200.5°  el 55.2°", "12 km/h", "MSPA", "VGR2",
+		"160 bps", "8.420 GHz", "-155.2 dBm", "7.163 GHz", "18.0 kW",
+		"last 3 fetches since 06:00 UTC",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("antenna detail missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Active Spacecraft") {
+		t.Error("the panel should replace the links table")
+	}
+
+	key(tea.KeyMsg{Type: tea.KeyRight})
+	if m.antennaID != "DSS63" || !strings.Contains(m.View(), "none active") {
+		t.Errorf("right: %q, want the idle DSS63", m.antennaID)
+	}
+	key(tea.KeyMsg{Type: tea.KeyRight})
+	if m.antennaID != "DSS14" {
+		t.Errorf("right should wrap to DSS14, got %q", m.antennaID)
+	}
+
+	// Moving between spacecraft follows the selection's dish
+	m = m.SelectSpacecraft("VGR2")
+	key(tea.KeyMsg{Type: tea.KeyUp})
+	key(tea.KeyMsg{Type: tea.KeyDown})
+	if sc := m.GetSelectedSpacecraft(); sc == nil || m.antennaID != sc.PrimaryLink.Station {
+		t.Errorf("panel shows %q after moving the cursor", m.antennaID)
+	}
+
+	key(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.AntennaOpen() || !strings.Contains(m.View(), "Active Spacecraft") {
+		t.Error("esc should close the panel")
+	}
+}
+
+func TestAntennaSparkline(t *testing.T) {
+	history := []state.AntennaSample{
+		{},
+		{Targets: 1},
+		{Targets: 1, DownRate: 50},
+		{Targets: 1, DownRate: 90, UpRate: 10},
+	}
+	if got, want := antennaSparkline(history), "·▁▄█"; got != want {
+		t.Errorf("antennaSparkline = %q, want %q", got, want)
+	}
+}
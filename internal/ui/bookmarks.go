@@ -0,0 +1,202 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/litescript/ls-horizons/internal/bookmarks"
+	"github.com/litescript/ls-horizons/internal/dsn"
+)
+
+// BookmarkPlayer switches the data feed to replay a bookmark's snapshot.
+type BookmarkPlayer func(b bookmarks.Bookmark) error
+
+// bookmarkSavedMsg reports the result of saving a bookmark.
+type bookmarkSavedMsg struct {
+	bookmark bookmarks.Bookmark
+	err      error
+}
+
+// bookmarkOpenedMsg reports the result of switching to a bookmark.
+type bookmarkOpenedMsg struct {
+	bookmark bookmarks.Bookmark
+	err      error
+}
+
+// SetBookmarks enables b to bookmark the current moment into store and B
+// to browse the bookmarks; play revisits one (nil disables revisiting).
+func (m Model) SetBookmarks(store *bookmarks.Store, play BookmarkPlayer) Model {
+	m.bookmarks = store
+	m.playBookmark = play
+	return m
+}
+
+// startBookmark captures the current moment and prompts for a note.
+func (m Model) startBookmark() Model {
+	if m.bookmarks == nil {
+		m.statusMsg = "Bookmarks are off"
+		return m
+	}
+	if m.snapshot.Data == nil {
+		m.statusMsg = "No data to bookmark yet"
+		return m
+	}
+	b := bookmarks.Bookmark{
+		Time:     time.Now(),
+		View:     m.viewMode.String(),
+		Snapshot: dsn.ExportSnapshot(m.snapshot.Data, m.snapshot.LastFetch),
+	}
+	if f := m.currentFocus(); f.Kind == dsn.BodySpacecraft.String() {
+		b.Spacecraft = f.Code
+	}
+	m.pendingBookmark = &b
+	m.bookmarkDraft = nil
+	return m
+}
+
+// updateBookmarkDraft handles keys while the bookmark note is typed. The
+// note is optional: enter on an empty prompt saves the bookmark as is.
+func (m Model) updateBookmarkDraft(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		b := *m.pendingBookmark
+		b.Note = string(m.bookmarkDraft)
+		m.pendingBookmark = nil
+		store := m.bookmarks
+		return m, func() tea.Msg {
+			saved, err := store.Add(b)
+			return bookmarkSavedMsg{bookmark: saved, err: err}
+		}
+	case tea.KeyEsc:
+		m.pendingBookmark = nil
+		m.statusMsg = "Bookmark cancelled"
+	case tea.KeyBackspace:
+		if len(m.bookmarkDraft) > 0 {
+			m.bookmarkDraft = m.bookmarkDraft[:len(m.bookmarkDraft)-1]
+		}
+	case tea.KeySpace:
+		m.bookmarkDraft = append(m.bookmarkDraft, ' ')
+	case tea.KeyRunes:
+		m.bookmarkDraft = append(m.bookmarkDraft, msg.Runes...)
+	}
+	return m, nil
+}
+
+// updateBrowser handles keys in the bookmark browser.
+func (m Model) updateBrowser(msg tea.KeyMsg) (Model, tea.Cmd) {
+	list := m.bookmarks.List()
+	switch msg.String() {
+	case "up", "k":
+		if m.bookmarkCursor > 0 {
+			m.bookmarkCursor--
+		}
+	case "down", "j":
+		if m.bookmarkCursor < len(list)-1 {
+			m.bookmarkCursor++
+		}
+	case "esc", "B":
+		m.browsing = false
+	case "enter":
+		if m.playBookmark == nil || m.bookmarkCursor >= len(list) {
+			break
+		}
+		m.browsing = false
+		b, play := list[m.bookmarkCursor], m.playBookmark
+		return m, func() tea.Msg {
+			return bookmarkOpenedMsg{bookmark: b, err: play(b)}
+		}
+	}
+	return m, nil
+}
+
+// openBookmark shows a bookmark being replayed: its view now, and its
+// focused spacecraft once its data arrives (see restoreBookmarkFocus).
+func (m Model) openBookmark(b bookmarks.Bookmark) Model {
+	m.viewMode = ParseViewMode(b.View)
+	m.about = false
+	if m.replay == 0 {
+		m.replay = 1
+	}
+	m.revisiting = &b
+	m.statusMsg = "Revisiting bookmark from " + b.FeedTime().UTC().Format("Jan 02 15:04 UTC")
+	if b.Note != "" {
+		m.statusMsg += ": " + b.Note
+	}
+	return m
+}
+
+// restoreBookmarkFocus focuses the revisited bookmark's spacecraft in
+// every view once the snapshot on screen is the bookmark's. Updates still
+// in flight from the previous feed are passed over.
+func (m Model) restoreBookmarkFocus() Model {
+	b := m.revisiting
+	if b == nil || m.snapshot.Data == nil || !m.snapshot.Data.Timestamp.Equal(b.FeedTime()) {
+		return m
+	}
+	m.revisiting = nil
+	if b.Spacecraft == "" {
+		return m
+	}
+	return m.focusSpacecraft(b.Spacecraft)
+}
+
+// focusSpacecraft selects the spacecraft with code in the dashboard, sky,
+// and mission views.
+func (m Model) focusSpacecraft(code string) Model {
+	m.dashboard = m.dashboard.SelectSpacecraft(code)
+	m.skyView = m.skyView.SyncFromDashboard(m.dashboard, m.followedSnapshot())
+	for _, sc := range m.snapshot.Spacecraft {
+		if sc.Name == code {
+			m.missionDetail.SetSelectedSpacecraft(sc.ID)
+			m.missionDetail = m.missionDetail.UpdateData(m.snapshot)
+			m.state.SetFocusedSpacecraft(sc.ID)
+			break
+		}
+	}
+	return m
+}
+
+// renderBookmarks renders the bookmark browser, newest first.
+func (m Model) renderBookmarks() string {
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("60"))
+	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("252"))
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Bookmarks"))
+	b.WriteString("\n\n")
+
+	list := m.bookmarks.List()
+	if len(list) == 0 {
+		b.WriteString(dimStyle.Render("  No bookmarks yet: press b to bookmark the current moment."))
+		return b.String()
+	}
+
+	// Keep the cursor on screen
+	rows := max(3, m.height-defaultHeaderLines-4)
+	start := max(0, m.bookmarkCursor-rows+1)
+	end := min(len(list), start+rows)
+	for i := start; i < end; i++ {
+		bm := list[i]
+		line := fmt.Sprintf("%s  %-9s %-6s %s", bm.FeedTime().UTC().Format("2006-01-02 15:04 UTC"),
+			bm.View, bm.Spacecraft, bm.Note)
+		if i == m.bookmarkCursor {
+			b.WriteString(selectedRowStyle.Render("> " + line))
+		} else {
+			b.WriteString("  " + valueStyle.Render(line))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// renderBookmarkPrompt renders the note prompt for a bookmark being taken.
+func (m Model) renderBookmarkPrompt() string {
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("60"))
+	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("252"))
+	return dimStyle.Render("Bookmark note (optional) > ") + valueStyle.Render(string(m.bookmarkDraft)) + "█" +
+		dimStyle.Render("  enter: save | esc: cancel")
+}
@@ -0,0 +1,126 @@
+package ui
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/litescript/ls-horizons/internal/bookmarks"
+	"github.com/litescript/ls-horizons/internal/dsn"
+	"github.com/litescript/ls-horizons/internal/state"
+)
+
+func TestBookmark_TakeAndRevisit(t *testing.T) {
+	feedTime := time.Date(2025, 12, 5, 6, 30, 0, 0, time.UTC)
+	mgr := state.NewManager(state.DefaultConfig())
+	mgr.Update(&dsn.DSNData{Timestamp: feedTime, Links: []dsn.Link{
+		{SpacecraftID: 170, Spacecraft: "JWST", AntennaID: "DSS26", Complex: dsn.ComplexGoldstone},
+		{SpacecraftID: 31, Spacecraft: "VGR1", AntennaID: "DSS63", Complex: dsn.ComplexMadrid},
+	}}, time.Second, nil)
+
+	store, err := bookmarks.Open(filepath.Join(t.TempDir(), "bookmarks.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var played []bookmarks.Bookmark
+	m := New(mgr, nil).SetBookmarks(store, func(b bookmarks.Bookmark) error {
+		played = append(played, b)
+		return nil
+	})
+	send := func(msg tea.Msg) tea.Cmd {
+		updated, cmd := m.Update(msg)
+		m = updated.(Model)
+		return cmd
+	}
+	keys := func(s string) tea.Cmd {
+		var cmd tea.Cmd
+		for _, r := range s {
+			msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+			if r == ' ' {
+				msg = tea.KeyMsg{Type: tea.KeySpace}
+			}
+			cmd = send(msg)
+		}
+		return cmd
+	}
+	run := func(cmd tea.Cmd) {
+		for _, msg := range collectMsgs(cmd) {
+			switch msg.(type) {
+			case bookmarkSavedMsg, bookmarkOpenedMsg:
+				send(msg)
+			}
+		}
+	}
+
+	send(tea.WindowSizeMsg{Width: 120, Height: 40})
+	send(DataUpdateMsg{Snapshot: mgr.Snapshot()})
+	m.dashboard = m.dashboard.SelectSpacecraft("VGR1")
+	if m.dashboard.GetSelectedSpacecraft().Code != "VGR1" {
+		t.Fatal("SelectSpacecraft did not move the cursor")
+	}
+	keys("3")
+	if m.viewMode != ViewSky {
+		t.Fatal("3 should open the sky view")
+	}
+
+	// b captures the moment; the note keys don't reach the view
+	keys("b")
+	keys("tcm burn")
+	if m.viewMode != ViewSky || m.pendingBookmark == nil {
+		t.Fatalf("note keys left the prompt: view %v", m.viewMode)
+	}
+	run(send(tea.KeyMsg{Type: tea.KeyEnter}))
+
+	list := store.List()
+	if len(list) != 1 {
+		t.Fatalf("bookmarks = %+v, want one", list)
+	}
+	b := list[0]
+	if b.View != "sky" || b.Spacecraft != "VGR1" || b.Note != "tcm burn" || !b.FeedTime().Equal(feedTime) {
+		t.Errorf("bookmark = %+v", b)
+	}
+	if !strings.Contains(m.statusMsg, "Bookmarked") {
+		t.Errorf("statusMsg = %q", m.statusMsg)
+	}
+
+	// Revisit from the dashboard: the player gets the bookmark, the view
+	// comes back, and the focus follows once the bookmark's data arrives
+	keys("1")
+	m.dashboard = m.dashboard.SelectSpacecraft("JWST")
+	keys("B")
+	if !m.browsing || !strings.Contains(m.View(), "tcm burn") {
+		t.Fatal("B should list the bookmark")
+	}
+	run(send(tea.KeyMsg{Type: tea.KeyEnter}))
+	if len(played) != 1 || m.viewMode != ViewSky || m.browsing || m.replay == 0 {
+		t.Fatalf("after enter: played %d, view %v, browsing %v, replay %v", len(played), m.viewMode, m.browsing, m.replay)
+	}
+
+	replayed := state.NewManager(state.DefaultConfig())
+	replayed.UpdateAt(dsn.ImportSnapshot(played[0].Snapshot), feedTime, 0, nil)
+	send(DataUpdateMsg{Snapshot: replayed.Snapshot()})
+	if sv := m.skyView.FocusedSpacecraft(); sv == nil || sv.Code != "VGR1" {
+		t.Errorf("sky focus = %+v, want VGR1", sv)
+	}
+	if m.missionDetail.SelectedSpacecraftID() != 31 {
+		t.Errorf("mission selection = %d, want VGR1", m.missionDetail.SelectedSpacecraftID())
+	}
+}
+
+func TestBookmark_Cancel(t *testing.T) {
+	mgr := state.NewManager(state.DefaultConfig())
+	mgr.Update(&dsn.DSNData{}, time.Second, nil)
+	store, _ := bookmarks.Open(filepath.Join(t.TempDir(), "bookmarks.jsonl"))
+	m := New(mgr, nil).SetBookmarks(store, nil)
+	updated, _ := m.Update(DataUpdateMsg{Snapshot: mgr.Snapshot()})
+	updated, _ = updated.(Model).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	updated, cmd := updated.(Model).Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+
+	if m.pendingBookmark != nil || len(collectMsgs(cmd)) != 0 || len(store.List()) != 0 {
+		t.Error("esc should drop the bookmark")
+	}
+}
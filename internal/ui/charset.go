@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"os"
+	"strings"
+)
+
+// Charset selects the glyphs used for sky paths, charts, and spinners.
+type Charset int
+
+const (
+	// CharsetBraille draws with Unicode braille (2x4 subpixel dots).
+	CharsetBraille Charset = iota
+	// CharsetASCII draws with plain ASCII density glyphs (.,:*#) for fonts
+	// and consoles that render braille poorly or not at all.
+	CharsetASCII
+)
+
+// String returns the charset name.
+func (c Charset) String() string {
+	switch c {
+	case CharsetASCII:
+		return "ascii"
+	default:
+		return "braille"
+	}
+}
+
+// ParseCharset parses a charset name. "auto" (or anything unrecognised)
+// detects support from the environment.
+func ParseCharset(s string) Charset {
+	switch s {
+	case "braille":
+		return CharsetBraille
+	case "ascii":
+		return CharsetASCII
+	default:
+		return DetectCharset(os.Getenv)
+	}
+}
+
+// DetectCharset guesses whether the terminal can show braille glyphs. The
+// Linux virtual console and legacy terminals have no braille in their
+// fonts, and a non-UTF-8 locale can't encode it at all.
+func DetectCharset(getenv func(string) string) Charset {
+	switch getenv("TERM") {
+	case "linux", "dumb", "vt100", "vt102", "vt220", "cons25", "ansi":
+		return CharsetASCII
+	}
+
+	// The first locale variable set wins, as in setlocale(3)
+	for _, key := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if v := getenv(key); v != "" {
+			v = strings.ToUpper(v)
+			if strings.Contains(v, "UTF-8") || strings.Contains(v, "UTF8") {
+				return CharsetBraille
+			}
+			return CharsetASCII
+		}
+	}
+
+	return CharsetBraille
+}
+
+// asciiDensity maps a braille dot pattern to an ASCII glyph by how many of
+// the eight dots are set. Two dots side by side read better as ',' than
+// as ':'.
+func asciiDensity(dots rune) rune {
+	n := 0
+	for b := dots; b != 0; b &= b - 1 {
+		n++
+	}
+	switch {
+	case n == 0:
+		return ' '
+	case n == 1:
+		return '.'
+	case n == 2:
+		for _, row := range brailleDots {
+			if dots == row[0]|row[1] {
+				return ','
+			}
+		}
+		return ':'
+	case n <= 4:
+		return '*'
+	default:
+		return '#'
+	}
+}
+
+// spinnerFrames returns the footer activity spinner for a charset.
+func spinnerFrames(c Charset) []string {
+	if c == CharsetASCII {
+		return []string{"|", "/", "-", "\\"}
+	}
+	return []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+}
@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestDetectCharset(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want Charset
+	}{
+		{"utf8 xterm", map[string]string{"TERM": "xterm-256color", "LANG": "en_US.UTF-8"}, CharsetBraille},
+		{"linux console", map[string]string{"TERM": "linux", "LANG": "en_US.UTF-8"}, CharsetASCII},
+		{"posix locale", map[string]string{"TERM": "xterm", "LANG": "C"}, CharsetASCII},
+		{"LC_ALL overrides LANG", map[string]string{"LC_ALL": "C", "LANG": "en_US.UTF-8"}, CharsetASCII},
+		{"lowercase utf8", map[string]string{"LC_CTYPE": "en_GB.utf8"}, CharsetBraille},
+		{"nothing set", map[string]string{}, CharsetBraille},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DetectCharset(func(k string) string { return tt.env[k] })
+			if got != tt.want {
+				t.Errorf("DetectCharset = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCharsetExplicit(t *testing.T) {
+	if got := ParseCharset("ascii"); got != CharsetASCII {
+		t.Errorf("ParseCharset(ascii) = %v, want ascii", got)
+	}
+	if got := ParseCharset("braille"); got != CharsetBraille {
+		t.Errorf("ParseCharset(braille) = %v, want braille", got)
+	}
+}
+
+func TestASCIIDensity(t *testing.T) {
+	tests := []struct {
+		dots rune
+		want rune
+	}{
+		{0, ' '},
+		{0x01, '.'},
+		{0x01 | 0x08, ','}, // side by side
+		{0x01 | 0x02, ':'}, // stacked
+		{0x01 | 0x02 | 0x04, '*'},
+		{0xFF, '#'},
+	}
+	for _, tt := range tests {
+		if got := asciiDensity(tt.dots); got != tt.want {
+			t.Errorf("asciiDensity(%#x) = %q, want %q", tt.dots, got, tt.want)
+		}
+	}
+}
+
+func TestBrailleCanvasASCIIRender(t *testing.T) {
+	const w, h = 10, 3
+	canvas := make([][]rune, h)
+	colors := make([][]lipgloss.Color, h)
+	for y := range canvas {
+		canvas[y] = []rune(strings.Repeat(" ", w))
+		colors[y] = make([]lipgloss.Color, w)
+	}
+	canvas[1][9] = '✦' // a star the path must not overwrite
+
+	bc := newBrailleCanvas(w, h, CharsetASCII)
+	drawBrailleLine(bc, 0, 1.5, 9.9, 1.5, colorPathFuture)
+	bc.render(canvas, colors)
+
+	for x, r := range canvas[1] {
+		if r >= 0x2800 && r <= 0x28FF {
+			t.Fatalf("cell %d is braille %q in ASCII mode", x, r)
+		}
+	}
+	if canvas[1][0] == ' ' {
+		t.Error("path should be drawn at the start of the line")
+	}
+	if canvas[1][9] != '✦' {
+		t.Errorf("star overwritten with %q", canvas[1][9])
+	}
+}
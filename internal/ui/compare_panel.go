@@ -0,0 +1,131 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+	"github.com/litescript/ls-horizons/internal/state"
+)
+
+// compareSideWidth is the width of each half of the comparison.
+const compareSideWidth = 30
+
+// RenderComparePanel renders a pinned snapshot beside the current one,
+// one row per spacecraft, highlighting the changes --diff would print:
+// new and lost links, handoffs, and rate changes over 50%. At most
+// maxRows spacecraft are listed.
+// Format:
+//
+//	Compare
+//	  +1 new  -1 lost  →1 handoff  Δ0 rate
+//	  Pinned 14:02:05 (12m ago)          Now 14:14:05
+//	  JWST     DSS26  28.0 Mbps       →  JWST     DSS34  28.0 Mbps
+//	  MRO      DSS14  2.00 Mbps       -
+func RenderComparePanel(pinned *state.Snapshot, current state.Snapshot, maxRows int) string {
+	var b strings.Builder
+
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("60"))
+	newStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+	lostStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	changedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("220"))
+
+	b.WriteString(titleStyle.Render("Compare"))
+	if pinned == nil || pinned.Data == nil {
+		b.WriteString("\n")
+		b.WriteString(dimStyle.Render("  No pinned snapshot: press p to pin the dashboard as it is now"))
+		b.WriteString("\n")
+		return b.String()
+	}
+	b.WriteString("\n")
+
+	diff := dsn.ComputeDiff(pinned.Data, current.Data)
+	if !diff.HasChanges() {
+		b.WriteString(dimStyle.Render("  No changes since the pin"))
+	} else {
+		b.WriteString("  " + newStyle.Render(fmt.Sprintf("+%d new", len(diff.NewLinks))) +
+			"  " + lostStyle.Render(fmt.Sprintf("-%d lost", len(diff.LostLinks))) +
+			"  " + changedStyle.Render(fmt.Sprintf("→%d handoff  Δ%d rate", len(diff.Handoffs), len(diff.RateChange))))
+	}
+	b.WriteString("\n")
+	pinnedLabel := fmt.Sprintf("Pinned %s (%s ago)", pinned.LastFetch.Format("15:04:05"),
+		formatDuration(current.LastFetch.Sub(pinned.LastFetch)))
+	b.WriteString(headerStyle.Render("  " + pad(pinnedLabel, compareSideWidth) + "     " + pad("Now "+current.LastFetch.Format("15:04:05"), compareSideWidth)))
+	b.WriteString("\n")
+
+	// Links by spacecraft, as ComputeDiff pairs them
+	before, after := linksBySpacecraft(pinned.Data), linksBySpacecraft(current.Data)
+	var codes []string
+	for code := range before {
+		codes = append(codes, code)
+	}
+	for code := range after {
+		if _, ok := before[code]; !ok {
+			codes = append(codes, code)
+		}
+	}
+	slices.Sort(codes)
+
+	handoffs := make(map[string]bool)
+	for _, h := range diff.Handoffs {
+		handoffs[h.Spacecraft] = true
+	}
+	rates := make(map[string]bool)
+	for _, r := range diff.RateChange {
+		rates[r.Spacecraft] = true
+	}
+
+	shown := codes[:min(len(codes), max(maxRows, 1))]
+	for _, code := range shown {
+		prev, hadPrev := before[code]
+		curr, hasCurr := after[code]
+
+		left, right := strings.Repeat(" ", compareSideWidth), ""
+		if hadPrev {
+			left = compareSide(prev)
+		}
+		if hasCurr {
+			right = compareSide(curr)
+		}
+
+		marker := " "
+		switch {
+		case !hadPrev:
+			marker, right = newStyle.Render("+"), newStyle.Render(right)
+		case !hasCurr:
+			marker, left = lostStyle.Render("-"), lostStyle.Render(left)
+		case handoffs[code]:
+			marker, right = changedStyle.Render("→"), changedStyle.Render(right)
+		case rates[code]:
+			marker, right = changedStyle.Render("Δ"), changedStyle.Render(right)
+		default:
+			left, right = rowStyle.Render(left), rowStyle.Render(right)
+		}
+		b.WriteString("  " + left + "  " + marker + "  " + right + "\n")
+	}
+	if n := len(codes) - len(shown); n > 0 {
+		b.WriteString(dimStyle.Render(fmt.Sprintf("  ... %d more", n)))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// linksBySpacecraft returns one link per spacecraft, the last listed, as
+// ComputeDiff compares them.
+func linksBySpacecraft(data *dsn.DSNData) map[string]dsn.Link {
+	links := make(map[string]dsn.Link)
+	if data != nil {
+		for _, l := range data.Links {
+			links[l.Spacecraft] = l
+		}
+	}
+	return links
+}
+
+// compareSide formats one link for a side of the comparison.
+func compareSide(l dsn.Link) string {
+	return pad(l.Spacecraft, 8) + " " + pad(l.AntennaID, 6) + " " + pad(dsn.FormatLinkRate(l), compareSideWidth-16)
+}
@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+	"github.com/litescript/ls-horizons/internal/state"
+)
+
+func TestRenderComparePanel(t *testing.T) {
+	at := time.Date(2025, 12, 5, 12, 0, 0, 0, time.UTC)
+	pinned := &state.Snapshot{LastFetch: at, Data: &dsn.DSNData{Links: []dsn.Link{
+		{Spacecraft: "JWST", AntennaID: "DSS26", StationID: "DSS26", DataRate: 28e6},
+		{Spacecraft: "MRO", AntennaID: "DSS14", StationID: "DSS14", DataRate: 2e6},
+		{Spacecraft: "VGR1", AntennaID: "DSS63", StationID: "DSS63", DataRate: 160},
+		{Spacecraft: "MVN", AntennaID: "DSS55", StationID: "DSS55", DataRate: 1e6},
+	}}}
+	current := state.Snapshot{LastFetch: at.Add(12 * time.Minute), Data: &dsn.DSNData{Links: []dsn.Link{
+		{Spacecraft: "JWST", AntennaID: "DSS34", StationID: "DSS34", DataRate: 28e6}, // handoff
+		{Spacecraft: "VGR1", AntennaID: "DSS63", StationID: "DSS63", DataRate: 160},  // unchanged
+		{Spacecraft: "MVN", AntennaID: "DSS55", StationID: "DSS55", DataRate: 4e6},   // rate change
+		{Spacecraft: "PSYC", AntennaID: "DSS25", StationID: "DSS25", DataRate: 1e5},  // new
+	}}}
+
+	out := RenderComparePanel(pinned, current, 20)
+	for _, want := range []string{"+1 new", "-1 lost", "→1 handoff  Δ1 rate", "Pinned 12:00:00 (12m ago)", "Now 12:12:00"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+	rows := map[string]string{}
+	for _, line := range strings.Split(out, "\n") {
+		if f := strings.Fields(line); len(f) > 0 {
+			rows[f[0]] = line
+		}
+	}
+	for code, marker := range map[string]string{"JWST": "→", "MRO": "-", "MVN": "Δ", "VGR1": ""} {
+		line := rows[code]
+		if marker != "" && !strings.Contains(line, "  "+marker+"  ") {
+			t.Errorf("%s row should be marked %q: %q", code, marker, line)
+		}
+		if marker == "" && strings.Count(line, code) != 2 {
+			t.Errorf("unchanged %s should show on both sides: %q", code, line)
+		}
+	}
+	if !strings.Contains(out, "+  PSYC") {
+		t.Errorf("new PSYC should show on the right only:\n%s", out)
+	}
+
+	if out := RenderComparePanel(pinned, current, 2); !strings.Contains(out, "... 3 more") {
+		t.Errorf("rows past the limit should be counted:\n%s", out)
+	}
+	if out := RenderComparePanel(nil, current, 20); !strings.Contains(out, "press p") {
+		t.Errorf("no pin should explain how to pin:\n%s", out)
+	}
+}
+
+func TestDashboardPinCompare(t *testing.T) {
+	key := func(r rune) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}} }
+	first := state.Snapshot{Data: &dsn.DSNData{Links: []dsn.Link{{Spacecraft: "VGR1", AntennaID: "DSS63", StationID: "DSS63"}}}}
+	m := NewDashboardModel().SetSize(120, 40).UpdateData(first)
+
+	m, _ = m.Update(key('p'))
+	m = m.UpdateData(state.Snapshot{Data: &dsn.DSNData{Links: []dsn.Link{{Spacecraft: "VGR1", AntennaID: "DSS43", StationID: "DSS43"}}}})
+	m, _ = m.Update(key('c'))
+	if !m.Comparing() {
+		t.Fatal("c should open the comparison")
+	}
+	if view := m.View(); !strings.Contains(view, "→1 handoff") {
+		t.Errorf("comparison should show the handoff since the pin:\n%s", view)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.Comparing() {
+		t.Error("esc should close the comparison")
+	}
+}
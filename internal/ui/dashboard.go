@@ -47,8 +47,36 @@ var (
 
 	stationStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#6a6a7a"))
+
+	uplinkBadgeStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(lipgloss.Color("214"))
+
+	// Rare acquisitions: the dashboard badge and event log rows
+	rareStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("220"))
+
+	// Antennas shared by several spacecraft
+	mspaStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("111"))
+
+	// Quiet complexes: the dashboard badge and event log rows
+	quietStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("209"))
 )
 
+// windGlyph marks an antenna whose wind is near or past its stow limit.
+const windGlyph = "≋"
+
+// rateLowGlyph marks a link running far below its spacecraft's usual rate.
+const rateLowGlyph = "▼"
+
+// rareBadgeWindow is how long after a rare acquisition the dashboard
+// keeps the spacecraft's RARE badge.
+const rareBadgeWindow = 12 * time.Hour
+
 // DashboardModel is the control room dashboard view.
 type DashboardModel struct {
 	width      int
@@ -57,6 +85,16 @@ type DashboardModel struct {
 	snapshot   state.Snapshot
 	spacecraft []dsn.SpacecraftView // grouped spacecraft with their links
 	lastErr    error
+
+	showQuality  bool     // Data Quality panel visible
+	showTimeline bool     // Utilization timeline panel visible
+	compact      bool     // Small-display layout (--profile small)
+	antennaID    string   // dish shown in the antenna detail panel ("" = closed)
+	charset      Charset  // glyphs for the timeline charts
+	lang         Language // language of error hints
+
+	pinned    *state.Snapshot // snapshot frozen with p, for comparison (nil = none)
+	comparing bool            // pinned and current shown side by side instead of the links table
 }
 
 // NewDashboardModel creates a new dashboard model.
@@ -76,6 +114,19 @@ func (m DashboardModel) SetSize(width, height int) DashboardModel {
 	return m
 }
 
+// SetCompact switches to the small-display layout: one summary line for
+// the complexes and a single-metric row per spacecraft.
+func (m DashboardModel) SetCompact(on bool) DashboardModel {
+	m.compact = on
+	return m
+}
+
+// SetCharset selects braille or ASCII glyphs for the timeline charts.
+func (m DashboardModel) SetCharset(c Charset) DashboardModel {
+	m.charset = c
+	return m
+}
+
 // UpdateData updates the model with new data.
 func (m DashboardModel) UpdateData(snapshot state.Snapshot) DashboardModel {
 	m.snapshot = snapshot
@@ -83,6 +134,7 @@ func (m DashboardModel) UpdateData(snapshot state.Snapshot) DashboardModel {
 	// Build spacecraft views (grouped, filtered)
 	elevMap := dsn.BuildElevationMap(snapshot.Data)
 	m.spacecraft = dsn.BuildSpacecraftViews(snapshot.Data, elevMap)
+	applyRateBaselines(m.spacecraft, snapshot.RateBaselines)
 
 	// Clamp cursor to valid range
 	if m.cursor >= len(m.spacecraft) {
@@ -92,6 +144,12 @@ func (m DashboardModel) UpdateData(snapshot state.Snapshot) DashboardModel {
 	return m
 }
 
+// SetLanguage selects the language of error hints.
+func (m DashboardModel) SetLanguage(lang Language) DashboardModel {
+	m.lang = lang
+	return m
+}
+
 // SetError sets the last error for display.
 func (m DashboardModel) SetError(err error) DashboardModel {
 	m.lastErr = err
@@ -104,6 +162,21 @@ func (m DashboardModel) Update(msg tea.Msg) (DashboardModel, tea.Cmd) {
 	case tea.KeyMsg:
 		scCount := len(m.spacecraft)
 
+		// The antenna panel steps through dishes; moving between
+		// spacecraft shows the new selection's dish
+		if m.AntennaOpen() {
+			switch msg.String() {
+			case "left", "h":
+				return m.stepAntenna(-1), nil
+			case "right", "l":
+				return m.stepAntenna(1), nil
+			case "a", "esc":
+				m.antennaID = ""
+				return m, nil
+			}
+		}
+		prevCursor := m.cursor
+
 		switch msg.String() {
 		case "up", "k":
 			if m.cursor > 0 {
@@ -119,6 +192,21 @@ func (m DashboardModel) Update(msg tea.Msg) (DashboardModel, tea.Cmd) {
 			if scCount > 0 {
 				m.cursor = scCount - 1
 			}
+		case "x":
+			m.showQuality = !m.showQuality
+		case "t":
+			m.showTimeline = !m.showTimeline
+		case "a":
+			m = m.openAntenna()
+		case "p":
+			if m.snapshot.Data != nil {
+				pinned := m.snapshot
+				m.pinned = &pinned
+			}
+		case "c":
+			m.comparing = !m.comparing
+		case "esc":
+			m.comparing = false
 		case "enter":
 			// Open Mission view for selected spacecraft
 			if sc := m.GetSelectedSpacecraft(); sc != nil {
@@ -127,6 +215,9 @@ func (m DashboardModel) Update(msg tea.Msg) (DashboardModel, tea.Cmd) {
 				}
 			}
 		}
+		if m.AntennaOpen() && m.cursor != prevCursor {
+			m = m.openAntenna()
+		}
 	}
 
 	return m, nil
@@ -138,7 +229,7 @@ func (m DashboardModel) View() string {
 
 	// Show error state if present
 	if m.lastErr != nil {
-		b.WriteString(errorStyle.Render("Error: " + m.lastErr.Error()))
+		b.WriteString(errorStyle.Render("Error: " + errorText(m.lang, m.lastErr)))
 		b.WriteString("\n\n")
 	}
 
@@ -148,12 +239,55 @@ func (m DashboardModel) View() string {
 		return b.String()
 	}
 
+	if m.compact {
+		b.WriteString(m.renderCompactSummary())
+		b.WriteString("\n")
+		if m.showQuality {
+			b.WriteString(RenderQualityPanel(m.snapshot.Quality, m.snapshot.QualityHistory))
+			b.WriteString("\n")
+		}
+		if m.showTimeline {
+			b.WriteString(m.renderTimeline())
+			b.WriteString("\n")
+		}
+		switch {
+		case m.AntennaOpen():
+			b.WriteString(m.renderAntennaDetail())
+		case m.comparing:
+			b.WriteString(m.renderCompare())
+		default:
+			b.WriteString(m.renderCompactTable())
+		}
+		return b.String()
+	}
+
 	// Complex load summary
 	b.WriteString(m.renderComplexSummary())
 	b.WriteString("\n\n")
 
-	// Active links table
-	b.WriteString(m.renderLinksTable())
+	// Data quality panel (toggled with x)
+	if m.showQuality {
+		b.WriteString(RenderQualityPanel(m.snapshot.Quality, m.snapshot.QualityHistory))
+		b.WriteString("\n")
+	}
+
+	// Utilization timeline (toggled with t)
+	if m.showTimeline {
+		b.WriteString(m.renderTimeline())
+		b.WriteString("\n")
+	}
+
+	// Active links table, the drill-down into one dish (a), or the
+	// comparison with the pinned snapshot (c)
+	switch {
+	case m.AntennaOpen():
+		b.WriteString(m.renderAntennaDetail())
+	case m.comparing:
+		b.WriteString(m.renderCompare())
+	default:
+		b.WriteString(m.renderLinksTable())
+		b.WriteString(m.renderMSPAGroups())
+	}
 
 	return b.String()
 }
@@ -166,11 +300,13 @@ const (
 	glyphUp       = "▲"
 	glyphDown     = "▽"
 	glyphShifting = "◆"
+	glyphQuiet    = "◌"
 
 	labelStable   = "stable"
 	labelUp       = "up"
 	labelDown     = "down"
 	labelShifting = "shifting"
+	labelQuiet    = "quiet"
 )
 
 func (m DashboardModel) renderComplexSummary() string {
@@ -191,6 +327,10 @@ func (m DashboardModel) renderComplexSummary() string {
 		name := fmt.Sprintf("%-10s", info.Name)
 		statusLine := complexNameStyle.Render(name) + "  " +
 			statusGlyphStyle.Render(glyph+" "+label)
+		if since, quiet := m.snapshot.QuietComplexes[c]; quiet {
+			// Format: "Madrid      ◌ quiet  QUIET 1h 35m"
+			statusLine += "  " + quietStyle.Render("QUIET "+formatDuration(m.snapshot.LastFetch.Sub(since)))
+		}
 		b.WriteString("  " + statusLine + "\n")
 
 		// Format: "    → JWST@DSS26, MRO@DSS36"
@@ -203,9 +343,20 @@ func (m DashboardModel) renderComplexSummary() string {
 
 // classifyComplexStatus determines the status glyph and label for a complex
 // based on recent events within the lookback window.
-// Priority: shifting (HANDOFF) > down (LINK_LOST) > up (NEW_LINK/LINK_RESUMED) > stable
+// Priority: quiet (COMPLEX_QUIET) > shifting (HANDOFF) > down (LINK_LOST) >
+// up (NEW_LINK/LINK_RESUMED) > stable
 func (m DashboardModel) classifyComplexStatus(c dsn.Complex) (glyph, label string) {
-	cutoff := time.Now().Add(-statusLookbackWindow)
+	if _, quiet := m.snapshot.QuietComplexes[c]; quiet {
+		return glyphQuiet, labelQuiet
+	}
+
+	// Events carry feed time, so look back from the feed's clock; this
+	// keeps replays of old recordings consistent
+	ref := time.Now()
+	if m.snapshot.Data != nil && !m.snapshot.Data.Timestamp.IsZero() {
+		ref = m.snapshot.Data.Timestamp
+	}
+	cutoff := ref.Add(-statusLookbackWindow)
 	complexID := string(c)
 
 	hasHandoff := false
@@ -362,20 +513,24 @@ func (m DashboardModel) renderUtilizationBar(util float64, width int) string {
 const (
 	colAntenna  = 7
 	colBand     = 4
-	colRate     = 10
+	colDown     = 14
+	colUp       = 11
 	colDistance = 11
+	colSNR      = 9
 	colStruggle = 8
 )
 
 // renderColumnHeader renders the column labels for the antenna detail rows.
 func (m DashboardModel) renderColumnHeader() string {
 	// Align with bullet rows: "  • " prefix (4 chars) then columns
-	line := fmt.Sprintf("    %s  %s  %s  %s  %s",
+	line := fmt.Sprintf("    %s  %s  %s  %s  %s  %s  %s",
 		pad("Station", colAntenna),
 		pad("Band", colBand),
-		pad("Rate", colRate),
+		pad("Down", colDown),
+		pad("Up", colUp),
+		pad("SNR", colSNR),
 		pad("Distance", colDistance),
-		"Struggle",
+		"Struggle ("+m.snapshot.Data.ActiveHealthModel().Name+")",
 	)
 	return headerStyle.Render(line)
 }
@@ -453,10 +608,31 @@ func (m DashboardModel) renderSpacecraftHeader(sc dsn.SpacecraftView, selected b
 		line = sc.Code
 	}
 
+	// Commanding windows stand out with a badge after the name
+	badge := ""
+	if sc.Uplinking() {
+		badge = " " + uplinkBadgeStyle.Render(dsn.UplinkBadge+" UPLINK")
+	}
+	if m.rareAcquisition(sc.Code) {
+		badge += " " + rareStyle.Render("★ RARE")
+	}
+
 	if selected {
-		return selectedRowStyle.Render("▶ " + line)
+		return selectedRowStyle.Render("▶ "+line) + badge
+	}
+	return missionStyle.Render("  "+line) + badge
+}
+
+// rareAcquisition reports whether the spacecraft was acquired after a long
+// absence within rareBadgeWindow of the latest fetch.
+func (m DashboardModel) rareAcquisition(code string) bool {
+	cutoff := m.snapshot.LastFetch.Add(-rareBadgeWindow)
+	for _, e := range m.snapshot.Events {
+		if e.Type == state.EventRareAcquisition && strings.EqualFold(e.Spacecraft, code) && e.Timestamp.After(cutoff) {
+			return true
+		}
 	}
-	return missionStyle.Render("  " + line)
+	return false
 }
 
 // renderLinkDetail renders a single antenna link line.
@@ -466,14 +642,40 @@ func (m DashboardModel) renderLinkDetail(link dsn.LinkView, selected bool) strin
 		band = "-"
 	}
 
-	// Format: "  • DSS34   X   344 bps   21.3 B km   ▃▃▃▃▃"
-	line := fmt.Sprintf("  • %s  %s  %s  %s  %s",
+	// Format: "  • DSS34   X   ↓ 344 bps   ↑ 16.0 bps  30 dB-Hz  21.3 B km   ▃▃▃▃▃"
+	// Down and up are separate so a dish receiving, commanding, or both
+	// reads at a glance
+	// Carrier locks get a hollow glyph: no data, but nothing wrong
+	glyph := "•"
+	if link.Carrier {
+		glyph = "◦"
+	}
+	line := fmt.Sprintf("  %s %s  %s  %s  %s  %s  %s  %s",
+		glyph,
 		pad(link.Station, colAntenna),
 		pad(band, colBand),
-		pad(dsn.FormatDataRate(link.Rate), colRate),
+		pad(link.FormatDown(), colDown),
+		pad(link.FormatUp(), colUp),
+		pad(link.FormatSNR(), colSNR),
 		pad(dsn.FormatDistance(link.DistanceKm), colDistance),
 		m.renderStruggleBar(link.Struggle),
 	)
+	if link.MSPA {
+		// "  MSPA 80% +MVN": the dish is shared, and with whom
+		line += fmt.Sprintf("  %s %.0f%% +%s", dsn.MSPABadge, link.Share*100, strings.Join(link.SharedWith, "+"))
+	}
+	if link.Inferred {
+		// "  ? inferred": the feed's target and signals didn't match up
+		line += "  ? " + dsn.InferredLabel
+	}
+	if link.WindRisk != dsn.WindCalm {
+		// "  ≋ 58 km/h (near stow)": the pass may end early
+		line += "  " + windGlyph + " " + m.snapshot.Data.ActiveWindLimits().Format(link.Wind)
+	}
+	if shortfall := link.RateShortfall(); shortfall >= dsn.RateShortfallFlag {
+		// "  ▼ rate 80% below normal (28.0 Mbps)"
+		line += fmt.Sprintf("  %s %s (%s)", rateLowGlyph, dsn.FormatRateShortfall(shortfall), dsn.FormatDataRate(link.Baseline))
+	}
 
 	if selected {
 		// Slightly dimmer than header but still highlighted
@@ -483,6 +685,58 @@ func (m DashboardModel) renderLinkDetail(link dsn.LinkView, selected bool) strin
 	return stationStyle.Render(line)
 }
 
+// applyRateBaselines replaces the bundled rate baselines in views with the
+// state manager's, which include those learned from the sighting log.
+func applyRateBaselines(views []dsn.SpacecraftView, baselines map[dsn.RateBand]float64) {
+	for i := range views {
+		sv := &views[i]
+		apply := func(lv *dsn.LinkView) {
+			if bps, ok := baselines[dsn.NewRateBand(sv.Code, lv.Band)]; ok {
+				lv.Baseline = bps
+			}
+		}
+		for j := range sv.Links {
+			apply(&sv.Links[j])
+		}
+		apply(&sv.PrimaryLink)
+	}
+}
+
+// renderMSPAGroups lists the antennas tracking several spacecraft at once,
+// with each spacecraft's part of the dish's combined rate:
+// "  DSS54  Madrid  MSPA  2.50 Mbps: MRO 80% · MVN 20%". Empty when no
+// antenna is shared.
+func (m DashboardModel) renderMSPAGroups() string {
+	groups := dsn.MSPAGroups(m.snapshot.Data)
+	if len(groups) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("\n")
+	b.WriteString(titleStyle.Render("Shared Antennas"))
+	b.WriteString("\n")
+	for _, g := range groups {
+		var parts []string
+		for _, l := range g.Links {
+			parts = append(parts, fmt.Sprintf("%s %.0f%%", l.Spacecraft, g.Share(l)*100))
+		}
+		line := fmt.Sprintf("  %s  %s  %s  %s: %s",
+			pad(g.AntennaID, colAntenna),
+			pad(dsn.KnownComplexes[g.Complex].Name, 10),
+			dsn.MSPABadge,
+			dsn.FormatDataRate(g.TotalRate()),
+			strings.Join(parts, " · "),
+		)
+		if m.width > 0 {
+			line = truncate(line, m.width)
+		}
+		b.WriteString(mspaStyle.Render(line))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
 func (m DashboardModel) buildElevationMap() map[string]float64 {
 	elevMap := make(map[string]float64)
 	if m.snapshot.Data == nil {
@@ -517,6 +771,93 @@ func (m DashboardModel) renderStruggleBar(struggle float64) string {
 	return filledPart + emptyPart
 }
 
+// renderCompactSummary renders all three complexes on one line:
+// "GDS ◎2 CDS ▲3 MDS ◎1" (status glyph and active spacecraft links).
+func (m DashboardModel) renderCompactSummary() string {
+	counts := make(map[dsn.Complex]int)
+	if m.snapshot.Data != nil {
+		for _, link := range m.snapshot.Data.Links {
+			if dsn.IsRealSpacecraft(link.Spacecraft) {
+				counts[link.Complex]++
+			}
+		}
+	}
+
+	var parts []string
+	for _, c := range []dsn.Complex{dsn.ComplexGoldstone, dsn.ComplexCanberra, dsn.ComplexMadrid} {
+		glyph, _ := m.classifyComplexStatus(c)
+		parts = append(parts, complexNameStyle.Render(dsn.ComplexShortName(c))+" "+
+			statusGlyphStyle.Render(fmt.Sprintf("%s%d", glyph, counts[c])))
+	}
+	return strings.Join(parts, " ")
+}
+
+// renderCompactTable renders one row per spacecraft with a single metric,
+// the primary link's data rate: "▶ VGR2   DSS43  160 b/s".
+func (m DashboardModel) renderCompactTable() string {
+	var b strings.Builder
+
+	if len(m.spacecraft) == 0 {
+		b.WriteString("No active spacecraft\n")
+		return b.String()
+	}
+
+	maxRows := m.height - 3
+	if maxRows < 3 {
+		maxRows = 3
+	}
+
+	startIdx := 0
+	if m.cursor >= maxRows {
+		startIdx = m.cursor - maxRows + 1
+	}
+	endIdx := min(startIdx+maxRows, len(m.spacecraft))
+
+	for i := startIdx; i < endIdx; i++ {
+		sc := m.spacecraft[i]
+		line := fmt.Sprintf("%s %s %s",
+			pad(sc.Code, 6),
+			pad(sc.PrimaryLink.Station, 6),
+			sc.PrimaryLink.FormatRate(),
+		)
+		if i == m.cursor {
+			b.WriteString(selectedRowStyle.Render("▶ " + line))
+		} else {
+			b.WriteString(missionStyle.Render("  " + line))
+		}
+		b.WriteString("\n")
+	}
+
+	if len(m.spacecraft) > maxRows {
+		dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("60"))
+		b.WriteString(dimStyle.Render(fmt.Sprintf("%d-%d/%d", startIdx+1, endIdx, len(m.spacecraft))))
+	}
+
+	return b.String()
+}
+
+// renderTimeline renders the utilization timeline up to the latest fetch,
+// so replays chart the recorded hours rather than the wall clock's.
+func (m DashboardModel) renderTimeline() string {
+	return RenderTimelinePanel(m.snapshot.Timeline, m.snapshot.TimelineWindow, m.snapshot.LastFetch, m.width, m.charset)
+}
+
+// renderCompare renders the pinned snapshot beside the current one, as
+// many spacecraft as fit.
+func (m DashboardModel) renderCompare() string {
+	return RenderComparePanel(m.pinned, m.snapshot, m.height-4)
+}
+
+// Comparing reports whether the pinned snapshot comparison is shown.
+func (m DashboardModel) Comparing() bool {
+	return m.comparing
+}
+
+// ShowQuality returns whether the Data Quality panel is visible.
+func (m DashboardModel) ShowQuality() bool {
+	return m.showQuality
+}
+
 // GetSelectedSpacecraft returns the currently selected spacecraft, if any.
 func (m DashboardModel) GetSelectedSpacecraft() *dsn.SpacecraftView {
 	if len(m.spacecraft) == 0 {
@@ -528,23 +869,25 @@ func (m DashboardModel) GetSelectedSpacecraft() *dsn.SpacecraftView {
 	return &m.spacecraft[m.cursor]
 }
 
-func truncate(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
-	}
-	if maxLen <= 3 {
-		return s[:maxLen]
+// SelectSpacecraft moves the cursor to the spacecraft with the given DSN
+// code, if it is listed.
+func (m DashboardModel) SelectSpacecraft(code string) DashboardModel {
+	for i, sv := range m.spacecraft {
+		if sv.Code == code {
+			m.cursor = i
+			break
+		}
 	}
-	return s[:maxLen-3] + "..."
+	return m
 }
 
-// pad truncates or pads a string to exactly the given width.
+// truncate shortens s to at most maxLen terminal cells.
+func truncate(s string, maxLen int) string {
+	return dsn.TruncateWidth(s, maxLen, "...")
+}
+
+// pad truncates or pads a string to exactly the given width in terminal
+// cells, so wide characters in names keep columns aligned.
 func pad(s string, width int) string {
-	if len(s) > width {
-		if width <= 3 {
-			return s[:width]
-		}
-		return s[:width-3] + "..."
-	}
-	return s + strings.Repeat(" ", width-len(s))
+	return dsn.PadWidth(s, width, "...")
 }
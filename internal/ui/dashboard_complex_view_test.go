@@ -310,3 +310,32 @@ func TestComplexStatus_Alignment(t *testing.T) {
 		}
 	}
 }
+
+func TestComplexStatus_Quiet(t *testing.T) {
+	now := time.Date(2025, 12, 5, 12, 0, 0, 0, time.UTC)
+	m := DashboardModel{
+		snapshot: state.Snapshot{
+			Data:           &dsn.DSNData{Timestamp: now},
+			LastFetch:      now,
+			QuietComplexes: map[dsn.Complex]time.Time{dsn.ComplexMadrid: now.Add(-95 * time.Minute)},
+			// A quiet complex outranks its recent link events
+			Events: []state.Event{{Type: state.EventLinkLost, Timestamp: now.Add(-time.Minute), Complex: "mdscc"}},
+		},
+	}
+
+	if glyph, label := m.classifyComplexStatus(dsn.ComplexMadrid); glyph != glyphQuiet || label != labelQuiet {
+		t.Errorf("Madrid = %s %s, want quiet", glyph, label)
+	}
+	if glyph, _ := m.classifyComplexStatus(dsn.ComplexGoldstone); glyph != glyphStable {
+		t.Errorf("Goldstone = %s, want stable", glyph)
+	}
+
+	for _, line := range strings.Split(m.renderComplexSummary(), "\n") {
+		if strings.Contains(line, "Madrid") && !strings.Contains(line, "QUIET 1h 35m") {
+			t.Errorf("Madrid line = %q, want quiet badge", line)
+		}
+		if strings.Contains(line, "Goldstone") && strings.Contains(line, "QUIET") {
+			t.Errorf("Goldstone line = %q, shouldn't be quiet", line)
+		}
+	}
+}
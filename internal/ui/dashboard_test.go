@@ -3,8 +3,10 @@ package ui
 import (
 	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 
 	"github.com/litescript/ls-horizons/internal/dsn"
 	"github.com/litescript/ls-horizons/internal/state"
@@ -163,3 +165,208 @@ func TestDashboardNavigationAndEnter(t *testing.T) {
 		t.Errorf("spacecraft ID = %d, want 300", openMsg.SpacecraftID)
 	}
 }
+
+func TestDashboardQualityPanelToggle(t *testing.T) {
+	m := NewDashboardModel()
+	now := time.Now()
+	snap := state.Snapshot{
+		Data: &dsn.DSNData{Timestamp: now},
+		Quality: dsn.QualityReport{
+			AssessedAt:  now,
+			FeedLatency: 2 * time.Second,
+			Issues: []dsn.QualityIssue{
+				{Category: dsn.QualityImpossibleValue, Subject: "DSS14", Detail: "azimuth 400.0° outside 0-360"},
+			},
+		},
+	}
+	m = m.UpdateData(snap)
+
+	if strings.Contains(m.View(), "Data Quality") {
+		t.Error("Data Quality panel should be hidden by default")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if !m.ShowQuality() {
+		t.Fatal("x should toggle Data Quality panel on")
+	}
+
+	view := m.View()
+	for _, want := range []string{"Data Quality", "Impossible values", "DSS14"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+}
+
+func TestRenderQualityPanelEmpty(t *testing.T) {
+	out := RenderQualityPanel(dsn.QualityReport{}, nil)
+	if !strings.Contains(out, "No fetches assessed yet") {
+		t.Errorf("unexpected empty panel: %q", out)
+	}
+}
+
+func TestDashboardCompactFitsNarrowDisplay(t *testing.T) {
+	m := NewDashboardModel().SetCompact(true).SetSize(40, 20)
+	m.snapshot = state.Snapshot{Data: &dsn.DSNData{Timestamp: time.Now()}}
+	m.spacecraft = []dsn.SpacecraftView{
+		{ID: 100, Code: "VGR1", Name: "Voyager 1", PrimaryLink: dsn.LinkView{Station: "DSS43", Rate: 160}},
+		{ID: 200, Code: "JWST", Name: "James Webb Space Telescope", PrimaryLink: dsn.LinkView{Station: "DSS26", Rate: 28e6}},
+	}
+
+	view := m.View()
+	for _, unwanted := range []string{"Goldstone", "James Webb", "Struggle"} {
+		if strings.Contains(view, unwanted) {
+			t.Errorf("compact view should not contain %q", unwanted)
+		}
+	}
+	for _, want := range []string{"GDS", "VGR1", "DSS43"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("compact view missing %q", want)
+		}
+	}
+	for _, line := range strings.Split(view, "\n") {
+		if w := lipgloss.Width(line); w > 40 {
+			t.Errorf("line width = %d, want <= 40: %q", w, line)
+		}
+	}
+}
+
+func TestRenderSpacecraftHeader_Uplink(t *testing.T) {
+	m := DashboardModel{}
+	sc := dsn.SpacecraftView{Code: "VGR2", Name: "Voyager 2", Links: []dsn.LinkView{{Station: "DSS43"}}}
+
+	if got := m.renderSpacecraftHeader(sc, false); strings.Contains(got, dsn.UplinkBadge) {
+		t.Errorf("header without uplink = %q, want no badge", got)
+	}
+
+	sc.Links = append(sc.Links, dsn.LinkView{Station: "DSS35", Uplink: true})
+	for _, selected := range []bool{false, true} {
+		if got := m.renderSpacecraftHeader(sc, selected); !strings.Contains(got, dsn.UplinkBadge+" UPLINK") {
+			t.Errorf("header (selected=%v) = %q, want uplink badge", selected, got)
+		}
+	}
+}
+
+func TestRenderSpacecraftHeader_Rare(t *testing.T) {
+	now := time.Date(2025, 12, 5, 12, 0, 0, 0, time.UTC)
+	sc := dsn.SpacecraftView{Code: "NHPC", Name: "New Horizons"}
+	m := DashboardModel{snapshot: state.Snapshot{
+		LastFetch: now,
+		Events:    []state.Event{{Type: state.EventRareAcquisition, Spacecraft: "NHPC", Timestamp: now.Add(-time.Hour)}},
+	}}
+	if got := m.renderSpacecraftHeader(sc, false); !strings.Contains(got, "★ RARE") {
+		t.Errorf("header = %q, want rare badge", got)
+	}
+
+	m.snapshot.LastFetch = now.Add(rareBadgeWindow)
+	if got := m.renderSpacecraftHeader(sc, false); strings.Contains(got, "RARE") {
+		t.Errorf("header = %q, badge should expire", got)
+	}
+}
+
+func TestRenderLinkDetail_CarrierLock(t *testing.T) {
+	m := DashboardModel{}
+
+	carrier := m.renderLinkDetail(dsn.LinkView{Station: "DSS43", Band: "X", Carrier: true}, false)
+	if !strings.Contains(carrier, "◦") || !strings.Contains(carrier, dsn.CarrierLockLabel) {
+		t.Errorf("carrier lock row = %q, want ◦ glyph and %q", carrier, dsn.CarrierLockLabel)
+	}
+
+	data := m.renderLinkDetail(dsn.LinkView{Station: "DSS43", Band: "X", Rate: 160}, false)
+	if !strings.Contains(data, "•") || strings.Contains(data, dsn.CarrierLockLabel) {
+		t.Errorf("data row = %q, want • glyph and a rate", data)
+	}
+}
+
+func TestWatchlistToggle(t *testing.T) {
+	mgr := state.NewManager(state.DefaultConfig())
+	mgr.Update(&dsn.DSNData{Links: []dsn.Link{
+		{SpacecraftID: 31, Spacecraft: "VGR1", AntennaID: "DSS63", Complex: dsn.ComplexMadrid},
+		{SpacecraftID: 170, Spacecraft: "JWST", AntennaID: "DSS26", Complex: dsn.ComplexGoldstone},
+	}}, time.Second, nil)
+
+	m := New(mgr, nil).SetWatchlist(dsn.ParseWatchlist("VGR1"))
+	send := func(msg tea.Msg) {
+		updated, _ := m.Update(msg)
+		m = updated.(Model)
+	}
+	codes := func() []string {
+		var out []string
+		for _, sv := range m.dashboard.spacecraft {
+			out = append(out, sv.Code)
+		}
+		return out
+	}
+
+	send(DataUpdateMsg{Snapshot: mgr.Snapshot()})
+	if got := codes(); len(got) != 1 || got[0] != "VGR1" {
+		t.Fatalf("following: dashboard = %v, want only VGR1", got)
+	}
+	if len(m.missionDetail.snapshot.Spacecraft) != 2 {
+		t.Error("the mission view should keep every spacecraft")
+	}
+
+	send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	if got := codes(); len(got) != 2 {
+		t.Errorf("w should show everything, dashboard = %v", got)
+	}
+	send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	if got := codes(); len(got) != 1 {
+		t.Errorf("w again should follow the watchlist, dashboard = %v", got)
+	}
+}
+
+func TestRenderLinkDetail_Directions(t *testing.T) {
+	m := DashboardModel{}
+
+	both := m.renderLinkDetail(dsn.LinkView{Station: "DSS43", Band: "X", Downlink: true, DownRate: 160, Uplink: true, UpRate: 16}, false)
+	if !strings.Contains(both, "↓ 160 bps") || !strings.Contains(both, "↑ 16.0 bps") {
+		t.Errorf("row = %q, want down and up rates", both)
+	}
+
+	down := m.renderLinkDetail(dsn.LinkView{Station: "DSS14", Band: "X", Downlink: true, DownRate: 2e6}, false)
+	if !strings.Contains(down, "↓ ") || strings.Contains(down, "↑") {
+		t.Errorf("row = %q, want downlink only", down)
+	}
+}
+
+func TestDashboard_MSPA(t *testing.T) {
+	data := &dsn.DSNData{Links: []dsn.Link{
+		{Complex: dsn.ComplexMadrid, AntennaID: "DSS54", Spacecraft: "MRO", SpacecraftID: 74, DataRate: 2e6},
+		{Complex: dsn.ComplexMadrid, AntennaID: "DSS54", Spacecraft: "MVN", SpacecraftID: 202, DataRate: 5e5},
+		{Complex: dsn.ComplexMadrid, AntennaID: "DSS63", Spacecraft: "VGR1", SpacecraftID: 31, DataRate: 160},
+	}}
+	m := DashboardModel{snapshot: state.Snapshot{Data: data}}
+
+	groups := m.renderMSPAGroups()
+	if !strings.Contains(groups, "DSS54") || !strings.Contains(groups, "MRO 80% · MVN 20%") || strings.Contains(groups, "DSS63") {
+		t.Errorf("groups = %q, want DSS54 shared by MRO and MVN", groups)
+	}
+
+	row := m.renderLinkDetail(dsn.LinkView{Station: "DSS54", MSPA: true, Share: 0.2, SharedWith: []string{"MRO"}}, false)
+	if !strings.Contains(row, "MSPA 20% +MRO") {
+		t.Errorf("row = %q, want MSPA badge", row)
+	}
+	if got := (DashboardModel{snapshot: state.Snapshot{Data: &dsn.DSNData{}}}).renderMSPAGroups(); got != "" {
+		t.Errorf("no MSPA antennas rendered %q", got)
+	}
+}
+
+func TestDashboard_RateBelowBaseline(t *testing.T) {
+	data := &dsn.DSNData{Links: []dsn.Link{
+		{Spacecraft: "JNO", SpacecraftID: 61, AntennaID: "DSS14", Complex: dsn.ComplexGoldstone, Band: "X", DataRate: 2e3},
+	}}
+	m := NewDashboardModel().UpdateData(state.Snapshot{
+		Data:          data,
+		RateBaselines: map[dsn.RateBand]float64{dsn.NewRateBand("JNO", "X"): 1e4},
+	})
+	lv := m.spacecraft[0].Links[0]
+	if row := m.renderLinkDetail(lv, false); !strings.Contains(row, "▼ rate 80% below normal (10.0 kbps)") {
+		t.Errorf("row = %q, want the rate flagged", row)
+	}
+
+	lv.Rate = 5e3
+	if row := m.renderLinkDetail(lv, false); strings.Contains(row, "below normal") {
+		t.Errorf("row = %q, 50%% below should not be flagged", row)
+	}
+}
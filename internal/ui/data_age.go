@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// dataAgeRamp colors a datum's age from fresh (green) to stale (red): the
+// first step whose limit the age is under wins, and anything older is
+// dataAgeStaleColor.
+var dataAgeRamp = []struct {
+	under time.Duration
+	color string
+}{
+	{time.Minute, "46"},
+	{5 * time.Minute, "114"},
+	{15 * time.Minute, "220"},
+	{time.Hour, "208"},
+}
+
+const dataAgeStaleColor = "196"
+
+// dataAgeStyle returns the staleness color for an age.
+func dataAgeStyle(age time.Duration) lipgloss.Style {
+	color := dataAgeStaleColor
+	for _, step := range dataAgeRamp {
+		if age < step.under {
+			color = step.color
+			break
+		}
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(color))
+}
+
+// formatDataAge formats an age compactly: "12s", "4m", "2h 10m".
+func formatDataAge(age time.Duration) string {
+	age = max(age, 0)
+	switch {
+	case age < time.Minute:
+		return fmt.Sprintf("%ds", int(age.Seconds()))
+	case age < time.Hour:
+		return fmt.Sprintf("%dm", int(age.Minutes()))
+	default:
+		return fmt.Sprintf("%dh %dm", int(age.Hours()), int(age.Minutes())%60)
+	}
+}
+
+// renderDataAge renders "label age" colored by staleness, or "label —"
+// when the datum hasn't arrived.
+func renderDataAge(label string, at, now time.Time) string {
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("60"))
+	if at.IsZero() {
+		return dimStyle.Render(label + " —")
+	}
+	age := now.Sub(at)
+	return dimStyle.Render(label+" ") + dataAgeStyle(age).Render(formatDataAge(age))
+}
+
+// renderDataAges lists how old the data behind the current view is: the
+// DSN feed's own timestamp everywhere, plus the ephemeris each view draws
+// on (pass plan and elevation trace in Mission, the trajectory path in
+// Sky, planet and spacecraft positions in Orbit). Empty during replay,
+// where old data is the point.
+// Format:
+//
+//	feed 12s · passes 4m · elev 1m
+func (m Model) renderDataAges(now time.Time) string {
+	if m.replay > 0 || m.snapshot.Data == nil {
+		return ""
+	}
+	feed := m.snapshot.Data.Timestamp
+	if feed.IsZero() {
+		feed = m.snapshot.LastFetch
+	}
+	ages := []string{renderDataAge("feed", feed, now)}
+	switch m.viewMode {
+	case ViewMissionDetail:
+		var passes, elev time.Time
+		if p := m.snapshot.PassPlan; p != nil {
+			passes = p.GeneratedAt
+		}
+		if t := m.snapshot.ElevationTrace; t != nil {
+			elev = t.GeneratedAt
+		}
+		ages = append(ages, renderDataAge("passes", passes, now), renderDataAge("elev", elev, now))
+	case ViewSky:
+		if m.skyView.pathMode != PathOff {
+			ages = append(ages, renderDataAge("path", m.skyView.pathLastFetch, now))
+		}
+	case ViewSolarSystem:
+		snap := m.solarSystem.solarSnap
+		ages = append(ages, renderDataAge("planets", snap.PlanetsUpdated, now), renderDataAge("S/C", snap.SpacecraftUpdated, now))
+	}
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("60"))
+	return strings.Join(ages, dimStyle.Render(" · "))
+}
@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+	"github.com/litescript/ls-horizons/internal/state"
+)
+
+func TestFormatDataAge(t *testing.T) {
+	tests := []struct {
+		age  time.Duration
+		want string
+	}{
+		{-time.Second, "0s"},
+		{12 * time.Second, "12s"},
+		{4*time.Minute + 30*time.Second, "4m"},
+		{130 * time.Minute, "2h 10m"},
+	}
+	for _, tt := range tests {
+		if got := formatDataAge(tt.age); got != tt.want {
+			t.Errorf("formatDataAge(%v) = %q, want %q", tt.age, got, tt.want)
+		}
+	}
+}
+
+func TestDataAgeStyle_Ramp(t *testing.T) {
+	fresh := dataAgeStyle(10 * time.Second).GetForeground()
+	stale := dataAgeStyle(3 * time.Hour).GetForeground()
+	if fresh == stale {
+		t.Error("fresh and stale data share a color")
+	}
+	if dataAgeStyle(2*time.Hour).GetForeground() != stale {
+		t.Error("anything over an hour should be stale")
+	}
+}
+
+func TestRenderDataAges(t *testing.T) {
+	now := time.Date(2025, 12, 5, 12, 0, 0, 0, time.UTC)
+	m := New(nil, nil)
+	if got := m.renderDataAges(now); got != "" {
+		t.Errorf("ages before data = %q, want none", got)
+	}
+
+	m.snapshot = state.Snapshot{
+		Data:     &dsn.DSNData{Timestamp: now.Add(-12 * time.Second)},
+		PassPlan: &dsn.PassPlan{GeneratedAt: now.Add(-4 * time.Minute)},
+	}
+	if got := m.renderDataAges(now); got != "feed 12s" {
+		t.Errorf("dashboard ages = %q, want the feed only", got)
+	}
+
+	m.viewMode = ViewMissionDetail
+	if got := m.renderDataAges(now); got != "feed 12s · passes 4m · elev —" {
+		t.Errorf("mission ages = %q", got)
+	}
+
+	m.viewMode = ViewSolarSystem
+	m.solarSystem.solarSnap.PlanetsUpdated = now.Add(-2 * time.Hour)
+	if got := m.renderDataAges(now); !strings.Contains(got, "planets 2h 0m") || !strings.Contains(got, "S/C —") {
+		t.Errorf("orbit ages = %q", got)
+	}
+
+	m.replay = 2
+	if got := m.renderDataAges(now); got != "" {
+		t.Errorf("ages during replay = %q, want none", got)
+	}
+}
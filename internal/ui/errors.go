@@ -0,0 +1,25 @@
+package ui
+
+import (
+	"github.com/litescript/ls-horizons/internal/dsn"
+)
+
+// errorHint returns the remediation hint for an error code in lang. The
+// English hints are dsn's, shared with the exports.
+func errorHint(lang Language, code dsn.ErrorCode) string {
+	if s, ok := aboutCatalog[lang]["error."+string(code)]; ok {
+		return s
+	}
+	return code.Hint()
+}
+
+// errorText formats err for display with its short code and hint:
+// "[FEED] DSN feed unavailable: ... · check the network; ...".
+func errorText(lang Language, err error) string {
+	code := dsn.ErrorCodeOf(err)
+	s := "[" + string(code) + "] " + err.Error()
+	if hint := errorHint(lang, code); hint != "" {
+		s += " · " + hint
+	}
+	return s
+}
@@ -0,0 +1,221 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+	"github.com/litescript/ls-horizons/internal/notify"
+	"github.com/litescript/ls-horizons/internal/state"
+)
+
+// eventGlyphs mark each event type, as in the headless --events log.
+var eventGlyphs = map[state.EventType]string{
+	state.EventNewLink:            "●",
+	state.EventHandoff:            "→",
+	state.EventLinkLost:           "○",
+	state.EventLinkResumed:        "◐",
+	state.EventUplinkStart:        "⬆",
+	state.EventUplinkEnd:          "·",
+	state.EventRareAcquisition:    "★",
+	state.EventComplexQuiet:       "◌",
+	state.EventComplexActive:      "◉",
+	state.EventWindRisk:           windGlyph,
+	state.EventPointingDivergence: "⌖",
+}
+
+// EventsModel is the full-screen event log: every event kept by the state
+// manager, newest first, filterable by type and spacecraft.
+type EventsModel struct {
+	width    int
+	height   int
+	events   []state.Event // chronological, as in the snapshot
+	shown    []state.Event // newest first, after filters
+	scroll   int           // index of the top row in shown
+	typeIdx  int           // 0 = all types, else state.EventTypes[typeIdx-1]
+	scFilter string        // spacecraft code ("" = all)
+}
+
+// NewEventsModel creates a new event log model.
+func NewEventsModel() EventsModel {
+	return EventsModel{}
+}
+
+// SetSize updates the viewport size.
+func (m EventsModel) SetSize(width, height int) EventsModel {
+	m.width = width
+	m.height = height
+	return m.clampScroll()
+}
+
+// UpdateData updates the model with new data. The scroll position stays
+// on the same rows, so reading back through the log isn't interrupted by
+// new events arriving on top.
+func (m EventsModel) UpdateData(snapshot state.Snapshot) EventsModel {
+	before := len(m.shown)
+	m.events = snapshot.Events
+	m = m.filter()
+	if m.scroll > 0 {
+		m.scroll += len(m.shown) - before
+	}
+	return m.clampScroll()
+}
+
+// Update handles messages.
+func (m EventsModel) Update(msg tea.Msg) (EventsModel, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	page := m.rows()
+	switch key.String() {
+	case "down", "j":
+		m.scroll++
+	case "up", "k":
+		m.scroll--
+	case "pgdown", " ":
+		m.scroll += page
+	case "pgup":
+		m.scroll -= page
+	case "home", "g":
+		m.scroll = 0
+	case "end", "G":
+		m.scroll = len(m.shown)
+	case "t":
+		m.typeIdx = (m.typeIdx + 1) % (len(state.EventTypes) + 1)
+		m.scroll = 0
+		m = m.filter()
+	case "f":
+		m.scFilter = nextCode(m.spacecraftCodes(), m.scFilter)
+		m.scroll = 0
+		m = m.filter()
+	case "esc":
+		m.typeIdx, m.scFilter, m.scroll = 0, "", 0
+		m = m.filter()
+	}
+	return m.clampScroll(), nil
+}
+
+// typeFilter returns the event type shown, or "" for all.
+func (m EventsModel) typeFilter() state.EventType {
+	if m.typeIdx == 0 {
+		return ""
+	}
+	return state.EventTypes[m.typeIdx-1]
+}
+
+// filter rebuilds the shown rows from the events and filters.
+func (m EventsModel) filter() EventsModel {
+	t := m.typeFilter()
+	m.shown = m.shown[:0:0]
+	for i := len(m.events) - 1; i >= 0; i-- {
+		e := m.events[i]
+		if (t == "" || e.Type == t) && (m.scFilter == "" || e.Spacecraft == m.scFilter) {
+			m.shown = append(m.shown, e)
+		}
+	}
+	return m
+}
+
+// spacecraftCodes returns the spacecraft with events, sorted.
+func (m EventsModel) spacecraftCodes() []string {
+	var codes []string
+	for _, e := range m.events {
+		if e.Spacecraft != "" && !slices.Contains(codes, e.Spacecraft) {
+			codes = append(codes, e.Spacecraft)
+		}
+	}
+	slices.Sort(codes)
+	return codes
+}
+
+// nextCode cycles through codes after current, then back to "" (all).
+func nextCode(codes []string, current string) string {
+	if current == "" {
+		if len(codes) == 0 {
+			return ""
+		}
+		return codes[0]
+	}
+	i := slices.Index(codes, current)
+	if i < 0 || i+1 == len(codes) {
+		return ""
+	}
+	return codes[i+1]
+}
+
+// rows returns how many events fit between the title and column header
+// and the count of older events.
+func (m EventsModel) rows() int {
+	return max(1, m.height-5)
+}
+
+func (m EventsModel) clampScroll() EventsModel {
+	m.scroll = min(m.scroll, len(m.shown)-m.rows())
+	m.scroll = max(m.scroll, 0)
+	return m
+}
+
+// View renders the event log.
+func (m EventsModel) View() string {
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("60"))
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(fmt.Sprintf("Event Log (%d of %d)", len(m.shown), len(m.events))))
+	filters := "all types"
+	if t := m.typeFilter(); t != "" {
+		filters = string(t)
+	}
+	if m.scFilter != "" {
+		filters += ", " + m.scFilter
+	} else {
+		filters += ", all spacecraft"
+	}
+	b.WriteString("  " + dimStyle.Render("showing "+filters))
+	b.WriteString("\n\n")
+
+	if len(m.shown) == 0 {
+		if len(m.events) == 0 {
+			b.WriteString(dimStyle.Render("  No events yet: acquisitions, handoffs, and losses appear here as they happen."))
+		} else {
+			b.WriteString(dimStyle.Render("  No events match the filters (esc clears them)."))
+		}
+		return b.String()
+	}
+
+	b.WriteString(labelStyle.Render(fmt.Sprintf("  %-20s  %-14s %-6s %s", "TIME (UTC)", "EVENT", "CODE", "DETAIL")))
+	b.WriteString("\n")
+	end := min(len(m.shown), m.scroll+m.rows())
+	for _, e := range m.shown[m.scroll:end] {
+		// Network-level events have a complex in place of a spacecraft
+		code := e.Spacecraft
+		if code == "" {
+			code = dsn.ComplexShortName(dsn.Complex(e.Complex))
+		}
+		line := fmt.Sprintf("  %-20s  %s %-12s %-6s %s: %s",
+			e.Timestamp.UTC().Format("2006-01-02 15:04:05"),
+			eventGlyphs[e.Type], e.Type, code,
+			notify.Title(e), notify.Message(e))
+		if m.width > 0 {
+			line = truncate(line, m.width)
+		}
+		style := rowStyle
+		switch e.Type {
+		case state.EventRareAcquisition:
+			style = rareStyle
+		case state.EventComplexQuiet:
+			style = quietStyle
+		}
+		b.WriteString(style.Render(line))
+		b.WriteString("\n")
+	}
+	if end < len(m.shown) {
+		b.WriteString(dimStyle.Render(fmt.Sprintf("  … %d older", len(m.shown)-end)))
+	}
+	return b.String()
+}
@@ -0,0 +1,113 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/litescript/ls-horizons/internal/state"
+)
+
+// eventLog returns n events alternating between VGR1 handoffs and JWST
+// new links, a minute apart.
+func eventLog(n int) []state.Event {
+	t0 := time.Date(2025, 12, 5, 6, 0, 0, 0, time.UTC)
+	var events []state.Event
+	for i := range n {
+		e := state.Event{Type: state.EventHandoff, Timestamp: t0.Add(time.Duration(i) * time.Minute),
+			Spacecraft: "VGR1", OldStation: "gdscc", NewStation: "mdscc", AntennaID: fmt.Sprintf("DSS%02d", i)}
+		if i%2 == 1 {
+			e = state.Event{Type: state.EventNewLink, Timestamp: e.Timestamp, Spacecraft: "JWST", AntennaID: e.AntennaID, Complex: "cdscc"}
+		}
+		events = append(events, e)
+	}
+	return events
+}
+
+func TestEventsModel_Filters(t *testing.T) {
+	m := NewEventsModel().SetSize(120, 30).UpdateData(state.Snapshot{Events: eventLog(10)})
+	key := func(k string) {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+	}
+
+	if len(m.shown) != 10 || m.shown[0].AntennaID != "DSS09" {
+		t.Fatalf("shown = %d, first %s; want all events newest first", len(m.shown), m.shown[0].AntennaID)
+	}
+
+	key("t") // NEW_LINK
+	if len(m.shown) != 5 || m.shown[0].Type != state.EventNewLink {
+		t.Errorf("type filter: %d shown, want the 5 new links", len(m.shown))
+	}
+	key("t") // HANDOFF
+	if len(m.shown) != 5 || m.shown[0].Type != state.EventHandoff {
+		t.Errorf("type filter: %d shown, want the 5 handoffs", len(m.shown))
+	}
+
+	key("f") // JWST: no handoffs
+	if m.scFilter != "JWST" || len(m.shown) != 0 || !strings.Contains(m.View(), "No events match") {
+		t.Errorf("JWST handoffs: filter %q, %d shown", m.scFilter, len(m.shown))
+	}
+	key("f") // VGR1
+	if m.scFilter != "VGR1" || len(m.shown) != 5 {
+		t.Errorf("VGR1 handoffs: filter %q, %d shown", m.scFilter, len(m.shown))
+	}
+	if out := m.View(); !strings.Contains(out, "Voyager 1: Handed off from Goldstone to Madrid") || !strings.Contains(out, "HANDOFF, VGR1") {
+		t.Errorf("View missing the handoff row or filter line:\n%s", out)
+	}
+	key("f") // back to all spacecraft
+	if m.scFilter != "" || len(m.shown) != 5 {
+		t.Errorf("f should cycle back to all spacecraft, got %q", m.scFilter)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if len(m.shown) != 10 {
+		t.Errorf("esc should clear the filters, %d shown", len(m.shown))
+	}
+}
+
+func TestEventsModel_Scroll(t *testing.T) {
+	// 10 rows fit at height 15
+	m := NewEventsModel().SetSize(120, 15).UpdateData(state.Snapshot{Events: eventLog(40)})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyPgDown})
+	if m.scroll != 10 {
+		t.Fatalf("scroll after pgdown = %d, want 10", m.scroll)
+	}
+	top := m.shown[m.scroll]
+
+	// New events arrive on top without moving the rows being read
+	m = m.UpdateData(state.Snapshot{Events: eventLog(43)})
+	if m.shown[m.scroll] != top {
+		t.Errorf("top row moved from %s to %s", top.AntennaID, m.shown[m.scroll].AntennaID)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnd})
+	if m.scroll != 43-10 {
+		t.Errorf("scroll after end = %d, want %d", m.scroll, 43-10)
+	}
+	if strings.Contains(m.View(), "older") {
+		t.Error("the oldest page shouldn't count older events")
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyHome})
+	if m.scroll != 0 || !strings.Contains(m.View(), "… 33 older") {
+		t.Errorf("scroll after home = %d", m.scroll)
+	}
+}
+
+func TestEventsViewKeys(t *testing.T) {
+	m := New(state.NewManager(state.DefaultConfig()), nil)
+	for _, k := range []string{"5", "e"} {
+		m.viewMode = ViewDashboard
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		if got := updated.(Model).viewMode; got != ViewEvents {
+			t.Errorf("%s: view = %v, want events", k, got)
+		}
+	}
+	m.viewMode = ViewEvents
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if got := updated.(Model).viewMode; got != ViewDashboard {
+		t.Errorf("tab from events = %v, want the dashboard", got)
+	}
+}
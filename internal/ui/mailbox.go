@@ -0,0 +1,122 @@
+package ui
+
+import (
+	"context"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Mailbox delivers background results to the program latest-only per key.
+//
+// tea.Program.Send blocks until the event loop takes the message, and the
+// event loop stalls while a slow terminal (e.g. over SSH) drains the last
+// frame. Rather than queueing every snapshot behind it, producers Post into
+// the mailbox without blocking; a newer message replaces any undelivered
+// one with the same key, and a single pump hands the survivors to Send.
+type Mailbox struct {
+	mu      sync.Mutex
+	pending map[string]tea.Msg
+	order   []string // keys in first-posted order
+	dropped uint64
+	wake    chan struct{}
+}
+
+// Mailbox keys for the messages routed through it.
+const (
+	mailboxFetch = "fetch" // DataUpdateMsg / ErrorMsg from the fetch loop
+)
+
+// NewMailbox creates an empty mailbox.
+func NewMailbox() *Mailbox {
+	return &Mailbox{
+		pending: make(map[string]tea.Msg),
+		wake:    make(chan struct{}, 1),
+	}
+}
+
+// Post queues msg under key, replacing any undelivered message with the
+// same key. It never blocks.
+func (b *Mailbox) Post(key string, msg tea.Msg) {
+	if msg == nil {
+		return
+	}
+
+	b.mu.Lock()
+	if _, ok := b.pending[key]; ok {
+		b.dropped++
+	} else {
+		b.order = append(b.order, key)
+	}
+	b.pending[key] = msg
+	b.mu.Unlock()
+
+	select {
+	case b.wake <- struct{}{}:
+	default:
+	}
+}
+
+// PostFetch posts a fetch-loop result (DataUpdateMsg or ErrorMsg). Only the
+// latest fetch outcome matters, so they share one key.
+func (b *Mailbox) PostFetch(msg tea.Msg) {
+	b.Post(mailboxFetch, msg)
+}
+
+// Dropped returns how many messages were superseded before delivery.
+func (b *Mailbox) Dropped() uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.dropped
+}
+
+// next removes and returns the oldest pending message.
+func (b *Mailbox) next() (tea.Msg, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.order) == 0 {
+		return nil, false
+	}
+	key := b.order[0]
+	b.order = b.order[1:]
+	msg := b.pending[key]
+	delete(b.pending, key)
+	return msg, true
+}
+
+// Run delivers pending messages with send (typically tea.Program.Send)
+// until ctx is cancelled. Messages posted while send is blocked coalesce.
+func (b *Mailbox) Run(ctx context.Context, send func(tea.Msg)) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-b.wake:
+		}
+
+		for {
+			msg, ok := b.next()
+			if !ok {
+				break
+			}
+			send(msg)
+			if ctx.Err() != nil {
+				return
+			}
+		}
+	}
+}
+
+// deliver routes a command's result through the mailbox, if one is set,
+// so a backlog of results for the same key collapses to the latest.
+func (m *Model) deliver(key string, cmd tea.Cmd) tea.Cmd {
+	if m.mailbox == nil || cmd == nil {
+		return cmd
+	}
+	mb := m.mailbox
+	return func() tea.Msg {
+		mb.Post(key, cmd())
+		return nil
+	}
+}
@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/litescript/ls-horizons/internal/state"
+)
+
+func TestMailbox_CoalescesByKey(t *testing.T) {
+	mb := NewMailbox()
+	for i := 1; i <= 3; i++ {
+		mb.PostFetch(DataUpdateMsg{Snapshot: state.Snapshot{FetchDuration: time.Duration(i)}})
+	}
+	mb.Post("pass:1", passPlanUpdatedMsg{spacecraftID: 1})
+
+	if got := mb.Dropped(); got != 2 {
+		t.Errorf("Dropped() = %d, want 2", got)
+	}
+
+	msg, ok := mb.next()
+	if !ok {
+		t.Fatal("expected a pending message")
+	}
+	if upd, ok := msg.(DataUpdateMsg); !ok || upd.Snapshot.FetchDuration != 3 {
+		t.Errorf("first message = %#v, want latest DataUpdateMsg", msg)
+	}
+	if msg, _ := mb.next(); msg == nil {
+		t.Error("expected pass plan message second")
+	} else if _, ok := msg.(passPlanUpdatedMsg); !ok {
+		t.Errorf("second message = %T, want passPlanUpdatedMsg", msg)
+	}
+	if _, ok := mb.next(); ok {
+		t.Error("mailbox should be empty")
+	}
+}
+
+func TestMailbox_RunDeliversLatestWhileBlocked(t *testing.T) {
+	mb := NewMailbox()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	release := make(chan struct{})
+	got := make(chan tea.Msg, 10)
+	send := func(msg tea.Msg) {
+		got <- msg
+		<-release // simulate a slow terminal
+	}
+	go mb.Run(ctx, send)
+
+	mb.PostFetch(DataUpdateMsg{Snapshot: state.Snapshot{FetchDuration: 1}})
+	first := <-got
+
+	// Posted while the first send is blocked: only the last survives
+	for i := 2; i <= 5; i++ {
+		mb.PostFetch(DataUpdateMsg{Snapshot: state.Snapshot{FetchDuration: time.Duration(i)}})
+	}
+	close(release)
+
+	second := <-got
+	if first.(DataUpdateMsg).Snapshot.FetchDuration != 1 {
+		t.Errorf("first delivered = %v, want 1", first.(DataUpdateMsg).Snapshot.FetchDuration)
+	}
+	if second.(DataUpdateMsg).Snapshot.FetchDuration != 5 {
+		t.Errorf("second delivered = %v, want 5", second.(DataUpdateMsg).Snapshot.FetchDuration)
+	}
+
+	select {
+	case extra := <-got:
+		t.Errorf("unexpected extra delivery: %#v", extra)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
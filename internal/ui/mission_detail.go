@@ -1,7 +1,9 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"strings"
 	"time"
 
@@ -10,6 +12,7 @@ import (
 
 	"github.com/litescript/ls-horizons/internal/dsn"
 	"github.com/litescript/ls-horizons/internal/ephem"
+	"github.com/litescript/ls-horizons/internal/notes"
 	"github.com/litescript/ls-horizons/internal/state"
 )
 
@@ -23,16 +26,55 @@ type MissionDetailModel struct {
 	showPassPanel bool
 	passPlan      *dsn.PassPlan
 	animTick      int // Animation tick for shimmer effects
+	charset       Charset
+	lang          Language // language of error hints
+
+	showPolar bool // the alt-az plot of a pass, under the pass list
+	plotPass  int  // index in the pass plan of the pass plotted, or -1 for the current or next
+
+	notes   *notes.Store // the user's journal (nil = notes off)
+	editing bool         // a note is being typed
+	draft   []rune       // the note being typed
+
+	tabs      []missionTab // pinned spacecraft, in pin order
+	activeTab int          // index in tabs of the one shown, or -1
+}
+
+// missionTab is a pinned spacecraft and the view state it had when last
+// shown, restored when the tab comes back round.
+type missionTab struct {
+	id            int
+	code          string
+	scrollY       int
+	showPassPanel bool
+	showPolar     bool
 }
 
+// maxNotesShown is how many of a spacecraft's latest notes are listed.
+const maxNotesShown = 5
+
 // NewMissionDetailModel creates a new mission detail model.
 func NewMissionDetailModel() MissionDetailModel {
 	return MissionDetailModel{
 		selectedID:    -1,
 		showPassPanel: true, // Default ON per spec
+		activeTab:     -1,
+		plotPass:      -1,
 	}
 }
 
+// SetCharset selects braille or ASCII glyphs for the alt-az plot.
+func (m MissionDetailModel) SetCharset(c Charset) MissionDetailModel {
+	m.charset = c
+	return m
+}
+
+// SetLanguage selects the language of error hints.
+func (m MissionDetailModel) SetLanguage(lang Language) MissionDetailModel {
+	m.lang = lang
+	return m
+}
+
 // SetSize updates the viewport size.
 func (m MissionDetailModel) SetSize(width, height int) MissionDetailModel {
 	m.width = width
@@ -63,6 +105,24 @@ func (m MissionDetailModel) UpdateData(snapshot state.Snapshot) MissionDetailMod
 	return m
 }
 
+// SetNotes attaches the notes journal shown under each spacecraft.
+func (m MissionDetailModel) SetNotes(store *notes.Store) MissionDetailModel {
+	m.notes = store
+	return m
+}
+
+// Editing reports whether a note is being typed, in which case the view
+// takes every key.
+func (m MissionDetailModel) Editing() bool {
+	return m.editing
+}
+
+// noteSavedMsg reports the result of saving a note.
+type noteSavedMsg struct {
+	note notes.Note
+	err  error
+}
+
 // SpacecraftChangedMsg signals the selected spacecraft changed.
 type SpacecraftChangedMsg struct {
 	SpacecraftID int
@@ -73,7 +133,15 @@ func (m MissionDetailModel) Update(msg tea.Msg) (MissionDetailModel, tea.Cmd) {
 	var cmd tea.Cmd
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.editing {
+			return m.updateDraft(msg)
+		}
 		switch msg.String() {
+		case "n":
+			if m.notes != nil && m.selectedCode() != "" {
+				m.editing = true
+				m.draft = nil
+			}
 		case "up", "k":
 			m.scrollY--
 			if m.scrollY < 0 {
@@ -82,30 +150,77 @@ func (m MissionDetailModel) Update(msg tea.Msg) (MissionDetailModel, tea.Cmd) {
 		case "down", "j":
 			m.scrollY++
 		case "left", "[":
-			oldID := m.selectedID
-			m.selectPrevSpacecraft()
-			if m.selectedID != oldID {
-				newID := m.selectedID // Capture value explicitly for closure
-				cmd = func() tea.Msg {
-					return SpacecraftChangedMsg{SpacecraftID: newID}
-				}
-			}
+			cmd = m.changeSpacecraft(m.selectPrevSpacecraft)
 		case "right", "]":
-			oldID := m.selectedID
-			m.selectNextSpacecraft()
-			if m.selectedID != oldID {
-				newID := m.selectedID // Capture value explicitly for closure
-				cmd = func() tea.Msg {
-					return SpacecraftChangedMsg{SpacecraftID: newID}
-				}
-			}
+			cmd = m.changeSpacecraft(m.selectNextSpacecraft)
+		case "p":
+			m.togglePin()
+		case "P":
+			cmd = m.changeSpacecraft(m.nextTab)
 		case "h":
 			m.showPassPanel = !m.showPassPanel
+		case "a":
+			m.showPolar = !m.showPolar
+		case ",":
+			m.stepPlotPass(-1)
+		case ".":
+			m.stepPlotPass(1)
 		}
 	}
 	return m, cmd
 }
 
+// updateDraft edits the note being typed: enter saves it, esc drops it.
+func (m MissionDetailModel) updateDraft(msg tea.KeyMsg) (MissionDetailModel, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.editing = false
+		store, code, text := m.notes, m.selectedCode(), string(m.draft)
+		if strings.TrimSpace(text) == "" {
+			return m, nil
+		}
+		return m, func() tea.Msg {
+			n, err := store.Add(code, text, time.Now())
+			return noteSavedMsg{note: n, err: err}
+		}
+	case tea.KeyEsc:
+		m.editing = false
+	case tea.KeyBackspace:
+		if len(m.draft) > 0 {
+			m.draft = m.draft[:len(m.draft)-1]
+		}
+	case tea.KeySpace:
+		m.draft = append(m.draft, ' ')
+	case tea.KeyRunes:
+		m.draft = append(m.draft, msg.Runes...)
+	}
+	return m, nil
+}
+
+// selectedCode returns the DSN code of the selected spacecraft, or "".
+func (m MissionDetailModel) selectedCode() string {
+	for _, sc := range m.snapshot.Spacecraft {
+		if sc.ID == m.selectedID {
+			return sc.Name
+		}
+	}
+	return ""
+}
+
+// changeSpacecraft runs move and, if it selected another spacecraft,
+// returns a command announcing it.
+func (m *MissionDetailModel) changeSpacecraft(move func()) tea.Cmd {
+	oldID := m.selectedID
+	move()
+	if m.selectedID == oldID {
+		return nil
+	}
+	newID := m.selectedID // Capture value explicitly for closure
+	return func() tea.Msg {
+		return SpacecraftChangedMsg{SpacecraftID: newID}
+	}
+}
+
 func (m *MissionDetailModel) selectNextSpacecraft() {
 	if len(m.snapshot.Spacecraft) == 0 {
 		return
@@ -117,8 +232,7 @@ func (m *MissionDetailModel) selectNextSpacecraft() {
 			continue
 		}
 		if foundCurrent {
-			m.selectedID = sc.ID
-			m.scrollY = 0
+			m.showSpacecraft(sc.ID)
 			return
 		}
 		if sc.ID == m.selectedID {
@@ -139,8 +253,7 @@ func (m *MissionDetailModel) selectPrevSpacecraft() {
 		}
 		if sc.ID == m.selectedID {
 			if prevID != 0 {
-				m.selectedID = prevID
-				m.scrollY = 0
+				m.showSpacecraft(prevID)
 			}
 			return
 		}
@@ -148,13 +261,79 @@ func (m *MissionDetailModel) selectPrevSpacecraft() {
 	}
 }
 
+// showSpacecraft selects id, saving the view state of the tab being left
+// and restoring that of id's tab if it is pinned.
+func (m *MissionDetailModel) showSpacecraft(id int) {
+	if m.activeTab >= 0 {
+		m.tabs[m.activeTab].scrollY = m.scrollY
+		m.tabs[m.activeTab].showPassPanel = m.showPassPanel
+		m.tabs[m.activeTab].showPolar = m.showPolar
+	}
+	m.selectedID = id
+	m.scrollY = 0
+	m.plotPass = -1
+	m.activeTab = m.tabIndex(id)
+	if m.activeTab >= 0 {
+		m.scrollY = m.tabs[m.activeTab].scrollY
+		m.showPassPanel = m.tabs[m.activeTab].showPassPanel
+		m.showPolar = m.tabs[m.activeTab].showPolar
+	}
+}
+
+// tabIndex returns the index of id's tab, or -1 if it isn't pinned.
+func (m MissionDetailModel) tabIndex(id int) int {
+	for i, t := range m.tabs {
+		if t.id == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// togglePin pins the selected spacecraft as a tab, or unpins it.
+func (m *MissionDetailModel) togglePin() {
+	if i := m.tabIndex(m.selectedID); i >= 0 {
+		m.tabs = append(m.tabs[:i], m.tabs[i+1:]...)
+		m.activeTab = -1
+		return
+	}
+	code := m.selectedCode()
+	if code == "" {
+		return
+	}
+	m.tabs = append(m.tabs, missionTab{id: m.selectedID, code: code, scrollY: m.scrollY, showPassPanel: m.showPassPanel, showPolar: m.showPolar})
+	m.activeTab = len(m.tabs) - 1
+}
+
+// nextTab shows the tab after the active one, or the first if none is.
+func (m *MissionDetailModel) nextTab() {
+	if len(m.tabs) == 0 {
+		return
+	}
+	m.showSpacecraft(m.tabs[(m.activeTab+1)%len(m.tabs)].id)
+}
+
+// PinnedSpacecraft returns the IDs of the pinned spacecraft, in tab order.
+func (m MissionDetailModel) PinnedSpacecraft() []int {
+	ids := make([]int, len(m.tabs))
+	for i, t := range m.tabs {
+		ids[i] = t.id
+	}
+	return ids
+}
+
 // View renders the mission detail view.
 func (m MissionDetailModel) View() string {
 	var b strings.Builder
 
-	// Spacecraft selector
+	// Spacecraft selector, and the pinned tabs under it
 	b.WriteString(m.renderSpacecraftSelector())
-	b.WriteString("\n\n")
+	b.WriteString("\n")
+	if len(m.tabs) > 0 {
+		b.WriteString(m.renderTabs())
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
 
 	// Find selected spacecraft
 	var selected *dsn.Spacecraft
@@ -170,18 +349,52 @@ func (m MissionDetailModel) View() string {
 		return b.String()
 	}
 
-	// Spacecraft details first
-	b.WriteString(m.renderSpacecraftDetails(selected))
-
-	// Pass panel below details (if enabled)
+	// Spacecraft details first, then the pass panel (if enabled)
+	body := m.renderSpacecraftDetails(selected)
 	if m.showPassPanel {
-		b.WriteString("\n")
-		b.WriteString(m.renderPassPanel())
+		body += "\n" + m.renderPassPanel()
 	}
+	b.WriteString(scrollLines(body, m.scrollY))
 
 	return b.String()
 }
 
+// scrollLines drops the first n lines of s, keeping at least the last.
+func scrollLines(s string, n int) string {
+	if n <= 0 {
+		return s
+	}
+	lines := strings.Split(s, "\n")
+	return strings.Join(lines[min(n, len(lines)-1):], "\n")
+}
+
+// renderTabs renders the pinned spacecraft tabs, the shown one
+// highlighted.
+func (m MissionDetailModel) renderTabs() string {
+	activeStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Padding(0, 1)
+	tabStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("244")).
+		Padding(0, 1)
+
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Render("Pinned:     "))
+	for i, t := range m.tabs {
+		label := fmt.Sprintf("%d %s", i+1, t.code)
+		if i == m.activeTab {
+			b.WriteString(activeStyle.Render(label))
+		} else {
+			b.WriteString(tabStyle.Render(label))
+		}
+		b.WriteString(" ")
+	}
+	b.WriteString(tabStyle.Render("P: next"))
+	return b.String()
+}
+
 func (m MissionDetailModel) renderSpacecraftSelector() string {
 	var b strings.Builder
 
@@ -259,7 +472,24 @@ func (m MissionDetailModel) renderSpacecraftDetails(sc *dsn.Spacecraft) string {
 		b.WriteString("\n")
 
 		for i, link := range sc.Links {
-			b.WriteString(fmt.Sprintf("\n  Link %d: %s @ %s\n", i+1, link.AntennaID, link.Complex))
+			b.WriteString(fmt.Sprintf("\n  Link %d: %s @ %s", i+1, link.AntennaID, link.Complex))
+			if link.CarrierOnly() {
+				b.WriteString(" ◦ " + dsn.CarrierLockLabel)
+			}
+			if link.Inferred {
+				b.WriteString(" ? " + dsn.InferredLabel)
+			}
+			if link.Uplink {
+				b.WriteString(" " + dsn.UplinkBadge + " uplink")
+			}
+			b.WriteString("\n")
+
+			if link.TrackingMode != "" {
+				b.WriteString("    ")
+				b.WriteString(labelStyle.Render("Tracking:"))
+				b.WriteString(valueStyle.Render(string(link.TrackingMode)))
+				b.WriteString("\n")
+			}
 
 			b.WriteString("    ")
 			b.WriteString(labelStyle.Render("Band:"))
@@ -281,11 +511,24 @@ func (m MissionDetailModel) renderSpacecraftDetails(sc *dsn.Spacecraft) string {
 			b.WriteString(valueStyle.Render(dsn.FormatDataRate(link.UpRate)))
 			b.WriteString("\n")
 
+			if link.DownPower != 0 {
+				b.WriteString("    ")
+				b.WriteString(labelStyle.Render("Rx Power:"))
+				b.WriteString(valueStyle.Render(dsn.FormatPower(link.DownPower) + " (SNR ~" + dsn.FormatSNR(link) + ")"))
+				b.WriteString("\n")
+			}
+
 			// Doppler modeling (based on carrier frequency)
 			b.WriteString("    ")
 			b.WriteString(labelStyle.Render("Doppler:"))
 			b.WriteString(valueStyle.Render(m.renderDopplerInfo(link.Band, sc.Distance)))
 			b.WriteString("\n")
+
+			if handoff := m.renderHandoff(link, time.Now()); handoff != "" {
+				b.WriteString("    ")
+				b.WriteString(handoff)
+				b.WriteString("\n")
+			}
 		}
 	}
 
@@ -295,25 +538,86 @@ func (m MissionDetailModel) renderSpacecraftDetails(sc *dsn.Spacecraft) string {
 	b.WriteString("\n")
 	b.WriteString(m.renderElevationSparkline())
 	b.WriteString("\n")
+	if margin := m.renderMarginForecast(sc, time.Now()); margin != "" {
+		b.WriteString(margin)
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(m.renderSignalHistory())
+
+	if m.notes != nil {
+		b.WriteString("\n")
+		b.WriteString(m.renderNotes(sc.Name))
+	}
+
+	return b.String()
+}
+
+// renderNotes lists the journal entries for a spacecraft, newest last,
+// with the note being typed below them.
+func (m MissionDetailModel) renderNotes(code string) string {
+	var b strings.Builder
+
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("205"))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("252"))
+
+	b.WriteString(headerStyle.Render("Notes"))
+	b.WriteString("\n")
 
+	list := m.notes.For(code)
+	if len(list) > maxNotesShown {
+		b.WriteString(dimStyle.Render(fmt.Sprintf("  … %d earlier", len(list)-maxNotesShown)))
+		b.WriteString("\n")
+		list = list[len(list)-maxNotesShown:]
+	}
+	for _, n := range list {
+		b.WriteString("  ")
+		b.WriteString(dimStyle.Render(n.Time.Local().Format("2006-01-02 15:04")))
+		b.WriteString("  ")
+		b.WriteString(valueStyle.Render(n.Text))
+		b.WriteString("\n")
+	}
+
+	switch {
+	case m.editing:
+		b.WriteString("  > " + valueStyle.Render(string(m.draft)) + "█\n")
+		b.WriteString(dimStyle.Render("  enter: save | esc: cancel"))
+		b.WriteString("\n")
+	case len(list) == 0:
+		b.WriteString(dimStyle.Render("  None yet. Press n to add one."))
+		b.WriteString("\n")
+	}
 	return b.String()
 }
 
-// renderDopplerInfo renders Doppler information for a link.
-// Since we don't have measured Doppler from DSN, we show model parameters.
+// renderDopplerInfo renders the Doppler shift on a band's carrier
+// estimated from the spacecraft's range rate, which is fitted to its RTLT
+// history.
 func (m MissionDetailModel) renderDopplerInfo(band string, distanceKm float64) string {
 	if distanceKm <= 0 {
 		return "N/A"
 	}
 
-	freq := dsn.GetBandFrequency(band)
-	if freq <= 0 {
-		return "N/A"
+	var samples []dsn.RTLTSample
+	if hist := m.snapshot.SpacecraftHistory; hist != nil && hist.SpacecraftID == m.selectedID {
+		samples = make([]dsn.RTLTSample, len(hist.RTLTHistory))
+		for i, p := range hist.RTLTHistory {
+			samples[i] = dsn.RTLTSample{Time: p.Timestamp, RTLT: p.Value}
+		}
 	}
 
-	// Without range rate data, we can only show the carrier frequency
-	// Real implementation would compute Doppler from range rate
-	return fmt.Sprintf("Model: %s @ %.0f MHz", band, freq)
+	d := dsn.DopplerFromRTLT(samples, band)
+	carrier := fmt.Sprintf("%s @ %.0f MHz", band, d.CarrierFreqMHz)
+	if !d.Valid {
+		return "Estimating from RTLT... (" + carrier + ")"
+	}
+	direction := "receding"
+	if d.LOSVelocity < 0 {
+		direction = "approaching"
+	}
+	return fmt.Sprintf("%s (%s %.2f km/s, %s)", dsn.FormatDopplerShift(d.DopplerShift), direction, math.Abs(d.LOSVelocity), carrier)
 }
 
 // SparklineWidth is the fixed width of the elevation sparkline.
@@ -340,7 +644,7 @@ func (m MissionDetailModel) renderElevationSparkline() string {
 
 	if m.snapshot.ElevationTraceError != nil {
 		dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
-		return dimStyle.Render("Error: " + m.snapshot.ElevationTraceError.Error())
+		return dimStyle.Render("Error: " + errorText(m.lang, m.snapshot.ElevationTraceError))
 	}
 
 	trace := m.snapshot.ElevationTrace
@@ -403,6 +707,78 @@ func (m MissionDetailModel) renderElevationSparkline() string {
 	return sb.String()
 }
 
+// renderMarginForecast projects the link at the elevation trace's complex
+// to the end of its current pass (see dsn.ForecastMargin), or returns ""
+// when there is no pass in progress to project.
+// Format:
+//
+//	Margin: margin shrinking, ~40 min of good geometry left
+func (m MissionDetailModel) renderMarginForecast(sc *dsn.Spacecraft, now time.Time) string {
+	trace := m.snapshot.ElevationTrace
+	if trace == nil || m.snapshot.ElevationTraceLoading {
+		return ""
+	}
+	var link *dsn.Link
+	for i := range sc.Links {
+		l := &sc.Links[i]
+		if l.Complex == trace.Complex && (trace.Antenna == "" || l.AntennaID == trace.Antenna) {
+			link = l
+			break
+		}
+	}
+	if link == nil {
+		return ""
+	}
+
+	var passEnd time.Time
+	if plan := m.snapshot.PassPlan; plan != nil {
+		for _, p := range plan.GetPassesForComplex(trace.Complex) {
+			if p.Status == dsn.PassNow {
+				passEnd = p.End
+				break
+			}
+		}
+	}
+	var rateTrend float64
+	if hist := m.snapshot.SpacecraftHistory; hist != nil && hist.SpacecraftID == sc.ID {
+		rateTrend = hist.RateTrend()
+	}
+	f, ok := m.snapshot.Data.ActiveHealthModel().ForecastMargin(*link, trace, rateTrend, passEnd, m.snapshot.PassElevations.For(link.Spacecraft, link.Complex), now)
+	if !ok {
+		return ""
+	}
+
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+	style := lipgloss.NewStyle().Foreground(lipgloss.Color("250"))
+	if f.Degrades || f.GoodUntil.IsZero() {
+		style = lipgloss.NewStyle().Foreground(lipgloss.Color("208"))
+	}
+	return labelStyle.Render("Margin: ") + style.Render(f.Describe(now))
+}
+
+// renderHandoff predicts when link's complex will hand the spacecraft
+// off (see dsn.PredictHandoff), or returns "" when the pass plan can't
+// tell. The elevation trace refines the pass end when it is for the
+// link's complex.
+// Format:
+//
+//	handoff in ~42m → Madrid
+func (m MissionDetailModel) renderHandoff(link dsn.Link, now time.Time) string {
+	trace := m.snapshot.ElevationTrace
+	if m.snapshot.ElevationTraceLoading {
+		trace = nil
+	}
+	h, ok := dsn.PredictHandoff(link, m.snapshot.PassPlan, trace, now)
+	if !ok {
+		return ""
+	}
+	style := lipgloss.NewStyle().Foreground(lipgloss.Color("250"))
+	if h.Gap > 0 {
+		style = lipgloss.NewStyle().Foreground(lipgloss.Color("208"))
+	}
+	return style.Render(h.Describe(now))
+}
+
 // renderShimmerSparkline renders a loading animation sparkline.
 func (m MissionDetailModel) renderShimmerSparkline(msg string) string {
 	var sb strings.Builder
@@ -500,10 +876,10 @@ func (m MissionDetailModel) SelectedSpacecraftID() int {
 	return m.selectedID
 }
 
-// SetSelectedSpacecraft sets the selected spacecraft by ID.
+// SetSelectedSpacecraft sets the selected spacecraft by ID, switching to
+// its tab if it is pinned.
 func (m *MissionDetailModel) SetSelectedSpacecraft(id int) {
-	m.selectedID = id
-	m.scrollY = 0
+	m.showSpacecraft(id)
 }
 
 // UpdatePassPlan updates the pass plan data.
@@ -517,6 +893,53 @@ func (m MissionDetailModel) ShowPassPanel() bool {
 	return m.showPassPanel
 }
 
+// plottedPass returns the index in the pass plan of the pass the alt-az
+// plot shows: the one picked with , and ., else the current pass, else
+// the next, else the first. It returns -1 if there are no passes.
+func (m MissionDetailModel) plottedPass() int {
+	plan := m.snapshot.PassPlan
+	if plan == nil || len(plan.Passes) == 0 {
+		return -1
+	}
+	if m.plotPass >= 0 && m.plotPass < len(plan.Passes) {
+		return m.plotPass
+	}
+	for _, status := range []dsn.PassStatus{dsn.PassNow, dsn.PassNext} {
+		for i, p := range plan.Passes {
+			if p.Status == status {
+				return i
+			}
+		}
+	}
+	return 0
+}
+
+// stepPlotPass moves the alt-az plot to the pass delta places later in
+// the plan, stopping at either end.
+func (m *MissionDetailModel) stepPlotPass(delta int) {
+	i := m.plottedPass()
+	if i < 0 {
+		return
+	}
+	m.plotPass = max(0, min(i+delta, len(m.snapshot.PassPlan.Passes)-1))
+}
+
+// renderPolarPlot renders the alt-az plot of the plotted pass.
+func (m MissionDetailModel) renderPolarPlot() string {
+	i := m.plottedPass()
+	if i < 0 {
+		return ""
+	}
+	p := m.snapshot.PassPlan.Passes[i]
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("244")).
+		Render(fmt.Sprintf("  ALT-AZ  pass %d of %d  (,/. pass  a: hide)", i+1, len(m.snapshot.PassPlan.Passes))))
+	b.WriteString("\n")
+	b.WriteString(RenderPolarPlot(p, dsn.PassTrack(p, m.snapshot.PassSamples), time.Now(), m.charset))
+	b.WriteString("\n")
+	return b.String()
+}
+
 // renderPassPanel renders the pass & handoff panel.
 func (m MissionDetailModel) renderPassPanel() string {
 	var b strings.Builder
@@ -553,7 +976,11 @@ func (m MissionDetailModel) renderPassPanel() string {
 		}
 	}
 
-	b.WriteString(headerStyle.Render(fmt.Sprintf("PASSES — %s (next 24h)", scName)))
+	window := "24h"
+	if plan := m.snapshot.PassPlan; plan != nil && plan.WindowEnd.After(plan.GeneratedAt) {
+		window = formatPassWindow(plan.WindowEnd.Sub(plan.GeneratedAt))
+	}
+	b.WriteString(headerStyle.Render(fmt.Sprintf("PASSES — %s (next %s)", scName, window)))
 	b.WriteString("\n")
 	b.WriteString(strings.Repeat("─", 60))
 	b.WriteString("\n\n")
@@ -562,12 +989,11 @@ func (m MissionDetailModel) renderPassPanel() string {
 	passPlan := m.snapshot.PassPlan
 	if passPlan == nil || len(passPlan.Passes) == 0 {
 		if m.snapshot.PassPlanError != nil {
-			errStr := m.snapshot.PassPlanError.Error()
 			var msg string
-			if strings.Contains(errStr, "unknown spacecraft") {
-				msg = "  Ephemeris data not available for this mission"
+			if errors.Is(m.snapshot.PassPlanError, dsn.ErrUnknownTarget) {
+				msg = "  [" + string(dsn.CodeUnknownTarget) + "] Ephemeris data not available for this mission"
 			} else {
-				msg = fmt.Sprintf("  %v", m.snapshot.PassPlanError)
+				msg = "  " + errorText(m.lang, m.snapshot.PassPlanError)
 			}
 			b.WriteString(dimStyle.Render(msg))
 		} else if m.snapshot.PassPlanLoading {
@@ -581,77 +1007,130 @@ func (m MissionDetailModel) renderPassPanel() string {
 		return b.String()
 	}
 
+	if note := passElevationNote(passPlan.MinElevations, passPlan.SpacecraftCode); note != "" {
+		b.WriteString(dimStyle.Render("  " + note))
+		b.WriteString("\n\n")
+	}
+
 	// Column headers
 	b.WriteString(labelStyle.Render("  COMPLEX   START      PEAK EL   END        SUN SEP   STATUS"))
 	b.WriteString("\n")
 	b.WriteString(dimStyle.Render("  " + strings.Repeat("─", 58)))
 	b.WriteString("\n")
 
-	// Group passes by complex for cleaner display
-	complexes := []dsn.Complex{dsn.ComplexGoldstone, dsn.ComplexCanberra, dsn.ComplexMadrid}
+	// Antennas currently linked to this spacecraft, for elevation masks
+	linkedAntenna := make(map[dsn.Complex]string)
+	if m.snapshot.Data != nil {
+		for _, link := range m.snapshot.Data.Links {
+			if link.SpacecraftID == m.selectedID {
+				if _, ok := linkedAntenna[link.Complex]; !ok {
+					linkedAntenna[link.Complex] = link.AntennaID
+				}
+			}
+		}
+	}
+	flagged := false
+	var plotted *dsn.Pass
+	if i := m.plottedPass(); m.showPolar && i >= 0 {
+		plotted = &passPlan.Passes[i]
+	}
 
-	for _, c := range complexes {
-		passes := passPlan.GetPassesForComplex(c)
-		shortName := dsn.ComplexShortName(c)
+	// Group passes by complex for cleaner display, and by UTC day too
+	// when the window is longer than the default day
+	complexes := []dsn.Complex{dsn.ComplexGoldstone, dsn.ComplexCanberra, dsn.ComplexMadrid}
+	days := []dsn.PassDay{{Passes: passPlan.Passes}}
+	if passPlan.WindowEnd.Sub(passPlan.GeneratedAt) > dsn.PassWindowDuration {
+		days = passPlan.Days(time.UTC)
+	}
 
-		if len(passes) == 0 {
-			b.WriteString(fmt.Sprintf("  %-8s  ", shortName))
-			b.WriteString(dimStyle.Render("-- no passes --"))
+	for _, day := range days {
+		if !day.Date.IsZero() {
+			b.WriteString(labelStyle.Render("  " + day.Date.Format("Mon 02 Jan")))
 			b.WriteString("\n")
-			continue
 		}
+		dayPlan := &dsn.PassPlan{Passes: day.Passes}
+		for _, c := range complexes {
+			passes := dayPlan.GetPassesForComplex(c)
+			shortName := dsn.ComplexShortName(c)
+			mask := dsn.ElevationMaskFor(linkedAntenna[c])
 
-		for i, p := range passes {
-			// Skip past passes for cleaner display (show max 1 past)
-			if p.Status == dsn.PassPast && i > 0 {
-				continue
-			}
-
-			// Complex name (only show for first pass of this complex)
-			if i == 0 {
+			if len(passes) == 0 {
 				b.WriteString(fmt.Sprintf("  %-8s  ", shortName))
-			} else {
-				b.WriteString("            ")
+				b.WriteString(dimStyle.Render("-- no passes --"))
+				b.WriteString("\n")
+				continue
 			}
 
-			// Start time
-			b.WriteString(valueStyle.Render(p.Start.UTC().Format("15:04")))
-			b.WriteString("      ")
+			for i, p := range passes {
+				// Skip past passes for cleaner display (show max 1 past)
+				if p.Status == dsn.PassPast && i > 0 {
+					continue
+				}
 
-			// Peak elevation
-			elStr := fmt.Sprintf("%2.0f°", p.MaxElDeg)
-			b.WriteString(valueStyle.Render(elStr))
-			b.WriteString("       ")
+				// Complex name (only show for first pass of this complex)
+				if i == 0 {
+					b.WriteString(fmt.Sprintf("  %-8s  ", shortName))
+				} else {
+					b.WriteString("            ")
+				}
 
-			// End time
-			b.WriteString(valueStyle.Render(p.End.UTC().Format("15:04")))
-			b.WriteString("      ")
+				// Start time
+				b.WriteString(valueStyle.Render(p.Start.UTC().Format("15:04")))
+				b.WriteString("      ")
+
+				// Peak elevation, flagged against the antenna's elevation mask
+				elStr := fmt.Sprintf("%2.0f°", p.MaxElDeg)
+				switch p.Feasibility(mask) {
+				case dsn.PassMarginal:
+					b.WriteString(warningStyle.Render(elStr + "!"))
+					flagged = true
+				case dsn.PassUntrackable:
+					b.WriteString(dimStyle.Render(elStr + "x"))
+					flagged = true
+				default:
+					b.WriteString(valueStyle.Render(elStr + " "))
+				}
+				b.WriteString("      ")
+
+				// End time
+				b.WriteString(valueStyle.Render(p.End.UTC().Format("15:04")))
+				b.WriteString("      ")
+
+				// Sun separation
+				sunStr := fmt.Sprintf("%3.0f°", p.SunMinSep)
+				if p.SunMinSep < 10 {
+					b.WriteString(warningStyle.Render(sunStr))
+				} else {
+					b.WriteString(valueStyle.Render(sunStr))
+				}
+				b.WriteString("      ")
+
+				// Status
+				switch p.Status {
+				case dsn.PassNow:
+					b.WriteString(nowStyle.Render("NOW"))
+				case dsn.PassNext:
+					b.WriteString(nextStyle.Render("NEXT"))
+				case dsn.PassPast:
+					b.WriteString(dimStyle.Render("PAST"))
+				default:
+					b.WriteString(dimStyle.Render("—"))
+				}
+				if plotted != nil && p.Complex == plotted.Complex && p.Start.Equal(plotted.Start) {
+					b.WriteString(labelStyle.Render("  ◂ plotted"))
+				}
 
-			// Sun separation
-			sunStr := fmt.Sprintf("%3.0f°", p.SunMinSep)
-			if p.SunMinSep < 10 {
-				b.WriteString(warningStyle.Render(sunStr))
-			} else {
-				b.WriteString(valueStyle.Render(sunStr))
-			}
-			b.WriteString("      ")
-
-			// Status
-			switch p.Status {
-			case dsn.PassNow:
-				b.WriteString(nowStyle.Render("NOW"))
-			case dsn.PassNext:
-				b.WriteString(nextStyle.Render("NEXT"))
-			case dsn.PassPast:
-				b.WriteString(dimStyle.Render("PAST"))
-			default:
-				b.WriteString(dimStyle.Render("—"))
+				b.WriteString("\n")
 			}
-
-			b.WriteString("\n")
 		}
 	}
 
+	if flagged {
+		b.WriteString(dimStyle.Render(fmt.Sprintf("  ! marginal  x below antenna mask (70m %.0f°, 34m %.0f°)",
+			dsn.Mask70m.MinElDeg, dsn.Mask34m.MinElDeg)))
+		b.WriteString("\n")
+	}
+
 	// Show next pass summary
 	b.WriteString("\n")
 	if current := passPlan.GetCurrentPass(); current != nil {
@@ -670,6 +1149,11 @@ func (m MissionDetailModel) renderPassPanel() string {
 		b.WriteString("\n")
 	}
 
+	if m.showPolar {
+		b.WriteString("\n")
+		b.WriteString(m.renderPolarPlot())
+	}
+
 	return b.String()
 }
 
@@ -681,6 +1165,16 @@ func isStationNotSpacecraft(name string) bool {
 	return upper == "DSS" || strings.HasPrefix(upper, "DSS-") || strings.HasPrefix(upper, "DSS ")
 }
 
+// formatPassWindow formats a pass plan's span: "24h", or "7d" for whole
+// days beyond one.
+func formatPassWindow(d time.Duration) string {
+	d = d.Round(time.Hour)
+	if d > 24*time.Hour && d%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%dd", d/(24*time.Hour))
+	}
+	return fmt.Sprintf("%dh", int(d.Hours()))
+}
+
 // formatDuration formats a duration for display.
 func formatDuration(d time.Duration) string {
 	if d < 0 {
@@ -744,3 +1238,20 @@ func (m MissionDetailModel) renderShimmerText(text string) string {
 
 	return result.String()
 }
+
+// passElevationNote lists the minimum pass elevation in elev at each
+// complex for a spacecraft, or returns "" when all are the default
+// MinPassElevation.
+func passElevationNote(elev dsn.PassElevations, scCode string) string {
+	var parts []string
+	custom := false
+	for _, c := range []dsn.Complex{dsn.ComplexGoldstone, dsn.ComplexCanberra, dsn.ComplexMadrid} {
+		el := elev.For(scCode, c)
+		custom = custom || el != dsn.MinPassElevation
+		parts = append(parts, fmt.Sprintf("%s %g°", dsn.ComplexShortName(c), el))
+	}
+	if !custom {
+		return ""
+	}
+	return "Passes above " + strings.Join(parts, " · ")
+}
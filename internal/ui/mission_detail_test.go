@@ -2,12 +2,17 @@ package ui
 
 import (
 	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/litescript/ls-horizons/internal/astro"
 	"github.com/litescript/ls-horizons/internal/dsn"
+	"github.com/litescript/ls-horizons/internal/notes"
 	"github.com/litescript/ls-horizons/internal/state"
 )
 
@@ -449,3 +454,311 @@ func TestSparklineBlocks(t *testing.T) {
 		}
 	}
 }
+
+func TestMissionDetailPassPanel_ElevationMask(t *testing.T) {
+	now := time.Now()
+	m := NewMissionDetailModel()
+	m = m.SetSize(100, 40)
+	m = m.UpdateData(state.Snapshot{
+		Spacecraft: []dsn.Spacecraft{{ID: 1, Name: "Test"}},
+		Data: &dsn.DSNData{
+			Links: []dsn.Link{{SpacecraftID: 1, Complex: dsn.ComplexMadrid, AntennaID: "DSS63"}},
+		},
+		PassPlan: &dsn.PassPlan{
+			SpacecraftCode: "TEST",
+			GeneratedAt:    now,
+			Passes: []dsn.Pass{
+				// Below the default 34m mask at Goldstone
+				{Complex: dsn.ComplexGoldstone, Start: now.Add(time.Hour), End: now.Add(2 * time.Hour), MaxElDeg: 8, Status: dsn.PassNext},
+				// Marginal for the linked 70m at Madrid
+				{Complex: dsn.ComplexMadrid, Start: now.Add(3 * time.Hour), End: now.Add(4 * time.Hour), MaxElDeg: 8, Status: dsn.PassFuture},
+			},
+		},
+	})
+	m.selectedID = 1
+
+	out := m.renderPassPanel()
+	if !strings.Contains(out, "8°x") {
+		t.Error("Goldstone pass below 34m mask should be flagged x")
+	}
+	if !strings.Contains(out, "8°!") {
+		t.Error("Madrid pass near 70m mask should be flagged !")
+	}
+	if !strings.Contains(out, "below antenna mask") {
+		t.Error("flagged passes should show the mask legend")
+	}
+}
+
+func TestMissionDetailAddNote(t *testing.T) {
+	store, err := notes.Open(filepath.Join(t.TempDir(), "notes.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := New(nil, nil).SetNotes(store)
+	m.viewMode = ViewMissionDetail
+	m.missionDetail = m.missionDetail.UpdateData(state.Snapshot{
+		Spacecraft: []dsn.Spacecraft{{ID: 31, Name: "VGR1"}},
+	})
+
+	press := func(key tea.KeyMsg) tea.Cmd {
+		updated, cmd := m.Update(key)
+		m = updated.(Model)
+		return cmd
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if !m.missionDetail.Editing() {
+		t.Fatal("n should start a note")
+	}
+
+	// View and quit keys are typed into the note
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q2")})
+	press(tea.KeyMsg{Type: tea.KeySpace})
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("safe mode")})
+	if m.viewMode != ViewMissionDetail {
+		t.Fatal("typing a note switched views")
+	}
+
+	cmd := press(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.missionDetail.Editing() || cmd == nil {
+		t.Fatal("enter should finish the note and save it")
+	}
+	var saved tea.Msg
+	for _, msg := range collectMsgs(cmd) {
+		if _, ok := msg.(noteSavedMsg); ok {
+			saved = msg
+		}
+	}
+	if saved == nil {
+		t.Fatal("no noteSavedMsg")
+	}
+	updated, _ := m.Update(saved)
+	m = updated.(Model)
+
+	got := store.For("VGR1")
+	if len(got) != 1 || got[0].Text != "q2 safe mode" {
+		t.Fatalf("notes = %+v, want the typed note", got)
+	}
+	if !strings.Contains(m.missionDetail.renderNotes("VGR1"), "q2 safe mode") {
+		t.Error("saved note not listed in the mission view")
+	}
+	if !strings.Contains(m.statusMsg, "Note saved") {
+		t.Errorf("statusMsg = %q", m.statusMsg)
+	}
+}
+
+func TestMissionDetailNoteCancel(t *testing.T) {
+	store, _ := notes.Open(filepath.Join(t.TempDir(), "notes.jsonl"))
+	m := NewMissionDetailModel().SetNotes(store).UpdateData(state.Snapshot{
+		Spacecraft: []dsn.Spacecraft{{ID: 31, Name: "VGR1"}},
+	})
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("oops")})
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.Editing() || cmd != nil || len(store.For("VGR1")) != 0 {
+		t.Error("esc should drop the note without saving")
+	}
+}
+
+// collectMsgs runs cmd, expanding batches, and returns the messages.
+func collectMsgs(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	msg := cmd()
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		var msgs []tea.Msg
+		for _, c := range batch {
+			msgs = append(msgs, collectMsgs(c)...)
+		}
+		return msgs
+	}
+	return []tea.Msg{msg}
+}
+
+func TestMissionDetailDoppler(t *testing.T) {
+	mgr := state.NewManager(state.DefaultConfig())
+	feed := time.Date(2025, 12, 5, 12, 0, 0, 0, time.UTC)
+	update := func(i int) {
+		// RTLT shrinking by 1 ms a minute: approaching at ~2.5 km/s
+		mgr.Update(&dsn.DSNData{
+			Timestamp: feed.Add(time.Duration(i) * time.Minute),
+			Links: []dsn.Link{{SpacecraftID: 74, Spacecraft: "MRO", AntennaID: "DSS14", Band: "X",
+				RTLT: 1000 - float64(i)*0.001, Distance: 1.5e8, DataRate: 1e6}},
+		}, 0, nil)
+	}
+	update(0)
+	mgr.SetFocusedSpacecraft(74)
+
+	m := NewMissionDetailModel().SetSize(120, 60).UpdateData(mgr.Snapshot())
+	if out := m.View(); !strings.Contains(out, "Estimating from RTLT... (X @ 8420 MHz)") {
+		t.Errorf("single sample should still be estimating:\n%s", out)
+	}
+
+	for i := 1; i <= 10; i++ {
+		update(i)
+	}
+	m = m.UpdateData(mgr.Snapshot())
+	// -2.50 km/s * 8420 MHz / c
+	if out := m.View(); !strings.Contains(out, "-70.17 kHz (approaching 2.50 km/s, X @ 8420 MHz)") {
+		t.Errorf("view missing the Doppler estimate:\n%s", out)
+	}
+}
+
+func TestMissionDetailMarginForecast(t *testing.T) {
+	now := time.Date(2025, 12, 5, 12, 0, 0, 0, time.UTC)
+	trace := &dsn.ElevationTrace{SpacecraftCode: "MRO", Complex: dsn.ComplexGoldstone, Antenna: "DSS14"}
+	for min := 0; min <= 120; min += 5 {
+		trace.Samples = append(trace.Samples, dsn.ElevationSample{
+			Time: now.Add(time.Duration(min) * time.Minute), Elevation: 60 - float64(min)*0.5,
+		})
+	}
+	sc := &dsn.Spacecraft{ID: 74, Name: "MRO", Links: []dsn.Link{
+		{SpacecraftID: 74, Spacecraft: "MRO", AntennaID: "DSS14", Complex: dsn.ComplexGoldstone, Distance: 1e6, DataRate: 1e6},
+	}}
+
+	m := NewMissionDetailModel()
+	m.snapshot = state.Snapshot{
+		ElevationTrace: trace,
+		PassPlan: &dsn.PassPlan{Passes: []dsn.Pass{
+			{Complex: dsn.ComplexGoldstone, Start: now.Add(-time.Hour), End: now.Add(40 * time.Minute), Status: dsn.PassNow},
+		}},
+	}
+	if got := m.renderMarginForecast(sc, now); !strings.Contains(got, "margin shrinking, good to end of pass (~40 min)") {
+		t.Errorf("margin = %q, want good to the pass end", got)
+	}
+
+	// No link at the trace's antenna, nothing to project
+	m.snapshot.ElevationTrace.Antenna = "DSS15"
+	if got := m.renderMarginForecast(sc, now); got != "" {
+		t.Errorf("margin = %q, want none", got)
+	}
+}
+
+func TestMissionDetailHandoff(t *testing.T) {
+	now := time.Date(2025, 12, 5, 12, 0, 0, 0, time.UTC)
+	link := dsn.Link{SpacecraftID: 74, Spacecraft: "MRO", AntennaID: "DSS14", Complex: dsn.ComplexGoldstone}
+
+	m := NewMissionDetailModel()
+	if got := m.renderHandoff(link, now); got != "" {
+		t.Errorf("handoff = %q with no pass plan, want none", got)
+	}
+	m.snapshot = state.Snapshot{
+		PassPlan: &dsn.PassPlan{Passes: []dsn.Pass{
+			{Complex: dsn.ComplexGoldstone, Start: now.Add(-time.Hour), End: now.Add(50 * time.Minute), Status: dsn.PassNow},
+			{Complex: dsn.ComplexMadrid, Start: now.Add(34 * time.Minute), End: now.Add(8 * time.Hour), Status: dsn.PassNext},
+		}},
+	}
+	if got := m.renderHandoff(link, now); !strings.Contains(got, "handoff in ~42m → Madrid") {
+		t.Errorf("handoff = %q, want ~42m to Madrid", got)
+	}
+}
+
+func TestMissionDetailPinnedTabs(t *testing.T) {
+	key := func(r rune) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}} }
+	m := NewMissionDetailModel().UpdateData(state.Snapshot{
+		Spacecraft: []dsn.Spacecraft{
+			{ID: 1, Name: "VGR1"},
+			{ID: 2, Name: "VGR2"},
+			{ID: 3, Name: "NHPC"},
+		},
+	})
+
+	// Pin VGR1 scrolled down with the pass panel hidden
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m, _ = m.Update(key('h'))
+	m, _ = m.Update(key('p'))
+	// Pin NHPC and show its pass panel again
+	m, _ = m.Update(key(']'))
+	m, _ = m.Update(key(']'))
+	m, _ = m.Update(key('p'))
+	m, _ = m.Update(key('h'))
+	if got := m.PinnedSpacecraft(); len(got) != 2 || got[0] != 1 || got[1] != 3 {
+		t.Fatalf("pinned = %v, want [1 3]", got)
+	}
+	if !strings.Contains(m.View(), "2 NHPC") {
+		t.Error("view should list the pinned tabs")
+	}
+
+	// Cycling restores VGR1's own scroll and panel state
+	m, cmd := m.Update(key('P'))
+	if m.SelectedSpacecraftID() != 1 || m.scrollY != 1 || m.ShowPassPanel() {
+		t.Errorf("tab 1: id %d scroll %d panel %v, want 1, 1, false", m.SelectedSpacecraftID(), m.scrollY, m.ShowPassPanel())
+	}
+	if msg, ok := cmd().(SpacecraftChangedMsg); !ok || msg.SpacecraftID != 1 {
+		t.Errorf("cycling should announce the spacecraft, got %v", msg)
+	}
+	m, _ = m.Update(key('P'))
+	if m.SelectedSpacecraftID() != 3 || m.scrollY != 0 || !m.ShowPassPanel() {
+		t.Errorf("tab 2: id %d scroll %d panel %v, want 3, 0, true", m.SelectedSpacecraftID(), m.scrollY, m.ShowPassPanel())
+	}
+
+	// Unpinning leaves the spacecraft shown
+	m, _ = m.Update(key('p'))
+	if got := m.PinnedSpacecraft(); len(got) != 1 || got[0] != 1 {
+		t.Errorf("pinned = %v after unpinning, want [1]", got)
+	}
+	if m.SelectedSpacecraftID() != 3 {
+		t.Errorf("selected %d after unpinning, want 3", m.SelectedSpacecraftID())
+	}
+}
+
+func TestMissionDetailPolarPlot(t *testing.T) {
+	key := func(r rune) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}} }
+	now := time.Now()
+	// A fixed point north of the equator passes over every complex daily
+	var samples []astro.RADecAtTime
+	for at := now; !at.After(now.Add(24 * time.Hour)); at = at.Add(dsn.PassSampleInterval) {
+		samples = append(samples, astro.RADecAtTime{Time: at, RAdeg: 100, DecDeg: 20})
+	}
+	plan := dsn.ComputePassPlan("TEST", dsn.PassElevations{}, samples, now)
+	if len(plan.Passes) < 3 {
+		t.Fatalf("plan has %d passes", len(plan.Passes))
+	}
+	m := NewMissionDetailModel().SetSize(100, 60).UpdateData(state.Snapshot{
+		Spacecraft:  []dsn.Spacecraft{{ID: 1, Name: "TEST"}},
+		PassPlan:    plan,
+		PassSamples: samples,
+	})
+	m.selectedID = 1
+
+	if strings.Contains(m.renderPassPanel(), "ALT-AZ") {
+		t.Error("the alt-az plot should be off by default")
+	}
+	m, _ = m.Update(key('a'))
+	out := m.renderPassPanel()
+	first := m.plottedPass()
+	for _, want := range []string{fmt.Sprintf("pass %d of %d", first+1, len(plan.Passes)), "◂ plotted", "○", "●", "×"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+
+	m, _ = m.Update(key('.'))
+	if got := m.plottedPass(); got != first+1 {
+		t.Errorf(". plotted pass %d, want %d", got, first+1)
+	}
+	for range len(plan.Passes) {
+		m, _ = m.Update(key(','))
+	}
+	if got := m.plottedPass(); got != 0 {
+		t.Errorf(", past the start plotted pass %d, want 0", got)
+	}
+}
+
+func TestPassElevationNote(t *testing.T) {
+	if got := passElevationNote(dsn.PassElevations{}, "JUNO"); got != "" {
+		t.Errorf("with defaults, note = %q, want none", got)
+	}
+	elev := dsn.PassElevations{
+		Complex:    map[dsn.Complex]float64{dsn.ComplexMadrid: 8},
+		Spacecraft: map[string]float64{"JUNO": 12.5},
+	}
+	if got, want := passElevationNote(elev, "JNO"), "Passes above GDS 12.5° · CDS 12.5° · MDS 12.5°"; got != want {
+		t.Errorf("note = %q, want %q", got, want)
+	}
+	if got, want := passElevationNote(elev, "MRO"), "Passes above GDS 5° · CDS 5° · MDS 8°"; got != want {
+		t.Errorf("note = %q, want %q", got, want)
+	}
+}
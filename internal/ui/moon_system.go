@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"math"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/litescript/ls-horizons/internal/astro"
+	"github.com/litescript/ls-horizons/internal/dsn"
+)
+
+// Moon systems: when zoomed to at least moonZoomScale on a planet with
+// major moons, they and the spacecraft among them are drawn around it on
+// their own linear scale, fetched from Horizons every moonRefreshInterval
+const (
+	moonZoomScale       = 5.0
+	moonRefreshInterval = 10 * time.Minute
+)
+
+// Spacecraft within moonCandidateReach system radii of the planet are
+// fetched with its moons; those within moonInsetReach are drawn among them.
+const (
+	moonCandidateReach = 4.0
+	moonInsetReach     = 1.5
+)
+
+// glyphSatellite marks a moon (glyphMoon is Earth's Moon in the sky view).
+const glyphSatellite = '◦'
+
+// planetSystemMsg is sent when a planet system fetch completes.
+type planetSystemMsg struct {
+	parent string
+	system dsn.PlanetSystem
+	err    error
+}
+
+// SetMoonProvider sets the provider of heliocentric positions for moons
+// and the spacecraft orbiting with them. Without one, no moons are drawn.
+func (m SolarSystemModel) SetMoonProvider(p dsn.SolarSystemProvider) SolarSystemModel {
+	m.moonProvider = p
+	return m
+}
+
+// fetchMoonsForFocus starts fetching the focused planet's moons when
+// zoomed in on it, unless they are fresh or already being fetched.
+func (m SolarSystemModel) fetchMoonsForFocus() (SolarSystemModel, tea.Cmd) {
+	planet := m.FocusedBody()
+	if m.moonProvider == nil || planet == nil || planet.Kind != dsn.BodyPlanet || m.scale() < moonZoomScale {
+		return m, nil
+	}
+	radius := dsn.SystemRadiusAU(planet.Code)
+	if radius == 0 || m.systemPending == planet.Code {
+		return m, nil
+	}
+	if sys, ok := m.systems[planet.Code]; ok && time.Since(sys.Time) < moonRefreshInterval {
+		return m, nil
+	}
+
+	var candidates []dsn.EclipticBody
+	for _, body := range m.solarSnap.Bodies {
+		if body.Kind == dsn.BodySpacecraft && body.Pos.Sub(planet.Pos).Norm() < moonCandidateReach*radius {
+			candidates = append(candidates, body)
+		}
+	}
+
+	m.systemPending = planet.Code
+	provider, parent := m.moonProvider, planet.Code
+	return m, func() tea.Msg {
+		sys, err := dsn.FetchPlanetSystem(provider, parent, candidates, time.Now())
+		return planetSystemMsg{parent: parent, system: sys, err: err}
+	}
+}
+
+// storeSystem caches a fetched planet system; a failed fetch keeps the
+// last one.
+func (m SolarSystemModel) storeSystem(msg planetSystemMsg) SolarSystemModel {
+	if m.systemPending == msg.parent {
+		m.systemPending = ""
+	}
+	if msg.err != nil {
+		return m
+	}
+	if m.systems == nil {
+		m.systems = make(map[string]dsn.PlanetSystem)
+	}
+	m.systems[msg.parent] = msg.system
+	return m
+}
+
+// shownSystem returns the moons and nearby spacecraft to draw around the
+// focused planet, if zoomed in on one whose system has been fetched.
+func (m SolarSystemModel) shownSystem() (dsn.PlanetSystem, bool) {
+	planet := m.FocusedBody()
+	if planet == nil || planet.Kind != dsn.BodyPlanet || m.scale() < moonZoomScale || m.split {
+		return dsn.PlanetSystem{}, false
+	}
+	sys, ok := m.systems[planet.Code]
+	if !ok {
+		return dsn.PlanetSystem{}, false
+	}
+
+	radius := dsn.SystemRadiusAU(planet.Code)
+	shown := dsn.PlanetSystem{Parent: sys.Parent, Time: sys.Time}
+	for _, body := range sys.Bodies {
+		if body.Kind == dsn.BodyMoon || body.Pos.Norm() < moonInsetReach*radius {
+			shown.Bodies = append(shown.Bodies, body)
+		}
+	}
+	return shown, true
+}
+
+// drawMoonSystem draws a planet's moons and the spacecraft among them
+// around the planet at (px, py), turned to cfg's view orientation with the
+// system radius spanning a third of the pane, and returns positions with
+// theirs added for labeling.
+func (m SolarSystemModel) drawMoonSystem(grid [][]rune, sys dsn.PlanetSystem, px, py int, cfg astro.ProjectionConfig, positions []bodyPos) []bodyPos {
+	h := len(grid)
+	w := len(grid[0])
+	cellsPerAU := float64(min(w, h)) / 3 / dsn.SystemRadiusAU(sys.Parent)
+
+	for _, body := range sys.Bodies {
+		// Oriented like the rest of the view; the inset is linear
+		x, y := cfg.Rotate(body.Pos)
+		sx := px + int(math.Round(x*cellsPerAU))
+		sy := py - int(math.Round(y*cellsPerAU))
+		if sx < 0 || sx >= w || sy < 0 || sy >= h || (sx == px && sy == py) {
+			continue // off-screen, or too close to tell from the planet
+		}
+
+		glyph := glyphSatellite
+		if body.Kind == dsn.BodySpacecraft {
+			glyph = m.getBodyGlyph(body, false)
+		}
+		grid[sy][sx] = glyph
+		positions = append(positions, bodyPos{x: sx, y: sy, name: body.Name, kind: body.Kind, inSystem: true})
+	}
+	return positions
+}
+
+// insetCodes returns the codes of the spacecraft drawn in a planet system
+// rather than at their own place in the view.
+func insetCodes(sys dsn.PlanetSystem) map[string]bool {
+	codes := make(map[string]bool)
+	for _, body := range sys.Bodies {
+		if body.Kind == dsn.BodySpacecraft {
+			codes[body.Code] = true
+		}
+	}
+	return codes
+}
@@ -0,0 +1,135 @@
+package ui
+
+import (
+	"math"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/litescript/ls-horizons/internal/astro"
+	"github.com/litescript/ls-horizons/internal/dsn"
+	"github.com/litescript/ls-horizons/internal/ephem"
+)
+
+// Orbit trails: heliocentric vectors either side of now for the focused
+// spacecraft, in one Horizons request, fetched again after
+// trailRefreshInterval
+const (
+	trailSpan            = 30 * 24 * time.Hour
+	trailStep            = 12 * time.Hour
+	trailRefreshInterval = 6 * time.Hour
+)
+
+// Trail colors: the past fades from the spacecraft's green over
+// trailSpan; the future is a faint gray
+var trailFade = []lipgloss.Color{"46", "34", "28", "22"}
+
+const colorTrailFuture = lipgloss.Color("238")
+
+// orbitTrail is a spacecraft's heliocentric path around the time it was
+// fetched.
+type orbitTrail struct {
+	times     []time.Time
+	pos       []astro.Vec3
+	fetchedAt time.Time
+}
+
+// trailFetchMsg is sent when a trail fetch completes.
+type trailFetchMsg struct {
+	code  string
+	trail orbitTrail
+	err   error
+}
+
+// SetTrailProvider sets the provider of heliocentric vectors for orbit
+// trails. Without one, no trails are drawn.
+func (m SolarSystemModel) SetTrailProvider(p dsn.SolarSystemSeriesProvider) SolarSystemModel {
+	m.trailProvider = p
+	return m
+}
+
+// fetchTrailForFocus starts fetching the focused spacecraft's trail unless
+// a recent one is cached or a fetch is already running.
+func (m SolarSystemModel) fetchTrailForFocus() (SolarSystemModel, tea.Cmd) {
+	body := m.FocusedBody()
+	if m.trailProvider == nil || body == nil || body.Kind != dsn.BodySpacecraft {
+		return m, nil
+	}
+	naifID := ephem.GetNAIFID(body.Code)
+	if naifID == 0 || m.trailPending == body.Code {
+		return m, nil
+	}
+	if t, ok := m.trails[body.Code]; ok && time.Since(t.fetchedAt) < trailRefreshInterval {
+		return m, nil
+	}
+
+	m.trailPending = body.Code
+	provider, code := m.trailProvider, body.Code
+	now := time.Now()
+	var times []time.Time
+	for t := now.Add(-trailSpan).Truncate(trailStep); !t.After(now.Add(trailSpan)); t = t.Add(trailStep) {
+		times = append(times, t)
+	}
+	return m, func() tea.Msg {
+		pos, err := provider.GetHeliocentricPositions(int(naifID), times)
+		return trailFetchMsg{code: code, trail: orbitTrail{times: times, pos: pos, fetchedAt: now}, err: err}
+	}
+}
+
+// storeTrail caches a fetched trail. A failed fetch is cached empty, so it
+// isn't retried until trailRefreshInterval has passed.
+func (m SolarSystemModel) storeTrail(msg trailFetchMsg) SolarSystemModel {
+	if m.trailPending == msg.code {
+		m.trailPending = ""
+	}
+	if msg.err != nil || len(msg.trail.pos) != len(msg.trail.times) {
+		msg.trail.times, msg.trail.pos = nil, nil
+	}
+	if m.trails == nil {
+		m.trails = make(map[string]orbitTrail)
+	}
+	m.trails[msg.code] = msg.trail
+	return m
+}
+
+// drawTrail draws the focused spacecraft's trail in braille: the past
+// fading with age, the future faint. The split is now, or when the light
+// now reaching Earth left, matching where the spacecraft is plotted.
+func (m SolarSystemModel) drawTrail(bc *brailleCanvas, originX, originY int, displayScale float64, cfg astro.ProjectionConfig, now time.Time) {
+	body := m.FocusedBody()
+	if body == nil || body.Kind != dsn.BodySpacecraft {
+		return
+	}
+	trail := m.trails[body.Code]
+	if len(trail.pos) < 2 {
+		return
+	}
+	split := now
+	if !m.geometric {
+		split = now.Add(-body.LightTime)
+	}
+
+	screen := func(p astro.Vec3) (float64, float64) {
+		proj := astro.ProjectEcliptic(p, cfg)
+		return float64(originX) + proj.X*displayScale + 0.5, float64(originY) - proj.Y*displayScale + 0.5
+	}
+	for i := 1; i < len(trail.pos); i++ {
+		mid := trail.times[i-1].Add(trail.times[i].Sub(trail.times[i-1]) / 2)
+		color := colorTrailFuture
+		if mid.Before(split) {
+			level := int(split.Sub(mid) * time.Duration(len(trailFade)) / trailSpan)
+			color = trailFade[min(level, len(trailFade)-1)]
+		}
+		ax, ay := screen(trail.pos[i-1])
+		bx, by := screen(trail.pos[i])
+		steps := int(math.Ceil(math.Hypot(bx-ax, 2*(by-ay)) * 4))
+		if steps > 4*(bc.width+bc.height) {
+			continue // too long to trace at this zoom
+		}
+		for s := 0; s <= steps; s++ {
+			f := float64(s) / float64(max(steps, 1))
+			bc.setPixel(ax+f*(bx-ax), ay+f*(by-ay), color)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+	"github.com/litescript/ls-horizons/internal/sandbox"
+)
+
+// paletteAction is a command listed in the ctrl+p palette. Most run by
+// pressing their keys; those without a key run run.
+type paletteAction struct {
+	name string
+	hint string       // key shown beside the name
+	keys []tea.KeyMsg // pressed in order: a view's key, then its own
+	run  func(Model) (Model, tea.Cmd)
+}
+
+// runeKeys returns key presses for single-character keys.
+func runeKeys(keys ...rune) []tea.KeyMsg {
+	msgs := make([]tea.KeyMsg, len(keys))
+	for i, r := range keys {
+		msgs[i] = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+	}
+	return msgs
+}
+
+// paletteActions are the commands in the palette, in the order listed.
+var paletteActions = []paletteAction{
+	{name: "View: Dashboard", hint: "1", keys: runeKeys('1')},
+	{name: "View: Mission", hint: "2", keys: runeKeys('2')},
+	{name: "View: Sky", hint: "3", keys: runeKeys('3')},
+	{name: "View: Orbit", hint: "4", keys: runeKeys('4')},
+	{name: "View: Events", hint: "5", keys: runeKeys('5')},
+	{name: "Find spacecraft", hint: "/", keys: runeKeys('/')},
+	{name: "Sky: cycle labels", hint: "l", keys: runeKeys('3', 'l')},
+	{name: "Sky: cycle complex filter", hint: "c", keys: runeKeys('3', 'c')},
+	{name: "Sky: toggle trajectory path", hint: "p", keys: runeKeys('3', 'p')},
+	{name: "Sky: toggle visibility", hint: "v", keys: runeKeys('3', 'v')},
+	{name: "Orbit: cycle labels", hint: "l", keys: runeKeys('4', 'l')},
+	{name: "Orbit: cycle scale mode", hint: "z", keys: runeKeys('4', 'z')},
+	{name: "Orbit: toggle stars", hint: "t", keys: runeKeys('4', 't')},
+	{name: "Orbit: apparent/geometric positions", hint: "a", keys: runeKeys('4', 'a')},
+	{name: "Orbit: split inner/outer", hint: "v", keys: runeKeys('4', 'v')},
+	{name: "Orbit: toggle 3D view", hint: "x", keys: runeKeys('4', 'x')},
+	{name: "Orbit: refresh positions", hint: "R", keys: runeKeys('4', 'R')},
+	{name: "Toggle watchlist", hint: "w", keys: runeKeys('w')},
+	{name: "Bookmark this moment", hint: "b", keys: runeKeys('b')},
+	{name: "Browse bookmarks", hint: "B", keys: runeKeys('B')},
+	{name: "Refresh DSN data now", run: Model.forceRefresh},
+	{name: "Export snapshot (JSON)", run: Model.exportSnapshot},
+	{name: "Reload config", hint: "ctrl+r", keys: []tea.KeyMsg{{Type: tea.KeyCtrlR}}},
+	{name: "Check for updates", hint: "u", keys: runeKeys('u')},
+	{name: "About this view", hint: "i", keys: runeKeys('i')},
+	{name: "Quit", hint: "q", keys: runeKeys('q')},
+}
+
+// snapshotExportedMsg reports the result of exporting a snapshot.
+type snapshotExportedMsg struct {
+	path string
+	err  error
+}
+
+// SetRefresher enables forcing a DSN fetch from the palette; refresh asks
+// the fetch loop for one (nil disables it).
+func (m Model) SetRefresher(refresh func()) Model {
+	m.refreshNow = refresh
+	return m
+}
+
+// startPalette opens the command palette.
+func (m Model) startPalette() Model {
+	m.palette = true
+	m.paletteQuery = nil
+	m.paletteCursor = 0
+	return m
+}
+
+// paletteMatches returns the actions whose name contains every word of the
+// query, case- and accent-insensitively.
+func (m Model) paletteMatches() []paletteAction {
+	words := strings.Fields(dsn.FoldName(string(m.paletteQuery)))
+	var matches []paletteAction
+	for _, action := range paletteActions {
+		name := dsn.FoldName(action.name)
+		all := true
+		for _, w := range words {
+			if !strings.Contains(name, w) {
+				all = false
+				break
+			}
+		}
+		if all {
+			matches = append(matches, action)
+		}
+	}
+	return matches
+}
+
+// updatePalette handles keys while the palette is open: typing narrows
+// the list, up/down pick an action, enter runs it.
+func (m Model) updatePalette(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc, tea.KeyCtrlP:
+		m.palette = false
+		return m, nil
+	case tea.KeyEnter:
+		m.palette = false
+		matches := m.paletteMatches()
+		if m.paletteCursor >= len(matches) {
+			m.statusMsg = fmt.Sprintf("No command matching %q", string(m.paletteQuery))
+			return m, nil
+		}
+		return m.runAction(matches[m.paletteCursor])
+	case tea.KeyUp:
+		if m.paletteCursor > 0 {
+			m.paletteCursor--
+		}
+		return m, nil
+	case tea.KeyDown, tea.KeyCtrlN:
+		if m.paletteCursor < len(m.paletteMatches())-1 {
+			m.paletteCursor++
+		}
+		return m, nil
+	case tea.KeyBackspace:
+		if len(m.paletteQuery) > 0 {
+			m.paletteQuery = m.paletteQuery[:len(m.paletteQuery)-1]
+		}
+	case tea.KeySpace:
+		m.paletteQuery = append(m.paletteQuery, ' ')
+	case tea.KeyRunes:
+		m.paletteQuery = append(m.paletteQuery, msg.Runes...)
+	default:
+		return m, nil
+	}
+	m.paletteCursor = 0
+	return m, nil
+}
+
+// runAction runs a palette action as if its keys had been pressed.
+func (m Model) runAction(action paletteAction) (Model, tea.Cmd) {
+	if action.run != nil {
+		return action.run(m)
+	}
+	var cmds []tea.Cmd
+	for _, key := range action.keys {
+		updated, cmd := m.Update(key)
+		m = updated.(Model)
+		cmds = append(cmds, cmd)
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// forceRefresh asks for a DSN fetch ahead of the next scheduled one.
+func (m Model) forceRefresh() (Model, tea.Cmd) {
+	switch {
+	case m.replay > 0:
+		m.statusMsg = "Refresh is off while replaying"
+		return m, nil
+	case m.refreshNow == nil:
+		m.statusMsg = "Refresh is unavailable"
+		return m, nil
+	}
+	m.refreshNow()
+	m.statusMsg = "Refreshing DSN data..."
+	return m, nil
+}
+
+// exportSnapshot writes the current snapshot as JSON to the working
+// directory, named for its fetch time.
+func (m Model) exportSnapshot() (Model, tea.Cmd) {
+	if m.snapshot.Data == nil {
+		m.statusMsg = "No data to export yet"
+		return m, nil
+	}
+	export := dsn.ExportSnapshot(m.snapshot.Data, m.snapshot.LastFetch)
+	path := "ls-horizons-" + m.snapshot.LastFetch.UTC().Format("20060102-150405") + ".json"
+	return m, func() tea.Msg {
+		if err := sandbox.CheckWrite(path); err != nil {
+			return snapshotExportedMsg{path: path, err: err}
+		}
+		f, err := os.Create(path)
+		if err != nil {
+			return snapshotExportedMsg{path: path, err: err}
+		}
+		err = export.WriteJSON(f)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		return snapshotExportedMsg{path: path, err: err}
+	}
+}
+
+// renderPalette renders the command palette: the query and the matching
+// actions with their keys.
+func (m Model) renderPalette() string {
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("60"))
+	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("252"))
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Commands"))
+	b.WriteString("\n\n  ")
+	b.WriteString(dimStyle.Render("> ") + valueStyle.Render(string(m.paletteQuery)) + "█")
+	b.WriteString("\n\n")
+
+	matches := m.paletteMatches()
+	if len(matches) == 0 {
+		b.WriteString(dimStyle.Render("  No matching commands."))
+		return b.String()
+	}
+
+	// Keep the cursor on screen
+	rows := max(3, m.height-defaultHeaderLines-6)
+	start := max(0, m.paletteCursor-rows+1)
+	end := min(len(matches), start+rows)
+	for i := start; i < end; i++ {
+		action := matches[i]
+		line := fmt.Sprintf("%-38s %s", action.name, action.hint)
+		if i == m.paletteCursor {
+			b.WriteString(selectedRowStyle.Render("> " + line))
+		} else {
+			b.WriteString("  " + valueStyle.Render(fmt.Sprintf("%-38s ", action.name)) + dimStyle.Render(action.hint))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
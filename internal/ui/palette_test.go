@@ -0,0 +1,120 @@
+package ui
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+	"github.com/litescript/ls-horizons/internal/state"
+)
+
+func TestPalette_RunsActions(t *testing.T) {
+	mgr := state.NewManager(state.DefaultConfig())
+	mgr.Update(&dsn.DSNData{Timestamp: time.Date(2025, 12, 5, 6, 30, 0, 0, time.UTC), Links: []dsn.Link{
+		{SpacecraftID: 31, Spacecraft: "VGR1", AntennaID: "DSS63", Complex: dsn.ComplexMadrid},
+	}}, time.Second, nil)
+
+	refreshes := 0
+	m := New(mgr, nil).SetRefresher(func() { refreshes++ })
+	send := func(msg tea.Msg) tea.Cmd {
+		updated, cmd := m.Update(msg)
+		m = updated.(Model)
+		return cmd
+	}
+	keys := func(s string) {
+		for _, r := range s {
+			msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+			if r == ' ' {
+				msg = tea.KeyMsg{Type: tea.KeySpace}
+			}
+			send(msg)
+		}
+	}
+	send(tea.WindowSizeMsg{Width: 120, Height: 40})
+	send(DataUpdateMsg{Snapshot: mgr.Snapshot()})
+
+	// Typed keys filter the list rather than reaching the view
+	send(tea.KeyMsg{Type: tea.KeyCtrlP})
+	if !m.palette {
+		t.Fatal("ctrl+p should open the palette")
+	}
+	if got := len(m.paletteMatches()); got != len(paletteActions) {
+		t.Fatalf("empty query lists %d actions, want all %d", got, len(paletteActions))
+	}
+	keys("sky complex")
+	matches := m.paletteMatches()
+	if len(matches) != 1 || matches[0].name != "Sky: cycle complex filter" {
+		t.Fatalf("matches for %q = %+v", string(m.paletteQuery), matches)
+	}
+	if m.viewMode != ViewDashboard {
+		t.Fatal("query keys reached the view")
+	}
+
+	// A view's action switches to it before pressing its key
+	before := m.skyView.complex
+	send(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.palette || m.viewMode != ViewSky {
+		t.Fatalf("enter should close the palette and open the sky view: palette %v, view %v", m.palette, m.viewMode)
+	}
+	if m.skyView.complex == before {
+		t.Error("complex filter did not change")
+	}
+
+	send(tea.KeyMsg{Type: tea.KeyCtrlP})
+	keys("refresh dsn")
+	send(tea.KeyMsg{Type: tea.KeyEnter})
+	if refreshes != 1 {
+		t.Errorf("refreshes = %d, want 1", refreshes)
+	}
+
+	// Esc closes without running anything
+	send(tea.KeyMsg{Type: tea.KeyCtrlP})
+	keys("quit")
+	if cmd := send(tea.KeyMsg{Type: tea.KeyEsc}); cmd != nil || m.palette {
+		t.Fatal("esc should close the palette without quitting")
+	}
+
+	send(tea.KeyMsg{Type: tea.KeyCtrlP})
+	keys("nonsense")
+	send(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.palette || m.statusMsg == "" {
+		t.Error("enter with no match should close the palette with a status message")
+	}
+}
+
+func TestPalette_ExportSnapshot(t *testing.T) {
+	mgr := state.NewManager(state.DefaultConfig())
+	mgr.Update(&dsn.DSNData{Timestamp: time.Date(2025, 12, 5, 6, 30, 0, 0, time.UTC), Links: []dsn.Link{
+		{SpacecraftID: 31, Spacecraft: "VGR1", AntennaID: "DSS63", Complex: dsn.ComplexMadrid},
+	}}, time.Second, nil)
+	m := New(mgr, nil)
+	updated, _ := m.Update(DataUpdateMsg{Snapshot: mgr.Snapshot()})
+	m = updated.(Model)
+
+	t.Chdir(t.TempDir())
+	m, cmd := m.exportSnapshot()
+	msg, ok := cmd().(snapshotExportedMsg)
+	if !ok || msg.err != nil {
+		t.Fatalf("export = %+v", msg)
+	}
+	updated, _ = m.Update(msg)
+	if got := updated.(Model).statusMsg; got != "Snapshot exported to "+msg.path {
+		t.Errorf("status = %q", got)
+	}
+
+	data, err := os.ReadFile(msg.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var export dsn.SnapshotExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		t.Fatal(err)
+	}
+	if len(export.Links) != 1 || export.Links[0].Spacecraft != "VGR1" {
+		t.Errorf("exported links = %+v", export.Links)
+	}
+}
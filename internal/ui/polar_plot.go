@@ -0,0 +1,150 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+)
+
+// polarPlotRows is the height of the alt-az plot's disc in terminal rows.
+// Braille dots are about square, so the disc is twice as many columns
+// wide.
+const polarPlotRows = 11
+
+// Alt-az plot colors and markers
+const (
+	colorPolarRing = "#4A4A5A"
+	colorPolarRise = "#7AA2F7"
+	colorPolarPeak = "#E0AF68"
+	colorPolarSet  = "#F7768E"
+
+	glyphRise = '○'
+	glyphPeak = '●'
+	glyphSet  = '×'
+	glyphNow  = '◆'
+)
+
+// RenderPolarPlot draws a pass as seen from its complex: the sky as a disc
+// with the zenith at the center and the horizon at the rim, north up and
+// east right, with the track from rise through peak to set in braille
+// beside a legend of the rise, peak, and set times and azimuths. The part
+// of the track before now is dimmed and the current position marked.
+func RenderPolarPlot(p dsn.Pass, track []dsn.PassTrackPoint, now time.Time, charset Charset) string {
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+	if len(track) < 2 {
+		return dimStyle.Render("  No ephemeris samples cover this pass")
+	}
+
+	// The disc, with a cell of margin all round for the compass points
+	rows, cols := polarPlotRows+2, 2*polarPlotRows+2
+	canvas := make([][]rune, rows)
+	colors := make([][]lipgloss.Color, rows)
+	for y := range canvas {
+		canvas[y] = []rune(strings.Repeat(" ", cols))
+		colors[y] = make([]lipgloss.Color, cols)
+	}
+	bc := newBrailleCanvas(cols, rows, charset)
+
+	// Positions in cells: the disc's center and radius
+	cx, cy := float64(cols)/2, float64(rows)/2
+	radius := float64(polarPlotRows) / 2
+	project := func(azDeg, elDeg float64) (float64, float64) {
+		r := radius * (90 - math.Max(elDeg, 0)) / 90
+		az := azDeg * math.Pi / 180
+		// A cell is twice as tall as it is wide
+		return cx + 2*r*math.Sin(az), cy - r*math.Cos(az)
+	}
+
+	// Horizon, and a sparser ring at 45° elevation
+	for a := 0.0; a < 360; a += 2 {
+		x, y := project(a, 0)
+		bc.setPixel(x, y, colorPolarRing)
+		if math.Mod(a, 10) == 0 {
+			x, y = project(a, 45)
+			bc.setPixel(x, y, colorPolarRing)
+		}
+	}
+
+	// The track, drawn in short steps between samples
+	for i := 1; i < len(track); i++ {
+		a, b := track[i-1], track[i]
+		color := lipgloss.Color(colorPathFuture)
+		if b.Time.Before(now) {
+			color = colorPathPast
+		}
+		ax, ay := project(a.AzDeg, a.ElDeg)
+		bx, by := project(b.AzDeg, b.ElDeg)
+		steps := int(math.Ceil(math.Hypot(bx-ax, 2*(by-ay)) * 4))
+		for s := 0; s <= steps; s++ {
+			f := float64(s) / float64(max(steps, 1))
+			bc.setPixel(ax+f*(bx-ax), ay+f*(by-ay), color)
+		}
+	}
+	bc.render(canvas, colors)
+
+	// Compass points and markers over the braille
+	put := func(x, y float64, r rune, color lipgloss.Color) {
+		col, row := int(x), int(y)
+		if row >= 0 && row < rows && col >= 0 && col < cols {
+			canvas[row][col] = r
+			colors[row][col] = color
+		}
+	}
+	put(cx, 0, 'N', colorPolarRing)
+	put(cx, float64(rows-1), 'S', colorPolarRing)
+	put(0, cy, 'W', colorPolarRing)
+	put(float64(cols-1), cy, 'E', colorPolarRing)
+
+	rise, set := track[0], track[len(track)-1]
+	peak := rise
+	for _, pt := range track {
+		if pt.ElDeg > peak.ElDeg {
+			peak = pt
+		}
+	}
+	x, y := project(rise.AzDeg, rise.ElDeg)
+	put(x, y, glyphRise, colorPolarRise)
+	x, y = project(set.AzDeg, set.ElDeg)
+	put(x, y, glyphSet, colorPolarSet)
+	x, y = project(peak.AzDeg, peak.ElDeg)
+	put(x, y, glyphPeak, colorPolarPeak)
+	current, up := dsn.TrackAt(track, now)
+	if up {
+		x, y = project(current.AzDeg, current.ElDeg)
+		put(x, y, glyphNow, colorPathNow)
+	}
+
+	var plot []string
+	for y := range canvas {
+		var line strings.Builder
+		for x := range canvas[y] {
+			line.WriteString(lipgloss.NewStyle().Foreground(colors[y][x]).Render(string(canvas[y][x])))
+		}
+		plot = append(plot, line.String())
+	}
+
+	// Legend
+	mark := func(r rune, color lipgloss.Color) string {
+		return lipgloss.NewStyle().Foreground(color).Render(string(r))
+	}
+	legend := []string{
+		labelStyle.Render(fmt.Sprintf("%s pass  %s–%s UTC", dsn.ComplexShortName(p.Complex),
+			p.Start.UTC().Format("Mon 15:04"), p.End.UTC().Format("15:04"))),
+		"",
+		mark(glyphRise, colorPolarRise) + fmt.Sprintf(" rise  %s  az %3.0f°", rise.Time.UTC().Format("15:04"), rise.AzDeg),
+		mark(glyphPeak, colorPolarPeak) + fmt.Sprintf(" peak  %s  az %3.0f°  el %2.0f°", peak.Time.UTC().Format("15:04"), peak.AzDeg, peak.ElDeg),
+		mark(glyphSet, colorPolarSet) + fmt.Sprintf(" set   %s  az %3.0f°", set.Time.UTC().Format("15:04"), set.AzDeg),
+	}
+	if up {
+		legend = append(legend, mark(glyphNow, colorPathNow)+fmt.Sprintf(" now          az %3.0f°  el %2.0f°", current.AzDeg, current.ElDeg))
+	}
+	legend = append(legend, "", dimStyle.Render("zenith at center, north up"))
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, "  "+strings.Join(plot, "\n  "), "   ", strings.Join(legend, "\n"))
+}
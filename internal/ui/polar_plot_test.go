@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+)
+
+func TestRenderPolarPlot(t *testing.T) {
+	start := time.Date(2025, 3, 1, 4, 0, 0, 0, time.UTC)
+	p := dsn.Pass{Complex: dsn.ComplexGoldstone, Start: start, Peak: start.Add(4 * time.Hour), End: start.Add(8 * time.Hour), MaxElDeg: 60}
+	// Rising in the east, peaking south, setting in the west
+	track := []dsn.PassTrackPoint{
+		{Time: start, AzDeg: 100, ElDeg: 6},
+		{Time: start.Add(2 * time.Hour), AzDeg: 140, ElDeg: 40},
+		{Time: start.Add(4 * time.Hour), AzDeg: 180, ElDeg: 60},
+		{Time: start.Add(6 * time.Hour), AzDeg: 220, ElDeg: 40},
+		{Time: start.Add(8 * time.Hour), AzDeg: 260, ElDeg: 6},
+	}
+
+	for _, cs := range []Charset{CharsetBraille, CharsetASCII} {
+		out := RenderPolarPlot(p, track, start.Add(3*time.Hour), cs)
+		for _, want := range []string{"N", "E", "S", "W", "○", "●", "×", "◆",
+			"GDS pass", "rise  04:00  az 100°", "peak  08:00  az 180°  el 60°", "set   12:00  az 260°"} {
+			if !strings.Contains(out, want) {
+				t.Errorf("charset %v: missing %q in:\n%s", cs, want, out)
+			}
+		}
+		if got := strings.Count(out, "\n") + 1; got != polarPlotRows+2 {
+			t.Errorf("charset %v: %d lines, want %d", cs, got, polarPlotRows+2)
+		}
+	}
+
+	// After the pass there is no current position
+	if out := RenderPolarPlot(p, track, start.Add(9*time.Hour), CharsetBraille); strings.Contains(out, "◆") {
+		t.Error("a pass that has set should not mark a current position")
+	}
+	if out := RenderPolarPlot(p, nil, start, CharsetBraille); !strings.Contains(out, "No ephemeris samples") {
+		t.Errorf("no track: got %q", out)
+	}
+}
@@ -0,0 +1,38 @@
+package ui
+
+// Profile selects the overall layout density.
+type Profile int
+
+const (
+	// ProfileDefault is the full layout with logo and detailed tables.
+	ProfileDefault Profile = iota
+	// ProfileSmall targets 80×24 terminals and 40-column e-paper style
+	// displays: no logo, abbreviated names, one row per spacecraft.
+	ProfileSmall
+)
+
+// String returns the profile name.
+func (p Profile) String() string {
+	switch p {
+	case ProfileSmall:
+		return "small"
+	default:
+		return "default"
+	}
+}
+
+// ParseProfile parses a profile name. Unknown names select the default.
+func ParseProfile(s string) Profile {
+	switch s {
+	case "small", "compact":
+		return ProfileSmall
+	default:
+		return ProfileDefault
+	}
+}
+
+// Header heights (lines above the content area) per profile.
+const (
+	defaultHeaderLines = 15 // logo, tagline, version, tabs, footer
+	smallHeaderLines   = 3  // tabs and footer
+)
@@ -0,0 +1,44 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestParseProfile(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Profile
+	}{
+		{"small", ProfileSmall},
+		{"compact", ProfileSmall},
+		{"default", ProfileDefault},
+		{"", ProfileDefault},
+		{"bogus", ProfileDefault},
+	}
+	for _, tt := range tests {
+		if got := ParseProfile(tt.in); got != tt.want {
+			t.Errorf("ParseProfile(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSmallProfileHeader(t *testing.T) {
+	m := New(nil, nil).SetProfile(ProfileSmall)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 40, Height: 24})
+	m = updated.(Model)
+
+	header := m.renderHeader()
+	if strings.Contains(header, "██") {
+		t.Error("small profile header should not include the logo")
+	}
+	if w := lipgloss.Width(header); w > 40 {
+		t.Errorf("header width = %d, want <= 40", w)
+	}
+	if m.dashboard.height != 24-smallHeaderLines {
+		t.Errorf("dashboard height = %d, want %d", m.dashboard.height, 24-smallHeaderLines)
+	}
+}
@@ -0,0 +1,127 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+)
+
+// qualityMaxIssues is the number of individual issues listed under the counts.
+const qualityMaxIssues = 5
+
+// qualityLatencyWarn is the feed latency above which the value is highlighted.
+const qualityLatencyWarn = 30 * time.Second
+
+// RenderQualityPanel renders the Data Quality panel: feed latency, issue
+// counts per category with a sparkline over recent fetches, and the most
+// recent individual issues.
+// Format:
+//
+//	Data Quality
+//	  Feed latency      2.1s
+//	  Parse errors      0   ▁▁▁▁▁▁
+//	  Missing fields    1   ▁▁▃▁▁▃
+//	  Impossible values 0   ▁▁▁▁▁▁
+func RenderQualityPanel(current dsn.QualityReport, history []dsn.QualityReport) string {
+	var b strings.Builder
+
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("60"))
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("252"))
+	warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("208"))
+
+	b.WriteString(titleStyle.Render("Data Quality"))
+	b.WriteString("\n")
+
+	if current.AssessedAt.IsZero() {
+		b.WriteString(dimStyle.Render("  No fetches assessed yet"))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	// Feed latency
+	latency := current.FeedLatency.Round(100 * time.Millisecond).String()
+	b.WriteString("  " + labelStyle.Render(fmt.Sprintf("%-18s", "Feed latency")))
+	if current.FeedLatency > qualityLatencyWarn || current.FeedLatency < 0 {
+		b.WriteString(warnStyle.Render(latency))
+	} else {
+		b.WriteString(valueStyle.Render(latency))
+	}
+	b.WriteString("\n")
+
+	// Per-category counts with history sparkline
+	for _, c := range dsn.QualityCategories {
+		count := current.Count(c)
+		countStr := fmt.Sprintf("%-4d", count)
+		b.WriteString("  " + labelStyle.Render(fmt.Sprintf("%-18s", qualityCategoryLabel(c))))
+		if count > 0 {
+			b.WriteString(warnStyle.Render(countStr))
+		} else {
+			b.WriteString(valueStyle.Render(countStr))
+		}
+		b.WriteString(dimStyle.Render(qualitySparkline(history, c)))
+		b.WriteString("\n")
+	}
+
+	// Most recent individual issues
+	if len(current.Issues) > 0 {
+		b.WriteString(dimStyle.Render("  Latest issues:"))
+		b.WriteString("\n")
+		for i, issue := range current.Issues {
+			if i >= qualityMaxIssues {
+				b.WriteString(dimStyle.Render(fmt.Sprintf("    … %d more", len(current.Issues)-qualityMaxIssues)))
+				b.WriteString("\n")
+				break
+			}
+			b.WriteString(dimStyle.Render(fmt.Sprintf("    %-8s %s", truncate(issue.Subject, 8), issue.Detail)))
+			b.WriteString("\n")
+		}
+	}
+
+	return b.String()
+}
+
+// qualityCategoryLabel returns the display label for a quality category.
+func qualityCategoryLabel(c dsn.QualityCategory) string {
+	switch c {
+	case dsn.QualityParseError:
+		return "Parse errors"
+	case dsn.QualityMissingField:
+		return "Missing fields"
+	case dsn.QualityImpossibleValue:
+		return "Impossible values"
+	default:
+		return string(c)
+	}
+}
+
+// qualitySparkline renders issue counts for a category across history,
+// scaled to the largest count seen.
+func qualitySparkline(history []dsn.QualityReport, c dsn.QualityCategory) string {
+	if len(history) == 0 {
+		return ""
+	}
+
+	counts := make([]int, len(history))
+	maxCount := 0
+	for i, r := range history {
+		counts[i] = r.Count(c)
+		if counts[i] > maxCount {
+			maxCount = counts[i]
+		}
+	}
+
+	var sb strings.Builder
+	for _, n := range counts {
+		idx := 0
+		if maxCount > 0 {
+			idx = n * (len(sparklineBlocks) - 1) / maxCount
+		}
+		sb.WriteRune(sparklineBlocks[idx])
+	}
+	return sb.String()
+}
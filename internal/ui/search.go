@@ -0,0 +1,144 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+)
+
+// searchResultLimit is the most matches the search overlay lists.
+const searchResultLimit = 10
+
+// searchMatch is a spacecraft matching the search query.
+type searchMatch struct {
+	code    string
+	mission string
+	rank    int // 0: code prefix, 1: word prefix of the mission, 2: anywhere
+}
+
+// startSearch opens the spacecraft search overlay.
+func (m Model) startSearch() Model {
+	m.searching = true
+	m.searchQuery = nil
+	m.searchCursor = 0
+	return m
+}
+
+// searchMatches returns the spacecraft in view whose code or mission name
+// contains the query, case- and accent-insensitively: code prefixes first,
+// then mission word prefixes, then other matches, each alphabetical.
+func (m Model) searchMatches() []searchMatch {
+	query := dsn.FoldName(string(m.searchQuery))
+	var matches []searchMatch
+	for _, sc := range m.followedSnapshot().Spacecraft {
+		mission := sc.Name
+		if info := dsn.GetSpacecraftInfo(sc.Name); info != nil {
+			mission = info.Name
+		}
+		code, name := dsn.FoldName(sc.Name), dsn.FoldName(mission)
+
+		rank := -1
+		switch {
+		case strings.HasPrefix(code, query):
+			rank = 0
+		case strings.HasPrefix(name, query) || strings.Contains(name, " "+query):
+			rank = 1
+		case strings.Contains(code, query) || strings.Contains(name, query):
+			rank = 2
+		}
+		if rank >= 0 {
+			matches = append(matches, searchMatch{code: sc.Name, mission: mission, rank: rank})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].rank != matches[j].rank {
+			return matches[i].rank < matches[j].rank
+		}
+		return matches[i].code < matches[j].code
+	})
+	if len(matches) > searchResultLimit {
+		matches = matches[:searchResultLimit]
+	}
+	return matches
+}
+
+// updateSearch handles keys while the search overlay is open: typing
+// narrows the matches, up/down pick one, enter jumps to it.
+func (m Model) updateSearch(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.searching = false
+		return m, nil
+	case tea.KeyEnter:
+		m.searching = false
+		matches := m.searchMatches()
+		if m.searchCursor >= len(matches) {
+			m.statusMsg = fmt.Sprintf("No spacecraft matching %q", string(m.searchQuery))
+			return m, nil
+		}
+		return m.jumpToSpacecraft(matches[m.searchCursor].code)
+	case tea.KeyUp, tea.KeyCtrlP:
+		if m.searchCursor > 0 {
+			m.searchCursor--
+		}
+		return m, nil
+	case tea.KeyDown, tea.KeyCtrlN:
+		if m.searchCursor < len(m.searchMatches())-1 {
+			m.searchCursor++
+		}
+		return m, nil
+	case tea.KeyBackspace:
+		if len(m.searchQuery) > 0 {
+			m.searchQuery = m.searchQuery[:len(m.searchQuery)-1]
+		}
+	case tea.KeySpace:
+		m.searchQuery = append(m.searchQuery, ' ')
+	case tea.KeyRunes:
+		m.searchQuery = append(m.searchQuery, msg.Runes...)
+	default:
+		return m, nil
+	}
+	m.searchCursor = 0
+	return m, nil
+}
+
+// jumpToSpacecraft focuses the spacecraft with code in every view,
+// staying in the current one.
+func (m Model) jumpToSpacecraft(code string) (Model, tea.Cmd) {
+	m = m.focusSpacecraft(code)
+	var cmd tea.Cmd
+	m.solarSystem, cmd = m.solarSystem.FocusCode(code)
+	return m, cmd
+}
+
+// renderSearch renders the search overlay: the query and its matches.
+func (m Model) renderSearch() string {
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("60"))
+	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("252"))
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Find spacecraft"))
+	b.WriteString("\n\n  ")
+	b.WriteString(dimStyle.Render("/ ") + valueStyle.Render(string(m.searchQuery)) + "█")
+	b.WriteString("\n\n")
+
+	matches := m.searchMatches()
+	if len(matches) == 0 {
+		b.WriteString(dimStyle.Render("  No matching spacecraft in view."))
+	}
+	for i, match := range matches {
+		line := fmt.Sprintf("%-6s %s", match.code, match.mission)
+		if i == m.searchCursor {
+			b.WriteString(selectedRowStyle.Render("> " + line))
+		} else {
+			b.WriteString("  " + valueStyle.Render(line))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,105 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+	"github.com/litescript/ls-horizons/internal/state"
+)
+
+func TestSearch_JumpToSpacecraft(t *testing.T) {
+	mgr := state.NewManager(state.DefaultConfig())
+	mgr.Update(&dsn.DSNData{Timestamp: time.Date(2025, 12, 5, 6, 30, 0, 0, time.UTC), Links: []dsn.Link{
+		{SpacecraftID: 170, Spacecraft: "JWST", AntennaID: "DSS26", Complex: dsn.ComplexGoldstone},
+		{SpacecraftID: 31, Spacecraft: "VGR1", AntennaID: "DSS63", Complex: dsn.ComplexMadrid},
+		{SpacecraftID: 74, Spacecraft: "MRO", AntennaID: "DSS43", Complex: dsn.ComplexCanberra},
+	}}, time.Second, nil)
+
+	m := New(mgr, nil)
+	send := func(msg tea.Msg) {
+		updated, _ := m.Update(msg)
+		m = updated.(Model)
+	}
+	keys := func(s string) {
+		for _, r := range s {
+			send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+	}
+	send(tea.WindowSizeMsg{Width: 120, Height: 40})
+	send(DataUpdateMsg{Snapshot: mgr.Snapshot()})
+	keys("3")
+	if m.viewMode != ViewSky {
+		t.Fatal("3 should open the sky view")
+	}
+
+	// Typed keys filter the list rather than reaching the view
+	keys("/")
+	keys("voy")
+	if !m.searching || m.viewMode != ViewSky {
+		t.Fatalf("query keys left the search: searching %v, view %v", m.searching, m.viewMode)
+	}
+	matches := m.searchMatches()
+	if len(matches) != 1 || matches[0].code != "VGR1" {
+		t.Fatalf("matches for %q = %+v, want VGR1", string(m.searchQuery), matches)
+	}
+
+	send(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.searching || m.viewMode != ViewSky {
+		t.Fatalf("enter should close the search and stay in the sky view: searching %v, view %v", m.searching, m.viewMode)
+	}
+	if got := m.dashboard.GetSelectedSpacecraft(); got == nil || got.Code != "VGR1" {
+		t.Fatalf("selected = %+v, want VGR1", got)
+	}
+
+	// Esc closes without moving the selection
+	keys("/")
+	keys("jw")
+	send(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.searching || m.dashboard.GetSelectedSpacecraft().Code != "VGR1" {
+		t.Fatal("esc should close the search without jumping")
+	}
+}
+
+func TestSearch_Ranking(t *testing.T) {
+	mgr := state.NewManager(state.DefaultConfig())
+	mgr.Update(&dsn.DSNData{Timestamp: time.Date(2025, 12, 5, 6, 30, 0, 0, time.UTC), Links: []dsn.Link{
+		{SpacecraftID: 170, Spacecraft: "JWST", AntennaID: "DSS26", Complex: dsn.ComplexGoldstone},
+		{SpacecraftID: 31, Spacecraft: "VGR1", AntennaID: "DSS63", Complex: dsn.ComplexMadrid},
+		{SpacecraftID: 74, Spacecraft: "MRO", AntennaID: "DSS43", Complex: dsn.ComplexCanberra},
+	}}, time.Second, nil)
+
+	m := New(mgr, nil)
+	updated, _ := m.Update(DataUpdateMsg{Snapshot: mgr.Snapshot()})
+	m = updated.(Model).startSearch()
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"m", []string{"MRO", "JWST"}},  // code prefix before a match inside "James"
+		{"re", []string{"MRO"}},         // "Reconnaissance"
+		{"oyager", []string{"VGR1"}},    // anywhere in the name
+		{"TELESCOPE", []string{"JWST"}}, // case-insensitive
+		{"xyz", nil},
+	}
+	for _, tt := range tests {
+		m.searchQuery = []rune(tt.query)
+		var got []string
+		for _, match := range m.searchMatches() {
+			got = append(got, match.code)
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("%q: got %v, want %v", tt.query, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%q: got %v, want %v", tt.query, got, tt.want)
+				break
+			}
+		}
+	}
+}
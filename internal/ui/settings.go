@@ -0,0 +1,160 @@
+package ui
+
+import (
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"github.com/litescript/ls-horizons/internal/astro"
+)
+
+// Settings are user preferences layered over the built-in defaults,
+// typically from the config file. They can be reloaded at runtime.
+type Settings struct {
+	Refresh     time.Duration // DSN refresh interval (0 = unchanged)
+	DefaultView ViewMode      // View shown at startup
+	SkyLabels   LabelMode
+	OrbitLabels LabelMode
+	Theme       Theme
+
+	// SunAvoidance is the solar separation, in degrees, inside which the
+	// Sky view warns about the focused spacecraft.
+	SunAvoidance float64
+
+	// Stars is the Sky view's star catalog (empty = built-in), drawn down
+	// to magnitude StarMagLimit.
+	Stars        astro.StarCatalog
+	StarMagLimit float64
+}
+
+// DefaultSettings returns the settings used when no config is present.
+func DefaultSettings() Settings {
+	return Settings{
+		DefaultView:  ViewDashboard,
+		SkyLabels:    LabelFocused,
+		OrbitLabels:  LabelFocused,
+		Theme:        ThemeDefault,
+		SunAvoidance: DefaultSunAvoidance,
+		StarMagLimit: astro.DefaultStarMagLimit,
+	}
+}
+
+// SettingsLoader re-reads settings, e.g. from the config file.
+type SettingsLoader func() (Settings, error)
+
+// settingsReloadedMsg carries the result of a runtime settings reload.
+type settingsReloadedMsg struct {
+	settings Settings
+	err      error
+}
+
+// ParseViewMode parses a view name. Unknown names select the dashboard.
+func ParseViewMode(s string) ViewMode {
+	switch s {
+	case "mission", "detail":
+		return ViewMissionDetail
+	case "sky":
+		return ViewSky
+	case "orbit", "solar":
+		return ViewSolarSystem
+	case "events":
+		return ViewEvents
+	default:
+		return ViewDashboard
+	}
+}
+
+// ParseLabelMode parses a label mode name. Unknown names select LabelFocused.
+func ParseLabelMode(s string) LabelMode {
+	switch s {
+	case "none", "off":
+		return LabelNone
+	case "all":
+		return LabelAll
+	default:
+		return LabelFocused
+	}
+}
+
+// Theme selects the color treatment.
+type Theme int
+
+const (
+	// ThemeDefault uses the terminal's detected color support.
+	ThemeDefault Theme = iota
+	// ThemeMono renders without color, for monochrome or high-contrast use.
+	ThemeMono
+)
+
+// ParseTheme parses a theme name. Unknown names select the default.
+func ParseTheme(s string) Theme {
+	switch s {
+	case "mono", "monochrome", "none":
+		return ThemeMono
+	default:
+		return ThemeDefault
+	}
+}
+
+// detectedProfile is the terminal's color profile before any theme applied.
+var detectedProfile = sync.OnceValue(lipgloss.ColorProfile)
+
+// apply switches lipgloss rendering to the theme's color profile.
+func (t Theme) apply() {
+	profile := detectedProfile()
+	if t == ThemeMono {
+		profile = termenv.Ascii
+	}
+	lipgloss.SetColorProfile(profile)
+}
+
+// SetSettings applies settings at startup, including the initial view.
+func (m Model) SetSettings(s Settings) Model {
+	m.viewMode = s.DefaultView
+	return m.applySettings(s)
+}
+
+// SetSettingsLoader enables runtime reloading with ctrl+r.
+func (m Model) SetSettingsLoader(load SettingsLoader) Model {
+	m.loadSettings = load
+	return m
+}
+
+// applySettings applies everything but the initial view, which would
+// otherwise yank the user away from what they're looking at on reload.
+func (m Model) applySettings(s Settings) Model {
+	if s.Refresh > 0 && m.state != nil {
+		m.state.SetRefreshInterval(s.Refresh)
+	}
+	m.skyView.labelMode = s.SkyLabels
+	if s.SunAvoidance > 0 {
+		m.skyView.sunAvoidance = s.SunAvoidance
+	}
+	stars := s.Stars
+	if len(stars.Stars) == 0 {
+		stars = astro.DefaultStarCatalog()
+	}
+	limit := s.StarMagLimit
+	if limit == 0 {
+		limit = astro.DefaultStarMagLimit
+	}
+	m.skyView.starCatalog = stars.Brighter(limit)
+	m.solarSystem.labelMode = s.OrbitLabels
+	s.Theme.apply()
+	return m
+}
+
+// reloadSettings runs the settings loader off the event loop.
+func (m Model) reloadSettings() tea.Cmd {
+	load := m.loadSettings
+	if load == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		s, err := load()
+		return settingsReloadedMsg{settings: s, err: err}
+	}
+}
@@ -0,0 +1,127 @@
+package ui
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"github.com/litescript/ls-horizons/internal/astro"
+	"github.com/litescript/ls-horizons/internal/state"
+)
+
+func TestParseSettingsNames(t *testing.T) {
+	views := map[string]ViewMode{
+		"dashboard": ViewDashboard, "mission": ViewMissionDetail,
+		"sky": ViewSky, "orbit": ViewSolarSystem, "bogus": ViewDashboard,
+	}
+	for in, want := range views {
+		if got := ParseViewMode(in); got != want {
+			t.Errorf("ParseViewMode(%q) = %v, want %v", in, got, want)
+		}
+	}
+
+	labels := map[string]LabelMode{
+		"none": LabelNone, "focused": LabelFocused, "all": LabelAll, "": LabelFocused,
+	}
+	for in, want := range labels {
+		if got := ParseLabelMode(in); got != want {
+			t.Errorf("ParseLabelMode(%q) = %v, want %v", in, got, want)
+		}
+	}
+
+	if ParseTheme("mono") != ThemeMono || ParseTheme("") != ThemeDefault {
+		t.Error("ParseTheme: mono/default mismatch")
+	}
+}
+
+func TestSetSettings(t *testing.T) {
+	defer ThemeDefault.apply()
+
+	mgr := state.NewManager(state.DefaultConfig())
+	m := New(mgr, nil).SetSettings(Settings{
+		Refresh:     30 * time.Second,
+		DefaultView: ViewSky,
+		SkyLabels:   LabelAll,
+		OrbitLabels: LabelNone,
+		Theme:       ThemeMono,
+	})
+
+	if m.viewMode != ViewSky {
+		t.Errorf("viewMode = %v, want ViewSky", m.viewMode)
+	}
+	if m.skyView.labelMode != LabelAll || m.solarSystem.labelMode != LabelNone {
+		t.Errorf("label modes = %v/%v, want all/none", m.skyView.labelMode, m.solarSystem.labelMode)
+	}
+	if got := mgr.RefreshInterval(); got != 30*time.Second {
+		t.Errorf("RefreshInterval = %v, want 30s", got)
+	}
+	if lipgloss.ColorProfile() != termenv.Ascii {
+		t.Error("mono theme should render without color")
+	}
+}
+
+func TestSetSettings_Stars(t *testing.T) {
+	cat := astro.StarCatalog{Stars: []astro.Star{
+		{Name: "Sirius", RAdeg: 101.3, DecDeg: -16.7, Mag: -1.46},
+		{Name: "HIP 1", RAdeg: 0, DecDeg: 1.1, Mag: 5.9},
+		{Name: "HIP 2", RAdeg: 0, DecDeg: 1.2, Mag: 9.1},
+	}}
+	s := DefaultSettings()
+	s.Stars = cat
+	m := New(nil, nil).SetSettings(s)
+	if got := len(m.skyView.starCatalog.Stars); got != 2 {
+		t.Errorf("drew %d stars at the default limit, want 2", got)
+	}
+
+	s.StarMagLimit = 10
+	if got := len(m.applySettings(s).skyView.starCatalog.Stars); got != 3 {
+		t.Errorf("drew %d stars to mag 10, want 3", got)
+	}
+
+	// No catalog falls back to the built-in one
+	m = m.applySettings(DefaultSettings())
+	if got, want := len(m.skyView.starCatalog.Stars), len(astro.DefaultStarCatalog().Stars); got != want {
+		t.Errorf("drew %d stars, want the %d built in", got, want)
+	}
+}
+
+func TestSettingsReload(t *testing.T) {
+	defer ThemeDefault.apply()
+
+	var loadErr error
+	m := New(nil, nil).SetSettingsLoader(func() (Settings, error) {
+		s := DefaultSettings()
+		s.DefaultView = ViewSolarSystem
+		s.SkyLabels = LabelNone
+		return s, loadErr
+	})
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+	m = updated.(Model)
+	if cmd == nil {
+		t.Fatal("ctrl+r should start a reload")
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+
+	if m.skyView.labelMode != LabelNone {
+		t.Errorf("sky labels = %v, want LabelNone after reload", m.skyView.labelMode)
+	}
+	if m.viewMode != ViewDashboard {
+		t.Error("reload should not change the current view")
+	}
+	if m.statusMsg != "Config reloaded" {
+		t.Errorf("statusMsg = %q", m.statusMsg)
+	}
+
+	loadErr = errors.New("line 3: unknown key")
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+	updated, _ = updated.(Model).Update(cmd())
+	if got := updated.(Model).statusMsg; got != "Config reload failed: line 3: unknown key" {
+		t.Errorf("statusMsg = %q", got)
+	}
+}
@@ -0,0 +1,164 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+	"github.com/litescript/ls-horizons/internal/state"
+)
+
+// renderSignalHistory renders sparklines of the selected spacecraft's
+// round-trip light time and data rate over the history the state manager
+// keeps, so rate dips and range drift show, not just the latest values.
+// Format:
+//
+//	Signal History (last 1h 52m)
+//	RTLT            ▃▃▄▄▄▅▅▅▆▆▆▇▇▇  44.6 hr  +0.80 s
+//	Data Rate       ▇▇▇▇▂▁▁▇▇▇▇▇▇▇  160 bps  min 40 bps
+func (m MissionDetailModel) renderSignalHistory() string {
+	var b strings.Builder
+
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("205"))
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Width(16)
+	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("252"))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	rtltStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("39"))
+	rateStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
+
+	hist := m.snapshot.SpacecraftHistory
+	if hist == nil || hist.SpacecraftID != m.selectedID {
+		hist = &state.SpacecraftHistory{}
+	}
+	start, end := historySpan(hist.RTLTHistory, hist.RateHistory)
+
+	title := "Signal History"
+	if end.After(start) {
+		title += " (last " + formatDuration(end.Sub(start)) + ")"
+	}
+	b.WriteString(headerStyle.Render(title))
+	b.WriteString("\n")
+
+	// RTLT changes by parts per million over hours, so it is scaled to
+	// its own range; rates are scaled from zero so dips read as dips
+	b.WriteString(labelStyle.Render("RTLT:"))
+	if rtlt := hist.RTLTHistory; len(rtlt) >= 2 {
+		lo, hi := seriesRange(rtlt)
+		b.WriteString(rtltStyle.Render(historySparkline(rtlt, start, end, SparklineWidth, lo, hi)))
+		latest := rtlt[len(rtlt)-1].Value
+		b.WriteString(valueStyle.Render("  " + dsn.FormatRTLT(latest)))
+		b.WriteString(dimStyle.Render("  " + formatRTLTDrift(latest-rtlt[0].Value)))
+	} else {
+		b.WriteString(dimStyle.Render("Collecting samples..."))
+	}
+	b.WriteString("\n")
+
+	b.WriteString(labelStyle.Render("Data Rate:"))
+	if rate := hist.RateHistory; len(rate) >= 2 {
+		lo, hi := seriesRange(rate)
+		b.WriteString(rateStyle.Render(historySparkline(rate, start, end, SparklineWidth, 0, hi)))
+		b.WriteString(valueStyle.Render("  " + dsn.FormatDataRate(rate[len(rate)-1].Value)))
+		b.WriteString(dimStyle.Render("  min " + dsn.FormatDataRate(lo)))
+	} else {
+		b.WriteString(dimStyle.Render("Collecting samples..."))
+	}
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+// historySpan returns the time covered by either series.
+func historySpan(series ...[]state.TimeSeries) (start, end time.Time) {
+	for _, s := range series {
+		if len(s) == 0 {
+			continue
+		}
+		if first := s[0].Timestamp; start.IsZero() || first.Before(start) {
+			start = first
+		}
+		if last := s[len(s)-1].Timestamp; last.After(end) {
+			end = last
+		}
+	}
+	return start, end
+}
+
+// seriesRange returns the smallest and largest values in s.
+func seriesRange(s []state.TimeSeries) (lo, hi float64) {
+	lo, hi = math.Inf(1), math.Inf(-1)
+	for _, p := range s {
+		lo = math.Min(lo, p.Value)
+		hi = math.Max(hi, p.Value)
+	}
+	return lo, hi
+}
+
+// historyGap is the longest gap between samples a sparkline bridges;
+// longer ones (the spacecraft out of view) are left blank.
+const historyGap = 15 * time.Minute
+
+// historySparkline draws s across [start, end] in width cells, each the
+// average of its samples scaled from lo to hi. Cells between samples up
+// to historyGap apart repeat the previous cell; a flat series draws at
+// mid height.
+func historySparkline(s []state.TimeSeries, start, end time.Time, width int, lo, hi float64) string {
+	sums := make([]float64, width)
+	counts := make([]int, width)
+	firsts := make([]time.Time, width)
+	lasts := make([]time.Time, width)
+	span := end.Sub(start)
+	for _, p := range s {
+		i := width - 1
+		if span > 0 {
+			i = min(width-1, int(float64(p.Timestamp.Sub(start))/float64(span)*float64(width)))
+		}
+		if i < 0 {
+			continue
+		}
+		if counts[i] == 0 {
+			firsts[i] = p.Timestamp
+		}
+		sums[i] += p.Value
+		counts[i]++
+		lasts[i] = p.Timestamp
+	}
+
+	cells := make([]rune, width)
+	prev := -1
+	for i := range width {
+		cells[i] = ' '
+		if counts[i] == 0 {
+			continue
+		}
+		level := len(sparklineBlocks) / 2
+		if hi > lo {
+			t := (sums[i]/float64(counts[i]) - lo) / (hi - lo)
+			level = min(len(sparklineBlocks)-1, max(0, int(t*float64(len(sparklineBlocks)-1)+0.5)))
+		}
+		cells[i] = sparklineBlocks[level]
+		if prev >= 0 && firsts[i].Sub(lasts[prev]) <= historyGap {
+			for j := prev + 1; j < i; j++ {
+				cells[j] = cells[prev]
+			}
+		}
+		prev = i
+	}
+	return string(cells)
+}
+
+// formatRTLTDrift formats a change in round-trip light time; a receding
+// spacecraft drifts positive.
+func formatRTLTDrift(seconds float64) string {
+	switch {
+	case math.Abs(seconds) < 0.0005:
+		return "steady"
+	case math.Abs(seconds) < 1:
+		return fmt.Sprintf("%+.0f ms", seconds*1000)
+	default:
+		return fmt.Sprintf("%+.2f s", seconds)
+	}
+}
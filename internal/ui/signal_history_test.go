@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+	"github.com/litescript/ls-horizons/internal/state"
+)
+
+func TestHistorySparkline(t *testing.T) {
+	start := time.Date(2025, 12, 5, 12, 0, 0, 0, time.UTC)
+	at := func(min int, v float64) state.TimeSeries {
+		return state.TimeSeries{Timestamp: start.Add(time.Duration(min) * time.Minute), Value: v}
+	}
+
+	tests := []struct {
+		name   string
+		series []state.TimeSeries
+		lo, hi float64
+		want   string
+	}{
+		{"rising", []state.TimeSeries{at(0, 0), at(1, 50), at(2, 100), at(3, 100)}, 0, 100, "▁▅██"},
+		{"short gap", []state.TimeSeries{at(0, 100), at(3, 50)}, 0, 100, "███▅"},
+		{"dip", []state.TimeSeries{at(0, 100), at(1, 10), at(2, 100), at(3, 100)}, 0, 100, "█▂██"},
+		{"flat", []state.TimeSeries{at(0, 7), at(3, 7)}, 7, 7, "▅▅▅▅"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := historySparkline(tt.series, start, start.Add(3*time.Minute), 4, tt.lo, tt.hi); got != tt.want {
+				t.Errorf("historySparkline = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// An hour out of view stays blank
+	outage := []state.TimeSeries{at(0, 100), at(60, 100)}
+	if got := historySparkline(outage, start, start.Add(time.Hour), 4, 0, 100); got != "█  █" {
+		t.Errorf("outage = %q, want %q", got, "█  █")
+	}
+}
+
+func TestMissionDetailSignalHistory(t *testing.T) {
+	mgr := state.NewManager(state.DefaultConfig())
+	feed := time.Date(2025, 12, 5, 12, 0, 0, 0, time.UTC)
+	for i := range 90 {
+		rate := 160.0
+		if i > 40 && i < 50 {
+			rate = 40 // a dip
+		}
+		mgr.Update(&dsn.DSNData{
+			Timestamp: feed.Add(time.Duration(i) * time.Minute),
+			Links:     []dsn.Link{{SpacecraftID: 32, Spacecraft: "VGR2", AntennaID: "DSS43", RTLT: 160000 + float64(i)*0.01, DataRate: rate}},
+		}, 0, nil)
+	}
+	mgr.SetFocusedSpacecraft(32)
+
+	m := NewMissionDetailModel().SetSize(120, 60).UpdateData(mgr.Snapshot())
+	out := m.View()
+	for _, want := range []string{"Signal History (last 1h 29m)", "RTLT:", "44.4 hr", "+890 ms", "Data Rate:", "160 bps", "min 40.0 bps"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("view missing %q:\n%s", want, out)
+		}
+	}
+
+	// Until history arrives for the selection there is nothing to draw
+	m = NewMissionDetailModel().SetSize(120, 60).UpdateData(state.Snapshot{
+		Spacecraft: []dsn.Spacecraft{{ID: 32, Name: "VGR2"}},
+	})
+	if out := m.View(); !strings.Contains(out, "Collecting samples...") {
+		t.Errorf("view without history:\n%s", out)
+	}
+}
@@ -43,6 +43,17 @@ const (
 	colorStarMedium  = "250" // medium gray
 	colorStarDim     = "244" // dim gray
 	colorStarVeryDim = "240" // very dim gray
+
+	// Sun and Moon
+	glyphSun     = '☉'
+	glyphMoon    = '☾'
+	colorSun     = "#FFD75F" // warm yellow
+	colorMoon    = "#C8C8D8" // pale silver
+	colorSunCone = "208"     // orange, as other warnings
+
+	// DefaultSunAvoidance is the solar separation, in degrees, inside
+	// which the focused spacecraft gets an avoidance-cone warning.
+	DefaultSunAvoidance = 10.0
 )
 
 // LabelMode controls how spacecraft labels are displayed.
@@ -104,6 +115,8 @@ type SkyViewModel struct {
 	animTargAz  float64
 	animTargEl  float64
 	animStart   time.Time
+	noAnimation bool // eco mode: snap camera instead of animating
+	charset     Charset
 
 	// Focus - now operates on spacecraft, not individual links
 	focusIdx   int
@@ -127,8 +140,13 @@ type SkyViewModel struct {
 	visibilityMode  VisibilityMode
 	visibilityCache *dsn.VisibilityCache
 
-	// Star catalog (loaded once)
+	// Star catalog, already cut to the magnitude limit
 	starCatalog astro.StarCatalog
+
+	// Solar separation (degrees) that triggers the avoidance-cone warning
+	sunAvoidance float64
+
+	refraction bool // stars, paths, and the Sun at apparent elevations
 }
 
 // NewSkyViewModel creates a new sky view model.
@@ -140,7 +158,8 @@ func NewSkyViewModel() SkyViewModel {
 		pathMode:        PathOff,      // paths off by default until provider is set
 		visibilityMode:  VisibilityOff,
 		visibilityCache: dsn.NewVisibilityCache(),
-		starCatalog:     astro.DefaultStarCatalog(),
+		starCatalog:     astro.DefaultStarCatalog().Brighter(astro.DefaultStarMagLimit),
+		sunAvoidance:    DefaultSunAvoidance,
 	}
 }
 
@@ -150,6 +169,25 @@ func (m SkyViewModel) SetPathProvider(provider ephem.Provider) SkyViewModel {
 	return m
 }
 
+// SetNoAnimation disables camera animation; focus changes snap instantly.
+func (m SkyViewModel) SetNoAnimation(off bool) SkyViewModel {
+	m.noAnimation = off
+	return m
+}
+
+// SetRefraction places stars, trajectory paths, and the Sun at apparent
+// elevations, raised by atmospheric refraction, rather than airless ones.
+func (m SkyViewModel) SetRefraction(on bool) SkyViewModel {
+	m.refraction = on
+	return m
+}
+
+// SetCharset selects braille or ASCII glyphs for the trajectory path.
+func (m SkyViewModel) SetCharset(c Charset) SkyViewModel {
+	m.charset = c
+	return m
+}
+
 // SetSize updates the viewport size.
 func (m SkyViewModel) SetSize(width, height int) SkyViewModel {
 	m.width = width
@@ -178,6 +216,14 @@ func (m SkyViewModel) UpdateData(snapshot state.Snapshot) SkyViewModel {
 	return m
 }
 
+// FocusedSpacecraft returns the spacecraft the camera is focused on, if any.
+func (m SkyViewModel) FocusedSpacecraft() *dsn.SpacecraftView {
+	if m.focusIdx < 0 || m.focusIdx >= len(m.spacecraft) {
+		return nil
+	}
+	return &m.spacecraft[m.focusIdx]
+}
+
 // SyncFromDashboard initializes sky view focus from dashboard selection.
 func (m SkyViewModel) SyncFromDashboard(dash DashboardModel, snapshot state.Snapshot) SkyViewModel {
 	// Build spacecraft views (grouped, filtered)
@@ -423,6 +469,11 @@ func (m SkyViewModel) startAnimation() (SkyViewModel, tea.Cmd) {
 	}
 
 	coord := m.spacecraft[m.focusIdx].Coord()
+	if m.noAnimation {
+		m.camAz = coord.AzDeg
+		m.camEl = coord.ElDeg
+		return m, nil
+	}
 	m.animating = true
 	m.animStartAz = m.camAz
 	m.animStartEl = m.camEl
@@ -594,6 +645,9 @@ func (m SkyViewModel) renderStatus() string {
 			if sc.Name != sc.Code {
 				status.WriteString("\n    " + dimStyle.Render(sc.Name))
 			}
+			if warning := m.sunWarning(time.Now()); warning != "" {
+				status.WriteString("\n    " + warning)
+			}
 			status.WriteString("\n\n")
 			status.WriteString(RenderVisibilityPanel(visibility))
 			return status.String()
@@ -619,10 +673,45 @@ func (m SkyViewModel) renderStatus() string {
 		dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colorSpacecraft))
 		status += "\n" + dimStyle.Render("    "+sc.Name)
 	}
+	if warning := m.sunWarning(time.Now()); warning != "" {
+		status += "\n    " + warning
+	}
 
 	return status
 }
 
+// sunAndMoon returns where the Sun and Moon are in the observer's sky.
+func sunAndMoon(observer astro.Observer, t time.Time) (sun, moon astro.SkyCoord) {
+	ra, dec := astro.SunPosition(t)
+	sun = astro.EquatorialToHorizontal(astro.SkyCoord{RAdeg: ra, DecDeg: dec}, observer, t)
+	ra, dec = astro.MoonPosition(t)
+	moon = astro.EquatorialToHorizontal(astro.SkyCoord{RAdeg: ra, DecDeg: dec}, observer, t)
+	return sun, moon
+}
+
+// focusSunSeparation returns the focused spacecraft's angular distance
+// from the Sun, in degrees, and false if nothing is focused.
+func (m SkyViewModel) focusSunSeparation(sun astro.SkyCoord) (float64, bool) {
+	sc := m.FocusedSpacecraft()
+	if sc == nil {
+		return 0, false
+	}
+	coord := sc.Coord()
+	return astro.AngularSeparation(sun.AzDeg, sun.ElDeg, coord.AzDeg, coord.ElDeg), true
+}
+
+// sunWarning returns the avoidance-cone warning for the focused
+// spacecraft, or "" if it is clear of the Sun.
+func (m SkyViewModel) sunWarning(now time.Time) string {
+	sun, _ := sunAndMoon(m.getObserver(), now)
+	sep, ok := m.focusSunSeparation(sun)
+	if !ok || sep >= m.sunAvoidance {
+		return ""
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(colorSunCone)).Bold(true).
+		Render(fmt.Sprintf("%c %.1f° from the Sun, inside the %.0f° avoidance cone: expect degraded links", glyphSun, sep, m.sunAvoidance))
+}
+
 // spacecraftPos tracks spacecraft position for label rendering
 type spacecraftPos struct {
 	x, y       int
@@ -632,14 +721,16 @@ type spacecraftPos struct {
 	labelEnd   int // calculated label end position
 }
 
-// getObserver returns the observer location based on the focused spacecraft's complex.
-// Defaults to Goldstone if no spacecraft is focused.
+// getObserver returns the observer location based on the focused spacecraft's
+// primary antenna. Defaults to Goldstone if no spacecraft is focused.
 func (m SkyViewModel) getObserver() astro.Observer {
+	obs := dsn.ObserverForComplex(dsn.ComplexGoldstone)
 	if len(m.spacecraft) > 0 && m.focusIdx < len(m.spacecraft) {
 		primary := m.spacecraft[m.focusIdx].PrimaryLink
-		return dsn.ObserverForComplex(primary.Complex)
+		obs = dsn.ObserverForAntenna(primary.Station, primary.Complex)
 	}
-	return dsn.ObserverForComplex(dsn.ComplexGoldstone)
+	obs.Refraction = m.refraction
+	return obs
 }
 
 func (m SkyViewModel) renderSkyCanvas(width, height int) string {
@@ -692,6 +783,15 @@ func (m SkyViewModel) renderSkyCanvas(width, height int) string {
 		m.renderPath(canvas, colors, width, horizonY, now)
 	}
 
+	// Draw the Sun and Moon, ringing the Sun with its avoidance cone
+	// while the focused spacecraft is inside it
+	sun, moon := sunAndMoon(observer, now)
+	if sep, ok := m.focusSunSeparation(sun); ok && sep < m.sunAvoidance {
+		m.renderSunCone(canvas, colors, width, horizonY, sun)
+	}
+	m.drawBody(canvas, colors, width, height, moon, glyphMoon, colorMoon)
+	m.drawBody(canvas, colors, width, height, sun, glyphSun, colorSun)
+
 	// Draw horizon line (purple tint)
 	for x := 0; x < width; x++ {
 		canvas[horizonY][x] = '─'
@@ -859,19 +959,22 @@ func (m SkyViewModel) renderLabels(canvas [][]rune, colors [][]lipgloss.Color, w
 }
 
 // brailleCanvas holds subpixel data for smooth arc rendering.
-// Each cell maps to a 2x4 grid of braille dots.
+// Each cell maps to a 2x4 grid of braille dots. With CharsetASCII the same
+// dots are accumulated but each cell is drawn as an ASCII density glyph.
 type brailleCanvas struct {
 	width, height int
 	dots          [][]rune           // accumulated braille patterns
 	colors        [][]lipgloss.Color // color per cell
+	charset       Charset
 }
 
-func newBrailleCanvas(width, height int) *brailleCanvas {
+func newBrailleCanvas(width, height int, charset Charset) *brailleCanvas {
 	bc := &brailleCanvas{
-		width:  width,
-		height: height,
-		dots:   make([][]rune, height),
-		colors: make([][]lipgloss.Color, height),
+		width:   width,
+		height:  height,
+		charset: charset,
+		dots:    make([][]rune, height),
+		colors:  make([][]lipgloss.Color, height),
 	}
 	for y := 0; y < height; y++ {
 		bc.dots[y] = make([]rune, width)
@@ -914,6 +1017,14 @@ func (bc *brailleCanvas) setPixel(fx, fy float64, color lipgloss.Color) {
 func (bc *brailleCanvas) render(canvas [][]rune, colors [][]lipgloss.Color) {
 	for y := 0; y < bc.height && y < len(canvas); y++ {
 		for x := 0; x < bc.width && x < len(canvas[y]); x++ {
+			if bc.dots[y][x] != 0 && bc.charset == CharsetASCII {
+				// Only draw on empty cells so stars and markers stay visible
+				if canvas[y][x] == ' ' {
+					canvas[y][x] = asciiDensity(bc.dots[y][x])
+					colors[y][x] = bc.colors[y][x]
+				}
+				continue
+			}
 			if bc.dots[y][x] != 0 {
 				// Only draw on empty cells or other braille
 				if canvas[y][x] == ' ' || (canvas[y][x] >= 0x2800 && canvas[y][x] <= 0x28FF) {
@@ -936,7 +1047,7 @@ func (m SkyViewModel) renderPath(canvas [][]rune, colors [][]lipgloss.Color, wid
 		return
 	}
 
-	bc := newBrailleCanvas(width, horizonY)
+	bc := newBrailleCanvas(width, horizonY, m.charset)
 
 	// Collect visible points with screen coordinates
 	type screenPoint struct {
@@ -1053,6 +1164,40 @@ func (m SkyViewModel) starGlyph(mag float64) (rune, lipgloss.Color) {
 	}
 }
 
+// drawBody draws the Sun or Moon if it is above the horizon and in view.
+func (m SkyViewModel) drawBody(canvas [][]rune, colors [][]lipgloss.Color, width, height int, coord astro.SkyCoord, glyph rune, color lipgloss.Color) {
+	if coord.ElDeg <= 0 {
+		return
+	}
+	x, y, visible := m.projectToScreen(coord.AzDeg, coord.ElDeg, width, height)
+	if !visible || x < 0 || x >= width || y < 0 || y >= height-2 {
+		return
+	}
+	canvas[y][x] = glyph
+	colors[y][x] = color
+}
+
+// renderSunCone outlines the avoidance cone around the Sun in braille:
+// the circle sunAvoidance degrees from it on the sky.
+func (m SkyViewModel) renderSunCone(canvas [][]rune, colors [][]lipgloss.Color, width, horizonY int, sun astro.SkyCoord) {
+	bc := newBrailleCanvas(width, horizonY, m.charset)
+	r := m.sunAvoidance * math.Pi / 180
+	el0 := sun.ElDeg * math.Pi / 180
+	for bearing := 0.0; bearing < 360; bearing += 2 {
+		b := bearing * math.Pi / 180
+		el := math.Asin(math.Sin(el0)*math.Cos(r) + math.Cos(el0)*math.Sin(r)*math.Cos(b))
+		dAz := math.Atan2(math.Sin(b)*math.Sin(r)*math.Cos(el0), math.Cos(r)-math.Sin(el0)*math.Sin(el))
+		if el <= 0 {
+			continue
+		}
+		fx, fy, visible := m.projectToScreenFloat(sun.AzDeg+dAz*180/math.Pi, el*180/math.Pi, width, horizonY)
+		if visible {
+			bc.setPixel(fx, fy, colorSunCone)
+		}
+	}
+	bc.render(canvas, colors)
+}
+
 func (m SkyViewModel) drawCardinal(canvas [][]rune, colors [][]lipgloss.Color, width, height int, label string, az float64) {
 	x, _, visible := m.projectToScreen(az, 0, width, height)
 	if !visible {
@@ -2,7 +2,13 @@ package ui
 
 import (
 	"math"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/litescript/ls-horizons/internal/astro"
+	"github.com/litescript/ls-horizons/internal/dsn"
+	"github.com/litescript/ls-horizons/internal/ephem"
 )
 
 func TestNormalizeAngle(t *testing.T) {
@@ -173,3 +179,82 @@ func TestLabelModeConstants(t *testing.T) {
 		t.Errorf("LabelAll = %d, want 2", LabelAll)
 	}
 }
+
+func TestStartAnimation_NoAnimationSnaps(t *testing.T) {
+	m := NewSkyViewModel().SetNoAnimation(true)
+	m.spacecraft = []dsn.SpacecraftView{
+		{Name: "VGR1", PrimaryLink: dsn.LinkView{}},
+	}
+	m.camAz, m.camEl = 10, 10
+
+	m, cmd := m.startAnimation()
+	if cmd != nil {
+		t.Error("expected no animation tick in no-animation mode")
+	}
+	if m.animating {
+		t.Error("animating should be false in no-animation mode")
+	}
+	coord := m.spacecraft[0].Coord()
+	if m.camAz != coord.AzDeg || m.camEl != coord.ElDeg {
+		t.Errorf("camera = (%v, %v), want snapped to (%v, %v)", m.camAz, m.camEl, coord.AzDeg, coord.ElDeg)
+	}
+}
+
+func BenchmarkSkyViewRender(b *testing.B) {
+	for _, cs := range []Charset{CharsetBraille, CharsetASCII} {
+		b.Run(cs.String(), func(b *testing.B) {
+			m := NewSkyViewModel().SetSize(120, 40).SetCharset(cs)
+			m.spacecraft = []dsn.SpacecraftView{
+				{Code: "VGR1", Name: "Voyager 1", PrimaryLink: dsn.LinkView{Station: "DSS43", AzDeg: 180, ElDeg: 30}},
+				{Code: "JWST", Name: "James Webb", PrimaryLink: dsn.LinkView{Station: "DSS26", AzDeg: 200, ElDeg: 45}},
+			}
+			m.camAz, m.camEl = 180, 30
+
+			// A path arc through the field of view exercises the subpixel canvas
+			now := time.Now()
+			m.pathMode = PathOn
+			for i := 0; i < 145; i++ {
+				m.currentPath.Points = append(m.currentPath.Points, ephem.EphemerisPoint{
+					Time:  now.Add(time.Duration(i-72) * 10 * time.Minute),
+					Coord: astro.SkyCoord{AzDeg: 140 + float64(i)*0.5, ElDeg: 10 + 30*math.Sin(float64(i)/145*math.Pi)},
+					Valid: true,
+				})
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_ = m.View()
+			}
+		})
+	}
+}
+
+func TestSunWarning(t *testing.T) {
+	now := time.Date(2024, 6, 21, 20, 0, 0, 0, time.UTC) // early afternoon at Goldstone
+	m := NewSkyViewModel()
+	sun, _ := sunAndMoon(dsn.ObserverForComplex(dsn.ComplexGoldstone), now)
+	if sun.ElDeg <= 0 {
+		t.Fatalf("Sun el = %.1f, want above the horizon", sun.ElDeg)
+	}
+
+	if got := m.sunWarning(now); got != "" {
+		t.Errorf("warning with nothing focused = %q, want none", got)
+	}
+
+	m.spacecraft = []dsn.SpacecraftView{
+		{Code: "PSP", PrimaryLink: dsn.LinkView{Complex: dsn.ComplexGoldstone, AzDeg: sun.AzDeg, ElDeg: sun.ElDeg - 3}},
+	}
+	if got := m.sunWarning(now); !strings.Contains(got, "3.0° from the Sun") {
+		t.Errorf("warning 3° from the Sun = %q", got)
+	}
+
+	m.spacecraft[0].PrimaryLink.ElDeg = sun.ElDeg - 20
+	if got := m.sunWarning(now); got != "" {
+		t.Errorf("warning 20° from the Sun = %q, want none", got)
+	}
+	m.sunAvoidance = 25
+	if got := m.sunWarning(now); got == "" {
+		t.Error("no warning inside a widened 25° cone")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -29,8 +30,33 @@ type SolarSystemModel struct {
 	labelMode  LabelMode // Label display mode (reuses sky_view LabelMode)
 	userPanned bool      // True if user has manually panned (disables auto-center on zoom)
 	showStars  bool      // Whether to show background starfield
+	geometric  bool      // Plot geometric positions instead of light-time corrected ones
+	split      bool      // Inner system (linear) and outer system (log) side by side
+	oblique    bool      // 3D view at yawDeg/pitchDeg instead of top-down
+	yawDeg     float64
+	pitchDeg   float64
+	charset    Charset
+
+	// Orbit trails for focused spacecraft, by code
+	trailProvider dsn.SolarSystemSeriesProvider
+	trails        map[string]orbitTrail
+	trailPending  string
+
+	// Moon systems of zoomed-in planets, by planet code
+	moonProvider  dsn.SolarSystemProvider
+	systems       map[string]dsn.PlanetSystem
+	systemPending string
 }
 
+// 3D view: the tilt it opens at, and the step of each rotation key
+const (
+	defaultPitchDeg = 60.0
+	rotateStepDeg   = 15.0
+)
+
+// splitInnerAU is the radius of the inner pane of the split view.
+const splitInnerAU = 5.5
+
 // Discrete zoom levels for clean stepping
 var zoomLevels = []float64{0.25, 0.5, 0.75, 1.0, 1.5, 2.0, 3.0, 5.0, 10.0}
 
@@ -60,6 +86,12 @@ func (m SolarSystemModel) SetSize(width, height int) SolarSystemModel {
 	return m
 }
 
+// SetCharset selects braille or ASCII glyphs for orbit trails.
+func (m SolarSystemModel) SetCharset(c Charset) SolarSystemModel {
+	m.charset = c
+	return m
+}
+
 // UpdateData updates the model with new data.
 func (m SolarSystemModel) UpdateData(snapshot state.Snapshot, solarSnap dsn.SolarSystemSnapshot) SolarSystemModel {
 	m.snapshot = snapshot
@@ -75,13 +107,17 @@ func (m SolarSystemModel) Update(msg tea.Msg) (SolarSystemModel, tea.Cmd) {
 		// Focus navigation (j/k like other views, or [/])
 		case "j", "[":
 			m.focusPrev()
+			return m.fetchForFocus()
 		case "k", "]":
 			m.focusNext()
+			return m.fetchForFocus()
 		// Spacecraft cycling (n/N since tab is global view switch)
 		case "n":
 			m.focusNextSpacecraft()
+			return m.fetchForFocus()
 		case "N":
 			m.focusPrevSpacecraft()
+			return m.fetchForFocus()
 
 		// Viewport panning (arrow keys - no conflict with global keys)
 		case "up":
@@ -113,6 +149,7 @@ func (m SolarSystemModel) Update(msg tea.Msg) (SolarSystemModel, tea.Cmd) {
 					m.centerOnFocused()
 				}
 			}
+			return m.fetchMoonsForFocus()
 		case "-":
 			if m.zoomLevel > 0 {
 				m.zoomLevel--
@@ -142,16 +179,95 @@ func (m SolarSystemModel) Update(msg tea.Msg) (SolarSystemModel, tea.Cmd) {
 		case "t":
 			m.showStars = !m.showStars
 
+		// Apparent (light-time corrected) or geometric positions
+		case "a":
+			m.geometric = !m.geometric
+			if !m.userPanned {
+				m.centerOnFocused()
+			}
+
+		// Split screen: inner and outer system side by side
+		case "v":
+			m.split = !m.split
+
+		// 3D view: x toggles it, ,/. turn it about the ecliptic pole, </>
+		// tilt it between face-on and edge-on
+		case "x":
+			m.oblique = !m.oblique
+			if m.oblique && m.yawDeg == 0 && m.pitchDeg == 0 {
+				m.pitchDeg = defaultPitchDeg
+			}
+			if !m.userPanned {
+				m.centerOnFocused()
+			}
+		case ",", ".", "<", ">":
+			m.rotate(msg.String())
+			if !m.userPanned {
+				m.centerOnFocused()
+			}
+
 		// Reset everything
 		case "r":
 			m.panX, m.panY = 0, 0
 			m.zoomLevel = 3
 			m.userPanned = false
+			m.oblique = false
+			m.yawDeg, m.pitchDeg = 0, 0
+
+		// Manual refresh: p = planets only, R = planets and spacecraft
+		case "p":
+			return m, func() tea.Msg {
+				return SolarSystemRefreshMsg{Planets: true}
+			}
+		case "R":
+			return m, func() tea.Msg {
+				return SolarSystemRefreshMsg{Planets: true, Spacecraft: true}
+			}
 		}
+
+	case trailFetchMsg:
+		m = m.storeTrail(msg)
+	case planetSystemMsg:
+		m = m.storeSystem(msg)
 	}
 	return m, nil
 }
 
+// orient sets the 3D view orientation on cfg, if the view is oblique.
+func (m SolarSystemModel) orient(cfg astro.ProjectionConfig) astro.ProjectionConfig {
+	if m.oblique {
+		cfg.YawDeg, cfg.PitchDeg = m.yawDeg, m.pitchDeg
+	}
+	return cfg
+}
+
+// rotate turns the 3D view by rotateStepDeg for a rotation key, switching
+// to it from top-down.
+func (m *SolarSystemModel) rotate(key string) {
+	if !m.oblique {
+		m.oblique = true
+		m.yawDeg, m.pitchDeg = 0, 0
+	}
+	switch key {
+	case ",":
+		m.yawDeg = math.Mod(m.yawDeg-rotateStepDeg+360, 360)
+	case ".":
+		m.yawDeg = math.Mod(m.yawDeg+rotateStepDeg, 360)
+	case "<":
+		m.pitchDeg = math.Max(m.pitchDeg-rotateStepDeg, 0)
+	case ">":
+		m.pitchDeg = math.Min(m.pitchDeg+rotateStepDeg, 90)
+	}
+}
+
+// fetchForFocus starts the fetches the newly focused body needs: its
+// trail, or its moons when zoomed in.
+func (m SolarSystemModel) fetchForFocus() (SolarSystemModel, tea.Cmd) {
+	m, trailCmd := m.fetchTrailForFocus()
+	m, moonCmd := m.fetchMoonsForFocus()
+	return m, tea.Batch(trailCmd, moonCmd)
+}
+
 func (m *SolarSystemModel) focusNext() {
 	bodies := m.solarSnap.Bodies
 	if len(bodies) == 0 {
@@ -221,13 +337,13 @@ func (m *SolarSystemModel) centerOnFocused() {
 	}
 
 	body := m.solarSnap.Bodies[m.focusIdx]
-	cfg := astro.ProjectionConfig{
+	cfg := m.orient(astro.ProjectionConfig{
 		Scale: m.scale(),
 		Mode:  m.scaleMode,
-	}
+	})
 
 	// Get projected position
-	proj := astro.ProjectEclipticTopDown(body.Pos, cfg)
+	proj := astro.ProjectEcliptic(body.Position(!m.geometric), cfg)
 
 	// Set pan to center on this body
 	// panX = -proj.X and panY = -proj.Y centers the body on screen
@@ -251,12 +367,17 @@ func (m SolarSystemModel) View() string {
 	return lipgloss.JoinVertical(lipgloss.Left, canvas, hud)
 }
 
+// glyphOtherPosition marks where the focused body would be plotted with
+// the light-time correction toggled.
+const glyphOtherPosition = '∘'
+
 // bodyPos tracks a body's screen position for label rendering.
 type bodyPos struct {
 	x, y      int
 	name      string
 	kind      dsn.BodyKind
 	isFocused bool
+	inSystem  bool // Drawn among the focused planet's moons
 }
 
 // buildCanvas renders the solar system to a string canvas.
@@ -268,13 +389,8 @@ func (m SolarSystemModel) buildCanvas() string {
 	}
 	canvasW := m.width
 
-	// Create character grid
-	grid := make([][]rune, canvasH)
-	for y := range grid {
-		grid[y] = make([]rune, canvasW)
-		for x := range grid[y] {
-			grid[y][x] = ' '
-		}
+	if m.split {
+		return m.buildSplitCanvas(canvasW, canvasH)
 	}
 
 	// Screen center
@@ -282,10 +398,10 @@ func (m SolarSystemModel) buildCanvas() string {
 	screenCenterY := canvasH / 2
 
 	scale := m.scale()
-	cfg := astro.ProjectionConfig{
+	cfg := m.orient(astro.ProjectionConfig{
 		Scale: scale,
 		Mode:  m.scaleMode,
-	}
+	})
 
 	// Compute display scaling factor
 	// Map log(30 AU + 1) ~ 1.5 to fit in half the canvas
@@ -297,29 +413,126 @@ func (m SolarSystemModel) buildCanvas() string {
 	originX := screenCenterX + int(m.panX*displayScale)
 	originY := screenCenterY - int(m.panY*displayScale)
 
+	return m.renderPane(orbitPane{
+		width: canvasW, height: canvasH,
+		originX: originX, originY: originY,
+		displayScale: displayScale, cfg: cfg,
+	})
+}
+
+// buildSplitCanvas draws the inner system to 5.5 AU on a linear scale
+// beside the whole system on a log scale, both centered on the Sun and
+// zoomed together, so Mars orbiters and the Voyagers are legible at once.
+func (m SolarSystemModel) buildSplitCanvas(canvasW, canvasH int) string {
+	paneW := (canvasW - 1) / 2
+	fit := float64(min(paneW/2, canvasH/2)) * 0.9
+
+	inner := m.renderPane(orbitPane{
+		width: paneW, height: canvasH,
+		originX: paneW / 2, originY: canvasH / 2,
+		displayScale: fit / splitInnerAU * m.scale(),
+		cfg:          m.orient(astro.ProjectionConfig{Scale: 1, Mode: astro.ScaleLinear}),
+		title:        fmt.Sprintf("Inner ≤%.1f AU, linear", splitInnerAU),
+		limitAU:      splitInnerAU,
+	})
+
+	// The outer pane reaches the farthest body in the ecliptic plane,
+	// and Neptune at least
+	outerAU := 30.0
+	for _, body := range m.solarSnap.Bodies {
+		pos := body.Position(!m.geometric)
+		outerAU = math.Max(outerAU, math.Hypot(pos.X, pos.Y))
+	}
+	outerW := canvasW - paneW - 1
+	outer := m.renderPane(orbitPane{
+		width: outerW, height: canvasH,
+		originX: outerW / 2, originY: canvasH / 2,
+		displayScale: fit / math.Log10(outerAU+1) * m.scale(),
+		cfg:          m.orient(astro.ProjectionConfig{Scale: 1, Mode: astro.ScaleLogR}),
+		title:        fmt.Sprintf("Outer ≤%.0f AU, log", outerAU),
+	})
+
+	divider := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).
+		Render(strings.TrimSuffix(strings.Repeat("│\n", canvasH), "\n"))
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		strings.TrimSuffix(inner, "\n"), divider, strings.TrimSuffix(outer, "\n")) + "\n"
+}
+
+// orbitPane is where and how renderPane draws the solar system.
+type orbitPane struct {
+	width, height    int
+	originX, originY int // Sun's cell
+	displayScale     float64
+	cfg              astro.ProjectionConfig
+	title            string  // Drawn top-left, if set
+	limitAU          float64 // If set, bodies and orbits farther out are left off
+}
+
+// renderPane draws the solar system into a pane.
+func (m SolarSystemModel) renderPane(p orbitPane) string {
+	canvasW, canvasH := p.width, p.height
+	originX, originY := p.originX, p.originY
+	displayScale, cfg := p.displayScale, p.cfg
+
+	// Create character grid
+	grid := make([][]rune, canvasH)
+	for y := range grid {
+		grid[y] = make([]rune, canvasW)
+		for x := range grid[y] {
+			grid[y][x] = ' '
+		}
+	}
+
 	// Draw starfield background (before everything else)
-	if m.showStars {
+	if m.showStars && p.limitAU == 0 {
 		m.drawStarfield(grid, originX, originY, displayScale, cfg)
 	}
 
-	// Draw orbit rings centered on the panned origin
-	m.drawOrbitRings(grid, originX, originY, displayScale, cfg)
+	// Draw planetary orbits around the panned origin
+	m.drawOrbits(grid, originX, originY, displayScale, cfg, time.Now(), p.limitAU)
 
 	// Track body positions for labels
 	var positions []bodyPos
 
+	// Spacecraft among the focused planet's moons are drawn with them
+	system, showSystem := m.shownSystem()
+	inSystem := insetCodes(system)
+	systemX, systemY := -1, -1
+
 	// Draw bodies (except Sun - draw it last)
 	for i, body := range m.solarSnap.Bodies {
-		if body.Kind == dsn.BodySun {
+		if body.Kind == dsn.BodySun || inSystem[body.Code] {
+			continue
+		}
+		if pos := body.Position(!m.geometric); p.limitAU > 0 && math.Hypot(pos.X, pos.Y) > p.limitAU {
 			continue
 		}
 
-		proj := astro.ProjectEclipticTopDown(body.Pos, cfg)
+		proj := astro.ProjectEcliptic(body.Position(!m.geometric), cfg)
 
 		// Convert to screen coordinates relative to panned origin
 		sx := originX + int(proj.X*displayScale)
 		sy := originY - int(proj.Y*displayScale) // Y flipped for screen
 
+		// The focused body also marks its other position, geometric or
+		// apparent, when light time moves it to another cell
+		if i == m.focusIdx && body.LightTime > 0 {
+			other := astro.ProjectEcliptic(body.Position(m.geometric), cfg)
+			ox := originX + int(other.X*displayScale)
+			oy := originY - int(other.Y*displayScale)
+			if (ox != sx || oy != sy) && ox >= 0 && ox < canvasW && oy >= 0 && oy < canvasH && grid[oy][ox] == ' ' {
+				grid[oy][ox] = glyphOtherPosition
+			}
+		}
+
+		// Tilted, spacecraft hang a line to the ecliptic below or above
+		// them, so their inclination shows
+		if m.oblique && cfg.PitchDeg > 0 && body.Kind == dsn.BodySpacecraft {
+			pos := body.Position(!m.geometric)
+			foot := astro.ProjectEcliptic(astro.Vec3{X: pos.X, Y: pos.Y}, cfg)
+			drawDropLine(grid, sx, sy, originX+int(foot.X*displayScale), originY-int(foot.Y*displayScale))
+		}
+
 		if sx < 0 || sx >= canvasW || sy < 0 || sy >= canvasH {
 			continue
 		}
@@ -327,6 +540,9 @@ func (m SolarSystemModel) buildCanvas() string {
 		// Select glyph based on body type
 		glyph := m.getBodyGlyph(body, i == m.focusIdx)
 		grid[sy][sx] = glyph
+		if i == m.focusIdx {
+			systemX, systemY = sx, sy
+		}
 
 		// Track position for labels
 		positions = append(positions, bodyPos{
@@ -338,6 +554,10 @@ func (m SolarSystemModel) buildCanvas() string {
 		})
 	}
 
+	if showSystem && systemX >= 0 {
+		positions = m.drawMoonSystem(grid, system, systemX, systemY, cfg, positions)
+	}
+
 	// Draw Sun at panned origin LAST so it's always visible
 	if originX >= 0 && originX < canvasW && originY >= 0 && originY < canvasH {
 		grid[originY][originX] = '☉'
@@ -354,48 +574,83 @@ func (m SolarSystemModel) buildCanvas() string {
 	// Draw labels based on label mode
 	m.renderLabels(grid, canvasW, canvasH, positions)
 
-	// Convert grid to string with colors
-	return m.renderGrid(grid, screenCenterX, screenCenterY, displayScale, cfg)
-}
+	if p.title != "" {
+		for i, r := range []rune(p.title) {
+			if i+1 < canvasW {
+				grid[0][i+1] = r
+			}
+		}
+	}
 
-func (m SolarSystemModel) drawOrbitRings(grid [][]rune, cx, cy int, scale float64, cfg astro.ProjectionConfig) {
-	// Draw reference orbit circles for key distances
-	orbitAUs := []float64{1, 5, 10, 20, 30} // Earth, Jupiter, Saturn, Uranus, Neptune regions
+	// Draw the focused spacecraft's trail into the cells left empty, so
+	// bodies and labels stay on top
+	colors := make([][]lipgloss.Color, canvasH)
+	for y := range colors {
+		colors[y] = make([]lipgloss.Color, canvasW)
+	}
+	trail := newBrailleCanvas(canvasW, canvasH, m.charset)
+	m.drawTrail(trail, originX, originY, displayScale, cfg, time.Now())
+	trail.render(grid, colors)
 
-	for _, au := range orbitAUs {
-		// Project this distance
-		proj := astro.ProjectEclipticTopDown(astro.Vec3{X: au, Y: 0, Z: 0}, cfg)
-		r := proj.X * scale
+	// Convert grid to string with colors
+	return m.renderGrid(grid, colors)
+}
 
-		// Draw circle with ASCII
-		m.drawCircle(grid, cx, cy, r)
+// glyphDropLine joins a spacecraft to the ecliptic in the 3D view.
+const glyphDropLine = '┆'
+
+// drawDropLine draws a line from (x0, y0) toward (x1, y1) over empty and
+// orbit cells, leaving both ends for the body and the plane.
+func drawDropLine(grid [][]rune, x0, y0, x1, y1 int) {
+	steps := max(x1-x0, x0-x1, y1-y0, y0-y1)
+	for s := 1; s < steps; s++ {
+		x := x0 + int(math.Round(float64(s*(x1-x0))/float64(steps)))
+		y := y0 + int(math.Round(float64(s*(y1-y0))/float64(steps)))
+		if y >= 0 && y < len(grid) && x >= 0 && x < len(grid[y]) && (grid[y][x] == ' ' || grid[y][x] == '·') {
+			grid[y][x] = glyphDropLine
+		}
 	}
 }
 
-func (m SolarSystemModel) drawCircle(grid [][]rune, cx, cy int, r float64) {
-	if r < 1 {
-		return
-	}
+// orbitPoints is how many points each planet's orbit is traced through.
+const orbitPoints = 360
 
+// drawOrbits draws each planet's orbit ellipse from its Keplerian elements,
+// projected like the bodies so each planet sits on its own orbit. Orbits
+// reaching beyond limitAU, if set, are left off.
+func (m SolarSystemModel) drawOrbits(grid [][]rune, cx, cy int, displayScale float64, cfg astro.ProjectionConfig, now time.Time, limitAU float64) {
 	h := len(grid)
 	w := len(grid[0])
 
-	// Draw circle using parametric equations
-	steps := int(2 * math.Pi * r)
-	if steps < 8 {
-		steps = 8
-	}
-	if steps > 360 {
-		steps = 360
+	screen := func(p astro.Vec3) (float64, float64) {
+		proj := astro.ProjectEcliptic(p, cfg)
+		return proj.X * displayScale, -proj.Y * displayScale
 	}
 
-	for i := 0; i < steps; i++ {
-		theta := 2 * math.Pi * float64(i) / float64(steps)
-		x := cx + int(r*math.Cos(theta))
-		y := cy - int(r*math.Sin(theta)*0.5) // Aspect ratio correction
-
-		if x >= 0 && x < w && y >= 0 && y < h && grid[y][x] == ' ' {
-			grid[y][x] = '·'
+	for _, planet := range dsn.Planets {
+		elements, ok := astro.PlanetElements[planet.Name]
+		if !ok || (limitAU > 0 && elements.A*(1+elements.E) > limitAU) {
+			continue
+		}
+		points := elements.Orbit(now, orbitPoints)
+		for i := range points {
+			ax, ay := screen(points[i])
+			bx, by := screen(points[(i+1)%len(points)])
+
+			// Fill the gap between points once the orbit spans more
+			// cells than it has points
+			steps := max(int(math.Ceil(math.Max(math.Abs(bx-ax), math.Abs(by-ay)))), 1)
+			if steps > w+h {
+				continue // zoomed in past this segment's ends
+			}
+			for s := 0; s < steps; s++ {
+				f := float64(s) / float64(steps)
+				x := cx + int(ax+f*(bx-ax))
+				y := cy + int(ay+f*(by-ay))
+				if x >= 0 && x < w && y >= 0 && y < h && grid[y][x] == ' ' {
+					grid[y][x] = '·'
+				}
+			}
 		}
 	}
 }
@@ -418,11 +673,8 @@ func (m SolarSystemModel) drawStarfield(grid [][]rune, cx, cy int, displayScale
 	shellRadius := astro.DefaultStarShellRadiusAU / cfg.Scale
 
 	// Set up projection config for stars
-	starCfg := astro.ProjectionConfig{
-		Scale:             cfg.Scale,
-		Mode:              cfg.Mode,
-		StarShellRadiusAU: shellRadius,
-	}
+	starCfg := cfg
+	starCfg.StarShellRadiusAU = shellRadius
 
 	for _, star := range catalog.Stars {
 		// Project star to ecliptic top-down view
@@ -476,7 +728,7 @@ func (m SolarSystemModel) renderLabels(grid [][]rune, width, height int, positio
 		showLabel := false
 		switch m.labelMode {
 		case LabelFocused:
-			showLabel = pos.isFocused
+			showLabel = pos.isFocused || pos.inSystem
 		case LabelAll:
 			showLabel = true
 		}
@@ -537,7 +789,7 @@ func (m SolarSystemModel) getBodyGlyph(body dsn.EclipticBody, focused bool) rune
 	}
 }
 
-func (m SolarSystemModel) renderGrid(grid [][]rune, cx, cy int, scale float64, cfg astro.ProjectionConfig) string {
+func (m SolarSystemModel) renderGrid(grid [][]rune, colors [][]lipgloss.Color) string {
 	var b strings.Builder
 
 	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
@@ -546,6 +798,7 @@ func (m SolarSystemModel) renderGrid(grid [][]rune, cx, cy int, scale float64, c
 	planetStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("39"))
 	giantStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("208"))
 	scStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("46"))
+	moonStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("250"))
 	focusStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("229")).Bold(true)
 	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("249"))
 
@@ -553,10 +806,16 @@ func (m SolarSystemModel) renderGrid(grid [][]rune, cx, cy int, scale float64, c
 		for x, ch := range row {
 			var style lipgloss.Style
 
-			switch ch {
-			case ' ':
+			switch {
+			case ch == ' ':
 				b.WriteRune(ch)
 				continue
+			case colors[y][x] != "":
+				b.WriteString(lipgloss.NewStyle().Foreground(colors[y][x]).Render(string(ch)))
+				continue
+			}
+
+			switch ch {
 			case '·':
 				style = dimStyle
 			case '∗', '˙': // Star glyphs
@@ -569,6 +828,12 @@ func (m SolarSystemModel) renderGrid(grid [][]rune, cx, cy int, scale float64, c
 				style = giantStyle
 			case '◇':
 				style = scStyle
+			case glyphOtherPosition:
+				style = dimStyle
+			case glyphSatellite:
+				style = moonStyle
+			case glyphDropLine:
+				style = dimStyle
 			case '●', '◉', '◆':
 				style = focusStyle
 			case '◄':
@@ -580,8 +845,6 @@ func (m SolarSystemModel) renderGrid(grid [][]rune, cx, cy int, scale float64, c
 			}
 
 			b.WriteString(style.Render(string(ch)))
-			_ = x // Avoid unused variable warning
-			_ = y
 		}
 		b.WriteRune('\n')
 	}
@@ -610,8 +873,13 @@ func (m SolarSystemModel) renderHUD() string {
 		b.WriteString(labelStyle.Render("Distance:"))
 		b.WriteString(valueStyle.Render(fmt.Sprintf("%.3f AU", focused.DistanceAU())))
 		b.WriteString("  ")
-		b.WriteString(labelStyle.Render("Light Time:"))
-		b.WriteString(valueStyle.Render(astro.FormatLightTime(focused.LightTimeSec())))
+		if focused.LightTime > 0 {
+			b.WriteString(labelStyle.Render("From Earth:"))
+			b.WriteString(valueStyle.Render(astro.FormatLightTime(focused.LightTime.Seconds())))
+		} else {
+			b.WriteString(labelStyle.Render("From Sun:"))
+			b.WriteString(valueStyle.Render(astro.FormatLightTime(focused.LightTimeSec())))
+		}
 	} else {
 		b.WriteString(headerStyle.Render("☉ Sun"))
 		b.WriteString("  ")
@@ -639,6 +907,9 @@ func (m SolarSystemModel) renderHUD() string {
 	case astro.ScaleOuter:
 		modeName = "Outer"
 	}
+	if m.split {
+		modeName = "Split"
+	}
 
 	// Label mode indicator
 	labelName := ""
@@ -657,6 +928,18 @@ func (m SolarSystemModel) renderHUD() string {
 		starsName = "on"
 	}
 
+	// Positions: where Earth sees bodies now, or where they are
+	posName := "apparent"
+	if m.geometric {
+		posName = "geometric"
+	}
+
+	// View: top-down, or the 3D orientation
+	viewName := "top"
+	if m.oblique {
+		viewName = fmt.Sprintf("tilt %.0f° yaw %.0f°", m.pitchDeg, m.yawDeg)
+	}
+
 	// Use consistent label/value styling
 	b.WriteString(dimStyle.Render("Mode:"))
 	b.WriteString(valueStyle.Render(modeName))
@@ -669,10 +952,47 @@ func (m SolarSystemModel) renderHUD() string {
 	b.WriteString("  ")
 	b.WriteString(dimStyle.Render("Stars:"))
 	b.WriteString(valueStyle.Render(starsName))
+	b.WriteString("  ")
+	b.WriteString(dimStyle.Render("Pos:"))
+	b.WriteString(valueStyle.Render(posName))
+	b.WriteString("  ")
+	b.WriteString(dimStyle.Render("View:"))
+	b.WriteString(valueStyle.Render(viewName))
+	b.WriteString("  ")
+	b.WriteString(dimStyle.Render("Planets:"))
+	b.WriteString(refreshAgeStyle(m.solarSnap.PlanetsUpdated, valueStyle).Render(formatRefreshAge(m.solarSnap.PlanetsUpdated)))
+	b.WriteString("  ")
+	b.WriteString(dimStyle.Render("S/C:"))
+	b.WriteString(refreshAgeStyle(m.solarSnap.SpacecraftUpdated, valueStyle).Render(formatRefreshAge(m.solarSnap.SpacecraftUpdated)))
 
 	return b.String()
 }
 
+// formatRefreshAge formats how long ago a cache refresh happened.
+func formatRefreshAge(t time.Time) string {
+	if t.IsZero() {
+		return "—"
+	}
+	age := time.Since(t)
+	switch {
+	case age < time.Minute:
+		return "now"
+	case age < time.Hour:
+		return fmt.Sprintf("%dm ago", int(age.Minutes()))
+	default:
+		return fmt.Sprintf("%dh ago", int(age.Hours()))
+	}
+}
+
+// refreshAgeStyle colors a refresh time by staleness (see dataAgeStyle),
+// using fallback when there hasn't been one.
+func refreshAgeStyle(t time.Time, fallback lipgloss.Style) lipgloss.Style {
+	if t.IsZero() {
+		return fallback
+	}
+	return dataAgeStyle(time.Since(t))
+}
+
 // FocusedBody returns the currently focused body, or nil for Sun.
 func (m SolarSystemModel) FocusedBody() *dsn.EclipticBody {
 	if m.focusIdx >= 0 && m.focusIdx < len(m.solarSnap.Bodies) {
@@ -686,6 +1006,20 @@ func (m SolarSystemModel) ShowStars() bool {
 	return m.showStars
 }
 
+// FocusCode focuses and centers the body with code, if it is in view,
+// starting the fetches it needs.
+func (m SolarSystemModel) FocusCode(code string) (SolarSystemModel, tea.Cmd) {
+	for i, body := range m.solarSnap.Bodies {
+		if body.Code == code {
+			m.focusIdx = i
+			m.centerOnFocused()
+			m.userPanned = false
+			return m.fetchForFocus()
+		}
+	}
+	return m, nil
+}
+
 // SetFocusByCode sets focus to a body by its code.
 func (m *SolarSystemModel) SetFocusByCode(code string) {
 	for i, body := range m.solarSnap.Bodies {
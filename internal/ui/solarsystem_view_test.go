@@ -1,10 +1,13 @@
 package ui
 
 import (
+	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 
 	"github.com/litescript/ls-horizons/internal/astro"
 	"github.com/litescript/ls-horizons/internal/dsn"
@@ -363,3 +366,351 @@ func TestSolarSystemModelStarGlyph(t *testing.T) {
 		}
 	}
 }
+
+func TestSolarSystemModelRefreshKeys(t *testing.T) {
+	m := NewSolarSystemModel()
+
+	tests := []struct {
+		key  string
+		want SolarSystemRefreshMsg
+	}{
+		{"p", SolarSystemRefreshMsg{Planets: true}},
+		{"R", SolarSystemRefreshMsg{Planets: true, Spacecraft: true}},
+	}
+	for _, tt := range tests {
+		_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(tt.key)})
+		if cmd == nil {
+			t.Fatalf("key %q: expected refresh command", tt.key)
+		}
+		if got := cmd(); got != tt.want {
+			t.Errorf("key %q: msg = %+v, want %+v", tt.key, got, tt.want)
+		}
+	}
+}
+
+// fakeTrailProvider returns a straight-line path along +X.
+type fakeTrailProvider struct {
+	calls int
+}
+
+func (p *fakeTrailProvider) GetHeliocentricPositions(naifID int, times []time.Time) ([]astro.Vec3, error) {
+	p.calls++
+	pos := make([]astro.Vec3, len(times))
+	for i, t := range times {
+		pos[i] = astro.Vec3{X: 1 + t.Sub(times[0]).Hours()/(24*60), Y: 0.5}
+	}
+	return pos, nil
+}
+
+func TestSolarSystemModelOrbitTrail(t *testing.T) {
+	provider := &fakeTrailProvider{}
+	m := NewSolarSystemModel().SetTrailProvider(provider).SetSize(120, 40)
+	m = m.UpdateData(state.Snapshot{}, dsn.SolarSystemSnapshot{
+		Bodies: []dsn.EclipticBody{
+			{Name: "Earth", Code: "EARTH", Kind: dsn.BodyPlanet, Pos: astro.Vec3{X: 1}},
+			{Name: "Voyager 1", Code: "VGR1", Kind: dsn.BodySpacecraft, Pos: astro.Vec3{X: 1.5, Y: 0.5}},
+		},
+	})
+
+	// Focusing a planet fetches nothing
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")})
+	if cmd != nil {
+		t.Fatal("expected no trail fetch for a planet")
+	}
+	if strings.ContainsAny(m.buildCanvas(), "⠁⠂⠄⡀⠈⠐⠠⢀") {
+		t.Error("expected no trail before a fetch")
+	}
+
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if cmd == nil {
+		t.Fatal("expected a trail fetch for the focused spacecraft")
+	}
+	msg, ok := cmd().(trailFetchMsg)
+	if !ok || msg.code != "VGR1" || msg.err != nil {
+		t.Fatalf("unexpected fetch result %+v", msg)
+	}
+	if want := int(2*trailSpan/trailStep) + 1; len(msg.trail.times) != want {
+		t.Errorf("fetched %d epochs, want %d", len(msg.trail.times), want)
+	}
+
+	// No second fetch while one is pending
+	if _, again := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")}); again != nil {
+		t.Error("expected no fetch while one is pending")
+	}
+
+	m, _ = m.Update(msg)
+	if m.trailPending != "" {
+		t.Error("expected pending fetch cleared")
+	}
+	if !strings.ContainsRune(m.buildCanvas(), '◆') {
+		t.Error("expected the focused spacecraft drawn over its trail")
+	}
+	braille := 0
+	for _, r := range m.buildCanvas() {
+		if r > 0x2800 && r <= 0x28FF {
+			braille++
+		}
+	}
+	if braille == 0 {
+		t.Error("expected braille trail cells")
+	}
+
+	// A recent trail is not fetched again
+	m.focusIdx = -1
+	if _, again := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")}); again != nil {
+		t.Error("expected cached trail reused")
+	}
+
+	m = m.SetCharset(CharsetASCII)
+	for _, r := range m.buildCanvas() {
+		if r >= 0x2800 && r <= 0x28FF {
+			t.Fatal("expected no braille in ASCII mode")
+		}
+	}
+}
+
+func TestSolarSystemModelOrbitTrailFade(t *testing.T) {
+	m := NewSolarSystemModel().SetSize(80, 30)
+	now := time.Now()
+	times := []time.Time{now.Add(-2 * trailSpan), now.Add(-trailStep), now, now.Add(trailStep)}
+	m.solarSnap = dsn.SolarSystemSnapshot{Bodies: []dsn.EclipticBody{
+		{Name: "Voyager 1", Code: "VGR1", Kind: dsn.BodySpacecraft, Pos: astro.Vec3{X: 1}},
+	}}
+	m.focusIdx = 0
+	m = m.storeTrail(trailFetchMsg{code: "VGR1", trail: orbitTrail{
+		times: times,
+		pos:   []astro.Vec3{{X: -1}, {X: 0, Y: 1}, {X: 1}, {X: 0, Y: -1}},
+	}})
+
+	bc := newBrailleCanvas(80, 30, CharsetBraille)
+	m.drawTrail(bc, 40, 15, 10, astro.ProjectionConfig{Scale: 1, Mode: astro.ScaleLogR}, now)
+	seen := map[lipgloss.Color]bool{}
+	for y := range bc.dots {
+		for x := range bc.dots[y] {
+			if bc.dots[y][x] != 0 {
+				seen[bc.colors[y][x]] = true
+			}
+		}
+	}
+	for _, c := range []lipgloss.Color{trailFade[0], trailFade[len(trailFade)-1], colorTrailFuture} {
+		if !seen[c] {
+			t.Errorf("expected trail color %v, got %v", c, seen)
+		}
+	}
+
+	// A failed fetch leaves no trail
+	m = m.storeTrail(trailFetchMsg{code: "VGR1", err: errors.New("horizons down")})
+	bc = newBrailleCanvas(80, 30, CharsetBraille)
+	m.drawTrail(bc, 40, 15, 10, astro.ProjectionConfig{Scale: 1, Mode: astro.ScaleLogR}, now)
+	for y := range bc.dots {
+		for x := range bc.dots[y] {
+			if bc.dots[y][x] != 0 {
+				t.Fatal("expected no trail after a failed fetch")
+			}
+		}
+	}
+}
+
+func TestSolarSystemModelOrbitsThroughPlanets(t *testing.T) {
+	m := NewSolarSystemModel().SetSize(120, 40)
+	grid := make([][]rune, 35)
+	for y := range grid {
+		grid[y] = []rune(strings.Repeat(" ", 120))
+	}
+	cfg := astro.ProjectionConfig{Scale: 1, Mode: astro.ScaleLogR}
+	now := time.Now()
+	m.drawOrbits(grid, 60, 17, 30, cfg, now, 0)
+
+	for _, name := range []string{"Earth", "Mars", "Jupiter"} {
+		proj := astro.ProjectEclipticTopDown(astro.PlanetElements[name].Position(now), cfg)
+		x, y := 60+int(proj.X*30), 17-int(proj.Y*30)
+		if grid[y][x] != '·' {
+			t.Errorf("%s at (%d,%d) is not on its orbit", name, x, y)
+		}
+	}
+}
+
+func TestSolarSystemModelSplitView(t *testing.T) {
+	m := NewSolarSystemModel().SetSize(120, 40)
+	m = m.UpdateData(state.Snapshot{}, dsn.SolarSystemSnapshot{
+		Bodies: []dsn.EclipticBody{
+			{Name: "Sun", Code: "SUN", Kind: dsn.BodySun},
+			{Name: "Mars", Code: "MARS", Kind: dsn.BodyPlanet, Pos: astro.Vec3{X: 1.5}},
+			{Name: "Voyager 1", Code: "VGR1", Kind: dsn.BodySpacecraft, Pos: astro.Vec3{X: -100, Y: 120, Z: 40}},
+		},
+	})
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	if !m.split {
+		t.Fatal("expected split view after v")
+	}
+
+	canvas := m.buildCanvas()
+	lines := strings.Split(strings.TrimSuffix(canvas, "\n"), "\n")
+	if len(lines) != 35 {
+		t.Errorf("split canvas has %d lines, want 35", len(lines))
+	}
+	for _, want := range []string{"Inner ≤5.5 AU, linear", "Outer ≤156 AU, log", "│"} {
+		if !strings.Contains(canvas, want) {
+			t.Errorf("split canvas missing %q", want)
+		}
+	}
+
+	// Mars shows in both panes; Voyager only in the outer one
+	if n := strings.Count(canvas, "•"); n != 2 {
+		t.Errorf("Mars drawn %d times, want 2", n)
+	}
+	if n := strings.Count(canvas, "◇"); n != 1 {
+		t.Errorf("Voyager drawn %d times, want 1", n)
+	}
+	if !strings.Contains(m.renderHUD(), "Split") {
+		t.Error("expected HUD mode Split")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	if m.split {
+		t.Error("expected single view after second v")
+	}
+}
+
+// fakeMoonProvider places Jupiter at 5 AU, its Galileans around it where
+// their labels don't collide, and Juno among them.
+type fakeMoonProvider struct{}
+
+func (fakeMoonProvider) GetHeliocentricPosition(naifID int, t time.Time) (astro.Vec3, error) {
+	jupiter := astro.Vec3{X: 5}
+	switch naifID {
+	case 599:
+		return jupiter, nil
+	case 501:
+		return jupiter.Add(astro.Vec3{Y: -0.0028}), nil
+	case 502:
+		return jupiter.Add(astro.Vec3{X: -0.0045, Y: -0.0045}), nil
+	case 503:
+		return jupiter.Add(astro.Vec3{X: -0.0072, Y: 0.0036}), nil
+	case 504:
+		return jupiter.Add(astro.Vec3{X: 0.0089, Y: -0.0089}), nil
+	case -61:
+		return jupiter.Add(astro.Vec3{X: 0.004, Y: 0.004}), nil
+	}
+	return astro.Vec3{}, errors.New("no ephemeris")
+}
+
+func TestSolarSystemModelMoonSystem(t *testing.T) {
+	m := NewSolarSystemModel().SetMoonProvider(fakeMoonProvider{}).SetSize(120, 40)
+	m = m.UpdateData(state.Snapshot{}, dsn.SolarSystemSnapshot{
+		Bodies: []dsn.EclipticBody{
+			{Name: "Sun", Code: "SUN", Kind: dsn.BodySun},
+			{Name: "Jupiter", Code: "JUP", Kind: dsn.BodyPlanet, Class: dsn.ClassGiant, Pos: astro.Vec3{X: 5}},
+			{Name: "Juno", Code: "JUNO", Kind: dsn.BodySpacecraft, Pos: astro.Vec3{X: 5.004, Y: 0.004}},
+		},
+	})
+
+	// Focused but not zoomed in: nothing to fetch
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")})
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")})
+	if m.FocusedBody() == nil || m.FocusedBody().Code != "JUP" {
+		t.Fatalf("expected Jupiter focused, got %+v", m.FocusedBody())
+	}
+	if cmd != nil {
+		if _, ok := cmd().(planetSystemMsg); ok {
+			t.Fatal("expected no moon fetch at 1x")
+		}
+	}
+
+	var msg tea.Msg
+	for m.scale() < moonZoomScale {
+		m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("+")})
+	}
+	if cmd == nil {
+		t.Fatal("expected a moon fetch once zoomed in")
+	}
+	msg = cmd()
+	sys, ok := msg.(planetSystemMsg)
+	if !ok || sys.err != nil || len(sys.system.Bodies) != 5 {
+		t.Fatalf("unexpected fetch result %+v", msg)
+	}
+
+	canvas := m.buildCanvas()
+	if strings.ContainsRune(canvas, glyphSatellite) {
+		t.Error("expected no moons before the fetch completes")
+	}
+
+	m, _ = m.Update(msg)
+	canvas = m.buildCanvas()
+	if n := strings.Count(canvas, string(glyphSatellite)); n != 4 {
+		t.Errorf("drew %d moons, want 4", n)
+	}
+	for _, name := range []string{"Io", "Europa", "Ganymede", "Callisto", "Juno"} {
+		if !strings.Contains(canvas, name) {
+			t.Errorf("expected %s labeled", name)
+		}
+	}
+	if n := strings.Count(canvas, "◇"); n != 1 {
+		t.Errorf("Juno drawn %d times, want once among the moons", n)
+	}
+
+	// Zooming back out hides them
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("0")})
+	if strings.ContainsRune(m.buildCanvas(), glyphSatellite) {
+		t.Error("expected no moons at 1x")
+	}
+}
+
+func TestSolarSystemModelObliqueView(t *testing.T) {
+	m := NewSolarSystemModel().SetSize(120, 40)
+	m = m.UpdateData(state.Snapshot{}, dsn.SolarSystemSnapshot{
+		Bodies: []dsn.EclipticBody{
+			{Name: "Sun", Code: "SUN", Kind: dsn.BodySun},
+			{Name: "Earth", Code: "EARTH", Kind: dsn.BodyPlanet, Pos: astro.Vec3{X: 1}},
+			{Name: "Ulysses", Code: "ULYS", Kind: dsn.BodySpacecraft, Pos: astro.Vec3{X: 0.5, Y: 0.5, Z: 2}},
+		},
+	})
+	key := func(m SolarSystemModel, k string) SolarSystemModel {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		return m
+	}
+
+	if strings.ContainsRune(m.buildCanvas(), glyphDropLine) {
+		t.Error("expected no drop lines top-down")
+	}
+
+	m = key(m, "x")
+	if !m.oblique || m.pitchDeg != defaultPitchDeg || m.yawDeg != 0 {
+		t.Fatalf("after x: oblique %v tilt %v yaw %v", m.oblique, m.pitchDeg, m.yawDeg)
+	}
+	if !strings.Contains(m.renderHUD(), "tilt 60° yaw 0°") {
+		t.Error("expected the orientation in the HUD")
+	}
+	if !strings.ContainsRune(m.buildCanvas(), glyphDropLine) {
+		t.Error("expected a drop line from the inclined spacecraft")
+	}
+
+	// Yaw wraps; tilt stops at edge-on and face-on
+	m = key(m, ",")
+	if m.yawDeg != 345 {
+		t.Errorf("yaw after , = %v, want 345", m.yawDeg)
+	}
+	for range 5 {
+		m = key(m, ">")
+	}
+	if m.pitchDeg != 90 {
+		t.Errorf("tilt = %v, want capped at 90", m.pitchDeg)
+	}
+	for range 10 {
+		m = key(m, "<")
+	}
+	if m.pitchDeg != 0 {
+		t.Errorf("tilt = %v, want floored at 0", m.pitchDeg)
+	}
+
+	// Rotating from top-down starts from face-on
+	m = key(key(m, "r"), ".")
+	if !m.oblique || m.yawDeg != 15 || m.pitchDeg != 0 {
+		t.Errorf("after r and .: oblique %v tilt %v yaw %v", m.oblique, m.pitchDeg, m.yawDeg)
+	}
+	m = key(m, "x")
+	if m.oblique {
+		t.Error("expected top-down after x")
+	}
+}
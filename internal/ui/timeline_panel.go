@@ -0,0 +1,184 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+	"github.com/litescript/ls-horizons/internal/state"
+)
+
+// timelineRows is the height of each chart in terminal rows (4 dot levels
+// per row in braille).
+const timelineRows = 2
+
+// timelineComplexes is the chart order, west to east as in the summary.
+var timelineComplexes = []dsn.Complex{dsn.ComplexGoldstone, dsn.ComplexCanberra, dsn.ComplexMadrid}
+
+// RenderTimelinePanel renders per-complex charts of active links and
+// aggregate data rate across the timeline window, ending at now. Each
+// chart column is the busiest sample in its slice of the window; both
+// metrics share a scale across complexes so they can be compared.
+// Format:
+//
+//	Utilization (last 6h)   active links            data rate
+//	  Goldstone     ⣀⣤⣶⣿⣿⣶   4       ⣀⣀⣤⣿⣿⣶   28.0 Mbps
+//	                ⣿⣿⣿⣿⣿⣿   peak 6  ⣿⣿⣿⣿⣿⣿   peak 30.0 Mbps
+func RenderTimelinePanel(samples []state.TimelineSample, window time.Duration, now time.Time, width int, charset Charset) string {
+	var b strings.Builder
+
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("60"))
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("252"))
+	linksStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("39"))
+	rateStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
+
+	b.WriteString(titleStyle.Render("Utilization (last " + formatWindow(window) + ")"))
+	b.WriteString("\n")
+	if len(samples) == 0 || window <= 0 {
+		b.WriteString(dimStyle.Render("  No samples yet: one is taken per minute"))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	// Label, then two charts each followed by a value column
+	const labelWidth, valueWidth = 14, 16
+	cells := max(8, (width-labelWidth-2*valueWidth-4)/2)
+	start := now.Add(-window)
+
+	links := make(map[dsn.Complex][]float64)
+	rates := make(map[dsn.Complex][]float64)
+	var maxLinks, maxRate float64
+	for _, c := range timelineComplexes {
+		links[c] = timelineColumns(samples, start, window, 2*cells, func(s state.TimelineSample) float64 { return float64(s.Links[c]) })
+		rates[c] = timelineColumns(samples, start, window, 2*cells, func(s state.TimelineSample) float64 { return s.Rate[c] })
+		for i := range links[c] {
+			maxLinks = math.Max(maxLinks, links[c][i])
+			maxRate = math.Max(maxRate, rates[c][i])
+		}
+	}
+	latest := samples[len(samples)-1]
+
+	b.WriteString(labelStyle.Render(pad("", labelWidth) + pad("active links", cells+valueWidth+2) + "data rate"))
+	b.WriteString("\n")
+	for _, c := range timelineComplexes {
+		linkRows := brailleBars(links[c], maxLinks, timelineRows, charset)
+		rateRows := brailleBars(rates[c], maxRate, timelineRows, charset)
+		peakLinks, peakRate := 0.0, 0.0
+		for i := range links[c] {
+			peakLinks = math.Max(peakLinks, links[c][i])
+			peakRate = math.Max(peakRate, rates[c][i])
+		}
+		for row := range timelineRows {
+			label, linkValue, rateValue := "", "", ""
+			switch row {
+			case 0:
+				label = dsn.KnownComplexes[c].Name
+				linkValue = fmt.Sprintf("%d", latest.Links[c])
+				rateValue = formatTimelineRate(latest.Rate[c])
+			case 1:
+				linkValue = fmt.Sprintf("peak %.0f", peakLinks)
+				rateValue = "peak " + formatTimelineRate(peakRate)
+			}
+			b.WriteString("  " + complexNameStyle.Render(pad(label, labelWidth-2)))
+			b.WriteString(linksStyle.Render(linkRows[row]) + "  ")
+			b.WriteString(valueStyle.Render(pad(linkValue, valueWidth)))
+			b.WriteString(rateStyle.Render(rateRows[row]) + "  ")
+			b.WriteString(valueStyle.Render(rateValue))
+			b.WriteString("\n")
+		}
+	}
+
+	// Time axis under each chart
+	axis := "-" + formatWindow(window)
+	axis += strings.Repeat(" ", max(1, cells-len(axis)-len("now"))) + "now"
+	b.WriteString(dimStyle.Render(pad("", labelWidth) + pad(axis, cells+2+valueWidth) + axis))
+	b.WriteString("\n")
+	return b.String()
+}
+
+// timelineColumns splits the window into n equal slices and returns the
+// largest value of each, or NaN for a slice without samples.
+func timelineColumns(samples []state.TimelineSample, start time.Time, window time.Duration, n int, value func(state.TimelineSample) float64) []float64 {
+	cols := make([]float64, n)
+	for i := range cols {
+		cols[i] = math.NaN()
+	}
+	for _, s := range samples {
+		offset := s.Timestamp.Sub(start)
+		if offset < 0 || offset > window {
+			continue
+		}
+		i := min(n-1, int(float64(offset)/float64(window)*float64(n)))
+		if v := value(s); math.IsNaN(cols[i]) || v > cols[i] {
+			cols[i] = v
+		}
+	}
+	return cols
+}
+
+// brailleBars draws values as a filled bar chart, two values per cell
+// and four dot levels per row, scaled to maxValue. Rows are returned top
+// first. NaN values (no data) draw nothing; any positive value draws at
+// least one dot.
+func brailleBars(values []float64, maxValue float64, rows int, charset Charset) []string {
+	cells := (len(values) + 1) / 2
+	dots := make([][]rune, rows)
+	for r := range dots {
+		dots[r] = make([]rune, cells)
+	}
+	levels := rows * 4
+	for i, v := range values {
+		if math.IsNaN(v) || v <= 0 || maxValue <= 0 {
+			continue
+		}
+		h := max(1, int(math.Round(v/maxValue*float64(levels))))
+		for level := range min(h, levels) {
+			// level 0 is the bottom dot of the bottom row
+			row := rows - 1 - level/4
+			dots[row][i/2] |= brailleDots[3-level%4][i%2]
+		}
+	}
+
+	out := make([]string, rows)
+	for r, line := range dots {
+		var sb strings.Builder
+		for _, d := range line {
+			switch {
+			case charset == CharsetASCII:
+				sb.WriteRune(asciiDensity(d))
+			case d == 0:
+				sb.WriteRune(' ')
+			default:
+				sb.WriteRune(0x2800 | d)
+			}
+		}
+		out[r] = sb.String()
+	}
+	return out
+}
+
+// formatTimelineRate formats an aggregate rate; an idle complex reads
+// 0 bps rather than FormatDataRate's N/A.
+func formatTimelineRate(bps float64) string {
+	if bps <= 0 {
+		return "0 bps"
+	}
+	return dsn.FormatDataRate(bps)
+}
+
+// formatWindow formats a timeline window as whole hours or minutes.
+func formatWindow(d time.Duration) string {
+	switch {
+	case d >= time.Hour && d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	default:
+		return d.String()
+	}
+}
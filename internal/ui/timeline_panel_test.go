@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+	"github.com/litescript/ls-horizons/internal/state"
+)
+
+func TestBrailleBars(t *testing.T) {
+	nan := math.NaN()
+	tests := []struct {
+		name    string
+		values  []float64
+		charset Charset
+		want    []string
+	}{
+		// A full left column and a half-height right one
+		{"braille", []float64{8, 4}, CharsetBraille, []string{"⡇", "⣿"}},
+		{"ascii", []float64{8, 4}, CharsetASCII, []string{"*", "#"}},
+		{"small values show a dot", []float64{0.1, 0}, CharsetBraille, []string{" ", "⡀"}},
+		{"no data draws nothing", []float64{nan, nan, 8}, CharsetBraille, []string{" ⡇", " ⡇"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := brailleBars(tt.values, 8, 2, tt.charset)
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("brailleBars = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderTimelinePanel(t *testing.T) {
+	now := time.Date(2025, 12, 5, 12, 0, 0, 0, time.UTC)
+	var samples []state.TimelineSample
+	for i := range 360 {
+		samples = append(samples, state.TimelineSample{
+			Timestamp: now.Add(time.Duration(i-359) * time.Minute),
+			Links:     map[dsn.Complex]int{dsn.ComplexGoldstone: i % 5, dsn.ComplexMadrid: 2},
+			Rate:      map[dsn.Complex]float64{dsn.ComplexGoldstone: 1e6, dsn.ComplexMadrid: float64(i) * 1000},
+		})
+	}
+
+	out := RenderTimelinePanel(samples, 6*time.Hour, now, 120, CharsetBraille)
+	for _, want := range []string{
+		"Utilization (last 6h)", "active links", "data rate",
+		"Goldstone", "Canberra", "Madrid",
+		"peak 4", "359 kbps", "peak 0 bps", "-6h", "now",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("panel missing %q:\n%s", want, out)
+		}
+	}
+
+	if out := RenderTimelinePanel(nil, 6*time.Hour, now, 120, CharsetBraille); !strings.Contains(out, "No samples yet") {
+		t.Errorf("empty panel = %q", out)
+	}
+}
+
+func TestTimelineColumns(t *testing.T) {
+	start := time.Date(2025, 12, 5, 6, 0, 0, 0, time.UTC)
+	samples := []state.TimelineSample{
+		{Timestamp: start.Add(-time.Minute), Links: map[dsn.Complex]int{dsn.ComplexGoldstone: 9}}, // before the window
+		{Timestamp: start, Links: map[dsn.Complex]int{dsn.ComplexGoldstone: 1}},
+		{Timestamp: start.Add(10 * time.Minute), Links: map[dsn.Complex]int{dsn.ComplexGoldstone: 3}},
+		{Timestamp: start.Add(20 * time.Minute), Links: map[dsn.Complex]int{dsn.ComplexGoldstone: 2}},
+	}
+	cols := timelineColumns(samples, start, time.Hour, 4, func(s state.TimelineSample) float64 {
+		return float64(s.Links[dsn.ComplexGoldstone])
+	})
+	// 15-minute slices: the busiest sample in each, NaN without one
+	if cols[0] != 3 || cols[1] != 2 || !math.IsNaN(cols[2]) || !math.IsNaN(cols[3]) {
+		t.Errorf("timelineColumns = %v", cols)
+	}
+}
+
+func TestDashboard_TimelineToggle(t *testing.T) {
+	mgr := state.NewManager(state.DefaultConfig())
+	mgr.Update(antennaData(time.Now()), 0, nil)
+	m := NewDashboardModel().SetSize(120, 40).UpdateData(mgr.Snapshot())
+	if strings.Contains(m.View(), "Utilization") {
+		t.Fatal("timeline shown before t")
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	if out := m.View(); !strings.Contains(out, "Utilization (last 6h)") || !strings.Contains(out, "Goldstone") {
+		t.Errorf("t should show the timeline:\n%s", out)
+	}
+}
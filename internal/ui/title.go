@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+)
+
+// Terminal title stack sequences (XTWINOPS): the title is saved before
+// the TUI starts setting it and restored on exit. Terminals and
+// multiplexers without a stack ignore them.
+const (
+	PushTitle = "\x1b[22;2t"
+	PopTitle  = "\x1b[23;2t"
+)
+
+// SetWindowTitle makes the TUI keep the terminal (and tmux pane) title
+// set to a compact live status, readable while the window is in the
+// background.
+func (m Model) SetWindowTitle(on bool) Model {
+	m.windowTitle = on
+	return m
+}
+
+// windowTitle summarizes the feed and the focused spacecraft:
+//
+//	DSN: 27 links | VGR1 160 bps
+func windowTitle(data *dsn.DSNData, f Focus) string {
+	if data == nil {
+		return "DSN: waiting for data"
+	}
+	title := fmt.Sprintf("DSN: %d links", len(data.Links))
+	if len(data.Links) == 1 {
+		title = "DSN: 1 link"
+	}
+	if f.Kind != dsn.BodySpacecraft.String() || f.Code == "" {
+		return title
+	}
+	for _, link := range data.Links {
+		if link.Spacecraft == f.Code {
+			return title + " | " + f.Code + " " + dsn.FormatLinkRate(link)
+		}
+	}
+	return title + " | " + f.Code + " untracked"
+}
+
+// updateTitle sets the window title if the status in it changed.
+func (m Model) updateTitle() (Model, tea.Cmd) {
+	if !m.windowTitle {
+		return m, nil
+	}
+	title := windowTitle(m.followedSnapshot().Data, m.currentFocus())
+	if title == m.lastTitle {
+		return m, nil
+	}
+	m.lastTitle = title
+	return m, tea.SetWindowTitle(title)
+}
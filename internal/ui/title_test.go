@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/litescript/ls-horizons/internal/dsn"
+	"github.com/litescript/ls-horizons/internal/state"
+)
+
+func TestWindowTitle(t *testing.T) {
+	data := &dsn.DSNData{Links: []dsn.Link{
+		{Spacecraft: "VGR1", AntennaID: "DSS43", DataRate: 160},
+		{Spacecraft: "JWST", AntennaID: "DSS26", DataRate: 28e6},
+	}}
+	sc := func(code string) Focus { return Focus{View: "mission", Kind: dsn.BodySpacecraft.String(), Code: code} }
+
+	tests := []struct {
+		name  string
+		data  *dsn.DSNData
+		focus Focus
+		want  string
+	}{
+		{"no data", nil, Focus{}, "DSN: waiting for data"},
+		{"no focus", data, Focus{View: "events"}, "DSN: 2 links"},
+		{"focused", data, sc("VGR1"), "DSN: 2 links | VGR1 " + dsn.FormatDataRate(160)},
+		{"untracked", data, sc("MRO"), "DSN: 2 links | MRO untracked"},
+		{"planet", data, Focus{View: "orbit", Kind: "planet", Code: "MARS"}, "DSN: 2 links"},
+	}
+	for _, tt := range tests {
+		if got := windowTitle(tt.data, tt.focus); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestUpdateTitle(t *testing.T) {
+	mgr := state.NewManager(state.DefaultConfig())
+	mgr.Update(&dsn.DSNData{Links: []dsn.Link{
+		{SpacecraftID: 31, Spacecraft: "VGR1", AntennaID: "DSS63", Complex: dsn.ComplexMadrid, DataRate: 160},
+	}}, time.Second, nil)
+
+	m, cmd := New(mgr, nil).updateTitle()
+	if cmd != nil || m.lastTitle != "" {
+		t.Fatal("title set while off")
+	}
+
+	m = m.SetWindowTitle(true)
+	updated, _ := m.Update(DataUpdateMsg{Snapshot: mgr.Snapshot()})
+	m = updated.(Model)
+	if want := "DSN: 1 link | VGR1 " + dsn.FormatDataRate(160); m.lastTitle != want {
+		t.Errorf("title = %q, want %q", m.lastTitle, want)
+	}
+	if _, cmd := m.updateTitle(); cmd != nil {
+		t.Error("unchanged title set again")
+	}
+
+	// The focus moves with the view
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("5")})
+	m = updated.(Model)
+	if m.lastTitle != "DSN: 1 link" {
+		t.Errorf("events view title = %q", m.lastTitle)
+	}
+}
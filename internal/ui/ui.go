@@ -9,8 +9,12 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/litescript/ls-horizons/internal/astro"
+	"github.com/litescript/ls-horizons/internal/bookmarks"
 	"github.com/litescript/ls-horizons/internal/dsn"
 	"github.com/litescript/ls-horizons/internal/ephem"
+	"github.com/litescript/ls-horizons/internal/notes"
+	"github.com/litescript/ls-horizons/internal/sandbox"
 	"github.com/litescript/ls-horizons/internal/state"
 	"github.com/litescript/ls-horizons/internal/version"
 )
@@ -23,8 +27,27 @@ const (
 	ViewMissionDetail
 	ViewSky
 	ViewSolarSystem
+	ViewEvents
+
+	viewCount = iota // number of views, for cycling with tab
 )
 
+// String returns the view name, as accepted by ParseViewMode.
+func (v ViewMode) String() string {
+	switch v {
+	case ViewMissionDetail:
+		return "mission"
+	case ViewSky:
+		return "sky"
+	case ViewSolarSystem:
+		return "orbit"
+	case ViewEvents:
+		return "events"
+	default:
+		return "dashboard"
+	}
+}
+
 // Msg types for Bubble Tea
 type (
 	// TickMsg triggers periodic UI updates.
@@ -52,6 +75,7 @@ type (
 	passPlanUpdatedMsg struct {
 		spacecraftID int
 		plan         *dsn.PassPlan
+		samples      []astro.RADecAtTime // RA/Dec the plan was computed from
 		err          error
 	}
 
@@ -70,6 +94,15 @@ type (
 	DashboardOpenMissionMsg struct {
 		SpacecraftID int
 	}
+
+	// solarPlanetsUpdatedMsg signals a manual planet refresh completed.
+	solarPlanetsUpdatedMsg struct{}
+
+	// SolarSystemRefreshMsg requests an immediate solar system cache refresh.
+	SolarSystemRefreshMsg struct {
+		Planets    bool
+		Spacecraft bool
+	}
 )
 
 // Model is the root Bubble Tea model.
@@ -77,20 +110,60 @@ type Model struct {
 	// Dependencies
 	state         *state.Manager
 	ephemProvider ephem.Provider
+	horizonsUsage *ephem.Usage // Horizons requests against the quota (nil = not shown)
 
 	// UI state
 	viewMode  ViewMode
 	width     int
 	height    int
 	ready     bool
-	statusMsg string // Status message for update checks, etc.
-	animTick  int    // Animation tick for shimmer effects
+	statusMsg string  // Status message for update checks, etc.
+	animTick  int     // Animation tick for shimmer effects
+	eco       bool    // Low-power mode: no animation, focused-only prefetch
+	replay    float64 // Playback speed when replaying recorded snapshots (0 = live)
+	profile   Profile
+	charset   Charset
+	lang      Language // language of the about pages
+	about     bool     // "about this data" page shown over the current view
+	mailbox   *Mailbox // latest-only delivery of background results (nil = direct)
+
+	watchlist dsn.Watchlist // spacecraft to follow (empty = no watchlist)
+	following bool          // dashboard and sky show only the watchlist
+
+	bookmarks       *bookmarks.Store    // bookmarks taken with b (nil = off)
+	playBookmark    BookmarkPlayer      // revisits a bookmark (nil = browse only)
+	pendingBookmark *bookmarks.Bookmark // moment captured, note being typed
+	bookmarkDraft   []rune              // the bookmark note being typed
+	browsing        bool                // bookmark browser shown over the current view
+	bookmarkCursor  int
+	revisiting      *bookmarks.Bookmark // bookmark whose focus is restored when its data arrives
+
+	searching    bool   // spacecraft search shown over the current view
+	searchQuery  []rune // what has been typed after /
+	searchCursor int
+
+	palette       bool   // command palette shown over the current view
+	paletteQuery  []rune // what has been typed since ctrl+p
+	paletteCursor int
+	refreshNow    func() // asks for a DSN fetch now (nil = unavailable)
+
+	announcer Announcer // focus change side channel (nil = off)
+	lastFocus Focus     // last focus announced
+
+	windowTitle bool   // keep the terminal title set to a live status
+	lastTitle   string // title last set
+
+	publishFocus func(code string) // shares focus with other instances (nil = off)
+	remoteFocus  string            // spacecraft last focused from another instance
+
+	loadSettings SettingsLoader // re-reads the config on ctrl+r (nil = disabled)
 
 	// Sub-models
 	dashboard     DashboardModel
 	missionDetail MissionDetailModel
 	skyView       SkyViewModel
 	solarSystem   SolarSystemModel
+	events        EventsModel
 
 	// Data snapshot (updated on DataUpdateMsg)
 	snapshot   state.Snapshot
@@ -110,11 +183,16 @@ func New(stateMgr *state.Manager, ephemProvider ephem.Provider) Model {
 
 	// Create solar system cache with Horizons provider if available
 	var solarCache *dsn.SolarSystemCache
-	if hp, ok := ephemProvider.(*ephem.HorizonsProvider); ok {
-		solarCache = dsn.NewSolarSystemCache(hp)
+	solarSystem := NewSolarSystemModel()
+	if sp, ok := ephemProvider.(dsn.SolarSystemProvider); ok {
+		solarCache = dsn.NewSolarSystemCache(sp)
+		solarSystem = solarSystem.SetMoonProvider(sp)
 	} else {
 		solarCache = dsn.NewSolarSystemCache(nil)
 	}
+	if vp, ok := ephemProvider.(dsn.SolarSystemSeriesProvider); ok {
+		solarSystem = solarSystem.SetTrailProvider(vp)
+	}
 
 	return Model{
 		state:         stateMgr,
@@ -123,13 +201,116 @@ func New(stateMgr *state.Manager, ephemProvider ephem.Provider) Model {
 		dashboard:     NewDashboardModel(),
 		missionDetail: NewMissionDetailModel(),
 		skyView:       skyView,
-		solarSystem:   NewSolarSystemModel(),
+		solarSystem:   solarSystem,
+		events:        NewEventsModel(),
 		solarCache:    solarCache,
 	}
 }
 
+// SetSolarSystemConfig sets the planet and spacecraft refresh intervals
+// used by the Orbit view.
+func (m Model) SetSolarSystemConfig(cfg dsn.SolarSystemConfig) Model {
+	if m.solarCache != nil {
+		m.solarCache.SetConfig(cfg)
+	}
+	return m
+}
+
+// SetEcoMode enables low-power mode: animation is dropped entirely and
+// pass plans are only prefetched for the focused spacecraft.
+func (m Model) SetEcoMode(on bool) Model {
+	m.eco = on
+	m.skyView = m.skyView.SetNoAnimation(on)
+	return m
+}
+
+// SetHorizonsUsage shows Horizons requests against the quota: a footer
+// warning as they near it, and a breakdown on the about page.
+func (m Model) SetHorizonsUsage(u *ephem.Usage) Model {
+	m.horizonsUsage = u
+	return m
+}
+
+// SetRefraction shows the Sky view at apparent elevations, corrected for
+// atmospheric refraction as the antennas see them near the horizon.
+func (m Model) SetRefraction(on bool) Model {
+	m.skyView = m.skyView.SetRefraction(on)
+	return m
+}
+
+// SetReplay marks the data as a replay of recorded snapshots at the given
+// playback speed. The footer shows the feed time instead of a refresh
+// countdown.
+func (m Model) SetReplay(speed float64) Model {
+	m.replay = speed
+	return m
+}
+
+// SetProfile selects the layout profile. ProfileSmall drops the logo and
+// abbreviates the tabs, footer, and dashboard for small displays.
+func (m Model) SetProfile(p Profile) Model {
+	m.profile = p
+	m.dashboard = m.dashboard.SetCompact(p == ProfileSmall)
+	return m
+}
+
+// SetCharset selects braille or ASCII glyphs for sky paths, charts, and
+// spinners.
+func (m Model) SetCharset(c Charset) Model {
+	m.charset = c
+	m.skyView = m.skyView.SetCharset(c)
+	m.dashboard = m.dashboard.SetCharset(c)
+	m.missionDetail = m.missionDetail.SetCharset(c)
+	m.solarSystem = m.solarSystem.SetCharset(c)
+	return m
+}
+
+// SetLanguage selects the language of the per-view "about this data"
+// pages and of error hints.
+func (m Model) SetLanguage(lang Language) Model {
+	m.lang = lang
+	m.dashboard = m.dashboard.SetLanguage(lang)
+	m.missionDetail = m.missionDetail.SetLanguage(lang)
+	return m
+}
+
+// SetMailbox routes pass plan and elevation trace results through mb, so a
+// slow terminal sees only the latest result per spacecraft. The same
+// mailbox should carry fetch-loop updates (see Mailbox.PostFetch).
+func (m Model) SetMailbox(mb *Mailbox) Model {
+	m.mailbox = mb
+	return m
+}
+
+// SetWatchlist limits the dashboard, sky view, and event log to the
+// spacecraft on w; w toggles between them and everything.
+func (m Model) SetWatchlist(w dsn.Watchlist) Model {
+	m.watchlist = w
+	m.following = len(w) > 0
+	return m
+}
+
+// followedSnapshot returns the snapshot the dashboard and sky view show:
+// narrowed to the watchlist while following it.
+func (m Model) followedSnapshot() state.Snapshot {
+	if !m.following {
+		return m.snapshot
+	}
+	return m.snapshot.Follow(m.watchlist)
+}
+
+// SetNotes shows the notes journal in the mission view, where n adds a
+// note on the selected spacecraft (nil disables notes).
+func (m Model) SetNotes(store *notes.Store) Model {
+	m.missionDetail = m.missionDetail.SetNotes(store)
+	return m
+}
+
 // Init implements tea.Model.
 func (m Model) Init() tea.Cmd {
+	if m.eco {
+		return tea.Batch(tickCmd(), m.dashboard.Init())
+	}
 	return tea.Batch(
 		tickCmd(),
 		animTickCmd(),
@@ -143,6 +324,35 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		// A note being typed takes every key but ctrl+c
+		if m.viewMode == ViewMissionDetail && m.missionDetail.Editing() && msg.String() != "ctrl+c" {
+			cmds = append(cmds, m.updateActiveView(msg))
+			break
+		}
+		if m.pendingBookmark != nil && msg.String() != "ctrl+c" {
+			var cmd tea.Cmd
+			m, cmd = m.updateBookmarkDraft(msg)
+			cmds = append(cmds, cmd)
+			break
+		}
+		if m.palette && msg.String() != "ctrl+c" {
+			var cmd tea.Cmd
+			m, cmd = m.updatePalette(msg)
+			cmds = append(cmds, cmd)
+			break
+		}
+		if m.searching && msg.String() != "ctrl+c" {
+			var cmd tea.Cmd
+			m, cmd = m.updateSearch(msg)
+			cmds = append(cmds, cmd)
+			break
+		}
+		if m.browsing && msg.String() != "q" && msg.String() != "ctrl+c" {
+			var cmd tea.Cmd
+			m, cmd = m.updateBrowser(msg)
+			cmds = append(cmds, cmd)
+			break
+		}
 		switch msg.String() {
 		case "q", "ctrl+c":
 			return m, tea.Quit
@@ -154,25 +364,114 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "3", "s":
 			// Enter Sky View, sync focus from dashboard if available
 			if m.viewMode != ViewSky {
-				m.skyView = m.skyView.SyncFromDashboard(m.dashboard, m.snapshot)
+				m.skyView = m.skyView.SyncFromDashboard(m.dashboard, m.followedSnapshot())
 			}
 			m.viewMode = ViewSky
 		case "4", "o":
 			m.viewMode = ViewSolarSystem
+		case "5", "e":
+			m.viewMode = ViewEvents
 
 		case "tab":
 			// Cycle through views
-			m.viewMode = (m.viewMode + 1) % 4
+			m.viewMode = (m.viewMode + 1) % viewCount
+
+		case "i":
+			m.about = !m.about
+
+		case "w":
+			if len(m.watchlist) == 0 {
+				m.statusMsg = "No watchlist: start with --follow VGR1,JWST"
+				break
+			}
+			m.following = !m.following
+			m.dashboard = m.dashboard.UpdateData(m.followedSnapshot())
+			m.skyView = m.skyView.UpdateData(m.followedSnapshot())
+			m.events = m.events.UpdateData(m.followedSnapshot())
+
+		case "ctrl+p":
+			m = m.startPalette()
+
+		case "/":
+			m = m.startSearch()
+
+		case "b":
+			m = m.startBookmark()
+
+		case "B":
+			if m.bookmarks == nil {
+				m.statusMsg = "Bookmarks are off"
+				break
+			}
+			m.browsing = true
+			m.bookmarkCursor = 0
+
+		case "ctrl+r":
+			if m.loadSettings == nil {
+				break
+			}
+			m.statusMsg = "Reloading config..."
+			cmds = append(cmds, m.reloadSettings())
 
 		case "u":
+			if err := sandbox.Check("update check"); err != nil {
+				m.statusMsg = "Update check " + sandbox.ErrReadOnly.Error()
+				break
+			}
 			m.statusMsg = "Checking for updates..."
 			cmds = append(cmds, checkForUpdate())
 
 		default:
+			// The about page takes no view keys; esc closes it
+			if m.about {
+				if msg.String() == "esc" {
+					m.about = false
+				}
+				break
+			}
 			// Pass to active view
 			cmds = append(cmds, m.updateActiveView(msg))
 		}
 
+	case noteSavedMsg:
+		if msg.err != nil {
+			m.statusMsg = fmt.Sprintf("Note not saved: %v", msg.err)
+		} else {
+			m.statusMsg = "Note saved for " + msg.note.Spacecraft
+		}
+
+	case snapshotExportedMsg:
+		if msg.err != nil {
+			m.statusMsg = fmt.Sprintf("Snapshot not exported: %v", msg.err)
+		} else {
+			m.statusMsg = "Snapshot exported to " + msg.path
+		}
+
+	case bookmarkSavedMsg:
+		if msg.err != nil {
+			m.statusMsg = fmt.Sprintf("Bookmark not saved: %v", msg.err)
+		} else {
+			m.statusMsg = "Bookmarked " + msg.bookmark.FeedTime().UTC().Format("Jan 02 15:04 UTC") + " (B: browse)"
+		}
+
+	case bookmarkOpenedMsg:
+		if msg.err != nil {
+			m.statusMsg = fmt.Sprintf("Bookmark not opened: %v", msg.err)
+		} else {
+			m = m.openBookmark(msg.bookmark)
+		}
+
+	case RemoteFocusMsg:
+		m = m.followRemoteFocus(msg.Code)
+
+	case settingsReloadedMsg:
+		if msg.err != nil {
+			m.statusMsg = fmt.Sprintf("Config reload failed: %v", msg.err)
+		} else {
+			m = m.applySettings(msg.settings)
+			m.statusMsg = "Config reloaded"
+		}
+
 	case updateCheckMsg:
 		if msg.info.Error != nil {
 			m.statusMsg = fmt.Sprintf("Update check failed: %v", msg.info.Error)
@@ -190,11 +489,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Propagate to sub-models
 		// Logo takes ~11 lines (added version line), footer ~2 lines
-		contentHeight := msg.Height - 15
+		contentHeight := msg.Height - defaultHeaderLines
+		if m.profile == ProfileSmall {
+			contentHeight = msg.Height - smallHeaderLines
+		}
 		m.dashboard = m.dashboard.SetSize(msg.Width, contentHeight)
 		m.missionDetail = m.missionDetail.SetSize(msg.Width, contentHeight)
 		m.skyView = m.skyView.SetSize(msg.Width, contentHeight)
 		m.solarSystem = m.solarSystem.SetSize(msg.Width, contentHeight)
+		m.events = m.events.SetSize(msg.Width, contentHeight)
 
 	case TickMsg:
 		cmds = append(cmds, tickCmd())
@@ -209,9 +512,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case DataUpdateMsg:
 		m.snapshot = msg.Snapshot
-		m.dashboard = m.dashboard.UpdateData(m.snapshot)
+		m.dashboard = m.dashboard.UpdateData(m.followedSnapshot())
 		m.missionDetail = m.missionDetail.UpdateData(m.snapshot)
-		m.skyView = m.skyView.UpdateData(m.snapshot)
+		m.skyView = m.skyView.UpdateData(m.followedSnapshot())
+		m.events = m.events.UpdateData(m.followedSnapshot())
+		m = m.restoreBookmarkFocus()
 
 		// Update solar system cache with DSN data (async to avoid blocking UI)
 		if m.solarCache != nil {
@@ -219,10 +524,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.solarCache.NeedsSpacecraftRefresh() {
 				_ = m.solarCache.UpdateSpacecraft(m.snapshot.Data)
 			}
-			// Planet updates are slow (HTTP calls) - do async
+			// Planet and spacecraft vector updates are slow (HTTP calls) - do async
 			if m.solarCache.NeedsPlanetRefresh() {
 				go m.solarCache.UpdatePlanets()
 			}
+			if m.solarCache.NeedsSpacecraftVectorRefresh() {
+				go m.solarCache.UpdateSpacecraftVectors()
+			}
 			solarSnap := m.solarCache.GetSnapshot()
 			m.solarSystem = m.solarSystem.UpdateData(m.snapshot, solarSnap)
 		}
@@ -237,7 +545,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds = append(cmds, m.refreshAllPassPlans()...)
 
 	case passPlanUpdatedMsg:
-		m.state.UpdatePassPlan(msg.spacecraftID, msg.plan, msg.err)
+		m.state.UpdatePassPlanSamples(msg.spacecraftID, msg.plan, msg.samples, msg.err)
 		m.passPlanFetching = false
 		// Request fresh snapshot to get the updated pass plan
 		m.snapshot = m.state.Snapshot()
@@ -290,6 +598,31 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+	case SolarSystemRefreshMsg:
+		if m.solarCache != nil {
+			if msg.Spacecraft {
+				_ = m.solarCache.UpdateSpacecraft(m.snapshot.Data)
+				if m.solarCache.NeedsSpacecraftVectorRefresh() {
+					go m.solarCache.UpdateSpacecraftVectors()
+				}
+			}
+			if msg.Planets {
+				m.statusMsg = "Refreshing planet positions..."
+				cache := m.solarCache
+				cmds = append(cmds, func() tea.Msg {
+					_ = cache.UpdatePlanets()
+					return solarPlanetsUpdatedMsg{}
+				})
+			}
+			m.solarSystem = m.solarSystem.UpdateData(m.snapshot, m.solarCache.GetSnapshot())
+		}
+
+	case solarPlanetsUpdatedMsg:
+		m.statusMsg = ""
+		if m.solarCache != nil {
+			m.solarSystem = m.solarSystem.UpdateData(m.snapshot, m.solarCache.GetSnapshot())
+		}
+
 	case DashboardOpenMissionMsg:
 		// Open Mission view for selected spacecraft from Dashboard
 		if msg.SpacecraftID > 0 {
@@ -320,6 +653,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+	case trailFetchMsg, planetSystemMsg:
+		// Kept even if the user has left the Orbit view meanwhile
+		m.solarSystem, _ = m.solarSystem.Update(msg)
+
 	case ErrorMsg:
 		// Could display error in status bar
 		m.dashboard = m.dashboard.SetError(msg.Error)
@@ -328,6 +665,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds = append(cmds, m.updateActiveView(msg))
 	}
 
+	m = m.announceFocus()
+	var titleCmd tea.Cmd
+	m, titleCmd = m.updateTitle()
+	cmds = append(cmds, titleCmd)
 	return m, tea.Batch(cmds...)
 }
 
@@ -342,6 +683,8 @@ func (m *Model) updateActiveView(msg tea.Msg) tea.Cmd {
 		m.skyView, cmd = m.skyView.Update(msg)
 	case ViewSolarSystem:
 		m.solarSystem, cmd = m.solarSystem.Update(msg)
+	case ViewEvents:
+		m.events, cmd = m.events.Update(msg)
 	}
 	return cmd
 }
@@ -352,6 +695,19 @@ func (m Model) View() string {
 		return "Initializing..."
 	}
 
+	if m.palette {
+		return m.renderFrame(m.renderPalette())
+	}
+	if m.searching {
+		return m.renderFrame(m.renderSearch())
+	}
+	if m.browsing {
+		return m.renderFrame(m.renderBookmarks())
+	}
+	if m.about {
+		return m.renderFrame(m.renderAbout())
+	}
+
 	var content string
 	switch m.viewMode {
 	case ViewDashboard:
@@ -362,6 +718,8 @@ func (m Model) View() string {
 		content = m.skyView.View()
 	case ViewSolarSystem:
 		content = m.solarSystem.View()
+	case ViewEvents:
+		content = m.events.View()
 	}
 
 	return m.renderFrame(content)
@@ -375,6 +733,9 @@ func (m Model) renderFrame(content string) string {
 }
 
 func (m Model) renderHeader() string {
+	if m.profile == ProfileSmall {
+		return m.renderStatusLine()
+	}
 	return m.renderLogo() + m.renderStatusLine()
 }
 
@@ -490,10 +851,23 @@ func (m Model) renderStatusLine() string {
 }
 
 func (m Model) renderTabs() string {
-	tabs := []string{"[1] Dashboard", "[2] Mission", "[3] Sky", "[4] Orbit"}
+	tabs := []string{"[1] Dashboard", "[2] Mission", "[3] Sky", "[4] Orbit", "[5] Events"}
 	activeStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#9D4EDD")).Bold(true)
 	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("60"))
 
+	if m.profile == ProfileSmall {
+		tabs = []string{"1 Dash", "2 Msn", "3 Sky", "4 Orb", "5 Evt"}
+		var parts []string
+		for i, tab := range tabs {
+			if ViewMode(i) == m.viewMode {
+				parts = append(parts, activeStyle.Render(tab))
+			} else {
+				parts = append(parts, dimStyle.Render(tab))
+			}
+		}
+		return strings.Join(parts, " ")
+	}
+
 	var parts []string
 	for i, tab := range tabs {
 		if ViewMode(i) == m.viewMode {
@@ -511,12 +885,15 @@ func (m Model) renderFooter() string {
 	accentStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#7B2CBF"))
 
 	// Animated spinner frames
-	spinnerFrames := []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
-	spinner := spinnerFrames[m.animTick%len(spinnerFrames)]
+	frames := spinnerFrames(m.charset)
+	spinner := frames[m.animTick%len(frames)]
 
 	var status string
 	if m.snapshot.LastError != nil {
-		status = errorStyle.Render("ERROR: " + m.snapshot.LastError.Error())
+		status = errorStyle.Render("ERROR: " + errorText(m.lang, m.snapshot.LastError))
+	} else if m.replay > 0 && m.snapshot.Data != nil {
+		status = accentStyle.Render(spinner) + dimStyle.Render(fmt.Sprintf(" replay %s (%g×)",
+			m.snapshot.Data.Timestamp.UTC().Format("Jan 02 15:04:05 UTC"), m.replay))
 	} else if !m.snapshot.LastFetch.IsZero() {
 		// Show countdown to next refresh with spinner
 		countdown := time.Until(m.snapshot.NextRefresh).Round(time.Second)
@@ -531,29 +908,88 @@ func (m Model) renderFooter() string {
 		status = accentStyle.Render(spinner) + " " + m.renderShimmerText("Waiting for data...")
 	}
 
+	if m.profile == ProfileSmall {
+		return m.renderCompactFooter(status)
+	}
+
 	// View-specific help hints
 	var help string
-	switch m.viewMode {
-	case ViewMissionDetail:
-		help = dimStyle.Render("←/→: spacecraft | h: passes | ↑↓: scroll")
-	case ViewSky:
-		help = dimStyle.Render("j/k: focus | l: labels | c: complex | p: path | v: visibility")
-	case ViewSolarSystem:
-		help = dimStyle.Render("j/k: focus | n/N: spacecraft | +/-: zoom | arrows: pan | f: find | l: labels | z: mode | t: stars")
+	switch {
+	case m.palette:
+		help = dimStyle.Render("type: filter | ↑↓: select | enter: run | esc: close")
+	case m.searching:
+		help = dimStyle.Render("type: code or mission | ↑↓: select | enter: jump | esc: close")
+	case m.browsing:
+		help = dimStyle.Render("↑↓: select | enter: revisit in replay | esc: close")
+	case m.about:
+		help = dimStyle.Render(tr(m.lang, "hint"))
+	case m.viewMode == ViewMissionDetail:
+		help = dimStyle.Render("←/→: spacecraft | p: pin | P: next pinned | h: passes | a: alt-az | n: note | ↑↓: scroll | i: about")
+	case m.viewMode == ViewSky:
+		help = dimStyle.Render("j/k: focus | l: labels | c: complex | p: path | v: visibility | i: about")
+	case m.viewMode == ViewEvents:
+		help = dimStyle.Render("↑↓/pgup/pgdn: scroll | t: type | f: spacecraft | esc: clear filters | i: about")
+	case m.viewMode == ViewSolarSystem:
+		help = dimStyle.Render("j/k: focus | n/N: spacecraft | +/-: zoom | arrows: pan | f: find | l: labels | z: mode | t: stars | a: apparent/geometric | v: split | x: 3D | ,/.: yaw | </>: tilt | p/R: refresh | i: about")
+	case m.viewMode == ViewDashboard && m.dashboard.Comparing():
+		help = dimStyle.Render("p: re-pin | c/esc: close | x: data quality | t: timeline | tab: switch view | i: about")
+	case m.viewMode == ViewDashboard && m.dashboard.AntennaOpen():
+		help = dimStyle.Render("←/→: dish | ↑↓: spacecraft | a/esc: close | x: data quality | t: timeline | tab: switch view | i: about")
 	default:
-		help = dimStyle.Render("↑↓: navigate | tab: switch view")
+		help = dimStyle.Render("↑↓: navigate | a: antenna | x: data quality | t: timeline | p/c: pin/compare | w: watchlist | b/B: bookmark | /: find | ctrl+p: commands | tab: switch view | i: about")
 	}
 
-	footer := "  " + status + "  " + dimStyle.Render("|") + "  " + help
+	footer := "  " + status + "  " + dimStyle.Render("|") + "  "
+	if ages := m.renderDataAges(time.Now()); ages != "" {
+		footer += ages + "  " + dimStyle.Render("|") + "  "
+	}
+	footer += help
+	if sandbox.Enabled() {
+		footer += "  " + dimStyle.Render("| read-only")
+	}
+	if m.eco {
+		footer += "  " + dimStyle.Render("| eco")
+	}
+	if m.following {
+		footer += "  " + dimStyle.Render("| following "+strings.Join(m.watchlist, ","))
+	}
+	if m.horizonsUsage != nil {
+		if w := m.horizonsUsage.Stats(time.Now()).Warning(); w != "" {
+			footer += "  " + dimStyle.Render("|") + " " + lipgloss.NewStyle().Foreground(lipgloss.Color("208")).Render(w)
+		}
+	}
 
 	// Show update status message if present
-	if m.statusMsg != "" {
+	if m.pendingBookmark != nil {
+		footer += "\n  " + m.renderBookmarkPrompt()
+	} else if m.statusMsg != "" {
 		footer += "\n  " + dimStyle.Render(m.statusMsg)
 	}
 
 	return footer
 }
 
+// renderCompactFooter renders a single footer line for the small profile:
+// status plus short mode flags, with no key help.
+func (m Model) renderCompactFooter(status string) string {
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("60"))
+
+	if m.pendingBookmark != nil {
+		return dimStyle.Render("note> ") + string(m.bookmarkDraft) + "█"
+	}
+	if m.snapshot.LastError != nil {
+		status = errorStyle.Render("ERR " + string(dsn.ErrorCodeOf(m.snapshot.LastError)) + " " + truncate(m.snapshot.LastError.Error(), 30))
+	}
+	footer := status
+	if sandbox.Enabled() {
+		footer += dimStyle.Render(" RO")
+	}
+	if m.eco {
+		footer += dimStyle.Render(" eco")
+	}
+	return footer
+}
+
 // GetSelectedSpacecraft returns the currently selected spacecraft ID (for mission detail).
 func (m Model) GetSelectedSpacecraft() int {
 	return m.missionDetail.selectedID
@@ -660,6 +1096,10 @@ func (m *Model) refreshAllPassPlans() []tea.Cmd {
 		if isStationNotSpacecraft(sc.Name) {
 			continue
 		}
+		// Eco mode skips background prefetch for unfocused spacecraft
+		if m.eco && sc.ID != focusedID {
+			continue
+		}
 		if m.state.NeedsPassPlanRefresh(sc.ID) {
 			// Add to queue if not already there
 			if !m.isInQueue(sc.ID) {
@@ -720,7 +1160,7 @@ func (m *Model) processPassPlanQueue() tea.Cmd {
 	}
 
 	m.passPlanFetching = true
-	return m.refreshPassPlanFor(spacecraftID)
+	return m.deliver(fmt.Sprintf("pass:%d", spacecraftID), m.refreshPassPlanFor(spacecraftID))
 }
 
 // scheduleNextPassPlanFetch schedules the next queue item after a delay.
@@ -761,7 +1201,7 @@ func (m *Model) refreshPassPlanFor(spacecraftID int) tea.Cmd {
 			return passPlanUpdatedMsg{
 				spacecraftID: spacecraftID,
 				plan:         nil,
-				err:          fmt.Errorf("unknown spacecraft: %s", scName),
+				err:          fmt.Errorf("%w: spacecraft %s", dsn.ErrUnknownTarget, scName),
 			}
 		}
 	}
@@ -773,14 +1213,14 @@ func (m *Model) refreshPassPlanFor(spacecraftID int) tea.Cmd {
 			return passPlanUpdatedMsg{
 				spacecraftID: spacecraftID,
 				plan:         nil,
-				err:          fmt.Errorf("unknown spacecraft: %s", scName),
+				err:          fmt.Errorf("%w: spacecraft %s", dsn.ErrUnknownTarget, scName),
 			}
 		}
 	}
 	scCode := targetInfo.Code
 
-	// Get Horizons provider for RA/Dec query
-	hp, ok := m.ephemProvider.(*ephem.HorizonsProvider)
+	// Get a provider for the RA/Dec query
+	hp, ok := m.ephemProvider.(ephem.RADecProvider)
 	if !ok {
 		return func() tea.Msg {
 			return passPlanUpdatedMsg{
@@ -791,32 +1231,48 @@ func (m *Model) refreshPassPlanFor(spacecraftID int) tea.Cmd {
 		}
 	}
 
+	// Previous plan and samples, reused when the window has only slid
+	var prevPlan *dsn.PassPlan
+	var prevSamples []astro.RADecAtTime
+	if cached := m.state.GetCachedPassPlan(spacecraftID); cached != nil {
+		prevPlan, prevSamples = cached.Plan, cached.Samples
+	}
+
 	// Compute pass plan async
 	return func() tea.Msg {
 		now := time.Now()
-		start := now
-		end := now.Add(24 * time.Hour)
-		step := 5 * time.Minute
-
-		samples, err := hp.GetRADecPath(naifID, start, end, step)
-		if err != nil {
-			return passPlanUpdatedMsg{spacecraftID: spacecraftID, plan: nil, err: err}
+		window := m.state.PassWindow()
+		end := now.Add(window.Span)
+		step := window.Step
+
+		// Keep overlapping samples and fetch only the new tail
+		samples, from := dsn.ReusableSamples(prevSamples, now, step)
+		if len(samples) == 0 {
+			prevPlan = nil
+		}
+		if end.Sub(from) >= step {
+			tail, err := hp.GetRADecPath(naifID, from, end, step)
+			if err != nil {
+				return passPlanUpdatedMsg{spacecraftID: spacecraftID, plan: nil, err: err}
+			}
+			samples = append(samples[:len(samples):len(samples)], tail...)
 		}
 
-		plan := dsn.ComputePassPlan(scCode, samples, now)
-		return passPlanUpdatedMsg{spacecraftID: spacecraftID, plan: plan, err: nil}
+		plan := dsn.ExtendPassPlan(scCode, m.state.PassElevations(), prevPlan, samples, now)
+		return passPlanUpdatedMsg{spacecraftID: spacecraftID, plan: plan, samples: samples, err: nil}
 	}
 }
 
-// getTargetComplexForElevTrace determines which DSN complex to use for elevation trace.
-// Priority: 1) Active link complex, 2) NOW pass complex, 3) NEXT pass complex.
-// Returns empty string if no suitable complex found.
-func (m *Model) getTargetComplexForElevTrace(spacecraftID int) dsn.Complex {
+// getTargetComplexForElevTrace determines which DSN complex (and antenna, when
+// linked) to use for elevation trace.
+// Priority: 1) Active link antenna, 2) NOW pass complex, 3) NEXT pass complex.
+// Returns empty strings if no suitable complex found.
+func (m *Model) getTargetComplexForElevTrace(spacecraftID int) (dsn.Complex, string) {
 	// First, check for active link
 	if m.snapshot.Data != nil {
 		for _, link := range m.snapshot.Data.Links {
 			if link.SpacecraftID == spacecraftID && link.Complex != "" {
-				return link.Complex
+				return link.Complex, link.AntennaID
 			}
 		}
 	}
@@ -829,7 +1285,7 @@ func (m *Model) getTargetComplexForElevTrace(spacecraftID int) dsn.Complex {
 			pass := &m.snapshot.PassPlan.Passes[i]
 			// NOW pass: current time is within the pass window
 			if now.After(pass.Start) && now.Before(pass.End) {
-				return pass.Complex
+				return pass.Complex, ""
 			}
 			// Track the first future pass as NEXT candidate
 			if pass.Start.After(now) && nextPass == nil {
@@ -838,11 +1294,11 @@ func (m *Model) getTargetComplexForElevTrace(spacecraftID int) dsn.Complex {
 		}
 		// Return NEXT pass complex if we found one
 		if nextPass != nil {
-			return nextPass.Complex
+			return nextPass.Complex, ""
 		}
 	}
 
-	return ""
+	return "", ""
 }
 
 // maybeRefreshElevTrace checks if elevation trace needs refresh and triggers it.
@@ -851,21 +1307,22 @@ func (m *Model) maybeRefreshElevTrace(spacecraftID int) tea.Cmd {
 		return nil
 	}
 
-	targetComplex := m.getTargetComplexForElevTrace(spacecraftID)
+	targetComplex, targetAntenna := m.getTargetComplexForElevTrace(spacecraftID)
 	if targetComplex == "" {
 		// No complex available for elevation trace
 		return nil
 	}
 
-	if m.state.NeedsElevationTraceRefresh(spacecraftID, targetComplex) {
-		return m.refreshElevTraceFor(spacecraftID, targetComplex)
+	if m.state.NeedsElevationTraceRefresh(spacecraftID, targetComplex, targetAntenna) {
+		return m.deliver(fmt.Sprintf("elev:%d", spacecraftID), m.refreshElevTraceFor(spacecraftID, targetComplex, targetAntenna))
 	}
 
 	return nil
 }
 
-// refreshElevTraceFor starts async elevation trace computation for a spacecraft.
-func (m *Model) refreshElevTraceFor(spacecraftID int, complex dsn.Complex) tea.Cmd {
+// refreshElevTraceFor starts async elevation trace computation for a spacecraft
+// as seen from antenna, or from the complex reference point if antenna is empty.
+func (m *Model) refreshElevTraceFor(spacecraftID int, complex dsn.Complex, antenna string) tea.Cmd {
 	// Find spacecraft name
 	var scName string
 	for _, sc := range m.snapshot.Spacecraft {
@@ -891,7 +1348,7 @@ func (m *Model) refreshElevTraceFor(spacecraftID int, complex dsn.Complex) tea.C
 				spacecraftID: spacecraftID,
 				trace:        nil,
 				complex:      complex,
-				err:          fmt.Errorf("unknown spacecraft: %s", scName),
+				err:          fmt.Errorf("%w: spacecraft %s", dsn.ErrUnknownTarget, scName),
 			}
 		}
 	}
@@ -904,14 +1361,14 @@ func (m *Model) refreshElevTraceFor(spacecraftID int, complex dsn.Complex) tea.C
 				spacecraftID: spacecraftID,
 				trace:        nil,
 				complex:      complex,
-				err:          fmt.Errorf("unknown spacecraft: %s", scName),
+				err:          fmt.Errorf("%w: spacecraft %s", dsn.ErrUnknownTarget, scName),
 			}
 		}
 	}
 	scCode := targetInfo.Code
 
-	// Get Horizons provider for RA/Dec query
-	hp, ok := m.ephemProvider.(*ephem.HorizonsProvider)
+	// Get a provider for the RA/Dec query
+	hp, ok := m.ephemProvider.(ephem.RADecProvider)
 	if !ok {
 		return func() tea.Msg {
 			return elevTraceUpdatedMsg{
@@ -924,19 +1381,34 @@ func (m *Model) refreshElevTraceFor(spacecraftID int, complex dsn.Complex) tea.C
 	}
 
 	// Compute elevation trace async
+	stateMgr := m.state
 	return func() tea.Msg {
 		now := time.Now()
-		// Request RA/Dec for ±2h window
+		// RA/Dec needed for the ±2h window
 		start := now.Add(-dsn.ElevationTraceWindow)
 		end := now.Add(dsn.ElevationTraceWindow)
 		step := dsn.ElevationTraceSampleInterval
 
-		samples, err := hp.GetRADecPath(naifID, start, end, step)
-		if err != nil {
-			return elevTraceUpdatedMsg{spacecraftID: spacecraftID, trace: nil, complex: complex, err: err}
+		// Reuse the cached RA/Dec path when it still covers the window;
+		// only fetch from Horizons (with slack for later refreshes) when not
+		samples, hash, ok := stateMgr.ElevationGeometry(spacecraftID, start, end)
+		if !ok {
+			var err error
+			samples, err = hp.GetRADecPath(naifID, start, end.Add(state.ElevationGeometrySlack), step)
+			if err != nil {
+				return elevTraceUpdatedMsg{spacecraftID: spacecraftID, trace: nil, complex: complex, err: err}
+			}
+			hash = stateMgr.StoreElevationGeometry(spacecraftID, samples)
+		}
+
+		// Same spacecraft, antenna, and geometry: the trace is unchanged
+		key := state.ElevTraceKey{SpacecraftID: spacecraftID, Complex: complex, Antenna: antenna, Geometry: hash}
+		if trace := stateMgr.ElevationTraceFor(key, now); trace != nil {
+			return elevTraceUpdatedMsg{spacecraftID: spacecraftID, trace: trace, complex: complex, err: nil}
 		}
 
-		trace := dsn.ComputeElevationTrace(scCode, complex, samples, now)
+		trace := dsn.ComputeAntennaElevationTrace(scCode, complex, antenna, samples, now)
+		stateMgr.StoreElevationTraceFor(key, trace)
 		return elevTraceUpdatedMsg{spacecraftID: spacecraftID, trace: trace, complex: complex, err: nil}
 	}
 }